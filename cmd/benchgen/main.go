@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// benchgen writes the synthetic configurations used by the benchmarks as
+// fixtures: a list of Ingress objects that can be created in a cluster with
+// kubectl, or the configuration used to render the NGINX template.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/bench"
+)
+
+func main() {
+	opts := bench.Options{}
+
+	flag.IntVar(&opts.Hosts, "hosts", 100, "Number of hosts, each one defined in its own Ingress")
+	flag.IntVar(&opts.Paths, "paths", 10, "Number of paths of each host")
+	flag.StringVar(&opts.Mix, "mix", bench.CommonMix, "Mix of annotations of the Ingress objects: none, common, heavy or rotate")
+	flag.StringVar(&opts.Namespace, "namespace", "default", "Namespace of the Ingress objects")
+	format := flag.String("format", "ingresses", "Format of the fixture: ingresses, a List of Ingress objects, or template, the configuration used to render nginx.conf")
+	output := flag.String("output", "", "File where the fixture is written. Defaults to the standard output")
+	flag.Parse()
+
+	data, err := generate(opts, *format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error generating fixture: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		fmt.Println(string(data))
+		return
+	}
+
+	if err := os.WriteFile(*output, data, 0o644); err != nil { //nolint:gosec // fixtures are not sensitive
+		fmt.Fprintf(os.Stderr, "error writing fixture: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// ingressList is a List of Ingress objects accepted by kubectl
+type ingressList struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Items      []*networking.Ingress `json:"items"`
+}
+
+func generate(opts bench.Options, format string) ([]byte, error) {
+	switch format {
+	case "ingresses":
+		ings, err := bench.Ingresses(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.MarshalIndent(&ingressList{
+			APIVersion: "v1",
+			Kind:       "List",
+			Items:      ings,
+		}, "", "  ")
+	case "template":
+		tc, err := bench.TemplateConfig(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.MarshalIndent(tc, "", "  ")
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
@@ -20,9 +20,19 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"k8s.io/ingress-nginx/internal/ingress/confighistory"
+	"k8s.io/ingress-nginx/internal/ingress/effectiveconfig"
+	"k8s.io/ingress-nginx/internal/ingress/loglevel"
+	"k8s.io/ingress-nginx/internal/ingress/supportbundle"
 	"k8s.io/ingress-nginx/internal/nginx"
 )
 
@@ -99,15 +109,67 @@ func main() {
 	}
 	rootCmd.AddCommand(generalCmd)
 
+	var runtimeDir string
+	var healthzPort, generation int
+	var diff, history bool
 	confCmd := &cobra.Command{
 		Use:   "conf",
-		Short: "Dump the contents of /etc/nginx/nginx.conf",
+		Short: "Dump the contents of nginx.conf, or of a previous generation from the configuration history",
 		Run: func(_ *cobra.Command, _ []string) {
-			readNginxConf()
+			if generation == 0 && !diff && !history {
+				readNginxConf(runtimeDir)
+				return
+			}
+			configHistory(healthzPort, generation, diff, history)
 		},
 	}
+	confCmd.Flags().StringVar(&runtimeDir, "runtime-dir", "", "The --runtime-dir of the controller")
+	confCmd.Flags().IntVar(&healthzPort, "healthz-port", 10254, `Port of the healthz endpoint of the controller.`)
+	confCmd.Flags().IntVar(&generation, "generation", 0, `Generation of nginx.conf in the configuration history, negative to count from the newest one, -1 being the newest.`)
+	confCmd.Flags().BoolVar(&diff, "diff", false, `Output the changes made by the generation to the previous one, by default the newest generation.`)
+	confCmd.Flags().BoolVar(&history, "history", false, `Output the generations kept in the configuration history.`)
 	rootCmd.AddCommand(confCmd)
 
+	supportBundleCmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Output the support bundle of the controller as a JSON document, without private keys and credentials",
+		Run: func(_ *cobra.Command, _ []string) {
+			supportBundle(healthzPort)
+		},
+	}
+	supportBundleCmd.Flags().IntVar(&healthzPort, "healthz-port", 10254, `Port of the healthz endpoint of the controller.`)
+	rootCmd.AddCommand(supportBundleCmd)
+
+	var namespace string
+	effectiveConfigCmd := &cobra.Command{
+		Use:   "effective-config INGRESS",
+		Short: "Output the settings of an Ingress merged with the defaults and its locations in the running configuration",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			effectiveConfig(healthzPort, namespace, args[0])
+		},
+	}
+	effectiveConfigCmd.Flags().IntVar(&healthzPort, "healthz-port", 10254, `Port of the healthz endpoint of the controller.`)
+	effectiveConfigCmd.Flags().StringVar(&namespace, "namespace", "default", `Namespace of the Ingress.`)
+	rootCmd.AddCommand(effectiveConfigCmd)
+
+	var verbosity, errorLogLevel string
+	var duration time.Duration
+	var reset bool
+	logLevelCmd := &cobra.Command{
+		Use:   "log-level",
+		Short: "Show or change the verbosity of the controller and the level of the error log of NGINX, reverted after a duration",
+		Run: func(_ *cobra.Command, _ []string) {
+			logLevel(healthzPort, verbosity, errorLogLevel, duration, reset)
+		},
+	}
+	logLevelCmd.Flags().IntVar(&healthzPort, "healthz-port", 10254, `Port of the healthz endpoint of the controller.`)
+	logLevelCmd.Flags().StringVar(&verbosity, "v", "", `Verbosity of the logs of the controller.`)
+	logLevelCmd.Flags().StringVar(&errorLogLevel, "error-log-level", "", `Level of the error log of NGINX.`)
+	logLevelCmd.Flags().DurationVar(&duration, "duration", loglevel.DefaultDuration, `Time until the log levels are reverted.`)
+	logLevelCmd.Flags().BoolVar(&reset, "reset", false, `Revert the log levels changed.`)
+	rootCmd.AddCommand(logLevelCmd)
+
 	rootCmd.PersistentFlags().IntVar(&nginx.StatusPort, "status-port", 10246, `Port to use for the lua HTTP endpoint configuration.`)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -240,7 +302,11 @@ func general() {
 	fmt.Println(prettyBuffer.String())
 }
 
-func readNginxConf() {
+func readNginxConf(runtimeDir string) {
+	if runtimeDir != "" {
+		nginx.SetRuntimeDirectory(runtimeDir)
+	}
+
 	conf, err := nginx.ReadNginxConf()
 	if err != nil {
 		fmt.Println(err)
@@ -249,3 +315,139 @@ func readNginxConf() {
 
 	fmt.Println(conf)
 }
+
+func supportBundle(healthzPort int) {
+	resp, err := adminRequest(http.MethodGet, fmt.Sprintf("http://127.0.0.1:%v%v", healthzPort, supportbundle.Path))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		fmt.Printf("Controller returned code %v\n", resp.StatusCode)
+		return
+	}
+
+	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func effectiveConfig(healthzPort int, namespace, name string) {
+	u := url.URL{
+		Scheme:   "http",
+		Host:     fmt.Sprintf("127.0.0.1:%v", healthzPort),
+		Path:     effectiveconfig.Path,
+		RawQuery: url.Values{"namespace": []string{namespace}, "name": []string{name}}.Encode(),
+	}
+
+	resp, err := adminRequest(http.MethodGet, u.String())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		fmt.Printf("Controller returned code %v: %s\n", resp.StatusCode, strings.TrimSpace(string(body)))
+		return
+	}
+
+	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func configHistory(healthzPort, generation int, diff, history bool) {
+	u := url.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("127.0.0.1:%v", healthzPort),
+		Path:   confighistory.Path,
+	}
+
+	if !history {
+		if generation == 0 {
+			generation = -1
+		}
+		u.RawQuery = url.Values{
+			"generation": []string{strconv.Itoa(generation)},
+			"diff":       []string{strconv.FormatBool(diff)},
+		}.Encode()
+	}
+
+	resp, err := adminRequest(http.MethodGet, u.String())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		fmt.Printf("Controller returned code %v: %s\n", resp.StatusCode, strings.TrimSpace(string(body)))
+		return
+	}
+
+	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func logLevel(healthzPort int, verbosity, errorLogLevel string, duration time.Duration, reset bool) {
+	u := url.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("127.0.0.1:%v", healthzPort),
+		Path:   loglevel.Path,
+	}
+
+	method := http.MethodGet
+	switch {
+	case reset:
+		method = http.MethodDelete
+	case verbosity != "" || errorLogLevel != "":
+		method = http.MethodPut
+		u.RawQuery = url.Values{
+			"v":               []string{verbosity},
+			"error-log-level": []string{errorLogLevel},
+			"duration":        []string{duration.String()},
+		}.Encode()
+	}
+
+	resp, err := adminRequest(method, u.String())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		fmt.Printf("Controller returned code %v: %s\n", resp.StatusCode, strings.TrimSpace(string(body)))
+		return
+	}
+
+	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// serviceAccountTokenFile is the token of the service account of the pod
+const serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// adminRequest sends a request to an admin endpoint of the controller, with
+// the token of the pod used when --admin-auth is token
+func adminRequest(method, u string) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if token, err := os.ReadFile(serviceAccountTokenFile); err == nil {
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	client := http.Client{Timeout: 30 * time.Second}
+	return client.Do(req)
+}
@@ -19,10 +19,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -31,17 +33,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	discovery "k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	certutil "k8s.io/client-go/util/cert"
 	"k8s.io/klog/v2"
 
+	"k8s.io/ingress-nginx/internal/ingress/adminauth"
+	"k8s.io/ingress-nginx/internal/ingress/autotune"
+	"k8s.io/ingress-nginx/internal/ingress/confighistory"
 	"k8s.io/ingress-nginx/internal/ingress/controller"
+	"k8s.io/ingress-nginx/internal/ingress/effectiveconfig"
+	"k8s.io/ingress-nginx/internal/ingress/ldapauth"
+	"k8s.io/ingress-nginx/internal/ingress/loglevel"
 	"k8s.io/ingress-nginx/internal/ingress/metric"
+	"k8s.io/ingress-nginx/internal/ingress/supportbundle"
 	"k8s.io/ingress-nginx/internal/k8s"
 	"k8s.io/ingress-nginx/internal/net/ssl"
 	"k8s.io/ingress-nginx/internal/nginx"
+	"k8s.io/ingress-nginx/internal/redact"
 	"k8s.io/ingress-nginx/pkg/util/file"
 	"k8s.io/ingress-nginx/version"
 
@@ -52,6 +63,7 @@ import (
 
 func main() {
 	klog.InitFlags(nil)
+	redact.InstallLogFilter()
 
 	fmt.Println(version.String())
 
@@ -64,12 +76,19 @@ func main() {
 		klog.Fatal(err)
 	}
 
+	if conf.ConfigFile != "" {
+		_, err = ingressflags.WatchConfigFile(conf.ConfigFile, conf.CommandLineFlags)
+		if err != nil {
+			klog.Fatalf("Error watching the configuration file: %v", err)
+		}
+	}
+
 	err = file.CreateRequiredDirectories()
 	if err != nil {
 		klog.Fatal(err)
 	}
 
-	kubeClient, err := createApiserverClient(conf.APIServerHost, conf.RootCAFile, conf.KubeConfigFile)
+	kubeClient, err := createApiserverClient(conf.APIServerHost, conf.RootCAFile, conf.KubeConfigFile, conf.KubeAPIQPS, conf.KubeAPIBurst)
 	if err != nil {
 		handleFatalInitError(err)
 	}
@@ -115,6 +134,38 @@ func main() {
 	}
 	conf.Client = kubeClient
 
+	if conf.UpdateStatus {
+		// the status updates have their own rate limits, so they don't throttle the informers
+		cfg, err := createApiserverConfig(conf.APIServerHost, conf.RootCAFile, conf.KubeConfigFile, conf.StatusKubeAPIQPS, conf.StatusKubeAPIBurst)
+		if err != nil {
+			handleFatalInitError(err)
+		}
+
+		conf.StatusClient, err = kubernetes.NewForConfig(cfg)
+		if err != nil {
+			handleFatalInitError(err)
+		}
+	}
+
+	if conf.WatchReferenceGrants || conf.WatchHTTPTransforms {
+		cfg, err := createApiserverConfig(conf.APIServerHost, conf.RootCAFile, conf.KubeConfigFile, conf.KubeAPIQPS, conf.KubeAPIBurst)
+		if err != nil {
+			handleFatalInitError(err)
+		}
+
+		dynamicClient, err := dynamic.NewForConfig(cfg)
+		if err != nil {
+			handleFatalInitError(err)
+		}
+
+		if conf.WatchReferenceGrants {
+			conf.DynamicClient = dynamicClient
+		}
+		if conf.WatchHTTPTransforms {
+			conf.HTTPTransformClient = dynamicClient
+		}
+	}
+
 	err = k8s.GetIngressPod(kubeClient)
 	if err != nil {
 		klog.Fatalf("Unexpected error obtaining ingress-nginx pod: %v", err)
@@ -130,7 +181,7 @@ func main() {
 
 	mc := metric.NewDummyCollector()
 	if conf.EnableMetrics {
-		mc, err = metric.NewCollector(conf.MetricsPerHost, conf.MetricsPerUndefinedHost, conf.ReportStatusClasses, reg, conf.IngressClassConfiguration.Controller, *conf.MetricsBuckets, conf.MetricsBucketFactor, conf.MetricsMaxBuckets, conf.ExcludeSocketMetrics)
+		mc, err = metric.NewCollector(conf.MetricsPerHost, conf.MetricsPerUndefinedHost, conf.ReportStatusClasses, reg, conf.IngressClassConfiguration.Controller, *conf.MetricsBuckets, conf.MetricsBucketFactor, conf.MetricsMaxBuckets, conf.ExcludeSocketMetrics, conf.ErrorLogMetrics)
 		if err != nil {
 			klog.Fatalf("Error creating prometheus collector:  %v", err)
 		}
@@ -143,11 +194,43 @@ func main() {
 		go metrics.RegisterProfiler(nginx.ProfilerAddress, nginx.ProfilerPort)
 	}
 
+	// NGINX validates the LDAP credentials using the health check server
+	ldapHost := "127.0.0.1"
+	if conf.HealthCheckHost != "" {
+		ldapHost = conf.HealthCheckHost
+	}
+	ldapauth.ListenAddress = net.JoinHostPort(ldapHost, strconv.Itoa(conf.ListenPorts.Health))
+
 	ngx := controller.NewNGINXController(conf, mc)
 
 	mux := http.NewServeMux()
 	metrics.RegisterHealthz(nginx.HealthPath, mux, ngx)
 	metrics.RegisterMetrics(reg, mux)
+	metrics.RegisterSaturation(mux, mc)
+	ldapauth.Register(mux, ngx)
+
+	adminMux := http.NewServeMux()
+	autotune.Register(adminMux, ngx.AutoTuner())
+	supportbundle.Register(adminMux, ngx)
+	effectiveconfig.Register(adminMux, ngx)
+	confighistory.Register(adminMux, ngx.ConfigHistory())
+	adminPaths := []string{autotune.Path, supportbundle.Path, effectiveconfig.Path, confighistory.Path}
+	// changing the log levels requires an authenticated user
+	if conf.AdminAuth != adminauth.ModeNone {
+		loglevel.Register(adminMux, ngx.LogLevelChanger())
+		adminPaths = append(adminPaths, loglevel.Path)
+	}
+
+	adminServer, err := registerAdmin(mux, adminMux, adminPaths, conf, kubeClient)
+	if err != nil {
+		klog.Fatalf("Error creating the admin server: %v", err)
+	}
+	if adminServer != nil {
+		go func() {
+			klog.InfoS("Starting the admin server", "address", adminServer.Addr)
+			klog.Fatal(adminServer.ListenAndServeTLS("", ""))
+		}()
+	}
 
 	_, errExists := os.Stat("/chroot")
 	if errExists == nil {
@@ -158,9 +241,31 @@ func main() {
 	go metrics.StartHTTPServer(conf.HealthCheckHost, conf.ListenPorts.Health, mux)
 	go ngx.Start()
 
-	process.HandleSigterm(ngx, conf.PostShutdownGracePeriod, func(code int) {
+	process.HandleShutdown(ngx, conf.PostShutdownGracePeriod, func(code int) {
 		os.Exit(code)
-	})
+	}, ngx.DrainRequests())
+}
+
+// registerAdmin exposes the admin endpoints of adminMux as defined by --admin-auth:
+// in the healthz mux with token or none, or in the returned server with mtls
+func registerAdmin(mux, adminMux *http.ServeMux, paths []string, conf *controller.Configuration, kubeClient kubernetes.Interface) (*http.Server, error) {
+	var handler http.Handler
+	switch conf.AdminAuth {
+	case adminauth.ModeMTLS:
+		return adminauth.NewMTLSServer(net.JoinHostPort(conf.HealthCheckHost, strconv.Itoa(conf.AdminPort)),
+			adminMux, conf.AdminTLSCertFile, conf.AdminTLSKeyFile, conf.AdminClientCAFile)
+	case adminauth.ModeNone:
+		klog.Warning("The admin endpoints are not authenticated, they only accept requests from the loopback interface")
+		handler = adminauth.LocalOnly(adminMux)
+	default:
+		handler = adminauth.NewTokenAuthorizer(kubeClient).Wrap(adminMux)
+	}
+
+	for _, path := range paths {
+		mux.Handle(path, handler)
+	}
+
+	return nil, nil
 }
 
 // createApiserverClient creates a new Kubernetes REST client. apiserverHost is
@@ -171,37 +276,13 @@ func main() {
 // If neither apiserverHost nor kubeConfig is passed in, we assume the
 // controller runs inside Kubernetes and fallback to the in-cluster config. If
 // the in-cluster config is missing or fails, we fallback to the default config.
-func createApiserverClient(apiserverHost, rootCAFile, kubeConfig string) (*kubernetes.Clientset, error) {
-	cfg, err := clientcmd.BuildConfigFromFlags(apiserverHost, kubeConfig)
+// qps and burst limit the requests of the client to the API server.
+func createApiserverClient(apiserverHost, rootCAFile, kubeConfig string, qps float32, burst int) (*kubernetes.Clientset, error) {
+	cfg, err := createApiserverConfig(apiserverHost, rootCAFile, kubeConfig, qps, burst)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: remove after k8s v1.22
-	cfg.WarningHandler = rest.NoWarnings{}
-
-	// Configure the User-Agent used for the HTTP requests made to the API server.
-	cfg.UserAgent = fmt.Sprintf(
-		"%s/%s (%s/%s) ingress-nginx/%s",
-		filepath.Base(os.Args[0]),
-		version.RELEASE,
-		runtime.GOOS,
-		runtime.GOARCH,
-		version.COMMIT,
-	)
-
-	if apiserverHost != "" && rootCAFile != "" {
-		tlsClientConfig := rest.TLSClientConfig{}
-
-		if _, err := certutil.NewPool(rootCAFile); err != nil {
-			klog.ErrorS(err, "Loading CA config", "file", rootCAFile)
-		} else {
-			tlsClientConfig.CAFile = rootCAFile
-		}
-
-		cfg.TLSClientConfig = tlsClientConfig
-	}
-
 	klog.InfoS("Creating API client", "host", cfg.Host)
 
 	client, err := kubernetes.NewForConfig(cfg)
@@ -257,6 +338,45 @@ func createApiserverClient(apiserverHost, rootCAFile, kubeConfig string) (*kuber
 	return client, nil
 }
 
+// createApiserverConfig creates the REST configuration used by the clients of
+// the Kubernetes API server. See createApiserverClient for the arguments.
+func createApiserverConfig(apiserverHost, rootCAFile, kubeConfig string, qps float32, burst int) (*rest.Config, error) {
+	cfg, err := clientcmd.BuildConfigFromFlags(apiserverHost, kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.QPS = qps
+	cfg.Burst = burst
+
+	// TODO: remove after k8s v1.22
+	cfg.WarningHandler = rest.NoWarnings{}
+
+	// Configure the User-Agent used for the HTTP requests made to the API server.
+	cfg.UserAgent = fmt.Sprintf(
+		"%s/%s (%s/%s) ingress-nginx/%s",
+		filepath.Base(os.Args[0]),
+		version.RELEASE,
+		runtime.GOOS,
+		runtime.GOARCH,
+		version.COMMIT,
+	)
+
+	if apiserverHost != "" && rootCAFile != "" {
+		tlsClientConfig := rest.TLSClientConfig{}
+
+		if _, err := certutil.NewPool(rootCAFile); err != nil {
+			klog.ErrorS(err, "Loading CA config", "file", rootCAFile)
+		} else {
+			tlsClientConfig.CAFile = rootCAFile
+		}
+
+		cfg.TLSClientConfig = tlsClientConfig
+	}
+
+	return cfg, nil
+}
+
 // Handler for fatal init errors. Prints a verbose error message and exits.
 func handleFatalInitError(err error) {
 	klog.Fatalf("Error while initiating a connection to the Kubernetes API server. "+
@@ -19,6 +19,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"syscall"
@@ -30,6 +32,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"k8s.io/ingress-nginx/internal/ingress/adminauth"
 	"k8s.io/ingress-nginx/internal/ingress/controller"
 	"k8s.io/ingress-nginx/internal/k8s"
 	"k8s.io/ingress-nginx/internal/nginx"
@@ -38,7 +41,7 @@ import (
 )
 
 func TestCreateApiserverClient(t *testing.T) {
-	_, err := createApiserverClient("", "", "")
+	_, err := createApiserverClient("", "", "", 5, 10)
 	if err == nil {
 		t.Fatal("Expected an error creating REST client without an API server URL or kubeconfig file.")
 	}
@@ -122,6 +125,65 @@ func TestHandleSigterm(t *testing.T) {
 	}
 }
 
+func TestRegisterAdmin(t *testing.T) {
+	ingressflags.ResetForTesting(func() { t.Fatal("bad parse") })
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "--http-port", "0", "--https-port", "0"}
+	_, conf, err := ingressflags.ParseFlags()
+	if err != nil {
+		t.Fatalf("unexpected error parsing the flags: %v", err)
+	}
+
+	paths := []string{"/support-bundle", "/autotune"}
+	adminMux := http.NewServeMux()
+	for _, path := range paths {
+		adminMux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	testCases := []struct {
+		name       string
+		mode       string
+		remoteAddr string
+		expected   int
+	}{
+		{"default", "", "192.0.2.1:1234", http.StatusUnauthorized},
+		{"default from the loopback interface", "", "127.0.0.1:1234", http.StatusUnauthorized},
+		{"none", adminauth.ModeNone, "192.0.2.1:1234", http.StatusNotFound},
+		{"none from the loopback interface", adminauth.ModeNone, "127.0.0.1:1234", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			adminConf := *conf
+			if tc.mode != "" {
+				adminConf.AdminAuth = tc.mode
+			}
+
+			mux := http.NewServeMux()
+			server, err := registerAdmin(mux, adminMux, paths, &adminConf, fake.NewSimpleClientset())
+			if err != nil || server != nil {
+				t.Fatalf("expected the admin endpoints in the healthz mux but got %v, %v", server, err)
+			}
+
+			for _, path := range paths {
+				req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+				req.RemoteAddr = tc.remoteAddr
+
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, req)
+				if w.Code != tc.expected {
+					t.Errorf("expected status %v for an anonymous request to %v but got %v", tc.expected, path, w.Code)
+				}
+			}
+		})
+	}
+}
+
 func createConfigMap(clientSet kubernetes.Interface, ns string, t *testing.T) string {
 	t.Helper()
 
@@ -18,6 +18,7 @@ package conf
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -42,11 +43,26 @@ func CreateCommand(flags *genericclioptions.ConfigFlags) *cobra.Command {
 				return err
 			}
 
-			util.PrintError(conf(flags, host, *pod, *deployment, *selector, *container))
+			runtimeDir, err := cmd.Flags().GetString("runtime-dir")
+			if err != nil {
+				return err
+			}
+
+			hist, err := historyOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			util.PrintError(conf(flags, host, runtimeDir, hist, *pod, *deployment, *selector, *container))
 			return nil
 		},
 	}
 	cmd.Flags().String("host", "", "Print just the server block with this hostname")
+	cmd.Flags().String("runtime-dir", "", "The --runtime-dir of the controller, when it is set")
+	cmd.Flags().Int("generation", 0, "Print this generation of the configuration history, negative to count from the newest one, -1 being the newest")
+	cmd.Flags().Bool("diff", false, "Print the changes made by the generation to the previous one, by default the newest generation")
+	cmd.Flags().Bool("history", false, "List the generations kept in the configuration history")
+	cmd.Flags().Int("healthz-port", 10254, "Port of the healthz endpoint of the ingress-nginx controller")
 	pod = util.AddPodFlag(cmd)
 	deployment = util.AddDeploymentFlag(cmd)
 	selector = util.AddSelectorFlag(cmd)
@@ -55,13 +71,66 @@ func CreateCommand(flags *genericclioptions.ConfigFlags) *cobra.Command {
 	return cmd
 }
 
-func conf(flags *genericclioptions.ConfigFlags, host, podName, deployment, selector, container string) error {
+// history selects the configuration history of the controller instead of the running nginx.conf
+type history struct {
+	generation  int
+	diff        bool
+	list        bool
+	healthzPort int
+}
+
+func historyOptions(cmd *cobra.Command) (history, error) {
+	var h history
+	var err error
+	if h.generation, err = cmd.Flags().GetInt("generation"); err != nil {
+		return h, err
+	}
+	if h.diff, err = cmd.Flags().GetBool("diff"); err != nil {
+		return h, err
+	}
+	if h.list, err = cmd.Flags().GetBool("history"); err != nil {
+		return h, err
+	}
+	h.healthzPort, err = cmd.Flags().GetInt("healthz-port")
+	return h, err
+}
+
+func (h history) enabled() bool {
+	return h.generation != 0 || h.diff || h.list
+}
+
+func (h history) args() []string {
+	args := []string{"--healthz-port", strconv.Itoa(h.healthzPort)}
+	switch {
+	case h.list:
+		args = append(args, "--history")
+	case h.diff:
+		args = append(args, "--diff", "--generation", strconv.Itoa(h.generation))
+	default:
+		args = append(args, "--generation", strconv.Itoa(h.generation))
+	}
+	return args
+}
+
+func conf(flags *genericclioptions.ConfigFlags, host, runtimeDir string, hist history, podName, deployment, selector, container string) error {
+	if host != "" && (hist.diff || hist.list) {
+		return fmt.Errorf("--host can not be used with --diff or --history")
+	}
+
 	pod, err := request.ChoosePod(flags, podName, deployment, selector)
 	if err != nil {
 		return err
 	}
 
-	nginxConf, err := kubectl.PodExecString(flags, &pod, container, []string{"/dbg", "conf"})
+	command := []string{"/dbg", "conf"}
+	if runtimeDir != "" {
+		command = append(command, "--runtime-dir", runtimeDir)
+	}
+	if hist.enabled() {
+		command = append(command, hist.args()...)
+	}
+
+	nginxConf, err := kubectl.PodExecString(flags, &pod, container, command)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effectiveconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"k8s.io/ingress-nginx/cmd/plugin/kubectl"
+	"k8s.io/ingress-nginx/cmd/plugin/request"
+	"k8s.io/ingress-nginx/cmd/plugin/util"
+)
+
+// CreateCommand creates and returns this cobra subcommand
+func CreateCommand(flags *genericclioptions.ConfigFlags) *cobra.Command {
+	var pod, deployment, selector, container *string
+	cmd := &cobra.Command{
+		Use:   "effective-config [NAMESPACE/]INGRESS",
+		Short: "Show the settings of an Ingress merged with the defaults of the ConfigMap and its locations in the running configuration",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			healthzPort, err := cmd.Flags().GetInt("healthz-port")
+			if err != nil {
+				return err
+			}
+
+			util.PrintError(effectiveConfig(flags, *pod, *deployment, *selector, *container, healthzPort, args[0]))
+			return nil
+		},
+	}
+
+	pod = util.AddPodFlag(cmd)
+	deployment = util.AddDeploymentFlag(cmd)
+	selector = util.AddSelectorFlag(cmd)
+	container = util.AddContainerFlag(cmd)
+
+	cmd.Flags().Int("healthz-port", 10254, "Port of the healthz endpoint of the ingress-nginx controller")
+
+	return cmd
+}
+
+func effectiveConfig(flags *genericclioptions.ConfigFlags, podName, deployment, selector, container string, healthzPort int, ingress string) error {
+	// the namespace flag selects the namespace of the controller
+	namespace, name := "default", ingress
+	if i := strings.Index(ingress, "/"); i >= 0 {
+		namespace, name = ingress[:i], ingress[i+1:]
+	}
+	if namespace == "" || name == "" {
+		return fmt.Errorf("invalid Ingress %q, expected [NAMESPACE/]INGRESS", ingress)
+	}
+
+	pod, err := request.ChoosePod(flags, podName, deployment, selector)
+	if err != nil {
+		return err
+	}
+
+	command := []string{"/dbg", "effective-config", name, "--namespace", namespace, "--healthz-port", strconv.Itoa(healthzPort)}
+	out, err := kubectl.PodExecString(flags, &pod, container, command)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+	return nil
+}
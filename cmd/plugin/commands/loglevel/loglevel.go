@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loglevel
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"k8s.io/ingress-nginx/cmd/plugin/kubectl"
+	"k8s.io/ingress-nginx/cmd/plugin/request"
+	"k8s.io/ingress-nginx/cmd/plugin/util"
+)
+
+// CreateCommand creates and returns this cobra subcommand
+func CreateCommand(flags *genericclioptions.ConfigFlags) *cobra.Command {
+	var pod, deployment, selector, container *string
+	cmd := &cobra.Command{
+		Use:   "log-level",
+		Short: "Show or change the verbosity of the controller and the level of the error log of NGINX, reverted after a duration",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			healthzPort, err := cmd.Flags().GetInt("healthz-port")
+			if err != nil {
+				return err
+			}
+			verbosity, err := cmd.Flags().GetString("v")
+			if err != nil {
+				return err
+			}
+			errorLogLevel, err := cmd.Flags().GetString("error-log-level")
+			if err != nil {
+				return err
+			}
+			duration, err := cmd.Flags().GetDuration("duration")
+			if err != nil {
+				return err
+			}
+			reset, err := cmd.Flags().GetBool("reset")
+			if err != nil {
+				return err
+			}
+
+			util.PrintError(logLevel(flags, *pod, *deployment, *selector, *container, healthzPort, verbosity, errorLogLevel, duration, reset))
+			return nil
+		},
+	}
+
+	pod = util.AddPodFlag(cmd)
+	deployment = util.AddDeploymentFlag(cmd)
+	selector = util.AddSelectorFlag(cmd)
+	container = util.AddContainerFlag(cmd)
+
+	cmd.Flags().Int("healthz-port", 10254, "Port of the healthz endpoint of the ingress-nginx controller")
+	cmd.Flags().String("v", "", "Verbosity of the logs of the controller")
+	cmd.Flags().String("error-log-level", "", "Level of the error log of NGINX")
+	cmd.Flags().Duration("duration", 15*time.Minute, "Time until the log levels are reverted")
+	cmd.Flags().Bool("reset", false, "Revert the log levels changed")
+
+	return cmd
+}
+
+func logLevel(flags *genericclioptions.ConfigFlags, podName, deployment, selector, container string,
+	healthzPort int, verbosity, errorLogLevel string, duration time.Duration, reset bool,
+) error {
+	pod, err := request.ChoosePod(flags, podName, deployment, selector)
+	if err != nil {
+		return err
+	}
+
+	command := []string{"/dbg", "log-level", "--healthz-port", strconv.Itoa(healthzPort),
+		"--v", verbosity, "--error-log-level", errorLogLevel, "--duration", duration.String()}
+	if reset {
+		command = append(command, "--reset")
+	}
+
+	out, err := kubectl.PodExecString(flags, &pod, container, command)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"k8s.io/ingress-nginx/cmd/plugin/util"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/deprecation"
+)
+
+// documentSeparator separates the documents of a YAML stream
+var documentSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// CreateCommand creates and returns this cobra subcommand
+func CreateCommand(_ *genericclioptions.ConfigFlags) *cobra.Command {
+	var opts migrateOptions
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Rewrite the deprecated annotations and ConfigMap keys of manifests",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			util.PrintError(opts.run(os.Stdout, os.Stderr))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.files, "filename", "f", []string{"-"}, "Manifests to rewrite, - for the standard input")
+	cmd.Flags().StringSliceVar(&opts.configMaps, "configmap", []string{"ingress-nginx-controller"}, "Names of the configuration ConfigMaps of the controller")
+	cmd.Flags().StringVar(&opts.annotationsPrefix, "annotations-prefix", parser.DefaultAnnotationsPrefix, "Prefix of the Ingress annotations")
+
+	return cmd
+}
+
+type migrateOptions struct {
+	files             []string
+	configMaps        []string
+	annotationsPrefix string
+}
+
+func (opts *migrateOptions) run(out, report io.Writer) error {
+	for _, file := range opts.files {
+		var data []byte
+		var err error
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := opts.migrate(data, out, report); err != nil {
+			return fmt.Errorf("%v: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// migrate writes the documents of the manifests with the deprecated names renamed
+// and reports the deprecated names. The documents without a deprecated name are
+// written unchanged, the rewritten ones lose their comments and order of fields.
+func (opts *migrateOptions) migrate(data []byte, out, report io.Writer) error {
+	first := true
+	for _, doc := range documentSeparator.Split(string(data), -1) {
+		doc = strings.Trim(doc, "\n")
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return err
+		}
+
+		usages := opts.translate(obj)
+		for _, u := range usages {
+			fmt.Fprintf(report, "%v %v: %v\n", obj["kind"], objectName(obj), u)
+		}
+
+		if !first {
+			fmt.Fprintln(out, "---")
+		}
+		first = false
+
+		if !slices.ContainsFunc(usages, func(u deprecation.Usage) bool { return u.Translated }) {
+			fmt.Fprintln(out, doc)
+			continue
+		}
+
+		rewritten, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if _, err := out.Write(rewritten); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// translate renames the deprecated annotations of an Ingress or keys of a
+// configuration ConfigMap and returns the deprecated names
+func (opts *migrateOptions) translate(obj map[string]interface{}) []deprecation.Usage {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	switch obj["kind"] {
+	case "Ingress":
+		annotations, usages := deprecation.TranslateAnnotations(toStringMap(metadata["annotations"]), opts.annotationsPrefix)
+		if len(usages) > 0 {
+			metadata["annotations"] = annotations
+		}
+		return usages
+	case "ConfigMap":
+		if name, _ := metadata["name"].(string); !slices.Contains(opts.configMaps, name) {
+			return nil
+		}
+		data, usages := deprecation.TranslateConfigMap(toStringMap(obj["data"]))
+		if len(usages) > 0 {
+			obj["data"] = data
+		}
+		return usages
+	}
+
+	return nil
+}
+
+func toStringMap(value interface{}) map[string]string {
+	values, _ := value.(map[string]interface{})
+	m := make(map[string]string, len(values))
+	for k, v := range values {
+		m[k] = fmt.Sprintf("%v", v)
+	}
+
+	return m
+}
+
+func objectName(obj map[string]interface{}) string {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	if namespace, _ := metadata["namespace"].(string); namespace != "" {
+		return fmt.Sprintf("%v/%v", namespace, name)
+	}
+
+	return name
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"k8s.io/ingress-nginx/cmd/plugin/kubectl"
+	"k8s.io/ingress-nginx/cmd/plugin/request"
+	"k8s.io/ingress-nginx/cmd/plugin/util"
+	"k8s.io/ingress-nginx/pkg/util/file"
+)
+
+// CreateCommand creates and returns this cobra subcommand
+func CreateCommand(flags *genericclioptions.ConfigFlags) *cobra.Command {
+	var pod, deployment, selector, container *string
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Export the support bundle of an ingress-nginx instance, without private keys and credentials",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			healthzPort, err := cmd.Flags().GetInt("healthz-port")
+			if err != nil {
+				return err
+			}
+
+			util.PrintError(supportBundle(flags, *pod, *deployment, *selector, *container, output, healthzPort))
+			return nil
+		},
+	}
+
+	pod = util.AddPodFlag(cmd)
+	deployment = util.AddDeploymentFlag(cmd)
+	selector = util.AddSelectorFlag(cmd)
+	container = util.AddContainerFlag(cmd)
+
+	cmd.Flags().StringP("output", "o", "", "Write the support bundle to this file instead of the standard output")
+	cmd.Flags().Int("healthz-port", 10254, "Port of the healthz endpoint of the ingress-nginx controller")
+
+	return cmd
+}
+
+func supportBundle(flags *genericclioptions.ConfigFlags, podName, deployment, selector, container, output string, healthzPort int) error {
+	pod, err := request.ChoosePod(flags, podName, deployment, selector)
+	if err != nil {
+		return err
+	}
+
+	command := []string{"/dbg", "support-bundle", "--healthz-port", strconv.Itoa(healthzPort)}
+	out, err := kubectl.PodExecString(flags, &pod, container, command)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		fmt.Print(out)
+		return nil
+	}
+
+	if err := os.WriteFile(output, []byte(out), file.ReadWriteByUser); err != nil {
+		return err
+	}
+
+	fmt.Printf("Support bundle of pod %v written to %v\n", pod.Name, output)
+	return nil
+}
@@ -23,8 +23,12 @@ import (
 	networking "k8s.io/api/networking/v1"
 	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/ingress-nginx/cmd/plugin/util"
+	"k8s.io/ingress-nginx/pkg/ingress/annotations"
 )
 
+// annotationsParser parses the annotations with the parsers of the controller
+var annotationsParser = annotations.NewParser(annotations.Offline{}, annotations.Options{})
+
 // IngressLint is a validation for an ingress
 type IngressLint struct {
 	message string
@@ -94,9 +98,18 @@ func GetIngressLints() []IngressLint {
 			message: "Contains an configuration-snippet that contains a Satisfy directive.\nPlease use https://kubernetes.github.io/ingress-nginx/user-guide/nginx-configuration/annotations/#satisfy",
 			f:       satisfyDirective,
 		},
+		{
+			message: "Contains an annotation with an invalid value, the Ingress is rejected by the controller",
+			f:       invalidAnnotationValue,
+		},
 	}
 }
 
+func invalidAnnotationValue(ing *networking.Ingress) bool {
+	_, err := annotationsParser.Parse(ing)
+	return err != nil
+}
+
 func xForwardedPrefixIsBool(ing *networking.Ingress) bool {
 	for name, val := range ing.Annotations {
 		if strings.HasSuffix(name, "/x-forwarded-prefix") && (val == "true" || val == "false") {
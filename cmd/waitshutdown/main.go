@@ -18,7 +18,7 @@ package main
 
 import (
 	"os"
-	"os/exec"
+	"syscall"
 	"time"
 
 	"k8s.io/ingress-nginx/internal/nginx"
@@ -26,12 +26,19 @@ import (
 )
 
 func main() {
-	err := exec.Command("bash", "-c", "pkill -SIGTERM -f nginx-ingress-controller").Run()
+	pids, err := nginx.FindProcesses("nginx-ingress-controller")
 	if err != nil {
 		klog.ErrorS(err, "terminating ingress controller")
 		os.Exit(1)
 	}
 
+	for _, pid := range pids {
+		if err := terminate(pid); err != nil {
+			klog.ErrorS(err, "terminating ingress controller", "pid", pid)
+			os.Exit(1)
+		}
+	}
+
 	// wait for the NGINX process to terminate
 	timer := time.NewTicker(time.Second * 1)
 	for range timer.C {
@@ -41,3 +48,13 @@ func main() {
 		}
 	}
 }
+
+// terminate sends SIGTERM to the process
+func terminate(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+
+	return process.Signal(syscall.SIGTERM)
+}
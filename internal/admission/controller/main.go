@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -27,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/admission/policy"
 )
 
 // Checker must return an error if the ingress provided as argument
@@ -42,6 +45,9 @@ type IngressAdmission struct {
 	Checker Checker
 }
 
+// PolicyViolationCause is the type of the causes of the Ingress objects denied by the admission policies
+const PolicyViolationCause metav1.CauseType = "PolicyViolation"
+
 var ingressResource = metav1.GroupVersionKind{
 	Group:   networking.GroupName,
 	Version: "v1",
@@ -100,6 +106,13 @@ func (ia *IngressAdmission) HandleAdmission(obj runtime.Object) (runtime.Object,
 			Message: err.Error(),
 		}
 
+		var violations policy.Violations
+		if errors.As(err, &violations) {
+			status.Result.Code = http.StatusForbidden
+			status.Result.Reason = metav1.StatusReasonForbidden
+			status.Result.Details = violationDetails(review.Request, violations)
+		}
+
 		review.Response = status
 		return review, nil
 	}
@@ -110,3 +123,20 @@ func (ia *IngressAdmission) HandleAdmission(obj runtime.Object) (runtime.Object,
 
 	return review, nil
 }
+
+// violationDetails returns the policies denying an Ingress as the causes of the status
+func violationDetails(request *admissionv1.AdmissionRequest, violations policy.Violations) *metav1.StatusDetails {
+	details := &metav1.StatusDetails{
+		Name:  request.Name,
+		Group: networking.GroupName,
+		Kind:  "Ingress",
+	}
+	for _, v := range violations {
+		details.Causes = append(details.Causes, metav1.StatusCause{
+			Type:    PolicyViolationCause,
+			Field:   v.Policy,
+			Message: v.Message,
+		})
+	}
+	return details
+}
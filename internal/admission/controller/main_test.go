@@ -18,6 +18,8 @@ package controller
 
 import (
 	"fmt"
+	"net/http"
+	"reflect"
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -25,6 +27,8 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/json"
+
+	"k8s.io/ingress-nginx/internal/admission/policy"
 )
 
 const testIngressName = "testIngressName"
@@ -133,3 +137,43 @@ func TestHandleAdmission(t *testing.T) {
 		t.Fatalf("when the checker returns no error, the request should be allowed")
 	}
 }
+
+func TestHandleAdmissionPolicyViolations(t *testing.T) {
+	raw, err := json.Marshal(networking.Ingress{ObjectMeta: v1.ObjectMeta{Name: testIngressName}})
+	if err != nil {
+		t.Fatalf("failed to prepare test ingress data: %v", err.Error())
+	}
+
+	adm := &IngressAdmission{
+		Checker: testChecker{
+			t: t,
+			err: policy.Violations{
+				{Policy: "corp-hosts", Message: "hosts must end with .corp.example.com"},
+			},
+		},
+	}
+
+	result, err := adm.HandleAdmission(&admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Name:   testIngressName,
+			Kind:   v1.GroupVersionKind{Group: networking.GroupName, Version: "v1", Kind: "Ingress"},
+			Object: runtime.RawExtension{Raw: raw},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	response := result.(*admissionv1.AdmissionReview).Response
+	if response.Allowed {
+		t.Fatalf("when the policies are violated, the request should not be allowed")
+	}
+	if response.Result.Code != http.StatusForbidden || response.Result.Reason != v1.StatusReasonForbidden {
+		t.Errorf("expected a forbidden status but got %v %v", response.Result.Code, response.Result.Reason)
+	}
+
+	expected := []v1.StatusCause{{Type: PolicyViolationCause, Field: "corp-hosts", Message: "hosts must end with .corp.example.com"}}
+	if response.Result.Details == nil || !reflect.DeepEqual(response.Result.Details.Causes, expected) {
+		t.Errorf("expected the causes %v but got %v", expected, response.Result.Details)
+	}
+}
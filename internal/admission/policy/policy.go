@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/ingress-nginx/internal/expression"
+)
+
+// Policy is a rule the Ingress objects must satisfy to be admitted
+type Policy struct {
+	// Name is the key of the policy in the ConfigMap
+	Name string `json:"-"`
+	// Expression must evaluate to true for the Ingress to be admitted
+	Expression string `json:"expression"`
+	// Message is returned to the client when the Ingress is denied
+	Message string `json:"message,omitempty"`
+
+	compiled *expression.Expression
+	// err is the error parsing the policy, the invalid policies deny all the Ingress objects
+	err error
+}
+
+// Violation is a policy denying an Ingress
+type Violation struct {
+	Policy  string
+	Message string
+}
+
+// Violations is the error returned when an Ingress does not satisfy the policies
+type Violations []Violation
+
+func (v Violations) Error() string {
+	messages := make([]string, 0, len(v))
+	for _, violation := range v {
+		messages = append(messages, fmt.Sprintf("%v: %v", violation.Policy, violation.Message))
+	}
+	return fmt.Sprintf("denied by the admission policies: %v", strings.Join(messages, "; "))
+}
+
+// Parse returns the policies of the data of a ConfigMap sorted by name.
+// Each key is the name of a policy and each value a YAML document with
+// the expression and the message of the policy.
+func Parse(data map[string]string) []*Policy {
+	policies := make([]*Policy, 0, len(data))
+	for name, value := range data {
+		p := &Policy{}
+		if err := yaml.UnmarshalStrict([]byte(value), p); err != nil {
+			p.err = fmt.Errorf("invalid policy: %w", err)
+		} else if p.Expression == "" {
+			p.err = fmt.Errorf("invalid policy: missing expression")
+		} else if p.compiled, p.err = expression.Compile(p.Expression); p.err != nil {
+			p.err = fmt.Errorf("invalid expression: %w", p.err)
+		}
+		p.Name = name
+		policies = append(policies, p)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return policies
+}
+
+// Err returns the error parsing the policy
+func (p *Policy) Err() error {
+	return p.err
+}
+
+// Variables returns the variables of the expressions for an Ingress:
+// object is the Ingress and annotations the annotations with the prefix
+// of the controller, without the prefix
+func Variables(ing *networking.Ingress, prefix string) (map[string]interface{}, error) {
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ing)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := map[string]interface{}{}
+	for key, value := range ing.GetAnnotations() {
+		if name, ok := strings.CutPrefix(key, prefix+"/"); ok {
+			annotations[name] = value
+		}
+	}
+
+	return map[string]interface{}{
+		"object":      object,
+		"annotations": annotations,
+	}, nil
+}
+
+// Evaluate returns the Violations of the policies by an Ingress, nil when it satisfies all of them
+func Evaluate(policies []*Policy, ing *networking.Ingress, prefix string) error {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	vars, err := Variables(ing, prefix)
+	if err != nil {
+		return fmt.Errorf("unable to evaluate the admission policies: %w", err)
+	}
+
+	var violations Violations
+	for _, p := range policies {
+		if p.err != nil {
+			violations = append(violations, Violation{Policy: p.Name, Message: p.err.Error()})
+			continue
+		}
+
+		v, err := p.compiled.Eval(vars)
+		if err != nil {
+			violations = append(violations, Violation{Policy: p.Name, Message: fmt.Sprintf("error evaluating %q: %v", p.Expression, err)})
+			continue
+		}
+		admitted, isBool := v.(bool)
+		switch {
+		case !isBool:
+			violations = append(violations, Violation{Policy: p.Name, Message: fmt.Sprintf("expression %q returned %T instead of a boolean", p.Expression, v)})
+		case !admitted:
+			message := p.Message
+			if message == "" {
+				message = fmt.Sprintf("failed expression %q", p.Expression)
+			}
+			violations = append(violations, Violation{Policy: p.Name, Message: message})
+		}
+	}
+
+	if len(violations) > 0 {
+		return violations
+	}
+	return nil
+}
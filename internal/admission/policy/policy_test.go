@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvaluate(t *testing.T) {
+	policies := Parse(map[string]string{
+		"corp-hosts": `
+expression: object.spec.rules.all(r, r.host.endsWith(".corp.example.com"))
+message: hosts must end with .corp.example.com`,
+		"body-size": `expression: '!("proxy-body-size" in annotations) || bytes(annotations["proxy-body-size"]) <= bytes("50m")'`,
+	})
+	if len(policies) != 2 || policies[0].Name != "body-size" || policies[1].Name != "corp-hosts" {
+		t.Fatalf("unexpected policies %v", policies)
+	}
+	for _, p := range policies {
+		if p.Err() != nil {
+			t.Fatalf("unexpected error parsing the policy %v: %v", p.Name, p.Err())
+		}
+	}
+
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-body-size": "8m",
+			},
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{{Host: "api.corp.example.com"}},
+		},
+	}
+	if err := Evaluate(policies, ing, "nginx.ingress.kubernetes.io"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	ing.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = "1g"
+	ing.Spec.Rules = append(ing.Spec.Rules, networking.IngressRule{Host: "example.com"})
+	err := Evaluate(policies, ing, "nginx.ingress.kubernetes.io")
+
+	var violations Violations
+	if !errors.As(err, &violations) {
+		t.Fatalf("expected violations but got %v", err)
+	}
+	expected := Violations{
+		{Policy: "body-size", Message: `failed expression "!(\"proxy-body-size\" in annotations) || bytes(annotations[\"proxy-body-size\"]) <= bytes(\"50m\")"`},
+		{Policy: "corp-hosts", Message: "hosts must end with .corp.example.com"},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("expected %v but got %v", expected, violations)
+	}
+}
+
+func TestEvaluateInvalidPolicies(t *testing.T) {
+	policies := Parse(map[string]string{
+		"no-expression": `message: missing`,
+		"unknown-field": `expressions: "true"`,
+		"invalid":       `expression: object.`,
+		"not-bool":      `expression: object.metadata.name`,
+		"error":         `expression: object.spec.tls.all(t, true)`,
+	})
+
+	ing := &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+	err := Evaluate(policies, ing, "nginx.ingress.kubernetes.io")
+
+	var violations Violations
+	if !errors.As(err, &violations) {
+		t.Fatalf("expected violations but got %v", err)
+	}
+	if len(violations) != len(policies) {
+		t.Errorf("expected every invalid policy to deny the Ingress but got %v", violations)
+	}
+}
+
+func TestEvaluateWithoutPolicies(t *testing.T) {
+	if err := Evaluate(Parse(nil), &networking.Ingress{}, "nginx.ingress.kubernetes.io"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bench generates synthetic configurations of N hosts with M paths
+// and a mix of annotations, used to measure the performance of the
+// annotation parsing and the rendering of the NGINX configuration.
+package bench
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/ingress/defaults"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+	"k8s.io/ingress-nginx/internal/nginx"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+	utilingress "k8s.io/ingress-nginx/pkg/util/ingress"
+)
+
+// Names of the mixes of annotations of the generated Ingress objects
+const (
+	// NoneMix does not add annotations
+	NoneMix = "none"
+	// CommonMix adds the annotations used by most of the Ingress objects
+	CommonMix = "common"
+	// HeavyMix adds annotations of authentication, rate limiting and allow lists
+	HeavyMix = "heavy"
+	// RotateMix rotates the none, common and heavy mixes between the hosts
+	RotateMix = "rotate"
+)
+
+const defUpstreamName = "upstream-default-backend"
+
+var mixes = map[string]map[string]string{
+	NoneMix: {},
+	CommonMix: {
+		"rewrite-target":     "/",
+		"ssl-redirect":       "false",
+		"proxy-body-size":    "8m",
+		"proxy-read-timeout": "120",
+		"enable-cors":        "true",
+	},
+	HeavyMix: {
+		"rewrite-target":         "/",
+		"ssl-redirect":           "false",
+		"proxy-body-size":        "8m",
+		"proxy-read-timeout":     "120",
+		"enable-cors":            "true",
+		"cors-allow-origin":      "https://example.com",
+		"limit-rps":              "10",
+		"limit-connections":      "5",
+		"allowlist-source-range": "10.0.0.0/8,192.168.0.0/16",
+		"auth-url":               "http://auth.example.com/verify",
+		"custom-http-errors":     "404,503",
+		"upstream-vhost":         "example.internal",
+		"affinity":               "cookie",
+		"proxy-buffering":        "on",
+	},
+}
+
+// Options defines the size of the generated configuration
+type Options struct {
+	// Hosts is the number of hosts, each one defined in its own Ingress
+	Hosts int
+	// Paths is the number of paths of each host
+	Paths int
+	// Mix is the name of the mix of annotations of the Ingress objects
+	Mix string
+	// Namespace of the Ingress objects
+	Namespace string
+}
+
+// Validate checks the options and sets the default values
+func (o *Options) Validate() error {
+	if o.Hosts < 1 {
+		return fmt.Errorf("the number of hosts must be greater than zero")
+	}
+
+	if o.Paths < 1 {
+		return fmt.Errorf("the number of paths must be greater than zero")
+	}
+
+	if o.Mix == "" {
+		o.Mix = CommonMix
+	}
+
+	if _, ok := mixes[o.Mix]; !ok && o.Mix != RotateMix {
+		return fmt.Errorf("unknown mix of annotations %q", o.Mix)
+	}
+
+	if o.Namespace == "" {
+		o.Namespace = apiv1.NamespaceDefault
+	}
+
+	return nil
+}
+
+func (o *Options) annotations(host int) map[string]string {
+	mix := o.Mix
+	if mix == RotateMix {
+		mix = []string{NoneMix, CommonMix, HeavyMix}[host%3]
+	}
+
+	anns := make(map[string]string, len(mixes[mix]))
+	for k, v := range mixes[mix] {
+		anns[parser.GetAnnotationWithPrefix(k)] = v
+	}
+
+	return anns
+}
+
+// Ingresses returns an Ingress object for each host
+func Ingresses(opts Options) ([]*networking.Ingress, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	pathType := networking.PathTypePrefix
+	ings := make([]*networking.Ingress, 0, opts.Hosts)
+	for h := 0; h < opts.Hosts; h++ {
+		paths := make([]networking.HTTPIngressPath, 0, opts.Paths)
+		for p := 0; p < opts.Paths; p++ {
+			paths = append(paths, networking.HTTPIngressPath{
+				Path:     fmt.Sprintf("/path-%v", p),
+				PathType: &pathType,
+				Backend: networking.IngressBackend{
+					Service: &networking.IngressServiceBackend{
+						Name: fmt.Sprintf("service-%v", p),
+						Port: networking.ServiceBackendPort{
+							Number: 80,
+						},
+					},
+				},
+			})
+		}
+
+		ings = append(ings, &networking.Ingress{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: networking.SchemeGroupVersion.String(),
+				Kind:       "Ingress",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("ingress-%v", h),
+				Namespace:   opts.Namespace,
+				Annotations: opts.annotations(h),
+			},
+			Spec: networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						Host: fmt.Sprintf("host-%v.example.com", h),
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{
+								Paths: paths,
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return ings, nil
+}
+
+// Resolver returns the defaults used to parse the annotations of the generated Ingress objects
+func Resolver() resolver.Resolver {
+	return backendResolver{
+		Mock:    resolver.Mock{AnnotationsRiskLevel: "Critical"},
+		backend: config.NewDefault().Backend,
+	}
+}
+
+type backendResolver struct {
+	resolver.Mock
+	backend defaults.Backend
+}
+
+func (r backendResolver) GetDefaultBackend() defaults.Backend {
+	return r.backend
+}
+
+// TemplateConfig returns the configuration used to render nginx.conf
+// with the servers, locations and backends of the generated Ingress objects
+func TemplateConfig(opts Options) (*config.TemplateConfig, error) {
+	ings, err := Ingresses(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	extractor := annotations.NewAnnotationExtractor(Resolver())
+
+	defServer, err := defaultServer(extractor)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := []*ingress.Server{defServer}
+	upstreams := []*ingress.Backend{newBackend(defUpstreamName)}
+	seen := map[string]bool{}
+	for _, ing := range ings {
+		anns, err := extractor.Extract(ing)
+		if err != nil {
+			return nil, fmt.Errorf("parsing annotations of ingress %v: %w", ing.Name, err)
+		}
+
+		rule := ing.Spec.Rules[0]
+		server := &ingress.Server{
+			Hostname: rule.Host,
+		}
+
+		for i := range rule.HTTP.Paths {
+			path := &rule.HTTP.Paths[i]
+			name := upstreamName(ing.Namespace, path.Backend.Service)
+			if !seen[name] {
+				seen[name] = true
+				upstreams = append(upstreams, newBackend(name))
+			}
+
+			server.Locations = append(server.Locations, newLocation(ing, anns, path.Path, path.PathType, name))
+		}
+
+		servers = append(servers, server)
+	}
+
+	cfg := config.NewDefault()
+	cfg.DefaultSSLCertificate = &ingress.SSLCert{}
+
+	return &config.TemplateConfig{
+		ProxySetHeaders:     map[string]string{},
+		AddHeaders:          map[string]string{},
+		BacklogSize:         32768,
+		Backends:            upstreams,
+		Servers:             servers,
+		Cfg:                 cfg,
+		IsIPV6Enabled:       true,
+		RedirectServers:     utilingress.BuildRedirects(servers),
+		ListenPorts:         &config.ListenPorts{HTTP: 80, HTTPS: 443, Health: 10254, Default: 8181, SSLProxy: 442},
+		HealthzURI:          nginx.HealthPath,
+		MonitorMaxBatchSize: 100,
+		PID:                 nginx.PID,
+		StatusPath:          nginx.StatusPath,
+		StatusPort:          nginx.StatusPort,
+		StreamPort:          nginx.StreamPort,
+	}, nil
+}
+
+func upstreamName(namespace string, service *networking.IngressServiceBackend) string {
+	return fmt.Sprintf("%v-%v-%v", namespace, service.Name, service.Port.Number)
+}
+
+func newBackend(name string) *ingress.Backend {
+	return &ingress.Backend{
+		Name: name,
+		Port: intstr.FromInt(80),
+		Endpoints: []ingress.Endpoint{
+			{Address: "10.0.0.1", Port: "8080"},
+			{Address: "10.0.0.2", Port: "8080"},
+		},
+	}
+}
+
+// defaultServer returns the catch-all server, using the
+// annotations of an empty Ingress to obtain the default values
+func defaultServer(extractor annotations.Extractor) (*ingress.Server, error) {
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default-backend",
+			Namespace: apiv1.NamespaceDefault,
+		},
+	}
+
+	anns, err := extractor.Extract(ing)
+	if err != nil {
+		return nil, err
+	}
+
+	pathType := networking.PathTypePrefix
+	location := newLocation(ing, anns, "/", &pathType, defUpstreamName)
+	location.IsDefBackend = true
+
+	return &ingress.Server{
+		Hostname:  "_",
+		Locations: []*ingress.Location{location},
+	}, nil
+}
+
+// newLocation copies the parsed annotations like the controller does for the locations of an Ingress
+func newLocation(ing *networking.Ingress, anns *annotations.Ingress, path string, pathType *networking.PathType, upstream string) *ingress.Location {
+	return &ingress.Location{
+		Path:     path,
+		PathType: pathType,
+		Ingress: &ingress.Ingress{
+			Ingress:           *ing,
+			ParsedAnnotations: anns,
+		},
+		IngressPath:                path,
+		Backend:                    upstream,
+		Port:                       intstr.FromInt(80),
+		BasicDigestAuth:            anns.BasicDigestAuth,
+		ClientBodyBufferSize:       anns.ClientBodyBufferSize,
+		Compression:                anns.Compression,
+		CustomHeaders:              anns.CustomHeaders,
+		HeadersFromVariables:       anns.HeadersFromVariables,
+		CorsConfig:                 anns.CorsConfig,
+		ExternalAuth:               anns.ExternalAuth,
+		EnableGlobalAuth:           anns.EnableGlobalAuth,
+		Proxy:                      anns.Proxy,
+		ProxySSL:                   anns.ProxySSL,
+		Ranges:                     anns.Ranges,
+		RateLimit:                  anns.RateLimit,
+		UploadGuard:                anns.UploadGuard,
+		Redirect:                   anns.Redirect,
+		Rewrite:                    anns.Rewrite,
+		UpstreamVhost:              anns.UpstreamVhost,
+		Denylist:                   anns.Denylist,
+		Allowlist:                  anns.Allowlist,
+		Denied:                     anns.Denied,
+		XForwardedPrefix:           anns.XForwardedPrefix,
+		UsePortInRedirects:         anns.UsePortInRedirects,
+		Connection:                 anns.Connection,
+		Logs:                       anns.Logs,
+		BackendProtocol:            anns.BackendProtocol,
+		FastCGI:                    anns.FastCGI,
+		CustomHTTPErrors:           anns.CustomHTTPErrors,
+		ModSecurity:                anns.ModSecurity,
+		Satisfy:                    anns.Satisfy,
+		Mirror:                     anns.Mirror,
+		SubFilter:                  anns.SubFilter,
+		Opentelemetry:              anns.Opentelemetry,
+		DefaultBackendUpstreamName: defUpstreamName,
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	"k8s.io/ingress-nginx/internal/ingress/controller/template"
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+func init() {
+	// the default value of nginx.TemplatePath assumes the template exists in
+	// the root filesystem and not in the rootfs directory
+	absPath, err := filepath.Abs(filepath.Join("..", "..", "rootfs", nginx.TemplatePath))
+	if err == nil {
+		nginx.TemplatePath = absPath
+	}
+}
+
+var sizes = []Options{
+	{Hosts: 10, Paths: 5},
+	{Hosts: 100, Paths: 10},
+	{Hosts: 1000, Paths: 5},
+}
+
+func TestOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		opts    Options
+		wantErr bool
+	}{
+		{Options{Hosts: 1, Paths: 1}, false},
+		{Options{Hosts: 1, Paths: 1, Mix: RotateMix}, false},
+		{Options{Hosts: 0, Paths: 1}, true},
+		{Options{Hosts: 1, Paths: 0}, true},
+		{Options{Hosts: 1, Paths: 1, Mix: "unknown"}, true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.opts.Validate()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%+v: expected error %v but returned %v", tc.opts, tc.wantErr, err)
+		}
+	}
+}
+
+func TestTemplateConfig(t *testing.T) {
+	tc, err := TemplateConfig(Options{Hosts: 3, Paths: 2, Mix: RotateMix})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the catch-all server and one server per host
+	if len(tc.Servers) != 4 {
+		t.Errorf("expected 4 servers but returned %v", len(tc.Servers))
+	}
+
+	// the default backend and one backend per path
+	if len(tc.Backends) != 3 {
+		t.Errorf("expected 3 backends but returned %v", len(tc.Backends))
+	}
+
+	heavy := tc.Servers[3].Locations[0]
+	if heavy.RateLimit.RPS.Limit != 10 {
+		t.Errorf("expected the annotations of the heavy mix to be parsed")
+	}
+
+	ngxTpl, err := template.NewTemplate(nginx.TemplatePath)
+	if err != nil {
+		t.Fatalf("invalid NGINX template: %v", err)
+	}
+
+	rt, err := ngxTpl.Write(tc)
+	if err != nil {
+		t.Fatalf("unexpected error writing template: %v", err)
+	}
+
+	for h := 0; h < 3; h++ {
+		if !strings.Contains(string(rt), fmt.Sprintf("## start server host-%v.example.com", h)) {
+			t.Errorf("expected the server of host %v in the configuration", h)
+		}
+	}
+}
+
+func BenchmarkExtractAnnotations(b *testing.B) {
+	for _, mix := range []string{NoneMix, CommonMix, HeavyMix} {
+		b.Run(mix, func(b *testing.B) {
+			ings, err := Ingresses(Options{Hosts: 100, Paths: 1, Mix: mix})
+			if err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+
+			extractor := annotations.NewAnnotationExtractor(Resolver())
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, ing := range ings {
+					if _, err := extractor.Extract(ing); err != nil {
+						b.Fatalf("unexpected error: %v", err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRenderTemplate(b *testing.B) {
+	ngxTpl, err := template.NewTemplate(nginx.TemplatePath)
+	if err != nil {
+		b.Fatalf("invalid NGINX template: %v", err)
+	}
+
+	for _, opts := range sizes {
+		for _, mix := range []string{NoneMix, RotateMix} {
+			opts.Mix = mix
+			b.Run(fmt.Sprintf("hosts=%v/paths=%v/mix=%v", opts.Hosts, opts.Paths, opts.Mix), func(b *testing.B) {
+				tc, err := TemplateConfig(opts)
+				if err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, err := ngxTpl.Write(tc); err != nil {
+						b.Fatalf("unexpected error writing template: %v", err)
+					}
+				}
+			})
+		}
+	}
+}
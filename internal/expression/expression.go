@@ -0,0 +1,850 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package expression implements the expression language of the controller,
+// used by the route-if annotation and by the admission policies.
+//
+// The language borrows the syntax of CEL but it is not CEL: it is implemented
+// by the controller, without type declarations, overloads, macros other than
+// all() and exists(), or the CEL standard library. Its grammar, in EBNF, is
+//
+//	Expr     = And { "||" And } .
+//	And      = Relation { "&&" Relation } .
+//	Relation = Unary [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "in" ) Unary ] .
+//	Unary    = "!" Unary | Postfix .
+//	Postfix  = Primary { "." ident [ "(" [ Args ] ")" ] | "[" Expr "]" } .
+//	Primary  = string | int | "true" | "false" | "null" | ident [ "(" Expr ")" ] |
+//	           "(" Expr ")" | "[" [ Expr { "," Expr } ] "]" .
+//	Args     = ident "," Expr | Expr .
+//	ident    = ( letter | "_" ) { letter | digit | "_" } .
+//	int      = digit { digit } .
+//	string   = "'" { char } "'" | `"` { char } `"` .
+//
+// A backslash in a string escapes the next character, \n and \t being a new
+// line and a tab. The values are strings, 64-bit integers, booleans, null,
+// lists and maps of strings. The methods are all(x, p) and exists(x, p) of the
+// lists and maps, size() and the string methods startsWith(), endsWith(),
+// contains() and matches(), a RE2 regular expression. The functions are has()
+// of a field selection, size(), int(), string() and bytes(), the number of
+// bytes of an NGINX size like 8k or 50m. Selecting a missing field is an error,
+// tested with has() first.
+package expression
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression is a compiled expression
+type Expression struct {
+	source string
+	// Root is the root node of the expression
+	Root Node
+}
+
+// String returns the source of the expression
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Eval evaluates the expression with the values of the variables
+func (e *Expression) Eval(vars map[string]interface{}) (interface{}, error) {
+	return e.Root.Eval(vars)
+}
+
+// Compile parses an expression
+func Compile(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+	}
+
+	return &Expression{source: source, Root: root}, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenInt
+	tokenSymbol
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// the symbols of the expressions, the longest first
+var symbols = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ".", ","}
+
+func tokenize(source string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] != '\\' || j+1 == len(runes) {
+					sb.WriteRune(runes[j])
+					continue
+				}
+				j++
+				switch runes[j] {
+				case 'n':
+					sb.WriteByte('\n')
+				case 't':
+					sb.WriteByte('\t')
+				default:
+					sb.WriteRune(runes[j])
+				}
+			}
+			if j == len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: sb.String(), pos: i})
+			i = j + 1
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenInt, value: string(runes[i:j]), pos: i})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: string(runes[i:j]), pos: i})
+			i = j
+		default:
+			found := false
+			for _, s := range symbols {
+				if strings.HasPrefix(string(runes[i:]), s) {
+					tokens = append(tokens, token{kind: tokenSymbol, value: s, pos: i})
+					i += len([]rune(s))
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+			}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the symbol or keyword value
+func (p *exprParser) accept(value string) bool {
+	t := p.peek()
+	if (t.kind == tokenSymbol || t.kind == tokenIdent) && t.value == value {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) expect(value string) error {
+	if !p.accept(value) {
+		t := p.peek()
+		return fmt.Errorf("expected %q at position %d", value, t.pos)
+	}
+	return nil
+}
+
+func (p *exprParser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Or: true, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (Node, error) {
+	left, err := p.parseRelation()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// the relation operators
+var relations = []string{"==", "!=", "<=", ">=", "<", ">", "in"}
+
+func (p *exprParser) parseRelation() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range relations {
+		if p.accept(op) {
+			right, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			return &Relation{Op: op, Left: left, Right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (Node, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *exprParser) parsePostfix() (Node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.accept("."):
+			t := p.next()
+			if t.kind != tokenIdent {
+				return nil, fmt.Errorf("expected a field or method name at position %d", t.pos)
+			}
+			if !p.accept("(") {
+				n = &Select{Operand: n, Field: t.value}
+				continue
+			}
+			n, err = p.parseMethod(n, t)
+			if err != nil {
+				return nil, err
+			}
+		case p.accept("["):
+			key, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			n = &Index{Operand: n, Key: key}
+		default:
+			return n, nil
+		}
+	}
+}
+
+// parseMethod parses the arguments of a method after the opening parenthesis
+func (p *exprParser) parseMethod(target Node, name token) (Node, error) {
+	switch name.value {
+	case "all", "exists":
+		variable := p.next()
+		if variable.kind != tokenIdent {
+			return nil, fmt.Errorf("expected a variable name at position %d", variable.pos)
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		predicate, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return &Quantifier{All: name.value == "all", Operand: target, Variable: variable.value, Predicate: predicate}, nil
+	case "size":
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return &Function{Name: "size", Args: []Node{target}}, nil
+	case "startsWith", "endsWith", "contains", "matches":
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		if name.value == "matches" {
+			if l, ok := arg.(*Literal); ok {
+				pattern, ok := l.Value.(string)
+				if !ok {
+					return nil, fmt.Errorf("matches expects a string at position %d", name.pos)
+				}
+				if _, err := regexp.Compile(pattern); err != nil {
+					return nil, fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+				}
+			}
+		}
+		return &StringMethod{Method: name.value, Operand: target, Arg: arg}, nil
+	default:
+		return nil, fmt.Errorf("unknown method %q at position %d", name.value, name.pos)
+	}
+}
+
+func (p *exprParser) parsePrimary() (Node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return &Literal{Value: t.value}, nil
+	case tokenInt:
+		i, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q at position %d", t.value, t.pos)
+		}
+		return &Literal{Value: i}, nil
+	case tokenIdent:
+		switch t.value {
+		case "true":
+			return &Literal{Value: true}, nil
+		case "false":
+			return &Literal{Value: false}, nil
+		case "null":
+			return &Literal{Value: nil}, nil
+		}
+		if !p.accept("(") {
+			return &Variable{Name: t.value}, nil
+		}
+		return p.parseFunction(t)
+	case tokenSymbol:
+		switch t.value {
+		case "(":
+			n, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expect(")")
+		case "[":
+			list := &List{}
+			for !p.accept("]") {
+				if len(list.Items) > 0 {
+					if err := p.expect(","); err != nil {
+						return nil, err
+					}
+				}
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				list.Items = append(list.Items, item)
+			}
+			return list, nil
+		}
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+}
+
+// parseFunction parses the arguments of a function after the opening parenthesis
+func (p *exprParser) parseFunction(name token) (Node, error) {
+	arg, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	switch name.value {
+	case "has":
+		s, ok := arg.(*Select)
+		if !ok {
+			return nil, fmt.Errorf("has expects a field selection at position %d", name.pos)
+		}
+		return &Has{Operand: s.Operand, Field: s.Field}, nil
+	case "size", "bytes", "int", "string":
+		return &Function{Name: name.value, Args: []Node{arg}}, nil
+	default:
+		return nil, fmt.Errorf("unknown function %q at position %d", name.value, name.pos)
+	}
+}
+
+// Node is a node of a compiled expression
+type Node interface {
+	// Eval evaluates the node with the values of the variables
+	Eval(vars map[string]interface{}) (interface{}, error)
+}
+
+// Literal is a string, an integer, a boolean or null
+type Literal struct {
+	Value interface{}
+}
+
+// Eval returns the value of the literal
+func (n *Literal) Eval(map[string]interface{}) (interface{}, error) {
+	return n.Value, nil
+}
+
+// Variable is a variable of the expression
+type Variable struct {
+	Name string
+}
+
+// Eval returns the value of the variable
+func (n *Variable) Eval(vars map[string]interface{}) (interface{}, error) {
+	v, ok := vars[n.Name]
+	if !ok {
+		return nil, fmt.Errorf("undeclared variable %q", n.Name)
+	}
+	return v, nil
+}
+
+// List is a list of expressions between brackets
+type List struct {
+	Items []Node
+}
+
+// Eval returns the values of the items
+func (n *List) Eval(vars map[string]interface{}) (interface{}, error) {
+	list := make([]interface{}, 0, len(n.Items))
+	for _, item := range n.Items {
+		v, err := item.Eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+// Logical is && or, when Or is true, ||
+type Logical struct {
+	Or          bool
+	Left, Right Node
+}
+
+// Eval evaluates the right operand only when the left one does not decide the result
+func (n *Logical) Eval(vars map[string]interface{}) (interface{}, error) {
+	left, err := evalBool(n.Left, vars)
+	if err != nil {
+		return nil, err
+	}
+	if left == n.Or {
+		return left, nil
+	}
+	return evalBool(n.Right, vars)
+}
+
+// Not is the negation of a boolean
+type Not struct {
+	Operand Node
+}
+
+// Eval returns the negation of the operand
+func (n *Not) Eval(vars map[string]interface{}) (interface{}, error) {
+	v, err := evalBool(n.Operand, vars)
+	if err != nil {
+		return nil, err
+	}
+	return !v, nil
+}
+
+// Relation is a comparison or the test of a value in a list or a key in a map
+type Relation struct {
+	Op          string
+	Left, Right Node
+}
+
+// Eval returns the result of the comparison
+func (n *Relation) Eval(vars map[string]interface{}) (interface{}, error) {
+	left, err := n.Left.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.Right.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	case "in":
+		switch r := right.(type) {
+		case []interface{}:
+			for _, item := range r {
+				if reflect.DeepEqual(left, item) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]interface{}:
+			key, ok := left.(string)
+			if !ok {
+				return nil, fmt.Errorf("'in' expects a string key but got %T", left)
+			}
+			_, ok = r[key]
+			return ok, nil
+		default:
+			return nil, fmt.Errorf("'in' expects a list or a map but got %T", right)
+		}
+	}
+
+	c, err := compare(left, right)
+	if err != nil {
+		return nil, err
+	}
+	switch n.Op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default:
+		return c >= 0, nil
+	}
+}
+
+// compare returns the order of two integers or two strings
+func compare(left, right interface{}) (int, error) {
+	switch l := left.(type) {
+	case int64:
+		if r, ok := right.(int64); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	}
+	return 0, fmt.Errorf("unable to compare %T with %T", left, right)
+}
+
+// Select is the selection of a field of a map
+type Select struct {
+	Operand Node
+	Field   string
+}
+
+// Eval returns the value of the field, an error if it is missing
+func (n *Select) Eval(vars map[string]interface{}) (interface{}, error) {
+	v, err := n.Operand.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unable to select the field %q of %T", n.Field, v)
+	}
+	field, ok := m[n.Field]
+	if !ok {
+		return nil, fmt.Errorf("no such key %q", n.Field)
+	}
+	return field, nil
+}
+
+// Has tests if a map has a field
+type Has struct {
+	Operand Node
+	Field   string
+}
+
+// Eval returns true if the map has the field
+func (n *Has) Eval(vars map[string]interface{}) (interface{}, error) {
+	v, err := n.Operand.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unable to test the field %q of %T", n.Field, v)
+	}
+	_, ok = m[n.Field]
+	return ok, nil
+}
+
+// Index is the value of a key of a map or of an item of a list
+type Index struct {
+	Operand, Key Node
+}
+
+// Eval returns the value of the key or the item, an error if it is missing
+func (n *Index) Eval(vars map[string]interface{}) (interface{}, error) {
+	v, err := n.Operand.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	key, err := n.Key.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o := v.(type) {
+	case map[string]interface{}:
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string key but got %T", key)
+		}
+		item, ok := o[k]
+		if !ok {
+			return nil, fmt.Errorf("no such key %q", k)
+		}
+		return item, nil
+	case []interface{}:
+		i, ok := key.(int64)
+		if !ok {
+			return nil, fmt.Errorf("expected an integer index but got %T", key)
+		}
+		if i < 0 || i >= int64(len(o)) {
+			return nil, fmt.Errorf("index %d out of range", i)
+		}
+		return o[i], nil
+	default:
+		return nil, fmt.Errorf("unable to index %T", v)
+	}
+}
+
+// Quantifier is the method all or, when All is false, exists
+type Quantifier struct {
+	All       bool
+	Operand   Node
+	Variable  string
+	Predicate Node
+}
+
+// Eval evaluates the predicate with the items of a list or the keys of a map
+func (n *Quantifier) Eval(vars map[string]interface{}) (interface{}, error) {
+	v, err := n.Operand.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []interface{}
+	switch o := v.(type) {
+	case []interface{}:
+		items = o
+	case map[string]interface{}:
+		// the quantifiers of maps iterate on the keys
+		for key := range o {
+			items = append(items, key)
+		}
+	default:
+		return nil, fmt.Errorf("unable to iterate on %T", v)
+	}
+
+	scope := make(map[string]interface{}, len(vars)+1)
+	for name, value := range vars {
+		scope[name] = value
+	}
+	for _, item := range items {
+		scope[n.Variable] = item
+		ok, err := evalBool(n.Predicate, scope)
+		if err != nil {
+			return nil, err
+		}
+		if ok != n.All {
+			return ok, nil
+		}
+	}
+	return n.All, nil
+}
+
+// StringMethod is the method startsWith, endsWith, contains or matches of a string
+type StringMethod struct {
+	Method       string
+	Operand, Arg Node
+}
+
+// Eval returns the result of the method
+func (n *StringMethod) Eval(vars map[string]interface{}) (interface{}, error) {
+	s, err := evalString(n.Operand, vars)
+	if err != nil {
+		return nil, err
+	}
+	arg, err := evalString(n.Arg, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Method {
+	case "startsWith":
+		return strings.HasPrefix(s, arg), nil
+	case "endsWith":
+		return strings.HasSuffix(s, arg), nil
+	case "contains":
+		return strings.Contains(s, arg), nil
+	default:
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", arg, err)
+		}
+		return re.MatchString(s), nil
+	}
+}
+
+// Function is the function size, bytes, int or string
+type Function struct {
+	Name string
+	Args []Node
+}
+
+// Eval returns the result of the function
+func (n *Function) Eval(vars map[string]interface{}) (interface{}, error) {
+	v, err := n.Args[0].Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Name {
+	case "size":
+		switch o := v.(type) {
+		case string:
+			return int64(len([]rune(o))), nil
+		case []interface{}:
+			return int64(len(o)), nil
+		case map[string]interface{}:
+			return int64(len(o)), nil
+		}
+		return nil, fmt.Errorf("size expects a string, a list or a map but got %T", v)
+	case "bytes":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("bytes expects a string but got %T", v)
+		}
+		return parseSize(s)
+	case "int":
+		switch o := v.(type) {
+		case int64:
+			return o, nil
+		case string:
+			i, err := strconv.ParseInt(o, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer %q", o)
+			}
+			return i, nil
+		}
+		return nil, fmt.Errorf("int expects a string or an integer but got %T", v)
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// parseSize returns the number of bytes of a NGINX size like 8k, 50m or 1g
+func parseSize(size string) (int64, error) {
+	s := strings.TrimSpace(size)
+	multiplier := int64(1)
+	if s != "" {
+		switch s[len(s)-1] {
+		case 'k', 'K':
+			multiplier = 1 << 10
+		case 'm', 'M':
+			multiplier = 1 << 20
+		case 'g', 'G':
+			multiplier = 1 << 30
+		}
+	}
+	if multiplier > 1 {
+		s = s[:len(s)-1]
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid size %q", size)
+	}
+	return i * multiplier, nil
+}
+
+func evalBool(n Node, vars map[string]interface{}) (bool, error) {
+	v, err := n.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a boolean but got %T", v)
+	}
+	return b, nil
+}
+
+func evalString(n Node, vars map[string]interface{}) (string, error) {
+	v, err := n.Eval(vars)
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string but got %T", v)
+	}
+	return s, nil
+}
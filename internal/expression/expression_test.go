@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expression
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]interface{}{
+		"object": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      "api",
+				"namespace": "team-a",
+			},
+			"spec": map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"host": "api.corp.example.com"},
+					map[string]interface{}{"host": "www.corp.example.com"},
+				},
+			},
+		},
+		"annotations": map[string]interface{}{
+			"proxy-body-size": "8m",
+		},
+	}
+
+	tests := []struct {
+		expression string
+		expected   interface{}
+	}{
+		{`object.spec.rules.all(r, r.host.endsWith(".corp.example.com"))`, true},
+		{`object.spec.rules.exists(r, r.host.startsWith("www."))`, true},
+		{`object.spec.rules.exists(r, r.host == "example.com")`, false},
+		{`!has(object.spec.tls) || object.spec.tls.all(t, size(t.hosts) > 0)`, true},
+		{`has(object.metadata.name) && object.metadata.name.matches("^[a-z]+$")`, true},
+		{`!("proxy-body-size" in annotations) || bytes(annotations["proxy-body-size"]) <= bytes("50m")`, true},
+		{`bytes(annotations["proxy-body-size"]) > bytes("1g")`, false},
+		{`object.metadata.namespace in ["team-a", 'team-b']`, true},
+		{`size(object.spec.rules) == 2 && object.spec.rules.size() >= 2`, true},
+		{`object.spec.rules[1].host.contains("www")`, true},
+		{`int("10") < 9 || string(10) == "10"`, true},
+		{`annotations.all(name, !name.endsWith("-snippet"))`, true},
+		{`object.metadata.name != null && (false || !false)`, true},
+		{`size("a\nb") == 3 && 'it\'s' == "it's"`, true},
+		{`object.spec`, vars["object"].(map[string]interface{})["spec"]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			e, err := Compile(test.expression)
+			if err != nil {
+				t.Fatalf("unexpected error compiling %q: %v", test.expression, err)
+			}
+			got, err := e.Eval(vars)
+			if err != nil {
+				t.Fatalf("unexpected error evaluating %q: %v", test.expression, err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v but got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	vars := map[string]interface{}{
+		"object": map[string]interface{}{
+			"spec": map[string]interface{}{},
+		},
+	}
+
+	for _, expression := range []string{
+		`object.spec.tls.all(t, true)`,
+		`unknown == 1`,
+		`object.spec && true`,
+		`object.spec.rules[0]`,
+		`size(1) == 1`,
+		`bytes("10x") > 0`,
+		`"a" < 1`,
+		`object.spec["rules"]`,
+		`object == null || 1`,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			e, err := Compile(expression)
+			if err != nil {
+				t.Fatalf("unexpected error compiling %q: %v", expression, err)
+			}
+			if _, err := e.Eval(vars); err == nil {
+				t.Errorf("expected an error evaluating %q", expression)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, expression := range []string{
+		``,
+		`object.`,
+		`object.spec.rules.all(1, true)`,
+		`object.name.matches("(")`,
+		`object.name.unknown()`,
+		`unknown(object)`,
+		`has(object)`,
+		`(true`,
+		`"unterminated`,
+		`object.name == 'a' 'b'`,
+		`object # 1`,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Compile(expression); err == nil {
+				t.Errorf("expected an error compiling %q", expression)
+			}
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	for size, expected := range map[string]int64{
+		"0":   0,
+		"100": 100,
+		"8k":  8 << 10,
+		"50m": 50 << 20,
+		"1G":  1 << 30,
+	} {
+		got, err := parseSize(size)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", size, err)
+		}
+		if got != expected {
+			t.Errorf("expected %v for %q but got %v", expected, size, got)
+		}
+	}
+
+	for _, size := range []string{"", "m", "-1k", "1t"} {
+		if _, err := parseSize(size); err == nil {
+			t.Errorf("expected an error parsing %q", size)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package adminauth protects the admin endpoints of the controller, like the
+// support bundle, with Kubernetes bearer tokens or client certificates.
+package adminauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// Values of the --admin-auth flag
+const (
+	// ModeNone exposes the admin endpoints without authentication in the healthz port,
+	// only to the requests from the loopback interface
+	ModeNone = "none"
+	// ModeToken requires a bearer token allowed to get the path of the endpoint
+	ModeToken = "token"
+	// ModeMTLS exposes the admin endpoints in a separate port requiring client certificates
+	ModeMTLS = "mtls"
+)
+
+// cacheTTL is the time an allowed token is not reviewed again
+const cacheTTL = time.Minute
+
+// reviewTimeout is the time to wait for the reviews of a token
+const reviewTimeout = 10 * time.Second
+
+// TokenAuthorizer authenticates bearer tokens with a TokenReview and authorizes
+// them with a SubjectAccessReview of the path of the request
+type TokenAuthorizer struct {
+	client kubernetes.Interface
+
+	mu sync.Mutex
+	// allowed contains the expiration of the allowed tokens, by hash, path and verb
+	allowed map[string]time.Time
+}
+
+// NewTokenAuthorizer returns a TokenAuthorizer using the given client
+func NewTokenAuthorizer(client kubernetes.Interface) *TokenAuthorizer {
+	return &TokenAuthorizer{
+		client:  client,
+		allowed: map[string]time.Time{},
+	}
+}
+
+// Wrap returns a handler calling h only for the requests with an allowed token
+func (a *TokenAuthorizer) Wrap(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="ingress-nginx"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		status, err := a.authorize(r.Context(), token, r.URL.Path, strings.ToLower(r.Method))
+		if err != nil {
+			klog.ErrorS(err, "Error reviewing the token of an admin request", "path", r.URL.Path)
+		}
+		if status == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="ingress-nginx", error="invalid_token"`)
+		}
+		if status != http.StatusOK {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// authorize returns the status code of a request with the token
+func (a *TokenAuthorizer) authorize(ctx context.Context, token, path, verb string) (int, error) {
+	key := fmt.Sprintf("%x %v %v", sha256.Sum256([]byte(token)), path, verb)
+
+	a.mu.Lock()
+	expiration, ok := a.allowed[key]
+	a.mu.Unlock()
+	if ok && time.Now().Before(expiration) {
+		return http.StatusOK, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reviewTimeout)
+	defer cancel()
+
+	tr, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !tr.Status.Authenticated {
+		return http.StatusUnauthorized, nil
+	}
+
+	user := tr.Status.User
+	extra := make(map[string]authzv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+
+	sar, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+			NonResourceAttributes: &authzv1.NonResourceAttributes{
+				Path: path,
+				Verb: verb,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !sar.Status.Allowed {
+		klog.V(2).InfoS("Admin request not allowed", "user", user.Username, "path", path, "verb", verb)
+		return http.StatusForbidden, nil
+	}
+
+	now := time.Now()
+	a.mu.Lock()
+	for k, exp := range a.allowed {
+		if now.After(exp) {
+			delete(a.allowed, k)
+		}
+	}
+	a.allowed[key] = now.Add(cacheTTL)
+	a.mu.Unlock()
+
+	return http.StatusOK, nil
+}
+
+// LocalOnly returns a handler calling h only for the requests from the loopback
+// interface, like the ones of the dbg tool run in the pod. The other requests
+// get a 404, as if the endpoints did not exist.
+func LocalOnly(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+			http.NotFound(w, r)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// bearerToken returns the token of the Authorization header of the request
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// NewMTLSServer returns a server for the handler listening in the given address,
+// which requires client certificates signed by the CA of the clientCAFile
+func NewMTLSServer(addr string, handler http.Handler, certFile, keyFile, clientCAFile string) (*http.Server, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading the certificate of the admin server: %w", err)
+	}
+
+	ca, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading the client CA of the admin server: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates found in the client CA file %v", clientCAFile)
+	}
+
+	return &http.Server{
+		Addr:    addr,
+		Handler: handler,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    pool,
+			MinVersion:   tls.VersionTLS12,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      300 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adminauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newFakeClient returns a client authenticating the token "valid" as the user
+// "admin", which is only allowed to get the path /support-bundle
+func newFakeClient(reviews *int) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		*reviews++
+		tr := action.(k8stesting.CreateAction).GetObject().(*authnv1.TokenReview)
+		if tr.Spec.Token == "valid" {
+			tr.Status.Authenticated = true
+			tr.Status.User = authnv1.UserInfo{Username: "admin"}
+		}
+		return true, tr, nil
+	})
+
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sar := action.(k8stesting.CreateAction).GetObject().(*authzv1.SubjectAccessReview)
+		attrs := sar.Spec.NonResourceAttributes
+		sar.Status.Allowed = sar.Spec.User == "admin" && attrs.Path == "/support-bundle" && attrs.Verb == "get"
+		return true, sar, nil
+	})
+
+	return client
+}
+
+func TestTokenAuthorizer(t *testing.T) {
+	reviews := 0
+	handler := NewTokenAuthorizer(newFakeClient(&reviews)).Wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		status        int
+	}{
+		{"without token", http.MethodGet, "/support-bundle", "", http.StatusUnauthorized},
+		{"basic authentication", http.MethodGet, "/support-bundle", "Basic YWRtaW46YWRtaW4=", http.StatusUnauthorized},
+		{"invalid token", http.MethodGet, "/support-bundle", "Bearer invalid", http.StatusUnauthorized},
+		{"allowed token", http.MethodGet, "/support-bundle", "Bearer valid", http.StatusOK},
+		{"path not allowed", http.MethodGet, "/autotune", "Bearer valid", http.StatusForbidden},
+		{"verb not allowed", http.MethodPost, "/support-bundle", "Bearer valid", http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, http.NoBody)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tc.status {
+				t.Errorf("expected status %v but got %v", tc.status, w.Code)
+			}
+			if tc.status == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Errorf("expected a WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestTokenAuthorizerCache(t *testing.T) {
+	reviews := 0
+	handler := NewTokenAuthorizer(newFakeClient(&reviews)).Wrap(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, path := range []string{"/support-bundle", "/support-bundle", "/autotune", "/autotune"} {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		req.Header.Set("Authorization", "Bearer valid")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// the denied requests are always reviewed
+	if reviews != 3 {
+		t.Errorf("expected 3 token reviews but got %v", reviews)
+	}
+}
+
+func TestLocalOnly(t *testing.T) {
+	handler := LocalOnly(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := map[string]int{
+		"127.0.0.1:1234": http.StatusOK,
+		"[::1]:1234":     http.StatusOK,
+		"10.0.0.1:1234":  http.StatusNotFound,
+		"invalid":        http.StatusNotFound,
+	}
+
+	for remoteAddr, expected := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/support-bundle", http.NoBody)
+		req.RemoteAddr = remoteAddr
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != expected {
+			t.Errorf("expected status %v from %v but got %v", expected, remoteAddr, w.Code)
+		}
+	}
+}
+
+func TestNewMTLSServer(t *testing.T) {
+	if _, err := NewMTLSServer(":0", http.NewServeMux(), "/nonexistent.crt", "/nonexistent.key", "/nonexistent.ca"); err == nil {
+		t.Errorf("expected an error loading a missing certificate")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package alias
 
 import (
+	"regexp"
 	"sort"
 	"strings"
 
@@ -24,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
 )
 
@@ -76,6 +78,14 @@ func (a alias) Parse(ing *networking.Ingress) (interface{}, error) {
 			continue
 		}
 
+		// regular expressions are matched by NGINX using PCRE, which accepts
+		// the syntax supported by the regexp package
+		if strings.HasPrefix(alias, "~") {
+			if _, err := regexp.Compile(alias[1:]); err != nil {
+				return []string{}, ing_errors.NewInvalidAnnotationContent(serverAliasAnnotation, alias)
+			}
+		}
+
 		if !aliases.Has(alias) {
 			aliases.Insert(alias)
 		}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allowedmethods
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	allowedMethodsAnnotation = "allowed-http-methods"
+)
+
+var (
+	methodRegex      = regexp.MustCompile(`^[A-Z][A-Z-]*$`)
+	methodsListRegex = regexp.MustCompile(`^[A-Z][A-Z-]*(,[A-Z][A-Z-]*)*$`)
+)
+
+var allowedMethodsAnnotations = parser.Annotation{
+	Group: "acl",
+	Annotations: parser.AnnotationFields{
+		allowedMethodsAnnotation: {
+			Validator: parser.ValidateRegex(methodsListRegex, true),
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation sets a comma separated list of HTTP methods, like GET,HEAD,POST, accepted by the location.
+			Requests using other methods are rejected with the allowed-http-methods-status-code of the ConfigMap, 405 by default. Methods are case sensitive.`,
+		},
+	},
+}
+
+// ValidMethod checks if the provided string is a valid HTTP method name
+func ValidMethod(method string) bool {
+	return methodRegex.MatchString(method)
+}
+
+type allowedMethods struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new allowed HTTP methods annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return allowedMethods{
+		r:                r,
+		annotationConfig: allowedMethodsAnnotations,
+	}
+}
+
+// Parse returns the HTTP methods accepted by the location, the ones of the
+// ConfigMap when the annotation is missing. An empty list allows any method.
+func (am allowedMethods) Parse(ing *networking.Ingress) (interface{}, error) {
+	defBackend := am.r.GetDefaultBackend()
+	defaultMethods := make([]string, len(defBackend.AllowedHTTPMethods))
+	copy(defaultMethods, defBackend.AllowedHTTPMethods)
+
+	val, err := parser.GetStringAnnotation(allowedMethodsAnnotation, ing, am.annotationConfig.Annotations)
+	if err != nil {
+		if err == errors.ErrMissingAnnotations {
+			return defaultMethods, nil
+		}
+		return defaultMethods, err
+	}
+
+	methods := []string{}
+	for _, method := range strings.Split(val, ",") {
+		method = strings.TrimSpace(method)
+		if !slices.Contains(methods, method) {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods, nil
+}
+
+func (am allowedMethods) GetDocumentation() parser.AnnotationFields {
+	return am.annotationConfig.Annotations
+}
+
+func (am allowedMethods) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(am.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, allowedMethodsAnnotations.Annotations)
+}
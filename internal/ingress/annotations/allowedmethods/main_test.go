@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allowedmethods
+
+import (
+	"reflect"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/defaults"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+type mockBackend struct {
+	resolver.Mock
+}
+
+// GetDefaultBackend returns the backend that must be used as default
+func (m mockBackend) GetDefaultBackend() defaults.Backend {
+	return defaults.Backend{
+		AllowedHTTPMethods: []string{"GET", "HEAD"},
+	}
+}
+
+func TestParseAnnotations(t *testing.T) {
+	ing := &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "foo",
+			Namespace: api.NamespaceDefault,
+		},
+	}
+
+	tests := []struct {
+		value     string
+		expected  []string
+		expectErr bool
+	}{
+		{"", []string{"GET", "HEAD"}, false},
+		{"GET,POST", []string{"GET", "POST"}, false},
+		{"GET, POST, GET", []string{"GET", "POST"}, false},
+		{"PROPFIND,VERSION-CONTROL", []string{"PROPFIND", "VERSION-CONTROL"}, false},
+		{"get,post", nil, true},
+		{"GET;POST", nil, true},
+		{"GET,", nil, true},
+	}
+
+	for _, test := range tests {
+		data := map[string]string{}
+		if test.value != "" {
+			data[parser.GetAnnotationWithPrefix(allowedMethodsAnnotation)] = test.value
+		}
+		ing.SetAnnotations(data)
+
+		val, err := NewParser(mockBackend{}).Parse(ing)
+		if (err != nil) != test.expectErr {
+			t.Errorf("%q: expected error %t but got %v", test.value, test.expectErr, err)
+		}
+		if test.expectErr {
+			continue
+		}
+		if !reflect.DeepEqual(val, test.expected) {
+			t.Errorf("%q: expected %v but got %v", test.value, test.expected, val)
+		}
+	}
+}
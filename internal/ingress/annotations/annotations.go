@@ -25,21 +25,31 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/alias"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/allowedmethods"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/auth"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/authldap"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/authreq"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/authreqglobal"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/authtls"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/backendprotocol"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/canary"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/clientbodybuffersize"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/compression"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/conflictpriority"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/connection"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/cors"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/customheaders"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/customhttperrors"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/defaultbackend"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/disableproxyintercepterrors"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/extralistenports"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/fastcgi"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/headerlimits"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/headersfromvariables"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/http2"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/http2pushpreload"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/httptransform"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/internallocations"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/ipallowlist"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/ipdenylist"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/loadbalancing"
@@ -50,10 +60,14 @@ import (
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/portinredirect"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/proxy"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/proxyintercepterrors"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/proxyssl"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/ranges"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/ratelimit"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/realip"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/redirect"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/rewrite"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/routeif"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/satisfy"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/serversnippet"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/serviceupstream"
@@ -62,11 +76,15 @@ import (
 	"k8s.io/ingress-nginx/internal/ingress/annotations/sslcipher"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/sslpassthrough"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/streamsnippet"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/subfilter"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/uploadguard"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/upstreamhashby"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/upstreamvhost"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/xforwardedprefix"
+	"k8s.io/ingress-nginx/internal/ingress/deprecation"
 	"k8s.io/ingress-nginx/internal/ingress/errors"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
+	"k8s.io/ingress-nginx/pkg/util/file"
 )
 
 // DeniedKeyName name of the key that contains the reason to deny a location
@@ -77,18 +95,28 @@ type Ingress struct {
 	metav1.ObjectMeta
 	BackendProtocol             string
 	Aliases                     []string
+	AuthLDAP                    authldap.Config
 	BasicDigestAuth             auth.Config
 	Canary                      canary.Config
 	CertificateAuth             authtls.Config
 	ClientBodyBufferSize        string
+	Compression                 compression.Config
+	ConflictPriority            int
 	CustomHeaders               customheaders.Config
 	ConfigurationSnippet        string
 	Connection                  connection.Config
 	CorsConfig                  cors.Config
 	CustomHTTPErrors            []int
+	CustomErrorsFallback        *apiv1.Service
 	DisableProxyInterceptErrors bool
 	DefaultBackend              *apiv1.Service
+	ExtraListenPorts            extralistenports.Config
 	FastCGI                     fastcgi.Config
+	HeaderLimits                headerlimits.Config
+	HeadersFromVariables        headersfromvariables.Config
+	HTTP2                       http2.Config
+	HTTPTransform               httptransform.Config
+	InternalLocations           internallocations.Config
 	Denied                      *string
 	ExternalAuth                authreq.Config
 	EnableGlobalAuth            bool
@@ -96,9 +124,14 @@ type Ingress struct {
 	Opentelemetry               opentelemetry.Config
 	Proxy                       proxy.Config
 	ProxySSL                    proxyssl.Config
+	ProxyInterceptErrors        string
+	Ranges                      ranges.Config
 	RateLimit                   ratelimit.Config
+	UploadGuard                 uploadguard.Config
+	RealIP                      realip.Config
 	Redirect                    redirect.Config
 	Rewrite                     rewrite.Config
+	RouteIf                     routeif.Config
 	Satisfy                     string
 	ServerSnippet               string
 	ServiceUpstream             bool
@@ -114,8 +147,12 @@ type Ingress struct {
 	Logs                        log.Config
 	ModSecurity                 modsecurity.Config
 	Mirror                      mirror.Config
+	SubFilter                   subfilter.Config
 	StreamSnippet               string
 	Allowlist                   ipallowlist.SourceRange
+	AllowedHTTPMethods          []string
+	// Deprecated contains the deprecated annotations of the Ingress
+	Deprecated []deprecation.Usage
 }
 
 // Extractor defines the annotation parsers to be used in the extraction of annotations
@@ -123,30 +160,50 @@ type Extractor struct {
 	annotations map[string]parser.IngressAnnotation
 }
 
+// NewAnnotationFactory creates the annotation parsers of the controller
 func NewAnnotationFactory(cfg resolver.Resolver) map[string]parser.IngressAnnotation {
+	return newAnnotationFactory(cfg, file.AuthDirectory)
+}
+
+func newAnnotationFactory(cfg resolver.Resolver, authDirectory string) map[string]parser.IngressAnnotation {
 	return map[string]parser.IngressAnnotation{
 		"Aliases":                     alias.NewParser(cfg),
-		"BasicDigestAuth":             auth.NewParser(auth.AuthDirectory, cfg),
+		"AuthLDAP":                    authldap.NewParser(cfg),
+		"BasicDigestAuth":             auth.NewParser(authDirectory, cfg),
 		"Canary":                      canary.NewParser(cfg),
 		"CertificateAuth":             authtls.NewParser(cfg),
 		"ClientBodyBufferSize":        clientbodybuffersize.NewParser(cfg),
+		"Compression":                 compression.NewParser(cfg),
+		"ConflictPriority":            conflictpriority.NewParser(cfg),
 		"CustomHeaders":               customheaders.NewParser(cfg),
 		"ConfigurationSnippet":        snippet.NewParser(cfg),
 		"Connection":                  connection.NewParser(cfg),
 		"CorsConfig":                  cors.NewParser(cfg),
 		"CustomHTTPErrors":            customhttperrors.NewParser(cfg),
+		"CustomErrorsFallback":        defaultbackend.NewFallbackParser(cfg),
 		"DisableProxyInterceptErrors": disableproxyintercepterrors.NewParser(cfg),
 		"DefaultBackend":              defaultbackend.NewParser(cfg),
+		"ExtraListenPorts":            extralistenports.NewParser(cfg),
 		"FastCGI":                     fastcgi.NewParser(cfg),
+		"HeaderLimits":                headerlimits.NewParser(cfg),
+		"HeadersFromVariables":        headersfromvariables.NewParser(cfg),
+		"HTTP2":                       http2.NewParser(cfg),
+		"HTTPTransform":               httptransform.NewParser(cfg),
+		"InternalLocations":           internallocations.NewParser(cfg),
 		"ExternalAuth":                authreq.NewParser(cfg),
 		"EnableGlobalAuth":            authreqglobal.NewParser(cfg),
 		"HTTP2PushPreload":            http2pushpreload.NewParser(cfg),
 		"Opentelemetry":               opentelemetry.NewParser(cfg),
 		"Proxy":                       proxy.NewParser(cfg),
+		"ProxyInterceptErrors":        proxyintercepterrors.NewParser(cfg),
 		"ProxySSL":                    proxyssl.NewParser(cfg),
+		"Ranges":                      ranges.NewParser(cfg),
 		"RateLimit":                   ratelimit.NewParser(cfg),
+		"UploadGuard":                 uploadguard.NewParser(cfg),
+		"RealIP":                      realip.NewParser(cfg),
 		"Redirect":                    redirect.NewParser(cfg),
 		"Rewrite":                     rewrite.NewParser(cfg),
+		"RouteIf":                     routeif.NewParser(cfg),
 		"Satisfy":                     satisfy.NewParser(cfg),
 		"ServerSnippet":               serversnippet.NewParser(cfg),
 		"ServiceUpstream":             serviceupstream.NewParser(cfg),
@@ -158,27 +215,53 @@ func NewAnnotationFactory(cfg resolver.Resolver) map[string]parser.IngressAnnota
 		"UpstreamVhost":               upstreamvhost.NewParser(cfg),
 		"Allowlist":                   ipallowlist.NewParser(cfg),
 		"Denylist":                    ipdenylist.NewParser(cfg),
+		"AllowedHTTPMethods":          allowedmethods.NewParser(cfg),
 		"XForwardedPrefix":            xforwardedprefix.NewParser(cfg),
 		"SSLCipher":                   sslcipher.NewParser(cfg),
 		"Logs":                        log.NewParser(cfg),
 		"BackendProtocol":             backendprotocol.NewParser(cfg),
 		"ModSecurity":                 modsecurity.NewParser(cfg),
 		"Mirror":                      mirror.NewParser(cfg),
+		"SubFilter":                   subfilter.NewParser(cfg),
 		"StreamSnippet":               streamsnippet.NewParser(cfg),
 	}
 }
 
 // NewAnnotationExtractor creates a new annotations extractor
 func NewAnnotationExtractor(cfg resolver.Resolver) Extractor {
+	return NewExtractor(cfg, file.AuthDirectory)
+}
+
+// NewExtractor creates an annotations extractor writing the files of the basic
+// authentication to authDirectory instead of the directory of the controller
+func NewExtractor(cfg resolver.Resolver, authDirectory string) Extractor {
 	return Extractor{
-		NewAnnotationFactory(cfg),
+		newAnnotationFactory(cfg, authDirectory),
 	}
 }
 
+// Documentation returns the annotations, without prefix, of every parser
+func (e Extractor) Documentation() map[string]parser.AnnotationFields {
+	docs := make(map[string]parser.AnnotationFields, len(e.annotations))
+	for name, annotationParser := range e.annotations {
+		docs[name] = annotationParser.GetDocumentation()
+	}
+
+	return docs
+}
+
 // Extract extracts the annotations from an Ingress
 func (e Extractor) Extract(ing *networking.Ingress) (*Ingress, error) {
+	// the deprecated annotations are parsed with the name of their replacement
+	anns, deprecated := deprecation.TranslateAnnotations(ing.GetAnnotations(), parser.AnnotationsPrefix)
+	if len(deprecated) > 0 {
+		ing = ing.DeepCopy()
+		ing.SetAnnotations(anns)
+	}
+
 	pia := &Ingress{
 		ObjectMeta: ing.ObjectMeta,
+		Deprecated: deprecated,
 	}
 
 	data := make(map[string]interface{})
@@ -232,3 +315,29 @@ func (e Extractor) Extract(ing *networking.Ingress) (*Ingress, error) {
 
 	return pia, nil
 }
+
+const (
+	// SourceAnnotation is the source of the settings set by an annotation of the Ingress
+	SourceAnnotation = "annotation"
+	// SourceDefault is the source of the settings using the defaults of the ConfigMap
+	SourceDefault = "default"
+)
+
+// Sources returns for every setting of the parsed annotations if it is set by
+// an annotation of the Ingress or uses the defaults of the ConfigMap
+func (e Extractor) Sources(ing *networking.Ingress) map[string]string {
+	anns, _ := deprecation.TranslateAnnotations(ing.GetAnnotations(), parser.AnnotationsPrefix)
+
+	sources := make(map[string]string, len(e.annotations))
+	for name, annotationParser := range e.annotations {
+		sources[name] = SourceDefault
+		for annotation := range annotationParser.GetDocumentation() {
+			if _, ok := anns[parser.GetAnnotationWithPrefix(annotation)]; ok {
+				sources[name] = SourceAnnotation
+				break
+			}
+		}
+	}
+
+	return sources
+}
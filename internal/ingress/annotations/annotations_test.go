@@ -366,3 +366,33 @@ func TestCustomResponseHeaders(t *testing.T) {
 		}
 	}
 }
+
+func TestSources(t *testing.T) {
+	ec := NewAnnotationExtractor(mockCfg{})
+	ing := buildIngress()
+	ing.SetAnnotations(map[string]string{
+		annotationCorsEnabled:      "true",
+		annotationUpstreamHashBy:   "$request_uri",
+		"example.com/unrelated":    "true",
+		annotationAffinityType:     "cookie",
+		annotationCorsAllowMethods: "GET",
+	})
+
+	sources := ec.Sources(ing)
+
+	expected := map[string]string{
+		"CorsConfig":      SourceAnnotation,
+		"UpstreamHashBy":  SourceAnnotation,
+		"SessionAffinity": SourceAnnotation,
+		"Proxy":           SourceDefault,
+		"RateLimit":       SourceDefault,
+	}
+	for name, source := range expected {
+		if sources[name] != source {
+			t.Errorf("expected source %v of %v but got %v", source, name, sources[name])
+		}
+	}
+	if len(sources) != len(ec.annotations) {
+		t.Errorf("expected a source for the %v parsers but got %v", len(ec.annotations), len(sources))
+	}
+}
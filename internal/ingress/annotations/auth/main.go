@@ -17,9 +17,11 @@ limitations under the License.
 package auth
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	api "k8s.io/api/core/v1"
@@ -28,6 +30,7 @@ import (
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/referencegrant"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
 	"k8s.io/ingress-nginx/pkg/util/file"
 )
@@ -43,17 +46,20 @@ const (
 var (
 	authTypeRegex       = regexp.MustCompile(`basic|digest`)
 	authSecretTypeRegex = regexp.MustCompile(`auth-file|auth-map`)
+	authSecretRegex     = regexp.MustCompile(`^[\-\.\_\~a-zA-Z0-9\/:]+(,[\-\.\_\~a-zA-Z0-9\/:]+)*$`)
 
-	// AuthDirectory default directory used to store files
-	// to authenticate request
-	AuthDirectory = "/etc/ingress-controller/auth"
+	// passwordHashRegex matches the password hashes supported by NGINX: the
+	// crypt() schemes (DES, MD5, SHA-256, SHA-512 and bcrypt), apr1, {PLAIN},
+	// {SHA} and {SSHA}
+	passwordHashRegex = regexp.MustCompile(`^(\$(1|5|6)\$[^:]+|\$2[aby]\$\d{2}\$[./A-Za-z0-9]{53}|\$apr1\$[^:]+|\{PLAIN\}.*|\{SHA\}[A-Za-z0-9+/=]+|\{SSHA\}[A-Za-z0-9+/=]+|[./A-Za-z0-9]{13})$`)
 )
 
 var AuthSecretConfig = parser.AnnotationConfig{
-	Validator:     parser.ValidateRegex(parser.BasicCharsRegex, true),
-	Scope:         parser.AnnotationScopeLocation,
-	Risk:          parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
-	Documentation: `This annotation defines the name of the Secret that contains the usernames and passwords which are granted access to the paths defined in the Ingress rules. `,
+	Validator: parser.ValidateRegex(authSecretRegex, true),
+	Scope:     parser.AnnotationScopeLocation,
+	Risk:      parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
+	Documentation: `This annotation defines the name of the Secret that contains the usernames and passwords which are granted access to the paths defined in the Ingress rules.
+	A comma separated list of Secrets can be used to merge the usernames of all of them.`,
 }
 
 var authSecretAnnotations = parser.Annotation{
@@ -118,9 +124,8 @@ func (bd1 *Config) Equal(bd2 *Config) bool {
 	if bd1.Secured != bd2.Secured {
 		return false
 	}
-	if bd1.FileSHA != bd2.FileSHA {
-		return false
-	}
+	// FileSHA is not compared, as NGINX reads the content of the file
+	// in each request and a change does not require a reload
 	if bd1.Secret != bd2.Secret {
 		return false
 	}
@@ -172,53 +177,76 @@ func (a auth) Parse(ing *networking.Ingress) (interface{}, error) {
 		}
 	}
 
-	sns, sname, err := cache.SplitMetaNamespaceKey(s)
-	if err != nil {
-		return nil, ing_errors.LocationDeniedError{
-			Reason: fmt.Errorf("error reading secret name from annotation: %w", err),
-		}
-	}
-
-	if sns == "" {
-		sns = ing.Namespace
-	}
-	secCfg := a.r.GetSecurityConfiguration()
-	// We don't accept different namespaces for secrets.
-	if !secCfg.AllowCrossNamespaceResources && sns != ing.Namespace {
-		return nil, ing_errors.LocationDeniedError{
-			Reason: fmt.Errorf("cross namespace usage of secrets is not allowed"),
-		}
-	}
-
-	name := fmt.Sprintf("%v/%v", sns, sname)
-	secret, err := a.r.GetSecret(name)
-	if err != nil {
-		return nil, ing_errors.LocationDeniedError{
-			Reason: fmt.Errorf("unexpected error reading secret %s: %w", name, err),
-		}
-	}
-
 	realm, err := parser.GetStringAnnotation(authRealmAnnotation, ing, a.annotationConfig.Annotations)
 	if ing_errors.IsValidationError(err) {
 		return nil, err
 	}
 
-	passFilename := fmt.Sprintf("%v/%v-%v-%v.passwd", a.authDirectory, ing.GetNamespace(), ing.UID, secret.UID)
+	secCfg := a.r.GetSecurityConfiguration()
 
-	switch secretType {
-	case fileAuth:
-		err = dumpSecretAuthFile(passFilename, secret)
+	names := []string{}
+	uids := []string{}
+	contents := [][]byte{}
+	for _, ref := range strings.Split(s, ",") {
+		sns, sname, err := cache.SplitMetaNamespaceKey(ref)
 		if err != nil {
-			return nil, err
+			return nil, ing_errors.LocationDeniedError{
+				Reason: fmt.Errorf("error reading secret name from annotation: %w", err),
+			}
+		}
+
+		if sns == "" {
+			sns = ing.Namespace
 		}
-	case mapAuth:
-		err = dumpSecretAuthMap(passFilename, secret)
+		// We don't accept different namespaces for secrets, unless a ReferenceGrant allows it.
+		if !secCfg.AllowCrossNamespaceResources && sns != ing.Namespace &&
+			!a.r.IsReferenceGranted(ing.Namespace, referencegrant.KindSecret, sns, sname) {
+			return nil, ing_errors.LocationDeniedError{
+				Reason: fmt.Errorf("cross namespace usage of secrets is not allowed"),
+			}
+		}
+
+		name := fmt.Sprintf("%v/%v", sns, sname)
+		secret, err := a.r.GetSecret(name)
 		if err != nil {
-			return nil, err
+			return nil, ing_errors.LocationDeniedError{
+				Reason: fmt.Errorf("unexpected error reading secret %s: %w", name, err),
+			}
+		}
+
+		var content []byte
+		switch secretType {
+		case fileAuth:
+			content, err = secretAuthFile(secret)
+			if err != nil {
+				return nil, err
+			}
+		case mapAuth:
+			content = secretAuthMap(secret)
+		default:
+			return nil, ing_errors.LocationDeniedError{
+				Reason: fmt.Errorf("invalid auth-secret-type in annotation, must be 'auth-file' or 'auth-map': %w", err),
+			}
+		}
+
+		names = append(names, name)
+		uids = append(uids, string(secret.UID))
+		contents = append(contents, content)
+	}
+
+	content := mergeAuthFiles(contents)
+	if at == "basic" {
+		if err := checkPasswordHashes(content); err != nil {
+			return nil, ing_errors.LocationDeniedError{Reason: err}
 		}
-	default:
+	}
+
+	// NGINX reads the file in each request, so the content can be updated without a reload
+	passFilename := fmt.Sprintf("%v/%v-%v-%v.passwd", a.authDirectory, ing.GetNamespace(), ing.UID, strings.Join(uids, "-"))
+	err = os.WriteFile(passFilename, content, file.ReadWriteByUser)
+	if err != nil {
 		return nil, ing_errors.LocationDeniedError{
-			Reason: fmt.Errorf("invalid auth-secret-type in annotation, must be 'auth-file' or 'auth-map': %w", err),
+			Reason: fmt.Errorf("unexpected error creating password file: %w", err),
 		}
 	}
 
@@ -228,44 +256,91 @@ func (a auth) Parse(ing *networking.Ingress) (interface{}, error) {
 		File:       passFilename,
 		Secured:    true,
 		FileSHA:    file.SHA1(passFilename),
-		Secret:     name,
+		Secret:     strings.Join(names, ","),
 		SecretType: secretType,
 	}, nil
 }
 
-// dumpSecret dumps the content of a secret into a file
-// in the expected format for the specified authorization
-func dumpSecretAuthFile(filename string, secret *api.Secret) error {
+// secretAuthFile returns the htpasswd file contained in the key auth of a secret
+func secretAuthFile(secret *api.Secret) ([]byte, error) {
 	val, ok := secret.Data["auth"]
 	if !ok {
-		return ing_errors.LocationDeniedError{
+		return nil, ing_errors.LocationDeniedError{
 			Reason: fmt.Errorf("the secret %s does not contain a key with value auth", secret.Name),
 		}
 	}
 
-	err := os.WriteFile(filename, val, file.ReadWriteByUser)
-	if err != nil {
-		return ing_errors.LocationDeniedError{
-			Reason: fmt.Errorf("unexpected error creating password file: %w", err),
-		}
-	}
-
-	return nil
+	return val, nil
 }
 
-func dumpSecretAuthMap(filename string, secret *api.Secret) error {
-	builder := &strings.Builder{}
-	for user, pass := range secret.Data {
+// secretAuthMap returns an htpasswd file with a line for each key of a secret,
+// where the key is the user and the value the password
+func secretAuthMap(secret *api.Secret) []byte {
+	users := make([]string, 0, len(secret.Data))
+	for user := range secret.Data {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+
+	builder := &bytes.Buffer{}
+	for _, user := range users {
 		builder.WriteString(user)
 		builder.WriteString(":")
-		builder.Write(pass)
+		builder.Write(secret.Data[user])
 		builder.WriteString("\n")
 	}
 
-	err := os.WriteFile(filename, []byte(builder.String()), file.ReadWriteByUser)
-	if err != nil {
-		return ing_errors.LocationDeniedError{
-			Reason: fmt.Errorf("unexpected error creating password file: %w", err),
+	return builder.Bytes()
+}
+
+// mergeAuthFiles merges htpasswd files. When a user is defined in several
+// files, the password of the first file is used.
+func mergeAuthFiles(contents [][]byte) []byte {
+	if len(contents) == 1 {
+		return contents[0]
+	}
+
+	users := map[string]bool{}
+	merged := &bytes.Buffer{}
+	for _, content := range contents {
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			user, _, _ := strings.Cut(line, ":")
+			if users[user] {
+				continue
+			}
+
+			users[user] = true
+			merged.WriteString(line)
+			merged.WriteString("\n")
+		}
+	}
+
+	return merged.Bytes()
+}
+
+// checkPasswordHashes returns an error if the password of a user
+// of an htpasswd file uses a hash not supported by NGINX
+func checkPasswordHashes(content []byte) error {
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		user, hash, found := strings.Cut(line, ":")
+		if !found {
+			return fmt.Errorf("invalid htpasswd line for user %q", user)
+		}
+
+		// an optional comment can follow the password
+		hash, _, _ = strings.Cut(hash, ":")
+		if !passwordHashRegex.MatchString(hash) {
+			return fmt.Errorf("unsupported password hash for user %q, use bcrypt, SHA-512, SHA-256, MD5 or apr1", user)
 		}
 	}
 
@@ -317,31 +317,107 @@ func dummySecretContent(t *testing.T) (fileName, dir string, s *api.Secret) {
 	return tmpfile.Name(), dir, s
 }
 
-func TestDumpSecretAuthFile(t *testing.T) {
-	tmpfile, dir, s := dummySecretContent(t)
+func TestSecretAuthFile(t *testing.T) {
+	_, dir, s := dummySecretContent(t)
 	defer os.RemoveAll(dir)
 
 	sd := s.Data
 	s.Data = nil
 
-	err := dumpSecretAuthFile(tmpfile, s)
+	_, err := secretAuthFile(s)
 	if err == nil {
 		t.Errorf("Expected error with secret without auth")
 	}
 
 	s.Data = sd
-	err = dumpSecretAuthFile(tmpfile, s)
+	content, err := secretAuthFile(s)
 	if err != nil {
-		t.Errorf("Unexpected error creating htpasswd file %v: %v", tmpfile, err)
+		t.Errorf("Unexpected error reading htpasswd file: %v", err)
+	}
+	if string(content) != string(sd["auth"]) {
+		t.Errorf("Expected %q but returned %q", sd["auth"], content)
+	}
+}
+
+func TestSecretAuthMap(t *testing.T) {
+	s := &api.Secret{
+		Data: map[string][]byte{
+			"foo": []byte("$apr1$OFG3Xybp$ckL0FHDAkoXYIlH9.cysT0"),
+			"bar": []byte("{PLAIN}bar"),
+		},
+	}
+
+	expected := "bar:{PLAIN}bar\nfoo:$apr1$OFG3Xybp$ckL0FHDAkoXYIlH9.cysT0\n"
+	if content := string(secretAuthMap(s)); content != expected {
+		t.Errorf("Expected %q but returned %q", expected, content)
 	}
 }
 
-func TestDumpSecretAuthMap(t *testing.T) {
-	tmpfile, dir, s := dummySecretContent(t)
+func TestMergeAuthFiles(t *testing.T) {
+	merged := mergeAuthFiles([][]byte{
+		[]byte("# team a\nfoo:{PLAIN}a\nbar:{PLAIN}a\n"),
+		[]byte("foo:{PLAIN}b\nbaz:{PLAIN}b"),
+	})
+
+	expected := "foo:{PLAIN}a\nbar:{PLAIN}a\nbaz:{PLAIN}b\n"
+	if string(merged) != expected {
+		t.Errorf("Expected %q but returned %q", expected, merged)
+	}
+}
+
+func TestCheckPasswordHashes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		content   string
+		expectErr bool
+	}{
+		{"apr1", "foo:$apr1$OFG3Xybp$ckL0FHDAkoXYIlH9.cysT0", false},
+		{"bcrypt", "foo:$2y$05$5mNiYgFf2ZK1WrKW9PKSkeHOUfRpBeRcp1xyTUn7RMh/VGEAbiZOC", false},
+		{"SHA-512", "foo:$6$rounds=5000$saltsalt$ZnHV8ULGtaAzu2XcLcfWBp84v0Ov2H5gH6FvFRIGiKqr1khH3/K4apTEZbNPddHr3zh7lZr34tNqRIWbVsVoW/", false},
+		{"SHA-1", "foo:{SHA}C+7Hteo/D9vJXQ3UfzxbwnXaijM=", false},
+		{"plain text", "foo:{PLAIN}bar", false},
+		{"comments and empty lines", "# users\n\nfoo:{PLAIN}bar\n", false},
+		{"password without hash", "foo:bar", true},
+		{"user without password", "foo", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkPasswordHashes([]byte(tc.content))
+			if tc.expectErr && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestIngressAuthMultipleSecrets(t *testing.T) {
+	ing := buildIngress()
+
+	data := map[string]string{}
+	data[parser.GetAnnotationWithPrefix(authTypeAnnotation)] = authType
+	data[parser.GetAnnotationWithPrefix(AuthSecretAnnotation)] = fmt.Sprintf("%v,%v", demoSecret, othernsDemoSecret)
+	data[parser.GetAnnotationWithPrefix(authRealmAnnotation)] = authRealm
+	ing.SetAnnotations(data)
+
+	_, dir, _ := dummySecretContent(t)
 	defer os.RemoveAll(dir)
 
-	err := dumpSecretAuthMap(tmpfile, s)
+	i, err := NewParser(dir, &mockSecret{
+		Mock: resolver.Mock{AllowCrossNamespace: true},
+	}).Parse(ing)
 	if err != nil {
-		t.Errorf("Unexpected error creating htpasswd file %v: %v", tmpfile, err)
+		t.Fatalf("Unexpected error with ingress: %v", err)
+	}
+
+	auth, ok := i.(*Config)
+	if !ok {
+		t.Fatalf("expected a BasicDigest type")
+	}
+	if auth.Secret != fmt.Sprintf("%v,%v", defaultDemoSecret, othernsDemoSecret) {
+		t.Errorf("Expected both secrets but returned %s", auth.Secret)
 	}
 }
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authldap
+
+import (
+	"fmt"
+
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/authreq"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/ldapauth"
+	"k8s.io/ingress-nginx/internal/ingress/referencegrant"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	authLDAPSecretAnnotation = "auth-ldap-secret" //#nosec G101
+	authRealmAnnotation      = "auth-realm"
+)
+
+var authLDAPAnnotations = parser.Annotation{
+	Group: "authentication",
+	Annotations: parser.AnnotationFields{
+		authLDAPSecretAnnotation: {
+			Validator: parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
+			Documentation: `This annotation defines the name of the Secret with the LDAP server used to validate the credentials of the requests.
+			The Secret contains the keys "url", "bind-dn" and optionally "ca.crt".`,
+		},
+		authRealmAnnotation: {
+			Validator:     parser.ValidateRegex(parser.CharsWithSpace, false),
+			Scope:         parser.AnnotationScopeLocation,
+			Risk:          parser.AnnotationRiskMedium, // Medium as it allows a subset of chars
+			Documentation: `This annotation defines the realm (message) that should be shown to user when authentication is requested.`,
+		},
+	},
+}
+
+// Config returns the LDAP authentication configuration for an Ingress rule
+type Config struct {
+	// Secret with the settings of the LDAP server
+	Secret string `json:"secret"`
+	Realm  string `json:"realm"`
+	// Ingress is the namespace and name of the Ingress, which the controller
+	// uses to find the Secret when NGINX validates the credentials
+	Ingress string `json:"ingress"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+	if c1.Secret != c2.Secret {
+		return false
+	}
+	if c1.Realm != c2.Realm {
+		return false
+	}
+	if c1.Ingress != c2.Ingress {
+		return false
+	}
+
+	return true
+}
+
+// ExternalAuth returns the external authentication validating the
+// credentials against the LDAP server using the controller
+func (c *Config) ExternalAuth() authreq.Config {
+	return authreq.Config{
+		URL:  ldapauth.AuthURL(c.Ingress, c.Realm),
+		Host: ldapauth.Host(),
+	}
+}
+
+type authLDAP struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new LDAP authentication annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return authLDAP{
+		r:                r,
+		annotationConfig: authLDAPAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress
+// rule used to validate the credentials against an LDAP server
+func (a authLDAP) Parse(ing *networking.Ingress) (interface{}, error) {
+	s, err := parser.GetStringAnnotation(authLDAPSecretAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	sns, sname, err := cache.SplitMetaNamespaceKey(s)
+	if err != nil {
+		return nil, ing_errors.LocationDeniedError{
+			Reason: fmt.Errorf("error reading secret name from annotation: %w", err),
+		}
+	}
+
+	if sns == "" {
+		sns = ing.Namespace
+	}
+	// We don't accept different namespaces for secrets, unless a ReferenceGrant allows it.
+	if !a.r.GetSecurityConfiguration().AllowCrossNamespaceResources && sns != ing.Namespace &&
+		!a.r.IsReferenceGranted(ing.Namespace, referencegrant.KindSecret, sns, sname) {
+		return nil, ing_errors.LocationDeniedError{
+			Reason: fmt.Errorf("cross namespace usage of secrets is not allowed"),
+		}
+	}
+
+	name := fmt.Sprintf("%v/%v", sns, sname)
+	secret, err := a.r.GetSecret(name)
+	if err != nil {
+		return nil, ing_errors.LocationDeniedError{
+			Reason: fmt.Errorf("unexpected error reading secret %s: %w", name, err),
+		}
+	}
+
+	if _, err := ldapauth.ConfigFromSecret(secret); err != nil {
+		return nil, ing_errors.LocationDeniedError{Reason: err}
+	}
+
+	realm, err := parser.GetStringAnnotation(authRealmAnnotation, ing, a.annotationConfig.Annotations)
+	if ing_errors.IsValidationError(err) {
+		return nil, err
+	}
+
+	return &Config{
+		Secret:  name,
+		Realm:   realm,
+		Ingress: fmt.Sprintf("%v/%v", ing.Namespace, ing.Name),
+	}, nil
+}
+
+func (a authLDAP) GetDocumentation() parser.AnnotationFields {
+	return a.annotationConfig.Annotations
+}
+
+func (a authLDAP) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(a.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, authLDAPAnnotations.Annotations)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authldap
+
+import (
+	"fmt"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/ldapauth"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+type mockSecret struct {
+	resolver.Mock
+}
+
+func (m mockSecret) GetSecret(name string) (*api.Secret, error) {
+	switch name {
+	case "default/ldap", "otherns/ldap":
+		return &api.Secret{
+			Data: map[string][]byte{
+				"url":     []byte("ldaps://ldap.example.org"),
+				"bind-dn": []byte("uid={username},ou=people,dc=example,dc=org"),
+			},
+		}, nil
+	case "default/invalid":
+		return &api.Secret{
+			Data: map[string][]byte{"url": []byte("ldaps://ldap.example.org")},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("there is no secret with name %v", name)
+}
+
+func buildIngress(annotations map[string]string) *networking.Ingress {
+	data := map[string]string{}
+	for k, v := range annotations {
+		data[parser.GetAnnotationWithPrefix(k)] = v
+	}
+
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   api.NamespaceDefault,
+			Annotations: data,
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		expectErr   bool
+	}{
+		{"secret", map[string]string{authLDAPSecretAnnotation: "ldap", authRealmAnnotation: "LDAP realm"}, &Config{Secret: "default/ldap", Realm: "LDAP realm", Ingress: "default/foo"}, false},
+		{"secret with namespace", map[string]string{authLDAPSecretAnnotation: "default/ldap"}, &Config{Secret: "default/ldap", Ingress: "default/foo"}, false},
+		{"secret of another namespace", map[string]string{authLDAPSecretAnnotation: "otherns/ldap"}, nil, true},
+		{"secret not found", map[string]string{authLDAPSecretAnnotation: "other"}, nil, true},
+		{"secret without bind-dn", map[string]string{authLDAPSecretAnnotation: "invalid"}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			i, err := NewParser(mockSecret{}).Parse(buildIngress(tc.annotations))
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			cfg, ok := i.(*Config)
+			if !ok {
+				t.Fatalf("expected a Config type")
+			}
+			if !cfg.Equal(tc.expected) {
+				t.Errorf("expected %+v but returned %+v", tc.expected, cfg)
+			}
+		})
+	}
+}
+
+func TestParseMissingAnnotation(t *testing.T) {
+	_, err := NewParser(mockSecret{}).Parse(buildIngress(map[string]string{}))
+	if !ing_errors.IsMissingAnnotations(err) {
+		t.Errorf("expected a missing annotation error but returned %v", err)
+	}
+}
+
+func TestExternalAuth(t *testing.T) {
+	cfg := &Config{Secret: "default/ldap", Ingress: "default/foo"}
+	auth := cfg.ExternalAuth()
+	if auth.URL != ldapauth.AuthURL("default/foo", "") || auth.Host != "127.0.0.1" {
+		t.Errorf("unexpected external authentication %+v", auth)
+	}
+}
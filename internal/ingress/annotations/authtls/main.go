@@ -24,6 +24,7 @@ import (
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/referencegrant"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
 	"k8s.io/ingress-nginx/internal/k8s"
 )
@@ -38,6 +39,7 @@ const (
 	annotationAuthTLSErrorPage          = "auth-tls-error-page"
 	annotationAuthTLSPassCertToUpstream = "auth-tls-pass-certificate-to-upstream" //#nosec G101
 	annotationAuthTLSMatchCN            = "auth-tls-match-cn"
+	annotationAuthTLSMatchSubject       = "auth-tls-match-subject"
 )
 
 var (
@@ -84,6 +86,13 @@ var authTLSAnnotations = parser.Annotation{
 			Risk:          parser.AnnotationRiskHigh,
 			Documentation: `This annotation adds a sanity check for the CN of the client certificate that is sent over using a string / regex starting with "CN="`,
 		},
+		annotationAuthTLSMatchSubject: {
+			Validator: validateMatchSubject,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskHigh,
+			Documentation: `This annotation defines a regex the subject of the client certificate, like "CN=client,OU=payments,O=Example", must match.
+			Requests with a certificate not matching the regex are rejected with a 403 status code.`,
+		},
 	},
 }
 
@@ -96,6 +105,7 @@ type Config struct {
 	ErrorPage          string `json:"errorPage"`
 	PassCertToUpstream bool   `json:"passCertToUpstream"`
 	MatchCN            string `json:"matchCN"`
+	MatchSubject       string `json:"matchSubject,omitempty"`
 	AuthTLSError       string
 }
 
@@ -125,6 +135,9 @@ func (assl1 *Config) Equal(assl2 *Config) bool {
 	if assl1.MatchCN != assl2.MatchCN {
 		return false
 	}
+	if assl1.MatchSubject != assl2.MatchSubject {
+		return false
+	}
 
 	return true
 }
@@ -153,7 +166,7 @@ func (a authTLS) Parse(ing *networking.Ingress) (interface{}, error) {
 		return &Config{}, err
 	}
 
-	ns, _, err := k8s.ParseNameNS(tlsauthsecret)
+	ns, secretName, err := k8s.ParseNameNS(tlsauthsecret)
 	if err != nil {
 		return &Config{}, ing_errors.NewLocationDenied(err.Error())
 	}
@@ -161,8 +174,9 @@ func (a authTLS) Parse(ing *networking.Ingress) (interface{}, error) {
 		ns = ing.Namespace
 	}
 	secCfg := a.r.GetSecurityConfiguration()
-	// We don't accept different namespaces for secrets.
-	if !secCfg.AllowCrossNamespaceResources && ns != ing.Namespace {
+	// We don't accept different namespaces for secrets, unless a ReferenceGrant allows it.
+	if !secCfg.AllowCrossNamespaceResources && ns != ing.Namespace &&
+		!a.r.IsReferenceGranted(ing.Namespace, referencegrant.KindSecret, ns, secretName) {
 		return &Config{}, ing_errors.NewLocationDenied("cross namespace secrets are not supported")
 	}
 
@@ -209,9 +223,30 @@ func (a authTLS) Parse(ing *networking.Ingress) (interface{}, error) {
 		config.MatchCN = ""
 	}
 
+	config.MatchSubject, err = parser.GetStringAnnotation(annotationAuthTLSMatchSubject, ing, a.annotationConfig.Annotations)
+	if err != nil {
+		if ing_errors.IsValidationError(err) {
+			return &Config{}, err
+		}
+		config.MatchSubject = ""
+	}
+
 	return config, nil
 }
 
+// validateMatchSubject checks the value is a regex without line breaks
+func validateMatchSubject(s string) error {
+	if parser.MaliciousRegex.MatchString(s) {
+		return fmt.Errorf("value %s contains a line break", s)
+	}
+
+	if _, err := regexp.Compile(s); err != nil {
+		return fmt.Errorf("value %s is not a valid regex: %w", s, err)
+	}
+
+	return nil
+}
+
 func (a authTLS) GetDocumentation() parser.AnnotationFields {
 	return a.annotationConfig.Annotations
 }
@@ -141,6 +141,7 @@ func TestAnnotations(t *testing.T) {
 	data[parser.GetAnnotationWithPrefix(annotationAuthTLSErrorPage)] = "ok.com/error"
 	data[parser.GetAnnotationWithPrefix(annotationAuthTLSPassCertToUpstream)] = "true"
 	data[parser.GetAnnotationWithPrefix(annotationAuthTLSMatchCN)] = "CN=(hello-app|ok|goodbye)"
+	data[parser.GetAnnotationWithPrefix(annotationAuthTLSMatchSubject)] = "(^|,)OU=payments(,|$)"
 
 	ing.SetAnnotations(data)
 
@@ -172,6 +173,9 @@ func TestAnnotations(t *testing.T) {
 	if u.MatchCN != "CN=(hello-app|ok|goodbye)" {
 		t.Errorf("expected %v but got %v", "CN=(hello-app|ok|goodbye)", u.MatchCN)
 	}
+	if u.MatchSubject != "(^|,)OU=payments(,|$)" {
+		t.Errorf("expected %v but got %v", "(^|,)OU=payments(,|$)", u.MatchSubject)
+	}
 }
 
 func TestInvalidAnnotations(t *testing.T) {
@@ -243,6 +247,14 @@ func TestInvalidAnnotations(t *testing.T) {
 	}
 	delete(data, parser.GetAnnotationWithPrefix(annotationAuthTLSMatchCN))
 
+	data[parser.GetAnnotationWithPrefix(annotationAuthTLSMatchSubject)] = "OU=(payments"
+	ing.SetAnnotations(data)
+	_, err = NewParser(fakeSecret).Parse(ing)
+	if err == nil {
+		t.Errorf("Expected error with ingress subject but got nil")
+	}
+	delete(data, parser.GetAnnotationWithPrefix(annotationAuthTLSMatchSubject))
+
 	ing.SetAnnotations(data)
 
 	i, err := NewParser(fakeSecret).Parse(ing)
@@ -342,6 +354,15 @@ func TestEquals(t *testing.T) {
 	}
 	cfg2.MatchCN = "CN=(hello-app|goodbye)"
 
+	// Different MatchSubject
+	cfg1.MatchSubject = "OU=payments"
+	cfg2.MatchSubject = "OU=billing"
+	result = cfg1.Equal(cfg2)
+	if result != false {
+		t.Errorf("Expected false")
+	}
+	cfg2.MatchSubject = "OU=payments"
+
 	// Equal Configs
 	result = cfg1.Equal(cfg2)
 	if result != true {
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compression
+
+import (
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	enableCompressionAnnotation = "enable-compression"
+	compressionTypesAnnotation  = "compression-types"
+)
+
+var compressionAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		enableCompressionAnnotation: {
+			Validator: parser.ValidateBool,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation allows to disable the gzip and brotli compression of the responses of this location.
+			Useful for backends that already compress their responses or that stream content`,
+		},
+		compressionTypesAnnotation: {
+			Validator:     parser.ValidateRegex(parser.MIMETypesRegex, false),
+			Scope:         parser.AnnotationScopeLocation,
+			Risk:          parser.AnnotationRiskLow,
+			Documentation: `This annotation overrides the MIME types, separated by spaces, compressed using gzip and brotli in this location`,
+		},
+	},
+}
+
+// Config contains the compression configuration of a location.
+// The zero value keeps the global configuration.
+type Config struct {
+	// Disabled turns off the gzip and brotli compression
+	Disabled bool `json:"disabled"`
+	// Types overrides the MIME types compressed using gzip and brotli
+	Types string `json:"types,omitempty"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return *c1 == *c2
+}
+
+type compression struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new compression annotations parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return compression{
+		r:                r,
+		annotationConfig: compressionAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to configure the compression of the responses
+func (c compression) Parse(ing *networking.Ingress) (interface{}, error) {
+	config := &Config{}
+
+	enabled, err := parser.GetBoolAnnotation(enableCompressionAnnotation, ing, c.annotationConfig.Annotations)
+	if err != nil && !ing_errors.IsMissingAnnotations(err) {
+		return config, err
+	}
+	if err == nil {
+		config.Disabled = !enabled
+	}
+
+	config.Types, err = parser.GetStringAnnotation(compressionTypesAnnotation, ing, c.annotationConfig.Annotations)
+	if err != nil && !ing_errors.IsMissingAnnotations(err) {
+		return config, err
+	}
+
+	return config, nil
+}
+
+func (c compression) GetDocumentation() parser.AnnotationFields {
+	return c.annotationConfig.Annotations
+}
+
+func (c compression) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(c.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, compressionAnnotations.Annotations)
+}
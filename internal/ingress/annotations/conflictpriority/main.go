@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conflictpriority
+
+import (
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	conflictPriorityAnnotation = "conflict-priority"
+)
+
+var conflictPriorityAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		conflictPriorityAnnotation: {
+			Validator:     parser.ValidateInt,
+			Scope:         parser.AnnotationScopeIngress,
+			Risk:          parser.AnnotationRiskLow,
+			Documentation: `This annotation sets the priority of the Ingress when other Ingresses define the same host and path and the ingress-conflict-policy is "priority". The Ingress with the highest priority wins.`,
+		},
+	},
+}
+
+type conflictPriority struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new conflict priority annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return conflictPriority{
+		r:                r,
+		annotationConfig: conflictPriorityAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress to use the priority
+// of the Ingress when the same host and path are defined by other Ingresses
+func (c conflictPriority) Parse(ing *networking.Ingress) (interface{}, error) {
+	val, err := parser.GetIntAnnotation(conflictPriorityAnnotation, ing, c.annotationConfig.Annotations)
+	if err != nil {
+		if errors.IsMissingAnnotations(err) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return val, nil
+}
+
+func (c conflictPriority) GetDocumentation() parser.AnnotationFields {
+	return c.annotationConfig.Annotations
+}
+
+func (c conflictPriority) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(c.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, conflictPriorityAnnotations.Annotations)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conflictpriority
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+func TestParse(t *testing.T) {
+	annotation := parser.GetAnnotationWithPrefix(conflictPriorityAnnotation)
+	ap := NewParser(&resolver.Mock{})
+
+	testCases := []struct {
+		annotations map[string]string
+		expected    int
+		expectErr   bool
+	}{
+		{map[string]string{annotation: "10"}, 10, false},
+		{map[string]string{annotation: "-5"}, -5, false},
+		{map[string]string{annotation: "high"}, 0, true},
+		{map[string]string{}, 0, false},
+		{nil, 0, false},
+	}
+
+	ing := &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "foo",
+			Namespace: api.NamespaceDefault,
+		},
+		Spec: networking.IngressSpec{},
+	}
+
+	for _, tc := range testCases {
+		ing.SetAnnotations(tc.annotations)
+		result, err := ap.Parse(ing)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("expected error: %t but returned %v for %v", tc.expectErr, err, tc.annotations)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("expected %v but returned %v for %v", tc.expected, result, tc.annotations)
+		}
+	}
+}
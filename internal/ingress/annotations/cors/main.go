@@ -62,6 +62,7 @@ const (
 	corsAllowCredentialsAnnotation = "cors-allow-credentials" //#nosec G101
 	corsExposeHeadersAnnotation    = "cors-expose-headers"
 	corsMaxAgeAnnotation           = "cors-max-age"
+	corsHandlePreflightAnnotation  = "cors-handle-preflight"
 )
 
 var corsAnnotation = parser.Annotation{
@@ -115,6 +116,13 @@ var corsAnnotation = parser.Annotation{
 			Risk:          parser.AnnotationRiskLow,
 			Documentation: `This annotation controls how long, in seconds, preflight requests can be cached.`,
 		},
+		corsHandlePreflightAnnotation: {
+			Validator: parser.ValidateBool,
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation answers all the preflight requests with the status code 204 without sending them to the backend.
+			The preflights of origins not allowed get no CORS headers, and the answers can be cached for cors-max-age seconds.`,
+		},
 	},
 }
 
@@ -132,6 +140,7 @@ type Config struct {
 	CorsAllowCredentials bool     `json:"corsAllowCredentials"`
 	CorsExposeHeaders    string   `json:"corsExposeHeaders"`
 	CorsMaxAge           int      `json:"corsMaxAge"`
+	CorsHandlePreflight  bool     `json:"corsHandlePreflight"`
 }
 
 // NewParser creates a new CORS annotation parser
@@ -168,6 +177,9 @@ func (c1 *Config) Equal(c2 *Config) bool {
 	if c1.CorsEnabled != c2.CorsEnabled {
 		return false
 	}
+	if c1.CorsHandlePreflight != c2.CorsHandlePreflight {
+		return false
+	}
 
 	if len(c1.CorsAllowOrigin) != len(c2.CorsAllowOrigin) {
 		return false
@@ -258,6 +270,14 @@ func (c cors) Parse(ing *networking.Ingress) (interface{}, error) {
 		config.CorsMaxAge = defaultCorsMaxAge
 	}
 
+	config.CorsHandlePreflight, err = parser.GetBoolAnnotation(corsHandlePreflightAnnotation, ing, c.annotationConfig.Annotations)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			klog.Warningf("cors-handle-preflight is invalid, defaulting to 'false'")
+		}
+		config.CorsHandlePreflight = false
+	}
+
 	return config, nil
 }
 
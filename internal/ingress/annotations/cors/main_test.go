@@ -85,6 +85,7 @@ func TestIngressCorsConfigValid(t *testing.T) {
 	data[parser.GetAnnotationWithPrefix(corsAllowOriginAnnotation)] = "null, https://origin123.test.com:4443"
 	data[parser.GetAnnotationWithPrefix(corsExposeHeadersAnnotation)] = "*, X-CustomResponseHeader"
 	data[parser.GetAnnotationWithPrefix(corsMaxAgeAnnotation)] = "600"
+	data[parser.GetAnnotationWithPrefix(corsHandlePreflightAnnotation)] = "true"
 	ing.SetAnnotations(data)
 
 	corst, err := NewParser(&resolver.Mock{}).Parse(ing)
@@ -124,6 +125,10 @@ func TestIngressCorsConfigValid(t *testing.T) {
 	if nginxCors.CorsMaxAge != 600 {
 		t.Errorf("expected %v but returned %v", data[parser.GetAnnotationWithPrefix(corsMaxAgeAnnotation)], nginxCors.CorsMaxAge)
 	}
+
+	if !nginxCors.CorsHandlePreflight {
+		t.Errorf("expected %v but returned %v", data[parser.GetAnnotationWithPrefix(corsHandlePreflightAnnotation)], nginxCors.CorsHandlePreflight)
+	}
 }
 
 func TestIngressCorsConfigInvalid(t *testing.T) {
@@ -139,6 +144,7 @@ func TestIngressCorsConfigInvalid(t *testing.T) {
 	data[parser.GetAnnotationWithPrefix(corsAllowOriginAnnotation)] = "origin123.test.com:4443"
 	data[parser.GetAnnotationWithPrefix(corsExposeHeadersAnnotation)] = "@alright, #ingress"
 	data[parser.GetAnnotationWithPrefix(corsMaxAgeAnnotation)] = "abcd"
+	data[parser.GetAnnotationWithPrefix(corsHandlePreflightAnnotation)] = "always"
 	ing.SetAnnotations(data)
 
 	corst, err := NewParser(&resolver.Mock{}).Parse(ing)
@@ -174,6 +180,10 @@ func TestIngressCorsConfigInvalid(t *testing.T) {
 	if nginxCors.CorsMaxAge != defaultCorsMaxAge {
 		t.Errorf("expected %v but returned %v", defaultCorsMaxAge, nginxCors.CorsMaxAge)
 	}
+
+	if nginxCors.CorsHandlePreflight {
+		t.Errorf("expected %v but returned %v", false, nginxCors.CorsHandlePreflight)
+	}
 }
 
 func TestIngressCorsConfigAllowOriginWithTrailingComma(t *testing.T) {
@@ -18,32 +18,51 @@ package defaultbackend
 
 import (
 	"fmt"
+	"strings"
 
 	networking "k8s.io/api/networking/v1"
+	machineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/referencegrant"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
 )
 
 const (
-	defaultBackendAnnotation = "default-backend"
+	defaultBackendAnnotation       = "default-backend"
+	customErrorsFallbackAnnotation = "custom-http-errors-fallback-backend"
 )
 
 var defaultBackendAnnotations = parser.Annotation{
 	Group: "backend",
 	Annotations: parser.AnnotationFields{
 		defaultBackendAnnotation: {
-			Validator: parser.ValidateServiceName,
+			Validator: validateServiceReference,
 			Scope:     parser.AnnotationScopeLocation,
 			Risk:      parser.AnnotationRiskLow,
 			Documentation: `This service will be used to handle the response when the configured service in the Ingress rule does not have any active endpoints. 
-			It will also be used to handle the error responses if both this annotation and the custom-http-errors annotation are set.`,
+			It will also be used to handle the error responses if both this annotation and the custom-http-errors annotation are set.
+			A service of another namespace, as namespace/name, requires a ReferenceGrant allowing it.`,
+		},
+	},
+}
+
+var customErrorsFallbackAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		customErrorsFallbackAnnotation: {
+			Validator: validateServiceReference,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This service will be used to handle the error responses of the custom-http-errors annotation when the service rendering them fails or has no active endpoints.
+			A service of another namespace, as namespace/name, requires a ReferenceGrant allowing it.`,
 		},
 	},
 }
 
 type backend struct {
 	r                resolver.Resolver
+	annotation       string
 	annotationConfig parser.Annotation
 }
 
@@ -51,19 +70,40 @@ type backend struct {
 func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 	return backend{
 		r:                r,
+		annotation:       defaultBackendAnnotation,
 		annotationConfig: defaultBackendAnnotations,
 	}
 }
 
+// NewFallbackParser creates a new parser for the service handling the
+// custom errors when the default backend fails
+func NewFallbackParser(r resolver.Resolver) parser.IngressAnnotation {
+	return backend{
+		r:                r,
+		annotation:       customErrorsFallbackAnnotation,
+		annotationConfig: customErrorsFallbackAnnotations,
+	}
+}
+
 // Parse parses the annotations contained in the ingress to use
 // a custom default backend
 func (b backend) Parse(ing *networking.Ingress) (interface{}, error) {
-	s, err := parser.GetStringAnnotation(defaultBackendAnnotation, ing, b.annotationConfig.Annotations)
+	s, err := parser.GetStringAnnotation(b.annotation, ing, b.annotationConfig.Annotations)
 	if err != nil {
 		return nil, err
 	}
 
-	name := fmt.Sprintf("%v/%v", ing.Namespace, s)
+	ns, svcName, found := strings.Cut(s, "/")
+	if !found {
+		ns, svcName = ing.Namespace, s
+	}
+
+	if ns != ing.Namespace && !b.r.GetSecurityConfiguration().AllowCrossNamespaceResources &&
+		!b.r.IsReferenceGranted(ing.Namespace, referencegrant.KindService, ns, svcName) {
+		return nil, fmt.Errorf("cross namespace usage of service %v/%v is not allowed", ns, svcName)
+	}
+
+	name := fmt.Sprintf("%v/%v", ns, svcName)
 	svc, err := b.r.GetService(name)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected error reading service %s: %w", name, err)
@@ -78,5 +118,19 @@ func (b backend) GetDocumentation() parser.AnnotationFields {
 
 func (b backend) Validate(anns map[string]string) error {
 	maxrisk := parser.StringRiskToRisk(b.r.GetSecurityConfiguration().AnnotationsRiskLevel)
-	return parser.CheckAnnotationRisk(anns, maxrisk, defaultBackendAnnotations.Annotations)
+	return parser.CheckAnnotationRisk(anns, maxrisk, b.annotationConfig.Annotations)
+}
+
+// validateServiceReference validates a service name, optionally prefixed by its namespace
+func validateServiceReference(value string) error {
+	ns, name, found := strings.Cut(value, "/")
+	if !found {
+		return parser.ValidateServiceName(value)
+	}
+
+	if errs := machineryvalidation.ValidateNamespaceName(ns, false); len(errs) != 0 {
+		return fmt.Errorf("annotation does not contain a valid namespace: %+v", errs)
+	}
+
+	return parser.ValidateServiceName(name)
 }
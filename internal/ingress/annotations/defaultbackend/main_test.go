@@ -17,6 +17,7 @@ limitations under the License.
 package defaultbackend
 
 import (
+	"strings"
 	"testing"
 
 	api "k8s.io/api/core/v1"
@@ -76,14 +77,15 @@ type mockService struct {
 
 // GetService mocks the GetService call from the defaultbackend package
 func (m mockService) GetService(name string) (*api.Service, error) {
-	if name != "default/demo-service" {
+	if name != "default/demo-service" && name != "shared/demo-service" {
 		return nil, errors.Errorf("there is no service with name %v", name)
 	}
 
+	ns, svcName, _ := strings.Cut(name, "/")
 	return &api.Service{
 		ObjectMeta: meta_v1.ObjectMeta{
-			Namespace: api.NamespaceDefault,
-			Name:      "demo-service",
+			Namespace: ns,
+			Name:      svcName,
 		},
 	}, nil
 }
@@ -139,3 +141,51 @@ func TestAnnotations(t *testing.T) {
 		}
 	}
 }
+
+func TestCrossNamespaceService(t *testing.T) {
+	ing := buildIngress()
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix(defaultBackendAnnotation): "shared/demo-service",
+	})
+
+	fakeService := &mockService{}
+	if _, err := NewParser(fakeService).Parse(ing); err == nil {
+		t.Errorf("expected an error using a service of another namespace without a ReferenceGrant")
+	}
+
+	fakeService.GrantedReferences = []string{"Service/shared/demo-service"}
+	i, err := NewParser(fakeService).Parse(ing)
+	if err != nil {
+		t.Fatalf("unexpected error using a service of another namespace with a ReferenceGrant: %v", err)
+	}
+
+	svc, ok := i.(*api.Service)
+	if !ok || svc.Namespace != "shared" {
+		t.Errorf("expected the service of the shared namespace but got %v", i)
+	}
+}
+
+func TestCustomErrorsFallback(t *testing.T) {
+	ing := buildIngress()
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix(defaultBackendAnnotation): "demo-service",
+	})
+
+	fakeService := &mockService{}
+	if _, err := NewFallbackParser(fakeService).Parse(ing); err == nil {
+		t.Errorf("expected an error without the fallback annotation")
+	}
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix(customErrorsFallbackAnnotation): "demo-service",
+	})
+	i, err := NewFallbackParser(fakeService).Parse(ing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc, ok := i.(*api.Service)
+	if !ok || svc.Name != "demo-service" {
+		t.Errorf("expected the demo-service service but got %v", i)
+	}
+}
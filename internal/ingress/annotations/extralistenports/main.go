@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extralistenports
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	extraListenPortsAnnotation    = "extra-listen-ports"
+	extraSSLListenPortsAnnotation = "extra-ssl-listen-ports"
+)
+
+// comma separated list of ports, like 8080,8081
+var portListRegex = regexp.MustCompile(`^\d{1,5}(,\d{1,5})*$`)
+
+var extraListenPortsAnnotations = parser.Annotation{
+	Group: "listen",
+	Annotations: parser.AnnotationFields{
+		extraListenPortsAnnotation: {
+			Validator: parser.ValidateRegex(portListRegex, true),
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation adds plain HTTP listeners to the server on the ports in the comma separated list.
+			Only the ports declared in the allowed-extra-listen-ports ConfigMap key are used.`,
+		},
+		extraSSLListenPortsAnnotation: {
+			Validator: parser.ValidateRegex(portListRegex, true),
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation adds HTTPS listeners to the server on the ports in the comma separated list.
+			Only the ports declared in the allowed-extra-listen-ports ConfigMap key are used.`,
+		},
+	},
+}
+
+// Config contains the additional ports the server listens on
+type Config struct {
+	HTTP  []int `json:"http,omitempty"`
+	HTTPS []int `json:"https,omitempty"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return slices.Equal(c1.HTTP, c2.HTTP) && slices.Equal(c1.HTTPS, c2.HTTPS)
+}
+
+type extraListenPorts struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new extra listen ports annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return extraListenPorts{
+		r:                r,
+		annotationConfig: extraListenPortsAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to add extra listen directives to the server
+func (e extraListenPorts) Parse(ing *networking.Ingress) (interface{}, error) {
+	config := &Config{}
+
+	var err error
+	config.HTTP, err = e.parsePorts(extraListenPortsAnnotation, ing)
+	if err != nil {
+		return config, err
+	}
+
+	config.HTTPS, err = e.parsePorts(extraSSLListenPortsAnnotation, ing)
+	if err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+func (e extraListenPorts) parsePorts(name string, ing *networking.Ingress) ([]int, error) {
+	val, err := parser.GetStringAnnotation(name, ing, e.annotationConfig.Annotations)
+	if err != nil {
+		if ing_errors.IsMissingAnnotations(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ports := make([]int, 0)
+	for _, p := range strings.Split(val, ",") {
+		port, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || port < 1 || port > 65535 {
+			return nil, ing_errors.NewInvalidAnnotationContent(name, val)
+		}
+		ports = append(ports, port)
+	}
+
+	return ports, nil
+}
+
+func (e extraListenPorts) GetDocumentation() parser.AnnotationFields {
+	return e.annotationConfig.Annotations
+}
+
+func (e extraListenPorts) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(e.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, extraListenPortsAnnotations.Annotations)
+}
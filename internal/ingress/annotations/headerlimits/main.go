@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package headerlimits
+
+import (
+	"regexp"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	largeClientHeaderBuffersAnnotation = "large-client-header-buffers"
+	maxHeaderCountAnnotation           = "max-header-count"
+)
+
+// number and size of the buffers, like 4 32k
+var buffersRegex = regexp.MustCompile(`^[1-9]\d* [1-9]\d*[kKmM]?$`)
+
+var headerLimitsAnnotations = parser.Annotation{
+	Group: "limits",
+	Annotations: parser.AnnotationFields{
+		largeClientHeaderBuffersAnnotation: {
+			Validator: parser.ValidateRegex(buffersRegex, false),
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation sets the maximum number and size of the buffers used to read large client request headers in the server, like "4 32k".
+			It overrides the large-client-header-buffers ConfigMap key.`,
+		},
+		maxHeaderCountAnnotation: {
+			Validator:     parser.ValidateInt,
+			Scope:         parser.AnnotationScopeIngress,
+			Risk:          parser.AnnotationRiskLow,
+			Documentation: `This annotation sets the maximum number of request headers accepted by the server. Requests with more headers are rejected with a 400 status code.`,
+		},
+	},
+}
+
+// Config contains the limits of the request headers of a server
+type Config struct {
+	LargeClientHeaderBuffers string `json:"largeClientHeaderBuffers,omitempty"`
+	MaxHeaderCount           int    `json:"maxHeaderCount,omitempty"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return *c1 == *c2
+}
+
+type headerLimits struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new header limits annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return headerLimits{
+		r:                r,
+		annotationConfig: headerLimitsAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to limit the size and number of the request headers
+func (h headerLimits) Parse(ing *networking.Ingress) (interface{}, error) {
+	config := &Config{}
+
+	var err error
+	config.LargeClientHeaderBuffers, err = parser.GetStringAnnotation(largeClientHeaderBuffersAnnotation, ing, h.annotationConfig.Annotations)
+	if err != nil && !ing_errors.IsMissingAnnotations(err) {
+		return config, err
+	}
+
+	config.MaxHeaderCount, err = parser.GetIntAnnotation(maxHeaderCountAnnotation, ing, h.annotationConfig.Annotations)
+	if err != nil && !ing_errors.IsMissingAnnotations(err) {
+		return config, err
+	}
+	if config.MaxHeaderCount < 0 {
+		err = ing_errors.NewInvalidAnnotationContent(maxHeaderCountAnnotation, config.MaxHeaderCount)
+		config.MaxHeaderCount = 0
+		return config, err
+	}
+
+	return config, nil
+}
+
+func (h headerLimits) GetDocumentation() parser.AnnotationFields {
+	return h.annotationConfig.Annotations
+}
+
+func (h headerLimits) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(h.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, headerLimitsAnnotations.Annotations)
+}
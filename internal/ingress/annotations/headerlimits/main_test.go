@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package headerlimits
+
+import (
+	"reflect"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+func TestParse(t *testing.T) {
+	ap := NewParser(&resolver.Mock{})
+	if ap == nil {
+		t.Fatalf("expected a parser.IngressAnnotation but returned nil")
+	}
+
+	annotationBuffers := parser.GetAnnotationWithPrefix(largeClientHeaderBuffersAnnotation)
+	annotationCount := parser.GetAnnotationWithPrefix(maxHeaderCountAnnotation)
+
+	testCases := []struct {
+		annotations map[string]string
+		expected    Config
+		expectErr   bool
+	}{
+		{map[string]string{annotationBuffers: "4 32k"}, Config{LargeClientHeaderBuffers: "4 32k"}, false},
+		{map[string]string{annotationCount: "50"}, Config{MaxHeaderCount: 50}, false},
+		{map[string]string{annotationBuffers: "8 1m", annotationCount: "100"}, Config{LargeClientHeaderBuffers: "8 1m", MaxHeaderCount: 100}, false},
+		{map[string]string{annotationBuffers: "32k"}, Config{}, true},
+		{map[string]string{annotationBuffers: "0 32k"}, Config{}, true},
+		{map[string]string{annotationBuffers: "4 32k; client_max_body_size 0"}, Config{}, true},
+		{map[string]string{annotationCount: "many"}, Config{}, true},
+		{map[string]string{annotationCount: "-1"}, Config{}, true},
+		{map[string]string{}, Config{}, false},
+		{nil, Config{}, false},
+	}
+
+	ing := &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "foo",
+			Namespace: api.NamespaceDefault,
+		},
+		Spec: networking.IngressSpec{},
+	}
+
+	for _, testCase := range testCases {
+		ing.SetAnnotations(testCase.annotations)
+		result, err := ap.Parse(ing)
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("expected error: %t got error: %t err value: %s. %+v", testCase.expectErr, err != nil, err, testCase.annotations)
+		}
+		if testCase.expectErr {
+			continue
+		}
+		if !reflect.DeepEqual(result, &testCase.expected) {
+			t.Errorf("expected %v but returned %v, annotations: %s", testCase.expected, result, testCase.annotations)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package headersfromvariables
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/customheaders"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	upstreamHeadersAnnotation = "upstream-headers-from-variables"
+	responseHeadersAnnotation = "response-headers-from-variables"
+)
+
+// allowedVariables contains the NGINX variables that can be used as the value
+// of a header. Variables containing request data (headers, cookies, arguments)
+// are excluded because they can be set by the client.
+var allowedVariables = map[string]bool{
+	"remote_addr":            true,
+	"remote_port":            true,
+	"server_port":            true,
+	"server_protocol":        true,
+	"scheme":                 true,
+	"request_id":             true,
+	"msec":                   true,
+	"time_iso8601":           true,
+	"connection":             true,
+	"connection_requests":    true,
+	"ssl_protocol":           true,
+	"ssl_cipher":             true,
+	"ssl_curve":              true,
+	"ssl_server_name":        true,
+	"ssl_session_reused":     true,
+	"ssl_early_data":         true,
+	"ssl_client_verify":      true,
+	"ssl_client_s_dn":        true,
+	"ssl_client_i_dn":        true,
+	"ssl_client_serial":      true,
+	"ssl_client_fingerprint": true,
+	"ssl_client_v_start":     true,
+	"ssl_client_v_end":       true,
+	"ssl_client_v_remain":    true,
+	"namespace":              true,
+	"ingress_name":           true,
+	"service_name":           true,
+	"service_port":           true,
+	"geoip2_city":            true,
+	"geoip2_continent_code":  true,
+	"geoip2_continent_name":  true,
+	"geoip2_country_code":    true,
+	"geoip2_country_name":    true,
+	"geoip2_region_code":     true,
+	"geoip2_region_name":     true,
+	"geoip2_postal_code":     true,
+	"geoip2_time_zone":       true,
+	"geoip2_asn":             true,
+	"geoip2_org":             true,
+}
+
+var variableRegexp = regexp.MustCompile(`^\$([a-z\d_]+)$`)
+
+// AllowedVariable checks if the NGINX variable (without the leading $) can be used as a header value
+func AllowedVariable(variable string) bool {
+	return allowedVariables[variable]
+}
+
+var headersFromVariablesAnnotation = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		upstreamHeadersAnnotation: {
+			Validator: validateHeaders,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskMedium,
+			Documentation: `This annotation sets headers sent to the upstream from NGINX variables.
+			The value is a list of "Header-Name: $variable" entries separated by commas or new lines. Only a fixed list of variables is allowed`,
+		},
+		responseHeadersAnnotation: {
+			Validator: validateHeaders,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskMedium,
+			Documentation: `This annotation sets headers sent to the client from NGINX variables.
+			The value is a list of "Header-Name: $variable" entries separated by commas or new lines. Only a fixed list of variables is allowed`,
+		},
+	},
+}
+
+// Config contains the headers, indexed by name, and the NGINX variable
+// (without the leading $) used as their value
+type Config struct {
+	Upstream map[string]string `json:"upstream,omitempty"`
+	Response map[string]string `json:"response,omitempty"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return reflect.DeepEqual(c1.Upstream, c2.Upstream) &&
+		reflect.DeepEqual(c1.Response, c2.Response)
+}
+
+type headersFromVariables struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new headers from variables annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return headersFromVariables{
+		r:                r,
+		annotationConfig: headersFromVariablesAnnotation,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to set upstream and response headers from NGINX variables
+func (h headersFromVariables) Parse(ing *networking.Ingress) (interface{}, error) {
+	config := &Config{}
+
+	for name, headers := range map[string]*map[string]string{
+		upstreamHeadersAnnotation: &config.Upstream,
+		responseHeadersAnnotation: &config.Response,
+	} {
+		val, err := parser.GetStringAnnotation(name, ing, h.annotationConfig.Annotations)
+		if err != nil {
+			if ing_errors.IsMissingAnnotations(err) {
+				continue
+			}
+			return &Config{}, err
+		}
+
+		*headers, err = parseHeaders(val)
+		if err != nil {
+			return &Config{}, ing_errors.NewLocationDenied(err.Error())
+		}
+	}
+
+	return config, nil
+}
+
+// parseHeaders parses a list of "Header-Name: $variable" entries separated
+// by commas or new lines
+func parseHeaders(value string) (map[string]string, error) {
+	headers := map[string]string{}
+
+	entries := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		header, variable, found := strings.Cut(entry, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid entry %q, expected \"Header-Name: $variable\"", entry)
+		}
+
+		header = strings.TrimSpace(header)
+		if !customheaders.ValidHeader(header) {
+			return nil, fmt.Errorf("invalid header name %q", header)
+		}
+
+		m := variableRegexp.FindStringSubmatch(strings.TrimSpace(variable))
+		if m == nil {
+			return nil, fmt.Errorf("invalid variable %q for header %s", strings.TrimSpace(variable), header)
+		}
+		if !AllowedVariable(m[1]) {
+			return nil, fmt.Errorf("variable $%s is not allowed for header %s", m[1], header)
+		}
+
+		headers[header] = m[1]
+	}
+
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("no headers defined")
+	}
+
+	return headers, nil
+}
+
+func validateHeaders(value string) error {
+	_, err := parseHeaders(value)
+	return err
+}
+
+func (h headersFromVariables) GetDocumentation() parser.AnnotationFields {
+	return h.annotationConfig.Annotations
+}
+
+func (h headersFromVariables) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(h.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, headersFromVariablesAnnotation.Annotations)
+}
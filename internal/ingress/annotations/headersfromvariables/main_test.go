@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package headersfromvariables
+
+import (
+	"reflect"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+func buildIngress() *networking.Ingress {
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "foo",
+			Namespace: api.NamespaceDefault,
+		},
+		Spec: networking.IngressSpec{
+			DefaultBackend: &networking.IngressBackend{
+				Service: &networking.IngressServiceBackend{
+					Name: "default-backend",
+					Port: networking.ServiceBackendPort{
+						Number: 80,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParseAnnotations(t *testing.T) {
+	tests := []struct {
+		title     string
+		upstream  string
+		response  string
+		expected  *Config
+		expectErr bool
+	}{
+		{"no annotations", "", "", &Config{}, false},
+		{
+			"upstream headers", "X-TLS-Version: $ssl_protocol, X-TLS-Cipher: $ssl_cipher", "",
+			&Config{Upstream: map[string]string{"X-TLS-Version": "ssl_protocol", "X-TLS-Cipher": "ssl_cipher"}}, false,
+		},
+		{
+			"response headers separated by new lines", "", "X-Geo-Country: $geoip2_country_code\n  X-Request-ID: $request_id\n",
+			&Config{Response: map[string]string{"X-Geo-Country": "geoip2_country_code", "X-Request-ID": "request_id"}}, false,
+		},
+		{
+			"both", "X-Client-Verify: $ssl_client_verify", "X-Namespace: $namespace",
+			&Config{Upstream: map[string]string{"X-Client-Verify": "ssl_client_verify"}, Response: map[string]string{"X-Namespace": "namespace"}}, false,
+		},
+		{"variable not allowed", "X-Cookie: $http_cookie", "", &Config{}, true},
+		{"invalid header name", "", "X Header: $scheme", &Config{}, true},
+		{"missing variable prefix", "X-Scheme: scheme", "", &Config{}, true},
+		{"variable with extra content", "", "X-Scheme: $scheme;more_set_headers", &Config{}, true},
+		{"missing separator", "X-Scheme", "", &Config{}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.title, func(t *testing.T) {
+			ing := buildIngress()
+			data := map[string]string{}
+			if tc.upstream != "" {
+				data[parser.GetAnnotationWithPrefix(upstreamHeadersAnnotation)] = tc.upstream
+			}
+			if tc.response != "" {
+				data[parser.GetAnnotationWithPrefix(responseHeadersAnnotation)] = tc.response
+			}
+			ing.SetAnnotations(data)
+
+			i, err := NewParser(&resolver.Mock{}).Parse(ing)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			c, ok := i.(*Config)
+			if !ok {
+				t.Fatalf("expected a *Config type")
+			}
+			if !reflect.DeepEqual(c, tc.expected) {
+				t.Errorf("expected %v but got %v", tc.expected, c)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	c1 := &Config{Response: map[string]string{"X-TLS-Version": "ssl_protocol"}}
+	c2 := &Config{Response: map[string]string{"X-TLS-Version": "ssl_protocol"}}
+	if !c1.Equal(c2) {
+		t.Errorf("expected configurations to be equal")
+	}
+
+	c2.Upstream = map[string]string{"X-TLS-Version": "ssl_protocol"}
+	if c1.Equal(c2) {
+		t.Errorf("expected configurations to differ")
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http2
+
+import (
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	http2Annotation = "http2"
+)
+
+var http2Annotations = parser.Annotation{
+	Group: "http2",
+	Annotations: parser.AnnotationFields{
+		http2Annotation: {
+			Validator: parser.ValidateBool,
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation: `This annotation enables or disables HTTP/2 in the server, overriding the use-http2 ConfigMap key.
+			It allows hosts with clients that do not support HTTP/2 correctly to use HTTP/1.1.`,
+		},
+	},
+}
+
+// Config indicates if HTTP/2 is enabled in a server
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Set indicates the annotation is defined, overriding the global configuration
+	Set bool `json:"set"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return *c1 == *c2
+}
+
+type http2 struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new HTTP/2 annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return http2{
+		r:                r,
+		annotationConfig: http2Annotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to enable or disable HTTP/2 in the server
+func (h http2) Parse(ing *networking.Ingress) (interface{}, error) {
+	enabled, err := parser.GetBoolAnnotation(http2Annotation, ing, h.annotationConfig.Annotations)
+	if err != nil {
+		if ing_errors.IsMissingAnnotations(err) {
+			return &Config{}, nil
+		}
+		return &Config{}, err
+	}
+
+	return &Config{
+		Enabled: enabled,
+		Set:     true,
+	}, nil
+}
+
+func (h http2) GetDocumentation() parser.AnnotationFields {
+	return h.annotationConfig.Annotations
+}
+
+func (h http2) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(h.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, http2Annotations.Annotations)
+}
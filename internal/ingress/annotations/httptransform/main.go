@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httptransform
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+	"k8s.io/ingress-nginx/internal/ingress/transform"
+)
+
+const (
+	httpTransformAnnotation = "http-transform"
+)
+
+var httpTransformAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		httpTransformAnnotation: {
+			Validator: parser.ValidateRegex(parser.BasicCharsRegex, true),
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskMedium,
+			Documentation: `This annotation sets the name of an HTTPTransform changing the headers, path and query parameters of the requests and the headers of the responses.
+			Only HTTPTransforms on the same namespace are allowed`,
+		},
+	},
+}
+
+// Config contains the HTTPTransform of a location
+type Config struct {
+	// Name is the namespace and name of the HTTPTransform
+	Name string `json:"name,omitempty"`
+	// Spec describes the changes of the requests and responses
+	Spec transform.Spec `json:"spec"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return c1.Name == c2.Name && reflect.DeepEqual(c1.Spec, c2.Spec)
+}
+
+type httpTransform struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new HTTPTransform annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return httpTransform{
+		r:                r,
+		annotationConfig: httpTransformAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress
+// to change the requests and responses
+func (a httpTransform) Parse(ing *networking.Ingress) (interface{}, error) {
+	name, err := parser.GetStringAnnotation(httpTransformAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil {
+		if ing_errors.IsMissingAnnotations(err) {
+			return &Config{}, nil
+		}
+		return &Config{}, err
+	}
+
+	if strings.Contains(name, "/") {
+		return &Config{}, ing_errors.NewLocationDenied("HTTPTransforms of other namespaces are not allowed")
+	}
+
+	key := fmt.Sprintf("%v/%v", ing.Namespace, name)
+	t, err := a.r.GetHTTPTransform(key)
+	if err != nil {
+		return &Config{}, ing_errors.NewLocationDenied(fmt.Sprintf("unable to find HTTPTransform %q: %v", key, err))
+	}
+
+	if err := t.Spec.Validate(); err != nil {
+		return &Config{}, ing_errors.NewLocationDenied(fmt.Sprintf("invalid HTTPTransform %q: %v", key, err))
+	}
+
+	return &Config{
+		Name: key,
+		Spec: t.Spec,
+	}, nil
+}
+
+func (a httpTransform) GetDocumentation() parser.AnnotationFields {
+	return a.annotationConfig.Annotations
+}
+
+func (a httpTransform) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(a.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, httpTransformAnnotations.Annotations)
+}
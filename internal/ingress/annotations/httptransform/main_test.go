@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httptransform
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+	"k8s.io/ingress-nginx/internal/ingress/transform"
+)
+
+func buildIngress() *networking.Ingress {
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "foo",
+			Namespace: api.NamespaceDefault,
+		},
+		Spec: networking.IngressSpec{
+			DefaultBackend: &networking.IngressBackend{
+				Service: &networking.IngressServiceBackend{
+					Name: "default-backend",
+					Port: networking.ServiceBackendPort{
+						Number: 80,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	valid := transform.Spec{
+		RequestHeaders: &transform.Headers{
+			Set:    []transform.NameValue{{Name: "X-Tenant", Value: "blue"}},
+			Remove: []string{"X-Debug"},
+		},
+		Path: &transform.Path{StripPrefix: "/api"},
+	}
+	invalid := transform.Spec{
+		ResponseHeaders: &transform.Headers{
+			Set: []transform.NameValue{{Name: "X-Bad", Value: "a\r\nb"}},
+		},
+	}
+
+	r := &resolver.Mock{
+		HTTPTransforms: map[string]*transform.HTTPTransform{
+			"default/valid":   {Spec: valid},
+			"default/invalid": {Spec: invalid},
+			"other/valid":     {Spec: valid},
+		},
+	}
+
+	tests := []struct {
+		value     string
+		expected  *Config
+		expectErr bool
+	}{
+		{"", &Config{}, false},
+		{"valid", &Config{Name: "default/valid", Spec: valid}, false},
+		{"invalid", &Config{}, true},
+		{"missing", &Config{}, true},
+		{"other/valid", &Config{}, true},
+	}
+
+	ing := buildIngress()
+	for _, test := range tests {
+		data := map[string]string{}
+		if test.value != "" {
+			data[parser.GetAnnotationWithPrefix(httpTransformAnnotation)] = test.value
+		}
+		ing.SetAnnotations(data)
+
+		i, err := NewParser(r).Parse(ing)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%v: expected error", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.value, err)
+			continue
+		}
+
+		c, ok := i.(*Config)
+		if !ok {
+			t.Fatalf("expected a Config type")
+		}
+		if !c.Equal(test.expected) {
+			t.Errorf("%v: expected %+v but got %+v", test.value, test.expected, c)
+		}
+	}
+}
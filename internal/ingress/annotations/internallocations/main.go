@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internallocations
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	internalLocationsAnnotation = "internal-locations"
+
+	separator = "=>"
+)
+
+var (
+	// pathRegex matches the path of an internal location or of a directory, like /protected/
+	pathRegex = regexp.MustCompile(`^/([A-Za-z0-9\-._~]+/)*$`)
+	// serviceRegex matches a Service port and an optional path, like files:8080/downloads/
+	serviceRegex = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]*[a-z0-9])?):(\d{1,5})(/([A-Za-z0-9\-._~]+/)*)?$`)
+)
+
+var internalLocationsAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		internalLocationsAnnotation: {
+			Validator: validateLocations,
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskHigh,
+			Documentation: `This annotation adds internal locations to the server, only reachable with the X-Accel-Redirect header of a response.
+			The value contains one "/path/ => target" pair per line, where the target is a directory below the internal-locations-root ConfigMap key
+			or a Service of the Ingress namespace, like "files:8080/downloads/"`,
+		},
+	},
+}
+
+// Location defines an internal location serving the files of a directory
+// (Alias) or proxying the requests to a Service (ProxyPass)
+type Location struct {
+	Path      string `json:"path"`
+	Alias     string `json:"alias,omitempty"`
+	ProxyPass string `json:"proxyPass,omitempty"`
+}
+
+// Config contains the internal locations of an Ingress
+type Config struct {
+	Locations []Location `json:"locations,omitempty"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return slices.Equal(c1.Locations, c2.Locations)
+}
+
+// Collides checks if the requests to a routed path can be served by the
+// internal location or the opposite. The root path is ignored because an
+// internal location always takes precedence over it.
+func Collides(internal, routed string) bool {
+	r := strings.TrimSuffix(routed, "/")
+	if r == "" {
+		return false
+	}
+
+	i := strings.TrimSuffix(internal, "/")
+	return i == r || strings.HasPrefix(i, r+"/") || strings.HasPrefix(r, i+"/")
+}
+
+// UnderRoot checks if the directory of an internal location is below the root directory
+func UnderRoot(dir, root string) bool {
+	if root == "" {
+		return false
+	}
+
+	root = strings.TrimSuffix(root, "/") + "/"
+	return strings.HasPrefix(dir, root)
+}
+
+type internalLocations struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new internal locations annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return internalLocations{
+		r:                r,
+		annotationConfig: internalLocationsAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to add internal locations to the server
+func (il internalLocations) Parse(ing *networking.Ingress) (interface{}, error) {
+	config := &Config{}
+
+	val, err := parser.GetStringAnnotation(internalLocationsAnnotation, ing, il.annotationConfig.Annotations)
+	if err != nil {
+		if ing_errors.IsMissingAnnotations(err) {
+			return config, nil
+		}
+		return config, err
+	}
+
+	locations, err := parseLocations(val, ing.Namespace)
+	if err != nil {
+		return config, ing_errors.NewLocationDenied(err.Error())
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			for _, location := range locations {
+				if Collides(location.Path, p.Path) {
+					return config, ing_errors.NewLocationDenied(
+						fmt.Sprintf("internal location %q collides with the path %q of the Ingress", location.Path, p.Path))
+				}
+			}
+		}
+	}
+
+	config.Locations = locations
+	return config, nil
+}
+
+// parseLocations parses one "/path/ => target" pair per line
+func parseLocations(value, namespace string) ([]Location, error) {
+	locations := []Location{}
+
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		p, target, found := strings.Cut(line, separator)
+		if !found {
+			return nil, fmt.Errorf("invalid internal location %q, expected \"/path/ %s target\"", line, separator)
+		}
+
+		location := Location{Path: strings.TrimSpace(p)}
+		target = strings.TrimSpace(target)
+
+		if location.Path == "/" {
+			return nil, fmt.Errorf("the root path cannot be an internal location")
+		}
+		if !pathRegex.MatchString(location.Path) {
+			return nil, fmt.Errorf("invalid internal location path %q, it must start and end with /", location.Path)
+		}
+
+		if slices.ContainsFunc(locations, func(l Location) bool { return l.Path == location.Path }) {
+			return nil, fmt.Errorf("internal location %q is defined more than once", location.Path)
+		}
+
+		switch {
+		case strings.HasPrefix(target, "/"):
+			if !pathRegex.MatchString(target) || path.Clean(target)+"/" != target {
+				return nil, fmt.Errorf("invalid directory %q for internal location %q, it must be an absolute path ending with /", target, location.Path)
+			}
+			location.Alias = target
+		case serviceRegex.MatchString(target):
+			m := serviceRegex.FindStringSubmatch(target)
+			port, err := strconv.Atoi(m[3])
+			if err != nil || port < 1 || port > 65535 {
+				return nil, fmt.Errorf("invalid port in %q for internal location %q", target, location.Path)
+			}
+			location.ProxyPass = fmt.Sprintf("http://%s.%s.svc:%d%s", m[1], namespace, port, m[4])
+		default:
+			return nil, fmt.Errorf("invalid target %q for internal location %q, expected a directory or a Service port", target, location.Path)
+		}
+
+		locations = append(locations, location)
+	}
+
+	if len(locations) == 0 {
+		return nil, fmt.Errorf("no internal locations defined")
+	}
+
+	return locations, nil
+}
+
+func validateLocations(value string) error {
+	_, err := parseLocations(value, "")
+	return err
+}
+
+func (il internalLocations) GetDocumentation() parser.AnnotationFields {
+	return il.annotationConfig.Annotations
+}
+
+func (il internalLocations) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(il.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, internalLocationsAnnotations.Annotations)
+}
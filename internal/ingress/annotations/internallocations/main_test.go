@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internallocations
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+func buildIngress() *networking.Ingress {
+	pathPrefix := networking.PathTypePrefix
+
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "foo",
+			Namespace: api.NamespaceDefault,
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: "foo.bar.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path:     "/app",
+									PathType: &pathPrefix,
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: "default-backend",
+											Port: networking.ServiceBackendPort{
+												Number: 80,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	ing := buildIngress()
+
+	tests := []struct {
+		title     string
+		value     string
+		expected  *Config
+		expectErr bool
+	}{
+		{"no annotation", "", &Config{}, false},
+		{
+			"directory and Service",
+			"/protected/ => /data/files/\n/remote/ => files:8080/downloads/",
+			&Config{Locations: []Location{
+				{Path: "/protected/", Alias: "/data/files/"},
+				{Path: "/remote/", ProxyPass: "http://files.default.svc:8080/downloads/"},
+			}},
+			false,
+		},
+		{"path without trailing slash", "/protected => /data/files/", nil, true},
+		{"root path", "/ => /data/files/", nil, true},
+		{"relative directory", "/protected/ => /data/../etc/", nil, true},
+		{"URL", "/protected/ => http://files.example.com/", nil, true},
+		{"invalid port", "/protected/ => files:0/", nil, true},
+		{"duplicated path", "/protected/ => /data/a/\n/protected/ => /data/b/", nil, true},
+		{"collides with a path of the Ingress", "/app/files/ => /data/files/", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			data := map[string]string{}
+			if test.value != "" {
+				data[parser.GetAnnotationWithPrefix(internalLocationsAnnotation)] = test.value
+			}
+			ing.SetAnnotations(data)
+
+			i, err := NewParser(&resolver.Mock{}).Parse(ing)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			c, ok := i.(*Config)
+			if !ok {
+				t.Fatalf("expected a *Config type")
+			}
+			if !c.Equal(test.expected) {
+				t.Errorf("expected %v but got %v", test.expected, c)
+			}
+		})
+	}
+}
+
+func TestCollides(t *testing.T) {
+	tests := []struct {
+		internal string
+		routed   string
+		expected bool
+	}{
+		{"/protected/", "/", false},
+		{"/protected/", "/protected", true},
+		{"/protected/", "/protected/files", true},
+		{"/protected/files/", "/protected", true},
+		{"/protected/", "/protectedfiles", false},
+		{"/protected/", "/app", false},
+	}
+
+	for _, test := range tests {
+		if got := Collides(test.internal, test.routed); got != test.expected {
+			t.Errorf("Collides(%q, %q): expected %v but got %v", test.internal, test.routed, test.expected, got)
+		}
+	}
+}
+
+func TestUnderRoot(t *testing.T) {
+	tests := []struct {
+		dir      string
+		root     string
+		expected bool
+	}{
+		{"/data/files/", "/data", true},
+		{"/data/files/", "/data/", true},
+		{"/database/", "/data", false},
+		{"/data/files/", "", false},
+	}
+
+	for _, test := range tests {
+		if got := UnderRoot(test.dir, test.root); got != test.expected {
+			t.Errorf("UnderRoot(%q, %q): expected %v but got %v", test.dir, test.root, test.expected, got)
+		}
+	}
+}
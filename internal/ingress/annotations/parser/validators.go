@@ -79,6 +79,8 @@ var (
 	// URLWithNginxVariableRegex defines a url that can contain nginx variables.
 	// It is a risky operation
 	URLWithNginxVariableRegex = regexp.MustCompile("^[" + extendedAlphaNumeric + urlEnabledChars + "$]*$")
+	// MIMETypesRegex defines a list of MIME types separated by spaces, like "application/json text/css"
+	MIMETypesRegex = regexp.MustCompile(`^[a-zA-Z\d.+\-*]+/[a-zA-Z\d.+\-*]+( +[a-zA-Z\d.+\-*]+/[a-zA-Z\d.+\-*]+)*$`)
 	// MaliciousRegex defines chars that are known to inject RCE
 	MaliciousRegex = regexp.MustCompile(`\r|\n`)
 )
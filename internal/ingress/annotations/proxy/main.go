@@ -44,6 +44,8 @@ const (
 	proxyBufferingAnnotation           = "proxy-buffering"
 	proxyHTTPVersionAnnotation         = "proxy-http-version"
 	proxyMaxTempFileSizeAnnotation     = "proxy-max-temp-file-size" //#nosec G101
+	clientBodyInFileOnlyAnnotation     = "client-body-in-file-only"
+	tempPathNameAnnotation             = "temp-path-name"
 )
 
 var validUpstreamAnnotation = regexp.MustCompile(`^((error|timeout|invalid_header|http_500|http_502|http_503|http_504|http_403|http_404|http_429|non_idempotent|off)\s?)+$`)
@@ -163,9 +165,26 @@ var proxyAnnotations = parser.Annotation{
 			Risk:          parser.AnnotationRiskLow,
 			Documentation: `This annotation defines the maximum size of a temporary file when buffering responses.`,
 		},
+		clientBodyInFileOnlyAnnotation: {
+			Validator: parser.ValidateOptions([]string{"on", "clean", "off"}, true, true),
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation defines if the client request bodies are always saved in files. It can be "on", "clean" or "off".
+			With "clean" the files are removed after the request is processed`,
+		},
+		tempPathNameAnnotation: {
+			Validator: parser.ValidateRegex(tempPathNameRegex, false),
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation sets the name of the directories below /tmp/nginx storing the request bodies and the buffered responses of this location,
+			to use a dedicated volume with a size limit`,
+		},
 	},
 }
 
+// tempPathNameRegex matches the name of the temporary directories of a location
+var tempPathNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9\-_]*$`)
+
 // Config returns the proxy timeout to use in the upstream server/s
 type Config struct {
 	BodySize             string `json:"bodySize"`
@@ -186,6 +205,8 @@ type Config struct {
 	ProxyBuffering       string `json:"proxyBuffering"`
 	ProxyHTTPVersion     string `json:"proxyHTTPVersion"`
 	ProxyMaxTempFileSize string `json:"proxyMaxTempFileSize"`
+	ClientBodyInFileOnly string `json:"clientBodyInFileOnly,omitempty"`
+	TempPathName         string `json:"tempPathName,omitempty"`
 }
 
 // Equal tests for equality between two Configuration types
@@ -252,6 +273,14 @@ func (l1 *Config) Equal(l2 *Config) bool {
 		return false
 	}
 
+	if l1.ClientBodyInFileOnly != l2.ClientBodyInFileOnly {
+		return false
+	}
+
+	if l1.TempPathName != l2.TempPathName {
+		return false
+	}
+
 	return true
 }
 
@@ -366,6 +395,16 @@ func (a proxy) Parse(ing *networking.Ingress) (interface{}, error) {
 		config.ProxyMaxTempFileSize = defBackend.ProxyMaxTempFileSize
 	}
 
+	config.ClientBodyInFileOnly, err = parser.GetStringAnnotation(clientBodyInFileOnlyAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil {
+		config.ClientBodyInFileOnly = ""
+	}
+
+	config.TempPathName, err = parser.GetStringAnnotation(tempPathNameAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil {
+		config.TempPathName = ""
+	}
+
 	return config, nil
 }
 
@@ -118,6 +118,8 @@ func TestProxy(t *testing.T) {
 	data[parser.GetAnnotationWithPrefix("proxy-buffering")] = "on"
 	data[parser.GetAnnotationWithPrefix("proxy-http-version")] = proxyHTTPVersion
 	data[parser.GetAnnotationWithPrefix("proxy-max-temp-file-size")] = proxyMaxTempFileSize
+	data[parser.GetAnnotationWithPrefix("client-body-in-file-only")] = "clean"
+	data[parser.GetAnnotationWithPrefix("temp-path-name")] = "uploads"
 	ing.SetAnnotations(data)
 
 	i, err := NewParser(mockBackend{}).Parse(ing)
@@ -170,6 +172,12 @@ func TestProxy(t *testing.T) {
 	if p.ProxyMaxTempFileSize != proxyMaxTempFileSize {
 		t.Errorf("expected 128k as proxy-max-temp-file-size but returned %v", p.ProxyMaxTempFileSize)
 	}
+	if p.ClientBodyInFileOnly != "clean" {
+		t.Errorf("expected clean as client-body-in-file-only but returned %v", p.ClientBodyInFileOnly)
+	}
+	if p.TempPathName != "uploads" {
+		t.Errorf("expected uploads as temp-path-name but returned %v", p.TempPathName)
+	}
 }
 
 func TestProxyComplex(t *testing.T) {
@@ -297,4 +305,32 @@ func TestProxyWithNoAnnotation(t *testing.T) {
 	if p.ProxyMaxTempFileSize != "1024m" {
 		t.Errorf("expected 1024m as proxy-max-temp-file-size but returned %v", p.ProxyMaxTempFileSize)
 	}
+	if p.ClientBodyInFileOnly != "" {
+		t.Errorf("expected empty client-body-in-file-only but returned %v", p.ClientBodyInFileOnly)
+	}
+	if p.TempPathName != "" {
+		t.Errorf("expected empty temp-path-name but returned %v", p.TempPathName)
+	}
+}
+
+func TestInvalidTempPathName(t *testing.T) {
+	ing := buildIngress()
+
+	for _, name := range []string{"../etc", "a/b", "-upload", "up load"} {
+		data := map[string]string{}
+		data[parser.GetAnnotationWithPrefix("temp-path-name")] = name
+		ing.SetAnnotations(data)
+
+		i, err := NewParser(mockBackend{}).Parse(ing)
+		if err != nil {
+			t.Fatalf("unexpected error parsing annotations: %v", err)
+		}
+		p, ok := i.(*Config)
+		if !ok {
+			t.Fatalf("expected a Config type")
+		}
+		if p.TempPathName != "" {
+			t.Errorf("expected temp-path-name %q to be ignored but returned %v", name, p.TempPathName)
+		}
+	}
 }
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxyintercepterrors
+
+import (
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	proxyInterceptErrorsAnnotation = "proxy-intercept-errors"
+)
+
+var proxyInterceptErrorsAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		proxyInterceptErrorsAnnotation: {
+			Validator: parser.ValidateBool,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation enables or disables NGINX proxy_intercept_errors for the location, overriding the value inherited from the custom-http-errors and disable-proxy-intercept-errors settings.
+			When enabled, the error codes of the global custom-http-errors are intercepted even if the global disable-proxy-intercept-errors is set. When disabled, no error returned by the upstream is intercepted.`,
+		},
+	},
+}
+
+type proxyInterceptErrors struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new proxyInterceptErrors annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return proxyInterceptErrors{
+		r:                r,
+		annotationConfig: proxyInterceptErrorsAnnotations,
+	}
+}
+
+// Parse returns the value of the proxy_intercept_errors directive of the
+// location, "on" or "off", or an empty string to keep the inherited one
+func (pie proxyInterceptErrors) Parse(ing *networking.Ingress) (interface{}, error) {
+	val, err := parser.GetBoolAnnotation(proxyInterceptErrorsAnnotation, ing, pie.annotationConfig.Annotations)
+	if err != nil {
+		if err == errors.ErrMissingAnnotations {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if val {
+		return "on", nil
+	}
+	return "off", nil
+}
+
+func (pie proxyInterceptErrors) GetDocumentation() parser.AnnotationFields {
+	return pie.annotationConfig.Annotations
+}
+
+func (pie proxyInterceptErrors) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(pie.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, proxyInterceptErrorsAnnotations.Annotations)
+}
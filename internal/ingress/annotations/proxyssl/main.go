@@ -25,6 +25,7 @@ import (
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/referencegrant"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
 	"k8s.io/ingress-nginx/internal/k8s"
 	"k8s.io/klog/v2"
@@ -194,14 +195,15 @@ func (p proxySSL) Parse(ing *networking.Ingress) (interface{}, error) {
 		return &Config{}, err
 	}
 
-	ns, _, err := k8s.ParseNameNS(proxysslsecret)
+	ns, secretName, err := k8s.ParseNameNS(proxysslsecret)
 	if err != nil {
 		return &Config{}, ing_errors.NewLocationDenied(err.Error())
 	}
 
 	secCfg := p.r.GetSecurityConfiguration()
-	// We don't accept different namespaces for secrets.
-	if !secCfg.AllowCrossNamespaceResources && ns != ing.Namespace {
+	// We don't accept different namespaces for secrets, unless a ReferenceGrant allows it.
+	if !secCfg.AllowCrossNamespaceResources && ns != ing.Namespace &&
+		!p.r.IsReferenceGranted(ing.Namespace, referencegrant.KindSecret, ns, secretName) {
 		return &Config{}, ing_errors.NewLocationDenied("cross namespace secrets are not supported")
 	}
 
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ranges
+
+import (
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	proxyForceRangesAnnotation = "proxy-force-ranges"
+	maxRangesAnnotation        = "max-ranges"
+	sliceSizeAnnotation        = "slice-size"
+)
+
+var rangesAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		proxyForceRangesAnnotation: {
+			Validator: parser.ValidateBool,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation enables the byte-range support for the responses of the backend
+			regardless of their Accept-Ranges header`,
+		},
+		maxRangesAnnotation: {
+			Validator:     parser.ValidateInt,
+			Scope:         parser.AnnotationScopeLocation,
+			Risk:          parser.AnnotationRiskLow,
+			Documentation: `This annotation limits the number of ranges allowed in a byte-range request. Zero disables the byte-range support`,
+		},
+		sliceSizeAnnotation: {
+			Validator: parser.ValidateRegex(parser.SizeRegex, true),
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation splits the requests to the backend in byte-range requests of this size, like 1m, and caches the responses.
+			Useful to serve large files`,
+		},
+	},
+}
+
+// Config contains the byte-range configuration of a location
+type Config struct {
+	ForceRanges bool `json:"forceRanges"`
+	MaxRanges   int  `json:"maxRanges"`
+	// MaxRangesSet indicates the max-ranges annotation is defined
+	MaxRangesSet bool   `json:"maxRangesSet"`
+	SliceSize    string `json:"sliceSize,omitempty"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return *c1 == *c2
+}
+
+type ranges struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new byte-range annotations parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return ranges{
+		r:                r,
+		annotationConfig: rangesAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to configure the byte-range requests
+func (a ranges) Parse(ing *networking.Ingress) (interface{}, error) {
+	config := &Config{}
+
+	var err error
+	config.ForceRanges, err = parser.GetBoolAnnotation(proxyForceRangesAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil && !ing_errors.IsMissingAnnotations(err) {
+		return &Config{}, err
+	}
+
+	config.MaxRanges, err = parser.GetIntAnnotation(maxRangesAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil && !ing_errors.IsMissingAnnotations(err) {
+		return &Config{}, err
+	}
+	if config.MaxRanges < 0 {
+		return &Config{}, ing_errors.NewInvalidAnnotationContent(maxRangesAnnotation, config.MaxRanges)
+	}
+	config.MaxRangesSet = err == nil
+
+	config.SliceSize, err = parser.GetStringAnnotation(sliceSizeAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil && !ing_errors.IsMissingAnnotations(err) {
+		return &Config{}, err
+	}
+
+	return config, nil
+}
+
+func (a ranges) GetDocumentation() parser.AnnotationFields {
+	return a.annotationConfig.Annotations
+}
+
+func (a ranges) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(a.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, rangesAnnotations.Annotations)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ranges
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+func buildIngress() *networking.Ingress {
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "foo",
+			Namespace: api.NamespaceDefault,
+		},
+		Spec: networking.IngressSpec{
+			DefaultBackend: &networking.IngressBackend{
+				Service: &networking.IngressServiceBackend{
+					Name: "default-backend",
+					Port: networking.ServiceBackendPort{
+						Number: 80,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	ing := buildIngress()
+
+	tests := []struct {
+		annotations map[string]string
+		expected    Config
+		expectErr   bool
+	}{
+		{map[string]string{}, Config{}, false},
+		{map[string]string{proxyForceRangesAnnotation: "true"}, Config{ForceRanges: true}, false},
+		{map[string]string{maxRangesAnnotation: "0"}, Config{MaxRangesSet: true}, false},
+		{map[string]string{maxRangesAnnotation: "2"}, Config{MaxRanges: 2, MaxRangesSet: true}, false},
+		{map[string]string{maxRangesAnnotation: "-1"}, Config{}, true},
+		{map[string]string{sliceSizeAnnotation: "1m"}, Config{SliceSize: "1m"}, false},
+		{map[string]string{sliceSizeAnnotation: "1m; proxy_cache off"}, Config{}, true},
+	}
+
+	for _, test := range tests {
+		data := map[string]string{}
+		for k, v := range test.annotations {
+			data[parser.GetAnnotationWithPrefix(k)] = v
+		}
+		ing.SetAnnotations(data)
+
+		i, err := NewParser(&resolver.Mock{}).Parse(ing)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("expected error parsing %v", test.annotations)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error parsing %v: %v", test.annotations, err)
+		}
+
+		c, ok := i.(*Config)
+		if !ok {
+			t.Fatalf("expected a *Config type")
+		}
+		if *c != test.expected {
+			t.Errorf("expected %v but got %v for %v", test.expected, *c, test.annotations)
+		}
+	}
+}
@@ -60,6 +60,12 @@ type Config struct {
 	ID string `json:"id"`
 
 	Allowlist []string `json:"allowlist"`
+
+	// RetryAfter adds a Retry-After header to the rejected requests
+	RetryAfter bool `json:"retry-after"`
+
+	// JSONResponse returns a JSON body describing the rejection
+	JSONResponse bool `json:"json-response"`
 }
 
 // Equal tests for equality between two RateLimit types
@@ -91,6 +97,12 @@ func (rt1 *Config) Equal(rt2 *Config) bool {
 	if rt1.Name != rt2.Name {
 		return false
 	}
+	if rt1.RetryAfter != rt2.RetryAfter {
+		return false
+	}
+	if rt1.JSONResponse != rt2.JSONResponse {
+		return false
+	}
 	if len(rt1.Allowlist) != len(rt2.Allowlist) {
 		return false
 	}
@@ -141,6 +153,8 @@ const (
 	limitRateBurstMultiplierAnnotation = "limit-burst-multiplier"
 	limitWhitelistAnnotation           = "limit-whitelist" // This annotation is an alias for limit-allowlist
 	limitAllowlistAnnotation           = "limit-allowlist"
+	limitRetryAfterAnnotation          = "limit-retry-after"
+	limitJSONResponseAnnotation        = "limit-json-response"
 )
 
 var rateLimitAnnotations = parser.Annotation{
@@ -191,6 +205,19 @@ var rateLimitAnnotations = parser.Annotation{
 			Documentation:     `List of CIDR/IP addresses that will not be rate-limited.`,
 			AnnotationAliases: []string{limitWhitelistAnnotation},
 		},
+		limitRetryAfterAnnotation: {
+			Validator: parser.ValidateBool,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation: `Adds a Retry-After header to the requests rejected by limit-rps, limit-rpm or limit-connections.
+			The delay is the time the rate limit needs to accept a new request.`,
+		},
+		limitJSONResponseAnnotation: {
+			Validator:     parser.ValidateBool,
+			Scope:         parser.AnnotationScopeLocation,
+			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation: `Returns a JSON body with the status code and the retry delay for the requests rejected by the rate limits.`,
+		},
 	},
 }
 
@@ -247,6 +274,15 @@ func (a ratelimit) Parse(ing *networking.Ingress) (interface{}, error) {
 		return nil, errCidr
 	}
 
+	retryAfter, err := parser.GetBoolAnnotation(limitRetryAfterAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil && errors.IsValidationError(err) {
+		return nil, err
+	}
+	jsonResponse, err := parser.GetBoolAnnotation(limitJSONResponseAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil && errors.IsValidationError(err) {
+		return nil, err
+	}
+
 	if rpm == 0 && rps == 0 && conn == 0 {
 		return &Config{
 			Connections:    Zone{},
@@ -283,6 +319,8 @@ func (a ratelimit) Parse(ing *networking.Ingress) (interface{}, error) {
 		Name:           zoneName,
 		ID:             encode(zoneName),
 		Allowlist:      cidrs,
+		RetryAfter:     retryAfter,
+		JSONResponse:   jsonResponse,
 	}, nil
 }
 
@@ -248,3 +248,35 @@ func TestAnnotationCIDR(t *testing.T) {
 		t.Errorf("expected 1 cidrs in limit by ip but %v was returned", len(rateLimit.Allowlist))
 	}
 }
+
+func TestRejectionResponse(t *testing.T) {
+	ing := buildIngress()
+
+	data := map[string]string{}
+	data[parser.GetAnnotationWithPrefix(limitRateRPMAnnotation)] = "10"
+	data[parser.GetAnnotationWithPrefix(limitRetryAfterAnnotation)] = "true"
+	data[parser.GetAnnotationWithPrefix(limitJSONResponseAnnotation)] = "true"
+	ing.SetAnnotations(data)
+
+	i, err := NewParser(mockBackend{}).Parse(ing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rateLimit, ok := i.(*Config)
+	if !ok {
+		t.Fatalf("expected a RateLimit type")
+	}
+	if !rateLimit.RetryAfter {
+		t.Errorf("expected the Retry-After header to be enabled")
+	}
+	if !rateLimit.JSONResponse {
+		t.Errorf("expected the JSON response to be enabled")
+	}
+
+	data[parser.GetAnnotationWithPrefix(limitRetryAfterAnnotation)] = "maybe"
+	ing.SetAnnotations(data)
+
+	if _, err := NewParser(mockBackend{}).Parse(ing); err == nil {
+		t.Errorf("expected an error with an invalid limit-retry-after value")
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package realip
+
+import (
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	realIPAnnotation = "enable-real-ip"
+)
+
+var realIPAnnotations = parser.Annotation{
+	Group: "realip",
+	Annotations: parser.AnnotationFields{
+		realIPAnnotation: {
+			Validator: parser.ValidateBool,
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation: `This annotation enables or disables the replacement of the client address with the one sent by the trusted proxies in the server,
+			overriding the enable-real-ip, use-forwarded-headers and proxy-real-ip-cidr ConfigMap keys.`,
+		},
+	},
+}
+
+// Config indicates if the client address is replaced by the real IP in a server
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Set indicates the annotation is defined, overriding the global configuration
+	Set bool `json:"set"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return *c1 == *c2
+}
+
+type realIP struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new real IP annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return realIP{
+		r:                r,
+		annotationConfig: realIPAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to enable or disable the real IP in the server
+func (a realIP) Parse(ing *networking.Ingress) (interface{}, error) {
+	enabled, err := parser.GetBoolAnnotation(realIPAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil {
+		if ing_errors.IsMissingAnnotations(err) {
+			return &Config{}, nil
+		}
+		return &Config{}, err
+	}
+
+	return &Config{
+		Enabled: enabled,
+		Set:     true,
+	}, nil
+}
+
+func (a realIP) GetDocumentation() parser.AnnotationFields {
+	return a.annotationConfig.Annotations
+}
+
+func (a realIP) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(a.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, realIPAnnotations.Annotations)
+}
@@ -19,6 +19,7 @@ package redirect
 import (
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	networking "k8s.io/api/networking/v1"
@@ -33,12 +34,21 @@ const (
 	defaultTemporalRedirectCode  = http.StatusFound
 )
 
+const (
+	// FromToWWWDirectionToWWW only redirects the domain to the www host
+	FromToWWWDirectionToWWW = "to-www"
+	// FromToWWWDirectionToApex only redirects the www host to the domain
+	FromToWWWDirectionToApex = "to-apex"
+)
+
 // Config returns the redirect configuration for an Ingress rule
 type Config struct {
 	URL       string `json:"url"`
 	Code      int    `json:"code"`
 	FromToWWW bool   `json:"fromToWWW"`
-	Relative  bool   `json:"relative"`
+	// FromToWWWDirection restricts the redirect of FromToWWW to one direction
+	FromToWWWDirection string `json:"fromToWWWDirection,omitempty"`
+	Relative           bool   `json:"relative"`
 }
 
 const (
@@ -54,10 +64,11 @@ var redirectAnnotations = parser.Annotation{
 	Group: "redirect",
 	Annotations: parser.AnnotationFields{
 		fromToWWWRedirAnnotation: {
-			Validator:     parser.ValidateBool,
-			Scope:         parser.AnnotationScopeLocation,
-			Risk:          parser.AnnotationRiskLow, // Low, as it allows just a set of options
-			Documentation: `In some scenarios, it is required to redirect from www.domain.com to domain.com or vice versa, which way the redirect is performed depends on the configured host value in the Ingress object.`,
+			Validator: validateFromToWWW,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskLow, // Low, as it allows just a set of options
+			Documentation: `In some scenarios, it is required to redirect from www.domain.com to domain.com or vice versa, which way the redirect is performed depends on the configured host value in the Ingress object.
+			The values "to-www" and "to-apex" only create the redirect in that direction.`,
 		},
 		temporalRedirectAnnotation: {
 			Validator: parser.ValidateRegex(parser.URLIsValidRegex, false),
@@ -112,7 +123,7 @@ func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 // If the Ingress contains both annotations the execution order is
 // temporal and then permanent
 func (r redirect) Parse(ing *networking.Ingress) (interface{}, error) {
-	r3w, err := parser.GetBoolAnnotation(fromToWWWRedirAnnotation, ing, r.annotationConfig.Annotations)
+	r3w, r3wDirection, err := parseFromToWWW(ing, r.annotationConfig.Annotations)
 	if err != nil && !errors.IsMissingAnnotations(err) {
 		return nil, err
 	}
@@ -142,10 +153,11 @@ func (r redirect) Parse(ing *networking.Ingress) (interface{}, error) {
 		}
 
 		return &Config{
-			URL:       tr,
-			Code:      trc,
-			FromToWWW: r3w,
-			Relative:  rr,
+			URL:                tr,
+			Code:               trc,
+			FromToWWW:          r3w,
+			FromToWWWDirection: r3wDirection,
+			Relative:           rr,
 		}, nil
 	}
 
@@ -165,10 +177,11 @@ func (r redirect) Parse(ing *networking.Ingress) (interface{}, error) {
 
 	if pr != "" || r3w {
 		return &Config{
-			URL:       pr,
-			Code:      prc,
-			FromToWWW: r3w,
-			Relative:  rr,
+			URL:                pr,
+			Code:               prc,
+			FromToWWW:          r3w,
+			FromToWWWDirection: r3wDirection,
+			Relative:           rr,
 		}, nil
 	}
 
@@ -198,12 +211,43 @@ func (r1 *Config) Equal(r2 *Config) bool {
 	if r1.FromToWWW != r2.FromToWWW {
 		return false
 	}
+	if r1.FromToWWWDirection != r2.FromToWWWDirection {
+		return false
+	}
 	if r1.Relative != r2.Relative {
 		return false
 	}
 	return true
 }
 
+// validateFromToWWW accepts a boolean or the direction of the redirect
+func validateFromToWWW(value string) error {
+	if value == FromToWWWDirectionToWWW || value == FromToWWWDirectionToApex {
+		return nil
+	}
+
+	return parser.ValidateBool(value)
+}
+
+// parseFromToWWW returns if the redirect to/from www is enabled and its direction
+func parseFromToWWW(ing *networking.Ingress, fields parser.AnnotationFields) (enabled bool, direction string, err error) {
+	val, err := parser.GetStringAnnotation(fromToWWWRedirAnnotation, ing, fields)
+	if err != nil {
+		return false, "", err
+	}
+
+	if val == FromToWWWDirectionToWWW || val == FromToWWWDirectionToApex {
+		return true, val, nil
+	}
+
+	enabled, err = strconv.ParseBool(val)
+	if err != nil {
+		return false, "", errors.NewInvalidAnnotationContent(fromToWWWRedirAnnotation, val)
+	}
+
+	return enabled, "", nil
+}
+
 func isValidURL(s string) error {
 	u, err := url.Parse(s)
 	if err != nil {
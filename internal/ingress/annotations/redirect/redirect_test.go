@@ -212,3 +212,55 @@ func TestParseAnnotations(t *testing.T) {
 		t.Errorf("unexpected error parsing ingress with relative-redirects")
 	}
 }
+
+func TestFromToWWWDirection(t *testing.T) {
+	tests := []struct {
+		value     string
+		enabled   bool
+		direction string
+		expErr    bool
+	}{
+		{"true", true, "", false},
+		{"false", false, "", false},
+		{"to-www", true, FromToWWWDirectionToWWW, false},
+		{"to-apex", true, FromToWWWDirectionToApex, false},
+		{"to-nowhere", false, "", true},
+	}
+
+	for _, test := range tests {
+		ing := new(networking.Ingress)
+		ing.SetAnnotations(map[string]string{
+			parser.GetAnnotationWithPrefix(fromToWWWRedirAnnotation): test.value,
+		})
+
+		i, err := NewParser(&resolver.Mock{}).Parse(ing)
+		if test.expErr {
+			if err == nil || errors.IsMissingAnnotations(err) {
+				t.Errorf("%v: expected an error but got %v", test.value, err)
+			}
+			continue
+		}
+
+		if !test.enabled {
+			if !errors.IsMissingAnnotations(err) {
+				t.Errorf("%v: expected no redirect but got %v", test.value, i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.value, err)
+			continue
+		}
+
+		redirect, ok := i.(*Config)
+		if !ok {
+			t.Fatalf("%v: expected a Redirect type", test.value)
+		}
+		if !redirect.FromToWWW {
+			t.Errorf("%v: expected the redirect from/to www to be enabled", test.value)
+		}
+		if redirect.FromToWWWDirection != test.direction {
+			t.Errorf("%v: expected direction %q but got %q", test.value, test.direction, redirect.FromToWWWDirection)
+		}
+	}
+}
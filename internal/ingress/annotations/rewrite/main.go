@@ -17,6 +17,7 @@ limitations under the License.
 package rewrite
 
 import (
+	"fmt"
 	"net/url"
 
 	networking "k8s.io/api/networking/v1"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/errors"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
+	"k8s.io/ingress-nginx/internal/ingress/tlsredirect"
 )
 
 const (
@@ -34,6 +36,7 @@ const (
 	forceSSLRedirectAnnotation      = "force-ssl-redirect"
 	useRegexAnnotation              = "use-regex"
 	appRootAnnotation               = "app-root"
+	noTLSRedirectAnnotation         = "no-tls-redirect-locations"
 )
 
 var rewriteAnnotations = parser.Annotation{
@@ -77,6 +80,13 @@ var rewriteAnnotations = parser.Annotation{
 			Risk:          parser.AnnotationRiskMedium,
 			Documentation: `This annotation defines the Application Root that the Controller must redirect if it's in / context`,
 		},
+		noTLSRedirectAnnotation: {
+			Validator: validateNoTLSRedirectLocations,
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation defines a comma-separated list of locations of the hosts of the Ingress never redirected to HTTPS,
+			a path starting with / is a prefix and a path starting with ~ a regular expression, e.g. /.well-known/acme-challenge,~^/health(z)?$`,
+		},
 	},
 }
 
@@ -94,6 +104,8 @@ type Config struct {
 	AppRoot string `json:"appRoot"`
 	// UseRegex indicates whether or not the locations use regex paths
 	UseRegex bool `json:"useRegex"`
+	// NoTLSRedirectLocations are the locations never redirected to HTTPS
+	NoTLSRedirectLocations []tlsredirect.Exception `json:"noTLSRedirectLocations"`
 }
 
 // Equal tests for equality between two Redirect types
@@ -119,6 +131,14 @@ func (r1 *Config) Equal(r2 *Config) bool {
 	if r1.UseRegex != r2.UseRegex {
 		return false
 	}
+	if len(r1.NoTLSRedirectLocations) != len(r2.NoTLSRedirectLocations) {
+		return false
+	}
+	for i := range r1.NoTLSRedirectLocations {
+		if !r1.NoTLSRedirectLocations[i].Equal(&r2.NoTLSRedirectLocations[i]) {
+			return false
+		}
+	}
 
 	return true
 }
@@ -180,6 +200,14 @@ func (a rewrite) Parse(ing *networking.Ingress) (interface{}, error) {
 		config.UseRegex = false
 	}
 
+	noTLSRedirectLocations, err := parser.GetStringAnnotation(noTLSRedirectAnnotation, ing, a.annotationConfig.Annotations)
+	if err == nil {
+		// validated with the annotation
+		config.NoTLSRedirectLocations, _ = tlsredirect.Parse(noTLSRedirectLocations)
+	} else if errors.IsValidationError(err) {
+		klog.Warningf("%s is invalid, ignoring: %v", noTLSRedirectAnnotation, err)
+	}
+
 	config.AppRoot, err = parser.GetStringAnnotation(appRootAnnotation, ing, a.annotationConfig.Annotations)
 	if err != nil {
 		if !errors.IsMissingAnnotations(err) && !errors.IsInvalidContent(err) {
@@ -205,6 +233,23 @@ func (a rewrite) Parse(ing *networking.Ingress) (interface{}, error) {
 	return config, nil
 }
 
+// validateNoTLSRedirectLocations checks the exceptions of the annotation, which
+// apply to the hosts of the Ingress and cannot define one
+func validateNoTLSRedirectLocations(value string) error {
+	exceptions, err := tlsredirect.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	for i := range exceptions {
+		if exceptions[i].Host != "" {
+			return fmt.Errorf("exception of host %v: the exceptions apply to the hosts of the Ingress", exceptions[i].Host)
+		}
+	}
+
+	return nil
+}
+
 func (a rewrite) GetDocumentation() parser.AnnotationFields {
 	return a.annotationConfig.Annotations
 }
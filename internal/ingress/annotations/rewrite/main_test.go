@@ -26,6 +26,7 @@ import (
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/defaults"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
+	"k8s.io/ingress-nginx/internal/ingress/tlsredirect"
 )
 
 const (
@@ -272,3 +273,34 @@ func TestUseRegex(t *testing.T) {
 		t.Errorf("Unexpected value got in UseRegex")
 	}
 }
+
+func TestNoTLSRedirectLocations(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected []tlsredirect.Exception
+	}{
+		{"/.well-known/acme-challenge", []tlsredirect.Exception{{Path: "/.well-known/acme-challenge"}}},
+		{"/healthz,~^/ping$", []tlsredirect.Exception{{Path: "/healthz"}, {Path: "^/ping$", Regex: true}}},
+		{"example.com/healthz", nil},
+		{"~^/(", nil},
+	}
+
+	for _, tc := range testCases {
+		ing := buildIngress()
+		ing.SetAnnotations(map[string]string{
+			parser.GetAnnotationWithPrefix("no-tls-redirect-locations"): tc.value,
+		})
+
+		i, err := NewParser(mockBackend{}).Parse(ing)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.value, err)
+		}
+		redirect, ok := i.(*Config)
+		if !ok {
+			t.Fatalf("expected a Config type")
+		}
+		if !redirect.Equal(&Config{NoTLSRedirectLocations: tc.expected}) {
+			t.Errorf("%v: expected %v but returned %v", tc.value, tc.expected, redirect.NoTLSRedirectLocations)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeif
+
+import (
+	"fmt"
+
+	"k8s.io/ingress-nginx/internal/expression"
+)
+
+// Operations of the nodes of an expression
+const (
+	OpAnd        = "and"
+	OpOr         = "or"
+	OpNot        = "not"
+	OpEqual      = "eq"
+	OpNotEqual   = "ne"
+	OpStartsWith = "startsWith"
+	OpEndsWith   = "endsWith"
+	OpContains   = "contains"
+	OpMatches    = "matches"
+	OpHas        = "has"
+	OpString     = "str"
+	OpTrue       = "true"
+	OpFalse      = "false"
+	OpPath       = "path"
+	OpMethod     = "method"
+	OpHost       = "host"
+	OpScheme     = "scheme"
+	OpHeader     = "header"
+	OpQuery      = "query"
+	OpCookie     = "cookie"
+)
+
+// Expr is a node of a compiled expression, evaluated by Lua for every request
+type Expr struct {
+	Op   string  `json:"op"`
+	Args []*Expr `json:"args,omitempty"`
+	// Value is the value of a string or the name of a header, query parameter or cookie
+	Value string `json:"value,omitempty"`
+}
+
+// String returns the expression in the syntax it is compiled from
+func (e *Expr) String() string {
+	switch e.Op {
+	case OpAnd:
+		return fmt.Sprintf("(%v && %v)", e.Args[0], e.Args[1])
+	case OpOr:
+		return fmt.Sprintf("(%v || %v)", e.Args[0], e.Args[1])
+	case OpNot:
+		return fmt.Sprintf("!%v", e.Args[0])
+	case OpEqual:
+		return fmt.Sprintf("%v == %v", e.Args[0], e.Args[1])
+	case OpNotEqual:
+		return fmt.Sprintf("%v != %v", e.Args[0], e.Args[1])
+	case OpStartsWith, OpEndsWith, OpContains, OpMatches:
+		return fmt.Sprintf("%v.%v(%v)", e.Args[0], e.Op, e.Args[1])
+	case OpHas:
+		return fmt.Sprintf("%q in request.%v", e.Args[0].Value, e.Args[0].Op)
+	case OpString:
+		return fmt.Sprintf("%q", e.Value)
+	case OpTrue, OpFalse:
+		return e.Op
+	case OpHeader, OpQuery, OpCookie:
+		return fmt.Sprintf("request.%v[%q]", e.Op, e.Value)
+	default:
+		return "request." + e.Op
+	}
+}
+
+// the fields of request and if they are maps of names to values
+var requestFields = map[string]bool{
+	OpPath:   false,
+	OpMethod: false,
+	OpHost:   false,
+	OpScheme: false,
+	OpHeader: true,
+	OpQuery:  true,
+	OpCookie: true,
+}
+
+type exprType int
+
+const (
+	typeString exprType = iota
+	typeBool
+	typeMap
+)
+
+func (t exprType) String() string {
+	switch t {
+	case typeString:
+		return "string"
+	case typeBool:
+		return "bool"
+	default:
+		return "map"
+	}
+}
+
+// Compile compiles an expression of the expression language of the controller
+// to the nodes evaluated by Lua. The expressions are restricted to booleans on
+// the fields path, method, host and scheme of request and the maps header,
+// query and cookie of request, indexed by a string or tested with in, the
+// strings, true, false, the operators ==, !=, !, && and || and the methods
+// startsWith, endsWith, contains and matches of the strings with a string
+// argument.
+func Compile(source string) (*Expr, error) {
+	compiled, err := expression.Compile(source)
+	if err != nil {
+		return nil, err
+	}
+
+	e, t, err := convert(compiled.Root)
+	if err != nil {
+		return nil, err
+	}
+	if t != typeBool {
+		return nil, fmt.Errorf("the expression is a %v instead of a bool", t)
+	}
+
+	return e, nil
+}
+
+// convert returns the Expr of a node of an expression and its type
+func convert(n expression.Node) (*Expr, exprType, error) {
+	switch n := n.(type) {
+	case *expression.Literal:
+		switch v := n.Value.(type) {
+		case string:
+			return &Expr{Op: OpString, Value: v}, typeString, nil
+		case bool:
+			if v {
+				return &Expr{Op: OpTrue}, typeBool, nil
+			}
+			return &Expr{Op: OpFalse}, typeBool, nil
+		}
+		return nil, typeBool, fmt.Errorf("unsupported value %v", n.Value)
+	case *expression.Logical:
+		op, symbol := OpAnd, "&&"
+		if n.Or {
+			op, symbol = OpOr, "||"
+		}
+		left, lt, err := convert(n.Left)
+		if err != nil {
+			return nil, lt, err
+		}
+		right, rt, err := convert(n.Right)
+		if err != nil {
+			return nil, rt, err
+		}
+		if lt != typeBool || rt != typeBool {
+			return nil, typeBool, fmt.Errorf("%v requires bool operands", symbol)
+		}
+		return &Expr{Op: op, Args: []*Expr{left, right}}, typeBool, nil
+	case *expression.Not:
+		e, t, err := convert(n.Operand)
+		if err != nil {
+			return nil, t, err
+		}
+		if t != typeBool {
+			return nil, t, fmt.Errorf("! requires a bool operand")
+		}
+		return &Expr{Op: OpNot, Args: []*Expr{e}}, typeBool, nil
+	case *expression.Relation:
+		return convertRelation(n)
+	case *expression.Select:
+		return convertRequest(n)
+	case *expression.Index:
+		field, t, err := convertRequest(n.Operand)
+		if err != nil {
+			return nil, t, err
+		}
+		name, ok := literalString(n.Key)
+		if t != typeMap || !ok {
+			return nil, typeBool, fmt.Errorf("request.%v must be indexed by a string", field.Op)
+		}
+		return &Expr{Op: field.Op, Value: name}, typeString, nil
+	case *expression.StringMethod:
+		s, t, err := convert(n.Operand)
+		if err != nil {
+			return nil, t, err
+		}
+		arg, ok := literalString(n.Arg)
+		if t != typeString || !ok {
+			return nil, typeBool, fmt.Errorf("%v requires a string and a string argument", n.Method)
+		}
+		return &Expr{Op: n.Method, Args: []*Expr{s, {Op: OpString, Value: arg}}}, typeBool, nil
+	}
+
+	return nil, typeBool, fmt.Errorf("unsupported expression %T", n)
+}
+
+func convertRelation(n *expression.Relation) (*Expr, exprType, error) {
+	left, lt, err := convert(n.Left)
+	if err != nil {
+		return nil, lt, err
+	}
+
+	switch n.Op {
+	case "==", "!=":
+		right, rt, err := convert(n.Right)
+		if err != nil {
+			return nil, rt, err
+		}
+		if lt != typeString || rt != typeString {
+			return nil, typeBool, fmt.Errorf("%v compares strings instead of a %v and a %v", n.Op, lt, rt)
+		}
+		op := OpEqual
+		if n.Op == "!=" {
+			op = OpNotEqual
+		}
+		return &Expr{Op: op, Args: []*Expr{left, right}}, typeBool, nil
+	case "in":
+		right, rt, err := convertRequest(n.Right)
+		if err != nil {
+			return nil, rt, err
+		}
+		if left.Op != OpString || rt != typeMap {
+			return nil, typeBool, fmt.Errorf("in tests a string in request.header, request.query or request.cookie")
+		}
+		return &Expr{Op: OpHas, Args: []*Expr{{Op: right.Op, Value: left.Value}}}, typeBool, nil
+	}
+
+	return nil, typeBool, fmt.Errorf("unsupported operator %v", n.Op)
+}
+
+// convertRequest returns the Expr of a field of request
+func convertRequest(n expression.Node) (*Expr, exprType, error) {
+	s, ok := n.(*expression.Select)
+	if !ok {
+		return nil, typeBool, fmt.Errorf("unsupported expression %T", n)
+	}
+	if v, ok := s.Operand.(*expression.Variable); !ok || v.Name != "request" {
+		return nil, typeBool, fmt.Errorf("unsupported field %v", s.Field)
+	}
+
+	isMap, ok := requestFields[s.Field]
+	if !ok {
+		return nil, typeBool, fmt.Errorf("unknown field request.%v", s.Field)
+	}
+	if isMap {
+		return &Expr{Op: s.Field}, typeMap, nil
+	}
+
+	return &Expr{Op: s.Field}, typeString, nil
+}
+
+// literalString returns the value of a string literal
+func literalString(n expression.Node) (string, bool) {
+	l, ok := n.(*expression.Literal)
+	if !ok {
+		return "", false
+	}
+
+	s, ok := l.Value.(string)
+	return s, ok
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeif
+
+import (
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{`request.header['x-beta'] == 'true'`, `request.header["x-beta"] == "true"`},
+		{`request.method != "GET"`, `request.method != "GET"`},
+		{
+			`request.path.startsWith("/api") && !(request.host.endsWith('.internal') || 'debug' in request.query)`,
+			`(request.path.startsWith("/api") && !(request.host.endsWith(".internal") || "debug" in request.query))`,
+		},
+		{`request.cookie['group'].matches('^beta-[0-9]+$')`, `request.cookie["group"].matches("^beta-[0-9]+$")`},
+		{`request.scheme == 'https' && request.path.contains('/v2/')`, `(request.scheme == "https" && request.path.contains("/v2/"))`},
+		{`true`, `true`},
+		{`'it\'s' == request.header['x']`, `"it's" == request.header["x"]`},
+	}
+
+	for _, test := range tests {
+		e, err := Compile(test.expression)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.expression, err)
+			continue
+		}
+		if e.String() != test.expected {
+			t.Errorf("%v: expected %v but got %v", test.expression, test.expected, e)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	expressions := []string{
+		``,
+		`request.path`,
+		`request.header`,
+		`request.header['x'] = 'b'`,
+		`request.body == 'a'`,
+		`request.header[0] == 'a'`,
+		`'a' == true`,
+		`!request.path`,
+		`request.path == 'a' &&`,
+		`request.path.startsWith(request.host)`,
+		`request.path.size() == '1'`,
+		`request.path.matches('(')`,
+		`'a' in request.path`,
+		`(true`,
+		`'unterminated`,
+		`true false`,
+		`request.path < 'b'`,
+		`request.header.all(h, true)`,
+		`has(request.path)`,
+		`1 == 1`,
+		`headers['x'] == 'a'`,
+	}
+
+	for _, expression := range expressions {
+		if _, err := Compile(expression); err == nil {
+			t.Errorf("%v: expected error", expression)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeif
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+	machineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	routeIfAnnotation = "route-if"
+)
+
+var routeIfAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		routeIfAnnotation: {
+			Validator: validateRules,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskMedium,
+			Documentation: `This annotation routes the requests matching an expression to another service of the namespace, one rule per line as "expression -> service name[:port]".
+			The expressions use the expression language of the controller on the path, method, host, scheme, headers, query parameters and cookies of the request. The first rule matching a request is used.`,
+		},
+	},
+}
+
+// Rule routes the requests matching an expression to a service
+type Rule struct {
+	// Expression is the expression of the rule, as written in the annotation
+	Expression string `json:"expression"`
+	// If is the compiled expression
+	If *Expr `json:"if"`
+	// Service is the service of the namespace of the Ingress receiving the requests
+	Service networking.IngressServiceBackend `json:"service"`
+	// Backend is the name of the upstream of the service
+	Backend string `json:"backend"`
+}
+
+// Config contains the rules routing the requests of a location
+type Config struct {
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return reflect.DeepEqual(c1.Rules, c2.Rules)
+}
+
+type routeIf struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new route-if annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return routeIf{
+		r:                r,
+		annotationConfig: routeIfAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress
+// to route the requests matching the expressions
+func (a routeIf) Parse(ing *networking.Ingress) (interface{}, error) {
+	value, err := parser.GetStringAnnotation(routeIfAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil {
+		if ing_errors.IsMissingAnnotations(err) {
+			return &Config{}, nil
+		}
+		return &Config{}, err
+	}
+
+	rules, err := parseRules(value)
+	if err != nil {
+		return &Config{}, ing_errors.NewLocationDenied(err.Error())
+	}
+
+	for i := range rules {
+		service := &rules[i].Service
+		if service.Port.Name == "" && service.Port.Number == 0 {
+			// the rules without port use the first port of the service
+			key := fmt.Sprintf("%v/%v", ing.Namespace, service.Name)
+			svc, err := a.r.GetService(key)
+			if err != nil {
+				return &Config{}, ing_errors.NewLocationDenied(fmt.Sprintf("unable to find service %q: %v", key, err))
+			}
+			if len(svc.Spec.Ports) == 0 {
+				return &Config{}, ing_errors.NewLocationDenied(fmt.Sprintf("service %q has no ports", key))
+			}
+			service.Port.Number = svc.Spec.Ports[0].Port
+		}
+
+		rules[i].Backend = backendName(ing.Namespace, service)
+	}
+
+	return &Config{Rules: rules}, nil
+}
+
+// backendName returns the name of the upstream created by the controller for a service
+func backendName(namespace string, service *networking.IngressServiceBackend) string {
+	if service.Port.Number > 0 {
+		return fmt.Sprintf("%s-%s-%d", namespace, service.Name, service.Port.Number)
+	}
+	return fmt.Sprintf("%s-%s-%s", namespace, service.Name, service.Port.Name)
+}
+
+// parseRules parses the rules of the annotation, one per line
+func parseRules(value string) ([]Rule, error) {
+	var rules []Rule
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		i := strings.LastIndex(line, "->")
+		if i < 0 {
+			return nil, fmt.Errorf("rule %q is not \"expression -> service name[:port]\"", line)
+		}
+
+		expression := strings.TrimSpace(line[:i])
+		e, err := Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression %q: %w", expression, err)
+		}
+
+		service, err := parseService(strings.TrimSpace(line[i+2:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %w", line, err)
+		}
+
+		rules = append(rules, Rule{Expression: expression, If: e, Service: service})
+	}
+
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no rule")
+	}
+
+	return rules, nil
+}
+
+// parseService parses the target of a rule, as "service name[:port]"
+func parseService(target string) (networking.IngressServiceBackend, error) {
+	service := networking.IngressServiceBackend{}
+
+	fields := strings.Fields(target)
+	if len(fields) != 2 || fields[0] != "service" {
+		return service, fmt.Errorf("the target %q is not \"service name[:port]\"", target)
+	}
+
+	name, port, hasPort := strings.Cut(fields[1], ":")
+	if errs := machineryvalidation.NameIsDNS1035Label(name, false); len(errs) > 0 {
+		return service, fmt.Errorf("invalid service name %q: %v", name, strings.Join(errs, ", "))
+	}
+	service.Name = name
+
+	if !hasPort {
+		return service, nil
+	}
+	if number, err := strconv.Atoi(port); err == nil {
+		if number < 1 || number > 65535 {
+			return service, fmt.Errorf("invalid port %v", port)
+		}
+		service.Port.Number = int32(number)
+		return service, nil
+	}
+	if errs := machineryvalidation.NameIsDNS1035Label(port, false); len(errs) > 0 {
+		return service, fmt.Errorf("invalid port name %q: %v", port, strings.Join(errs, ", "))
+	}
+	service.Port.Name = port
+
+	return service, nil
+}
+
+func validateRules(value string) error {
+	_, err := parseRules(value)
+	return err
+}
+
+func (a routeIf) GetDocumentation() parser.AnnotationFields {
+	return a.annotationConfig.Annotations
+}
+
+func (a routeIf) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(a.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, routeIfAnnotations.Annotations)
+}
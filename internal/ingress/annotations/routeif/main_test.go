@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routeif
+
+import (
+	"fmt"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+func buildIngress() *networking.Ingress {
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "foo",
+			Namespace: api.NamespaceDefault,
+		},
+	}
+}
+
+type mockService struct {
+	resolver.Mock
+}
+
+// GetService mocks the GetService call from the routeif package
+func (m mockService) GetService(name string) (*api.Service, error) {
+	if name != "default/beta-svc" {
+		return nil, fmt.Errorf("there is no service with name %v", name)
+	}
+
+	return &api.Service{
+		Spec: api.ServiceSpec{
+			Ports: []api.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}, nil
+}
+
+func TestParse(t *testing.T) {
+	beta := &Expr{Op: OpEqual, Args: []*Expr{{Op: OpHeader, Value: "x-beta"}, {Op: OpString, Value: "true"}}}
+	v2 := &Expr{Op: OpStartsWith, Args: []*Expr{{Op: OpPath}, {Op: OpString, Value: "/v2"}}}
+
+	tests := []struct {
+		value     string
+		expected  *Config
+		expectErr bool
+	}{
+		{"", &Config{}, false},
+		{
+			"request.header['x-beta'] == 'true' -> service beta-svc",
+			&Config{Rules: []Rule{{
+				Expression: "request.header['x-beta'] == 'true'",
+				If:         beta,
+				Service:    networking.IngressServiceBackend{Name: "beta-svc", Port: networking.ServiceBackendPort{Number: 8080}},
+				Backend:    "default-beta-svc-8080",
+			}}},
+			false,
+		},
+		{
+			"request.header['x-beta'] == 'true' -> service beta-svc:http\nrequest.path.startsWith('/v2') -> service api-v2:80",
+			&Config{Rules: []Rule{
+				{
+					Expression: "request.header['x-beta'] == 'true'",
+					If:         beta,
+					Service:    networking.IngressServiceBackend{Name: "beta-svc", Port: networking.ServiceBackendPort{Name: "http"}},
+					Backend:    "default-beta-svc-http",
+				},
+				{
+					Expression: "request.path.startsWith('/v2')",
+					If:         v2,
+					Service:    networking.IngressServiceBackend{Name: "api-v2", Port: networking.ServiceBackendPort{Number: 80}},
+					Backend:    "default-api-v2-80",
+				},
+			}},
+			false,
+		},
+		{"request.path.startsWith('/v2') -> service missing", &Config{}, true},
+		{"request.path.startsWith('/v2')", &Config{}, true},
+		{"request.path -> service beta-svc", &Config{}, true},
+		{"request.path.startsWith('/v2') -> beta-svc", &Config{}, true},
+		{"request.path.startsWith('/v2') -> service other/beta-svc", &Config{}, true},
+		{"request.path.startsWith('/v2') -> service beta-svc:0", &Config{}, true},
+	}
+
+	ing := buildIngress()
+	for _, test := range tests {
+		data := map[string]string{}
+		if test.value != "" {
+			data[parser.GetAnnotationWithPrefix(routeIfAnnotation)] = test.value
+		}
+		ing.SetAnnotations(data)
+
+		i, err := NewParser(mockService{}).Parse(ing)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%v: expected error", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.value, err)
+			continue
+		}
+
+		c, ok := i.(*Config)
+		if !ok {
+			t.Fatalf("expected a Config type")
+		}
+		if !c.Equal(test.expected) {
+			t.Errorf("%v: expected %+v but got %+v", test.value, test.expected, c)
+		}
+	}
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package sslcipher
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 
 	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/errors"
@@ -29,12 +32,16 @@ import (
 const (
 	sslPreferServerCipherAnnotation = "ssl-prefer-server-ciphers"
 	sslCipherAnnotation             = "ssl-ciphers"
+	sslProtocolsAnnotation          = "ssl-protocols"
 )
 
 // Should cover something like "ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP"
 // (?:@STRENGTH) is included twice so it can appear before or after @SECLEVEL=n
 var regexValidSSLCipher = regexp.MustCompile(`^(?:(?:[A-Za-z0-9!:+\-])*(?:@STRENGTH)*(?:@SECLEVEL=[0-5])*(?:@STRENGTH)*)*$`)
 
+// Should cover a list of protocols separated by spaces like "TLSv1.2 TLSv1.3"
+var regexValidSSLProtocols = regexp.MustCompile(`^(?:SSLv2|SSLv3|TLSv1|TLSv1\.1|TLSv1\.2|TLSv1\.3)(?: +(?:SSLv2|SSLv3|TLSv1|TLSv1\.1|TLSv1\.2|TLSv1\.3))*$`)
+
 var sslCipherAnnotations = parser.Annotation{
 	Group: "backend",
 	Annotations: parser.AnnotationFields{
@@ -51,6 +58,13 @@ var sslCipherAnnotations = parser.Annotation{
 			Risk:          parser.AnnotationRiskLow,
 			Documentation: `Using this annotation will set the ssl_ciphers directive at the server level. This configuration is active for all the paths in the host.`,
 		},
+		sslProtocolsAnnotation: {
+			Validator: parser.ValidateRegex(regexValidSSLProtocols, false),
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `Using this annotation will set the ssl_protocols directive at the server level, like "TLSv1.3" to require TLS 1.3 in the host.
+			This configuration is active for all the paths in the host and requires the clients to send the host name with SNI.`,
+		},
 	},
 }
 
@@ -59,10 +73,11 @@ type sslCipher struct {
 	annotationConfig parser.Annotation
 }
 
-// Config contains the ssl-ciphers & ssl-prefer-server-ciphers configuration
+// Config contains the ssl-ciphers, ssl-prefer-server-ciphers & ssl-protocols configuration
 type Config struct {
 	SSLCiphers             string
 	SSLPreferServerCiphers string
+	SSLProtocols           string
 }
 
 // NewParser creates a new sslCipher annotation parser
@@ -96,9 +111,46 @@ func (sc sslCipher) Parse(ing *networking.Ingress) (interface{}, error) {
 		return config, err
 	}
 
+	config.SSLProtocols, err = parser.GetStringAnnotation(sslProtocolsAnnotation, ing, sc.annotationConfig.Annotations)
+	if err != nil && !errors.IsInvalidContent(err) && !errors.IsMissingAnnotations(err) {
+		return config, err
+	}
+
 	return config, nil
 }
 
+// CheckNotWeaker returns an error if the configuration enables protocols or ciphers
+// not enabled by the global ssl-protocols and ssl-ciphers. Cipher strings are compared
+// by their elements, so only elements of the global list and exclusions are allowed.
+func CheckNotWeaker(config *Config, protocols, ciphers string) error {
+	if config.SSLProtocols != "" {
+		enabled := sets.New(strings.Fields(protocols)...)
+		for _, protocol := range strings.Fields(config.SSLProtocols) {
+			if !enabled.Has(protocol) {
+				return fmt.Errorf("%s annotation enables the protocol %s, not enabled by the global ssl-protocols %q",
+					parser.GetAnnotationWithPrefix(sslProtocolsAnnotation), protocol, protocols)
+			}
+		}
+	}
+
+	// an empty list uses the default ciphers of the TLS library
+	if config.SSLCiphers != "" && ciphers != "" {
+		enabled := sets.New(strings.Split(ciphers, ":")...)
+		for _, cipher := range strings.Split(config.SSLCiphers, ":") {
+			if cipher == "" || strings.HasPrefix(cipher, "!") || strings.HasPrefix(cipher, "-") {
+				continue
+			}
+
+			if !enabled.Has(cipher) {
+				return fmt.Errorf("%s annotation enables %s, not part of the global ssl-ciphers",
+					parser.GetAnnotationWithPrefix(sslCipherAnnotation), cipher)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (sc sslCipher) GetDocumentation() parser.AnnotationFields {
 	return sc.annotationConfig.Annotations
 }
@@ -35,29 +35,33 @@ func TestParse(t *testing.T) {
 
 	annotationSSLCiphers := parser.GetAnnotationWithPrefix(sslCipherAnnotation)
 	annotationSSLPreferServerCiphers := parser.GetAnnotationWithPrefix(sslPreferServerCipherAnnotation)
+	annotationSSLProtocols := parser.GetAnnotationWithPrefix(sslProtocolsAnnotation)
 
 	testCases := []struct {
 		annotations map[string]string
 		expected    Config
 		expectErr   bool
 	}{
-		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP", ""}, false},
-		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56@SECLEVEL=2:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP"}, Config{"ALL:!aNULL:!EXPORT56@SECLEVEL=2:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP", ""}, false},
-		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP@STRENGTH"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP@STRENGTH", ""}, false},
-		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP@STRENGTH@SECLEVEL=3"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP@STRENGTH@SECLEVEL=3", ""}, false},
-		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA@STRENGTH:+HIGH@SECLEVEL=5:+MEDIUM:+LOW:+SSLv2:+EXP"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA@STRENGTH:+HIGH@SECLEVEL=5:+MEDIUM:+LOW:+SSLv2:+EXP", ""}, false},
+		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP", "", ""}, false},
+		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56@SECLEVEL=2:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP"}, Config{"ALL:!aNULL:!EXPORT56@SECLEVEL=2:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP", "", ""}, false},
+		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP@STRENGTH"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP@STRENGTH", "", ""}, false},
+		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP@STRENGTH@SECLEVEL=3"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP@STRENGTH@SECLEVEL=3", "", ""}, false},
+		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA@STRENGTH:+HIGH@SECLEVEL=5:+MEDIUM:+LOW:+SSLv2:+EXP"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA@STRENGTH:+HIGH@SECLEVEL=5:+MEDIUM:+LOW:+SSLv2:+EXP", "", ""}, false},
 		{
 			map[string]string{annotationSSLCiphers: "ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:ECDHE-ECDSA-CHACHA20-POLY1305:ECDHE-RSA-CHACHA20-POLY1305:ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-SHA384:ECDHE-RSA-AES256-SHA384:ECDHE-ECDSA-AES128-SHA256:ECDHE-RSA-AES128-SHA256"},
-			Config{"ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:ECDHE-ECDSA-CHACHA20-POLY1305:ECDHE-RSA-CHACHA20-POLY1305:ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-SHA384:ECDHE-RSA-AES256-SHA384:ECDHE-ECDSA-AES128-SHA256:ECDHE-RSA-AES128-SHA256", ""},
+			Config{"ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:ECDHE-ECDSA-CHACHA20-POLY1305:ECDHE-RSA-CHACHA20-POLY1305:ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-SHA384:ECDHE-RSA-AES256-SHA384:ECDHE-ECDSA-AES128-SHA256:ECDHE-RSA-AES128-SHA256", "", ""},
 			false,
 		},
-		{map[string]string{annotationSSLCiphers: ""}, Config{"", ""}, false},
-		{map[string]string{annotationSSLPreferServerCiphers: "true"}, Config{"", "on"}, false},
-		{map[string]string{annotationSSLPreferServerCiphers: "false"}, Config{"", "off"}, false},
-		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP", annotationSSLPreferServerCiphers: "true"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP", "on"}, false},
-		{map[string]string{annotationSSLCiphers: "ALL:SOMETHING:;locationXPTO"}, Config{"", ""}, true},
-		{map[string]string{}, Config{"", ""}, false},
-		{nil, Config{"", ""}, false},
+		{map[string]string{annotationSSLCiphers: ""}, Config{"", "", ""}, false},
+		{map[string]string{annotationSSLPreferServerCiphers: "true"}, Config{"", "on", ""}, false},
+		{map[string]string{annotationSSLPreferServerCiphers: "false"}, Config{"", "off", ""}, false},
+		{map[string]string{annotationSSLCiphers: "ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP", annotationSSLPreferServerCiphers: "true"}, Config{"ALL:!aNULL:!EXPORT56:RC4+RSA:+HIGH:+MEDIUM:+LOW:+SSLv2:+EXP", "on", ""}, false},
+		{map[string]string{annotationSSLCiphers: "ALL:SOMETHING:;locationXPTO"}, Config{"", "", ""}, true},
+		{map[string]string{annotationSSLProtocols: "TLSv1.3"}, Config{"", "", "TLSv1.3"}, false},
+		{map[string]string{annotationSSLProtocols: "TLSv1.2 TLSv1.3"}, Config{"", "", "TLSv1.2 TLSv1.3"}, false},
+		{map[string]string{annotationSSLProtocols: "TLSv1.3; return 200"}, Config{"", "", ""}, true},
+		{map[string]string{}, Config{"", "", ""}, false},
+		{nil, Config{"", "", ""}, false},
 	}
 
 	ing := &networking.Ingress{
@@ -79,3 +83,33 @@ func TestParse(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckNotWeaker(t *testing.T) {
+	protocols := "TLSv1.2 TLSv1.3"
+	ciphers := "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384"
+
+	testCases := []struct {
+		config    Config
+		expectErr bool
+	}{
+		{Config{"", "", ""}, false},
+		{Config{"", "", "TLSv1.3"}, false},
+		{Config{"", "", "TLSv1.2 TLSv1.3"}, false},
+		{Config{"", "", "TLSv1.1 TLSv1.2"}, true},
+		{Config{"ECDHE-ECDSA-AES256-GCM-SHA384", "", ""}, false},
+		{Config{"ECDHE-ECDSA-AES128-GCM-SHA256:!aNULL:-MD5", "", "TLSv1.3"}, false},
+		{Config{"ECDHE-ECDSA-AES128-GCM-SHA256:AES128-SHA", "", ""}, true},
+		{Config{"ALL", "", ""}, true},
+	}
+
+	for _, tc := range testCases {
+		err := CheckNotWeaker(&tc.config, protocols, ciphers)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("expected error: %t but returned %v for %+v", tc.expectErr, err, tc.config)
+		}
+	}
+
+	if err := CheckNotWeaker(&Config{"ALL", "", ""}, protocols, ""); err != nil {
+		t.Errorf("expected no error with the default ciphers of the TLS library but returned %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subfilter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	subFilterAnnotation      = "sub-filter"
+	subFilterTypesAnnotation = "sub-filter-types"
+	subFilterOnceAnnotation  = "sub-filter-once"
+
+	separator = "=>"
+)
+
+var subFilterAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		subFilterAnnotation: {
+			Validator: validateFilters,
+			Scope:     parser.AnnotationScopeLocation,
+			Risk:      parser.AnnotationRiskMedium,
+			Documentation: `This annotation defines strings to replace in the response body, one "find => replace" pair per line.
+			The replacement can be empty to remove the string`,
+		},
+		subFilterTypesAnnotation: {
+			Validator:     parser.ValidateRegex(parser.MIMETypesRegex, false),
+			Scope:         parser.AnnotationScopeLocation,
+			Risk:          parser.AnnotationRiskLow,
+			Documentation: `This annotation defines the MIME types, separated by spaces, of the responses where the strings are replaced, in addition to text/html`,
+		},
+		subFilterOnceAnnotation: {
+			Validator:     parser.ValidateBool,
+			Scope:         parser.AnnotationScopeLocation,
+			Risk:          parser.AnnotationRiskLow,
+			Documentation: `This annotation defines if each string is replaced only once in the response body. Defaults to true`,
+		},
+	},
+}
+
+// Filter defines a string to replace in the response body
+type Filter struct {
+	Find    string `json:"find"`
+	Replace string `json:"replace"`
+}
+
+// Config contains the response body substitutions of a location
+type Config struct {
+	Filters []Filter `json:"filters,omitempty"`
+	Types   string   `json:"types,omitempty"`
+	Once    bool     `json:"once"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return slices.Equal(c1.Filters, c2.Filters) &&
+		c1.Types == c2.Types &&
+		c1.Once == c2.Once
+}
+
+type subFilter struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new sub filter annotations parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return subFilter{
+		r:                r,
+		annotationConfig: subFilterAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to replace strings in the response body
+func (s subFilter) Parse(ing *networking.Ingress) (interface{}, error) {
+	config := &Config{Once: true}
+
+	val, err := parser.GetStringAnnotation(subFilterAnnotation, ing, s.annotationConfig.Annotations)
+	if err != nil {
+		if ing_errors.IsMissingAnnotations(err) {
+			return config, nil
+		}
+		return config, err
+	}
+
+	config.Filters, err = parseFilters(val)
+	if err != nil {
+		return &Config{Once: true}, ing_errors.NewLocationDenied(err.Error())
+	}
+
+	config.Types, err = parser.GetStringAnnotation(subFilterTypesAnnotation, ing, s.annotationConfig.Annotations)
+	if err != nil && !ing_errors.IsMissingAnnotations(err) {
+		return config, err
+	}
+
+	once, err := parser.GetBoolAnnotation(subFilterOnceAnnotation, ing, s.annotationConfig.Annotations)
+	if err != nil && !ing_errors.IsMissingAnnotations(err) {
+		return config, err
+	}
+	if err == nil {
+		config.Once = once
+	}
+
+	return config, nil
+}
+
+// parseFilters parses one "find => replace" pair per line
+func parseFilters(value string) ([]Filter, error) {
+	filters := []Filter{}
+
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		find, replace, found := strings.Cut(line, separator)
+		if !found {
+			return nil, fmt.Errorf("invalid sub filter %q, expected \"find %s replace\"", line, separator)
+		}
+
+		find = strings.TrimSpace(find)
+		replace = strings.TrimSpace(replace)
+		if find == "" {
+			return nil, fmt.Errorf("invalid sub filter %q, the string to find is empty", line)
+		}
+		if strings.ContainsFunc(line, unicode.IsControl) {
+			return nil, fmt.Errorf("invalid sub filter %q, control characters are not allowed", line)
+		}
+
+		filters = append(filters, Filter{Find: find, Replace: replace})
+	}
+
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("no sub filters defined")
+	}
+
+	return filters, nil
+}
+
+func validateFilters(value string) error {
+	_, err := parseFilters(value)
+	return err
+}
+
+func (s subFilter) GetDocumentation() parser.AnnotationFields {
+	return s.annotationConfig.Annotations
+}
+
+func (s subFilter) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(s.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, subFilterAnnotations.Annotations)
+}
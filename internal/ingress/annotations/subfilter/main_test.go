@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subfilter
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+func buildIngress() *networking.Ingress {
+	return &networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "foo",
+			Namespace: api.NamespaceDefault,
+		},
+		Spec: networking.IngressSpec{
+			DefaultBackend: &networking.IngressBackend{
+				Service: &networking.IngressServiceBackend{
+					Name: "default-backend",
+					Port: networking.ServiceBackendPort{
+						Number: 80,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	ing := buildIngress()
+
+	tests := []struct {
+		title       string
+		annotations map[string]string
+		expected    *Config
+		expectErr   bool
+	}{
+		{"no annotations", map[string]string{}, &Config{Once: true}, false},
+		{
+			"filters",
+			map[string]string{subFilterAnnotation: "http://legacy.local => https://app.example.com\n\n  <a href=\"/old\"> => <a href=\"/new\">\n"},
+			&Config{
+				Filters: []Filter{
+					{Find: "http://legacy.local", Replace: "https://app.example.com"},
+					{Find: `<a href="/old">`, Replace: `<a href="/new">`},
+				},
+				Once: true,
+			},
+			false,
+		},
+		{
+			"empty replacement, types and once",
+			map[string]string{
+				subFilterAnnotation:      "<!-- debug --> =>",
+				subFilterTypesAnnotation: "application/javascript text/css",
+				subFilterOnceAnnotation:  "false",
+			},
+			&Config{
+				Filters: []Filter{{Find: "<!-- debug -->", Replace: ""}},
+				Types:   "application/javascript text/css",
+			},
+			false,
+		},
+		{"types without filters", map[string]string{subFilterTypesAnnotation: "text/css"}, &Config{Once: true}, false},
+		{"missing separator", map[string]string{subFilterAnnotation: "http://legacy.local"}, nil, true},
+		{"empty find", map[string]string{subFilterAnnotation: "=> https://app.example.com"}, nil, true},
+		{"control characters", map[string]string{subFilterAnnotation: "a\tb => c"}, nil, true},
+		{"invalid types", map[string]string{subFilterAnnotation: "a => b", subFilterTypesAnnotation: "text/css; sub_filter_once off"}, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			data := map[string]string{}
+			for k, v := range test.annotations {
+				data[parser.GetAnnotationWithPrefix(k)] = v
+			}
+			ing.SetAnnotations(data)
+
+			i, err := NewParser(&resolver.Mock{}).Parse(ing)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			c, ok := i.(*Config)
+			if !ok {
+				t.Fatalf("expected a *Config type")
+			}
+			if !c.Equal(test.expected) {
+				t.Errorf("expected %v but got %v", test.expected, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadguard
+
+import (
+	"strconv"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+)
+
+const (
+	maxConcurrentUploadsAnnotation = "max-concurrent-uploads"
+	uploadMinSizeAnnotation        = "upload-min-size"
+)
+
+// defaultMinSize is the size of the request bodies counted as uploads
+// when the upload-min-size annotation is not defined
+const defaultMinSize = "1m"
+
+var uploadGuardAnnotations = parser.Annotation{
+	Group: "backend",
+	Annotations: parser.AnnotationFields{
+		maxConcurrentUploadsAnnotation: {
+			Validator: parser.ValidateInt,
+			Scope:     parser.AnnotationScopeIngress,
+			Risk:      parser.AnnotationRiskLow,
+			Documentation: `This annotation limits the number of simultaneous uploads of a client IP address to the Ingress.
+			Uploads over the limit are rejected with the 429 status code`,
+		},
+		uploadMinSizeAnnotation: {
+			Validator:     parser.ValidateRegex(parser.SizeRegex, true),
+			Scope:         parser.AnnotationScopeIngress,
+			Risk:          parser.AnnotationRiskLow,
+			Documentation: `This annotation defines the size of the request bodies counted as uploads, like 10m. Default is 1m`,
+		},
+	},
+}
+
+// Config contains the limit of simultaneous uploads of a location
+type Config struct {
+	// MaxConcurrent is the number of simultaneous uploads allowed per client IP address.
+	// Zero disables the limit.
+	MaxConcurrent int `json:"maxConcurrent"`
+	// MinSize is the size in bytes of the request bodies counted as uploads.
+	// Requests with a chunked body are always counted.
+	MinSize int64 `json:"minSize"`
+}
+
+// Equal tests for equality between two Config types
+func (c1 *Config) Equal(c2 *Config) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+
+	return *c1 == *c2
+}
+
+type uploadGuard struct {
+	r                resolver.Resolver
+	annotationConfig parser.Annotation
+}
+
+// NewParser creates a new upload guard annotations parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return uploadGuard{
+		r:                r,
+		annotationConfig: uploadGuardAnnotations,
+	}
+}
+
+// Parse parses the annotations contained in the ingress rule
+// used to limit the simultaneous uploads
+func (a uploadGuard) Parse(ing *networking.Ingress) (interface{}, error) {
+	maxConcurrent, err := parser.GetIntAnnotation(maxConcurrentUploadsAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil {
+		if ing_errors.IsMissingAnnotations(err) {
+			return &Config{}, nil
+		}
+		return &Config{}, err
+	}
+	if maxConcurrent < 0 {
+		return &Config{}, ing_errors.NewInvalidAnnotationContent(maxConcurrentUploadsAnnotation, maxConcurrent)
+	}
+	if maxConcurrent == 0 {
+		return &Config{}, nil
+	}
+
+	size, err := parser.GetStringAnnotation(uploadMinSizeAnnotation, ing, a.annotationConfig.Annotations)
+	if err != nil {
+		if !ing_errors.IsMissingAnnotations(err) {
+			return &Config{}, err
+		}
+		size = defaultMinSize
+	}
+
+	minSize, err := sizeToBytes(size)
+	if err != nil {
+		return &Config{}, ing_errors.NewInvalidAnnotationContent(uploadMinSizeAnnotation, size)
+	}
+
+	return &Config{
+		MaxConcurrent: maxConcurrent,
+		MinSize:       minSize,
+	}, nil
+}
+
+// sizeToBytes converts a size understood by NGINX, like 10m, to bytes
+func sizeToBytes(size string) (int64, error) {
+	size = strings.ToLower(size)
+
+	multiplier := int64(1)
+	switch size[len(size)-1] {
+	case 'b':
+		size = size[:len(size)-1]
+	case 'k':
+		multiplier = 1 << 10
+		size = size[:len(size)-1]
+	case 'm':
+		multiplier = 1 << 20
+		size = size[:len(size)-1]
+	case 'g':
+		multiplier = 1 << 30
+		size = size[:len(size)-1]
+	}
+
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return n * multiplier, nil
+}
+
+func (a uploadGuard) GetDocumentation() parser.AnnotationFields {
+	return a.annotationConfig.Annotations
+}
+
+func (a uploadGuard) Validate(anns map[string]string) error {
+	maxrisk := parser.StringRiskToRisk(a.r.GetSecurityConfiguration().AnnotationsRiskLevel)
+	return parser.CheckAnnotationRisk(anns, maxrisk, uploadGuardAnnotations.Annotations)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package autotune sizes the worker processes, connections and buffers of
+// NGINX from the CPU and memory limits of the pod and the number of
+// connections observed, instead of defaults that assume a whole node.
+package autotune
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	klog "k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+)
+
+// Path is the path of the endpoint returning the values chosen by the tuner
+const Path = "/autotune"
+
+const (
+	mib = 1024 * 1024
+	gib = 1024 * mib
+
+	// connectionMemory is an estimation of the memory used by a connection
+	// proxied with the default buffers: header and body buffers of the
+	// client plus the proxy buffers of the upstream connection
+	connectionMemory = 64 * 1024
+
+	// connectionsMemoryRatio is the part of the memory limit used for connections,
+	// the rest is left for the Lua shared dicts, caches and the controller
+	connectionsMemoryRatio = 0.5
+
+	minWorkerConnections = 1024
+	maxWorkerConnections = 65536
+
+	minKeepaliveConnections = 32
+	maxKeepaliveConnections = 320
+)
+
+// Limits are the resources available to NGINX
+type Limits struct {
+	// CPUs is the number of CPUs usable by the pod
+	CPUs int `json:"cpus"`
+	// Memory is the limit of memory in bytes of the pod, or -1 if there is no limit
+	Memory int64 `json:"memory"`
+}
+
+// Values are the settings chosen by the tuner
+type Values struct {
+	WorkerProcesses              int    `json:"worker-processes"`
+	MaxWorkerConnections         int    `json:"max-worker-connections"`
+	UpstreamKeepaliveConnections int    `json:"upstream-keepalive-connections"`
+	ClientHeaderBufferSize       string `json:"client-header-buffer-size"`
+	ClientBodyBufferSize         string `json:"client-body-buffer-size"`
+	LargeClientHeaderBuffers     string `json:"large-client-header-buffers"`
+}
+
+// Compute returns the settings for the given limits and the
+// highest number of active connections observed
+func Compute(limits Limits, observedConnections int) Values {
+	workers := limits.CPUs
+	if workers < 1 {
+		workers = 1
+	}
+
+	v := Values{
+		WorkerProcesses:          workers,
+		MaxWorkerConnections:     16384,
+		ClientHeaderBufferSize:   "1k",
+		ClientBodyBufferSize:     "8k",
+		LargeClientHeaderBuffers: "4 8k",
+	}
+
+	if limits.Memory > 0 {
+		connections := int(float64(limits.Memory)*connectionsMemoryRatio) / connectionMemory
+		v.MaxWorkerConnections = connections / workers
+
+		// bigger buffers avoid writing requests to temporary files when there is enough memory
+		if limits.Memory/int64(workers) >= gib {
+			v.ClientHeaderBufferSize = "2k"
+			v.ClientBodyBufferSize = "16k"
+			v.LargeClientHeaderBuffers = "4 16k"
+		}
+	}
+
+	// leave room to double the connections observed, even over the memory estimation
+	if perWorker := 2 * observedConnections / workers; perWorker > v.MaxWorkerConnections {
+		v.MaxWorkerConnections = perWorker
+	}
+
+	v.MaxWorkerConnections = clamp(v.MaxWorkerConnections, minWorkerConnections, maxWorkerConnections)
+	v.UpstreamKeepaliveConnections = clamp(v.MaxWorkerConnections/32, minKeepaliveConnections, maxKeepaliveConnections)
+
+	return v
+}
+
+func clamp(value, lowest, highest int) int {
+	if value < lowest {
+		return lowest
+	}
+
+	if value > highest {
+		return highest
+	}
+
+	return value
+}
+
+// Status is the information returned by the endpoint of the tuner
+type Status struct {
+	Enabled             bool    `json:"enabled"`
+	Limits              Limits  `json:"limits"`
+	ObservedConnections int     `json:"observedConnections"`
+	Values              *Values `json:"values,omitempty"`
+}
+
+// Tuner keeps the highest number of connections observed
+// and the settings chosen for the last configuration
+type Tuner struct {
+	limits Limits
+
+	mu     sync.Mutex
+	status Status
+}
+
+// NewTuner returns a tuner for the given limits
+func NewTuner(limits Limits) *Tuner {
+	return &Tuner{
+		limits: limits,
+		status: Status{Limits: limits},
+	}
+}
+
+// Apply sets the settings chosen by the tuner in the configuration when auto-tune is enabled.
+// The observed connections only increase the settings, to avoid shrinking NGINX when the load drops.
+func (t *Tuner) Apply(cfg *config.Configuration, observedConnections int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.status.Enabled = cfg.AutoTune
+	if observedConnections > t.status.ObservedConnections {
+		t.status.ObservedConnections = observedConnections
+	}
+
+	if !cfg.AutoTune {
+		t.status.Values = nil
+		return
+	}
+
+	v := Compute(t.limits, t.status.ObservedConnections)
+	if t.status.Values == nil || *t.status.Values != v {
+		klog.InfoS("Auto-tuning NGINX", "limits", t.limits, "observedConnections", t.status.ObservedConnections, "values", v)
+	}
+	t.status.Values = &v
+
+	cfg.WorkerProcesses = strconv.Itoa(v.WorkerProcesses)
+	cfg.MaxWorkerConnections = v.MaxWorkerConnections
+	cfg.UpstreamKeepaliveConnections = v.UpstreamKeepaliveConnections
+	cfg.ClientHeaderBufferSize = v.ClientHeaderBufferSize
+	cfg.ClientBodyBufferSize = v.ClientBodyBufferSize
+	cfg.LargeClientHeaderBuffers = v.LargeClientHeaderBuffers
+}
+
+// Status returns the limits, observed connections and settings chosen by the tuner
+func (t *Tuner) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := t.status
+	if status.Values != nil {
+		v := *status.Values
+		status.Values = &v
+	}
+
+	return status
+}
+
+// Register exposes the status of the tuner in the given mux
+func Register(mux *http.ServeMux, t *Tuner) {
+	mux.HandleFunc(Path, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(t.Status()); err != nil {
+			klog.V(2).ErrorS(err, "Error writing auto-tune status")
+		}
+	})
+}
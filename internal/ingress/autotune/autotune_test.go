@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autotune
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+)
+
+func TestCompute(t *testing.T) {
+	testCases := []struct {
+		name                string
+		limits              Limits
+		observedConnections int
+		expected            Values
+	}{
+		{
+			name:   "without memory limit",
+			limits: Limits{CPUs: 4, Memory: -1},
+			expected: Values{
+				WorkerProcesses:              4,
+				MaxWorkerConnections:         16384,
+				UpstreamKeepaliveConnections: 320,
+				ClientHeaderBufferSize:       "1k",
+				ClientBodyBufferSize:         "8k",
+				LargeClientHeaderBuffers:     "4 8k",
+			},
+		},
+		{
+			name:   "small pod",
+			limits: Limits{CPUs: 1, Memory: 128 * mib},
+			expected: Values{
+				WorkerProcesses:              1,
+				MaxWorkerConnections:         1024,
+				UpstreamKeepaliveConnections: 32,
+				ClientHeaderBufferSize:       "1k",
+				ClientBodyBufferSize:         "8k",
+				LargeClientHeaderBuffers:     "4 8k",
+			},
+		},
+		{
+			name:   "pod with plenty of memory per worker",
+			limits: Limits{CPUs: 2, Memory: 4 * gib},
+			expected: Values{
+				WorkerProcesses:              2,
+				MaxWorkerConnections:         16384,
+				UpstreamKeepaliveConnections: 320,
+				ClientHeaderBufferSize:       "2k",
+				ClientBodyBufferSize:         "16k",
+				LargeClientHeaderBuffers:     "4 16k",
+			},
+		},
+		{
+			name:                "observed connections over the memory estimation",
+			limits:              Limits{CPUs: 2, Memory: 256 * mib},
+			observedConnections: 6000,
+			expected: Values{
+				WorkerProcesses:              2,
+				MaxWorkerConnections:         6000,
+				UpstreamKeepaliveConnections: 187,
+				ClientHeaderBufferSize:       "1k",
+				ClientBodyBufferSize:         "8k",
+				LargeClientHeaderBuffers:     "4 8k",
+			},
+		},
+		{
+			name:   "without CPUs",
+			limits: Limits{CPUs: 0, Memory: -1},
+			expected: Values{
+				WorkerProcesses:              1,
+				MaxWorkerConnections:         16384,
+				UpstreamKeepaliveConnections: 320,
+				ClientHeaderBufferSize:       "1k",
+				ClientBodyBufferSize:         "8k",
+				LargeClientHeaderBuffers:     "4 8k",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if v := Compute(tc.limits, tc.observedConnections); v != tc.expected {
+				t.Errorf("expected %+v but returned %+v", tc.expected, v)
+			}
+		})
+	}
+}
+
+func TestTunerApply(t *testing.T) {
+	tuner := NewTuner(Limits{CPUs: 2, Memory: 256 * mib})
+
+	cfg := config.NewDefault()
+	tuner.Apply(&cfg, 100)
+	if cfg.MaxWorkerConnections != 16384 || tuner.Status().Values != nil {
+		t.Errorf("expected the configuration not to change with auto-tune disabled")
+	}
+
+	cfg.AutoTune = true
+	tuner.Apply(&cfg, 6000)
+	if cfg.WorkerProcesses != "2" || cfg.MaxWorkerConnections != 6000 {
+		t.Errorf("unexpected worker settings %v and %v", cfg.WorkerProcesses, cfg.MaxWorkerConnections)
+	}
+
+	// the settings do not shrink when the load drops
+	tuner.Apply(&cfg, 10)
+	if cfg.MaxWorkerConnections != 6000 {
+		t.Errorf("expected 6000 worker connections but returned %v", cfg.MaxWorkerConnections)
+	}
+
+	mux := http.NewServeMux()
+	Register(mux, tuner)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, http.NoBody))
+
+	var status Status
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !status.Enabled || status.ObservedConnections != 6000 || status.Values == nil || status.Values.MaxWorkerConnections != 6000 {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package confighistory keeps the last generations of nginx.conf on disk
+// to compare the configurations applied before and after an incident.
+package confighistory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/redact"
+	"k8s.io/ingress-nginx/pkg/util/file"
+)
+
+// Path is the path of the endpoint exporting the generations of nginx.conf
+const Path = "/configuration-history"
+
+// ErrNotFound is returned when the generation is not kept in the history
+var ErrNotFound = errors.New("generation not found")
+
+// Generation is the metadata of a version of nginx.conf applied with a reload
+type Generation struct {
+	// Generation increases with each reload, across the restarts of the controller
+	Generation int       `json:"generation"`
+	Time       time.Time `json:"time"`
+	// Trigger is the reason of the reload, like sync or crash-recovery
+	Trigger string `json:"trigger"`
+	// Ingresses are the Ingresses created, changed or deleted since the previous reload
+	Ingresses []string `json:"ingresses,omitempty"`
+	// Checksum is the checksum of the configuration of the controller
+	Checksum string `json:"checksum"`
+}
+
+// History keeps the last generations of nginx.conf in a directory
+type History struct {
+	mu          sync.Mutex
+	dir         string
+	size        int
+	retention   time.Duration
+	generations []Generation
+}
+
+// New returns a history keeping up to size generations in dir, removing the
+// ones older than retention unless it is 0. The generations already in dir,
+// written before a restart, are kept.
+func New(dir string, size int, retention time.Duration) *History {
+	h := &History{
+		dir:       dir,
+		size:      size,
+		retention: retention,
+	}
+
+	metadata, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		klog.Warningf("Error listing the configuration history in %v: %v", dir, err)
+		return h
+	}
+
+	for _, path := range metadata {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			klog.Warningf("Error reading the configuration history: %v", err)
+			continue
+		}
+
+		var g Generation
+		if err := json.Unmarshal(b, &g); err != nil {
+			klog.Warningf("Error decoding %v: %v", path, err)
+			continue
+		}
+		h.generations = append(h.generations, g)
+	}
+
+	sort.Slice(h.generations, func(i, j int) bool {
+		return h.generations[i].Generation < h.generations[j].Generation
+	})
+
+	return h
+}
+
+func (h *History) path(generation int, ext string) string {
+	return filepath.Join(h.dir, strconv.Itoa(generation)+ext)
+}
+
+// Add records the content of nginx.conf as a new generation, removing the
+// oldest ones over the size or the retention of the history
+func (h *History) Add(g Generation, content []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	g.Generation = 1
+	if len(h.generations) > 0 {
+		g.Generation = h.generations[len(h.generations)-1].Generation + 1
+	}
+
+	metadata, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(h.dir, file.ReadWriteByUser); err != nil {
+		return err
+	}
+	if err := os.WriteFile(h.path(g.Generation, ".conf"), content, file.ReadWriteByUser); err != nil {
+		return err
+	}
+	// the metadata is written last, a generation without it is ignored
+	if err := os.WriteFile(h.path(g.Generation, ".json"), metadata, file.ReadWriteByUser); err != nil {
+		return err
+	}
+
+	h.generations = append(h.generations, g)
+	h.prune(g.Time)
+
+	return nil
+}
+
+// prune removes the oldest generations over the size or the retention, keeping the last one
+func (h *History) prune(now time.Time) {
+	removed := 0
+	for i, g := range h.generations[:len(h.generations)-1] {
+		if len(h.generations)-i <= h.size && (h.retention == 0 || now.Sub(g.Time) <= h.retention) {
+			break
+		}
+
+		for _, ext := range []string{".json", ".conf"} {
+			if err := os.Remove(h.path(g.Generation, ext)); err != nil && !os.IsNotExist(err) {
+				klog.Warningf("Error removing generation %v of the configuration history: %v", g.Generation, err)
+			}
+		}
+		removed++
+	}
+
+	h.generations = h.generations[removed:]
+}
+
+// List returns the generations kept, from the oldest to the newest
+func (h *History) List() []Generation {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]Generation(nil), h.generations...)
+}
+
+// index returns the position of a generation in the history. A negative
+// generation counts from the newest one, -1 being the newest.
+func (h *History) index(generation int) (int, error) {
+	if generation < 0 {
+		if i := len(h.generations) + generation; i >= 0 {
+			return i, nil
+		}
+		return 0, ErrNotFound
+	}
+
+	for i, g := range h.generations {
+		if g.Generation == generation {
+			return i, nil
+		}
+	}
+
+	return 0, ErrNotFound
+}
+
+func (h *History) read(i int) (*Generation, []byte, error) {
+	g := h.generations[i]
+	content, err := os.ReadFile(h.path(g.Generation, ".conf"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &g, content, nil
+}
+
+// Get returns a generation and its nginx.conf
+func (h *History) Get(generation int) (*Generation, []byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	i, err := h.index(generation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return h.read(i)
+}
+
+// Diff returns the changes of nginx.conf made by a generation, as a unified diff
+// against the previous generation
+func (h *History) Diff(generation int) (string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	i, err := h.index(generation)
+	if err != nil {
+		return "", err
+	}
+	if i == 0 {
+		return "", fmt.Errorf("%w: generation %v is the oldest one kept", ErrNotFound, h.generations[i].Generation)
+	}
+
+	previous, from, err := h.read(i - 1)
+	if err != nil {
+		return "", err
+	}
+	g, to, err := h.read(i)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(from)),
+		B:        difflib.SplitLines(string(to)),
+		FromFile: fmt.Sprintf("generation %v (%v)", previous.Generation, previous.Time.Format(time.RFC3339)),
+		ToFile:   fmt.Sprintf("generation %v (%v)", g.Generation, g.Time.Format(time.RFC3339)),
+		Context:  3,
+	})
+}
+
+// Register exposes the history in the given mux, without credentials. Without
+// query parameters it returns the metadata of the generations, with generation
+// the redacted nginx.conf of the generation, and with diff its changes.
+// A nil history is disabled.
+func Register(mux *http.ServeMux, h *History) {
+	mux.HandleFunc(Path, func(w http.ResponseWriter, r *http.Request) {
+		if h == nil {
+			http.Error(w, "the configuration history is disabled, see the flag --config-history-size", http.StatusNotFound)
+			return
+		}
+
+		query := r.URL.Query()
+		if !query.Has("generation") {
+			b, err := json.MarshalIndent(h.List(), "", "  ")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			if _, err := w.Write(append(b, '\n')); err != nil {
+				klog.V(2).ErrorS(err, "Error writing the configuration history")
+			}
+			return
+		}
+
+		generation, err := strconv.Atoi(query.Get("generation"))
+		if err != nil || generation == 0 {
+			http.Error(w, "the query parameter generation must be a generation or a negative offset from the newest one", http.StatusBadRequest)
+			return
+		}
+
+		var out string
+		if diff, _ := strconv.ParseBool(query.Get("diff")); diff {
+			out, err = h.Diff(generation)
+		} else {
+			var content []byte
+			_, content, err = h.Get(generation)
+			out = string(content)
+		}
+		if errors.Is(err, ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if _, err := w.Write([]byte(redact.String(out))); err != nil {
+			klog.V(2).ErrorS(err, "Error writing the configuration history")
+		}
+	})
+}
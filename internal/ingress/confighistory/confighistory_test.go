@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package confighistory
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	h := New(dir, 3, 0)
+	for i, content := range []string{"a\n", "b\n", "c\n", "d\n"} {
+		g := Generation{Time: now.Add(time.Duration(i) * time.Minute), Trigger: "sync", Checksum: content[:1]}
+		if err := h.Add(g, []byte(content)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	generations := h.List()
+	if len(generations) != 3 || generations[0].Generation != 2 || generations[2].Generation != 4 {
+		t.Fatalf("expected the generations 2 to 4 but got %v", generations)
+	}
+
+	if _, _, err := h.Get(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the generation 1 to be removed but got %v", err)
+	}
+
+	g, content, err := h.Get(-2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Generation != 3 || string(content) != "c\n" {
+		t.Errorf("expected the generation 3 but got %v with %q", g.Generation, content)
+	}
+
+	if _, _, err := h.Get(-4); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected an error getting a generation out of the history but got %v", err)
+	}
+
+	diff, err := h.Diff(-1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(diff, "-c\n") || !strings.Contains(diff, "+d\n") {
+		t.Errorf("unexpected diff:\n%v", diff)
+	}
+	if _, err := h.Diff(2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected an error comparing the oldest generation but got %v", err)
+	}
+
+	// the generations are kept after a restart
+	h = New(dir, 3, time.Hour)
+	if generations := h.List(); len(generations) != 3 || generations[2].Generation != 4 {
+		t.Fatalf("expected the generations to be loaded from the directory but got %v", generations)
+	}
+
+	if err := h.Add(Generation{Time: now.Add(2 * time.Hour), Trigger: "sync"}, []byte("e\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if generations := h.List(); len(generations) != 1 || generations[0].Generation != 5 {
+		t.Errorf("expected the generations older than the retention to be removed but got %v", generations)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	h := New(t.TempDir(), 5, 0)
+	for _, content := range []string{"a\n", "b\n"} {
+		if err := h.Add(Generation{Time: time.Now(), Trigger: "sync"}, []byte(content)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	Register(mux, h)
+
+	testCases := []struct {
+		query  string
+		status int
+		body   string
+	}{
+		{"", http.StatusOK, `"generation": 2`},
+		{"?generation=x", http.StatusBadRequest, ""},
+		{"?generation=0", http.StatusBadRequest, ""},
+		{"?generation=3", http.StatusNotFound, ""},
+		{"?generation=1", http.StatusOK, "a\n"},
+		{"?generation=-1&diff=true", http.StatusOK, "+b\n"},
+		{"?generation=1&diff=true", http.StatusNotFound, ""},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path+tc.query, http.NoBody))
+		if w.Code != tc.status {
+			t.Errorf("%v: expected status %v but got %v", tc.query, tc.status, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), tc.body) {
+			t.Errorf("%v: expected %q in the response but got %q", tc.query, tc.body, w.Body.String())
+		}
+	}
+
+	var generations []Generation
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, http.NoBody))
+	if err := json.Unmarshal(w.Body.Bytes(), &generations); err != nil || len(generations) != 2 {
+		t.Errorf("expected the list of the generations but got %v: %v", generations, err)
+	}
+
+	disabled := http.NewServeMux()
+	Register(disabled, nil)
+	w = httptest.NewRecorder()
+	disabled.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, http.NoBody))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %v with the history disabled but got %v", http.StatusNotFound, w.Code)
+	}
+}
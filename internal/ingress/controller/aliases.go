@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// checkAliasCollisions returns an error when a server alias of the Ingress
+// matches a host of a server defined by another Ingress, or a host or a
+// server alias of the Ingress matches a server alias of another Ingress.
+// Aliases of the servers shared by both Ingresses do not collide.
+func checkAliasCollisions(ing *ingress.Ingress, ingresses []*ingress.Ingress) error {
+	hosts, aliases := ingressServerNames(ing)
+
+	for _, other := range ingresses {
+		if other.Namespace == ing.Namespace && other.Name == ing.Name {
+			continue
+		}
+
+		otherHosts, otherAliases := ingressServerNames(other)
+
+		for _, alias := range aliases {
+			for _, host := range otherHosts.UnsortedList() {
+				if !hosts.Has(host) && serverNameMatches(alias, host) {
+					return fmt.Errorf("server alias %q collides with host %q defined in ingress %v", alias, host, k8s.MetaNamespaceKey(&other.Ingress))
+				}
+			}
+
+			if hosts.HasAny(otherHosts.UnsortedList()...) {
+				continue
+			}
+
+			for _, otherAlias := range otherAliases {
+				if serverNameMatches(alias, otherAlias) || serverNameMatches(otherAlias, alias) {
+					return fmt.Errorf("server alias %q collides with server alias %q defined in ingress %v", alias, otherAlias, k8s.MetaNamespaceKey(&other.Ingress))
+				}
+			}
+		}
+
+		for _, otherAlias := range otherAliases {
+			for _, host := range hosts.UnsortedList() {
+				if !otherHosts.Has(host) && serverNameMatches(otherAlias, host) {
+					return fmt.Errorf("host %q collides with server alias %q defined in ingress %v", host, otherAlias, k8s.MetaNamespaceKey(&other.Ingress))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ingressServerNames returns the hosts of the rules and the server aliases of an Ingress
+func ingressServerNames(ing *ingress.Ingress) (hosts sets.Set[string], aliases []string) {
+	hosts = sets.New[string]()
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" {
+			hosts.Insert(rule.Host)
+		}
+	}
+
+	if ing.ParsedAnnotations != nil {
+		aliases = ing.ParsedAnnotations.Aliases
+	}
+
+	return hosts, aliases
+}
+
+// serverNameMatches checks if the server name pattern, which can be a
+// wildcard or a regular expression, matches the name. Two patterns only
+// match when they are the same.
+func serverNameMatches(pattern, name string) bool {
+	if strings.EqualFold(pattern, name) {
+		return true
+	}
+
+	if strings.HasPrefix(name, "~") || strings.Contains(name, "*") || strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(pattern, "~"):
+		// NGINX matches the regular expressions of server names ignoring the case
+		re, err := regexp.Compile("(?i)" + pattern[1:])
+		return err == nil && re.MatchString(name)
+	case strings.HasPrefix(pattern, "*."):
+		return strings.HasSuffix(name, strings.ToLower(pattern[1:]))
+	case strings.HasPrefix(pattern, "."):
+		return name == strings.ToLower(pattern[1:]) || strings.HasSuffix(name, strings.ToLower(pattern))
+	case strings.HasSuffix(pattern, ".*"):
+		return strings.HasPrefix(name, strings.ToLower(pattern[:len(pattern)-1]))
+	}
+
+	return false
+}
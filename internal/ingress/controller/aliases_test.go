@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func newAliasIngress(name, host string, aliases ...string) *ingress.Ingress {
+	return &ingress.Ingress{
+		Ingress: networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: networking.IngressSpec{
+				Rules: []networking.IngressRule{{Host: host}},
+			},
+		},
+		ParsedAnnotations: &annotations.Ingress{Aliases: aliases},
+	}
+}
+
+func TestCheckAliasCollisions(t *testing.T) {
+	existing := []*ingress.Ingress{
+		newAliasIngress("shop", "shop.example.com", "store.example.com", `~^shop-\d+\.example\.com$`),
+		newAliasIngress("blog", "blog.example.com", "*.blog.example.com"),
+	}
+
+	testCases := []struct {
+		name     string
+		ingress  *ingress.Ingress
+		expected string
+	}{
+		{"no aliases", newAliasIngress("api", "api.example.com"), ""},
+		{"unique alias", newAliasIngress("api", "api.example.com", "api.example.org"), ""},
+		{"same ingress", newAliasIngress("shop", "shop.example.com", "store.example.com"), ""},
+		{"alias of a shared host", newAliasIngress("cart", "shop.example.com", "store.example.com"), ""},
+		{
+			"alias matches a host", newAliasIngress("api", "api.example.com", "Shop.example.com"),
+			`server alias "Shop.example.com" collides with host "shop.example.com" defined in ingress default/shop`,
+		},
+		{
+			"regex alias matches a host", newAliasIngress("api", "api.example.com", `~^(blog|api)\.example\.com$`),
+			`server alias "~^(blog|api)\\.example\\.com$" collides with host "blog.example.com" defined in ingress default/blog`,
+		},
+		{
+			"alias matches an alias", newAliasIngress("api", "api.example.com", "store.example.com"),
+			`server alias "store.example.com" collides with server alias "store.example.com" defined in ingress default/shop`,
+		},
+		{
+			"alias matches a wildcard alias", newAliasIngress("api", "api.example.com", "www.blog.example.com"),
+			`server alias "www.blog.example.com" collides with server alias "*.blog.example.com" defined in ingress default/blog`,
+		},
+		{
+			"host matches a regex alias", newAliasIngress("api", "shop-1.example.com"),
+			`host "shop-1.example.com" collides with server alias "~^shop-\\d+\\.example\\.com$" defined in ingress default/shop`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkAliasCollisions(tc.ingress, existing)
+			if tc.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error %q but returned %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// backendProtocolProbeTimeout is the time to wait for the response of an endpoint
+const backendProtocolProbeTimeout = 5 * time.Second
+
+// backendProtocolProbe is a location using HTTP to reach a backend
+type backendProtocolProbe struct {
+	ingress *ingress.Ingress
+	backend string
+	host    string
+	path    string
+}
+
+func (p *backendProtocolProbe) key() string {
+	return fmt.Sprintf("%v/%v", k8s.MetaNamespaceKey(p.ingress), p.backend)
+}
+
+// watchBackendProtocols periodically sends a request to an endpoint of the backends
+// using the HTTP protocol until the controller stops, emitting an event in the Ingress
+// when the backend redirects it to HTTPS
+func (n *NGINXController) watchBackendProtocols() {
+	client := &http.Client{
+		Timeout: backendProtocolProbeTimeout,
+		// the redirect is the response checked
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	warned := map[string]bool{}
+
+	wait.Until(func() {
+		running := n.runningConfig.Load()
+		if running == nil {
+			return
+		}
+
+		redirecting := map[string]bool{}
+		for _, p := range backendProtocolProbes(running) {
+			key := p.key()
+			if redirecting[key] {
+				continue
+			}
+
+			address, ok := backendEndpoint(running.Backends, p.backend)
+			if !ok {
+				continue
+			}
+
+			redirects, err := probeRedirectsToHTTPS(client, address, p.host, p.path)
+			if err != nil {
+				klog.V(3).ErrorS(err, "Error probing the protocol of the backend", "ingress", k8s.MetaNamespaceKey(p.ingress), "backend", p.backend)
+				continue
+			}
+
+			if !redirects {
+				continue
+			}
+
+			redirecting[key] = true
+			if warned[key] {
+				continue
+			}
+
+			msg := fmt.Sprintf("Backend %v redirects HTTP requests to HTTPS, consider using the annotation nginx.ingress.kubernetes.io/backend-protocol: \"HTTPS\"", p.backend)
+			klog.Warningf("%v (Ingress %v)", msg, k8s.MetaNamespaceKey(p.ingress))
+			n.recorder.Eventf(&p.ingress.Ingress, apiv1.EventTypeWarning, "BackendProtocolMismatch", msg)
+		}
+
+		// a backend warned again after it stops redirecting and starts again
+		warned = redirecting
+	}, n.cfg.BackendProtocolProbeInterval, n.stopCh)
+}
+
+// backendProtocolProbes returns the locations of the configuration using HTTP to reach their backend
+func backendProtocolProbes(cfg *ingress.Configuration) []*backendProtocolProbe {
+	probes := []*backendProtocolProbe{}
+	for _, server := range cfg.Servers {
+		if server.SSLPassthrough {
+			continue
+		}
+
+		host := server.Hostname
+		if host == defServerName {
+			host = ""
+		}
+
+		for _, loc := range server.Locations {
+			if loc.IsDefBackend || loc.Ingress == nil || !strings.EqualFold(loc.BackendProtocol, "HTTP") {
+				continue
+			}
+
+			path := loc.IngressPath
+			if !strings.HasPrefix(path, "/") {
+				path = "/"
+			}
+
+			probes = append(probes, &backendProtocolProbe{
+				ingress: loc.Ingress,
+				backend: loc.Backend,
+				host:    host,
+				path:    path,
+			})
+		}
+	}
+
+	return probes
+}
+
+// backendEndpoint returns the address of the first endpoint of a backend
+func backendEndpoint(backends []*ingress.Backend, name string) (string, bool) {
+	for _, backend := range backends {
+		if backend.Name != name || len(backend.Endpoints) == 0 {
+			continue
+		}
+
+		ep := backend.Endpoints[0]
+		return net.JoinHostPort(ep.Address, ep.Port), true
+	}
+
+	return "", false
+}
+
+// probeRedirectsToHTTPS returns true when an endpoint answers a request
+// using HTTP with a redirect to an HTTPS URL
+func probeRedirectsToHTTPS(client *http.Client, address, host, path string) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, (&url.URL{Scheme: "http", Host: address, Path: path}).String(), http.NoBody)
+	if err != nil {
+		return false, err
+	}
+	if host != "" {
+		req.Host = host
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return false, nil
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return false, nil
+	}
+
+	return strings.EqualFold(location.Scheme, "https"), nil
+}
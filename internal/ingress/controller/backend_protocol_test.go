@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func TestProbeRedirectsToHTTPS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/https":
+			http.Redirect(w, r, "https://"+r.Host+r.URL.Path, http.StatusMovedPermanently)
+		case "/login":
+			http.Redirect(w, r, "/login/form", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client := server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"/https", true},
+		{"/login", false},
+		{"/", false},
+	}
+
+	for _, tc := range testCases {
+		redirects, err := probeRedirectsToHTTPS(client, address, "example.com", tc.path)
+		if err != nil {
+			t.Fatalf("unexpected error probing %v: %v", tc.path, err)
+		}
+		if redirects != tc.expected {
+			t.Errorf("expected %v probing %v but returned %v", tc.expected, tc.path, redirects)
+		}
+	}
+}
+
+func TestBackendProtocolProbes(t *testing.T) {
+	ing := &ingress.Ingress{}
+	cfg := &ingress.Configuration{
+		Servers: []*ingress.Server{
+			{
+				Hostname: "example.com",
+				Locations: []*ingress.Location{
+					{Path: "/", IngressPath: "/", Backend: "default-http-80", BackendProtocol: "HTTP", Ingress: ing},
+					{Path: "/secure", IngressPath: "/secure", Backend: "default-https-443", BackendProtocol: "HTTPS", Ingress: ing},
+					{Path: "/", Backend: "upstream-default-backend", BackendProtocol: "HTTP", IsDefBackend: true},
+				},
+			},
+			{
+				Hostname: defServerName,
+				Locations: []*ingress.Location{
+					{Path: "/", Backend: "default-catch-all-80", BackendProtocol: "HTTP", Ingress: ing},
+				},
+			},
+			{
+				Hostname:       "passthrough.example.com",
+				SSLPassthrough: true,
+				Locations: []*ingress.Location{
+					{Path: "/", IngressPath: "/", Backend: "default-passthrough-443", BackendProtocol: "HTTP", Ingress: ing},
+				},
+			},
+		},
+	}
+
+	probes := backendProtocolProbes(cfg)
+	if len(probes) != 2 {
+		t.Fatalf("expected 2 probes but returned %v", len(probes))
+	}
+
+	if probes[0].backend != "default-http-80" || probes[0].host != "example.com" || probes[0].path != "/" {
+		t.Errorf("unexpected probe %+v", probes[0])
+	}
+	if probes[1].backend != "default-catch-all-80" || probes[1].host != "" || probes[1].path != "/" {
+		t.Errorf("unexpected probe %+v", probes[1])
+	}
+}
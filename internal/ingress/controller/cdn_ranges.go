@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/task"
+)
+
+const (
+	// cdnRangesCheckInterval is the time between checks of the cdn-provider
+	cdnRangesCheckInterval = time.Minute
+	// cdnRangesRefreshInterval is the time between downloads of the IP ranges of a provider
+	cdnRangesRefreshInterval = 12 * time.Hour
+	// cdnRangesRetryInterval is the time to wait after a failed download
+	cdnRangesRetryInterval = 5 * time.Minute
+	// cdnRangesTimeout is the time to wait for the list of IP ranges of a provider
+	cdnRangesTimeout = 30 * time.Second
+)
+
+// cdnRangesSource is the list of IP ranges published by a CDN provider
+type cdnRangesSource struct {
+	url   string
+	parse func([]byte) ([]string, error)
+}
+
+var cdnRangesSources = map[string]cdnRangesSource{
+	"cloudflare": {url: "https://api.cloudflare.com/client/v4/ips", parse: parseCloudflareRanges},
+	"fastly":     {url: "https://api.fastly.com/public-ip-list", parse: parseFastlyRanges},
+	"cloudfront": {url: "https://ip-ranges.amazonaws.com/ip-ranges.json", parse: parseCloudFrontRanges},
+}
+
+// cdnRanges contains the IP ranges downloaded for a CDN provider
+type cdnRanges struct {
+	mu       sync.RWMutex
+	provider string
+	ranges   []string
+	// next is the time of the next download, only used by update
+	next time.Time
+}
+
+// get returns the IP ranges of the provider, if downloaded
+func (c *cdnRanges) get(provider string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if provider == "" || c.provider != provider {
+		return nil
+	}
+
+	return c.ranges
+}
+
+// set replaces the provider and its IP ranges, returning true when they change
+func (c *cdnRanges) set(provider string, ranges []string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.provider == provider && slices.Equal(c.ranges, ranges) {
+		return false
+	}
+
+	changed := len(c.ranges) > 0 || len(ranges) > 0
+	c.provider = provider
+	c.ranges = ranges
+	return changed
+}
+
+// update downloads the IP ranges of the provider when it changes or they are
+// older than cdnRangesRefreshInterval, returning true when they change.
+// It must not be called concurrently.
+func (c *cdnRanges) update(client *http.Client, provider string, now time.Time) bool {
+	c.mu.RLock()
+	current := c.provider
+	c.mu.RUnlock()
+
+	if provider == current && now.Before(c.next) {
+		return false
+	}
+
+	source, ok := cdnRangesSources[provider]
+	if !ok {
+		if provider != "" {
+			klog.InfoS("The CDN provider does not publish its IP ranges, define them in trusted-proxies", "provider", provider)
+		}
+		c.next = now.Add(cdnRangesRefreshInterval)
+		return c.set(provider, nil)
+	}
+
+	ranges, err := fetchCDNRanges(client, source)
+	if err != nil {
+		klog.ErrorS(err, "Error downloading the IP ranges of the CDN provider", "provider", provider)
+		c.next = now.Add(cdnRangesRetryInterval)
+		if provider != current {
+			return c.set(provider, nil)
+		}
+		return false
+	}
+
+	c.next = now.Add(cdnRangesRefreshInterval)
+	if !c.set(provider, ranges) {
+		return false
+	}
+
+	klog.InfoS("Updated the IP ranges of the CDN provider", "provider", provider, "ranges", len(ranges))
+	return true
+}
+
+// watchCDNRanges keeps the IP ranges of the cdn-provider up to date until the
+// controller stops, synchronizing the configuration when they change
+func (n *NGINXController) watchCDNRanges() {
+	client := &http.Client{
+		Timeout: cdnRangesTimeout,
+	}
+
+	wait.Until(func() {
+		provider := n.store.GetBackendConfiguration().CDNProvider
+		if n.cdnRanges.update(client, provider, time.Now()) {
+			n.syncQueue.EnqueueTask(task.GetDummyObject("cdn-ranges-change"))
+		}
+	}, cdnRangesCheckInterval, n.stopCh)
+}
+
+// addCDNRanges trusts the IP ranges of the cdn-provider in the real IP module
+func addCDNRanges(cfg *ngx_config.Configuration, ranges []string) {
+	if cfg.CDNProvider == "" || len(ranges) == 0 {
+		return
+	}
+
+	cfg.ProxyRealIPCIDR = slices.Concat(cfg.ProxyRealIPCIDR, ranges)
+}
+
+// fetchCDNRanges downloads the IP ranges of a provider, returning them sorted
+func fetchCDNRanges(client *http.Client, source cdnRangesSource) ([]string, error) {
+	resp, err := client.Get(source.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %v from %v", resp.StatusCode, source.url)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges, err := source.parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the IP ranges from %v: %w", source.url, err)
+	}
+
+	for _, r := range ranges {
+		if _, _, err := net.ParseCIDR(r); err != nil {
+			return nil, fmt.Errorf("invalid IP range %q from %v", r, source.url)
+		}
+	}
+
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("no IP ranges in %v", source.url)
+	}
+
+	slices.Sort(ranges)
+	return slices.Compact(ranges), nil
+}
+
+func parseCloudflareRanges(body []byte) ([]string, error) {
+	var list struct {
+		Result struct {
+			IPv4 []string `json:"ipv4_cidrs"`
+			IPv6 []string `json:"ipv6_cidrs"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(list.Result.IPv4, list.Result.IPv6), nil
+}
+
+func parseFastlyRanges(body []byte) ([]string, error) {
+	var list struct {
+		IPv4 []string `json:"addresses"`
+		IPv6 []string `json:"ipv6_addresses"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(list.IPv4, list.IPv6), nil
+}
+
+// parseCloudFrontRanges returns the ranges of the CLOUDFRONT service from the
+// list of IP ranges of AWS
+func parseCloudFrontRanges(body []byte) ([]string, error) {
+	var list struct {
+		IPv4 []struct {
+			Prefix  string `json:"ip_prefix"`
+			Service string `json:"service"`
+		} `json:"prefixes"`
+		IPv6 []struct {
+			Prefix  string `json:"ipv6_prefix"`
+			Service string `json:"service"`
+		} `json:"ipv6_prefixes"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, err
+	}
+
+	ranges := []string{}
+	for _, p := range list.IPv4 {
+		if p.Service == "CLOUDFRONT" {
+			ranges = append(ranges, p.Prefix)
+		}
+	}
+	for _, p := range list.IPv6 {
+		if p.Service == "CLOUDFRONT" {
+			ranges = append(ranges, p.Prefix)
+		}
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
+)
+
+func TestParseCDNRanges(t *testing.T) {
+	testCases := map[string]struct {
+		parse    func([]byte) ([]string, error)
+		body     string
+		expected []string
+	}{
+		"cloudflare": {
+			parseCloudflareRanges,
+			`{"result":{"ipv4_cidrs":["173.245.48.0/20"],"ipv6_cidrs":["2400:cb00::/32"]},"success":true}`,
+			[]string{"173.245.48.0/20", "2400:cb00::/32"},
+		},
+		"fastly": {
+			parseFastlyRanges,
+			`{"addresses":["23.235.32.0/20"],"ipv6_addresses":["2a04:4e40::/32"]}`,
+			[]string{"23.235.32.0/20", "2a04:4e40::/32"},
+		},
+		"cloudfront": {
+			parseCloudFrontRanges,
+			`{"prefixes":[{"ip_prefix":"3.2.34.0/26","service":"AMAZON"},{"ip_prefix":"13.32.0.0/15","service":"CLOUDFRONT"}],
+			"ipv6_prefixes":[{"ipv6_prefix":"2600:9000::/28","service":"CLOUDFRONT"}]}`,
+			[]string{"13.32.0.0/15", "2600:9000::/28"},
+		},
+	}
+
+	for n, tc := range testCases {
+		ranges, err := tc.parse([]byte(tc.body))
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", n, err)
+			continue
+		}
+		if !reflect.DeepEqual(ranges, tc.expected) {
+			t.Errorf("%v: expected %v but returned %v", n, tc.expected, ranges)
+		}
+	}
+}
+
+func TestCDNRangesUpdate(t *testing.T) {
+	body := `{"addresses":["23.235.32.0/20","151.101.0.0/16"],"ipv6_addresses":[]}`
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		//nolint:errcheck // the response is checked by the test
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	sources := cdnRangesSources
+	cdnRangesSources = map[string]cdnRangesSource{
+		"fastly": {url: server.URL, parse: parseFastlyRanges},
+	}
+	defer func() { cdnRangesSources = sources }()
+
+	c := &cdnRanges{}
+	now := time.Now()
+
+	if c.update(server.Client(), "", now) {
+		t.Errorf("expected no change without provider")
+	}
+
+	if !c.update(server.Client(), "fastly", now) {
+		t.Errorf("expected a change when the provider is defined")
+	}
+	expected := []string{"151.101.0.0/16", "23.235.32.0/20"}
+	if ranges := c.get("fastly"); !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("expected %v but returned %v", expected, ranges)
+	}
+	if ranges := c.get("cloudflare"); ranges != nil {
+		t.Errorf("expected no ranges for another provider but returned %v", ranges)
+	}
+
+	body = `{"addresses":["23.235.32.0/20"],"ipv6_addresses":[]}`
+	if c.update(server.Client(), "fastly", now.Add(time.Minute)) {
+		t.Errorf("expected no download before the refresh interval")
+	}
+
+	status = http.StatusInternalServerError
+	if c.update(server.Client(), "fastly", now.Add(cdnRangesRefreshInterval)) {
+		t.Errorf("expected no change when the download fails")
+	}
+	if ranges := c.get("fastly"); !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("expected the previous ranges %v but returned %v", expected, ranges)
+	}
+
+	status = http.StatusOK
+	if !c.update(server.Client(), "fastly", now.Add(cdnRangesRefreshInterval+cdnRangesRetryInterval)) {
+		t.Errorf("expected a change when the ranges are refreshed")
+	}
+
+	if !c.update(server.Client(), "akamai", now.Add(cdnRangesRefreshInterval+cdnRangesRetryInterval)) {
+		t.Errorf("expected a change when the provider does not publish its ranges")
+	}
+	if ranges := c.get("akamai"); ranges != nil {
+		t.Errorf("expected no ranges but returned %v", ranges)
+	}
+}
+
+func TestAddCDNRanges(t *testing.T) {
+	cfg := ngx_config.NewDefault()
+	cfg.ProxyRealIPCIDR = []string{"10.0.0.0/8"}
+
+	addCDNRanges(&cfg, []string{"173.245.48.0/20"})
+	if !reflect.DeepEqual(cfg.ProxyRealIPCIDR, []string{"10.0.0.0/8"}) {
+		t.Errorf("expected the ranges to be ignored without cdn-provider but returned %v", cfg.ProxyRealIPCIDR)
+	}
+
+	cfg.CDNProvider = "cloudflare"
+	addCDNRanges(&cfg, []string{"173.245.48.0/20"})
+	expected := []string{"10.0.0.0/8", "173.245.48.0/20"}
+	if !reflect.DeepEqual(cfg.ProxyRealIPCIDR, expected) {
+		t.Errorf("expected %v but returned %v", expected, cfg.ProxyRealIPCIDR)
+	}
+}
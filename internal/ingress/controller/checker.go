@@ -17,17 +17,33 @@ limitations under the License.
 package controller
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/ncabatoff/process-exporter/proc"
+	apiv1 "k8s.io/api/core/v1"
 
 	"k8s.io/ingress-nginx/internal/nginx"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
 )
 
+// deepCheckSampleSize is the number of backends checked by the deep health check
+const deepCheckSampleSize = 5
+
+// dynamicConfigurationStatus is the state of the dynamic configuration in a NGINX worker
+type dynamicConfigurationStatus struct {
+	// BackendsChecksum is the checksum of the backends stored by Lua
+	BackendsChecksum *uint32 `json:"backends_checksum"`
+	// MissingBackends are the backends of the request without a balancer in the worker
+	MissingBackends []string `json:"missing_backends"`
+}
+
 // Name returns the healthcheck name
 func (n *NGINXController) Name() string {
 	return "nginx-ingress-controller"
@@ -69,5 +85,120 @@ func (n *NGINXController) Check(_ *http.Request) error {
 		return fmt.Errorf("dynamic load balancer not started")
 	}
 
+	if n.cfg.HealthzDeepCheck {
+		return n.checkDynamicConfiguration()
+	}
+
 	return nil
 }
+
+// checkDynamicConfiguration returns an error if the backends stored by Lua are not
+// the last ones sent by the controller or a sample of the backends with endpoints
+// do not have a balancer in the NGINX worker handling the request
+func (n *NGINXController) checkDynamicConfiguration() error {
+	running := n.runningConfig.Load()
+	if running == nil {
+		return nil
+	}
+
+	status, err := getDynamicConfigurationStatus(sampleBackends(running.Backends, deepCheckSampleSize))
+	if err != nil {
+		return err
+	}
+
+	if expected := n.backendsChecksum.Load(); expected != nil {
+		if status.BackendsChecksum == nil || *status.BackendsChecksum != *expected {
+			return fmt.Errorf("the backends of the dynamic configuration are stale")
+		}
+	}
+
+	if len(status.MissingBackends) > 0 {
+		return fmt.Errorf("the backends %v do not have a balancer in the NGINX worker", strings.Join(status.MissingBackends, ", "))
+	}
+
+	return nil
+}
+
+// getDynamicConfigurationStatus returns the state of the dynamic configuration in the
+// NGINX worker handling the request, checking the balancers of the backends
+func getDynamicConfigurationStatus(backends []string) (*dynamicConfigurationStatus, error) {
+	path := "/dynamic-configuration-status"
+	if len(backends) > 0 {
+		path += "?" + url.Values{"backend": backends}.Encode()
+	}
+
+	statusCode, body, err := nginx.NewGetStatusRequest(path)
+	if err != nil {
+		return nil, fmt.Errorf("checking the dynamic configuration: %w", err)
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("checking the dynamic configuration: unexpected status code %v", statusCode)
+	}
+
+	status := &dynamicConfigurationStatus{}
+	if err := json.Unmarshal(body, status); err != nil {
+		return nil, fmt.Errorf("decoding the status of the dynamic configuration: %w", err)
+	}
+
+	return status, nil
+}
+
+// sampleBackends returns the names of up to size random backends with endpoints
+// a balancer is created for
+func sampleBackends(backends []*ingress.Backend, size int) []string {
+	names := []string{}
+	for _, backend := range backends {
+		if len(backend.Endpoints) == 0 {
+			continue
+		}
+		if backend.Service != nil && backend.Service.Spec.Type == apiv1.ServiceTypeExternalName {
+			continue
+		}
+
+		names = append(names, backend.Name)
+	}
+
+	rand.Shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+
+	if len(names) > size {
+		names = names[:size]
+	}
+
+	return names
+}
+
+// IsReady returns an error if external load balancers should stop sending
+// new connections to the controller, because it is draining, reloading or
+// NGINX is not healthy
+func (n *NGINXController) IsReady() error {
+	if n.cfg.LBHealthCheckUnhealthyOnReload && n.isReloading.Load() {
+		return fmt.Errorf("the ingress controller is reloading the configuration")
+	}
+
+	return n.Check(nil)
+}
+
+// IsHostReady returns an error if the host is not part of the running configuration
+func (n *NGINXController) IsHostReady(host string) error {
+	running := n.runningConfig.Load()
+	if running == nil {
+		return fmt.Errorf("the ingress controller is not configured yet")
+	}
+
+	for _, server := range running.Servers {
+		if strings.EqualFold(server.Hostname, host) {
+			return nil
+		}
+
+		for _, alias := range server.Aliases {
+			if strings.EqualFold(alias, host) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("host %v is not present in the running configuration", host)
+}
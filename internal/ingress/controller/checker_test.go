@@ -22,12 +22,15 @@ import (
 	"net/http/httptest"
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apiserver/pkg/server/healthz"
 
 	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
 	"k8s.io/ingress-nginx/internal/nginx"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
 	"k8s.io/ingress-nginx/pkg/util/file"
 )
 
@@ -155,3 +158,132 @@ func callHealthz(expErr bool, healthzPath string, mux *http.ServeMux) error {
 
 	return nil
 }
+
+func TestIsHostReady(t *testing.T) {
+	n := &NGINXController{
+		cfg: &Configuration{
+			ListenPorts: &ngx_config.ListenPorts{},
+		},
+	}
+
+	n.runningConfig.Store(&ingress.Configuration{
+		Servers: []*ingress.Server{
+			{Hostname: "foo.bar", Aliases: []string{"www.foo.bar"}},
+		},
+	})
+
+	for _, host := range []string{"foo.bar", "FOO.bar", "www.foo.bar"} {
+		if err := n.IsHostReady(host); err != nil {
+			t.Errorf("expected host %v to be ready: %v", host, err)
+		}
+	}
+
+	if err := n.IsHostReady("unknown.bar"); err == nil {
+		t.Errorf("expected an error for an unknown host")
+	}
+}
+
+func TestIsReadyWhileReloading(t *testing.T) {
+	n := &NGINXController{
+		cfg: &Configuration{
+			ListenPorts:                    &ngx_config.ListenPorts{},
+			LBHealthCheckUnhealthyOnReload: true,
+		},
+	}
+
+	n.isReloading.Store(true)
+
+	if err := n.IsReady(); err == nil {
+		t.Errorf("expected an error while reloading")
+	}
+}
+
+func TestCheckDynamicConfiguration(t *testing.T) {
+	response := ""
+	listener, err := tryListen("tcp", fmt.Sprintf(":%v", nginx.StatusPort))
+	if err != nil {
+		t.Fatalf("creating tcp listener: %s", err)
+	}
+	defer listener.Close()
+	//nolint:gosec // Ignore not configured ReadHeaderTimeout in testing
+	server := &httptest.Server{
+		Listener: listener,
+		Config: &http.Server{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/dynamic-configuration-status" || r.URL.Query().Get("backend") != "app" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, response)
+			}),
+		},
+	}
+	defer server.Close()
+	server.Start()
+
+	n := &NGINXController{}
+
+	n.runningConfig.Store(&ingress.Configuration{
+		Backends: []*ingress.Backend{
+			{Name: "app", Endpoints: []ingress.Endpoint{{Address: "10.0.0.1", Port: "8080"}}},
+			{Name: "no-endpoints"},
+		},
+	})
+
+	response = `{"backends_checksum":null,"missing_backends":[]}`
+	if err := n.checkDynamicConfiguration(); err != nil {
+		t.Errorf("unexpected error before the backends are sent: %v", err)
+	}
+
+	checksum := uint32(3735928559)
+	n.backendsChecksum.Store(&checksum)
+
+	testCases := map[string]struct {
+		response  string
+		expectErr bool
+	}{
+		"valid":           {`{"backends_checksum":3735928559,"missing_backends":[]}`, false},
+		"stale":           {`{"backends_checksum":1,"missing_backends":[]}`, true},
+		"no checksum":     {`{"missing_backends":[]}`, true},
+		"missing backend": {`{"backends_checksum":3735928559,"missing_backends":["app"]}`, true},
+		"invalid":         {`OK`, true},
+	}
+
+	for name, tc := range testCases {
+		response = tc.response
+		if err := n.checkDynamicConfiguration(); (err != nil) != tc.expectErr {
+			t.Errorf("%v: expected error %v but returned %v", name, tc.expectErr, err)
+		}
+	}
+}
+
+func TestSampleBackends(t *testing.T) {
+	backends := []*ingress.Backend{
+		{Name: "no-endpoints"},
+		{
+			Name:      "external-name",
+			Service:   &apiv1.Service{Spec: apiv1.ServiceSpec{Type: apiv1.ServiceTypeExternalName}},
+			Endpoints: []ingress.Endpoint{{Address: "example.com", Port: "80"}},
+		},
+	}
+	for i := 0; i < 10; i++ {
+		backends = append(backends, &ingress.Backend{
+			Name:      fmt.Sprintf("app-%v", i),
+			Endpoints: []ingress.Endpoint{{Address: "10.0.0.1", Port: "8080"}},
+		})
+	}
+
+	sample := sampleBackends(backends, 5)
+	if len(sample) != 5 {
+		t.Fatalf("expected 5 backends but returned %v", sample)
+	}
+	for _, name := range sample {
+		if !strings.HasPrefix(name, "app-") {
+			t.Errorf("unexpected backend %v in the sample", name)
+		}
+	}
+
+	if sample := sampleBackends(backends[:2], 5); len(sample) != 0 {
+		t.Errorf("expected no backends but returned %v", sample)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/k8s"
+)
+
+// classLeaseConfig configures the lease owning an IngressClass. The controller
+// owning the lease updates the status of the Ingresses of the class, the other
+// controllers configured with the same class back off.
+type classLeaseConfig struct {
+	Client   clientset.Interface
+	Recorder record.EventRecorder
+
+	// Namespace is the namespace of the Lease
+	Namespace string
+	// Class is the name of the IngressClass
+	Class string
+	// Controller identifies the controller, shared by its replicas
+	Controller string
+	// Identity identifies the pod holding the Lease, prefixed by Controller
+	Identity string
+	TTL      time.Duration
+
+	OnStartedOwning func(chan struct{})
+}
+
+// classLeaseName returns the name of the Lease owning an IngressClass
+func classLeaseName(class string) string {
+	return fmt.Sprintf("ingress-nginx-class-%v", class)
+}
+
+// runClassLease owns the IngressClass while the context is not done, emitting an
+// event in the pod of the controller when another controller owns it
+func runClassLease(ctx context.Context, config *classLeaseConfig) {
+	var stopCh chan struct{}
+	callbacks := leaderelection.LeaderCallbacks{
+		OnStartedLeading: func(_ context.Context) {
+			klog.InfoS("Owning IngressClass", "class", config.Class)
+			stopCh = make(chan struct{})
+			config.OnStartedOwning(stopCh)
+		},
+		OnStoppedLeading: func() {
+			klog.InfoS("Not owning IngressClass anymore", "class", config.Class)
+			if stopCh != nil {
+				close(stopCh)
+				stopCh = nil
+			}
+		},
+		OnNewLeader: func(identity string) {
+			// a replica of the same controller taking over after a leader
+			// election is not a conflict
+			if identity == config.Identity || strings.HasPrefix(identity, config.Controller+"/") {
+				return
+			}
+
+			klog.Warningf("IngressClass %v is owned by the controller %v, the status of its Ingresses is not updated by this controller", config.Class, identity)
+			config.Recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeWarning, "IngressClassConflict",
+				"IngressClass %v is owned by the controller %v, the status of its Ingresses is not updated by this controller. Use another IngressClass or election ID.", config.Class, identity)
+		},
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{Namespace: config.Namespace, Name: classLeaseName(config.Class)},
+		Client:    config.Client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: config.Identity,
+		},
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   config.TTL,
+		RenewDeadline:   config.TTL / 2,
+		RetryPeriod:     config.TTL / 4,
+		ReleaseOnCancel: true,
+		Name:            config.Class,
+
+		Callbacks: callbacks,
+	})
+	if err != nil {
+		klog.ErrorS(err, "unexpected error owning IngressClass", "class", config.Class)
+		return
+	}
+
+	go func() {
+		// a controller losing the lease tries to own it again while the context is not done
+		for ctx.Err() == nil {
+			elector.Run(ctx)
+		}
+	}()
+}
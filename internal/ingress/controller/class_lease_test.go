@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"k8s.io/ingress-nginx/internal/k8s"
+)
+
+func TestClassLease(t *testing.T) {
+	k8s.IngressPodDetails = &k8s.PodInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testpod",
+			Namespace: "ingress-nginx",
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	owned := make(chan string, 3)
+	newConfig := func(controller, pod string, recorder record.EventRecorder) *classLeaseConfig {
+		identity := controller + "/" + pod
+		return &classLeaseConfig{
+			Client:     client,
+			Recorder:   recorder,
+			Namespace:  "kube-system",
+			Class:      "nginx",
+			Controller: controller,
+			Identity:   identity,
+			TTL:        time.Second,
+			OnStartedOwning: func(chan struct{}) {
+				owned <- identity
+			},
+		}
+	}
+
+	runClassLease(ctx, newConfig("first/ingress-nginx-leader", "pod-a", record.NewFakeRecorder(10)))
+	select {
+	case identity := <-owned:
+		if identity != "first/ingress-nginx-leader/pod-a" {
+			t.Fatalf("expected the first controller to own the IngressClass but %v owns it", identity)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the first controller to own the IngressClass")
+	}
+
+	replicaRecorder := record.NewFakeRecorder(10)
+	runClassLease(ctx, newConfig("first/ingress-nginx-leader", "pod-b", replicaRecorder))
+
+	recorder := record.NewFakeRecorder(10)
+	runClassLease(ctx, newConfig("second/ingress-nginx-leader", "pod-c", recorder))
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, "IngressClassConflict") || !strings.Contains(event, "first/ingress-nginx-leader/pod-a") {
+			t.Errorf("unexpected event %v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected an event in the second controller")
+	}
+
+	select {
+	case event := <-replicaRecorder.Events:
+		t.Errorf("expected no event in a replica of the first controller but got %v", event)
+	default:
+	}
+
+	select {
+	case identity := <-owned:
+		t.Errorf("expected the other controllers to back off but %v owns the IngressClass", identity)
+	default:
+	}
+
+	lease, err := client.CoordinationV1().Leases("kube-system").Get(context.TODO(), classLeaseName("nginx"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error getting the Lease: %v", err)
+	}
+	if *lease.Spec.HolderIdentity != "first/ingress-nginx-leader/pod-a" {
+		t.Errorf("expected the Lease to be held by the first controller but %v holds it", *lease.Spec.HolderIdentity)
+	}
+}
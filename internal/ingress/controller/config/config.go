@@ -24,10 +24,25 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/ingress-nginx/internal/ingress/defaults"
+	"k8s.io/ingress-nginx/internal/ingress/deprecation"
+	"k8s.io/ingress-nginx/internal/ingress/tlsredirect"
+	"k8s.io/ingress-nginx/internal/nginx"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
 	"k8s.io/ingress-nginx/pkg/util/runtime"
 )
 
+// Listener types that can be enabled or disabled per IP family
+const (
+	// HTTPListener is the listener of the HTTP port and the default server port
+	HTTPListener = "http"
+	// HTTPSListener is the listener of the HTTPS port
+	HTTPSListener = "https"
+	// StreamListener is the listener of the TCP and UDP services
+	StreamListener = "stream"
+	// StatusListener is the listener of the NGINX status server in the loopback interface
+	StatusListener = "status"
+)
+
 // EnableSSLChainCompletion Autocomplete SSL certificate chains with missing intermediate CA certificates.
 var EnableSSLChainCompletion = false
 
@@ -86,6 +101,126 @@ const (
 	defaultLimitConnZoneVariable = "$binary_remote_addr"
 )
 
+// Values of ingress-conflict-policy
+const (
+	IngressConflictPolicyOldestWins = "oldest-wins"
+	IngressConflictPolicyNewestWins = "newest-wins"
+	IngressConflictPolicyPriority   = "priority"
+)
+
+// SSLPolicy is a vetted set of TLS protocols, ciphers and curves
+type SSLPolicy struct {
+	Protocols string
+	// Ciphers is empty when only TLS 1.3 is enabled, as its cipher suites are not configured by ssl_ciphers
+	Ciphers   string
+	ECDHCurve string
+	// FIPS requires NGINX to be linked with a TLS library with a FIPS validated module
+	FIPS bool
+}
+
+// SSLPolicyPresets are the values of ssl-policy-preset. modern, intermediate and old follow the
+// Mozilla Server Side TLS recommendations, and fips the approved algorithms of NIST SP 800-52r2.
+var SSLPolicyPresets = map[string]SSLPolicy{
+	"modern": {
+		Protocols: "TLSv1.3",
+		ECDHCurve: "X25519:prime256v1:secp384r1",
+	},
+	"intermediate": {
+		Protocols: "TLSv1.2 TLSv1.3",
+		Ciphers:   "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:ECDHE-ECDSA-CHACHA20-POLY1305:ECDHE-RSA-CHACHA20-POLY1305:DHE-RSA-AES128-GCM-SHA256:DHE-RSA-AES256-GCM-SHA384:DHE-RSA-CHACHA20-POLY1305",
+		ECDHCurve: "X25519:prime256v1:secp384r1",
+	},
+	"old": {
+		Protocols: "TLSv1 TLSv1.1 TLSv1.2 TLSv1.3",
+		// OpenSSL 3 requires the security level 0 to negotiate TLS 1.0 and 1.1
+		Ciphers:   "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:ECDHE-ECDSA-CHACHA20-POLY1305:ECDHE-RSA-CHACHA20-POLY1305:DHE-RSA-AES128-GCM-SHA256:DHE-RSA-AES256-GCM-SHA384:DHE-RSA-CHACHA20-POLY1305:ECDHE-ECDSA-AES128-SHA256:ECDHE-RSA-AES128-SHA256:ECDHE-ECDSA-AES128-SHA:ECDHE-RSA-AES128-SHA:ECDHE-ECDSA-AES256-SHA384:ECDHE-RSA-AES256-SHA384:ECDHE-ECDSA-AES256-SHA:ECDHE-RSA-AES256-SHA:DHE-RSA-AES128-SHA256:DHE-RSA-AES256-SHA256:AES128-GCM-SHA256:AES256-GCM-SHA384:AES128-SHA256:AES256-SHA256:AES128-SHA:AES256-SHA:DES-CBC3-SHA:@SECLEVEL=0",
+		ECDHCurve: "X25519:prime256v1:secp384r1",
+	},
+	"fips": {
+		Protocols: "TLSv1.2 TLSv1.3",
+		Ciphers:   "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384",
+		ECDHCurve: "prime256v1:secp384r1",
+		FIPS:      true,
+	},
+}
+
+// CDNProvider is a CDN sending the requests to the controller
+type CDNProvider struct {
+	// Header is the header used by the CDN to send the address of the client
+	Header string
+}
+
+// CDNProviders are the values of cdn-provider. The IP ranges of the providers
+// are downloaded by the controller.
+var CDNProviders = map[string]CDNProvider{
+	"cloudflare": {Header: "CF-Connecting-IP"},
+	"fastly":     {Header: "Fastly-Client-IP"},
+	"cloudfront": {Header: "X-Forwarded-For"},
+	// Akamai does not publish its IP ranges, they must be defined in trusted-proxies
+	"akamai": {Header: "True-Client-IP"},
+}
+
+const (
+	// URIDecodingPermissive decodes the URI as NGINX does by default
+	URIDecodingPermissive = "permissive"
+	// URIDecodingStrict rejects paths with encoded slashes, backslashes, dots,
+	// null bytes or percent signs, which decode to a different path
+	URIDecodingStrict = "strict"
+
+	// AbsoluteURIAllow accepts requests with an absolute URI, using its host
+	AbsoluteURIAllow = "allow"
+	// AbsoluteURIMatchHost rejects requests with an absolute URI whose host
+	// differs from the Host header
+	AbsoluteURIMatchHost = "match-host"
+	// AbsoluteURIReject rejects requests with an absolute URI
+	AbsoluteURIReject = "reject"
+)
+
+const (
+	// ForwardedHeaderOff does not change the Forwarded header sent by the client
+	ForwardedHeaderOff = "off"
+	// ForwardedHeaderSet replaces the Forwarded header sent by the client
+	ForwardedHeaderSet = "set"
+	// ForwardedHeaderAppend appends an element to the Forwarded header sent by the client
+	ForwardedHeaderAppend = "append"
+)
+
+const (
+	// HTTP2CoalescingAllow accepts the requests of every host sharing an HTTP/2 connection
+	HTTP2CoalescingAllow = "allow"
+	// HTTP2CoalescingProtocol rejects the HTTP/2 requests of servers with HTTP/2 disabled,
+	// sent in a connection established for another host
+	HTTP2CoalescingProtocol = "protocol"
+	// HTTP2CoalescingStrict rejects the HTTP/2 requests with a host other than
+	// the SNI of the connection
+	HTTP2CoalescingStrict = "strict"
+)
+
+// RequestNormalization defines how requests are normalized and which
+// ambiguous requests are rejected
+type RequestNormalization struct {
+	RejectAmbiguousRequestLength bool
+	MergeSlashes                 bool
+	URIDecodingPolicy            string
+	AbsoluteURIPolicy            string
+}
+
+// RequestNormalizationProfiles are the values of request-normalization. The
+// default profile keeps the NGINX behavior.
+var RequestNormalizationProfiles = map[string]RequestNormalization{
+	"default": {
+		MergeSlashes:      true,
+		URIDecodingPolicy: URIDecodingPermissive,
+		AbsoluteURIPolicy: AbsoluteURIAllow,
+	},
+	"strict": {
+		RejectAmbiguousRequestLength: true,
+		MergeSlashes:                 true,
+		URIDecodingPolicy:            URIDecodingStrict,
+		AbsoluteURIPolicy:            AbsoluteURIMatchHost,
+	},
+}
+
 // Configuration represents the content of nginx.conf file
 type Configuration struct {
 	defaults.Backend `json:",squash"` //nolint:staticcheck // Ignore unknown JSON option "squash" error
@@ -110,6 +245,22 @@ type Configuration struct {
 	// This list should be separated by "," character
 	AnnotationValueWordBlocklist string `json:"annotation-value-word-blocklist"`
 
+	// IngressConflictPolicy defines the Ingress used when several Ingresses define the same
+	// host and path: the oldest one (oldest-wins), the newest one (newest-wins) or the one
+	// with the highest conflict-priority annotation (priority), using the oldest one on ties
+	IngressConflictPolicy string `json:"ingress-conflict-policy"`
+
+	// NamespaceMaxHosts, NamespaceMaxLocations, NamespaceMaxSnippetBytes and NamespaceMaxCertificates
+	// are the quotas of the Ingresses of each namespace, 0 for no limit. The Ingresses exceeding
+	// the quotas of their namespace are ignored, the oldest ones first fill the quotas
+	NamespaceMaxHosts        int `json:"namespace-max-hosts"`
+	NamespaceMaxLocations    int `json:"namespace-max-locations"`
+	NamespaceMaxSnippetBytes int `json:"namespace-max-snippet-bytes"`
+	NamespaceMaxCertificates int `json:"namespace-max-certificates"`
+
+	// EnforceNamespaceQuotas denies in the admission webhook the Ingresses exceeding the quotas of their namespace
+	EnforceNamespaceQuotas bool `json:"enforce-namespace-quotas"`
+
 	// Sets the name of the configmap that contains the headers to pass to the client
 	AddHeaders string `json:"add-headers,omitempty"`
 
@@ -216,6 +367,30 @@ type Configuration struct {
 	// By default this is enabled
 	IgnoreInvalidHeaders bool `json:"ignore-invalid-headers"`
 
+	// RequestNormalization sets the options below from one of the RequestNormalizationProfiles.
+	// The options defined in the ConfigMap take precedence over the profile.
+	RequestNormalization string `json:"request-normalization,omitempty"`
+
+	// RejectAmbiguousRequestLength rejects requests with both Content-Length and
+	// Transfer-Encoding headers with a 400 status code
+	// By default this is disabled
+	RejectAmbiguousRequestLength bool `json:"reject-ambiguous-request-length"`
+
+	// MergeSlashes enables the compression of two or more adjacent slashes in the URI
+	// http://nginx.org/en/docs/http/ngx_http_core_module.html#merge_slashes
+	// By default this is enabled
+	MergeSlashes bool `json:"merge-slashes"`
+
+	// URIDecodingPolicy defines if paths with percent-encoded characters changing the
+	// meaning of the path are rejected (strict) or decoded (permissive)
+	// Default: permissive
+	URIDecodingPolicy string `json:"uri-decoding-policy"`
+
+	// AbsoluteURIPolicy defines how requests with an absolute URI in the request line,
+	// like GET http://example.com/ HTTP/1.1, are handled: allow, match-host or reject
+	// Default: allow
+	AbsoluteURIPolicy string `json:"absolute-uri-policy"`
+
 	// RetryNonIdempotent since 1.9.13 NGINX will not retry non-idempotent requests (POST, LOCK, PATCH)
 	// in case of an error. The previous behavior can be restored using the value true
 	RetryNonIdempotent bool `json:"retry-non-idempotent"`
@@ -336,6 +511,27 @@ type Configuration struct {
 	// http://nginx.org/en/docs/http/ngx_http_core_module.html#server_names_hash_bucket_size
 	ServerNameHashBucketSize int `json:"server-name-hash-bucket-size,omitempty"`
 
+	// Computes the sizes of the server names hash tables required by the
+	// host names of the Ingress rules, using the configured values as minimum.
+	// When disabled the sizes are estimated from the length of the host names.
+	// By default this is enabled
+	ServerNameHashAutoSize bool `json:"server-name-hash-auto-size"`
+
+	// Collapses the servers of the subdomains of a domain with the same
+	// locations into a single server, using maps of the Host header to
+	// select the backend of each host.
+	// By default this is disabled
+	EnableWildcardHostCollapsing bool `json:"enable-wildcard-host-collapsing"`
+
+	// Minimum number of servers with the same locations required to
+	// collapse them
+	WildcardHostCollapsingMinServers int `json:"wildcard-host-collapsing-min-servers"`
+
+	// Replaces the names of the upstreams, like <namespace>-<service>-<port>,
+	// with short hashes in the NGINX variables and logs.
+	// By default this is disabled
+	HashUpstreamNames bool `json:"hash-upstream-names"`
+
 	// Size of the bucket for the proxy headers hash tables
 	// http://nginx.org/en/docs/hash.html
 	// https://nginx.org/en/docs/http/ngx_http_proxy_module.html#proxy_headers_hash_max_size
@@ -370,6 +566,10 @@ type Configuration struct {
 	// http://nginx.org/en/docs/http/ngx_http_ssl_module.html#ssl_protocols
 	SSLProtocols string `json:"ssl-protocols,omitempty"`
 
+	// SSLPolicyPreset sets the protocols, ciphers and curves of one of the SSLPolicyPresets.
+	// The values of ssl-protocols, ssl-ciphers and ssl-ecdh-curve take precedence over the preset.
+	SSLPolicyPreset string `json:"ssl-policy-preset,omitempty"`
+
 	// Enables or disable TLS 1.3 early data.
 	// http://nginx.org/en/docs/http/ngx_http_ssl_module.html#ssl_early_data
 	SSLEarlyData bool `json:"ssl-early-data,omitempty"`
@@ -407,6 +607,32 @@ type Configuration struct {
 	// Default: false
 	SSLRejectHandshake bool `json:"ssl-reject-handshake"`
 
+	// EnableTLSFingerprint computes the JA3 and JA4 fingerprints of the TLS clients,
+	// exposed in the variables $tls_ja3 and $tls_ja4
+	// Default: false
+	EnableTLSFingerprint bool `json:"enable-tls-fingerprint"`
+
+	// EnforceSNIHostMatch rejects with the code 421 the HTTPS requests with a Host
+	// header other than the server name sent in the TLS handshake (SNI)
+	// Default: false
+	EnforceSNIHostMatch bool `json:"enforce-sni-host-match"`
+
+	// DefaultServerSSLCertificate is the Secret (namespace/name) with the certificate
+	// of the catch-all server, used instead of the default certificate for the
+	// requests that do not match any server
+	DefaultServerSSLCertificate string `json:"default-server-ssl-certificate"`
+
+	// DefaultServerReturnCode is the status code returned by the catch-all server
+	// instead of sending the requests to the default backend: 404, 421 or 444
+	DefaultServerReturnCode int `json:"default-server-return-code"`
+
+	// DefaultServerBody is the body returned with DefaultServerReturnCode
+	DefaultServerBody string `json:"default-server-body"`
+
+	// DefaultServerRedirect is the URL the catch-all server redirects the requests to,
+	// using the code defined in HTTPRedirectCode
+	DefaultServerRedirect string `json:"default-server-redirect"`
+
 	// Enables or disables the use of the PROXY protocol to receive client connection
 	// (real IP address) information passed through proxy servers and load balancers
 	// such as HAproxy and Amazon Elastic Load Balancer (ELB).
@@ -418,6 +644,10 @@ type Configuration struct {
 	// Example '60s'
 	ProxyProtocolHeaderTimeout time.Duration `json:"proxy-protocol-header-timeout,omitempty"`
 
+	// ProxyProtocolRejectUntrusted closes the connections sending a PROXY protocol header
+	// from a peer outside of ProxyRealIPCIDR, instead of ignoring the header
+	ProxyProtocolRejectUntrusted bool `json:"proxy-protocol-reject-untrusted,omitempty"`
+
 	// Enables or disables the directive aio_write that writes files asynchronously
 	// https://nginx.org/en/docs/http/ngx_http_core_module.html#aio_write
 	EnableAioWrite bool `json:"enable-aio-write,omitempty"`
@@ -452,6 +682,12 @@ type Configuration struct {
 	// Default: true
 	UseHTTP2 bool `json:"use-http2,omitempty"`
 
+	// HTTP2Coalescing defines how the HTTP/2 requests for a host other than the one
+	// the connection was established for are handled: allow, protocol or strict.
+	// Clients reuse a connection for the hosts covered by its certificate.
+	// Default: allow
+	HTTP2Coalescing string `json:"http2-coalescing"`
+
 	// Disables gzipping of responses for requests with "User-Agent" header fields matching any of
 	// the specified regular expressions.
 	// http://nginx.org/en/docs/http/ngx_http_gzip_module.html#gzip_disable
@@ -472,6 +708,11 @@ type Configuration struct {
 	// http://nginx.org/en/docs/ngx_core_module.html#worker_processes
 	WorkerProcesses string `json:"worker-processes,omitempty"`
 
+	// AutoTune sizes worker-processes, max-worker-connections, upstream-keepalive-connections
+	// and the client buffers from the CPU and memory limits of the pod and the number of
+	// connections observed, ignoring the values of these settings in the ConfigMap
+	AutoTune bool `json:"auto-tune,omitempty"`
+
 	// Defines whether multiple concurrent reloads of worker processes should occur.
 	// Set this to false to prevent more than n x 2 workers to exist at any time, to avoid potential OOM situations and high CPU load
 	// With this setting on false, configuration changes in the queue will be re-queued with an exponential backoff, until the number of worker process is the expected value.
@@ -549,6 +790,11 @@ type Configuration struct {
 	// https://nginx.org/en/docs/http/ngx_http_proxy_module.html#proxy_intercept_errors
 	DisableProxyInterceptErrors bool `json:"disable-proxy-intercept-errors,omitempty"`
 
+	// TranslateGRPCErrors returns the grpc-status of the upstream failures
+	// to the gRPC clients of the locations using the GRPC or GRPCS backend
+	// protocol instead of the 502, 503 and 504 error pages
+	TranslateGRPCErrors bool `json:"translate-grpc-errors,omitempty"`
+
 	// Disable absolute redirects and enables relative redirects.
 	// https://nginx.org/en/docs/http/ngx_http_core_module.html#absolute_redirect
 	RelativeRedirects bool `json:"relative-redirects"`
@@ -559,6 +805,24 @@ type Configuration struct {
 	// Sets the ipv6 addresses on which the server will accept requests.
 	BindAddressIpv6 []string `json:"bind-address-ipv6,omitempty"`
 
+	// Sets the listener types (http, https, stream and status) accepting connections over IPv4.
+	// The status listener always accepts IPv4 connections because the controller uses it.
+	IPv4Listeners []string `json:"ipv4-listeners,omitempty"`
+
+	// Sets the listener types (http, https, stream and status) accepting connections over IPv6.
+	// IPv6 listeners are only configured when IPv6 is enabled in the pod and disable-ipv6 is false.
+	IPv6Listeners []string `json:"ipv6-listeners,omitempty"`
+
+	// Sets the ports Ingresses can use as additional listen ports of a server
+	// with the extra-listen-ports and extra-ssl-listen-ports annotations.
+	// By default this list is empty and no additional ports are allowed
+	AllowedExtraListenPorts []int `json:"allowed-extra-listen-ports,omitempty"`
+
+	// Sets the directory containing the directories Ingresses can serve
+	// with the internal-locations annotation.
+	// By default it is empty and only Services can be used
+	InternalLocationsRoot string `json:"internal-locations-root,omitempty"`
+
 	// Sets whether to use incoming X-Forwarded headers.
 	UseForwardedHeaders bool `json:"use-forwarded-headers"`
 
@@ -569,10 +833,35 @@ type Configuration struct {
 	// Default is X-Forwarded-For
 	ForwardedForHeader string `json:"forwarded-for-header,omitempty"`
 
+	// TrustedProxies is the list of IP addresses or CIDRs of the proxies in front of
+	// the controller. When defined, it replaces ProxyRealIPCIDR and the client address
+	// is the rightmost address of the forwarded-for header that is not trusted
+	TrustedProxies []string `json:"trusted-proxies,omitempty"`
+
+	// ForwardedForHops is the number of proxies in front of the controller. When greater
+	// than zero, the client address is the entry of the forwarded-for header added by the
+	// outermost proxy, counting from the right, instead of the rightmost untrusted one
+	// Default: 0
+	ForwardedForHops int `json:"forwarded-for-hops"`
+
+	// CDNProvider is one of the CDNProviders in front of the controller. It enables the
+	// real IP module trusting the IP ranges of the provider and its client address header.
+	// The value of forwarded-for-header takes precedence over the header of the provider.
+	CDNProvider string `json:"cdn-provider,omitempty"`
+
 	// Append the remote address to the X-Forwarded-For header instead of replacing it
 	// Default: false
 	ComputeFullForwardedFor bool `json:"compute-full-forwarded-for,omitempty"`
 
+	// ForwardedHeaderPolicy defines if the RFC 7239 Forwarded header is sent to the
+	// upstream servers: off, set or append
+	// Default: off
+	ForwardedHeaderPolicy string `json:"forwarded-header-policy"`
+
+	// ForwardedHeaderBy is the obfuscated identifier of the controller sent in the
+	// by parameter of the Forwarded header, like _ingress
+	ForwardedHeaderBy string `json:"forwarded-header-by"`
+
 	// If the request does not have a request-id, should we generate a random value?
 	// Default: true
 	GenerateRequestID bool `json:"generate-request-id,omitempty"`
@@ -652,6 +941,12 @@ type Configuration struct {
 	// Default: 308
 	HTTPRedirectCode int `json:"http-redirect-code"`
 
+	// AllowedHTTPMethodsStatusCode sets the HTTP status code returned to requests
+	// using a method outside of allowed-http-methods.
+	// Supported codes are 400 to 499
+	// Default: 405
+	AllowedHTTPMethodsStatusCode int `json:"allowed-http-methods-status-code"`
+
 	// ReusePort instructs NGINX to create an individual listening socket for
 	// each worker process (using the SO_REUSEPORT socket option), allowing a
 	// kernel to distribute incoming connections between worker processes
@@ -681,9 +976,17 @@ type Configuration struct {
 	SyslogPort int `json:"syslog-port"`
 
 	// NoTLSRedirectLocations is a comma-separated list of locations
-	// that should not get redirected to TLS
+	// that should not get redirected to TLS, with the form [host]/prefix
+	// or [host]~regex
 	NoTLSRedirectLocations string `json:"no-tls-redirect-locations"`
 
+	// DeferSSLRedirect disables the redirects to HTTPS of the servers with a TLS
+	// Secret which does not exist yet, served with the default certificate
+	DeferSSLRedirect bool `json:"defer-ssl-redirect"`
+
+	// NoTLSRedirectExceptions contains the parsed NoTLSRedirectLocations
+	NoTLSRedirectExceptions []tlsredirect.Exception `json:"-"`
+
 	// NoAuthLocations is a comma-separated list of locations that
 	// should not get authenticated
 	NoAuthLocations string `json:"no-auth-locations"`
@@ -693,9 +996,47 @@ type Configuration struct {
 	// +optional
 	GlobalExternalAuth GlobalExternalAuth `json:"global-external-auth"`
 
+	// AuthMemcachedHost is the memcached server storing the decisions of the external
+	// authentication cached with auth-cache-key, shared by all the replicas of the controller
+	AuthMemcachedHost string `json:"auth-cache-memcached-host"`
+
+	// AuthMemcachedPort is the port of the memcached server
+	AuthMemcachedPort int `json:"auth-cache-memcached-port"`
+
+	// AuthMemcachedTimeout is the timeout in milliseconds of the operations with the memcached server
+	AuthMemcachedTimeout int `json:"auth-cache-memcached-connect-timeout"`
+
+	// AuthMemcachedIdleTimeout is the time in milliseconds an idle connection to the memcached server is kept
+	AuthMemcachedIdleTimeout int `json:"auth-cache-memcached-max-idle-timeout"`
+
+	// AuthMemcachedPoolSize is the number of idle connections to the memcached server kept by each worker
+	AuthMemcachedPoolSize int `json:"auth-cache-memcached-pool-size"`
+
+	// AnomalyScoringService is the host:port of the gRPC service scoring the requests,
+	// the requests are not scored when empty
+	AnomalyScoringService string `json:"anomaly-scoring-service"`
+
+	// AnomalyScoringTimeout is the time in milliseconds the request waits for its score
+	AnomalyScoringTimeout int `json:"anomaly-scoring-timeout"`
+
+	// AnomalyScoringCacheTTL is the time in seconds the score of a request is reused
+	// for the requests with the same metadata
+	AnomalyScoringCacheTTL int `json:"anomaly-scoring-cache-ttl"`
+
+	// AnomalyScoringThreshold is the score from which the requests are rejected with
+	// a 403, the requests are not rejected when 0
+	AnomalyScoringThreshold float64 `json:"anomaly-scoring-threshold"`
+
 	// Checksum contains a checksum of the configmap configuration
 	Checksum string `json:"-"`
 
+	// RejectedKeys contains the ConfigMap keys ignored because they are unknown or
+	// their value is not valid, with the reason
+	RejectedKeys []string `json:"-"`
+
+	// DeprecatedKeys contains the deprecated ConfigMap keys used
+	DeprecatedKeys []deprecation.Usage `json:"-"`
+
 	// Block all requests from given IPs
 	BlockCIDRs []string `json:"block-cidrs"`
 
@@ -737,6 +1078,16 @@ type Configuration struct {
 	// from the gRPC server. The response is passed to the client synchronously,
 	// as soon as it is received.
 	GRPCBufferSizeKb int `json:"grpc-buffer-size-kb"`
+
+	// SliceCacheMaxSize sets the maximum size of the cache of the byte-range
+	// requests of the locations using the slice-size annotation
+	// Default: 1g
+	SliceCacheMaxSize string `json:"slice-cache-max-size"`
+
+	// SliceCacheValid sets the time the byte-range responses are cached
+	// for the locations using the slice-size annotation
+	// Default: 1h
+	SliceCacheValid string `json:"slice-cache-valid"`
 }
 
 // NewDefault returns the default nginx configuration
@@ -759,6 +1110,7 @@ func NewDefault() Configuration {
 		AllowBackendServerHeader:         false,
 		AnnotationValueWordBlocklist:     "",
 		AnnotationsRiskLevel:             "High",
+		IngressConflictPolicy:            IngressConflictPolicyOldestWins,
 		AccessLogPath:                    "/var/log/nginx/access.log",
 		AccessLogParams:                  "",
 		EnableAccessLogForDefaultBackend: false,
@@ -781,6 +1133,7 @@ func NewDefault() Configuration {
 		EnableRealIP:                     false,
 		ForwardedForHeader:               "X-Forwarded-For",
 		ComputeFullForwardedFor:          false,
+		ForwardedHeaderPolicy:            ForwardedHeaderOff,
 		ProxyAddOriginalURIHeader:        false,
 		GenerateRequestID:                true,
 		HTTP2MaxFieldSize:                "",
@@ -788,11 +1141,16 @@ func NewDefault() Configuration {
 		HTTP2MaxRequests:                 0,
 		HTTP2MaxConcurrentStreams:        128,
 		HTTPRedirectCode:                 308,
+		AllowedHTTPMethodsStatusCode:     405,
 		HSTS:                             true,
 		HSTSIncludeSubdomains:            true,
 		HSTSMaxAge:                       hstsMaxAge,
 		HSTSPreload:                      false,
 		IgnoreInvalidHeaders:             true,
+		RejectAmbiguousRequestLength:     false,
+		MergeSlashes:                     true,
+		URIDecodingPolicy:                URIDecodingPermissive,
+		AbsoluteURIPolicy:                AbsoluteURIAllow,
 		GzipLevel:                        1,
 		GzipMinLength:                    256,
 		GzipTypes:                        gzipTypes,
@@ -811,6 +1169,9 @@ func NewDefault() Configuration {
 		ProxyRealIPCIDR:                  defIPCIDR,
 		ProxyProtocolHeaderTimeout:       defProxyDeadlineDuration,
 		ServerNameHashMaxSize:            1024,
+		ServerNameHashAutoSize:           true,
+		EnableWildcardHostCollapsing:     false,
+		WildcardHostCollapsingMinServers: 10,
 		ProxyHeadersHashMaxSize:          512,
 		ProxyHeadersHashBucketSize:       64,
 		ProxyStreamResponses:             1,
@@ -822,6 +1183,7 @@ func NewDefault() Configuration {
 		SSLProtocols:                     sslProtocols,
 		SSLEarlyData:                     sslEarlyData,
 		SSLRejectHandshake:               false,
+		EnforceSNIHostMatch:              false,
 		SSLSessionCache:                  true,
 		SSLSessionCacheSize:              sslSessionCacheSize,
 		SSLSessionTickets:                false,
@@ -837,6 +1199,7 @@ func NewDefault() Configuration {
 		VariablesHashBucketSize:          256,
 		VariablesHashMaxSize:             2048,
 		UseHTTP2:                         true,
+		HTTP2Coalescing:                  HTTP2CoalescingAllow,
 		DisableProxyInterceptErrors:      false,
 		RelativeRedirects:                false,
 		ProxyStreamTimeout:               "600s",
@@ -865,6 +1228,7 @@ func NewDefault() Configuration {
 			DisableProxyInterceptErrors: false,
 			RelativeRedirects:           false,
 			DenylistSourceRange:         []string{},
+			AllowedHTTPMethods:          []string{},
 			WhitelistSourceRange:        []string{},
 			SkipAccessLogURLs:           []string{},
 			LimitRate:                   0,
@@ -882,8 +1246,11 @@ func NewDefault() Configuration {
 		LimitConnZoneVariable:          defaultLimitConnZoneVariable,
 		BindAddressIpv4:                defBindAddress,
 		BindAddressIpv6:                defBindAddress,
+		AllowedExtraListenPorts:        []int{},
+		IPv4Listeners:                  []string{HTTPListener, HTTPSListener, StreamListener, StatusListener},
+		IPv6Listeners:                  []string{HTTPListener, HTTPSListener, StreamListener},
 		OpentelemetryTrustIncomingSpan: true,
-		OpentelemetryConfig:            "/etc/ingress-controller/telemetry/opentelemetry.toml",
+		OpentelemetryConfig:            nginx.OpentelemetryConfigPath,
 		OtlpCollectorPort:              "4317",
 		OtelServiceName:                "nginx",
 		OtelSampler:                    "AlwaysOn",
@@ -896,13 +1263,22 @@ func NewDefault() Configuration {
 		LimitConnStatusCode:            503,
 		SyslogPort:                     514,
 		NoTLSRedirectLocations:         "/.well-known/acme-challenge",
+		NoTLSRedirectExceptions:        []tlsredirect.Exception{{Path: "/.well-known/acme-challenge"}},
 		NoAuthLocations:                "/.well-known/acme-challenge",
 		GlobalExternalAuth:             defGlobalExternalAuth,
+		AuthMemcachedPort:              11211,
+		AuthMemcachedTimeout:           50,
+		AuthMemcachedIdleTimeout:       10000,
+		AuthMemcachedPoolSize:          50,
+		AnomalyScoringTimeout:          50,
+		AnomalyScoringCacheTTL:         60,
 		ProxySSLLocationOnly:           false,
 		DefaultType:                    "text/html",
 		DebugConnections:               []string{},
 		StrictValidatePathType:         true,
 		GRPCBufferSizeKb:               0,
+		SliceCacheMaxSize:              "1g",
+		SliceCacheValid:                "1h",
 	}
 
 	if klog.V(5).Enabled() {
@@ -932,13 +1308,22 @@ type TemplateConfig struct {
 	ListenPorts              *ListenPorts                     `json:"ListenPorts"`
 	PublishService           *apiv1.Service                   `json:"PublishService"`
 	EnableMetrics            bool                             `json:"EnableMetrics"`
+	ErrorLogMetricsSocket    string                           `json:"ErrorLogMetricsSocket"`
+	ErrorLogMetricsLevel     string                           `json:"ErrorLogMetricsLevel"`
 	MaxmindEditionFiles      *[]string                        `json:"MaxmindEditionFiles"`
 	MonitorMaxBatchSize      int                              `json:"MonitorMaxBatchSize"`
 	PID                      string                           `json:"PID"`
+	TempDirectory            string                           `json:"TempDirectory"`
+	LuaConfigPath            string                           `json:"LuaConfigPath"`
+	GeoIPDirectory           string                           `json:"GeoIPDirectory"`
+	SSLSessionTicketKeyPath  string                           `json:"SSLSessionTicketKeyPath"`
 	StatusPath               string                           `json:"StatusPath"`
 	StatusPort               int                              `json:"StatusPort"`
 	StreamPort               int                              `json:"StreamPort"`
 	StreamSnippets           []string                         `json:"StreamSnippets"`
+
+	// DefaultBackendAssetsDirectory contains the pages of the internal default backend, empty for the compiled-in defaults
+	DefaultBackendAssetsDirectory string `json:"DefaultBackendAssetsDirectory"`
 }
 
 // ListenPorts describe the ports required to run the
@@ -949,6 +1334,9 @@ type ListenPorts struct {
 	Health   int `json:"Health"`
 	Default  int `json:"Default"`
 	SSLProxy int `json:"SSLProxy"`
+	// LBHealthCheck is the port used to answer health checks from
+	// external load balancers. Zero means disabled
+	LBHealthCheck int `json:"LBHealthCheck"`
 }
 
 // GlobalExternalAuth describe external authentication configuration for the
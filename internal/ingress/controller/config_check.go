@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/internal/nginx/conf"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// checkNginxConf checks the structure of the NGINX configuration, the blocks and
+// the directives are closed, without running nginx -t. The errors give the
+// location and the Ingress or the server of the invalid line.
+func checkNginxConf(name string, content []byte) error {
+	_, err := conf.Parse(content)
+	var syntaxErr *conf.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+
+	return fmt.Errorf("%v:%d: %v%v", name, syntaxErr.Line, syntaxErr.Msg, confOrigin(syntaxErr.Blocks))
+}
+
+// confOrigin returns the origin of a line in the blocks open at the line: the
+// innermost location of an Ingress, else the innermost server with a name,
+// else the innermost block
+func confOrigin(blocks []*conf.Directive) string {
+	for i := len(blocks) - 1; i >= 0; i-- {
+		if blocks[i].Name != "location" {
+			continue
+		}
+		if name, ok := blocks[i].Variable("ingress_name"); ok {
+			namespace, _ := blocks[i].Variable("namespace")
+			return fmt.Sprintf(" in %v of the Ingress %v/%v", blocks[i], namespace, name)
+		}
+	}
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		if blocks[i].Name != "server" {
+			continue
+		}
+		if names := blocks[i].Find("server_name"); len(names) > 0 && len(names[0].Args) > 0 {
+			return fmt.Sprintf(" in the server %v", names[0].Args[0])
+		}
+	}
+
+	if len(blocks) == 0 {
+		return ""
+	}
+
+	block := blocks[len(blocks)-1]
+	return fmt.Sprintf(" in the block %v of line %d", block, block.Line)
+}
+
+// checkIngressSnippets checks the snippets of the annotations of the Ingress
+// are a sequence of complete directives and blocks
+func checkIngressSnippets(ing *ingress.Ingress) error {
+	anns := ing.ParsedAnnotations
+	if anns == nil {
+		return nil
+	}
+
+	snippets := []struct {
+		annotation, value string
+	}{
+		{"configuration-snippet", anns.ConfigurationSnippet},
+		{"server-snippet", anns.ServerSnippet},
+		{"stream-snippet", anns.StreamSnippet},
+		{"auth-snippet", anns.ExternalAuth.AuthSnippet},
+	}
+
+	for _, snippet := range snippets {
+		if snippet.value == "" {
+			continue
+		}
+		if err := checkNginxConf(parser.GetAnnotationWithPrefix(snippet.annotation), []byte(snippet.value)); err != nil {
+			return fmt.Errorf("invalid snippet of the Ingress %v: %w", k8s.MetaNamespaceKey(ing), err)
+		}
+	}
+
+	return nil
+}
+
+// checkConfigSnippets checks the snippets of the configuration ConfigMap
+func checkConfigSnippets(cfg *config.Configuration) error {
+	snippets := []struct {
+		key, value string
+	}{
+		{"main-snippet", cfg.MainSnippet},
+		{"http-snippet", cfg.HTTPSnippet},
+		{"server-snippet", cfg.ServerSnippet},
+		{"location-snippet", cfg.LocationSnippet},
+		{"stream-snippet", cfg.StreamSnippet},
+	}
+
+	for _, snippet := range snippets {
+		if snippet.value == "" {
+			continue
+		}
+		if err := checkNginxConf(snippet.key, []byte(snippet.value)); err != nil {
+			return fmt.Errorf("invalid snippet of the configuration ConfigMap: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkRenderedConf checks the NGINX configuration before nginx -t. When it is
+// invalid, the snippets of the Ingresses and of the ConfigMap are checked to
+// name the one breaking the configuration.
+func checkRenderedConf(content []byte, cfg *config.Configuration, servers []*ingress.Server) error {
+	err := checkNginxConf("nginx.conf", content)
+	if err == nil {
+		return nil
+	}
+
+	if snippetErr := checkConfigSnippets(cfg); snippetErr != nil {
+		return snippetErr
+	}
+
+	checked := map[*ingress.Ingress]bool{}
+	for _, server := range servers {
+		for _, location := range server.Locations {
+			if location.Ingress == nil || checked[location.Ingress] {
+				continue
+			}
+			checked[location.Ingress] = true
+			if snippetErr := checkIngressSnippets(location.Ingress); snippetErr != nil {
+				return snippetErr
+			}
+		}
+	}
+
+	return err
+}
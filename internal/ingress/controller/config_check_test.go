@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func TestCheckNginxConf(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"empty", "", ""},
+		{"valid", `http {
+    server {
+        server_name a.com;
+        location / {
+            set $namespace "team-a";
+            set $ingress_name "web";
+            add_header X-Text "a } { ; b";
+            return 200 '${host}';
+        }
+    }
+}`, ""},
+		{"lua block", `http {
+    init_by_lua_block {
+        local s = "}"
+        local t = [==[ { ]] ]==]
+        -- }
+        --[[ { ]]
+        if x then local y = {} end
+    }
+}`, ""},
+		{"comments", "http { # }\n}\n", ""},
+		{"unclosed block", "http {\n    server {\n    }\n", `nginx.conf:4: unexpected end of file, expecting "}" in the block http of line 1`},
+		{"extra brace", "http {\n}\n}\n", `nginx.conf:3: unexpected "}"`},
+		{"unterminated directive", "http {\n    gzip on\n}\n", `nginx.conf:3: unexpected "}", the directive "gzip" is not terminated by ";" in the block http of line 1`},
+		{"unterminated string", "http {\n    return 200 \"a;\n}\n", "nginx.conf:2: unterminated string in the block http of line 1"},
+		{"unterminated lua", "http {\n    init_by_lua_block {\n        local s = \"}\n    }\n}\n", "nginx.conf:3: unterminated Lua string in the block http of line 1"},
+		{"location of an ingress", `http {
+    server {
+        server_name a.com;
+        location /api {
+            set $namespace "team-a";
+            set $ingress_name "web";
+            proxy_pass http://upstream
+        }
+    }
+}`, `nginx.conf:8: unexpected "}", the directive "proxy_pass" is not terminated by ";" in location /api of the Ingress team-a/web`},
+		{"block in the location of an ingress", "http {\n    server {\n        location / {\n            set $namespace \"team-a\";\n            set $ingress_name \"web\";\n            if ($http_x) {\n                return 200\n            }\n        }\n    }\n}\n", `nginx.conf:8: unexpected "}", the directive "return" is not terminated by ";" in location / of the Ingress team-a/web`},
+		{"server", "http {\n    server {\n        server_name a.com b.com;\n        ;\n    }\n}\n", `nginx.conf:4: unexpected ";" in the server a.com`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkNginxConf("nginx.conf", []byte(tc.content))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("expected error %q but got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCheckRenderedConf(t *testing.T) {
+	ing := &ingress.Ingress{
+		Ingress: networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+		},
+		ParsedAnnotations: &annotations.Ingress{
+			ConfigurationSnippet: "more_set_headers \"X-A: b\";\n}",
+		},
+	}
+	servers := []*ingress.Server{{
+		Hostname:  "a.com",
+		Locations: []*ingress.Location{{Path: "/", Ingress: ing}},
+	}}
+	content := []byte("http {\n    server {\n        location / {\n            more_set_headers \"X-A: b\";\n}\n        }\n    }\n}\n")
+
+	defaults := config.NewDefault()
+	err := checkRenderedConf(content, &defaults, servers)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "Ingress team-a/web") || !strings.Contains(err.Error(), "configuration-snippet") {
+		t.Errorf("expected the error to name the Ingress and the annotation but got %v", err)
+	}
+
+	cfg := config.NewDefault()
+	cfg.HTTPSnippet = "server {"
+	err = checkRenderedConf(content, &cfg, nil)
+	if err == nil || !strings.Contains(err.Error(), "http-snippet:1:") {
+		t.Errorf("expected the error to name the http-snippet but got %v", err)
+	}
+
+	ing.ParsedAnnotations.ConfigurationSnippet = "more_set_headers \"X-A: b\";"
+	if err := checkRenderedConf([]byte("http {\n}\n"), &defaults, servers); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+const (
+	driftNginxConf = "nginx-conf"
+	driftBackends  = "backends"
+)
+
+// driftKinds are the parts of the running configuration checked for changes out of band
+var driftKinds = []string{driftNginxConf, driftBackends}
+
+// watchConfigDrift periodically compares nginx.conf and the backends stored by Lua
+// with the ones written by the controller until the controller stops, emitting an
+// event when they were changed out of band, e.g. in a debugging session in the pod
+func (n *NGINXController) watchConfigDrift() {
+	reported := map[string]bool{}
+
+	wait.Until(func() {
+		drift, err := n.configDrift()
+		if err != nil {
+			klog.V(3).ErrorS(err, "Error checking the drift of the configuration")
+			return
+		}
+
+		for _, kind := range driftKinds {
+			msg, drifted := drift[kind]
+			n.metricCollector.SetConfigDrift(kind, drifted)
+
+			if !drifted {
+				delete(reported, kind)
+				continue
+			}
+			if reported[kind] {
+				continue
+			}
+			reported[kind] = true
+
+			klog.Warning(msg)
+			n.recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeWarning, "ConfigurationDrift", msg)
+		}
+	}, n.cfg.ConfigDriftCheckPeriod, n.stopCh)
+}
+
+// configDrift returns the description of the parts of the running configuration
+// changed out of band by kind. The parts not written by the controller yet are skipped.
+func (n *NGINXController) configDrift() (map[string]string, error) {
+	// the controller does not change the configuration during the check
+	n.configDriftLock.Lock()
+	defer n.configDriftLock.Unlock()
+
+	drift := map[string]string{}
+
+	if n.nginxConfChecksum != nil {
+		changed, err := fileChanged(nginx.ConfPath, n.nginxConfChecksum)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			drift[driftNginxConf] = fmt.Sprintf("%v was changed out of band, it is not the configuration written by the controller", nginx.ConfPath)
+		}
+	}
+
+	if expected := n.backendsChecksum.Load(); expected != nil {
+		status, err := getDynamicConfigurationStatus(nil)
+		if err != nil {
+			return nil, err
+		}
+		if status.BackendsChecksum == nil || *status.BackendsChecksum != *expected {
+			drift[driftBackends] = "the backends of the Lua shared dictionary configuration_data were changed out of band, they are not the backends sent by the controller"
+		}
+	}
+
+	return drift, nil
+}
+
+// fileChanged returns true when the SHA-256 checksum of the content of a file is not the expected one
+func fileChanged(path string, expected []byte) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	checksum := sha256.Sum256(content)
+	return !bytes.Equal(checksum[:], expected), nil
+}
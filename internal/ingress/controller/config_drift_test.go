@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+func TestFileChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nginx.conf")
+	if err := os.WriteFile(path, []byte("events {}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	checksum := sha256.Sum256([]byte("events {}"))
+	if changed, err := fileChanged(path, checksum[:]); err != nil || changed {
+		t.Errorf("expected an unchanged file but returned %v, %v", changed, err)
+	}
+
+	if err := os.WriteFile(path, []byte("events { worker_connections 1; }"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := fileChanged(path, checksum[:]); err != nil || !changed {
+		t.Errorf("expected a changed file but returned %v, %v", changed, err)
+	}
+
+	if _, err := fileChanged(filepath.Join(t.TempDir(), "missing.conf"), checksum[:]); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestConfigDriftBackends(t *testing.T) {
+	response := ""
+	listener, err := tryListen("tcp", fmt.Sprintf(":%v", nginx.StatusPort))
+	if err != nil {
+		t.Fatalf("creating tcp listener: %s", err)
+	}
+	defer listener.Close()
+	//nolint:gosec // Ignore not configured ReadHeaderTimeout in testing
+	server := &httptest.Server{
+		Listener: listener,
+		Config: &http.Server{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/dynamic-configuration-status" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, response)
+			}),
+		},
+	}
+	defer server.Close()
+	server.Start()
+
+	n := &NGINXController{}
+
+	response = `{"backends_checksum":1,"missing_backends":[]}`
+	if drift, err := n.configDrift(); err != nil || len(drift) != 0 {
+		t.Errorf("expected no drift before the backends are sent but returned %v, %v", drift, err)
+	}
+
+	checksum := uint32(3735928559)
+	n.backendsChecksum.Store(&checksum)
+
+	testCases := map[string]struct {
+		response  string
+		drifted   bool
+		expectErr bool
+	}{
+		"unchanged":   {`{"backends_checksum":3735928559,"missing_backends":[]}`, false, false},
+		"changed":     {`{"backends_checksum":1,"missing_backends":[]}`, true, false},
+		"no backends": {`{"missing_backends":[]}`, true, false},
+		"invalid":     {`OK`, false, true},
+	}
+
+	for name, tc := range testCases {
+		response = tc.response
+		drift, err := n.configDrift()
+		if (err != nil) != tc.expectErr {
+			t.Errorf("%v: expected error %v but returned %v", name, tc.expectErr, err)
+			continue
+		}
+		if _, drifted := drift[driftBackends]; drifted != tc.drifted {
+			t.Errorf("%v: expected drift %v but returned %v", name, tc.drifted, drift)
+		}
+	}
+}
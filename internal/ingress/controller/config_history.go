@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/ingress/confighistory"
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+// triggers of the reloads recorded in the configuration history
+const (
+	triggerSync          = "sync"
+	triggerCrashRecovery = "crash-recovery"
+)
+
+// ConfigHistory returns the last generations of nginx.conf, nil when the history is disabled
+func (n *NGINXController) ConfigHistory() *confighistory.History {
+	return n.configHistory
+}
+
+// recordGeneration adds nginx.conf to the configuration history after a reload
+func (n *NGINXController) recordGeneration(trigger string, ingresses []string, checksum string) {
+	if n.configHistory == nil {
+		return
+	}
+
+	content, err := os.ReadFile(nginx.ConfPath)
+	if err != nil {
+		klog.Warningf("Error reading %v for the configuration history: %v", nginx.ConfPath, err)
+		return
+	}
+
+	err = n.configHistory.Add(confighistory.Generation{
+		Time:      time.Now(),
+		Trigger:   trigger,
+		Ingresses: ingresses,
+		Checksum:  checksum,
+	}, content)
+	if err != nil {
+		klog.Warningf("Error adding nginx.conf to the configuration history: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+
+	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// sortIngressesByConflictPolicy returns the Ingresses, sorted from the oldest to the newest,
+// in the order they are merged. The first Ingress defining a host and path configures its
+// location, so the order decides the Ingress winning a conflict.
+func sortIngressesByConflictPolicy(ingresses []*ingress.Ingress, policy string) []*ingress.Ingress {
+	sorted := make([]*ingress.Ingress, len(ingresses))
+	copy(sorted, ingresses)
+
+	switch policy {
+	case ngx_config.IngressConflictPolicyNewestWins:
+		for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		}
+	case ngx_config.IngressConflictPolicyPriority:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return conflictPriority(sorted[i]) > conflictPriority(sorted[j])
+		})
+	}
+
+	return sorted
+}
+
+func conflictPriority(ing *ingress.Ingress) int {
+	if ing.ParsedAnnotations == nil {
+		return 0
+	}
+
+	return ing.ParsedAnnotations.ConflictPriority
+}
+
+// ingressConflict is a path of an Ingress served by another Ingress
+type ingressConflict struct {
+	ingress *ingress.Ingress
+	winner  *ingress.Ingress
+	host    string
+	path    string
+}
+
+// findIngressConflicts returns the paths of the Ingresses served by other Ingresses
+func findIngressConflicts(ingresses []*ingress.Ingress, servers []*ingress.Server) []*ingressConflict {
+	hosts := map[string]*ingress.Server{}
+	for _, server := range servers {
+		hosts[server.Hostname] = server
+	}
+
+	conflicts := []*ingressConflict{}
+	for _, ing := range ingresses {
+		if ing.ParsedAnnotations != nil && ing.ParsedAnnotations.Canary.Enabled {
+			continue
+		}
+
+		for _, rule := range ing.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+
+			host := rule.Host
+			if host == "" {
+				host = defServerName
+			}
+
+			server, ok := hosts[host]
+			if !ok {
+				continue
+			}
+
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service == nil {
+					continue
+				}
+
+				nginxPath := rootLocation
+				if path.Path != "" {
+					nginxPath = path.Path
+				}
+
+				for _, loc := range server.Locations {
+					if loc.Path != nginxPath || !apiequality.Semantic.DeepEqual(loc.PathType, path.PathType) {
+						continue
+					}
+
+					if loc.Ingress != nil && k8s.MetaNamespaceKey(loc.Ingress) != k8s.MetaNamespaceKey(ing) {
+						conflicts = append(conflicts, &ingressConflict{
+							ingress: ing,
+							winner:  loc.Ingress,
+							host:    host,
+							path:    nginxPath,
+						})
+					}
+
+					break
+				}
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// reportIngressConflicts emits an event in the Ingresses with paths served by other Ingresses
+func (n *NGINXController) reportIngressConflicts(ingresses []*ingress.Ingress, servers []*ingress.Server) {
+	policy := n.store.GetBackendConfiguration().IngressConflictPolicy
+	for _, c := range findIngressConflicts(ingresses, servers) {
+		ingKey := k8s.MetaNamespaceKey(c.ingress)
+		winnerKey := k8s.MetaNamespaceKey(c.winner)
+
+		klog.Warningf("Path %q of host %q in Ingress %v is served by Ingress %v (ingress-conflict-policy %v)",
+			c.path, c.host, ingKey, winnerKey, policy)
+		n.recorder.Eventf(&c.ingress.Ingress, apiv1.EventTypeWarning, "IngressConflict",
+			"Path %q of host %q is served by Ingress %v (ingress-conflict-policy %v)", c.path, c.host, winnerKey, policy)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func newConflictIngress(name string, priority int) *ingress.Ingress {
+	pathType := networking.PathTypePrefix
+	return &ingress.Ingress{
+		Ingress: networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						Host: "example.com",
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{
+								Paths: []networking.HTTPIngressPath{
+									{
+										Path:     "/",
+										PathType: &pathType,
+										Backend: networking.IngressBackend{
+											Service: &networking.IngressServiceBackend{
+												Name: name,
+												Port: networking.ServiceBackendPort{Number: 80},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		ParsedAnnotations: &annotations.Ingress{ConflictPriority: priority},
+	}
+}
+
+func TestSortIngressesByConflictPolicy(t *testing.T) {
+	oldest := newConflictIngress("oldest", 0)
+	middle := newConflictIngress("middle", 10)
+	newest := newConflictIngress("newest", 10)
+	ingresses := []*ingress.Ingress{oldest, middle, newest}
+
+	testCases := []struct {
+		policy   string
+		expected []*ingress.Ingress
+	}{
+		{ngx_config.IngressConflictPolicyOldestWins, []*ingress.Ingress{oldest, middle, newest}},
+		{ngx_config.IngressConflictPolicyNewestWins, []*ingress.Ingress{newest, middle, oldest}},
+		{ngx_config.IngressConflictPolicyPriority, []*ingress.Ingress{middle, newest, oldest}},
+	}
+
+	for _, tc := range testCases {
+		sorted := sortIngressesByConflictPolicy(ingresses, tc.policy)
+		for i := range tc.expected {
+			if sorted[i] != tc.expected[i] {
+				t.Errorf("expected %v in position %v with the policy %v but returned %v", tc.expected[i].Name, i, tc.policy, sorted[i].Name)
+			}
+		}
+	}
+
+	if ingresses[0] != oldest || ingresses[2] != newest {
+		t.Errorf("expected the Ingresses to not be modified")
+	}
+}
+
+func TestFindIngressConflicts(t *testing.T) {
+	winner := newConflictIngress("winner", 0)
+	loser := newConflictIngress("loser", 0)
+	pathType := networking.PathTypePrefix
+
+	servers := []*ingress.Server{
+		{
+			Hostname: "example.com",
+			Locations: []*ingress.Location{
+				{Path: "/", PathType: &pathType, Ingress: winner},
+			},
+		},
+	}
+
+	conflicts := findIngressConflicts([]*ingress.Ingress{winner, loser}, servers)
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict but returned %v", len(conflicts))
+	}
+
+	c := conflicts[0]
+	if c.ingress != loser || c.winner != winner || c.host != "example.com" || c.path != "/" {
+		t.Errorf("unexpected conflict %+v", c)
+	}
+}
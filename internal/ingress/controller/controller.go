@@ -17,7 +17,9 @@ limitations under the License.
 package controller
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,24 +27,33 @@ import (
 
 	"github.com/mitchellh/hashstructure/v2"
 	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networking "k8s.io/api/networking/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/ingress-nginx/internal/admission/policy"
 	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/authldap"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/canary"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/extralistenports"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/headerlimits"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/internallocations"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/log"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/proxy"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/sslcipher"
 	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
 	"k8s.io/ingress-nginx/internal/ingress/controller/ingressclass"
 	"k8s.io/ingress-nginx/internal/ingress/controller/store"
-	"k8s.io/ingress-nginx/internal/ingress/errors"
+	ing_errors "k8s.io/ingress-nginx/internal/ingress/errors"
 	"k8s.io/ingress-nginx/internal/ingress/inspector"
 	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
+	"k8s.io/ingress-nginx/internal/ingress/shard"
 	"k8s.io/ingress-nginx/internal/k8s"
 	"k8s.io/ingress-nginx/internal/nginx"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
@@ -66,8 +77,25 @@ type Configuration struct {
 
 	KubeConfigFile string
 
+	// KubeAPIQPS and KubeAPIBurst limit the requests of Client to the API server
+	KubeAPIQPS   float32
+	KubeAPIBurst int
+
+	// StatusKubeAPIQPS and StatusKubeAPIBurst limit the requests of StatusClient
+	StatusKubeAPIQPS   float32
+	StatusKubeAPIBurst int
+
 	Client clientset.Interface
 
+	// StatusClient is used to update the Ingress status. Client is used when nil.
+	StatusClient clientset.Interface
+
+	// DynamicClient is used to watch the ReferenceGrants. Disabled when nil.
+	DynamicClient dynamic.Interface
+
+	// HTTPTransformClient is used to watch the HTTPTransforms. Disabled when nil.
+	HTTPTransformClient dynamic.Interface
+
 	ResyncPeriod time.Duration
 
 	ConfigMapName  string
@@ -77,6 +105,10 @@ type Configuration struct {
 
 	WatchNamespaceSelector labels.Selector
 
+	WatchReferenceGrants bool
+
+	WatchHTTPTransforms bool
+
 	// +optional
 	TCPConfigMapName string
 	// +optional
@@ -93,13 +125,22 @@ type Configuration struct {
 	ElectionID             string
 	ElectionTTL            time.Duration
 	UpdateStatusOnShutdown bool
+	StatusServerSideApply  bool
+
+	// IngressClassLeaseNamespace is the namespace of the Leases owning the IngressClasses.
+	// Disabled when empty.
+	IngressClassLeaseNamespace string
 
 	HealthCheckHost string
 	ListenPorts     *ngx_config.ListenPorts
 
+	LBHealthCheckUnhealthyOnReload bool
+
 	DisableServiceExternalName bool
 
 	EnableSSLPassthrough bool
+	// SSLPassthroughPoolSize is the number of connections to NGINX opened in advance by the SSL Passthrough proxy
+	SSLPassthroughPoolSize int
 
 	DisableLeaderElection bool
 
@@ -113,6 +154,8 @@ type Configuration struct {
 	MetricsMaxBuckets       uint32
 	ReportStatusClasses     bool
 	ExcludeSocketMetrics    []string
+	// ErrorLogMetrics exports counters of the entries of the error log of NGINX
+	ErrorLogMetrics bool
 
 	FakeCertificate *ingress.SSLCert
 
@@ -120,6 +163,8 @@ type Configuration struct {
 
 	DisableCatchAll bool
 
+	DisallowWeakerTLS bool
+
 	IngressClassConfiguration *ingressclass.Configuration
 
 	ValidationWebhook         string
@@ -127,6 +172,14 @@ type Configuration struct {
 	ValidationWebhookKeyPath  string
 	DisableFullValidationTest bool
 
+	// AdmissionPoliciesConfigMap is the ConfigMap of the policies the Ingress objects must satisfy
+	// +optional
+	AdmissionPoliciesConfigMap string
+
+	// DefaultBackendAssetsConfigMap is the ConfigMap of the pages served by the internal default backend
+	// +optional
+	DefaultBackendAssetsConfigMap string
+
 	GlobalExternalAuth  *ngx_config.GlobalExternalAuth
 	MaxmindEditionFiles *[]string
 
@@ -134,16 +187,73 @@ type Configuration struct {
 
 	PostShutdownGracePeriod int
 	ShutdownGracePeriod     int
+	// L4ShutdownGracePeriod is the time in seconds the passthrough and stream
+	// connections are kept during the shutdown, 0 to wait for NGINX to stop
+	L4ShutdownGracePeriod int
+
+	PreemptionSources      []string
+	PreemptionTaintKeys    []string
+	PreemptionPollInterval time.Duration
+	PreemptionDrainDelay   time.Duration
+
+	LuaSharedDictsUsageThreshold float64
+
+	// DiskUsageCheckPeriod is the time between checks of the temporary
+	// and cache directories of NGINX, 0 to disable them
+	DiskUsageCheckPeriod time.Duration
+	// CacheEvictionHighWatermark is the used part of a filesystem over
+	// which the oldest cache files are removed, 0 to disable the eviction
+	CacheEvictionHighWatermark float64
+
+	BackendProtocolProbeInterval time.Duration
+
+	// ConfigDriftCheckPeriod is the time between checks of nginx.conf and the
+	// backends stored by Lua for changes out of band, 0 to disable them
+	ConfigDriftCheckPeriod time.Duration
+
+	// ConfigHistorySize is the number of generations of nginx.conf kept on disk, 0 to disable the history
+	ConfigHistorySize int
+	// ConfigHistoryRetention is the time the generations of nginx.conf are kept, 0 to keep them
+	ConfigHistoryRetention time.Duration
+
+	// Shard is the part of the Ingress objects processed by this replica
+	Shard shard.Shard
+
+	// CommandLineFlags contains the values of the flags of the controller
+	CommandLineFlags map[string]string
+
+	// ConfigFile is the path of the file with the values of the flags of the controller
+	ConfigFile string
 
 	InternalLoggerAddress string
 	IsChroot              bool
 	DeepInspector         bool
 
-	DynamicConfigurationRetries int
+	// HealthzDeepCheck verifies the dynamic configuration of Lua in the health check
+	HealthzDeepCheck bool
+
+	// CrashRecovery reverts the changes of the Ingresses when the NGINX workers crash repeatedly after them
+	CrashRecovery bool
+	// CrashRecoveryBisect identifies the Ingress causing the crashes among the ones changed
+	CrashRecoveryBisect bool
+
+	// AdminAuth is the protection of the admin endpoints: none, token or mtls
+	AdminAuth string
+	// AdminPort is the port of the admin endpoints when AdminAuth is mtls
+	AdminPort         int
+	AdminTLSCertFile  string
+	AdminTLSKeyFile   string
+	AdminClientCAFile string
+
+	DynamicConfigurationRetries   int
+	DynamicConfigurationChunkSize int
+	CompressDynamicConfiguration  bool
 
 	DisableSyncEvents bool
 
 	EnableTopologyAwareRouting bool
+
+	EnableIPv6Endpoints bool
 }
 
 func getIngressPodZone(svc *apiv1.Service) string {
@@ -179,48 +289,62 @@ func (n *NGINXController) syncIngress(interface{}) error {
 		return nil
 	}
 
-	ings := n.store.ListIngresses()
+	ings := n.cfg.Shard.FilterIngresses(n.store.ListIngresses())
+	if n.crashRecovery.bisecting() {
+		return n.continueBisect()
+	}
+	if n.crashRecovery.crashLooping() {
+		return n.recoverFromCrashLoop(ings)
+	}
+	ings = n.crashRecovery.filterIngresses(ings)
+	ings, overQuota := filterNamespaceQuotas(ings, newNamespaceQuotas(n.store.GetBackendConfiguration()))
+
 	hosts, servers, pcfg := n.getConfiguration(ings)
 
 	n.metricCollector.SetSSLExpireTime(servers)
 	n.metricCollector.SetSSLInfo(servers)
+	n.metricCollector.SetSSLPending(servers)
+	n.metricCollector.SetConfigErrors(len(n.store.GetBackendConfiguration().RejectedKeys))
+	n.setDeprecatedUsage(ings)
 
-	if n.runningConfig.Equal(pcfg) {
+	running := n.runningConfig.Load()
+	if running.Equal(pcfg) {
 		klog.V(3).Infof("No configuration change detected, skipping backend reload")
 		return nil
 	}
 
 	n.metricCollector.SetHosts(hosts)
 
-	if !utilingress.IsDynamicConfigurationEnough(pcfg, n.runningConfig) {
-		klog.InfoS("Configuration changes detected, backend reload required")
+	n.reportRedirectLoops(servers)
+	n.reportShadowedLocations(servers)
+	n.reportIngressConflicts(ings, servers)
+	n.reportNamespaceQuotas(overQuota)
 
-		hash, err := hashstructure.Hash(pcfg, hashstructure.FormatV1, &hashstructure.HashOptions{
-			TagName: "json",
-		})
-		if err != nil {
-			klog.Errorf("unexpected error hashing configuration: %v", err)
-		}
-
-		pcfg.ConfigurationChecksum = fmt.Sprintf("%v", hash)
+	if !utilingress.IsDynamicConfigurationEnough(pcfg, running) {
+		klog.InfoS("Configuration changes detected, backend reload required")
 
-		err = n.OnUpdate(*pcfg)
-		if err != nil {
-			n.metricCollector.IncReloadErrorCount()
-			n.metricCollector.ConfigSuccess(hash, false)
+		if err := n.reload(pcfg); err != nil {
 			klog.Errorf("Unexpected failure reloading the backend:\n%v", err)
 			n.recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeWarning, "RELOAD", fmt.Sprintf("Error reloading NGINX: %v", err))
 			return err
 		}
 
 		klog.InfoS("Backend successfully reloaded")
-		n.metricCollector.ConfigSuccess(hash, true)
-		n.metricCollector.IncReloadCount()
+
+		// the first reload after the start of the controller is not triggered by the Ingresses
+		versions := ingressVersions(ings)
+		var triggers []string
+		if n.reloadedIngresses != nil {
+			triggers = reloadTriggers(n.reloadedIngresses, versions)
+			n.metricCollector.IncReloadTriggers(triggers)
+		}
+		n.reloadedIngresses = versions
+		n.recordGeneration(triggerSync, triggers, pcfg.ConfigurationChecksum)
 
 		n.recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeNormal, "RELOAD", "NGINX reload triggered due to a change in configuration")
 	}
 
-	isFirstSync := n.runningConfig.Equal(&ingress.Configuration{})
+	isFirstSync := running.Equal(&ingress.Configuration{})
 	if isFirstSync {
 		// For the initial sync it always takes some time for NGINX to start listening
 		// For large configurations it might take a while so we loop and back off
@@ -242,6 +366,10 @@ func (n *NGINXController) syncIngress(interface{}) error {
 			klog.V(2).Infof("Dynamic reconfiguration succeeded.")
 			return true, nil
 		}
+		if errors.Is(err, errDataplaneOverloaded) {
+			// retrying only adds load to the dataplane
+			return false, err
+		}
 		retriesRemaining--
 		if retriesRemaining > 0 {
 			klog.Warningf("Dynamic reconfiguration failed (retrying; %d retries left): %v", retriesRemaining, err)
@@ -250,17 +378,50 @@ func (n *NGINXController) syncIngress(interface{}) error {
 		klog.Warningf("Dynamic reconfiguration failed: %v", err)
 		return false, err
 	})
+	if errors.Is(err, errDataplaneOverloaded) {
+		// a reload keeps the shared dictionaries, so it can not free the memory
+		err = fmt.Errorf("%w, increase the size of configuration_data with the lua-shared-dicts setting of the ConfigMap", err)
+	}
 	if err != nil {
 		klog.Errorf("Unexpected failure reconfiguring NGINX:\n%v", err)
 		return err
 	}
 
-	ri := utilingress.GetRemovedIngresses(n.runningConfig, pcfg)
-	rc := utilingress.GetRemovedCertificateSerialNumbers(n.runningConfig, pcfg)
+	ri := utilingress.GetRemovedIngresses(running, pcfg)
+	rc := utilingress.GetRemovedCertificateSerialNumbers(running, pcfg)
 	n.metricCollector.RemoveMetrics(ri, rc)
 
-	n.runningConfig = pcfg
+	n.runningConfig.Store(pcfg)
+	n.crashRecovery.applied(ings, time.Now())
+
+	return nil
+}
+
+// reload writes the configuration with its checksum and reloads NGINX,
+// recording the result in the metrics and the reload history
+func (n *NGINXController) reload(pcfg *ingress.Configuration) error {
+	hash, err := hashstructure.Hash(pcfg, hashstructure.FormatV1, &hashstructure.HashOptions{
+		TagName: "json",
+	})
+	if err != nil {
+		klog.Errorf("unexpected error hashing configuration: %v", err)
+	}
+
+	pcfg.ConfigurationChecksum = fmt.Sprintf("%v", hash)
 
+	start := time.Now()
+	n.isReloading.Store(true)
+	err = n.OnUpdate(*pcfg)
+	n.isReloading.Store(false)
+	n.recordReload(start, pcfg.ConfigurationChecksum, err)
+	if err != nil {
+		n.metricCollector.IncReloadErrorCount()
+		n.metricCollector.ConfigSuccess(hash, false)
+		return err
+	}
+
+	n.metricCollector.ConfigSuccess(hash, true)
+	n.metricCollector.IncReloadCount()
 	return nil
 }
 
@@ -307,6 +468,15 @@ func (n *NGINXController) CheckWarning(ing *networking.Ingress) ([]string, error
 		}
 	}
 
+	warnings = append(warnings, n.shadowedLocationWarnings(ing)...)
+
+	if n.cfg != nil {
+		if shards := n.cfg.Shard.IngressShards(ing); len(shards) > 1 {
+			warnings = append(warnings, fmt.Sprintf("the hosts of the Ingress are served by the shards %v, its status only contains the address of the shard %v; split the Ingress by host",
+				shards, n.cfg.Shard.StatusShard(ing)))
+		}
+	}
+
 	return warnings, nil
 }
 
@@ -382,6 +552,10 @@ func (n *NGINXController) CheckIngress(ing *networking.Ingress) error {
 		}
 	}
 
+	if err := n.checkAdmissionPolicies(ing); err != nil {
+		return err
+	}
+
 	k8s.SetDefaultNGINXPathType(ing)
 
 	allIngresses := n.store.ListIngresses()
@@ -396,10 +570,38 @@ func (n *NGINXController) CheckIngress(ing *networking.Ingress) error {
 		n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
 		return err
 	}
+
+	if n.cfg.DisallowWeakerTLS {
+		if err := sslcipher.CheckNotWeaker(&parsed.SSLCipher, cfg.SSLProtocols, cfg.SSLCiphers); err != nil {
+			n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
+			return err
+		}
+	}
+
+	err = checkCrossNamespaceReferences(ing, n.store.GetSecurityConfiguration().AllowCrossNamespaceResources, n.store.IsReferenceGranted)
+	if err != nil {
+		n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
+		return err
+	}
+
+	if cfg.EnforceNamespaceQuotas {
+		if err := checkNamespaceQuotas(ing, allIngresses, newNamespaceQuotas(cfg)); err != nil {
+			n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
+			return err
+		}
+	}
+
 	ings = append(ings, &ingress.Ingress{
 		Ingress:           *ing,
 		ParsedAnnotations: parsed,
 	})
+
+	err = checkIngressSnippets(ings[len(ings)-1])
+	if err != nil {
+		n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
+		return err
+	}
+
 	startTest := time.Now().UnixNano() / 1000000
 	_, servers, pcfg := n.getConfiguration(ings)
 
@@ -408,18 +610,37 @@ func (n *NGINXController) CheckIngress(ing *networking.Ingress) error {
 		n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
 		return err
 	}
+
+	err = checkRedirectLoops(ing, servers)
+	if err != nil {
+		n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
+		return err
+	}
+
+	err = checkAliasCollisions(ings[len(ings)-1], ings[:len(ings)-1])
+	if err != nil {
+		n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
+		return err
+	}
 	testedSize := len(ings)
 	if n.cfg.DisableFullValidationTest {
 		_, _, pcfg = n.getConfiguration(ings[len(ings)-1:])
 		testedSize = 1
 	}
 
+	setServerNamesHashSize(&cfg, pcfg.Servers)
 	content, err := n.generateTemplate(cfg, *pcfg)
 	if err != nil {
 		n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
 		return err
 	}
 
+	err = checkRenderedConf(content, &cfg, pcfg.Servers)
+	if err != nil {
+		n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
+		return err
+	}
+
 	err = n.testTemplate(content)
 	if err != nil {
 		n.metricCollector.IncCheckErrorCount(ing.ObjectMeta.Namespace, ing.Name)
@@ -438,6 +659,28 @@ func (n *NGINXController) CheckIngress(ing *networking.Ingress) error {
 	return nil
 }
 
+// checkAdmissionPolicies returns the policies of the ConfigMap AdmissionPoliciesConfigMap denying the ingress
+func (n *NGINXController) checkAdmissionPolicies(ing *networking.Ingress) error {
+	if n.cfg.AdmissionPoliciesConfigMap == "" {
+		return nil
+	}
+
+	cmap, err := n.store.GetConfigMap(n.cfg.AdmissionPoliciesConfigMap)
+	if err != nil {
+		klog.Warningf("Error reading admission policies ConfigMap %q from local store: %v", n.cfg.AdmissionPoliciesConfigMap, err)
+		return nil
+	}
+
+	policies := policy.Parse(cmap.Data)
+	for _, p := range policies {
+		if p.Err() != nil {
+			klog.Warningf("Admission policy %q of ConfigMap %q denies all the ingresses: %v", p.Name, n.cfg.AdmissionPoliciesConfigMap, p.Err())
+		}
+	}
+
+	return policy.Evaluate(policies, ing, parser.AnnotationsPrefix)
+}
+
 func (n *NGINXController) getStreamServices(configmapName string, proto apiv1.Protocol) []ingress.L4Service {
 	if configmapName == "" {
 		return []ingress.L4Service{}
@@ -457,19 +700,8 @@ func (n *NGINXController) getStreamServices(configmapName string, proto apiv1.Pr
 	svcs := make([]ingress.L4Service, 0, len(configmap.Data))
 	var svcProxyProtocol ingress.ProxyProtocol
 
-	rp := []int{
-		n.cfg.ListenPorts.HTTP,
-		n.cfg.ListenPorts.HTTPS,
-		n.cfg.ListenPorts.SSLProxy,
-		n.cfg.ListenPorts.Health,
-		n.cfg.ListenPorts.Default,
-		nginx.ProfilerPort,
-		nginx.StatusPort,
-		nginx.StreamPort,
-	}
-
-	reservedPorts := sets.NewInt(rp...)
-	// svcRef format: <(str)namespace>/<(str)service>:<(intstr)port>[:<("PROXY")decode>:<("PROXY")encode>]
+	reservedPorts := n.reservedPorts()
+	// svcRef format: <(str)namespace>/<(str)service>:<(intstr)port>[:<("PROXY")decode>:<("PROXY")encode>][;<option>=<value>...]
 	for port, svcRef := range configmap.Data {
 		externalPort, err := strconv.Atoi(port) // #nosec
 		if err != nil {
@@ -480,6 +712,7 @@ func (n *NGINXController) getStreamServices(configmapName string, proto apiv1.Pr
 			klog.Warningf("Port %d cannot be used for %v stream services. It is reserved for the Ingress controller.", externalPort, proto)
 			continue
 		}
+		svcRef, options, _ := strings.Cut(svcRef, ";")
 		nsSvcPort := strings.Split(svcRef, ":")
 		if len(nsSvcPort) < 2 {
 			klog.Warningf("Invalid Service reference %q for %v port %d", svcRef, proto, externalPort)
@@ -525,7 +758,7 @@ func (n *NGINXController) getStreamServices(configmapName string, proto apiv1.Pr
 				sp := svc.Spec.Ports[i]
 				if sp.Name == svcPort {
 					if sp.Protocol == proto {
-						endps = getEndpointsFromSlices(svc, &sp, proto, zone, n.store.GetServiceEndpointsSlices)
+						endps = getEndpointsFromSlices(svc, &sp, proto, zone, n.getServiceEndpointsSlices)
 						break
 					}
 				}
@@ -537,7 +770,7 @@ func (n *NGINXController) getStreamServices(configmapName string, proto apiv1.Pr
 				//nolint:gosec // Ignore G109 error
 				if sp.Port == int32(targetPort) {
 					if sp.Protocol == proto {
-						endps = getEndpointsFromSlices(svc, &sp, proto, zone, n.store.GetServiceEndpointsSlices)
+						endps = getEndpointsFromSlices(svc, &sp, proto, zone, n.getServiceEndpointsSlices)
 						break
 					}
 				}
@@ -549,6 +782,7 @@ func (n *NGINXController) getStreamServices(configmapName string, proto apiv1.Pr
 			klog.Warningf("Service %q does not have any active Endpoint for %v port %v", nsName, proto, svcPort)
 			continue
 		}
+		opts := parseStreamOptions(options, proto)
 		svcs = append(svcs, ingress.L4Service{
 			Port: externalPort,
 			Backend: ingress.L4Backend{
@@ -560,6 +794,8 @@ func (n *NGINXController) getStreamServices(configmapName string, proto apiv1.Pr
 			},
 			Endpoints: endps,
 			Service:   svc,
+			AccessLog: opts.accessLog,
+			Proxy:     opts.proxy,
 		})
 	}
 	// Keep upstream order sorted to reduce unnecessary nginx config reloads.
@@ -594,7 +830,7 @@ func (n *NGINXController) getDefaultUpstream() *ingress.Backend {
 	} else {
 		zone = emptyZone
 	}
-	endps := getEndpointsFromSlices(svc, &svc.Spec.Ports[0], apiv1.ProtocolTCP, zone, n.store.GetServiceEndpointsSlices)
+	endps := getEndpointsFromSlices(svc, &svc.Spec.Ports[0], apiv1.ProtocolTCP, zone, n.getServiceEndpointsSlices)
 	if len(endps) == 0 {
 		klog.Warningf("Service %q does not have any active Endpoint", svcKey)
 		endps = []ingress.Endpoint{n.DefaultEndpoint()}
@@ -605,9 +841,33 @@ func (n *NGINXController) getDefaultUpstream() *ingress.Backend {
 	return upstream
 }
 
+// setDeprecatedUsage updates the metrics of the deprecated annotations of the
+// Ingresses and the deprecated keys of the configuration ConfigMap
+func (n *NGINXController) setDeprecatedUsage(ings []*ingress.Ingress) {
+	anns := map[string]int{}
+	for _, ing := range ings {
+		if ing.ParsedAnnotations == nil {
+			continue
+		}
+		for _, u := range ing.ParsedAnnotations.Deprecated {
+			anns[u.Name]++
+		}
+	}
+
+	var keys []string
+	for _, u := range n.store.GetBackendConfiguration().DeprecatedKeys {
+		keys = append(keys, u.Name)
+	}
+
+	n.metricCollector.SetDeprecatedUsage(anns, keys)
+}
+
 // getConfiguration returns the configuration matching the standard kubernetes ingress
 func (n *NGINXController) getConfiguration(ingresses []*ingress.Ingress) (sets.Set[string], []*ingress.Server, *ingress.Configuration) {
+	ingresses = sortIngressesByConflictPolicy(ingresses, n.store.GetBackendConfiguration().IngressConflictPolicy)
 	upstreams, servers := n.getBackendServers(ingresses)
+	// an Ingress with hosts of different shards creates servers of other shards
+	servers = n.cfg.Shard.FilterServers(servers)
 	var passUpstreams []*ingress.SSLPassthroughBackend
 
 	hosts := sets.New[string]()
@@ -656,6 +916,10 @@ func (n *NGINXController) getConfiguration(ingresses []*ingress.Ingress) (sets.S
 		}
 	}
 
+	if n.store.GetBackendConfiguration().HashUpstreamNames {
+		hashUpstreamNames(upstreams, servers, passUpstreams)
+	}
+
 	return hosts, servers, &ingress.Configuration{
 		Backends:              upstreams,
 		Servers:               servers,
@@ -665,6 +929,8 @@ func (n *NGINXController) getConfiguration(ingresses []*ingress.Ingress) (sets.S
 		BackendConfigChecksum: n.store.GetBackendConfiguration().Checksum,
 		DefaultSSLCertificate: n.getDefaultSSLCertificate(),
 		StreamSnippets:        n.getStreamSnippets(ingresses),
+		CDNRanges:             n.cdnRanges.get(n.store.GetBackendConfiguration().CDNProvider),
+		ErrorLogLevel:         n.logLevel.ErrorLogLevel(),
 	}
 }
 
@@ -820,6 +1086,7 @@ func (n *NGINXController) getBackendServers(ingresses []*ingress.Ingress) ([]*in
 
 					if loc.Redirect.FromToWWW {
 						server.RedirectFromToWWW = true
+						server.RedirectFromToWWWDirection = loc.Redirect.FromToWWWDirection
 					}
 
 					break
@@ -842,6 +1109,7 @@ func (n *NGINXController) getBackendServers(ingresses []*ingress.Ingress) ([]*in
 
 					if loc.Redirect.FromToWWW {
 						server.RedirectFromToWWW = true
+						server.RedirectFromToWWWDirection = loc.Redirect.FromToWWWDirection
 					}
 					server.Locations = append(server.Locations, loc)
 				}
@@ -901,6 +1169,7 @@ func (n *NGINXController) getBackendServers(ingresses []*ingress.Ingress) ([]*in
 	}
 
 	aUpstreams := make([]*ingress.Backend, 0, len(upstreams))
+	fallbackUpstreams := sets.New[string]()
 
 	for _, upstream := range upstreams {
 		aUpstreams = append(aUpstreams, upstream)
@@ -912,6 +1181,17 @@ func (n *NGINXController) getBackendServers(ingresses []*ingress.Ingress) ([]*in
 		isHTTPSfrom := []*ingress.Server{}
 		for _, server := range servers {
 			for _, location := range server.Locations {
+				if shouldCreateUpstreamForLocationErrorsFallback(upstream, location) {
+					if nb := n.customErrorsFallbackUpstream(upstream, location.CustomErrorsFallback); nb != nil {
+						if !fallbackUpstreams.Has(nb.Name) {
+							klog.V(3).Infof("Creating \"%v\" upstream based on custom errors fallback annotation", nb.Name)
+							aUpstreams = append(aUpstreams, nb)
+							fallbackUpstreams.Insert(nb.Name)
+						}
+						location.CustomErrorsFallbackUpstreamName = nb.Name
+					}
+				}
+
 				// use default backend
 				if !shouldCreateUpstreamForLocationDefaultBackend(upstream, location) {
 					continue
@@ -929,7 +1209,7 @@ func (n *NGINXController) getBackendServers(ingresses []*ingress.Ingress) ([]*in
 				} else {
 					zone = emptyZone
 				}
-				endps := getEndpointsFromSlices(location.DefaultBackend, &sp, apiv1.ProtocolTCP, zone, n.store.GetServiceEndpointsSlices)
+				endps := getEndpointsFromSlices(location.DefaultBackend, &sp, apiv1.ProtocolTCP, zone, n.getServiceEndpointsSlices)
 				// custom backend is valid only if contains at least one endpoint
 				if len(endps) > 0 {
 					name := fmt.Sprintf("custom-default-backend-%v-%v", location.DefaultBackend.GetNamespace(), location.DefaultBackend.GetName())
@@ -968,13 +1248,8 @@ func (n *NGINXController) getBackendServers(ingresses []*ingress.Ingress) ([]*in
 
 	aServers := make([]*ingress.Server, 0, len(servers))
 	for _, value := range servers {
-		sort.SliceStable(value.Locations, func(i, j int) bool {
-			return value.Locations[i].Path > value.Locations[j].Path
-		})
-
-		sort.SliceStable(value.Locations, func(i, j int) bool {
-			return len(value.Locations[i].Path) > len(value.Locations[j].Path)
-		})
+		value.InternalLocations = removeRoutedInternalLocations(value)
+		sortLocations(value.Locations)
 		aServers = append(aServers, value)
 	}
 
@@ -989,6 +1264,18 @@ func (n *NGINXController) getBackendServers(ingresses []*ingress.Ingress) ([]*in
 	return aUpstreams, aServers
 }
 
+// sortLocations sorts the locations of a server in the order of nginx.conf,
+// from the longest path to the shortest one
+func sortLocations(locations []*ingress.Location) {
+	sort.SliceStable(locations, func(i, j int) bool {
+		return locations[i].Path > locations[j].Path
+	})
+
+	sort.SliceStable(locations, func(i, j int) bool {
+		return len(locations[i].Path) > len(locations[j].Path)
+	})
+}
+
 // createUpstreams creates the NGINX upstreams (Endpoints) for each Service
 // referenced in Ingress rules.
 func (n *NGINXController) createUpstreams(data []*ingress.Ingress, du *ingress.Backend) map[string]*ingress.Backend {
@@ -1131,9 +1418,50 @@ func (n *NGINXController) createUpstreams(data []*ingress.Ingress, du *ingress.B
 		}
 	}
 
+	// the services of the route-if annotation use the upstreams of the paths if any
+	for _, ing := range data {
+		for i := range ing.ParsedAnnotations.RouteIf.Rules {
+			n.createRouteUpstream(upstreams, ing, &ing.ParsedAnnotations.RouteIf.Rules[i].Service)
+		}
+	}
+
 	return upstreams
 }
 
+// createRouteUpstream creates the upstream of a service receiving the requests
+// routed by the route-if annotation, unless a path of an Ingress already uses it
+func (n *NGINXController) createRouteUpstream(upstreams map[string]*ingress.Backend, ing *ingress.Ingress, service *networking.IngressServiceBackend) {
+	name := upstreamName(ing.Namespace, service)
+	if _, ok := upstreams[name]; ok {
+		return
+	}
+
+	klog.V(3).Infof("Creating upstream %q", name)
+	svcName, svcPort := upstreamServiceNameAndPort(service)
+	upstream := newUpstream(name)
+	upstream.Port = svcPort
+
+	upstream.LoadBalancing = ing.ParsedAnnotations.LoadBalancing
+	if upstream.LoadBalancing == "" {
+		upstream.LoadBalancing = n.store.GetBackendConfiguration().LoadBalancing
+	}
+
+	svcKey := fmt.Sprintf("%v/%v", ing.Namespace, svcName)
+	endps, err := n.serviceEndpoints(svcKey, svcPort.String())
+	if err != nil {
+		klog.Warningf("Error obtaining Endpoints for Service %q: %v", svcKey, err)
+	}
+	upstream.Endpoints = endps
+
+	s, err := n.store.GetService(svcKey)
+	if err != nil {
+		klog.Warningf("Error obtaining Service %q: %v", svcKey, err)
+	}
+	upstream.Service = s
+
+	upstreams[name] = upstream
+}
+
 // getServiceClusterEndpoint returns an Endpoint corresponding to the ClusterIP
 // field of a Service.
 func (n *NGINXController) getServiceClusterEndpoint(svcKey string, backend *networking.IngressBackend) (endpoint ingress.Endpoint, err error) {
@@ -1172,6 +1500,25 @@ func (n *NGINXController) getServiceClusterEndpoint(svcKey string, backend *netw
 	return endpoint, err
 }
 
+// getServiceEndpointsSlices returns the EndpointSlices of a Service, without
+// the IPv6 ones unless the IPv6 endpoints are enabled
+func (n *NGINXController) getServiceEndpointsSlices(svcKey string) ([]*discoveryv1.EndpointSlice, error) {
+	epss, err := n.store.GetServiceEndpointsSlices(svcKey)
+	if err != nil || n.cfg.EnableIPv6Endpoints {
+		return epss, err
+	}
+
+	filtered := make([]*discoveryv1.EndpointSlice, 0, len(epss))
+	for _, eps := range epss {
+		if eps.AddressType == discoveryv1.AddressTypeIPv6 {
+			continue
+		}
+		filtered = append(filtered, eps)
+	}
+
+	return filtered, nil
+}
+
 // serviceEndpoints returns the upstream servers (Endpoints) associated with a Service.
 func (n *NGINXController) serviceEndpoints(svcKey, backendPort string) ([]ingress.Endpoint, error) {
 	var upstreams []ingress.Endpoint
@@ -1194,7 +1541,7 @@ func (n *NGINXController) serviceEndpoints(svcKey, backendPort string) ([]ingres
 			return upstreams, nil
 		}
 		servicePort := externalNamePorts(backendPort, svc)
-		endps := getEndpointsFromSlices(svc, servicePort, apiv1.ProtocolTCP, zone, n.store.GetServiceEndpointsSlices)
+		endps := getEndpointsFromSlices(svc, servicePort, apiv1.ProtocolTCP, zone, n.getServiceEndpointsSlices)
 		if len(endps) == 0 {
 			klog.Warningf("Service %q does not have any active Endpoint.", svcKey)
 			return upstreams, nil
@@ -1210,7 +1557,7 @@ func (n *NGINXController) serviceEndpoints(svcKey, backendPort string) ([]ingres
 		if strconv.Itoa(int(servicePort.Port)) == backendPort ||
 			servicePort.TargetPort.String() == backendPort ||
 			servicePort.Name == backendPort {
-			endps := getEndpointsFromSlices(svc, &servicePort, apiv1.ProtocolTCP, zone, n.store.GetServiceEndpointsSlices)
+			endps := getEndpointsFromSlices(svc, &servicePort, apiv1.ProtocolTCP, zone, n.getServiceEndpointsSlices)
 			if len(endps) == 0 {
 				klog.Warningf("Service %q does not have any active Endpoint.", svcKey)
 			}
@@ -1237,6 +1584,37 @@ func (n *NGINXController) getDefaultSSLCertificate() *ingress.SSLCert {
 	return n.cfg.FakeCertificate
 }
 
+// getDefaultServerSSLCertificate returns the certificate of the catch-all server,
+// used for the requests that do not match any server
+func (n *NGINXController) getDefaultServerSSLCertificate() *ingress.SSLCert {
+	secretName := n.store.GetBackendConfiguration().DefaultServerSSLCertificate
+	if secretName != "" {
+		certificate, err := n.store.GetLocalSSLCert(secretName)
+		if err == nil {
+			return certificate
+		}
+
+		klog.Warningf("Error loading the certificate of the default server, falling back to the default certificate:\n%v", err)
+	}
+
+	return n.getDefaultSSLCertificate()
+}
+
+// reservedPorts returns the ports used by the Ingress controller itself
+func (n *NGINXController) reservedPorts() sets.Int {
+	return sets.NewInt(
+		n.cfg.ListenPorts.HTTP,
+		n.cfg.ListenPorts.HTTPS,
+		n.cfg.ListenPorts.SSLProxy,
+		n.cfg.ListenPorts.Health,
+		n.cfg.ListenPorts.Default,
+		n.cfg.ListenPorts.LBHealthCheck,
+		nginx.ProfilerPort,
+		nginx.StatusPort,
+		nginx.StreamPort,
+	)
+}
+
 // createServers builds a map of host name to Server structs from a map of
 // already computed Upstream structs. Each Server is configured with at least
 // one root location, which uses a default backend if left unspecified.
@@ -1246,6 +1624,8 @@ func (n *NGINXController) createServers(data []*ingress.Ingress,
 ) map[string]*ingress.Server {
 	servers := make(map[string]*ingress.Server, len(data))
 	allAliases := make(map[string][]string, len(data))
+	// extra listen ports in use and whether they are configured with TLS
+	extraListenPorts := make(map[int]bool)
 
 	bdef := n.store.GetDefaultBackend()
 	ngxProxy := proxy.Config{
@@ -1272,7 +1652,7 @@ func (n *NGINXController) createServers(data []*ingress.Ingress,
 	pathTypePrefix := networking.PathTypePrefix
 	servers[defServerName] = &ingress.Server{
 		Hostname: defServerName,
-		SSLCert:  n.getDefaultSSLCertificate(),
+		SSLCert:  n.getDefaultServerSSLCertificate(),
 		Locations: []*ingress.Location{
 			{
 				Path:         rootLocation,
@@ -1363,6 +1743,10 @@ func (n *NGINXController) createServers(data []*ingress.Ingress,
 				SSLPassthrough:         anns.SSLPassthrough,
 				SSLCiphers:             anns.SSLCipher.SSLCiphers,
 				SSLPreferServerCiphers: anns.SSLCipher.SSLPreferServerCiphers,
+				SSLProtocols:           anns.SSLCipher.SSLProtocols,
+				HeaderLimits:           anns.HeaderLimits,
+				HTTP2:                  anns.HTTP2,
+				RealIP:                 anns.RealIP,
 			}
 		}
 	}
@@ -1415,6 +1799,47 @@ func (n *NGINXController) createServers(data []*ingress.Ingress,
 				servers[host].SSLPreferServerCiphers = anns.SSLCipher.SSLPreferServerCiphers
 			}
 
+			// only add SSL protocols if the server does not have them previously configured
+			if servers[host].SSLProtocols == "" && anns.SSLCipher.SSLProtocols != "" {
+				servers[host].SSLProtocols = anns.SSLCipher.SSLProtocols
+			}
+
+			if len(anns.ExtraListenPorts.HTTP) > 0 || len(anns.ExtraListenPorts.HTTPS) > 0 {
+				if len(servers[host].ExtraListenPorts.HTTP) == 0 && len(servers[host].ExtraListenPorts.HTTPS) == 0 {
+					servers[host].ExtraListenPorts = n.filterExtraListenPorts(&anns.ExtraListenPorts, extraListenPorts, host, ingKey)
+				} else {
+					klog.Warningf("Extra listen ports already configured for server %q, skipping (Ingress %q)", host, ingKey)
+				}
+			}
+
+			if len(anns.InternalLocations.Locations) > 0 {
+				servers[host].InternalLocations = n.filterInternalLocations(servers[host].InternalLocations, anns.InternalLocations.Locations, host, ingKey)
+			}
+
+			if anns.HeaderLimits != (headerlimits.Config{}) {
+				if servers[host].HeaderLimits == (headerlimits.Config{}) {
+					servers[host].HeaderLimits = anns.HeaderLimits
+				} else if servers[host].HeaderLimits != anns.HeaderLimits {
+					klog.Warningf("Header limits already configured for server %q, skipping (Ingress %q)", host, ingKey)
+				}
+			}
+
+			if anns.HTTP2.Set {
+				if !servers[host].HTTP2.Set {
+					servers[host].HTTP2 = anns.HTTP2
+				} else if servers[host].HTTP2 != anns.HTTP2 {
+					klog.Warningf("HTTP/2 already configured for server %q, skipping (Ingress %q)", host, ingKey)
+				}
+			}
+
+			if anns.RealIP.Set {
+				if !servers[host].RealIP.Set {
+					servers[host].RealIP = anns.RealIP
+				} else if servers[host].RealIP != anns.RealIP {
+					klog.Warningf("Real IP already configured for server %q, skipping (Ingress %q)", host, ingKey)
+				}
+			}
+
 			// only add a certificate if the server does not have one previously configured
 			if servers[host].SSLCert != nil {
 				continue
@@ -1437,6 +1862,10 @@ func (n *NGINXController) createServers(data []*ingress.Ingress,
 			if err != nil {
 				klog.Warningf("Error getting SSL certificate %q: %v. Using default certificate", secrKey, err)
 				servers[host].SSLCert = n.getDefaultSSLCertificate()
+				if _, err := n.store.GetSecret(secrKey); errors.As(err, new(store.NotExistsError)) {
+					servers[host].PendingCertificate = secrKey
+					servers[host].SSLRedirectDeferred = n.store.GetBackendConfiguration().DeferSSLRedirect
+				}
 				continue
 			}
 
@@ -1501,24 +1930,125 @@ func (n *NGINXController) createServers(data []*ingress.Ingress,
 	return servers
 }
 
+// filterExtraListenPorts removes the extra listen ports not allowed in the
+// configuration, reserved by the controller, or already used by another
+// server with a different TLS setting. NGINX enables TLS on all the servers
+// listening on a port if one of them uses it.
+func (n *NGINXController) filterExtraListenPorts(cfg *extralistenports.Config,
+	inUse map[int]bool, host, ingKey string,
+) extralistenports.Config {
+	allowed := sets.NewInt(n.store.GetBackendConfiguration().AllowedExtraListenPorts...)
+	reserved := n.reservedPorts()
+
+	filter := func(ports []int, ssl bool) []int {
+		valid := sets.NewInt()
+		for _, port := range ports {
+			if !allowed.Has(port) {
+				klog.Warningf("Port %d is not allowed as extra listen port for server %q, skipping (Ingress %q)", port, host, ingKey)
+				continue
+			}
+
+			if reserved.Has(port) {
+				klog.Warningf("Port %d cannot be used as extra listen port for server %q. It is reserved for the Ingress controller (Ingress %q)", port, host, ingKey)
+				continue
+			}
+
+			if usesSSL, ok := inUse[port]; ok && usesSSL != ssl {
+				klog.Warningf("Port %d is already used by another server with a different TLS configuration, skipping for server %q (Ingress %q)", port, host, ingKey)
+				continue
+			}
+
+			if valid.Has(port) {
+				continue
+			}
+
+			inUse[port] = ssl
+			valid.Insert(port)
+		}
+
+		return valid.List()
+	}
+
+	return extralistenports.Config{
+		HTTP:  filter(cfg.HTTP, false),
+		HTTPS: filter(cfg.HTTPS, true),
+	}
+}
+
+// filterInternalLocations adds the internal locations of an Ingress to the
+// ones of a server, skipping the directories not below the
+// internal-locations-root and the paths already used by another Ingress.
+func (n *NGINXController) filterInternalLocations(current, locations []internallocations.Location, host, ingKey string) []internallocations.Location {
+	root := n.store.GetBackendConfiguration().InternalLocationsRoot
+
+	for _, location := range locations {
+		if location.Alias != "" && !internallocations.UnderRoot(location.Alias, root) {
+			klog.Warningf("Directory %q of internal location %q is not below the internal-locations-root %q, skipping for server %q (Ingress %q)",
+				location.Alias, location.Path, root, host, ingKey)
+			continue
+		}
+
+		i := slices.IndexFunc(current, func(l internallocations.Location) bool {
+			return l.Path == location.Path
+		})
+		if i != -1 {
+			if current[i] != location {
+				klog.Warningf("Internal location %q already configured for server %q, skipping (Ingress %q)", location.Path, host, ingKey)
+			}
+			continue
+		}
+
+		current = append(current, location)
+	}
+
+	return current
+}
+
+// removeRoutedInternalLocations removes the internal locations of a server
+// colliding with the path of one of its locations.
+func removeRoutedInternalLocations(server *ingress.Server) []internallocations.Location {
+	return slices.DeleteFunc(server.InternalLocations, func(internal internallocations.Location) bool {
+		for _, loc := range server.Locations {
+			if internallocations.Collides(internal.Path, loc.Path) {
+				klog.Warningf("Internal location %q collides with the location %q of server %q, skipping", internal.Path, loc.Path, server.Hostname)
+				return true
+			}
+		}
+		return false
+	})
+}
+
 func locationApplyAnnotations(loc *ingress.Location, anns *annotations.Ingress) {
 	loc.BasicDigestAuth = anns.BasicDigestAuth
 	loc.ClientBodyBufferSize = anns.ClientBodyBufferSize
+	loc.Compression = anns.Compression
 	loc.CustomHeaders = anns.CustomHeaders
+	loc.HeadersFromVariables = anns.HeadersFromVariables
 	loc.ConfigurationSnippet = anns.ConfigurationSnippet
 	loc.CorsConfig = anns.CorsConfig
 	loc.ExternalAuth = anns.ExternalAuth
+	// auth-url takes precedence over the LDAP authentication
+	loc.AuthLDAP = authldap.Config{}
+	if anns.ExternalAuth.URL == "" && anns.AuthLDAP.Secret != "" {
+		loc.AuthLDAP = anns.AuthLDAP
+		loc.ExternalAuth = anns.AuthLDAP.ExternalAuth()
+	}
 	loc.EnableGlobalAuth = anns.EnableGlobalAuth
 	loc.HTTP2PushPreload = anns.HTTP2PushPreload
 	loc.Opentelemetry = anns.Opentelemetry
 	loc.Proxy = anns.Proxy
 	loc.ProxySSL = anns.ProxySSL
+	loc.Ranges = anns.Ranges
 	loc.RateLimit = anns.RateLimit
+	loc.UploadGuard = anns.UploadGuard
+	loc.HTTPTransform = anns.HTTPTransform
+	loc.RouteIf = anns.RouteIf
 	loc.Redirect = anns.Redirect
 	loc.Rewrite = anns.Rewrite
 	loc.UpstreamVhost = anns.UpstreamVhost
 	loc.Denylist = anns.Denylist
 	loc.Allowlist = anns.Allowlist
+	loc.AllowedHTTPMethods = anns.AllowedHTTPMethods
 	loc.Denied = anns.Denied
 	loc.XForwardedPrefix = anns.XForwardedPrefix
 	loc.UsePortInRedirects = anns.UsePortInRedirects
@@ -1529,11 +2059,15 @@ func locationApplyAnnotations(loc *ingress.Location, anns *annotations.Ingress)
 	loc.FastCGI = anns.FastCGI
 	loc.CustomHTTPErrors = anns.CustomHTTPErrors
 	loc.DisableProxyInterceptErrors = anns.DisableProxyInterceptErrors
+	loc.ProxyInterceptErrors = anns.ProxyInterceptErrors
+	loc.CustomErrorsFallback = anns.CustomErrorsFallback
 	loc.ModSecurity = anns.ModSecurity
 	loc.Satisfy = anns.Satisfy
 	loc.Mirror = anns.Mirror
+	loc.SubFilter = anns.SubFilter
 
 	loc.DefaultBackendUpstreamName = defUpstreamName
+	loc.CustomErrorsFallbackUpstreamName = ""
 }
 
 // OK to merge canary ingresses iff there exists one or more ingresses to potentially merge into
@@ -1740,6 +2274,39 @@ func shouldCreateUpstreamForLocationDefaultBackend(upstream *ingress.Backend, lo
 		location.DefaultBackend != nil
 }
 
+// shouldCreateUpstreamForLocationErrorsFallback returns true when the custom
+// errors of the location chain to a fallback service
+func shouldCreateUpstreamForLocationErrorsFallback(upstream *ingress.Backend, location *ingress.Location) bool {
+	return upstream.Name == location.Backend &&
+		len(location.CustomHTTPErrors) != 0 &&
+		location.CustomErrorsFallback != nil
+}
+
+// customErrorsFallbackUpstream returns the upstream of the service handling the
+// custom errors of a location when its default backend fails, or nil when the
+// service has no active endpoint
+func (n *NGINXController) customErrorsFallbackUpstream(upstream *ingress.Backend, svc *apiv1.Service) *ingress.Backend {
+	if len(svc.Spec.Ports) == 0 {
+		klog.Errorf("Custom errors fallback service %v/%v has no ports. Ignoring", svc.Namespace, svc.Name)
+		return nil
+	}
+
+	zone := emptyZone
+	if n.cfg.EnableTopologyAwareRouting {
+		zone = getIngressPodZone(svc)
+	}
+
+	endps := getEndpointsFromSlices(svc, &svc.Spec.Ports[0], apiv1.ProtocolTCP, zone, n.getServiceEndpointsSlices)
+	if len(endps) == 0 {
+		return nil
+	}
+
+	nb := upstream.DeepCopy()
+	nb.Name = fmt.Sprintf("custom-errors-fallback-%v-%v", svc.GetNamespace(), svc.GetName())
+	nb.Endpoints = endps
+	return nb
+}
+
 func externalNamePorts(name string, svc *apiv1.Service) *apiv1.ServicePort {
 	port, err := strconv.Atoi(name) // #nosec
 	if err != nil {
@@ -1833,7 +2400,7 @@ func checkOverlap(ing *networking.Ingress, servers []*ingress.Server) error {
 					return fmt.Errorf(`host "%s" and path "%s" is already defined in ingress %s/%s`, rule.Host, path.Path, existing.Namespace, existing.Name)
 				}
 
-				if annotationErr == errors.ErrMissingAnnotations && existingAnnotationErr == errors.ErrMissingAnnotations {
+				if annotationErr == ing_errors.ErrMissingAnnotations && existingAnnotationErr == ing_errors.ErrMissingAnnotations {
 					return fmt.Errorf(`host "%s" and path "%s" is already defined in ingress %s/%s`, rule.Host, path.Path, existing.Namespace, existing.Name)
 				}
 			}
@@ -27,6 +27,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -44,6 +45,8 @@ import (
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/canary"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/extralistenports"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/internallocations"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/ipallowlist"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/proxyssl"
@@ -54,8 +57,10 @@ import (
 	"k8s.io/ingress-nginx/internal/ingress/defaults"
 	"k8s.io/ingress-nginx/internal/ingress/metric"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
+	"k8s.io/ingress-nginx/internal/ingress/transform"
 	"k8s.io/ingress-nginx/internal/k8s"
 	"k8s.io/ingress-nginx/internal/net/ssl"
+	"k8s.io/ingress-nginx/internal/nginx"
 
 	"k8s.io/ingress-nginx/pkg/util/file"
 )
@@ -74,6 +79,14 @@ func (fakeIngressStore) GetIngressClass(_ *networking.Ingress, _ *ingressclass.C
 	return "nginx", nil
 }
 
+func (fakeIngressStore) IsReferenceGranted(_, _, _, _ string) bool {
+	return false
+}
+
+func (fakeIngressStore) GetHTTPTransform(key string) (*transform.HTTPTransform, error) {
+	return nil, fmt.Errorf("HTTPTransform %v not found", key)
+}
+
 func (fis *fakeIngressStore) GetBackendConfiguration() ngx_config.Configuration {
 	return fis.configuration
 }
@@ -109,6 +122,16 @@ func (fis *fakeIngressStore) FilterIngresses(ingresses []*ingress.Ingress, _ sto
 	return ingresses
 }
 
+func (fis *fakeIngressStore) GetIngress(key string) (*ingress.Ingress, error) {
+	for _, ing := range fis.ingresses {
+		if ing.Namespace+"/"+ing.Name == key {
+			return ing, nil
+		}
+	}
+
+	return nil, fmt.Errorf("ingress %v not found", key)
+}
+
 func (fakeIngressStore) GetLocalSSLCert(_ string) (*ingress.SSLCert, error) {
 	return nil, fmt.Errorf("test error")
 }
@@ -138,6 +161,10 @@ func (ntc testNginxTestCommand) ExecCommand(_ ...string) *exec.Cmd {
 	return nil
 }
 
+func (ntc testNginxTestCommand) Output(_ ...string) ([]byte, error) {
+	return ntc.out, ntc.err
+}
+
 func (ntc testNginxTestCommand) Test(cfg string) ([]byte, error) {
 	fd, err := os.Open(cfg)
 	if err != nil {
@@ -165,6 +192,8 @@ func (fakeTemplate) Write(conf *ngx_config.TemplateConfig) ([]byte, error) {
 		}
 		r = append(r, []byte(s.Hostname)...)
 	}
+	// terminate the names as a directive to pass the checks of nginx.conf
+	r = append(r, ';')
 	return r, nil
 }
 
@@ -224,7 +253,7 @@ func TestCheckIngress(t *testing.T) {
 		nginx.command = testNginxTestCommand{
 			t:        t,
 			err:      nil,
-			expected: "_,example.com",
+			expected: "_,example.com;",
 		}
 		if nginx.CheckIngress(ing) != nil {
 			t.Errorf("with a new ingress without error, no error should be returned")
@@ -243,7 +272,7 @@ func TestCheckIngress(t *testing.T) {
 			nginx.command = testNginxTestCommand{
 				t:        t,
 				err:      nil,
-				expected: "_,test.example.com",
+				expected: "_,test.example.com;",
 			}
 			if nginx.CheckIngress(ing) != nil {
 				t.Errorf("with a new ingress without error, no error should be returned")
@@ -255,7 +284,7 @@ func TestCheckIngress(t *testing.T) {
 				t:        t,
 				err:      fmt.Errorf("test error"),
 				out:      []byte("this is the test command output"),
-				expected: "_,test.example.com",
+				expected: "_,test.example.com;",
 			}
 			if nginx.CheckIngress(ing) == nil {
 				t.Errorf("with a new ingress with an error, an error should be returned")
@@ -2536,6 +2565,110 @@ func TestGetBackendServers(t *testing.T) {
 				}
 			},
 		},
+		{
+			Ingresses: []*ingress.Ingress{
+				{
+					Ingress: networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "extra-ports-a",
+							Namespace: "default",
+						},
+						Spec: networking.IngressSpec{
+							Rules: []networking.IngressRule{
+								{
+									Host: "a.example.com",
+									IngressRuleValue: networking.IngressRuleValue{
+										HTTP: &networking.HTTPIngressRuleValue{
+											Paths: []networking.HTTPIngressPath{
+												{
+													Path:     "/",
+													PathType: &pathTypePrefix,
+													Backend: networking.IngressBackend{
+														Service: &networking.IngressServiceBackend{
+															Name: "http-svc",
+															Port: networking.ServiceBackendPort{
+																Number: 80,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					ParsedAnnotations: &annotations.Ingress{
+						ExtraListenPorts: extralistenports.Config{HTTP: []int{8080, 80, 9000}, HTTPS: []int{8443}},
+					},
+				},
+				{
+					Ingress: networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "extra-ports-b",
+							Namespace: "default",
+						},
+						Spec: networking.IngressSpec{
+							Rules: []networking.IngressRule{
+								{
+									Host: "b.example.com",
+									IngressRuleValue: networking.IngressRuleValue{
+										HTTP: &networking.HTTPIngressRuleValue{
+											Paths: []networking.HTTPIngressPath{
+												{
+													Path:     "/",
+													PathType: &pathTypePrefix,
+													Backend: networking.IngressBackend{
+														Service: &networking.IngressServiceBackend{
+															Name: "http-svc",
+															Port: networking.ServiceBackendPort{
+																Number: 80,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					ParsedAnnotations: &annotations.Ingress{
+						ExtraListenPorts: extralistenports.Config{HTTPS: []int{8080, 8443}},
+					},
+				},
+			},
+			Validate: func(_ []*ingress.Ingress, _ []*ingress.Backend, servers []*ingress.Server) {
+				if len(servers) != 3 {
+					t.Errorf("servers count should be 3, got %d", len(servers))
+					return
+				}
+
+				expected := extralistenports.Config{HTTP: []int{8080}, HTTPS: []int{8443}}
+				if !(&servers[1].ExtraListenPorts).Equal(&expected) {
+					t.Errorf("expected extra listen ports %v for server %q, got %v", expected, servers[1].Hostname, servers[1].ExtraListenPorts)
+				}
+
+				// 8080 is used without TLS by a.example.com
+				expected = extralistenports.Config{HTTPS: []int{8443}}
+				if !(&servers[2].ExtraListenPorts).Equal(&expected) {
+					t.Errorf("expected extra listen ports %v for server %q, got %v", expected, servers[2].Hostname, servers[2].ExtraListenPorts)
+				}
+			},
+			SetConfigMap: func(ns string) *corev1.ConfigMap {
+				return &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:     "config",
+						SelfLink: fmt.Sprintf("/api/v1/namespaces/%s/configmaps/config", ns),
+					},
+					Data: map[string]string{
+						"allowed-extra-listen-ports": "80,8080,8443",
+					},
+				}
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -2598,6 +2731,8 @@ func newNGINXController(t *testing.T) *NGINXController {
 			AnnotationValue: "nginx",
 		},
 		false,
+		nil,
+		nil,
 	)
 
 	sslCert := ssl.GetFakeSSLCert()
@@ -2611,7 +2746,7 @@ func newNGINXController(t *testing.T) *NGINXController {
 	return &NGINXController{
 		store:   storer,
 		cfg:     config,
-		command: NewNginxCommand(),
+		command: nginx.NewCommand(),
 	}
 }
 
@@ -2663,7 +2798,9 @@ func newDynamicNginxController(t *testing.T, setConfigMap func(string) *corev1.C
 			Controller:      "k8s.io/ingress-nginx",
 			AnnotationValue: "nginx",
 		},
-		false)
+		false,
+		nil,
+		nil)
 
 	sslCert := ssl.GetFakeSSLCert()
 	config := &Configuration{
@@ -2676,7 +2813,28 @@ func newDynamicNginxController(t *testing.T, setConfigMap func(string) *corev1.C
 	return &NGINXController{
 		store:           storer,
 		cfg:             config,
-		command:         NewNginxCommand(),
+		command:         nginx.NewCommand(),
 		metricCollector: metric.DummyCollector{},
 	}
 }
+
+func TestRemoveRoutedInternalLocations(t *testing.T) {
+	server := &ingress.Server{
+		Hostname: "example.com",
+		Locations: []*ingress.Location{
+			{Path: "/"},
+			{Path: "/app"},
+		},
+		InternalLocations: []internallocations.Location{
+			{Path: "/protected/", Alias: "/data/files/"},
+			{Path: "/app/files/", ProxyPass: "http://files.default.svc:8080"},
+		},
+	}
+
+	expected := []internallocations.Location{
+		{Path: "/protected/", Alias: "/data/files/"},
+	}
+	if got := removeRoutedInternalLocations(server); !slices.Equal(got, expected) {
+		t.Errorf("expected %v but got %v", expected, got)
+	}
+}
@@ -0,0 +1,536 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/internal/nginx"
+	"k8s.io/ingress-nginx/internal/task"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+const (
+	// crashLoopThreshold is the number of workers respawned in crashLoopWindow considered a crash loop
+	crashLoopThreshold = 3
+	// crashLoopWindow is the time a configuration must run with fewer crashes than the threshold to be good
+	crashLoopWindow = time.Minute
+	// workersSettleTime is the time after a reload the workers are not checked, while the new ones start
+	workersSettleTime = 5 * time.Second
+	// workersCheckInterval is the time between checks of the workers of NGINX
+	workersCheckInterval = 2 * time.Second
+	// bisectObservationTime is the time the workers are checked for crashes with each candidate configuration
+	bisectObservationTime = 20 * time.Second
+	// maxBisectIngresses is the maximum number of changed Ingresses checked by the bisect
+	maxBisectIngresses = 16
+)
+
+// procPath is the directory with the information of the processes
+var procPath = "/proc"
+
+// pinnedIngress is an Ingress which changed before a crash loop of the workers,
+// replaced by its previous version until it changes again
+type pinnedIngress struct {
+	// resourceVersion is the version of the Ingress causing the crash loop
+	resourceVersion string
+	// previous is the version of the Ingress before the crash loop, nil if it did not exist
+	previous *ingress.Ingress
+}
+
+// crashBisect is the search of the Ingress causing a crash loop among the changed
+// ones, reverting the changes of half of the suspects in each candidate configuration
+type crashBisect struct {
+	// ings are the Ingresses of the configuration crash looping
+	ings []*ingress.Ingress
+	// changed are the keys of the Ingresses changed before the crash loop
+	changed []string
+	// suspects are the keys of the changed Ingresses which can cause the crash loop
+	suspects []string
+	// reverted are the suspects whose changes are reverted in the candidate configuration
+	reverted []string
+	// deadline is the time the workers of the candidate configuration are checked
+	deadline time.Time
+}
+
+// crashRecovery detects the worker processes of NGINX crashing repeatedly after a reload
+// and keeps the Ingresses of the last configuration running without crashes
+type crashRecovery struct {
+	mu sync.Mutex
+
+	// current are the Ingresses of the configuration running in NGINX
+	current map[string]*ingress.Ingress
+	// lastGood are the Ingresses of the last configuration running without a crash loop
+	lastGood map[string]*ingress.Ingress
+	// appliedAt is the time the current configuration was applied
+	appliedAt time.Time
+
+	// reloadedAt is the time of the last reload of NGINX
+	reloadedAt time.Time
+	// workers are the PIDs of the workers after the last check, nil while they settle after a reload
+	workers sets.Set[int]
+	// respawns are the times workers were started after a crash since the last reload
+	respawns []time.Time
+	// crashLoop is true when the workers of the current configuration are crash looping
+	crashLoop bool
+
+	// pinned are the Ingresses replaced by their previous version, by key
+	pinned map[string]pinnedIngress
+
+	// bisect is the bisect in progress, nil when there is none
+	bisect *crashBisect
+}
+
+func newCrashRecovery() *crashRecovery {
+	return &crashRecovery{
+		pinned: map[string]pinnedIngress{},
+	}
+}
+
+// ingressesByKey returns the Ingresses by namespace and name
+func ingressesByKey(ings []*ingress.Ingress) map[string]*ingress.Ingress {
+	byKey := make(map[string]*ingress.Ingress, len(ings))
+	for _, ing := range ings {
+		byKey[k8s.MetaNamespaceKey(ing)] = ing
+	}
+
+	return byKey
+}
+
+// workersReloaded resets the respawns of the workers after a reload of NGINX
+func (r *crashRecovery) workersReloaded(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reloadedAt = now
+	r.workers = nil
+	r.respawns = nil
+	r.crashLoop = false
+}
+
+// applied records the Ingresses of the configuration running in NGINX
+func (r *crashRecovery) applied(ings []*ingress.Ingress, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.current = ingressesByKey(ings)
+	r.appliedAt = now
+	if r.lastGood == nil {
+		r.lastGood = r.current
+	}
+}
+
+// checkWorkers counts the workers started since the previous check as respawns after
+// a crash and returns true when the current configuration starts a crash loop
+func (r *crashRecovery) checkWorkers(workers sets.Set[int], now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.reloadedAt) < workersSettleTime {
+		return false
+	}
+
+	if r.workers != nil {
+		for range workers.Difference(r.workers) {
+			r.respawns = append(r.respawns, now)
+		}
+	}
+	r.workers = workers
+
+	r.respawns = slices.DeleteFunc(r.respawns, func(t time.Time) bool {
+		return now.Sub(t) > crashLoopWindow
+	})
+
+	if r.crashLoop {
+		return false
+	}
+
+	if len(r.respawns) >= crashLoopThreshold {
+		r.crashLoop = true
+		return true
+	}
+
+	// the candidates of a bisect are never the last good configuration
+	if now.Sub(r.appliedAt) > crashLoopWindow && r.current != nil && r.bisect == nil {
+		r.lastGood = r.current
+	}
+
+	return false
+}
+
+// crashLooping returns if the workers of the current configuration are crash looping
+func (r *crashRecovery) crashLooping() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.crashLoop
+}
+
+// changedIngresses returns the keys of the Ingresses of the current configuration
+// added or changed since the last good one
+func (r *crashRecovery) changedIngresses() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var changed []string
+	for key, ing := range r.current {
+		previous, ok := r.lastGood[key]
+		if !ok || previous.ResourceVersion != ing.ResourceVersion {
+			changed = append(changed, key)
+		}
+	}
+
+	slices.Sort(changed)
+	return changed
+}
+
+// pin replaces the version of the Ingress with the one of the last good configuration
+func (r *crashRecovery) pin(ing *ingress.Ingress) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := k8s.MetaNamespaceKey(ing)
+	r.pinned[key] = pinnedIngress{
+		resourceVersion: ing.ResourceVersion,
+		previous:        r.lastGood[key],
+	}
+}
+
+// filterIngresses replaces the pinned Ingresses with their previous version.
+// Ingresses changed again since they were pinned are not replaced anymore.
+func (r *crashRecovery) filterIngresses(ings []*ingress.Ingress) []*ingress.Ingress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.filterIngressesLocked(ings)
+}
+
+func (r *crashRecovery) filterIngressesLocked(ings []*ingress.Ingress) []*ingress.Ingress {
+	if len(r.pinned) == 0 {
+		return ings
+	}
+
+	filtered := make([]*ingress.Ingress, 0, len(ings))
+	for _, ing := range ings {
+		key := k8s.MetaNamespaceKey(ing)
+
+		p, ok := r.pinned[key]
+		if !ok {
+			filtered = append(filtered, ing)
+			continue
+		}
+
+		if p.resourceVersion != ing.ResourceVersion {
+			klog.InfoS("Ingress changed after a crash loop of the NGINX workers, using the new version", "ingress", key)
+			delete(r.pinned, key)
+			filtered = append(filtered, ing)
+			continue
+		}
+
+		if p.previous != nil {
+			filtered = append(filtered, p.previous)
+		}
+	}
+
+	return filtered
+}
+
+// startBisect starts the search of the Ingress causing the crash loop among the
+// changed ones. The versions of ings are the ones checked and pinned at the end.
+func (r *crashRecovery) startBisect(ings []*ingress.Ingress, changed []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.bisect = &crashBisect{
+		ings:     ings,
+		changed:  changed,
+		suspects: changed,
+	}
+}
+
+// bisecting returns if a bisect is in progress
+func (r *crashRecovery) bisecting() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.bisect != nil
+}
+
+// bisectCandidate returns the Ingresses of the next configuration checked by the
+// bisect, without the changes of the first half of the suspects, and their keys
+func (r *crashRecovery) bisectCandidate() ([]*ingress.Ingress, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.bisect
+	b.reverted = b.suspects[:len(b.suspects)/2]
+	reverted := sets.New(b.reverted...)
+
+	candidate := make([]*ingress.Ingress, 0, len(b.ings))
+	for _, ing := range r.filterIngressesLocked(b.ings) {
+		key := k8s.MetaNamespaceKey(ing)
+		if !reverted.Has(key) {
+			candidate = append(candidate, ing)
+			continue
+		}
+
+		if previous, ok := r.lastGood[key]; ok {
+			candidate = append(candidate, previous)
+		}
+	}
+
+	return candidate, b.reverted
+}
+
+// observeBisect records the time the workers of the candidate configuration,
+// applied at now, are checked
+func (r *crashRecovery) observeBisect(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.bisect.deadline = now.Add(workersSettleTime + bisectObservationTime)
+}
+
+// bisectObserved returns if the result of the candidate configuration is known,
+// because a worker crashed or the workers were checked until the deadline
+func (r *crashRecovery) bisectObserved(now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.respawns) > 0 || !now.Before(r.bisect.deadline)
+}
+
+// narrowBisect keeps the suspects whose changes were reverted when the workers of the
+// candidate configuration did not crash, and the others when they did. When a single
+// suspect is left, the bisect ends and it returns the Ingresses with the versions to
+// pin and the key of the Ingress causing the crash loop.
+func (r *crashRecovery) narrowBisect() ([]*ingress.Ingress, []string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.bisect
+	if len(r.respawns) > 0 {
+		b.suspects = b.suspects[len(b.reverted):]
+	} else {
+		b.suspects = b.reverted
+	}
+
+	if len(b.suspects) > 1 {
+		return nil, nil, false
+	}
+
+	r.bisect = nil
+	return b.ings, b.suspects, true
+}
+
+// stopBisect ends the bisect in progress and returns the Ingresses with the
+// versions to pin and the keys of the changed ones
+func (r *crashRecovery) stopBisect() ([]*ingress.Ingress, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.bisect
+	r.bisect = nil
+
+	return b.ings, b.changed
+}
+
+// listWorkers returns the PIDs of the worker processes of the NGINX master process
+func listWorkers(proc string, master int) (sets.Set[int], error) {
+	entries, err := os.ReadDir(proc)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := sets.New[int]()
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		stat, err := os.ReadFile(filepath.Join(proc, entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+
+		// the parent PID is the second field after the name, which can contain spaces
+		end := strings.LastIndexByte(string(stat), ')')
+		if end < 0 {
+			continue
+		}
+		fields := strings.Fields(string(stat[end+1:]))
+		if len(fields) < 2 || fields[1] != strconv.Itoa(master) {
+			continue
+		}
+
+		cmdline, err := os.ReadFile(filepath.Join(proc, entry.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+
+		// workers shutting down after a reload are not replaced
+		if strings.TrimRight(string(cmdline), "\x00 ") == "nginx: worker process" {
+			workers.Insert(pid)
+		}
+	}
+
+	return workers, nil
+}
+
+// readMasterPID returns the PID of the NGINX master process
+func readMasterPID() (int, error) {
+	f, err := os.ReadFile(nginx.PID)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(f)))
+}
+
+// watchWorkerCrashes checks the worker processes of NGINX and starts the
+// recovery when they crash repeatedly after a reload
+func (n *NGINXController) watchWorkerCrashes() {
+	wait.Until(func() {
+		master, err := readMasterPID()
+		if err != nil {
+			klog.V(3).ErrorS(err, "Error reading the PID of NGINX")
+			return
+		}
+
+		workers, err := listWorkers(procPath, master)
+		if err != nil {
+			klog.V(3).ErrorS(err, "Error listing the workers of NGINX")
+			return
+		}
+
+		if n.crashRecovery.checkWorkers(workers, time.Now()) {
+			n.syncQueue.EnqueueTask(task.GetDummyObject("crash-loop"))
+		}
+	}, workersCheckInterval, n.stopCh)
+}
+
+// recoverFromCrashLoop pins the Ingresses changed since the last good configuration
+// to their previous version. With the bisect enabled, it starts the search of the
+// Ingress causing the crashes, to pin only it.
+func (n *NGINXController) recoverFromCrashLoop(ings []*ingress.Ingress) error {
+	changed := n.crashRecovery.changedIngresses()
+	if len(changed) == 0 {
+		klog.Warningf("NGINX workers are crash looping without changes of the Ingresses since the last good configuration")
+		n.recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeWarning, "CrashLoop",
+			"NGINX workers crashed %v times after a reload without changes of the Ingresses since the last good configuration", crashLoopThreshold)
+		// avoid reporting it again until the next reload
+		n.crashRecovery.workersReloaded(time.Now())
+		return nil
+	}
+
+	klog.Warningf("NGINX workers are crash looping, reverting the changes of the Ingresses %v", changed)
+	n.recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeWarning, "CrashLoop",
+		"NGINX workers crashed %v times after a reload, reverting the changes of the Ingresses %v", crashLoopThreshold, strings.Join(changed, ", "))
+
+	if n.cfg.CrashRecoveryBisect && len(changed) > 1 && len(changed) <= maxBisectIngresses {
+		n.crashRecovery.startBisect(ings, changed)
+		return n.bisectStep()
+	}
+
+	return n.revertChanges(ings, changed)
+}
+
+// bisectStep reloads NGINX with the next candidate configuration of the bisect. Its
+// workers are checked by a later task of the sync queue, which is not blocked meanwhile.
+func (n *NGINXController) bisectStep() error {
+	candidate, reverted := n.crashRecovery.bisectCandidate()
+	klog.InfoS("Reloading NGINX without the changes of Ingresses to find the one causing the crash loop", "ingresses", reverted)
+
+	if err := n.reloadIngresses(candidate); err != nil {
+		ings, changed := n.crashRecovery.stopBisect()
+		klog.ErrorS(err, "Error reloading NGINX during the bisect, reverting the changes of all the Ingresses")
+		return n.revertChanges(ings, changed)
+	}
+
+	n.crashRecovery.observeBisect(time.Now())
+	time.AfterFunc(workersSettleTime+bisectObservationTime, func() {
+		n.syncQueue.EnqueueTask(task.GetDummyObject("crash-bisect"))
+	})
+
+	return nil
+}
+
+// continueBisect checks the workers of the candidate configuration of the bisect,
+// and reloads NGINX with the next one or reverts the change of the Ingress found
+func (n *NGINXController) continueBisect() error {
+	if !n.crashRecovery.bisectObserved(time.Now()) {
+		// the configuration is not synchronized during the bisect
+		return nil
+	}
+
+	ings, culprit, done := n.crashRecovery.narrowBisect()
+	if !done {
+		return n.bisectStep()
+	}
+
+	klog.InfoS("Found the Ingress causing the crash loop of the NGINX workers", "ingress", culprit[0])
+	return n.revertChanges(ings, culprit)
+}
+
+// revertChanges pins the Ingresses to their previous version, using the versions of
+// ings as the ones causing the crashes, and reloads NGINX with the Ingresses of the store
+func (n *NGINXController) revertChanges(ings []*ingress.Ingress, keys []string) error {
+	byKey := ingressesByKey(ings)
+	for _, key := range keys {
+		ing, ok := byKey[key]
+		if !ok {
+			continue
+		}
+
+		n.crashRecovery.pin(ing)
+		n.recorder.Eventf(&ing.Ingress, apiv1.EventTypeWarning, "CrashLoop",
+			"NGINX workers crashed after a change of the Ingress, using the previous version until it changes")
+	}
+
+	return n.reloadIngresses(n.crashRecovery.filterIngresses(n.cfg.Shard.FilterIngresses(n.store.ListIngresses())))
+}
+
+// reloadIngresses reloads NGINX with the configuration of the Ingresses
+func (n *NGINXController) reloadIngresses(ings []*ingress.Ingress) error {
+	ings, _ = filterNamespaceQuotas(ings, newNamespaceQuotas(n.store.GetBackendConfiguration()))
+	_, _, pcfg := n.getConfiguration(ings)
+
+	if err := n.reload(pcfg); err != nil {
+		return err
+	}
+	n.recordGeneration(triggerCrashRecovery, nil, pcfg.ConfigurationChecksum)
+
+	if err := n.configureDynamically(pcfg); err != nil {
+		return err
+	}
+
+	n.runningConfig.Store(pcfg)
+	n.crashRecovery.applied(ings, time.Now())
+	return nil
+}
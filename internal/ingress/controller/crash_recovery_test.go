@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func newVersionedIngress(name, resourceVersion string) *ingress.Ingress {
+	ing := &ingress.Ingress{}
+	ing.ObjectMeta = metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       "default",
+		ResourceVersion: resourceVersion,
+	}
+
+	return ing
+}
+
+func writeProcess(t *testing.T, proc, pid, stat, cmdline string) {
+	dir := filepath.Join(proc, pid)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestListWorkers(t *testing.T) {
+	proc := t.TempDir()
+	writeProcess(t, proc, "10", "10 (nginx) S 1 10 10 0", "nginx: master process /usr/bin/nginx -c /etc/nginx/nginx.conf\x00")
+	writeProcess(t, proc, "11", "11 (nginx) S 10 10 10 0", "nginx: worker process\x00")
+	writeProcess(t, proc, "12", "12 (nginx) S 10 10 10 0", "nginx: worker process is shutting down\x00")
+	writeProcess(t, proc, "13", "13 (nginx) S 10 10 10 0", "nginx: cache manager process\x00")
+	writeProcess(t, proc, "14", "14 (nginx) S 10 10 10 0", "nginx: worker process\x00")
+	writeProcess(t, proc, "20", "20 (nginx) S 19 19 19 0", "nginx: worker process\x00")
+
+	workers, err := listWorkers(proc, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !workers.Equal(sets.New(11, 14)) {
+		t.Errorf("expected the workers 11 and 14 but got %v", sets.List(workers))
+	}
+}
+
+func TestCrashRecoveryCheckWorkers(t *testing.T) {
+	r := newCrashRecovery()
+	start := time.Now()
+	r.workersReloaded(start)
+	r.applied([]*ingress.Ingress{newVersionedIngress("foo", "1")}, start)
+
+	if r.checkWorkers(sets.New(1, 2), start.Add(time.Second)) {
+		t.Fatalf("expected no crash loop while the workers settle")
+	}
+
+	now := start.Add(workersSettleTime)
+	checks := []sets.Set[int]{
+		sets.New(1, 2),
+		sets.New(1, 3),
+		sets.New(1, 4),
+		sets.New(1, 5),
+	}
+	crashLoops := 0
+	for _, workers := range checks {
+		now = now.Add(workersCheckInterval)
+		if r.checkWorkers(workers, now) {
+			crashLoops++
+		}
+	}
+
+	if crashLoops != 1 {
+		t.Errorf("expected a crash loop but got %v", crashLoops)
+	}
+	if !r.crashLooping() || len(r.respawns) == 0 {
+		t.Errorf("expected the workers crash looping")
+	}
+
+	r.workersReloaded(now)
+	if r.crashLooping() || len(r.respawns) != 0 {
+		t.Errorf("expected the crashes reset after a reload")
+	}
+}
+
+func TestCrashRecoveryLastGood(t *testing.T) {
+	r := newCrashRecovery()
+	start := time.Now()
+	r.workersReloaded(start)
+	r.applied([]*ingress.Ingress{newVersionedIngress("foo", "1"), newVersionedIngress("bar", "1")}, start)
+
+	// the first configuration is the last good one
+	if changed := r.changedIngresses(); len(changed) != 0 {
+		t.Errorf("expected no changed Ingresses but got %v", changed)
+	}
+
+	r.applied([]*ingress.Ingress{newVersionedIngress("foo", "2"), newVersionedIngress("bar", "1"), newVersionedIngress("baz", "1")}, start)
+	expected := []string{"default/baz", "default/foo"}
+	if changed := r.changedIngresses(); !sets.New(changed...).Equal(sets.New(expected...)) {
+		t.Errorf("expected the changed Ingresses %v but got %v", expected, changed)
+	}
+
+	// the configuration is good after running a window without a crash loop
+	r.checkWorkers(sets.New(1), start.Add(crashLoopWindow+time.Second))
+	if changed := r.changedIngresses(); len(changed) != 0 {
+		t.Errorf("expected no changed Ingresses but got %v", changed)
+	}
+}
+
+func TestCrashRecoveryPin(t *testing.T) {
+	r := newCrashRecovery()
+	now := time.Now()
+	r.applied([]*ingress.Ingress{newVersionedIngress("foo", "1")}, now)
+	r.applied([]*ingress.Ingress{newVersionedIngress("foo", "2"), newVersionedIngress("bar", "1")}, now)
+
+	r.pin(newVersionedIngress("foo", "2"))
+	r.pin(newVersionedIngress("bar", "1"))
+
+	filtered := r.filterIngresses([]*ingress.Ingress{newVersionedIngress("foo", "2"), newVersionedIngress("bar", "1")})
+	if len(filtered) != 1 || filtered[0].Name != "foo" || filtered[0].ResourceVersion != "1" {
+		t.Errorf("expected the previous version of foo without bar but got %v", filtered)
+	}
+
+	// Ingresses changed again are not pinned anymore
+	filtered = r.filterIngresses([]*ingress.Ingress{newVersionedIngress("foo", "3"), newVersionedIngress("bar", "2")})
+	if len(filtered) != 2 || filtered[0].ResourceVersion != "3" || filtered[1].ResourceVersion != "2" {
+		t.Errorf("expected the new versions of foo and bar but got %v", filtered)
+	}
+}
+
+// fakeNGINX simulates the workers of NGINX, which crash when the configuration
+// contains a version of an Ingress
+type fakeNGINX struct {
+	r   *crashRecovery
+	now time.Time
+	// crashing returns if the workers crash with the Ingresses
+	crashing func(ings []*ingress.Ingress) bool
+
+	running []*ingress.Ingress
+	pid     int
+	workers sets.Set[int]
+	reloads int
+}
+
+func (f *fakeNGINX) reload(ings []*ingress.Ingress) {
+	f.reloads++
+	f.running = ings
+	f.pid++
+	f.workers = sets.New(f.pid)
+	f.r.workersReloaded(f.now)
+	f.r.applied(ings, f.now)
+}
+
+// run checks the workers until done returns true or the duration elapses,
+// and returns if a crash loop started
+func (f *fakeNGINX) run(d time.Duration, done func() bool) bool {
+	crashLoop := false
+	for end := f.now.Add(d); f.now.Before(end) && !done(); {
+		f.now = f.now.Add(workersCheckInterval)
+		if f.crashing(f.running) {
+			f.pid++
+			f.workers = sets.New(f.pid)
+		}
+		if f.r.checkWorkers(f.workers, f.now) {
+			crashLoop = true
+		}
+	}
+
+	return crashLoop
+}
+
+func TestCrashRecoveryBisect(t *testing.T) {
+	good := []*ingress.Ingress{}
+	changed := []*ingress.Ingress{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		good = append(good, newVersionedIngress(name, "1"))
+		version := "2"
+		if name == "a" || name == "d" {
+			version = "1"
+		}
+		changed = append(changed, newVersionedIngress(name, version))
+	}
+	// a new Ingress
+	changed = append(changed, newVersionedIngress("i", "1"))
+	changedByKey := ingressesByKey(changed)
+
+	for _, culprit := range []string{"default/b", "default/c", "default/e", "default/f", "default/g", "default/h", "default/i"} {
+		t.Run(culprit, func(t *testing.T) {
+			r := newCrashRecovery()
+			nginx := &fakeNGINX{
+				r:   r,
+				now: time.Now(),
+				crashing: func(ings []*ingress.Ingress) bool {
+					ing, ok := ingressesByKey(ings)[culprit]
+					return ok && ing.ResourceVersion == changedByKey[culprit].ResourceVersion
+				},
+			}
+
+			nginx.reload(good)
+			if nginx.run(crashLoopWindow+workersCheckInterval, func() bool { return false }) {
+				t.Fatalf("unexpected crash loop of the good configuration")
+			}
+
+			nginx.reload(changed)
+			if !nginx.run(crashLoopWindow, r.crashLooping) {
+				t.Fatalf("expected a crash loop after the changes")
+			}
+
+			r.startBisect(changed, r.changedIngresses())
+			steps := 0
+			var ings []*ingress.Ingress
+			var culprits []string
+			for done := false; !done; {
+				steps++
+				candidate, _ := r.bisectCandidate()
+				nginx.reload(candidate)
+				r.observeBisect(nginx.now)
+				nginx.run(workersSettleTime+bisectObservationTime, func() bool { return r.bisectObserved(nginx.now) })
+
+				ings, culprits, done = r.narrowBisect()
+			}
+
+			if len(culprits) != 1 || culprits[0] != culprit {
+				t.Fatalf("expected the culprit %v but got %v", culprit, culprits)
+			}
+			if steps > 3 {
+				t.Errorf("expected at most 3 steps to find the culprit among 7 changed Ingresses but got %v", steps)
+			}
+			if r.bisecting() {
+				t.Errorf("expected the bisect finished")
+			}
+
+			// the version captured before the bisect is pinned, not the one of the last candidate
+			r.pin(ingressesByKey(ings)[culprit])
+			nginx.reload(r.filterIngresses(changed))
+			if nginx.run(crashLoopWindow+workersCheckInterval, func() bool { return false }) {
+				t.Errorf("unexpected crash loop after reverting the change of the culprit")
+			}
+
+			running := ingressesByKey(nginx.running)
+			for key, ing := range changedByKey {
+				switch {
+				case key != culprit:
+					if running[key] == nil || running[key].ResourceVersion != ing.ResourceVersion {
+						t.Errorf("expected the change of %v applied but got %v", key, running[key])
+					}
+				case key == "default/i":
+					if running[key] != nil {
+						t.Errorf("expected the new Ingress %v removed", key)
+					}
+				default:
+					if running[key] == nil || running[key].ResourceVersion != "1" {
+						t.Errorf("expected the previous version of %v but got %v", key, running[key])
+					}
+				}
+			}
+			if changes := r.changedIngresses(); len(changes) != 0 {
+				t.Errorf("expected the configuration without the culprit to be the last good one but got the changes %v", changes)
+			}
+		})
+	}
+}
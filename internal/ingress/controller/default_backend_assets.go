@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+	"k8s.io/ingress-nginx/pkg/util/file"
+)
+
+// defaultBackendAssetsCheckInterval is the time between checks of the ConfigMap of the default backend assets
+const defaultBackendAssetsCheckInterval = 5 * time.Second
+
+// watchDefaultBackendAssets writes the files of the ConfigMap of the default backend assets
+// when it changes, NGINX reading them on every request without a reload
+func (n *NGINXController) watchDefaultBackendAssets() {
+	version := "-"
+
+	wait.Until(func() {
+		cmap, err := n.store.GetConfigMap(n.cfg.DefaultBackendAssetsConfigMap)
+		if err != nil {
+			klog.V(3).Infof("Error reading default backend assets ConfigMap %q from local store: %v", n.cfg.DefaultBackendAssetsConfigMap, err)
+			cmap = nil
+		}
+
+		current := ""
+		if cmap != nil {
+			current = cmap.ResourceVersion
+		}
+		if current == version {
+			return
+		}
+
+		if err := writeDefaultBackendAssets(nginx.DefaultBackendAssetsDirectory, cmap); err != nil {
+			klog.Errorf("Error writing the default backend assets: %v", err)
+			return
+		}
+		klog.InfoS("Updated the default backend assets", "configmap", n.cfg.DefaultBackendAssetsConfigMap)
+		version = current
+	}, defaultBackendAssetsCheckInterval, n.stopCh)
+}
+
+// writeDefaultBackendAssets replaces the files of dir with the keys of the ConfigMap,
+// removing all of them when it is nil. Each file is replaced atomically for NGINX
+// never to serve a partial file.
+func writeDefaultBackendAssets(dir string, cmap *apiv1.ConfigMap) error {
+	if err := os.MkdirAll(dir, file.ReadWriteByUser); err != nil {
+		return err
+	}
+
+	assets := map[string][]byte{}
+	if cmap != nil {
+		for name, value := range cmap.Data {
+			assets[name] = []byte(value)
+		}
+		for name, value := range cmap.BinaryData {
+			assets[name] = value
+		}
+	}
+
+	for name, content := range assets {
+		if filepath.Base(name) != name || strings.HasPrefix(name, ".") {
+			klog.Warningf("Ignoring default backend asset %q, the name is not a valid file name", name)
+			delete(assets, name)
+			continue
+		}
+
+		tmp, err := os.CreateTemp(dir, ".tmp-")
+		if err != nil {
+			return err
+		}
+		_, err = tmp.Write(content)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), filepath.Join(dir, name))
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+			return fmt.Errorf("writing default backend asset %q: %w", name, err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if _, ok := assets[entry.Name()]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
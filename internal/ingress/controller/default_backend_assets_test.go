@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func readAssets(t *testing.T, dir string) map[string]string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error reading %v: %v", dir, err)
+	}
+
+	assets := map[string]string{}
+	for _, entry := range entries {
+		b, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("unexpected error reading %v: %v", entry.Name(), err)
+		}
+		assets[entry.Name()] = string(b)
+	}
+	return assets
+}
+
+func TestWriteDefaultBackendAssets(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "default-backend")
+
+	err := writeDefaultBackendAssets(dir, &apiv1.ConfigMap{
+		Data: map[string]string{
+			"404.html":     "<h1>Not found</h1>",
+			"healthz.html": "<h1>OK</h1>",
+			".hidden":      "ignored",
+		},
+		BinaryData: map[string][]byte{
+			"logo.png": {0x89, 0x50, 0x4e, 0x47},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"404.html":     "<h1>Not found</h1>",
+		"healthz.html": "<h1>OK</h1>",
+		"logo.png":     "\x89PNG",
+	}
+	if got := readAssets(t, dir); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the assets %v but got %v", expected, got)
+	}
+
+	err = writeDefaultBackendAssets(dir, &apiv1.ConfigMap{
+		Data: map[string]string{"404.html": "<h1>Gone</h1>"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected = map[string]string{"404.html": "<h1>Gone</h1>"}
+	if got := readAssets(t, dir); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the assets %v but got %v", expected, got)
+	}
+
+	if err := writeDefaultBackendAssets(dir, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := readAssets(t, dir); len(got) != 0 {
+		t.Errorf("expected no assets without ConfigMap but got %v", got)
+	}
+}
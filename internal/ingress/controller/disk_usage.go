@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+const (
+	cacheKind = "cache"
+
+	// cacheEvictionMargin is the part of the filesystem freed below the high watermark,
+	// to not remove cache files at every check
+	cacheEvictionMargin = 0.05
+)
+
+// nginxPathKinds are the prefixes of the names of the temporary and cache
+// directories of NGINX, including the ones of the temp-path-name annotation
+var nginxPathKinds = []struct {
+	prefix string
+	kind   string
+}{
+	{"client-body", "client-body"},
+	{"proxy-temp", "proxy-temp"},
+	{"fastcgi-temp", "fastcgi-temp"},
+	{"nginx-cache-", cacheKind},
+}
+
+// tempFilesAdvice explains how to keep the requests and responses in memory
+var tempFilesAdvice = map[string]string{
+	"client-body":  "consider increasing client-body-buffer-size for the uploads",
+	"proxy-temp":   "consider increasing proxy-buffer-size and proxy-buffers-number, or disabling proxy-buffering for the large responses",
+	"fastcgi-temp": "consider increasing the fastcgi_buffers of the FastCGI backends",
+}
+
+// filesystemUsage returns the used and total bytes of the filesystem containing the path
+var filesystemUsage = statFilesystem
+
+// watchDiskUsage periodically exports the disk usage of the temporary and cache directories
+// of NGINX until the controller stops. It removes the oldest cache files when a filesystem
+// is over the high watermark and emits an event when requests or responses are buffered to files.
+func (n *NGINXController) watchDiskUsage() {
+	reported := map[string]bool{}
+
+	wait.Until(func() {
+		paths, err := nginxPathsUsage(nginx.TempDirectory)
+		if err != nil {
+			klog.V(3).ErrorS(err, "Error obtaining the disk usage of NGINX")
+			return
+		}
+
+		if n.cfg.CacheEvictionHighWatermark > 0 {
+			for i := range paths {
+				p := &paths[i]
+				if p.Kind == cacheKind && p.FilesystemUsedRatio >= n.cfg.CacheEvictionHighWatermark {
+					n.evictCacheFiles(p)
+				}
+			}
+		}
+
+		n.metricCollector.SetDiskUsage(paths)
+		n.reportTempFiles(paths, reported)
+	}, n.cfg.DiskUsageCheckPeriod, n.stopCh)
+}
+
+// evictCacheFiles removes the oldest files of a cache directory until
+// its filesystem is below the high watermark minus cacheEvictionMargin
+func (n *NGINXController) evictCacheFiles(p *collectors.PathUsage) {
+	used, total, err := filesystemUsage(p.Path)
+	if err != nil {
+		klog.Warningf("Error obtaining the filesystem usage of %v: %v", p.Path, err)
+		return
+	}
+
+	target := uint64((n.cfg.CacheEvictionHighWatermark - cacheEvictionMargin) * float64(total))
+	if used <= target {
+		return
+	}
+
+	files, freed, err := removeOldestFiles(p.Path, int64(used-target)) //nolint:gosec // used is over target
+	if err != nil {
+		klog.Warningf("Error removing cache files of %v: %v", p.Path, err)
+	}
+	if files == 0 {
+		return
+	}
+
+	p.Files -= files
+	p.Bytes -= freed
+	if used, total, err := filesystemUsage(p.Path); err == nil && total > 0 {
+		p.FilesystemUsedRatio = float64(used) / float64(total)
+	}
+	n.metricCollector.AddCacheEviction(p.Path, freed)
+
+	msg := "Removed %v cache files (%v bytes) of %v, its filesystem was over the cache eviction high watermark of %.0f%%"
+	klog.Warningf(msg, files, freed, p.Path, n.cfg.CacheEvictionHighWatermark*100)
+	n.recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeWarning, "CacheEvicted", msg,
+		files, freed, p.Path, n.cfg.CacheEvictionHighWatermark*100)
+}
+
+// reportTempFiles emits an event when a temporary directory starts containing files,
+// as it indicates the buffers are too small for the requests or responses
+func (n *NGINXController) reportTempFiles(paths []collectors.PathUsage, reported map[string]bool) {
+	for i := range paths {
+		p := &paths[i]
+		if p.Kind == cacheKind {
+			continue
+		}
+
+		if p.Files == 0 {
+			delete(reported, p.Path)
+			continue
+		}
+		if reported[p.Path] {
+			continue
+		}
+		reported[p.Path] = true
+
+		msg := "NGINX buffers %v files (%v bytes) in %v, %v"
+		klog.Warningf(msg, p.Files, p.Bytes, p.Path, tempFilesAdvice[p.Kind])
+		n.recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeWarning, "TempFilesInUse", msg,
+			p.Files, p.Bytes, p.Path, tempFilesAdvice[p.Kind])
+	}
+}
+
+// nginxPathKind returns the kind of a directory of nginx.TempDirectory,
+// or an empty string when it is not a temporary or cache directory
+func nginxPathKind(name string) string {
+	for _, k := range nginxPathKinds {
+		if strings.HasPrefix(name, k.prefix) {
+			return k.kind
+		}
+	}
+
+	return ""
+}
+
+// nginxPathsUsage returns the disk usage of the temporary and cache directories of root
+func nginxPathsUsage(root string) ([]collectors.PathUsage, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []collectors.PathUsage{}
+	for _, entry := range entries {
+		kind := nginxPathKind(entry.Name())
+		if !entry.IsDir() || kind == "" {
+			continue
+		}
+
+		usage := collectors.PathUsage{
+			Path: filepath.Join(root, entry.Name()),
+			Kind: kind,
+		}
+
+		err := walkFiles(usage.Path, func(_ string, info fs.FileInfo) {
+			usage.Files++
+			usage.Bytes += info.Size()
+		})
+		if err != nil {
+			klog.Warningf("Error obtaining the disk usage of %v: %v", usage.Path, err)
+			continue
+		}
+
+		if used, total, err := filesystemUsage(usage.Path); err == nil && total > 0 {
+			usage.FilesystemUsedRatio = float64(used) / float64(total)
+		}
+
+		paths = append(paths, usage)
+	}
+
+	return paths, nil
+}
+
+// walkFiles calls fn for every regular file of dir, ignoring
+// the files removed by NGINX during the walk
+func walkFiles(dir string, fn func(path string, info fs.FileInfo)) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path != dir {
+				return nil
+			}
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		fn(path, info)
+		return nil
+	})
+}
+
+// removeOldestFiles removes the least recently modified files of dir until
+// at least bytes are freed, returning the number of files and bytes removed
+func removeOldestFiles(dir string, bytes int64) (files, freed int64, err error) {
+	type cacheFile struct {
+		path string
+		info fs.FileInfo
+	}
+
+	var cacheFiles []cacheFile
+	err = walkFiles(dir, func(path string, info fs.FileInfo) {
+		cacheFiles = append(cacheFiles, cacheFile{path, info})
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	sort.Slice(cacheFiles, func(i, j int) bool {
+		return cacheFiles[i].info.ModTime().Before(cacheFiles[j].info.ModTime())
+	})
+
+	for _, f := range cacheFiles {
+		if freed >= bytes {
+			break
+		}
+
+		// NGINX handles a missing cache file as a cache miss
+		if err := os.Remove(f.path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return files, freed, err
+		}
+
+		files++
+		freed += f.info.Size()
+	}
+
+	return files, freed, nil
+}
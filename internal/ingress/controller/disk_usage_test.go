@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path string, size int, modTime time.Time) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNginxPathsUsage(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+
+	writeFile(t, filepath.Join(root, "nginx.pid"), 10, now)
+	writeFile(t, filepath.Join(root, "proxy-temp-uploads", "1", "00000001"), 100, now)
+	writeFile(t, filepath.Join(root, "nginx-cache-slice", "a", "bc", "1"), 200, now)
+	writeFile(t, filepath.Join(root, "nginx-cache-slice", "a", "bc", "2"), 300, now)
+	if err := os.Mkdir(filepath.Join(root, "client-body"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := nginxPathsUsage(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]struct {
+		kind         string
+		files, bytes int64
+	}{
+		"client-body":        {"client-body", 0, 0},
+		"nginx-cache-slice":  {"cache", 2, 500},
+		"proxy-temp-uploads": {"proxy-temp", 1, 100},
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %v directories but got %+v", len(expected), paths)
+	}
+	for _, p := range paths {
+		e, ok := expected[filepath.Base(p.Path)]
+		if !ok || p.Kind != e.kind || p.Files != e.files || p.Bytes != e.bytes {
+			t.Errorf("unexpected usage %+v", p)
+		}
+	}
+
+	if _, err := nginxPathsUsage(filepath.Join(root, "missing")); err == nil {
+		t.Errorf("expected an error for a missing directory")
+	}
+}
+
+func TestRemoveOldestFiles(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	for i, name := range []string{"oldest", "old", "recent"} {
+		writeFile(t, filepath.Join(dir, "a", name), 100, now.Add(time.Duration(i-3)*time.Hour))
+	}
+
+	files, freed, err := removeOldestFiles(dir, 150)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if files != 2 || freed != 200 {
+		t.Errorf("expected 2 files and 200 bytes removed but got %v and %v", files, freed)
+	}
+
+	for name, exists := range map[string]bool{"oldest": false, "old": false, "recent": true} {
+		_, err := os.Stat(filepath.Join(dir, "a", name))
+		if exists != (err == nil) {
+			t.Errorf("expected %v to exist: %v", name, exists)
+		}
+	}
+}
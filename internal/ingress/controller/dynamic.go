@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+// errDataplaneOverloaded is returned when NGINX can not store the dynamic
+// configuration without evicting the running one from the Lua shared dictionaries.
+// A reload does not help, the shared dictionaries keep their content and size.
+var errDataplaneOverloaded = errors.New("the dataplane is overloaded by the dynamic configuration")
+
+// Headers describing the chunk of a dynamic configuration sent in a request
+const (
+	generationHeader = "X-Configuration-Generation"
+	chunkHeader      = "X-Configuration-Chunk"
+	chunksHeader     = "X-Configuration-Chunks"
+)
+
+// dynamicConfigurationGeneration identifies the payloads sent in chunks. Lua rejects the
+// chunks of a generation older than the last one applied, so it starts with the current
+// time to keep increasing after a restart of the controller.
+var dynamicConfigurationGeneration = time.Now().UnixNano()
+
+// postOptions define how the dynamic configuration is sent to NGINX
+type postOptions struct {
+	// chunkSize is the biggest size in bytes of the body of a request. Bigger
+	// payloads are split in chunks that Lua joins after receiving the last one,
+	// so reading a request does not block the event loop of a worker for long.
+	// The last request still decodes the whole payload at once.
+	// Zero sends the payload in a single request.
+	chunkSize int
+	// compress compresses the body of the requests with gzip
+	compress bool
+}
+
+func (n *NGINXController) postOptions() postOptions {
+	return postOptions{
+		chunkSize: n.cfg.DynamicConfigurationChunkSize,
+		compress:  n.cfg.CompressDynamicConfiguration,
+	}
+}
+
+// postDynamicConfiguration encodes data in JSON format and POSTs it to the
+// internal HTTP endpoint handled by Lua, in chunks when it is bigger than
+// the chunk size.
+func postDynamicConfiguration(path string, data interface{}, opts postOptions) error {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return postDynamicConfigurationJSON(path, buf, opts)
+}
+
+// postDynamicConfigurationJSON POSTs a dynamic configuration already encoded in JSON
+func postDynamicConfigurationJSON(path string, buf []byte, opts postOptions) error {
+	if opts.chunkSize <= 0 || len(buf) <= opts.chunkSize {
+		return postChunk(path, buf, opts.compress, nil, http.StatusCreated)
+	}
+
+	generation := strconv.FormatInt(atomic.AddInt64(&dynamicConfigurationGeneration, 1), 10)
+	chunks := (len(buf) + opts.chunkSize - 1) / opts.chunkSize
+	for i := 0; i < chunks; i++ {
+		end := (i + 1) * opts.chunkSize
+		if end > len(buf) {
+			end = len(buf)
+		}
+
+		header := http.Header{}
+		header.Set(generationHeader, generation)
+		header.Set(chunkHeader, strconv.Itoa(i))
+		header.Set(chunksHeader, strconv.Itoa(chunks))
+
+		// Lua accepts the chunks until the last one, which applies the configuration
+		expectedStatus := http.StatusAccepted
+		if i == chunks-1 {
+			expectedStatus = http.StatusCreated
+		}
+
+		err := postChunk(path, buf[i*opts.chunkSize:end], opts.compress, header, expectedStatus)
+		if err != nil {
+			return fmt.Errorf("posting chunk %v of %v: %w", i+1, chunks, err)
+		}
+	}
+
+	return nil
+}
+
+func postChunk(path string, data []byte, compress bool, header http.Header, expectedStatus int) error {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/json")
+
+	if compress {
+		var b bytes.Buffer
+		w := gzip.NewWriter(&b)
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+
+		data = b.Bytes()
+		header.Set("Content-Encoding", "gzip")
+	}
+
+	statusCode, _, err := nginx.NewRawPostStatusRequest(path, header, data)
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case expectedStatus:
+		return nil
+	case http.StatusServiceUnavailable:
+		return errDataplaneOverloaded
+	default:
+		return fmt.Errorf("unexpected error code: %d", statusCode)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+func TestPostDynamicConfiguration(t *testing.T) {
+	listener, err := tryListen("tcp", fmt.Sprintf(":%v", nginx.StatusPort))
+	if err != nil {
+		t.Fatalf("creating tcp listener: %s", err)
+	}
+	defer listener.Close()
+
+	var (
+		received    []string
+		generations = map[string]bool{}
+		overloaded  bool
+	)
+
+	server := &httptest.Server{
+		Listener: listener,
+		//nolint:gosec // Ignore not configured ReadHeaderTimeout in testing
+		Config: &http.Server{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if overloaded {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				var body io.Reader = r.Body
+				if r.Header.Get("Content-Encoding") == "gzip" {
+					gz, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Errorf("unexpected error reading gzip body: %v", err)
+						return
+					}
+					body = gz
+				}
+
+				b, err := io.ReadAll(body)
+				if err != nil {
+					t.Errorf("unexpected error reading body: %v", err)
+					return
+				}
+				received = append(received, string(b))
+
+				generation := r.Header.Get(generationHeader)
+				if generation == "" {
+					w.WriteHeader(http.StatusCreated)
+					return
+				}
+				generations[generation] = true
+
+				chunk, _ := strconv.Atoi(r.Header.Get(chunkHeader))
+				chunks, _ := strconv.Atoi(r.Header.Get(chunksHeader))
+				if chunk == chunks-1 {
+					w.WriteHeader(http.StatusCreated)
+				} else {
+					w.WriteHeader(http.StatusAccepted)
+				}
+			}),
+		},
+	}
+	defer server.Close()
+	server.Start()
+
+	data := []string{strings.Repeat("a", 20), strings.Repeat("b", 20)}
+	expected := fmt.Sprintf(`[%q,%q]`, data[0], data[1])
+
+	testCases := []struct {
+		name   string
+		opts   postOptions
+		chunks int
+	}{
+		{"without chunks", postOptions{}, 1},
+		{"smaller than a chunk", postOptions{chunkSize: 1024}, 1},
+		{"in chunks", postOptions{chunkSize: 10}, 5},
+		{"in compressed chunks", postOptions{chunkSize: 10, compress: true}, 5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			received = nil
+			generations = map[string]bool{}
+
+			if err := postDynamicConfiguration("/configuration/backends", data, tc.opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(received) != tc.chunks {
+				t.Errorf("expected %v requests but received %v", tc.chunks, len(received))
+			}
+			if body := strings.Join(received, ""); body != expected {
+				t.Errorf("expected %v but received %v", expected, body)
+			}
+			if tc.chunks > 1 && len(generations) != 1 {
+				t.Errorf("expected the chunks of a single generation but received %v", generations)
+			}
+		})
+	}
+
+	overloaded = true
+	err = postDynamicConfiguration("/configuration/backends", data, postOptions{chunkSize: 10})
+	if !errors.Is(err, errDataplaneOverloaded) {
+		t.Errorf("expected an overloaded dataplane error but returned %v", err)
+	}
+}
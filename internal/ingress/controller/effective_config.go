@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	networking "k8s.io/api/networking/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	"k8s.io/ingress-nginx/internal/ingress/effectiveconfig"
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// EffectiveConfiguration returns the settings of an Ingress merged with the defaults
+// of the ConfigMap and the locations of its paths in the running configuration
+func (n *NGINXController) EffectiveConfiguration(namespace, name string) (*effectiveconfig.Configuration, error) {
+	var ing *ingress.Ingress
+	for _, i := range n.store.ListIngresses() {
+		if i.Namespace == namespace && i.Name == name {
+			ing = i
+			break
+		}
+	}
+	if ing == nil {
+		return nil, effectiveconfig.ErrNotFound
+	}
+
+	c := &effectiveconfig.Configuration{
+		Namespace:   namespace,
+		Name:        name,
+		Annotations: make(map[string]string, len(ing.Annotations)),
+		Settings:    ing.ParsedAnnotations,
+		Sources:     annotations.NewAnnotationExtractor(n.store).Sources(&ing.Ingress),
+		Locations:   []effectiveconfig.Location{},
+	}
+	for k, v := range ing.Annotations {
+		c.Annotations[k] = v
+	}
+
+	running := n.runningConfig.Load()
+	if running == nil {
+		c.Errors = append(c.Errors, "NGINX is not configured yet")
+		return c, nil
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		host := rule.Host
+		if host == "" {
+			host = defServerName
+		}
+
+		for i := range rule.HTTP.Paths {
+			path := &rule.HTTP.Paths[i]
+			nginxPath := rootLocation
+			if path.Path != "" {
+				nginxPath = path.Path
+			}
+
+			loc := findLocation(running, host, nginxPath, path.PathType)
+			if loc == nil {
+				c.Errors = append(c.Errors, fmt.Sprintf("path %v of host %v is not in the running configuration", nginxPath, host))
+				continue
+			}
+
+			l := effectiveconfig.Location{
+				Host: host,
+				Path: nginxPath,
+			}
+			if loc.Ingress != nil {
+				l.Ingress = k8s.MetaNamespaceKey(loc.Ingress)
+			}
+
+			// the Ingress of the location is already described by the configuration
+			settings := *loc
+			settings.Ingress = nil
+			l.Settings = &settings
+
+			c.Locations = append(c.Locations, l)
+		}
+	}
+
+	return c, nil
+}
+
+// findLocation returns the location of the running configuration serving the path
+// of the host, the default server is used by the hosts without a server
+func findLocation(running *ingress.Configuration, host, nginxPath string, pathType *networking.PathType) *ingress.Location {
+	var server *ingress.Server
+	for _, s := range running.Servers {
+		if s.Hostname == host {
+			server = s
+			break
+		}
+		if s.Hostname == defServerName {
+			server = s
+		}
+	}
+	if server == nil {
+		return nil
+	}
+
+	for _, loc := range server.Locations {
+		if loc.Path == nginxPath && apiequality.Semantic.DeepEqual(loc.PathType, pathType) {
+			return loc
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	"k8s.io/ingress-nginx/internal/ingress/effectiveconfig"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func TestEffectiveConfiguration(t *testing.T) {
+	winner := newConflictIngress("winner", 0)
+	loser := newConflictIngress("loser", 0)
+	loser.Annotations = map[string]string{"nginx.ingress.kubernetes.io/proxy-read-timeout": "120"}
+	pathType := networking.PathTypePrefix
+
+	n := &NGINXController{
+		store: &fakeIngressStore{ingresses: []*ingress.Ingress{winner, loser}},
+	}
+
+	c, err := n.EffectiveConfiguration("default", "loser")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.Errors) != 1 || len(c.Locations) != 0 {
+		t.Errorf("expected an error without running configuration but got %v", c.Errors)
+	}
+
+	n.runningConfig.Store(&ingress.Configuration{
+		Servers: []*ingress.Server{
+			{Hostname: defServerName},
+			{
+				Hostname: "example.com",
+				Locations: []*ingress.Location{
+					{Path: "/", PathType: &pathType, Ingress: winner, Backend: "default-winner-80"},
+				},
+			},
+		},
+	})
+
+	c, err = n.EffectiveConfiguration("default", "loser")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.Errors) != 0 {
+		t.Errorf("unexpected errors %v", c.Errors)
+	}
+	if c.Sources["Proxy"] != annotations.SourceAnnotation || c.Sources["RateLimit"] != annotations.SourceDefault {
+		t.Errorf("unexpected sources %v", c.Sources)
+	}
+	if len(c.Locations) != 1 {
+		t.Fatalf("expected one location but got %v", c.Locations)
+	}
+	loc := c.Locations[0]
+	if loc.Host != "example.com" || loc.Path != "/" || loc.Ingress != "default/winner" {
+		t.Errorf("expected the location of default/winner but got %+v", loc)
+	}
+	if settings, ok := loc.Settings.(*ingress.Location); !ok || settings.Ingress != nil || settings.Backend != "default-winner-80" {
+		t.Errorf("expected the settings of the location without the Ingress but got %+v", loc.Settings)
+	}
+
+	if _, err := n.EffectiveConfiguration("default", "missing"); !errors.Is(err, effectiveconfig.ErrNotFound) {
+		t.Errorf("expected ErrNotFound but got %v", err)
+	}
+}
@@ -19,6 +19,7 @@ package controller
 import (
 	"fmt"
 	"net"
+	"net/netip"
 	"reflect"
 	"strconv"
 	"strings"
@@ -146,7 +147,12 @@ func getEndpointsFromSlices(s *corev1.Service, port *corev1.ServicePort, proto c
 			}
 
 			for _, epPort := range ports {
-				for _, epAddress := range ep.Addresses {
+				for _, address := range ep.Addresses {
+					epAddress, ok := endpointAddress(eps.AddressType, address)
+					if !ok {
+						klog.Warningf("Ignoring invalid %v address %q of EndpointSlice %q", eps.AddressType, address, k8s.MetaNamespaceKey(eps))
+						continue
+					}
 					hostPort := net.JoinHostPort(epAddress, strconv.Itoa(int(epPort)))
 					if _, exists := processedUpstreamServers[hostPort]; exists {
 						continue
@@ -166,3 +172,22 @@ func getEndpointsFromSlices(s *corev1.Service, port *corev1.ServicePort, proto c
 	klog.V(3).Infof("Endpoints found for Service %q: %v", svcKey, upsServers)
 	return upsServers
 }
+
+// endpointAddress returns the canonical form of an IP address of an
+// EndpointSlice, so an IPv6 address is always written the same way
+// in the upstream servers. FQDN addresses are returned unchanged.
+func endpointAddress(addressType discoveryv1.AddressType, address string) (string, bool) {
+	if addressType != discoveryv1.AddressTypeIPv4 && addressType != discoveryv1.AddressTypeIPv6 {
+		return address, true
+	}
+
+	ip, err := netip.ParseAddr(address)
+	if err != nil || ip.Zone() != "" {
+		return "", false
+	}
+	if (addressType == discoveryv1.AddressTypeIPv4) != ip.Is4() {
+		return "", false
+	}
+
+	return ip.String(), true
+}
@@ -916,6 +916,83 @@ func TestGetEndpointsFromSlices(t *testing.T) {
 				},
 			},
 		},
+		{
+			"a dual-stack service should return the valid endpoints of both families",
+			&corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type:      corev1.ServiceTypeClusterIP,
+					ClusterIP: "1.1.1.1",
+					Ports: []corev1.ServicePort{
+						{
+							Name:       "default",
+							TargetPort: intstr.FromInt(80),
+						},
+					},
+				},
+			},
+			&corev1.ServicePort{
+				Name:       "default",
+				TargetPort: intstr.FromInt(80),
+			},
+			corev1.ProtocolTCP,
+			"",
+			func(string) ([]*discoveryv1.EndpointSlice, error) {
+				return []*discoveryv1.EndpointSlice{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{discoveryv1.LabelServiceName: "default"},
+						},
+						AddressType: discoveryv1.AddressTypeIPv4,
+						Endpoints: []discoveryv1.Endpoint{
+							{
+								Addresses: []string{"1.1.1.1"},
+								Conditions: discoveryv1.EndpointConditions{
+									Ready: &[]bool{true}[0],
+								},
+							},
+						},
+						Ports: []discoveryv1.EndpointPort{
+							{
+								Protocol: &[]corev1.Protocol{corev1.ProtocolTCP}[0],
+								Port:     &[]int32{80}[0],
+								Name:     &[]string{"default"}[0],
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{discoveryv1.LabelServiceName: "default"},
+						},
+						AddressType: discoveryv1.AddressTypeIPv6,
+						Endpoints: []discoveryv1.Endpoint{
+							{
+								Addresses: []string{"fd00:0:0::1", "fd00::1", "1.1.1.2"},
+								Conditions: discoveryv1.EndpointConditions{
+									Ready: &[]bool{true}[0],
+								},
+							},
+						},
+						Ports: []discoveryv1.EndpointPort{
+							{
+								Protocol: &[]corev1.Protocol{corev1.ProtocolTCP}[0],
+								Port:     &[]int32{80}[0],
+								Name:     &[]string{"default"}[0],
+							},
+						},
+					},
+				}, nil
+			},
+			[]ingress.Endpoint{
+				{
+					Address: "1.1.1.1",
+					Port:    "80",
+				},
+				{
+					Address: "fd00::1",
+					Port:    "80",
+				},
+			},
+		},
 	}
 
 	for _, testCase := range tests {
@@ -927,3 +1004,29 @@ func TestGetEndpointsFromSlices(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointAddress(t *testing.T) {
+	tests := []struct {
+		addressType discoveryv1.AddressType
+		address     string
+		expected    string
+		valid       bool
+	}{
+		{discoveryv1.AddressTypeIPv4, "10.0.0.1", "10.0.0.1", true},
+		{discoveryv1.AddressTypeIPv4, "fd00::1", "", false},
+		{discoveryv1.AddressTypeIPv6, "fd00:0:0:0:0:0:0:1", "fd00::1", true},
+		{discoveryv1.AddressTypeIPv6, "FD00::A", "fd00::a", true},
+		{discoveryv1.AddressTypeIPv6, "fe80::1%eth0", "", false},
+		{discoveryv1.AddressTypeIPv6, "[fd00::1]", "", false},
+		{discoveryv1.AddressTypeIPv6, "10.0.0.1", "", false},
+		{discoveryv1.AddressTypeFQDN, "example.com", "example.com", true},
+	}
+
+	for _, test := range tests {
+		address, valid := endpointAddress(test.addressType, test.address)
+		if address != test.expected || valid != test.valid {
+			t.Errorf("expected %q and %v for the %v address %q but got %q and %v",
+				test.expected, test.valid, test.addressType, test.address, address, valid)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+// Traffic of the connections closed at the end of the shutdown grace period
+const (
+	l4TrafficPassthrough = "passthrough"
+	l4TrafficStream      = "stream"
+)
+
+// tcpEstablished is the state of the established connections in /proc/net/tcp
+const tcpEstablished = "01"
+
+// establishedConnections returns the number of established TCP connections
+// accepted on the ports, from the tables of the sockets in /proc/net
+func establishedConnections(procNet string, ports map[int]bool) int {
+	count := 0
+	for _, table := range []string{"tcp", "tcp6"} {
+		f, err := os.Open(filepath.Join(procNet, table))
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(f)
+		// skip the header
+		scanner.Scan()
+		for scanner.Scan() {
+			// sl local_address rem_address st ...
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 4 || fields[3] != tcpEstablished {
+				continue
+			}
+
+			i := strings.LastIndexByte(fields[1], ':')
+			if i < 0 {
+				continue
+			}
+			port, err := strconv.ParseInt(fields[1][i+1:], 16, 32)
+			if err == nil && ports[int(port)] {
+				count++
+			}
+		}
+		f.Close()
+	}
+
+	return count
+}
+
+// streamPorts returns the ports of the TCP services of the stream context
+func (n *NGINXController) streamPorts() map[int]bool {
+	ports := make(map[int]bool)
+	running := n.runningConfig.Load()
+	if running == nil {
+		return ports
+	}
+
+	for _, svc := range running.TCPEndpoints {
+		ports[svc.Port] = true
+	}
+
+	return ports
+}
+
+// stopSSLProxy stops accepting new connections in the SSL Passthrough proxy,
+// the open connections are kept until they are closed or the shutdown ends
+func (n *NGINXController) stopSSLProxy() {
+	if n.sslProxyListener == nil {
+		return
+	}
+
+	klog.InfoS("Stopping TLS proxy for SSL Passthrough")
+	if err := n.sslProxyListener.Close(); err != nil {
+		klog.Warningf("Error closing the listener of the SSL Passthrough proxy: %v", err)
+	}
+}
+
+// forceL4Shutdown closes the passthrough connections and stops NGINX without
+// waiting for the stream sessions, at the end of the L4 shutdown grace period
+func (n *NGINXController) forceL4Shutdown() {
+	if streams := establishedConnections(filepath.Join(procPath, "net"), n.streamPorts()); streams > 0 {
+		klog.InfoS("Closing stream connections at the end of the shutdown grace period", "connections", streams)
+		n.metricCollector.AddShutdownForcedCloses(l4TrafficStream, streams)
+	}
+
+	if nginx.IsRunning() {
+		cmd := n.command.ExecCommand("-s", "stop")
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			klog.Warningf("Error stopping NGINX: %v", err)
+		}
+	}
+
+	n.closePassthroughConnections()
+}
+
+// closePassthroughConnections closes the connections still open in the SSL Passthrough proxy
+func (n *NGINXController) closePassthroughConnections() {
+	if n.sslProxyListener == nil {
+		return
+	}
+
+	if closed := n.Proxy.CloseAll(); closed > 0 {
+		klog.InfoS("Closed SSL Passthrough connections at the end of the shutdown grace period", "connections", closed)
+		n.metricCollector.AddShutdownForcedCloses(l4TrafficPassthrough, closed)
+	}
+}
+
+// waitForL4Connections waits for the passthrough connections until the end of the grace period
+func (n *NGINXController) waitForL4Connections(grace <-chan time.Time) {
+	if n.sslProxyListener == nil || grace == nil {
+		return
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for n.Proxy.Active() > 0 {
+		select {
+		case <-ticker.C:
+		case <-grace:
+			return
+		}
+	}
+}
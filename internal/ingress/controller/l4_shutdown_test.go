@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const procNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:0CEA 00000000:0000 0A 00000000:00000000 00:00000000 00000000   101        0 1001 1 0000000000000000 100 0 0 10 0
+   1: 0A00000A:0CEA 0A000005:D431 01 00000000:00000000 00:00000000 00000000   101        0 1002 1 0000000000000000 20 4 30 10 -1
+   2: 0A00000A:0CEA 0A000006:D432 06 00000000:00000000 00:00000000 00000000   101        0 1003 1 0000000000000000 20 4 30 10 -1
+   3: 0A00000A:01BB 0A000007:D433 01 00000000:00000000 00:00000000 00000000   101        0 1004 1 0000000000000000 20 4 30 10 -1
+`
+
+const procNetTCP6 = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000001000000:0CEA 00000000000000000000000001000000:D434 01 00000000:00000000 00:00000000 00000000   101        0 1005 1 0000000000000000 20 4 30 10 -1
+`
+
+func TestEstablishedConnections(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "tcp"), []byte(procNetTCP), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// port 3306 (0CEA) has an established connection in tcp, the others are listening or closing
+	if count := establishedConnections(dir, map[int]bool{3306: true}); count != 1 {
+		t.Errorf("expected 1 established connection but got %v", count)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "tcp6"), []byte(procNetTCP6), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count := establishedConnections(dir, map[int]bool{3306: true}); count != 2 {
+		t.Errorf("expected 2 established connections but got %v", count)
+	}
+	if count := establishedConnections(dir, map[int]bool{5432: true}); count != 0 {
+		t.Errorf("expected no established connection but got %v", count)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// namespaceQuotas are the maximum resources of the Ingresses of a namespace, 0 for no limit
+type namespaceQuotas struct {
+	hosts        int
+	locations    int
+	snippetBytes int
+	certificates int
+}
+
+func newNamespaceQuotas(cfg ngx_config.Configuration) namespaceQuotas {
+	return namespaceQuotas{
+		hosts:        cfg.NamespaceMaxHosts,
+		locations:    cfg.NamespaceMaxLocations,
+		snippetBytes: cfg.NamespaceMaxSnippetBytes,
+		certificates: cfg.NamespaceMaxCertificates,
+	}
+}
+
+func (q namespaceQuotas) enabled() bool {
+	return q.hosts > 0 || q.locations > 0 || q.snippetBytes > 0 || q.certificates > 0
+}
+
+// namespaceUsage are the resources used by the Ingresses of a namespace
+type namespaceUsage struct {
+	hosts        sets.Set[string]
+	locations    int
+	snippetBytes int
+	certificates sets.Set[string]
+}
+
+func newNamespaceUsage() *namespaceUsage {
+	return &namespaceUsage{
+		hosts:        sets.New[string](),
+		certificates: sets.New[string](),
+	}
+}
+
+// add adds the resources of the ingress to the usage and returns the quotas it exceeds,
+// the usage is not changed when the ingress exceeds quotas
+func (u *namespaceUsage) add(ing *networking.Ingress, q namespaceQuotas) []string {
+	hosts := u.hosts.Clone()
+	certificates := u.certificates.Clone()
+	locations := u.locations
+	snippetBytes := u.snippetBytes
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" {
+			hosts.Insert(rule.Host)
+		}
+		if rule.HTTP != nil {
+			locations += len(rule.HTTP.Paths)
+		}
+	}
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName != "" {
+			certificates.Insert(tls.SecretName)
+		}
+	}
+	for key, value := range ing.GetAnnotations() {
+		if strings.HasPrefix(key, parser.AnnotationsPrefix+"/") && strings.HasSuffix(key, "-snippet") {
+			snippetBytes += len(value)
+		}
+	}
+
+	var exceeded []string
+	check := func(name string, used, limit int) {
+		if limit > 0 && used > limit {
+			exceeded = append(exceeded, fmt.Sprintf("%v %d > %d", name, used, limit))
+		}
+	}
+	check("hosts", hosts.Len(), q.hosts)
+	check("locations", locations, q.locations)
+	check("snippet bytes", snippetBytes, q.snippetBytes)
+	check("certificates", certificates.Len(), q.certificates)
+	if len(exceeded) > 0 {
+		return exceeded
+	}
+
+	u.hosts = hosts
+	u.certificates = certificates
+	u.locations = locations
+	u.snippetBytes = snippetBytes
+	return nil
+}
+
+// quotaExceeded is an Ingress ignored because it exceeds quotas of its namespace
+type quotaExceeded struct {
+	ingress  *ingress.Ingress
+	exceeded []string
+}
+
+// filterNamespaceQuotas returns the ingresses within the quotas of their namespace and the
+// ingresses exceeding them. The ingresses fill the quotas in their order, oldest first.
+func filterNamespaceQuotas(ingresses []*ingress.Ingress, q namespaceQuotas) ([]*ingress.Ingress, []quotaExceeded) {
+	if !q.enabled() {
+		return ingresses, nil
+	}
+
+	usages := map[string]*namespaceUsage{}
+	admitted := make([]*ingress.Ingress, 0, len(ingresses))
+	var ignored []quotaExceeded
+	for _, ing := range ingresses {
+		usage, ok := usages[ing.Namespace]
+		if !ok {
+			usage = newNamespaceUsage()
+			usages[ing.Namespace] = usage
+		}
+
+		if exceeded := usage.add(&ing.Ingress, q); len(exceeded) > 0 {
+			ignored = append(ignored, quotaExceeded{ingress: ing, exceeded: exceeded})
+			continue
+		}
+		admitted = append(admitted, ing)
+	}
+
+	return admitted, ignored
+}
+
+// checkNamespaceQuotas returns an error when the ingress, replacing its previous version,
+// exceeds the quotas of its namespace with the ingresses already admitted
+func checkNamespaceQuotas(ing *networking.Ingress, ingresses []*ingress.Ingress, q namespaceQuotas) error {
+	if !q.enabled() {
+		return nil
+	}
+
+	usage := newNamespaceUsage()
+	for _, other := range ingresses {
+		if other.Namespace == ing.Namespace && other.Name != ing.Name {
+			// the ingresses exceeding the quotas are not added, as they are ignored by the sync
+			usage.add(&other.Ingress, q)
+		}
+	}
+
+	if exceeded := usage.add(ing, q); len(exceeded) > 0 {
+		return fmt.Errorf("ingress exceeds the quotas of namespace %v: %v", ing.Namespace, strings.Join(exceeded, ", "))
+	}
+	return nil
+}
+
+// reportNamespaceQuotas emits an event in the Ingresses ignored because they exceed the quotas of their namespace
+func (n *NGINXController) reportNamespaceQuotas(ignored []quotaExceeded) {
+	for _, i := range ignored {
+		klog.Warningf("Ignoring Ingress %v exceeding the quotas of its namespace: %v",
+			k8s.MetaNamespaceKey(i.ingress), strings.Join(i.exceeded, ", "))
+		n.recorder.Eventf(&i.ingress.Ingress, apiv1.EventTypeWarning, "NamespaceQuotaExceeded",
+			"Ingress ignored, it exceeds the quotas of namespace %v: %v", i.ingress.Namespace, strings.Join(i.exceeded, ", "))
+	}
+}
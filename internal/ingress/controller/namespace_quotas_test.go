@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func newQuotaIngress(namespace, name string, hosts []string, secret, snippet string) *ingress.Ingress {
+	ing := &ingress.Ingress{
+		Ingress: networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		},
+	}
+	for _, host := range hosts {
+		ing.Spec.Rules = append(ing.Spec.Rules, networking.IngressRule{
+			Host: host,
+			IngressRuleValue: networking.IngressRuleValue{
+				HTTP: &networking.HTTPIngressRuleValue{
+					Paths: []networking.HTTPIngressPath{{Path: "/"}},
+				},
+			},
+		})
+	}
+	if secret != "" {
+		ing.Spec.TLS = []networking.IngressTLS{{Hosts: hosts, SecretName: secret}}
+	}
+	if snippet != "" {
+		ing.Annotations = map[string]string{"nginx.ingress.kubernetes.io/configuration-snippet": snippet}
+	}
+	return ing
+}
+
+func ingressNames(ingresses []*ingress.Ingress) []string {
+	names := []string{}
+	for _, ing := range ingresses {
+		names = append(names, ing.Namespace+"/"+ing.Name)
+	}
+	return names
+}
+
+func TestFilterNamespaceQuotas(t *testing.T) {
+	ingresses := []*ingress.Ingress{
+		newQuotaIngress("team-a", "first", []string{"a.com", "b.com"}, "tls-a", ""),
+		newQuotaIngress("team-a", "same-hosts", []string{"a.com"}, "tls-a", ""),
+		newQuotaIngress("team-a", "new-host", []string{"c.com"}, "", ""),
+		newQuotaIngress("team-b", "other-namespace", []string{"c.com", "d.com"}, "", ""),
+		newQuotaIngress("team-a", "new-certificate", []string{"b.com"}, "tls-b", ""),
+		newQuotaIngress("team-a", "snippet", []string{"a.com"}, "", strings.Repeat("x", 11)),
+	}
+
+	testCases := []struct {
+		name     string
+		quotas   namespaceQuotas
+		admitted []string
+		ignored  []string
+	}{
+		{
+			name:   "without quotas",
+			quotas: namespaceQuotas{},
+			admitted: []string{
+				"team-a/first", "team-a/same-hosts", "team-a/new-host", "team-b/other-namespace",
+				"team-a/new-certificate", "team-a/snippet",
+			},
+		},
+		{
+			name:     "max hosts",
+			quotas:   namespaceQuotas{hosts: 2},
+			admitted: []string{"team-a/first", "team-a/same-hosts", "team-b/other-namespace", "team-a/new-certificate", "team-a/snippet"},
+			ignored:  []string{"team-a/new-host"},
+		},
+		{
+			name:     "max locations",
+			quotas:   namespaceQuotas{locations: 3},
+			admitted: []string{"team-a/first", "team-a/same-hosts", "team-b/other-namespace"},
+			ignored:  []string{"team-a/new-host", "team-a/new-certificate", "team-a/snippet"},
+		},
+		{
+			name:   "max snippet bytes and certificates",
+			quotas: namespaceQuotas{snippetBytes: 10, certificates: 1},
+			admitted: []string{
+				"team-a/first", "team-a/same-hosts", "team-a/new-host", "team-b/other-namespace",
+			},
+			ignored: []string{"team-a/new-certificate", "team-a/snippet"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			admitted, ignored := filterNamespaceQuotas(ingresses, tc.quotas)
+			if got := ingressNames(admitted); !reflect.DeepEqual(got, tc.admitted) {
+				t.Errorf("expected the admitted ingresses %v but got %v", tc.admitted, got)
+			}
+
+			var got []string
+			for _, i := range ignored {
+				got = append(got, i.ingress.Namespace+"/"+i.ingress.Name)
+				if len(i.exceeded) == 0 {
+					t.Errorf("expected the quotas exceeded by %v", i.ingress.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tc.ignored) {
+				t.Errorf("expected the ignored ingresses %v but got %v", tc.ignored, got)
+			}
+		})
+	}
+}
+
+func TestCheckNamespaceQuotas(t *testing.T) {
+	ingresses := []*ingress.Ingress{
+		newQuotaIngress("team-a", "first", []string{"a.com", "b.com"}, "", ""),
+		newQuotaIngress("team-b", "other", []string{"c.com", "d.com"}, "", ""),
+	}
+	quotas := namespaceQuotas{hosts: 2}
+
+	if err := checkNamespaceQuotas(&newQuotaIngress("team-a", "second", []string{"a.com"}, "", "").Ingress, ingresses, quotas); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// updates replace the previous version of the ingress
+	if err := checkNamespaceQuotas(&newQuotaIngress("team-a", "first", []string{"e.com"}, "", "").Ingress, ingresses, quotas); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := checkNamespaceQuotas(&newQuotaIngress("team-a", "second", []string{"c.com"}, "", "").Ingress, ingresses, quotas)
+	if expected := "ingress exceeds the quotas of namespace team-a: hosts 3 > 2"; err == nil || err.Error() != expected {
+		t.Errorf("expected the error %q but got %v", expected, err)
+	}
+
+	if err := checkNamespaceQuotas(&newQuotaIngress("team-a", "second", []string{"c.com"}, "", "").Ingress, ingresses, namespaceQuotas{}); err != nil {
+		t.Errorf("unexpected error without quotas: %v", err)
+	}
+}
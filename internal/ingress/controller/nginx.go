@@ -18,10 +18,13 @@ package controller
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io/fs"
 	"net"
 	"net/http"
@@ -32,10 +35,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"syscall"
+	"sync/atomic"
 	"text/template"
 	"time"
-	"unicode"
 
 	proxyproto "github.com/armon/go-proxyproto"
 	"github.com/eapache/channels"
@@ -48,21 +50,32 @@ import (
 	"k8s.io/ingress-nginx/pkg/tcpproxy"
 
 	adm_controller "k8s.io/ingress-nginx/internal/admission/controller"
+	"k8s.io/ingress-nginx/internal/ingress/autotune"
+	"k8s.io/ingress-nginx/internal/ingress/confighistory"
 	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
 	"k8s.io/ingress-nginx/internal/ingress/controller/process"
 	"k8s.io/ingress-nginx/internal/ingress/controller/store"
 	ngx_template "k8s.io/ingress-nginx/internal/ingress/controller/template"
+	"k8s.io/ingress-nginx/internal/ingress/lbhealth"
+	"k8s.io/ingress-nginx/internal/ingress/loglevel"
 	"k8s.io/ingress-nginx/internal/ingress/metric"
+	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
+	"k8s.io/ingress-nginx/internal/ingress/preemption"
+	"k8s.io/ingress-nginx/internal/ingress/shard"
 	"k8s.io/ingress-nginx/internal/ingress/status"
+	"k8s.io/ingress-nginx/internal/ingress/supportbundle"
+	"k8s.io/ingress-nginx/internal/k8s"
 	ing_net "k8s.io/ingress-nginx/internal/net"
 	"k8s.io/ingress-nginx/internal/net/dns"
 	"k8s.io/ingress-nginx/internal/net/ssl"
 	"k8s.io/ingress-nginx/internal/nginx"
+	"k8s.io/ingress-nginx/internal/redact"
 	"k8s.io/ingress-nginx/internal/task"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
 
 	"k8s.io/ingress-nginx/pkg/util/file"
 	utilingress "k8s.io/ingress-nginx/pkg/util/ingress"
+	ing_runtime "k8s.io/ingress-nginx/pkg/util/runtime"
 
 	klog "k8s.io/klog/v2"
 )
@@ -93,26 +106,36 @@ func NewNGINXController(config *Configuration, mc metric.Collector) *NGINXContro
 		syncRateLimiter:  flowcontrol.NewTokenBucketRateLimiter(config.SyncRateLimit, 1),
 		workersReloading: false,
 
-		recorder: eventBroadcaster.NewRecorder(scheme.Scheme, apiv1.EventSource{
+		recorder: redact.NewEventRecorder(eventBroadcaster.NewRecorder(scheme.Scheme, apiv1.EventSource{
 			Component: "nginx-ingress-controller",
-		}),
+		})),
 
 		stopCh:   make(chan struct{}),
 		updateCh: channels.NewRingChannel(1024),
 
 		ngxErrCh: make(chan error),
+		drainCh:  make(chan string, 1),
 
-		stopLock: &sync.Mutex{},
+		tuner: autotune.NewTuner(autotune.Limits{
+			CPUs:   ing_runtime.NumCPU(),
+			Memory: ing_runtime.MemoryLimit(),
+		}),
+
+		reloads: supportbundle.NewHistory(reloadHistorySize),
 
-		runningConfig: new(ingress.Configuration),
+		crashRecovery: newCrashRecovery(),
+
+		stopLock: &sync.Mutex{},
 
 		Proxy: &tcpproxy.TCPProxy{},
 
 		metricCollector: mc,
 
-		command: NewNginxCommand(),
+		command: nginx.NewCommand(),
 	}
 
+	n.runningConfig.Store(new(ingress.Configuration))
+
 	if n.cfg.ValidationWebhook != "" {
 		n.validationWebhookServer = &http.Server{
 			Addr: config.ValidationWebhook,
@@ -140,18 +163,33 @@ func NewNGINXController(config *Configuration, mc metric.Collector) *NGINXContro
 		config.DisableCatchAll,
 		config.DeepInspector,
 		config.IngressClassConfiguration,
-		config.DisableSyncEvents)
+		config.DisableSyncEvents,
+		config.DynamicClient,
+		config.HTTPTransformClient)
 
 	n.syncQueue = task.NewTaskQueue(n.syncIngress)
 
+	if config.ConfigHistorySize > 0 {
+		n.configHistory = confighistory.New(nginx.ConfigHistoryDirectory, config.ConfigHistorySize, config.ConfigHistoryRetention)
+	}
+	n.logLevel = loglevel.NewChanger(func() {
+		n.syncQueue.EnqueueTask(task.GetDummyObject("log-level-change"))
+	})
+
 	if config.UpdateStatus {
+		statusClient := config.StatusClient
+		if statusClient == nil {
+			statusClient = config.Client
+		}
+
 		n.syncStatus = status.NewStatusSyncer(status.Config{
-			Client:                 config.Client,
+			Client:                 statusClient,
 			PublishService:         config.PublishService,
 			PublishStatusAddress:   config.PublishStatusAddress,
-			IngressLister:          n.store,
+			IngressLister:          shard.Lister{IngressLister: n.store, Shard: config.Shard},
 			UpdateStatusOnShutdown: config.UpdateStatusOnShutdown,
 			UseNodeInternalIP:      config.UseNodeInternalIP,
+			ServerSideApply:        config.StatusServerSideApply,
 		})
 	} else {
 		klog.Warning("Update of Ingress status is disabled (flag --update-status)")
@@ -184,10 +222,10 @@ func NewNGINXController(config *Configuration, mc metric.Collector) *NGINXContro
 
 	filesToWatch := []string{}
 
-	if err := os.Mkdir("/etc/ingress-controller/geoip/", 0o755); err != nil && !os.IsExist(err) {
+	if err := os.Mkdir(nginx.GeoIPDirectory, 0o755); err != nil && !os.IsExist(err) {
 		klog.Fatalf("Error creating geoip dir: %v", err)
 	}
-	err = filepath.WalkDir("/etc/ingress-controller/geoip/", func(path string, info fs.DirEntry, err error) error {
+	err = filepath.WalkDir(nginx.GeoIPDirectory, func(path string, info fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -244,8 +282,9 @@ type NGINXController struct {
 	// ngxErrCh is used to detect errors with the NGINX processes
 	ngxErrCh chan error
 
-	// runningConfig contains the running configuration in the Backend
-	runningConfig *ingress.Configuration
+	// runningConfig contains the running configuration in the Backend.
+	// It is read by the HTTP handlers while the sync queue replaces it
+	runningConfig atomic.Pointer[ingress.Configuration]
 
 	t ngx_template.Writer
 
@@ -255,7 +294,46 @@ type NGINXController struct {
 
 	isShuttingDown bool
 
+	// isReloading is true while NGINX is reloading a new configuration
+	isReloading atomic.Bool
+
+	// drainCh receives the reason to gracefully shut down the controller,
+	// like a preemption notice of the node
+	drainCh chan string
+
+	// tuner sizes the worker processes, connections and buffers when auto-tune is enabled
+	tuner *autotune.Tuner
+
+	// reloads keeps the last reloads of NGINX for the support bundle
+	reloads *supportbundle.History
+
+	// cdnRanges contains the IP ranges of the cdn-provider
+	cdnRanges cdnRanges
+
+	// logLevel changes the log levels at runtime for debugging
+	logLevel *loglevel.Changer
+
+	// crashRecovery detects the workers crashing after a reload and reverts the changes of the Ingresses
+	crashRecovery *crashRecovery
+
+	// reloadedIngresses are the resource versions of the Ingresses of the last reload, by key
+	reloadedIngresses map[string]string
+
+	// configHistory keeps the last generations of nginx.conf, nil when it is disabled
+	configHistory *confighistory.History
+
+	// backendsChecksum is the checksum of the last backends sent to Lua
+	backendsChecksum atomic.Pointer[uint32]
+
+	// configDriftLock is held while nginx.conf or the backends are changed,
+	// for the checks of drift to not see a change in progress
+	configDriftLock sync.Mutex
+	// nginxConfChecksum is the SHA-256 checksum of the last nginx.conf written
+	nginxConfChecksum []byte
+
 	Proxy *tcpproxy.TCPProxy
+	// sslProxyListener accepts the connections of the SSL Passthrough proxy
+	sslProxyListener net.Listener
 
 	store store.Storer
 
@@ -263,13 +341,17 @@ type NGINXController struct {
 
 	validationWebhookServer *http.Server
 
-	command NginxExecTester
+	command nginx.Command
 }
 
 // Start starts a new NGINX master process running in the foreground.
 func (n *NGINXController) Start() {
 	klog.InfoS("Starting NGINX Ingress controller")
 
+	topology := ing_runtime.GetCPUTopology()
+	klog.InfoS("CPU topology", "architecture", topology.Architecture, "clusters", topology.Clusters, "aes", topology.AES)
+	n.metricCollector.SetCPUTopology(topology)
+
 	n.store.Run(n.stopCh)
 
 	// we need to use the defined ingress class to allow multiple leaders
@@ -286,14 +368,15 @@ func (n *NGINXController) Start() {
 			ElectionTTL: n.cfg.ElectionTTL,
 			OnStartedLeading: func(stopCh chan struct{}) {
 				if n.syncStatus != nil {
-					go n.syncStatus.Run(stopCh)
+					n.runStatusSync(stopCh)
 				}
 
 				n.metricCollector.OnStartedLeading(electionID)
 				// manually update SSL expiration metrics
 				// (to not wait for a reload)
-				n.metricCollector.SetSSLExpireTime(n.runningConfig.Servers)
-				n.metricCollector.SetSSLInfo(n.runningConfig.Servers)
+				running := n.runningConfig.Load()
+				n.metricCollector.SetSSLExpireTime(running.Servers)
+				n.metricCollector.SetSSLInfo(running.Servers)
 			},
 			OnStoppedLeading: func() {
 				n.metricCollector.OnStoppedLeading(electionID)
@@ -305,15 +388,46 @@ func (n *NGINXController) Start() {
 
 	// put NGINX in another process group to prevent it
 	// to receive signals meant for the controller
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
-		Pgid:    0,
-	}
+	setProcessGroup(cmd)
 
 	if n.cfg.EnableSSLPassthrough {
 		n.setupSSLProxy()
 	}
 
+	if n.cfg.ListenPorts.LBHealthCheck != 0 {
+		n.setupLBHealthCheck()
+	}
+
+	if len(n.cfg.PreemptionSources) > 0 {
+		n.setupPreemptionWatcher()
+	}
+
+	if n.cfg.LuaSharedDictsUsageThreshold > 0 {
+		go n.watchLuaSharedDicts()
+	}
+
+	if n.cfg.DiskUsageCheckPeriod > 0 {
+		go n.watchDiskUsage()
+	}
+
+	if n.cfg.BackendProtocolProbeInterval > 0 {
+		go n.watchBackendProtocols()
+	}
+
+	if n.cfg.ConfigDriftCheckPeriod > 0 {
+		go n.watchConfigDrift()
+	}
+
+	go n.watchCDNRanges()
+
+	if n.cfg.DefaultBackendAssetsConfigMap != "" {
+		go n.watchDefaultBackendAssets()
+	}
+
+	if n.cfg.CrashRecovery {
+		go n.watchWorkerCrashes()
+	}
+
 	klog.InfoS("Starting NGINX process")
 	n.start(cmd)
 
@@ -377,6 +491,37 @@ func (n *NGINXController) Start() {
 	}
 }
 
+// runStatusSync updates the status of the Ingresses until stopCh is closed. With
+// --ingress-class-lease-namespace, the status is only updated while this
+// controller owns the IngressClass, so controllers configured with the same
+// class don't fight over the status.
+func (n *NGINXController) runStatusSync(stopCh chan struct{}) {
+	if n.cfg.IngressClassLeaseNamespace == "" {
+		go n.syncStatus.Run(stopCh)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	controllerID := fmt.Sprintf("%v/%v", k8s.IngressPodDetails.Namespace, n.cfg.ElectionID)
+	runClassLease(ctx, &classLeaseConfig{
+		Client:     n.cfg.Client,
+		Recorder:   n.recorder,
+		Namespace:  n.cfg.IngressClassLeaseNamespace,
+		Class:      n.cfg.IngressClassConfiguration.AnnotationValue,
+		Controller: controllerID,
+		Identity:   fmt.Sprintf("%v/%v", controllerID, k8s.IngressPodDetails.Name),
+		TTL:        n.cfg.ElectionTTL,
+		OnStartedOwning: func(classStopCh chan struct{}) {
+			go n.syncStatus.Run(classStopCh)
+		},
+	})
+}
+
 // Stop gracefully stops the NGINX master process.
 func (n *NGINXController) Stop() error {
 	n.isShuttingDown = true
@@ -405,6 +550,8 @@ func (n *NGINXController) Stop() error {
 		}
 	}
 
+	n.stopSSLProxy()
+
 	// send stop signal to NGINX
 	klog.InfoS("Stopping NGINX process")
 	cmd := n.command.ExecCommand("-s", "quit")
@@ -415,15 +562,28 @@ func (n *NGINXController) Stop() error {
 		return err
 	}
 
+	var grace <-chan time.Time
+	if n.cfg.L4ShutdownGracePeriod > 0 {
+		graceTimer := time.NewTimer(time.Duration(n.cfg.L4ShutdownGracePeriod) * time.Second)
+		defer graceTimer.Stop()
+		grace = graceTimer.C
+	}
+
 	// wait for the NGINX process to terminate
 	timer := time.NewTicker(time.Second * 1)
-	for range timer.C {
-		if !nginx.IsRunning() {
-			klog.InfoS("NGINX process has stopped")
-			timer.Stop()
-			break
+	defer timer.Stop()
+	for nginx.IsRunning() {
+		select {
+		case <-timer.C:
+		case <-grace:
+			n.forceL4Shutdown()
+			grace = nil
 		}
 	}
+	klog.InfoS("NGINX process has stopped")
+
+	n.waitForL4Connections(grace)
+	n.closePassthroughConnections()
 
 	return nil
 }
@@ -493,63 +653,7 @@ func (n *NGINXController) generateTemplate(cfg ngx_config.Configuration, ingress
 		n.Proxy.ServerList = servers
 	}
 
-	// NGINX cannot resize the hash tables used to store server names. For
-	// this reason we check if the current size is correct for the host
-	// names defined in the Ingress rules and adjust the value if
-	// necessary.
-	// https://trac.nginx.org/nginx/ticket/352
-	// https://trac.nginx.org/nginx/ticket/631
-	var longestName int
-	var serverNameBytes int
-
-	for _, srv := range ingressCfg.Servers {
-		hostnameLength := len(srv.Hostname)
-		if srv.RedirectFromToWWW {
-			hostnameLength += 4
-		}
-		if longestName < hostnameLength {
-			longestName = hostnameLength
-		}
-
-		for _, alias := range srv.Aliases {
-			if longestName < len(alias) {
-				longestName = len(alias)
-			}
-		}
-
-		serverNameBytes += hostnameLength
-	}
-
-	nameHashBucketSize := nginxHashBucketSize(longestName)
-	if cfg.ServerNameHashBucketSize < nameHashBucketSize {
-		klog.V(3).InfoS("Adjusting ServerNameHashBucketSize variable", "value", nameHashBucketSize)
-		cfg.ServerNameHashBucketSize = nameHashBucketSize
-	}
-
-	serverNameHashMaxSize := nextPowerOf2(serverNameBytes)
-	if cfg.ServerNameHashMaxSize < serverNameHashMaxSize {
-		klog.V(3).InfoS("Adjusting ServerNameHashMaxSize variable", "value", serverNameHashMaxSize)
-		cfg.ServerNameHashMaxSize = serverNameHashMaxSize
-	}
-
-	if cfg.MaxWorkerOpenFiles == 0 {
-		// the limit of open files is per worker process
-		// and we leave some room to avoid consuming all the FDs available
-		maxOpenFiles := rlimitMaxNumFiles() - 1024
-		klog.V(3).InfoS("Maximum number of open file descriptors", "value", maxOpenFiles)
-		if maxOpenFiles < 1024 {
-			// this means the value of RLIMIT_NOFILE is too low.
-			maxOpenFiles = 1024
-		}
-		klog.V(3).InfoS("Adjusting MaxWorkerOpenFiles variable", "value", maxOpenFiles)
-		cfg.MaxWorkerOpenFiles = maxOpenFiles
-	}
-
-	if cfg.MaxWorkerConnections == 0 {
-		maxWorkerConnections := int(float64(cfg.MaxWorkerOpenFiles * 3.0 / 4))
-		klog.V(3).InfoS("Adjusting MaxWorkerConnections variable", "value", maxWorkerConnections)
-		cfg.MaxWorkerConnections = maxWorkerConnections
-	}
+	setWorkerLimits(&cfg)
 
 	setHeaders := map[string]string{}
 	if cfg.ProxySetHeaders != "" {
@@ -605,13 +709,18 @@ func (n *NGINXController) generateTemplate(cfg ngx_config.Configuration, ingress
 		}
 	}
 
+	servers := ingressCfg.Servers
+	if cfg.EnableWildcardHostCollapsing {
+		servers = collapseWildcardHosts(servers, cfg.WildcardHostCollapsingMinServers)
+	}
+
 	tc := &ngx_config.TemplateConfig{
 		ProxySetHeaders:          setHeaders,
 		AddHeaders:               addHeaders,
 		BacklogSize:              sysctlSomaxconn(),
 		Backends:                 ingressCfg.Backends,
 		PassthroughBackends:      ingressCfg.PassthroughBackends,
-		Servers:                  ingressCfg.Servers,
+		Servers:                  servers,
 		TCPBackends:              ingressCfg.TCPEndpoints,
 		UDPBackends:              ingressCfg.UDPEndpoints,
 		Cfg:                      cfg,
@@ -626,12 +735,25 @@ func (n *NGINXController) generateTemplate(cfg ngx_config.Configuration, ingress
 		HealthzURI:               nginx.HealthPath,
 		MonitorMaxBatchSize:      n.cfg.MonitorMaxBatchSize,
 		PID:                      nginx.PID,
+		TempDirectory:            nginx.TempDirectory,
+		LuaConfigPath:            nginx.LuaConfigPath,
+		GeoIPDirectory:           nginx.GeoIPDirectory,
+		SSLSessionTicketKeyPath:  nginx.SSLSessionTicketKeyPath,
 		StatusPath:               nginx.StatusPath,
 		StatusPort:               nginx.StatusPort,
 		StreamPort:               nginx.StreamPort,
 		StreamSnippets:           append(ingressCfg.StreamSnippets, cfg.StreamSnippet),
 	}
 
+	if n.cfg.DefaultBackendAssetsConfigMap != "" {
+		tc.DefaultBackendAssetsDirectory = nginx.DefaultBackendAssetsDirectory
+	}
+
+	if n.cfg.EnableMetrics && n.cfg.ErrorLogMetrics {
+		tc.ErrorLogMetricsSocket = nginx.ErrorLogSocket
+		tc.ErrorLogMetricsLevel = collectors.ErrorLogLevel
+	}
+
 	tc.Cfg.Checksum = ingressCfg.ConfigurationChecksum
 
 	return n.t.Write(tc)
@@ -643,7 +765,7 @@ func (n *NGINXController) testTemplate(cfg []byte) error {
 	if len(cfg) == 0 {
 		return fmt.Errorf("invalid NGINX configuration (empty)")
 	}
-	tmpfile, err := os.CreateTemp(filepath.Join(os.TempDir(), "nginx"), tempNginxPattern)
+	tmpfile, err := os.CreateTemp(nginx.TempDirectory, tempNginxPattern)
 	if err != nil {
 		return err
 	}
@@ -679,15 +801,24 @@ func (n *NGINXController) OnUpdate(ingressCfg ingress.Configuration) error {
 	cfg := n.store.GetBackendConfiguration()
 	cfg.Resolver = n.resolver
 
+	n.autoTune(&cfg)
+	setServerNamesHashSize(&cfg, ingressCfg.Servers)
+	addCDNRanges(&cfg, ingressCfg.CDNRanges)
+	if ingressCfg.ErrorLogLevel != "" {
+		cfg.ErrorLogLevel = ingressCfg.ErrorLogLevel
+	}
+
 	workerSerialReloads := cfg.WorkerSerialReloads
 	if workerSerialReloads && n.workersReloading {
 		return errors.New("worker reload already in progress, requeuing reload")
 	}
 
+	renderStart := time.Now()
 	content, err := n.generateTemplate(cfg, ingressCfg)
 	if err != nil {
 		return err
 	}
+	n.metricCollector.SetNamespaceConfig(namespaceConfigBytes(content), time.Since(renderStart))
 
 	err = n.createLuaConfig(&cfg)
 	if err != nil {
@@ -698,49 +829,32 @@ func (n *NGINXController) OnUpdate(ingressCfg ingress.Configuration) error {
 		return err
 	}
 
+	err = checkRenderedConf(content, &cfg, ingressCfg.Servers)
+	if err != nil {
+		return err
+	}
+
 	err = n.testTemplate(content)
 	if err != nil {
 		return err
 	}
 
 	if klog.V(2).Enabled() {
-		src, err := os.ReadFile(cfgPath)
+		src, err := os.ReadFile(nginx.ConfPath)
 		if err != nil {
 			return err
 		}
 		if !bytes.Equal(src, content) {
-			tmpfile, err := os.CreateTemp("", "new-nginx-cfg")
+			diffOutput, err := nginxConfigurationDiff(src, content)
 			if err != nil {
-				return err
-			}
-			defer tmpfile.Close()
-			err = os.WriteFile(tmpfile.Name(), content, file.ReadWriteByUser)
-			if err != nil {
-				return err
-			}
-			//nolint:gosec //Ignore G204 error
-			diffOutput, err := exec.Command("diff", "-I", "'# Configuration.*'", "-u", cfgPath, tmpfile.Name()).CombinedOutput()
-			if err != nil {
-				if exitError, ok := err.(*exec.ExitError); ok {
-					ws, ok := exitError.Sys().(syscall.WaitStatus)
-					if !ok {
-						klog.Errorf("unexpected type: %T", exitError.Sys())
-					}
-					if ws.ExitStatus() == 2 {
-						klog.Warningf("Failed to executing diff command: %v", err)
-					}
-				}
+				klog.Warningf("Failed to compute the configuration diff: %v", err)
 			}
 
-			klog.InfoS("NGINX configuration change", "diff", string(diffOutput))
-
-			// we do not defer the deletion of temp files in order
-			// to keep them around for inspection in case of error
-			os.Remove(tmpfile.Name())
+			klog.InfoS("NGINX configuration change", "diff", redact.String(diffOutput))
 		}
 	}
 
-	err = os.WriteFile(cfgPath, content, file.ReadWriteByUser)
+	err = n.writeNginxConf(content)
 	if err != nil {
 		return err
 	}
@@ -749,37 +863,110 @@ func (n *NGINXController) OnUpdate(ingressCfg ingress.Configuration) error {
 	if err != nil {
 		return fmt.Errorf("%v\n%v", err, string(o))
 	}
+	n.crashRecovery.workersReloaded(time.Now())
+
+	n.metricCollector.SetWorkerCapacity(workerCapacity(cfg))
+	n.metricCollector.SetServerNamesHash(cfg.ServerNameHashBucketSize, cfg.ServerNameHashMaxSize)
 
 	// Reload status checking runs in a separate goroutine to avoid blocking the sync queue
 	if workerSerialReloads {
-		go n.awaitWorkersReload()
+		go n.awaitWorkersReload(cfg.WorkerProcesses)
+	}
+
+	return nil
+}
+
+// writeNginxConf writes nginx.conf, keeping its checksum for the checks of drift
+func (n *NGINXController) writeNginxConf(content []byte) error {
+	n.configDriftLock.Lock()
+	defer n.configDriftLock.Unlock()
+
+	if err := os.WriteFile(nginx.ConfPath, content, file.ReadWriteByUser); err != nil {
+		return err
 	}
 
+	checksum := sha256.Sum256(content)
+	n.nginxConfChecksum = checksum[:]
+
 	return nil
 }
 
+// setWorkerLimits sets the number of open files and connections
+// of the worker processes when they are not configured
+func setWorkerLimits(cfg *ngx_config.Configuration) {
+	if cfg.MaxWorkerOpenFiles == 0 {
+		// the limit of open files is per worker process
+		// and we leave some room to avoid consuming all the FDs available
+		maxOpenFiles := rlimitMaxNumFiles() - 1024
+		klog.V(3).InfoS("Maximum number of open file descriptors", "value", maxOpenFiles)
+		if maxOpenFiles < 1024 {
+			// this means the value of RLIMIT_NOFILE is too low.
+			maxOpenFiles = 1024
+		}
+		klog.V(3).InfoS("Adjusting MaxWorkerOpenFiles variable", "value", maxOpenFiles)
+		cfg.MaxWorkerOpenFiles = maxOpenFiles
+	}
+
+	if cfg.MaxWorkerConnections == 0 {
+		maxWorkerConnections := int(float64(cfg.MaxWorkerOpenFiles * 3.0 / 4))
+		klog.V(3).InfoS("Adjusting MaxWorkerConnections variable", "value", maxWorkerConnections)
+		cfg.MaxWorkerConnections = maxWorkerConnections
+	}
+}
+
+// workerCapacity returns the number of worker processes and
+// connections per worker process of the configuration
+//
+//nolint:gocritic // the configuration is not mutated
+func workerCapacity(cfg ngx_config.Configuration) (workers, workerConnections int) {
+	setWorkerLimits(&cfg)
+
+	workers, err := strconv.Atoi(cfg.WorkerProcesses)
+	if err != nil {
+		workers = ing_runtime.NumCPU()
+	}
+
+	return workers, cfg.MaxWorkerConnections
+}
+
+// autoTune sets the settings chosen by the tuner in the configuration,
+// using the active connections of NGINX when the metrics are enabled
+func (n *NGINXController) autoTune(cfg *ngx_config.Configuration) {
+	observedConnections := 0
+	if cfg.AutoTune {
+		if s, err := n.metricCollector.Saturation(); err == nil {
+			observedConnections = s.ActiveConnections
+		}
+	}
+
+	n.tuner.Apply(cfg, observedConnections)
+}
+
+// AutoTuner returns the tuner of the settings of NGINX
+func (n *NGINXController) AutoTuner() *autotune.Tuner {
+	return n.tuner
+}
+
+// LogLevelChanger returns the changer of the log levels
+func (n *NGINXController) LogLevelChanger() *loglevel.Changer {
+	return n.logLevel
+}
+
 // awaitWorkersReload checks if the number of workers has returned to the expected count
-func (n *NGINXController) awaitWorkersReload() {
+func (n *NGINXController) awaitWorkersReload(expectedWorkers string) {
 	n.workersReloading = true
 	defer func() { n.workersReloading = false }()
 
-	expectedWorkers := n.store.GetBackendConfiguration().WorkerProcesses
 	var numWorkers string
 	klog.V(3).Infof("waiting for worker count to be equal to %s", expectedWorkers)
 	for numWorkers != expectedWorkers {
 		time.Sleep(time.Second)
-		o, err := exec.Command("/bin/sh", "-c", "pgrep worker | wc -l").Output()
+		workers, err := nginx.WorkerProcesses()
 		if err != nil {
 			klog.ErrorS(err, numWorkers)
 			return
 		}
-		// cleanup any non-printable chars from shell output
-		numWorkers = strings.Map(func(r rune) rune {
-			if unicode.IsPrint(r) {
-				return r
-			}
-			return -1
-		}, string(o))
+		numWorkers = strconv.Itoa(workers)
 
 		klog.V(3).Infof("Currently running nginx worker processes: %s, expected %s", numWorkers, expectedWorkers)
 	}
@@ -810,6 +997,63 @@ func nextPowerOf2(v int) int {
 	return v
 }
 
+func (n *NGINXController) setupLBHealthCheck() {
+	port := n.cfg.ListenPorts.LBHealthCheck
+	server := &lbhealth.Server{
+		Checker:            n,
+		ProxyHeaderTimeout: n.store.GetBackendConfiguration().ProxyProtocolHeaderTimeout,
+		ReadTimeout:        time.Second,
+	}
+
+	klog.InfoS("Starting load balancer health check listener", "port", port)
+	go func() {
+		klog.Fatal(server.ListenAndServe(fmt.Sprintf(":%v", port)))
+	}()
+}
+
+// DrainRequests returns the reasons to gracefully shut down the controller
+// before receiving a SIGTERM
+func (n *NGINXController) DrainRequests() <-chan string {
+	return n.drainCh
+}
+
+func (n *NGINXController) setupPreemptionWatcher() {
+	watcher := &preemption.Watcher{Interval: n.cfg.PreemptionPollInterval}
+	for _, name := range n.cfg.PreemptionSources {
+		source, err := preemption.NewSource(name, n.cfg.Client, k8s.IngressNodeDetails.Name, n.cfg.PreemptionTaintKeys)
+		if err != nil {
+			klog.ErrorS(err, "Error watching preemption notices", "source", name)
+			continue
+		}
+		watcher.Sources = append(watcher.Sources, source)
+	}
+
+	if len(watcher.Sources) == 0 {
+		return
+	}
+
+	klog.InfoS("Watching node preemption notices", "sources", n.cfg.PreemptionSources)
+	go func() {
+		source := watcher.Wait(n.stopCh)
+		if source == "" {
+			return
+		}
+
+		delay := n.cfg.PreemptionDrainDelay
+		klog.Warningf("Received node preemption notice from %v, draining the controller in %v", source, delay)
+		n.recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeWarning, "PREEMPTION",
+			fmt.Sprintf("Received node preemption notice from %v, draining the controller in %v", source, delay))
+
+		select {
+		case <-n.stopCh:
+			return
+		case <-time.After(delay):
+		}
+
+		n.drainCh <- fmt.Sprintf("node preemption notice from %v", source)
+	}()
+}
+
 func (n *NGINXController) setupSSLProxy() {
 	cfg := n.store.GetBackendConfiguration()
 	sslPort := n.cfg.ListenPorts.HTTPS
@@ -823,14 +1067,32 @@ func (n *NGINXController) setupSSLProxy() {
 			Port:          proxyPort,
 			ProxyProtocol: true,
 		},
+		Metrics: n.metricCollector,
+	}
+
+	if n.cfg.SSLPassthroughPoolSize > 0 {
+		n.Proxy.Pool = tcpproxy.NewPool(n.cfg.SSLPassthroughPoolSize, tcpproxy.DefaultPoolMaxIdle)
 	}
 
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%v", sslPort))
 	if err != nil {
 		klog.Fatalf("%v", err)
 	}
+	n.sslProxyListener = listener
 
-	proxyList := &proxyproto.Listener{Listener: listener, ProxyHeaderTimeout: cfg.ProxyProtocolHeaderTimeout}
+	// the peers are checked before decoding their PROXY protocol header
+	trustedList := &trustedPeersListener{
+		Listener: listener,
+		trusted: func() []string {
+			cfg := n.store.GetBackendConfiguration()
+			if !cfg.ProxyProtocolRejectUntrusted {
+				return nil
+			}
+			return cfg.ProxyRealIPCIDR
+		},
+		rejected: n.metricCollector.PassthroughUntrustedProxyProtocol,
+	}
+	proxyList := &proxyproto.Listener{Listener: trustedList, ProxyHeaderTimeout: cfg.ProxyProtocolHeaderTimeout}
 
 	// accept TCP connections on the configured HTTPS port
 	go func() {
@@ -847,6 +1109,9 @@ func (n *NGINXController) setupSSLProxy() {
 			}
 
 			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
 				klog.Warningf("Error accepting TCP connection: %v", err)
 				continue
 			}
@@ -860,15 +1125,22 @@ func (n *NGINXController) setupSSLProxy() {
 // configureDynamically encodes new Backends in JSON format and POSTs the
 // payload to an internal HTTP endpoint handled by Lua.
 func (n *NGINXController) configureDynamically(pcfg *ingress.Configuration) error {
-	backendsChanged := !reflect.DeepEqual(n.runningConfig.Backends, pcfg.Backends)
+	running := n.runningConfig.Load()
+
+	backendsChanged := !reflect.DeepEqual(running.Backends, pcfg.Backends)
 	if backendsChanged {
-		err := configureBackends(pcfg.Backends)
+		n.configDriftLock.Lock()
+		checksum, err := configureBackends(pcfg.Backends, n.postOptions())
+		if err == nil {
+			n.backendsChecksum.Store(&checksum)
+		}
+		n.configDriftLock.Unlock()
 		if err != nil {
 			return err
 		}
 	}
 
-	streamConfigurationChanged := !reflect.DeepEqual(n.runningConfig.TCPEndpoints, pcfg.TCPEndpoints) || !reflect.DeepEqual(n.runningConfig.UDPEndpoints, pcfg.UDPEndpoints)
+	streamConfigurationChanged := !reflect.DeepEqual(running.TCPEndpoints, pcfg.TCPEndpoints) || !reflect.DeepEqual(running.UDPEndpoints, pcfg.UDPEndpoints)
 	if streamConfigurationChanged {
 		err := updateStreamConfiguration(pcfg.TCPEndpoints, pcfg.UDPEndpoints)
 		if err != nil {
@@ -876,9 +1148,9 @@ func (n *NGINXController) configureDynamically(pcfg *ingress.Configuration) erro
 		}
 	}
 
-	serversChanged := !reflect.DeepEqual(n.runningConfig.Servers, pcfg.Servers)
+	serversChanged := !reflect.DeepEqual(running.Servers, pcfg.Servers)
 	if serversChanged {
-		err := configureCertificates(pcfg.Servers)
+		err := configureCertificates(pcfg.Servers, n.postOptions())
 		if err != nil {
 			return err
 		}
@@ -887,6 +1159,23 @@ func (n *NGINXController) configureDynamically(pcfg *ingress.Configuration) erro
 	return nil
 }
 
+// streamBackend returns the backend sent to the stream balancer, hashing
+// clients to endpoints when the service defines a hash key
+func streamBackend(name string, ep *ingress.L4Service, service *apiv1.Service) ingress.Backend {
+	backend := ingress.Backend{
+		Name:      name,
+		Endpoints: ep.Endpoints,
+		Port:      intstr.FromInt(ep.Port),
+		Service:   service,
+	}
+	if ep.Proxy.HashBy != "" {
+		backend.LoadBalancing = "chash"
+		backend.UpstreamHashBy = ingress.UpstreamHashByConfig{UpstreamHashBy: ep.Proxy.HashBy}
+	}
+
+	return backend
+}
+
 func updateStreamConfiguration(tcpEndpoints, udpEndpoints []ingress.L4Service) error {
 	streams := make([]ingress.Backend, 0)
 	for i := range tcpEndpoints {
@@ -897,12 +1186,7 @@ func updateStreamConfiguration(tcpEndpoints, udpEndpoints []ingress.L4Service) e
 		}
 
 		key := fmt.Sprintf("tcp-%v-%v-%v", ep.Backend.Namespace, ep.Backend.Name, ep.Backend.Port.String())
-		streams = append(streams, ingress.Backend{
-			Name:      key,
-			Endpoints: ep.Endpoints,
-			Port:      intstr.FromInt(ep.Port),
-			Service:   service,
-		})
+		streams = append(streams, streamBackend(key, ep, service))
 	}
 	for i := range udpEndpoints {
 		ep := &udpEndpoints[i]
@@ -912,12 +1196,7 @@ func updateStreamConfiguration(tcpEndpoints, udpEndpoints []ingress.L4Service) e
 		}
 
 		key := fmt.Sprintf("udp-%v-%v-%v", ep.Backend.Namespace, ep.Backend.Name, ep.Backend.Port.String())
-		streams = append(streams, ingress.Backend{
-			Name:      key,
-			Endpoints: ep.Endpoints,
-			Port:      intstr.FromInt(ep.Port),
-			Service:   service,
-		})
+		streams = append(streams, streamBackend(key, ep, service))
 	}
 
 	buf, err := json.Marshal(streams)
@@ -944,7 +1223,9 @@ func updateStreamConfiguration(tcpEndpoints, udpEndpoints []ingress.L4Service) e
 	return nil
 }
 
-func configureBackends(rawBackends []*ingress.Backend) error {
+// configureBackends sends the backends to Lua, returning the checksum of the
+// configuration Lua stores with them
+func configureBackends(rawBackends []*ingress.Backend, opts postOptions) (uint32, error) {
 	backends := make([]*ingress.Backend, len(rawBackends))
 
 	for i, backend := range rawBackends {
@@ -954,6 +1235,7 @@ func configureBackends(rawBackends []*ingress.Backend) error {
 		}
 		luaBackend := &ingress.Backend{
 			Name:                 backend.Name,
+			ReadableName:         backend.ReadableName,
 			Port:                 backend.Port,
 			SSLPassthrough:       backend.SSLPassthrough,
 			SessionAffinity:      backend.SessionAffinity,
@@ -977,16 +1259,12 @@ func configureBackends(rawBackends []*ingress.Backend) error {
 		backends[i] = luaBackend
 	}
 
-	statusCode, _, err := nginx.NewPostStatusRequest("/configuration/backends", "application/json", backends)
+	buf, err := json.Marshal(backends)
 	if err != nil {
-		return err
-	}
-
-	if statusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected error code: %d", statusCode)
+		return 0, err
 	}
 
-	return nil
+	return crc32.ChecksumIEEE(buf), postDynamicConfigurationJSON("/configuration/backends", buf, opts)
 }
 
 type sslConfiguration struct {
@@ -996,7 +1274,7 @@ type sslConfiguration struct {
 
 // configureCertificates JSON encodes certificates and POSTs it to an internal HTTP endpoint
 // that is handled by Lua
-func configureCertificates(rawServers []*ingress.Server) error {
+func configureCertificates(rawServers []*ingress.Server, opts postOptions) error {
 	configuration := &sslConfiguration{
 		Certificates: map[string]string{},
 		Servers:      map[string]string{},
@@ -1017,11 +1295,17 @@ func configureCertificates(rawServers []*ingress.Server) error {
 	}
 
 	for _, rawServer := range rawServers {
-		configure(rawServer.Hostname, rawServer.SSLCert)
+		sslCert := rawServer.SSLCert
+		if rawServer.SSLRedirectDeferred {
+			// Lua redirects to HTTPS the servers with a certificate
+			sslCert = nil
+		}
+
+		configure(rawServer.Hostname, sslCert)
 
 		for _, alias := range rawServer.Aliases {
-			if rawServer.SSLCert != nil && ssl.IsValidHostname(alias, rawServer.SSLCert.CN) {
-				configuration.Servers[alias] = rawServer.SSLCert.UID
+			if sslCert != nil && ssl.IsValidHostname(alias, sslCert.CN) {
+				configuration.Servers[alias] = sslCert.UID
 			} else {
 				configuration.Servers[alias] = emptyUID
 			}
@@ -1033,16 +1317,7 @@ func configureCertificates(rawServers []*ingress.Server) error {
 		configure(redirect.From, redirect.SSLCert)
 	}
 
-	statusCode, _, err := nginx.NewPostStatusRequest("/configuration/servers", "application/json", configuration)
-	if err != nil {
-		return err
-	}
-
-	if statusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected error code: %d", statusCode)
-	}
-
-	return nil
+	return postDynamicConfiguration("/configuration/servers", configuration, opts)
 }
 
 const otelTmpl = `
@@ -1092,30 +1367,48 @@ func (n *NGINXController) createLuaConfig(cfg *ngx_config.Configuration) error {
 		},
 		UseProxyProtocol:        cfg.UseProxyProtocol,
 		UseForwardedHeaders:     cfg.UseForwardedHeaders,
+		ForwardedForHeader:      cfg.ForwardedForHeader,
+		ForwardedForHops:        cfg.ForwardedForHops,
 		IsSSLPassthroughEnabled: n.cfg.EnableSSLPassthrough,
 		HTTPRedirectCode:        cfg.HTTPRedirectCode,
 		EnableOCSP:              cfg.EnableOCSP,
 		MonitorBatchMaxSize:     n.cfg.MonitorMaxBatchSize,
+		MetricsSocket:           nginx.MetricsSocket,
 		HSTS:                    cfg.HSTS,
 		HSTSMaxAge:              cfg.HSTSMaxAge,
 		HSTSIncludeSubdomains:   cfg.HSTSIncludeSubdomains,
 		HSTSPreload:             cfg.HSTSPreload,
+		AuthCacheMemcached: ngx_template.LuaMemcached{
+			Host:           cfg.AuthMemcachedHost,
+			Port:           cfg.AuthMemcachedPort,
+			ConnectTimeout: cfg.AuthMemcachedTimeout,
+			MaxIdleTimeout: cfg.AuthMemcachedIdleTimeout,
+			PoolSize:       cfg.AuthMemcachedPoolSize,
+		},
+		LimitReqStatusCode:  cfg.LimitReqStatusCode,
+		LimitConnStatusCode: cfg.LimitConnStatusCode,
+
+		AnomalyScoring: ngx_template.LuaAnomalyScoring{
+			Enabled:   cfg.AnomalyScoringService != "",
+			CacheTTL:  cfg.AnomalyScoringCacheTTL,
+			Threshold: cfg.AnomalyScoringThreshold,
+		},
 	}
 	jsonCfg, err := json.Marshal(luaconfigs)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(luaCfgPath, jsonCfg, file.ReadWriteByUser)
+	return os.WriteFile(nginx.LuaConfigPath, jsonCfg, file.ReadWriteByUser)
 }
 
 func cleanTempNginxCfg() error {
 	var files []string
 
-	err := filepath.Walk(filepath.Join(os.TempDir(), "nginx"), func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(nginx.TempDirectory, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() && path != filepath.Join(os.TempDir(), "nginx") {
+		if info.IsDir() && path != nginx.TempDirectory {
 			return filepath.SkipDir
 		}
 
@@ -134,10 +134,11 @@ func TestConfigureDynamically(t *testing.T) {
 	}
 
 	n := &NGINXController{
-		runningConfig: &ingress.Configuration{},
-		cfg:           &Configuration{},
+		cfg: &Configuration{},
 	}
 
+	n.runningConfig.Store(&ingress.Configuration{})
+
 	err = n.configureDynamically(commonConfig)
 	if err != nil {
 		t.Errorf("unexpected error posting dynamic configuration: %v", err)
@@ -147,7 +148,7 @@ func TestConfigureDynamically(t *testing.T) {
 	}
 
 	resetEndpointStats()
-	n.runningConfig.Backends = backends
+	n.runningConfig.Load().Backends = backends
 	err = n.configureDynamically(commonConfig)
 	if err != nil {
 		t.Errorf("unexpected error posting dynamic configuration: %v", err)
@@ -161,7 +162,7 @@ func TestConfigureDynamically(t *testing.T) {
 	}
 
 	resetEndpointStats()
-	n.runningConfig.Servers = servers
+	n.runningConfig.Load().Servers = servers
 	err = n.configureDynamically(commonConfig)
 	if err != nil {
 		t.Errorf("unexpected error posting dynamic configuration: %v", err)
@@ -209,6 +210,15 @@ func TestConfigureCertificates(t *testing.T) {
 		{
 			Hostname: "myapp.nossl",
 		},
+		{
+			Hostname: "myapp.pending",
+			SSLCert: &ingress.SSLCert{
+				PemCertKey: "default-cert",
+				UID:        "00cf1b4f-5b1d-4c0b-9d36-0e4a4d6bd1a3",
+			},
+			PendingCertificate:  "default/myapp-tls",
+			SSLRedirectDeferred: true,
+		},
 	}
 
 	server := &httptest.Server{
@@ -237,7 +247,7 @@ func TestConfigureCertificates(t *testing.T) {
 				}
 
 				for _, server := range servers {
-					if server.SSLCert == nil {
+					if server.SSLCert == nil || server.SSLRedirectDeferred {
 						if conf.Servers[server.Hostname] != emptyUID {
 							t.Errorf("Expected server %s to have UID of %s but got %s", server.Hostname, emptyUID, conf.Servers[server.Hostname])
 						}
@@ -253,12 +263,28 @@ func TestConfigureCertificates(t *testing.T) {
 	defer server.Close()
 	server.Start()
 
-	err = configureCertificates(servers)
+	err = configureCertificates(servers, postOptions{})
 	if err != nil {
 		t.Errorf("unexpected error posting dynamic certificate configuration: %v", err)
 	}
 }
 
+func TestStreamBackend(t *testing.T) {
+	ep := &ingress.L4Service{Port: 53}
+	if backend := streamBackend("udp-kube-system-kube-dns-53", ep, nil); backend.LoadBalancing != "" {
+		t.Errorf("expected the default load balancing but got %q", backend.LoadBalancing)
+	}
+
+	ep.Proxy.HashBy = "$remote_addr"
+	backend := streamBackend("udp-kube-system-kube-dns-53", ep, nil)
+	if backend.LoadBalancing != "chash" {
+		t.Errorf("expected chash load balancing but got %q", backend.LoadBalancing)
+	}
+	if backend.UpstreamHashBy.UpstreamHashBy != "$remote_addr" {
+		t.Errorf("expected $remote_addr as hash key but got %q", backend.UpstreamHashBy.UpstreamHashBy)
+	}
+}
+
 func TestNginxHashBucketSize(t *testing.T) {
 	tests := []struct {
 		n        int
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net"
+
+	"k8s.io/klog/v2"
+
+	ing_net "k8s.io/ingress-nginx/internal/net"
+)
+
+// trustedPeersListener closes the connections of the peers not trusted to send a
+// PROXY protocol header, before the header is decoded
+type trustedPeersListener struct {
+	net.Listener
+	// trusted returns the trusted networks and addresses, all the peers are
+	// trusted when it returns nil
+	trusted func() []string
+	// rejected is called for each connection closed
+	rejected func()
+}
+
+// Accept returns the next connection of a trusted peer
+func (l *trustedPeersListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		trusted := l.trusted()
+		if trusted == nil || isTrustedPeer(conn.RemoteAddr(), trusted) {
+			return conn, nil
+		}
+
+		klog.V(2).InfoS("Closing the connection of a peer not trusted to send a PROXY protocol header", "remote", conn.RemoteAddr())
+		//nolint:errcheck // the connection is discarded
+		conn.Close()
+		if l.rejected != nil {
+			l.rejected()
+		}
+	}
+}
+
+// isTrustedPeer returns true when the address of the peer is in one of the
+// trusted networks or addresses, as in proxy-real-ip-cidr
+func isTrustedPeer(addr net.Addr, trusted []string) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+
+	ipnets, ips, err := ing_net.ParseIPNets(trusted...)
+	if err != nil {
+		klog.Warningf("Error parsing the trusted networks %v: %v", trusted, err)
+		return false
+	}
+
+	if _, ok := ips[tcpAddr.IP.String()]; ok {
+		return true
+	}
+
+	for _, ipnet := range ipnets {
+		if ipnet.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+)
+
+func TestIsTrustedPeer(t *testing.T) {
+	testCases := []struct {
+		addr     net.Addr
+		trusted  []string
+		expected bool
+	}{
+		{&net.TCPAddr{IP: net.ParseIP("10.0.0.1")}, []string{"10.0.0.0/8"}, true},
+		{&net.TCPAddr{IP: net.ParseIP("10.0.0.1")}, []string{"192.168.0.0/16", "10.0.0.1"}, true},
+		{&net.TCPAddr{IP: net.ParseIP("172.16.0.1")}, []string{"10.0.0.0/8"}, false},
+		{&net.TCPAddr{IP: net.ParseIP("2001:db8::1")}, []string{"2001:db8::/32"}, true},
+		{&net.TCPAddr{IP: net.ParseIP("10.0.0.1")}, []string{"0.0.0.0/0"}, true},
+		{&net.TCPAddr{IP: net.ParseIP("10.0.0.1")}, []string{}, false},
+		{&net.TCPAddr{IP: net.ParseIP("10.0.0.1")}, []string{"invalid"}, false},
+		{&net.UnixAddr{Name: "/tmp/socket"}, []string{"0.0.0.0/0"}, false},
+	}
+
+	for _, tc := range testCases {
+		if actual := isTrustedPeer(tc.addr, tc.trusted); actual != tc.expected {
+			t.Errorf("expected %v for %v in %v but returned %v", tc.expected, tc.addr, tc.trusted, actual)
+		}
+	}
+}
+
+func TestTrustedPeersListener(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer listener.Close()
+
+	var trustLocal atomic.Bool
+	var rejected atomic.Int32
+	l := &trustedPeersListener{
+		Listener: listener,
+		trusted: func() []string {
+			if trustLocal.Load() {
+				return []string{"10.0.0.0/8", "127.0.0.1"}
+			}
+			return []string{"10.0.0.0/8"}
+		},
+		rejected: func() { rejected.Add(1) },
+	}
+
+	accepted := make(chan net.Conn)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			close(accepted)
+			return
+		}
+		accepted <- conn
+	}()
+
+	untrusted, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer untrusted.Close()
+
+	// the closed connection returns EOF
+	if _, err := untrusted.Read(make([]byte, 1)); err == nil {
+		t.Errorf("expected the connection of the untrusted peer to be closed")
+	}
+
+	trustLocal.Store(true)
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	if c := <-accepted; c == nil {
+		t.Fatalf("expected the connection of the trusted peer to be accepted")
+	} else {
+		c.Close()
+	}
+
+	if rejected.Load() != 1 {
+		t.Errorf("expected 1 rejected connection but %v were returned", rejected.Load())
+	}
+}
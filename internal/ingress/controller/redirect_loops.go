@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+	utilingress "k8s.io/ingress-nginx/pkg/util/ingress"
+)
+
+// maxRedirectHops is the number of redirects followed looking for a loop
+const maxRedirectHops = 10
+
+// redirectRequest is the part of a request used to choose a redirect
+type redirectRequest struct {
+	scheme string
+	host   string
+	path   string
+}
+
+func (r redirectRequest) String() string {
+	return fmt.Sprintf("%v://%v%v", r.scheme, r.host, r.path)
+}
+
+// redirectLoop describes requests redirected in a cycle and the Ingresses configuring the redirects
+type redirectLoop struct {
+	requests  []redirectRequest
+	ingresses []*ingress.Ingress
+}
+
+func (l *redirectLoop) String() string {
+	hops := make([]string, 0, len(l.requests)+1)
+	for _, r := range l.requests {
+		hops = append(hops, r.String())
+	}
+	hops = append(hops, l.requests[0].String())
+
+	return strings.Join(hops, " -> ")
+}
+
+// hasIngress returns true if the Ingress configures one of the redirects of the loop
+func (l *redirectLoop) hasIngress(ing *networking.Ingress) bool {
+	for _, i := range l.ingresses {
+		if i != nil && i.Namespace == ing.Namespace && i.Name == ing.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findRedirectLoops follows the redirects configured by the ssl-redirect, from-to-www-redirect,
+// permanent-redirect, temporal-redirect and app-root annotations from every location of the
+// servers, returning the loops found. Locations using regular expressions are not followed.
+func findRedirectLoops(servers []*ingress.Server) []*redirectLoop {
+	hosts := map[string]*ingress.Server{}
+	for _, server := range servers {
+		hosts[server.Hostname] = server
+	}
+
+	// the from-to-www-redirect annotation only creates a redirect if there is no server for the other host
+	wwwRedirects := map[string]*ingress.Server{}
+	for _, server := range servers {
+		from, ok := utilingress.FromToWWWRedirectHost(server)
+		if !ok {
+			continue
+		}
+
+		if _, ok := hosts[from]; !ok {
+			wwwRedirects[from] = server
+		}
+	}
+
+	next := func(r redirectRequest) (redirectRequest, *ingress.Ingress, bool) {
+		server, ok := hosts[r.host]
+		if !ok {
+			to, ok := wwwRedirects[r.host]
+			if !ok {
+				return r, nil, false
+			}
+
+			return redirectRequest{r.scheme, to.Hostname, r.path}, serverIngress(to), true
+		}
+
+		if r.path == rootLocation {
+			for _, loc := range server.Locations {
+				if loc.Rewrite.AppRoot != "" && loc.Ingress != nil {
+					return redirectRequest{r.scheme, r.host, loc.Rewrite.AppRoot}, loc.Ingress, true
+				}
+			}
+		}
+
+		loc := redirectLocation(server, r.path)
+		if loc == nil || loc.Ingress == nil {
+			return r, nil, false
+		}
+
+		if loc.Redirect.URL != "" {
+			to, ok := parseRedirectURL(r, loc.Redirect.URL)
+			return to, loc.Ingress, ok
+		}
+
+		if r.scheme == "http" && (loc.Rewrite.ForceSSLRedirect || (loc.Rewrite.SSLRedirect && server.SSLCert != nil)) {
+			return redirectRequest{"https", r.host, r.path}, loc.Ingress, true
+		}
+
+		return r, nil, false
+	}
+
+	loops := []*redirectLoop{}
+	found := map[string]bool{}
+	for _, server := range servers {
+		for _, loc := range server.Locations {
+			if loc.Rewrite.UseRegex {
+				continue
+			}
+
+			for _, scheme := range []string{"http", "https"} {
+				loop := followRedirects(redirectRequest{scheme, server.Hostname, loc.Path}, next)
+				if loop == nil {
+					continue
+				}
+
+				key := loopKey(loop)
+				if found[key] {
+					continue
+				}
+
+				found[key] = true
+				loops = append(loops, loop)
+			}
+		}
+	}
+
+	return loops
+}
+
+// followRedirects returns the loop found following the redirects from a request
+func followRedirects(r redirectRequest, next func(redirectRequest) (redirectRequest, *ingress.Ingress, bool)) *redirectLoop {
+	requests := []redirectRequest{}
+	ingresses := []*ingress.Ingress{}
+	for i := 0; i < maxRedirectHops; i++ {
+		to, ing, ok := next(r)
+		if !ok {
+			return nil
+		}
+
+		requests = append(requests, r)
+		ingresses = append(ingresses, ing)
+		for j := range requests {
+			if requests[j] == to {
+				return &redirectLoop{requests: requests[j:], ingresses: ingresses[j:]}
+			}
+		}
+
+		r = to
+	}
+
+	return nil
+}
+
+// loopKey identifies a loop independently of the request it was found from
+func loopKey(l *redirectLoop) string {
+	hops := make([]string, 0, len(l.requests))
+	for _, r := range l.requests {
+		hops = append(hops, r.String())
+	}
+	sort.Strings(hops)
+
+	return strings.Join(hops, " ")
+}
+
+// redirectLocation returns the location with the longest path matching a request
+func redirectLocation(server *ingress.Server, path string) *ingress.Location {
+	var match *ingress.Location
+	for _, loc := range server.Locations {
+		if loc.Rewrite.UseRegex {
+			continue
+		}
+
+		if loc.PathType != nil && *loc.PathType == networking.PathTypeExact {
+			if loc.Path != path {
+				continue
+			}
+		} else if !strings.HasPrefix(path, loc.Path) {
+			continue
+		}
+
+		if match == nil || len(loc.Path) > len(match.Path) {
+			match = loc
+		}
+	}
+
+	return match
+}
+
+// parseRedirectURL returns the request of a redirect to an URL. URLs using
+// NGINX variables are not followed.
+func parseRedirectURL(from redirectRequest, redirectURL string) (redirectRequest, bool) {
+	if strings.Contains(redirectURL, "$") {
+		return from, false
+	}
+
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		return from, false
+	}
+
+	to := redirectRequest{scheme: u.Scheme, host: u.Hostname(), path: u.Path}
+	if to.scheme == "" {
+		to.scheme = from.scheme
+	}
+	if to.host == "" {
+		to.host = from.host
+	}
+	if to.path == "" {
+		to.path = rootLocation
+	}
+
+	return to, true
+}
+
+// serverIngress returns the Ingress of the root location of a server
+func serverIngress(server *ingress.Server) *ingress.Ingress {
+	for _, loc := range server.Locations {
+		if loc.Path == rootLocation && loc.Ingress != nil {
+			return loc.Ingress
+		}
+	}
+
+	return nil
+}
+
+// checkRedirectLoops returns an error if the Ingress configures a redirect of a loop
+func checkRedirectLoops(ing *networking.Ingress, servers []*ingress.Server) error {
+	for _, loop := range findRedirectLoops(servers) {
+		if loop.hasIngress(ing) {
+			return fmt.Errorf("the redirects of the ingress create a redirect loop: %v", loop)
+		}
+	}
+
+	return nil
+}
+
+// reportRedirectLoops emits an event in the Ingresses configuring the redirects of a loop
+func (n *NGINXController) reportRedirectLoops(servers []*ingress.Server) {
+	for _, loop := range findRedirectLoops(servers) {
+		reported := map[string]bool{}
+		for _, ing := range loop.ingresses {
+			if ing == nil {
+				continue
+			}
+
+			key := k8s.MetaNamespaceKey(ing)
+			if reported[key] {
+				continue
+			}
+			reported[key] = true
+
+			klog.Warningf("Ingress %v configures a redirect loop: %v", key, loop)
+			n.recorder.Eventf(&ing.Ingress, apiv1.EventTypeWarning, "RedirectLoop", "Redirect loop detected: %v", loop)
+		}
+	}
+}
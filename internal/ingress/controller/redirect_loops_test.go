@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/redirect"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/rewrite"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func newRedirectLoopsIngress(name string) *ingress.Ingress {
+	return &ingress.Ingress{
+		Ingress: networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		},
+	}
+}
+
+func TestFindRedirectLoops(t *testing.T) {
+	foo := newRedirectLoopsIngress("foo")
+	bar := newRedirectLoopsIngress("bar")
+
+	testCases := []struct {
+		name      string
+		servers   []*ingress.Server
+		loops     int
+		ingresses []*ingress.Ingress
+	}{
+		{
+			name: "ssl-redirect without loops",
+			servers: []*ingress.Server{
+				{Hostname: "foo.com", SSLCert: &ingress.SSLCert{}, Locations: []*ingress.Location{
+					{Path: "/", Ingress: foo, Rewrite: rewrite.Config{SSLRedirect: true}},
+				}},
+			},
+		},
+		{
+			name: "permanent-redirect to the same location",
+			servers: []*ingress.Server{
+				{Hostname: "foo.com", Locations: []*ingress.Location{
+					{Path: "/", Ingress: foo, Redirect: redirect.Config{URL: "https://foo.com/", Code: 301}},
+				}},
+			},
+			loops:     1,
+			ingresses: []*ingress.Ingress{foo},
+		},
+		{
+			name: "permanent-redirect to HTTP of a host with ssl-redirect",
+			servers: []*ingress.Server{
+				{Hostname: "foo.com", SSLCert: &ingress.SSLCert{}, Locations: []*ingress.Location{
+					{Path: "/", Ingress: foo, Rewrite: rewrite.Config{SSLRedirect: true}},
+				}},
+				{Hostname: "bar.com", Locations: []*ingress.Location{
+					{Path: "/", Ingress: bar, Redirect: redirect.Config{URL: "http://foo.com/", Code: 301}},
+				}},
+			},
+		},
+		{
+			name: "permanent-redirect between hosts",
+			servers: []*ingress.Server{
+				{Hostname: "foo.com", Locations: []*ingress.Location{
+					{Path: "/", Ingress: foo, Redirect: redirect.Config{URL: "https://bar.com", Code: 301}},
+				}},
+				{Hostname: "bar.com", Locations: []*ingress.Location{
+					{Path: "/", Ingress: bar, Redirect: redirect.Config{URL: "https://foo.com/", Code: 302}},
+				}},
+			},
+			loops:     1,
+			ingresses: []*ingress.Ingress{foo, bar},
+		},
+		{
+			name: "from-to-www-redirect to a permanent-redirect",
+			servers: []*ingress.Server{
+				{Hostname: "foo.com", RedirectFromToWWW: true, Locations: []*ingress.Location{
+					{Path: "/", Ingress: foo, Redirect: redirect.Config{URL: "https://www.foo.com/", Code: 301}},
+				}},
+			},
+			loops:     1,
+			ingresses: []*ingress.Ingress{foo},
+		},
+		{
+			name: "app-root to a permanent-redirect",
+			servers: []*ingress.Server{
+				{Hostname: "foo.com", Locations: []*ingress.Location{
+					{Path: "/", Ingress: foo, Rewrite: rewrite.Config{AppRoot: "/app"}},
+					{Path: "/app", Ingress: bar, Redirect: redirect.Config{URL: "/", Code: 302}},
+				}},
+			},
+			// a loop for each scheme
+			loops:     2,
+			ingresses: []*ingress.Ingress{foo, bar},
+		},
+		{
+			name: "permanent-redirect using variables",
+			servers: []*ingress.Server{
+				{Hostname: "foo.com", Locations: []*ingress.Location{
+					{Path: "/", Ingress: foo, Redirect: redirect.Config{URL: "https://foo.com$request_uri", Code: 301}},
+				}},
+			},
+		},
+		{
+			name: "regular expressions",
+			servers: []*ingress.Server{
+				{Hostname: "foo.com", Locations: []*ingress.Location{
+					{Path: "/.*", Ingress: foo, Rewrite: rewrite.Config{UseRegex: true}, Redirect: redirect.Config{URL: "https://foo.com/", Code: 301}},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			loops := findRedirectLoops(tc.servers)
+			if len(loops) != tc.loops {
+				t.Fatalf("expected %v loops but returned %v: %v", tc.loops, len(loops), loops)
+			}
+
+			for _, ing := range tc.ingresses {
+				if !loops[0].hasIngress(&ing.Ingress) {
+					t.Errorf("expected Ingress %v in the loop %v", ing.Name, loops[0])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckRedirectLoops(t *testing.T) {
+	foo := newRedirectLoopsIngress("foo")
+	bar := newRedirectLoopsIngress("bar")
+
+	servers := []*ingress.Server{
+		{Hostname: "foo.com", Locations: []*ingress.Location{
+			{Path: "/", Ingress: foo, Redirect: redirect.Config{URL: "https://foo.com/", Code: 301}},
+		}},
+		{Hostname: "bar.com", Locations: []*ingress.Location{
+			{Path: "/", Ingress: bar},
+		}},
+	}
+
+	if err := checkRedirectLoops(&foo.Ingress, servers); err == nil {
+		t.Errorf("expected an error for the Ingress creating a loop")
+	}
+	if err := checkRedirectLoops(&bar.Ingress, servers); err != nil {
+		t.Errorf("unexpected error for the Ingress not creating a loop: %v", err)
+	}
+}
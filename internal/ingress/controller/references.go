@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/ldapauth"
+	"k8s.io/ingress-nginx/internal/ingress/referencegrant"
+	"k8s.io/ingress-nginx/internal/k8s"
+)
+
+// crossNamespaceReferences contains the kind of the object referenced by the
+// annotations that can reference objects of other namespaces
+var crossNamespaceReferences = []struct {
+	annotation string
+	kind       string
+}{
+	{"auth-secret", referencegrant.KindSecret},
+	{"auth-ldap-secret", referencegrant.KindSecret},
+	{"auth-tls-secret", referencegrant.KindSecret},
+	{"proxy-ssl-secret", referencegrant.KindSecret},
+	{"default-backend", referencegrant.KindService},
+	{"custom-http-errors-fallback-backend", referencegrant.KindService},
+}
+
+// checkCrossNamespaceReferences returns an error if the annotations of the Ingress
+// reference objects of other namespaces not allowed by a ReferenceGrant
+func checkCrossNamespaceReferences(ing *networking.Ingress, allowCrossNamespace bool,
+	isGranted func(fromNamespace, kind, namespace, name string) bool,
+) error {
+	if allowCrossNamespace {
+		return nil
+	}
+
+	for _, ref := range crossNamespaceReferences {
+		// the values are validated by the annotation parsers
+		value, err := parser.GetStringAnnotation(ref.annotation, ing, nil)
+		if err != nil {
+			continue
+		}
+
+		// auth-secret accepts a comma separated list
+		for _, objRef := range strings.Split(value, ",") {
+			ns, name, err := k8s.ParseNameNS(objRef)
+			if err != nil || ns == "" || ns == ing.Namespace {
+				continue
+			}
+
+			if !isGranted(ing.Namespace, ref.kind, ns, name) {
+				return fmt.Errorf("annotation %v references %v %v/%v of another namespace not allowed by a ReferenceGrant",
+					ref.annotation, ref.kind, ns, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LDAPSecret returns the Secret of the auth-ldap-secret annotation of an Ingress,
+// checking again a Secret of another namespace is still allowed by a ReferenceGrant
+func (n *NGINXController) LDAPSecret(key string) (*apiv1.Secret, error) {
+	ing, err := n.store.GetIngress(key)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := ing.ParsedAnnotations.AuthLDAP.Secret
+	if secret == "" {
+		return nil, fmt.Errorf("the Ingress %v does not use LDAP authentication: %w", key, ldapauth.ErrNotAllowed)
+	}
+
+	ns, name, err := k8s.ParseNameNS(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if ns != ing.Namespace && !n.store.GetSecurityConfiguration().AllowCrossNamespaceResources &&
+		!n.store.IsReferenceGranted(ing.Namespace, referencegrant.KindSecret, ns, name) {
+		return nil, fmt.Errorf("cross namespace usage of the secret %v by the Ingress %v: %w", secret, key, ldapauth.ErrNotAllowed)
+	}
+
+	return n.store.GetSecret(secret)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/referencegrant"
+)
+
+func TestCheckCrossNamespaceReferences(t *testing.T) {
+	isGranted := func(fromNamespace, kind, namespace, name string) bool {
+		return fromNamespace == "apps" && kind == referencegrant.KindSecret && namespace == "certs" && name == "shared-ca"
+	}
+
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		allow       bool
+		expectErr   bool
+	}{
+		{"without annotations", nil, false, false},
+		{"same namespace", map[string]string{"auth-secret": "apps/auth"}, false, false},
+		{"name without namespace", map[string]string{"default-backend": "backend"}, false, false},
+		{"granted secret", map[string]string{"auth-tls-secret": "certs/shared-ca"}, false, false},
+		{"secret not granted", map[string]string{"proxy-ssl-secret": "certs/other"}, false, true},
+		{"list with a secret not granted", map[string]string{"auth-secret": "certs/shared-ca,certs/other"}, false, true},
+		{"service not granted", map[string]string{"default-backend": "certs/shared-ca"}, false, true},
+		{"fallback service not granted", map[string]string{"custom-http-errors-fallback-backend": "certs/errors"}, false, true},
+		{"cross namespace resources allowed", map[string]string{"auth-secret": "other/auth"}, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "apps", Annotations: map[string]string{}},
+			}
+			for k, v := range tc.annotations {
+				ing.Annotations[parser.GetAnnotationWithPrefix(k)] = v
+			}
+
+			err := checkCrossNamespaceReferences(ing, tc.allow, isGranted)
+			if tc.expectErr && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// locationNamespace matches the namespace of the Ingress of a location in nginx.conf,
+// the collapsed locations shared by several namespaces are not matched
+var locationNamespace = regexp.MustCompile(`^\s*set \$namespace\s+"([^"]*)";`)
+
+// namespaceConfigBytes returns the bytes of the locations of nginx.conf by namespace of their Ingress.
+// The lines of the nested blocks of a location are counted in its namespace.
+func namespaceConfigBytes(content []byte) map[string]int {
+	type block struct {
+		location  bool
+		namespace string
+		bytes     int
+	}
+
+	sizes := map[string]int{}
+	var stack []*block
+
+	// innermost returns the innermost location of the block stack, nil outside the locations
+	innermost := func() *block {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].location {
+				return stack[i]
+			}
+		}
+		return nil
+	}
+
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+
+		switch {
+		case bytes.HasSuffix(trimmed, []byte("{")):
+			stack = append(stack, &block{location: bytes.HasPrefix(trimmed, []byte("location "))})
+		case bytes.Equal(trimmed, []byte("}")) && len(stack) > 0:
+			b := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if b.location {
+				b.bytes += len(line)
+				if b.namespace != "" {
+					sizes[b.namespace] += b.bytes
+				} else if parent := innermost(); parent != nil {
+					parent.bytes += b.bytes
+				}
+				continue
+			}
+		}
+
+		loc := innermost()
+		if loc == nil {
+			continue
+		}
+		loc.bytes += len(line)
+		if m := locationNamespace.FindSubmatch(line); m != nil {
+			loc.namespace = string(m[1])
+		}
+	}
+
+	return sizes
+}
+
+// reloadTriggers returns the keys of the Ingresses created, changed or deleted between two
+// configurations, the Ingresses of a configuration by key with their resource version
+func reloadTriggers(previous, current map[string]string) []string {
+	var triggers []string
+	for key, version := range current {
+		if previousVersion, ok := previous[key]; !ok || previousVersion != version {
+			triggers = append(triggers, key)
+		}
+	}
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			triggers = append(triggers, key)
+		}
+	}
+
+	slices.Sort(triggers)
+	return triggers
+}
+
+// ingressVersions returns the resource versions of the Ingresses by key
+func ingressVersions(ings []*ingress.Ingress) map[string]string {
+	versions := make(map[string]string, len(ings))
+	for _, ing := range ings {
+		versions[k8s.MetaNamespaceKey(ing)] = ing.ResourceVersion
+	}
+	return versions
+}
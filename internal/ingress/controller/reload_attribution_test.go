@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNamespaceConfigBytes(t *testing.T) {
+	content := `http {
+    server {
+        server_name a.com;
+
+        location /api {
+            set $namespace      "team-a";
+            rewrite_by_lua_block {
+                ngx.exit(200)
+            }
+        }
+
+        location / {
+            set $namespace      "team-b";
+            location /nested {
+                return 200;
+            }
+        }
+
+        location /collapsed {
+            set $namespace      $collapsed_1_namespace;
+        }
+    }
+}
+`
+
+	got := namespaceConfigBytes([]byte(content))
+	expected := map[string]int{
+		"team-a": len(`        location /api {
+            set $namespace      "team-a";
+            rewrite_by_lua_block {
+                ngx.exit(200)
+            }
+        }
+`),
+		"team-b": len(`        location / {
+            set $namespace      "team-b";
+            location /nested {
+                return 200;
+            }
+        }
+`),
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v but got %v", expected, got)
+	}
+}
+
+func TestReloadTriggers(t *testing.T) {
+	previous := map[string]string{
+		"team-a/api":     "1",
+		"team-a/deleted": "2",
+		"team-b/web":     "3",
+	}
+	current := map[string]string{
+		"team-a/api":   "1",
+		"team-b/web":   "4",
+		"team-c/added": "5",
+	}
+
+	expected := []string{"team-a/deleted", "team-b/web", "team-c/added"}
+	if got := reloadTriggers(previous, current); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v but got %v", expected, got)
+	}
+
+	if got := reloadTriggers(current, current); len(got) != 0 {
+		t.Errorf("expected no triggers but got %v", got)
+	}
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+	utilingress "k8s.io/ingress-nginx/pkg/util/ingress"
+)
+
+const (
+	// hashWordSize is the size of a pointer in NGINX, assuming a 64 bit CPU
+	hashWordSize = 8
+	// hashCacheLineSize is the alignment NGINX applies to server_names_hash_bucket_size
+	hashCacheLineSize = 64
+	// maxServerNamesHashBucketSize is the largest bucket size accepted by NGINX
+	maxServerNamesHashBucketSize = 65536 - hashCacheLineSize
+	// maxServerNamesHashMaxSize limits the search of a hash size before
+	// the bucket size is increased
+	maxServerNamesHashMaxSize = 1 << 18
+)
+
+// setServerNamesHashSize adjusts the size of the server names hash tables to
+// the host names defined in the Ingress rules. NGINX cannot resize the hash
+// tables used to store server names and fails to start when a name does not
+// fit in a bucket.
+// https://trac.nginx.org/nginx/ticket/352
+// https://trac.nginx.org/nginx/ticket/631
+func setServerNamesHashSize(cfg *ngx_config.Configuration, servers []*ingress.Server) {
+	var bucketSize, maxSize int
+	if cfg.ServerNameHashAutoSize {
+		bucketSize, maxSize = serverNamesHashSize(servers, cfg.ServerNameHashBucketSize, cfg.ServerNameHashMaxSize)
+	} else {
+		bucketSize, maxSize = estimateServerNamesHashSize(servers)
+	}
+
+	if cfg.ServerNameHashBucketSize < bucketSize {
+		klog.V(3).InfoS("Adjusting ServerNameHashBucketSize variable", "value", bucketSize)
+		cfg.ServerNameHashBucketSize = bucketSize
+	}
+
+	if cfg.ServerNameHashMaxSize < maxSize {
+		klog.V(3).InfoS("Adjusting ServerNameHashMaxSize variable", "value", maxSize)
+		cfg.ServerNameHashMaxSize = maxSize
+	}
+}
+
+// estimateServerNamesHashSize returns a bucket size able to hold the longest
+// host name and a maximum size derived from the length of all the host names.
+func estimateServerNamesHashSize(servers []*ingress.Server) (bucketSize, maxSize int) {
+	var longestName int
+	var serverNameBytes int
+
+	for _, srv := range servers {
+		hostnameLength := len(srv.Hostname)
+		if srv.RedirectFromToWWW {
+			hostnameLength += 4
+		}
+		if longestName < hostnameLength {
+			longestName = hostnameLength
+		}
+
+		for _, alias := range srv.Aliases {
+			if longestName < len(alias) {
+				longestName = len(alias)
+			}
+		}
+
+		serverNameBytes += hostnameLength
+	}
+
+	return nginxHashBucketSize(longestName), nextPowerOf2(serverNameBytes)
+}
+
+// serverNamesHashSize returns the smallest sizes, starting from the
+// configured ones, with which NGINX builds the server names hash tables of
+// the servers without exceeding the bucket size.
+func serverNamesHashSize(servers []*ingress.Server, bucketSize, maxSize int) (int, int) {
+	tables := serverNamesHashTables(servers)
+
+	longestName := 0
+	for _, keys := range tables {
+		for _, key := range keys {
+			if longestName < len(key) {
+				longestName = len(key)
+			}
+		}
+	}
+
+	bucketSize = alignSize(max(bucketSize, nginxHashBucketSize(longestName)), hashCacheLineSize)
+	if maxSize < 1 {
+		maxSize = 1
+	}
+
+	for {
+		for size := maxSize; size <= maxServerNamesHashMaxSize; size *= 2 {
+			if hashTablesFit(tables, bucketSize, size) {
+				return bucketSize, size
+			}
+		}
+
+		if bucketSize*2 > maxServerNamesHashBucketSize {
+			klog.Warningf("Unable to compute the size of the server names hash tables for %d servers", len(servers))
+			return bucketSize, maxSize
+		}
+
+		bucketSize *= 2
+	}
+}
+
+// serverNamesHashTables returns the keys of every hash table NGINX builds
+// for the server names: the exact names and one table per level of the
+// wildcard names. Regular expressions are not stored in hash tables.
+func serverNamesHashTables(servers []*ingress.Server) [][]string {
+	exact := map[string]struct{}{}
+	head := &labelTree{}
+	tail := &labelTree{}
+
+	reversed := func(name string) []string {
+		labels := strings.Split(name, ".")
+		slices.Reverse(labels)
+		return labels
+	}
+
+	addName := func(name string) {
+		name = strings.ToLower(name)
+		switch {
+		case name == "" || strings.HasPrefix(name, "~"):
+		case strings.HasPrefix(name, "*."):
+			head.insert(reversed(name[2:]))
+		case strings.HasPrefix(name, "."):
+			exact[name[1:]] = struct{}{}
+			head.insert(reversed(name[1:]))
+		case strings.HasSuffix(name, ".*"):
+			tail.insert(strings.Split(name[:len(name)-2], "."))
+		default:
+			exact[name] = struct{}{}
+		}
+	}
+
+	for _, srv := range servers {
+		// wildcard host names are rendered as regular expressions
+		if !strings.HasPrefix(srv.Hostname, "*") {
+			addName(srv.Hostname)
+		}
+
+		if from, ok := utilingress.FromToWWWRedirectHost(srv); ok {
+			addName(from)
+		}
+
+		for _, alias := range srv.Aliases {
+			addName(alias)
+		}
+	}
+
+	names := make([]string, 0, len(exact))
+	for name := range exact {
+		names = append(names, name)
+	}
+
+	tables := [][]string{names}
+	tables = head.tables(tables)
+	tables = tail.tables(tables)
+
+	return tables
+}
+
+// labelTree groups the labels of wildcard names the way NGINX splits
+// them into nested hash tables.
+type labelTree struct {
+	children map[string]*labelTree
+}
+
+func (t *labelTree) insert(labels []string) {
+	for _, label := range labels {
+		if t.children == nil {
+			t.children = map[string]*labelTree{}
+		}
+
+		child, ok := t.children[label]
+		if !ok {
+			child = &labelTree{}
+			t.children[label] = child
+		}
+		t = child
+	}
+}
+
+func (t *labelTree) tables(tables [][]string) [][]string {
+	if len(t.children) == 0 {
+		return tables
+	}
+
+	keys := make([]string, 0, len(t.children))
+	for label, child := range t.children {
+		keys = append(keys, label)
+		tables = child.tables(tables)
+	}
+
+	return append(tables, keys)
+}
+
+// hashTablesFit reports whether NGINX builds all the hash tables with the
+// given bucket size and maximum size.
+func hashTablesFit(tables [][]string, bucketSize, maxSize int) bool {
+	for _, keys := range tables {
+		if !hashFits(keys, bucketSize, maxSize) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hashFits mirrors the search of the hash size done by ngx_hash_init and
+// reports whether a size not bigger than maxSize is found.
+func hashFits(keys []string, bucketSize, maxSize int) bool {
+	if len(keys) == 0 {
+		return true
+	}
+
+	bucketSize -= hashWordSize
+
+	hashes := make([]uint64, len(keys))
+	sizes := make([]int, len(keys))
+	for i, key := range keys {
+		sizes[i] = hashElementSize(key)
+		if sizes[i] > bucketSize {
+			return false
+		}
+		hashes[i] = hashKey(key)
+	}
+
+	start := len(keys) / (bucketSize / (2 * hashWordSize))
+	if start == 0 {
+		start = 1
+	}
+	if maxSize > 10000 && maxSize/len(keys) < 100 {
+		start = maxSize - 1000
+	}
+
+	test := make([]int, maxSize)
+	for size := start; size <= maxSize; size++ {
+		clear(test[:size])
+
+		fits := true
+		for i := range keys {
+			bucket := hashes[i] % uint64(size)
+			test[bucket] += sizes[i]
+			if test[bucket] > bucketSize {
+				fits = false
+				break
+			}
+		}
+
+		if fits {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hashKey returns the hash NGINX computes for a key (ngx_hash_key)
+func hashKey(key string) uint64 {
+	var k uint64
+	for i := 0; i < len(key); i++ {
+		k = k*31 + uint64(key[i])
+	}
+
+	return k
+}
+
+// hashElementSize returns the space a key uses in a bucket (NGX_HASH_ELT_SIZE)
+func hashElementSize(key string) int {
+	return hashWordSize + alignSize(len(key)+2, hashWordSize)
+}
+
+func alignSize(n, alignment int) int {
+	return (n + alignment - 1) & ^(alignment - 1)
+}
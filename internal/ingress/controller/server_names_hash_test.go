@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func TestHashKey(t *testing.T) {
+	if v := hashKey("a"); v != 97 {
+		t.Errorf("expected 97 but returned %v", v)
+	}
+	if v := hashKey("ab"); v != 97*31+98 {
+		t.Errorf("expected %v but returned %v", 97*31+98, v)
+	}
+	if v := hashElementSize("example.com"); v != 24 {
+		t.Errorf("expected 24 but returned %v", v)
+	}
+}
+
+func TestServerNamesHashTables(t *testing.T) {
+	servers := []*ingress.Server{
+		{Hostname: "_"},
+		{Hostname: "*.wildcard.com"},
+		{Hostname: "example.com", RedirectFromToWWW: true, Aliases: []string{"*.example.com", "Alias.com", "~^regex$"}},
+		{Hostname: "www.foo.com", RedirectFromToWWW: true, Aliases: []string{".bar.com", "mail.example.*"}},
+	}
+
+	tables := serverNamesHashTables(servers)
+	for _, keys := range tables {
+		slices.Sort(keys)
+	}
+	slices.SortFunc(tables, func(a, b []string) int {
+		return strings.Compare(strings.Join(a, ","), strings.Join(b, ","))
+	})
+
+	expected := [][]string{
+		{"_", "alias.com", "bar.com", "example.com", "foo.com", "www.example.com", "www.foo.com"},
+		{"bar", "example"},
+		{"com"},
+		{"example"},
+		{"mail"},
+	}
+	if !slices.EqualFunc(tables, expected, slices.Equal[[]string]) {
+		t.Errorf("expected %v but returned %v", expected, tables)
+	}
+}
+
+func TestServerNamesHashSize(t *testing.T) {
+	longName := strings.Repeat("a", 200) + ".example.com"
+
+	manyServers := []*ingress.Server{}
+	for i := 0; i < 2000; i++ {
+		manyServers = append(manyServers, &ingress.Server{Hostname: fmt.Sprintf("host-%d.example.com", i)})
+	}
+
+	testCases := []struct {
+		name       string
+		servers    []*ingress.Server
+		bucketSize int
+		maxSize    int
+		expBucket  int
+		expMax     int
+	}{
+		{"defaults", []*ingress.Server{{Hostname: "_"}, {Hostname: "example.com"}}, 0, 1024, 64, 1024},
+		{"configured bucket size is aligned", []*ingress.Server{{Hostname: "example.com"}}, 100, 1024, 128, 1024},
+		{"long host name", []*ingress.Server{{Hostname: longName}}, 0, 1024, 256, 1024},
+		{"long alias", []*ingress.Server{{Hostname: "example.com", Aliases: []string{longName}}}, 64, 1024, 256, 1024},
+		{"long wildcard host name", []*ingress.Server{{Hostname: "*." + longName}}, 64, 1024, 64, 1024},
+		{"long wildcard alias", []*ingress.Server{{Hostname: "example.com", Aliases: []string{"*." + longName}}}, 64, 1024, 256, 1024},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bucketSize, maxSize := serverNamesHashSize(tc.servers, tc.bucketSize, tc.maxSize)
+			if bucketSize != tc.expBucket || maxSize != tc.expMax {
+				t.Errorf("expected %v/%v but returned %v/%v", tc.expBucket, tc.expMax, bucketSize, maxSize)
+			}
+		})
+	}
+
+	bucketSize, maxSize := serverNamesHashSize(manyServers, 64, 512)
+	if maxSize <= 512 {
+		t.Errorf("expected a max size bigger than 512 but returned %v", maxSize)
+	}
+	if !hashTablesFit(serverNamesHashTables(manyServers), bucketSize, maxSize) {
+		t.Errorf("expected the server names to fit in %v/%v", bucketSize, maxSize)
+	}
+}
+
+func TestSetServerNamesHashSize(t *testing.T) {
+	servers := []*ingress.Server{{Hostname: "*." + strings.Repeat("a", 200) + ".example.com"}}
+
+	cfg := ngx_config.NewDefault()
+	setServerNamesHashSize(&cfg, servers)
+	if cfg.ServerNameHashBucketSize != 64 || cfg.ServerNameHashMaxSize != 1024 {
+		t.Errorf("expected 64/1024 but returned %v/%v", cfg.ServerNameHashBucketSize, cfg.ServerNameHashMaxSize)
+	}
+
+	cfg = ngx_config.NewDefault()
+	cfg.ServerNameHashAutoSize = false
+	setServerNamesHashSize(&cfg, servers)
+	if cfg.ServerNameHashBucketSize != 256 || cfg.ServerNameHashMaxSize != 1024 {
+		t.Errorf("expected 256/1024 but returned %v/%v", cfg.ServerNameHashBucketSize, cfg.ServerNameHashMaxSize)
+	}
+}
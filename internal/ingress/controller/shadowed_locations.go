@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+
+	apiv1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// shadowedLocation is a location of a server never used for its path because
+// the regular expression of an earlier location matches it
+type shadowedLocation struct {
+	host     string
+	location *ingress.Location
+	by       *ingress.Location
+}
+
+func (s shadowedLocation) String() string {
+	return fmt.Sprintf("path %q of host %q in Ingress %v is shadowed by the regular expression %q of Ingress %v",
+		s.location.Path, s.host, locationIngressKey(s.location), s.by.Path, locationIngressKey(s.by))
+}
+
+// hasIngress returns true if the Ingress configures one of the locations
+func (s shadowedLocation) hasIngress(ing *networking.Ingress) bool {
+	for _, loc := range []*ingress.Location{s.location, s.by} {
+		if loc.Ingress != nil && loc.Ingress.Namespace == ing.Namespace && loc.Ingress.Name == ing.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func locationIngressKey(loc *ingress.Location) string {
+	if loc.Ingress == nil {
+		return "default backend"
+	}
+
+	return k8s.MetaNamespaceKey(loc.Ingress)
+}
+
+// usesRegex returns true if the locations of the server are rendered as regular
+// expressions, like the enforceRegexModifier function of the template
+func usesRegex(locations []*ingress.Location) bool {
+	for _, loc := range locations {
+		if loc.Rewrite.UseRegex || (loc.Rewrite.Target != "" && loc.Rewrite.Target != loc.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findShadowedLocations returns the locations of the servers using regular expressions whose
+// path is matched by the regular expression of an earlier location. NGINX uses the first
+// regular expression matching a request, so the later location never gets the requests of
+// its path. Only the paths without special characters are checked, as their requests are known.
+func findShadowedLocations(servers []*ingress.Server) []shadowedLocation {
+	shadowed := []shadowedLocation{}
+	for _, server := range servers {
+		if !usesRegex(server.Locations) {
+			continue
+		}
+
+		// the regular expressions are compiled once, nil when RE2 does not support the syntax
+		regexes := make([]*regexp.Regexp, len(server.Locations))
+		for i, loc := range server.Locations {
+			// the locations are rendered as ~* "^<path>"
+			regexes[i], _ = regexp.Compile("(?i)^(?:" + loc.Path + ")")
+		}
+
+		for j, loc := range server.Locations {
+			if loc.Ingress == nil || regexp.QuoteMeta(loc.Path) != loc.Path {
+				continue
+			}
+
+			for i := 0; i < j; i++ {
+				if regexes[i] == nil || !regexes[i].MatchString(loc.Path) {
+					continue
+				}
+
+				shadowed = append(shadowed, shadowedLocation{server.Hostname, loc, server.Locations[i]})
+				break
+			}
+		}
+	}
+
+	return shadowed
+}
+
+// reportShadowedLocations emits an event in the Ingresses configuring a shadowed location
+// and the location shadowing it
+func (n *NGINXController) reportShadowedLocations(servers []*ingress.Server) {
+	for _, s := range findShadowedLocations(servers) {
+		klog.Warningf("Location shadowed: %v", s)
+		n.recorder.Eventf(&s.location.Ingress.Ingress, apiv1.EventTypeWarning, "LocationShadowed", "Location shadowed: %v", s)
+		if s.by.Ingress != nil && locationIngressKey(s.by) != locationIngressKey(s.location) {
+			n.recorder.Eventf(&s.by.Ingress.Ingress, apiv1.EventTypeWarning, "LocationShadowed", "Location shadowed: %v", s)
+		}
+	}
+}
+
+// shadowedLocationWarnings returns the warnings of the paths of the Ingress shadowed by or shadowing
+// the locations of the same hosts in the running configuration
+func (n *NGINXController) shadowedLocationWarnings(ing *networking.Ingress) []string {
+	// invalid annotations are rejected by CheckIngress
+	parsed, err := annotations.NewAnnotationExtractor(n.store).Extract(ing)
+	if err != nil {
+		return nil
+	}
+	newIngress := &ingress.Ingress{Ingress: *ing, ParsedAnnotations: parsed}
+
+	servers := map[string]*ingress.Server{}
+	hosts := []string{}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		host := rule.Host
+		if host == "" {
+			host = defServerName
+		}
+
+		server, ok := servers[host]
+		if !ok {
+			server = &ingress.Server{Hostname: host}
+			servers[host] = server
+			hosts = append(hosts, host)
+			if running := n.runningConfig.Load(); running != nil {
+				for _, s := range running.Servers {
+					if s.Hostname != host {
+						continue
+					}
+					for _, loc := range s.Locations {
+						if loc.Ingress == nil || loc.Ingress.Namespace != ing.Namespace || loc.Ingress.Name != ing.Name {
+							server.Locations = append(server.Locations, loc)
+						}
+					}
+				}
+			}
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			nginxPath := rootLocation
+			if path.Path != "" {
+				nginxPath = path.Path
+			}
+
+			server.Locations = append(server.Locations, &ingress.Location{
+				Path:     nginxPath,
+				PathType: path.PathType,
+				Ingress:  newIngress,
+				Rewrite:  parsed.Rewrite,
+			})
+		}
+	}
+
+	checked := make([]*ingress.Server, 0, len(hosts))
+	for _, host := range hosts {
+		sortLocations(servers[host].Locations)
+		checked = append(checked, servers[host])
+	}
+
+	warnings := []string{}
+	for _, s := range findShadowedLocations(checked) {
+		if s.hasIngress(ing) {
+			warnings = append(warnings, s.String())
+		}
+	}
+
+	return warnings
+}
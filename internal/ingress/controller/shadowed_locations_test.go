@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/rewrite"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func newShadowIngress(name string) *ingress.Ingress {
+	return &ingress.Ingress{
+		Ingress: networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		},
+	}
+}
+
+func TestFindShadowedLocations(t *testing.T) {
+	regexIngress := newShadowIngress("regex")
+	apiIngress := newShadowIngress("api")
+
+	servers := []*ingress.Server{
+		{
+			Hostname: "example.com",
+			Locations: []*ingress.Location{
+				{Path: "/[a-z]+/.*", Ingress: regexIngress, Rewrite: rewrite.Config{UseRegex: true}},
+				{Path: "/api/v1", Ingress: apiIngress},
+				{Path: "/(v2)", Ingress: apiIngress},
+				{Path: "/b", Ingress: apiIngress},
+				{Path: "/", Ingress: nil},
+			},
+		},
+		{
+			Hostname: "prefix.example.com",
+			Locations: []*ingress.Location{
+				{Path: "/api/", Ingress: regexIngress},
+				{Path: "/api", Ingress: apiIngress},
+			},
+		},
+	}
+
+	shadowed := findShadowedLocations(servers)
+	if len(shadowed) != 1 {
+		t.Fatalf("expected one shadowed location but got %v", shadowed)
+	}
+
+	expected := `path "/api/v1" of host "example.com" in Ingress default/api is shadowed by the regular expression "/[a-z]+/.*" of Ingress default/regex`
+	if shadowed[0].String() != expected {
+		t.Errorf("expected %v but got %v", expected, shadowed[0])
+	}
+	if !shadowed[0].hasIngress(&regexIngress.Ingress) || !shadowed[0].hasIngress(&apiIngress.Ingress) {
+		t.Errorf("expected the shadowed location to have both Ingresses")
+	}
+}
+
+func TestShadowedLocationWarnings(t *testing.T) {
+	n := &NGINXController{
+		store: &fakeIngressStore{},
+	}
+
+	n.runningConfig.Store(&ingress.Configuration{
+		Servers: []*ingress.Server{
+			{
+				Hostname: "example.com",
+				Locations: []*ingress.Location{
+					{Path: "/.*/users/?", Ingress: newShadowIngress("regex"), Rewrite: rewrite.Config{UseRegex: true}},
+				},
+			},
+		},
+	})
+
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: "example.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{Path: "/v1/users", PathType: &pathTypeExact},
+								{Path: "/v1/orders", PathType: &pathTypeExact},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	warnings := n.shadowedLocationWarnings(ing)
+	expected := `path "/v1/users" of host "example.com" in Ingress default/api is shadowed by the regular expression "/.*/users/?" of Ingress default/regex`
+	if len(warnings) != 1 || warnings[0] != expected {
+		t.Errorf("expected the warning %v but got %v", expected, warnings)
+	}
+
+	ing.Spec.Rules[0].Host = "other.example.com"
+	if warnings := n.shadowedLocationWarnings(ing); len(warnings) != 0 {
+		t.Errorf("expected no warnings in another host but got %v", warnings)
+	}
+}
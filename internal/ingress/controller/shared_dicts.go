@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
+	"k8s.io/ingress-nginx/internal/k8s"
+)
+
+const (
+	luaSharedDictsCheckInterval = time.Minute
+
+	// maxLuaSharedDictMegabytes is the biggest size of a Lua shared dictionary accepted by lua-shared-dicts
+	maxLuaSharedDictMegabytes = 200
+)
+
+// growableLuaSharedDicts are the dictionaries used by the dynamic configuration.
+// When they are full the new backends and certificates are lost without a reload.
+var growableLuaSharedDicts = []string{"configuration_data", "certificate_data"}
+
+// watchLuaSharedDicts periodically checks the usage of the Lua shared dictionaries
+// until the controller stops, emitting an event when one of them is near its capacity
+func (n *NGINXController) watchLuaSharedDicts() {
+	recommended := map[string]int64{}
+
+	wait.Until(func() {
+		dicts, err := collectors.GetLuaSharedDicts()
+		if err != nil {
+			klog.V(3).ErrorS(err, "Error obtaining the usage of Lua shared dictionaries")
+			return
+		}
+
+		for i := range dicts {
+			d := &dicts[i]
+			size, ok := recommendLuaSharedDictSize(d, n.cfg.LuaSharedDictsUsageThreshold)
+			if !ok || recommended[d.Name] == size {
+				continue
+			}
+			recommended[d.Name] = size
+
+			msg := fmt.Sprintf("Lua shared dictionary %v is %.0f%% full, consider increasing its size with the lua-shared-dicts option of the ConfigMap to \"%v: %vM\"",
+				d.Name, d.Utilization()*100, d.Name, size)
+			klog.Warning(msg)
+			n.recorder.Eventf(k8s.IngressPodDetails, apiv1.EventTypeWarning, "LuaSharedDictNearCapacity", msg)
+		}
+	}, luaSharedDictsCheckInterval, n.stopCh)
+}
+
+// recommendLuaSharedDictSize returns the size in megabytes recommended for a dictionary
+// used by the dynamic configuration when its usage is over the threshold or it had evictions
+func recommendLuaSharedDictSize(d *collectors.LuaSharedDict, threshold float64) (int64, bool) {
+	growable := false
+	for _, name := range growableLuaSharedDicts {
+		if d.Name == name {
+			growable = true
+			break
+		}
+	}
+
+	if !growable || (d.Utilization() < threshold && d.Evictions == 0) {
+		return 0, false
+	}
+
+	current := d.Capacity / (1024 * 1024)
+	if current >= maxLuaSharedDictMegabytes {
+		return 0, false
+	}
+
+	size := 2 * current
+	if size < 1 {
+		size = 1
+	}
+	if size > maxLuaSharedDictMegabytes {
+		size = maxLuaSharedDictMegabytes
+	}
+
+	return size, true
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
+)
+
+func TestRecommendLuaSharedDictSize(t *testing.T) {
+	const mb = 1024 * 1024
+
+	testCases := []struct {
+		name     string
+		dict     collectors.LuaSharedDict
+		size     int64
+		expected bool
+	}{
+		{"under the threshold", collectors.LuaSharedDict{Name: "configuration_data", Capacity: 20 * mb, FreeSpace: 10 * mb}, 0, false},
+		{"over the threshold", collectors.LuaSharedDict{Name: "configuration_data", Capacity: 20 * mb, FreeSpace: 1 * mb}, 40, true},
+		{"with evictions", collectors.LuaSharedDict{Name: "certificate_data", Capacity: 20 * mb, FreeSpace: 10 * mb, Evictions: 1}, 40, true},
+		{"not used by the dynamic configuration", collectors.LuaSharedDict{Name: "balancer_ewma", Capacity: 10 * mb, FreeSpace: 0}, 0, false},
+		{"limited to the maximum size", collectors.LuaSharedDict{Name: "certificate_data", Capacity: 150 * mb, FreeSpace: 0}, 200, true},
+		{"at the maximum size", collectors.LuaSharedDict{Name: "certificate_data", Capacity: 200 * mb, FreeSpace: 0}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			size, ok := recommendLuaSharedDictSize(&tc.dict, 0.9)
+			if ok != tc.expected || size != tc.size {
+				t.Errorf("expected %v and %v but returned %v and %v", tc.size, tc.expected, size, ok)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	klog "k8s.io/klog/v2"
 
 	"k8s.io/ingress-nginx/internal/net/ssl"
+	"k8s.io/ingress-nginx/internal/redact"
 
 	"k8s.io/ingress-nginx/pkg/util/file"
 )
@@ -41,6 +42,11 @@ func (s *k8sStore) syncSecret(key string) {
 
 	klog.V(3).InfoS("Syncing Secret", "name", key)
 
+	// only the values of the Secrets used by the controller are redacted
+	if sec, err := s.listers.Secret.ByKey(key); err == nil {
+		redact.RegisterSecret(key, sec.Data)
+	}
+
 	cert, err := s.getPemCertificate(key)
 	if err != nil {
 		if !isErrSecretForAuth(err) {
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"k8s.io/ingress-nginx/internal/k8s"
+)
+
+// overlayAllowedKeys is the key of the base configuration ConfigMap containing
+// the keys the override ConfigMaps can define, any key when empty
+const overlayAllowedKeys = "configmap-overlay-allowed-keys"
+
+// mergedConfigMaps is the configuration of the configuration ConfigMaps merged in order
+type mergedConfigMaps struct {
+	data map[string]string
+	// source contains the ConfigMap defining the value of each key
+	source map[string]*corev1.ConfigMap
+	// conflicts contains the keys of each override ConfigMap replacing a different
+	// value of a previous ConfigMap, with the name of the previous ConfigMap
+	conflicts map[*corev1.ConfigMap][]string
+	// denied contains the keys of each override ConfigMap not allowed by the base ConfigMap
+	denied map[*corev1.ConfigMap][]string
+}
+
+// splitConfigMaps returns the names of the configuration ConfigMaps of the
+// comma-separated list of the --configmap flag
+func splitConfigMaps(names string) []string {
+	var cmaps []string
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			cmaps = append(cmaps, name)
+		}
+	}
+
+	return cmaps
+}
+
+// mergeConfigMaps merges the data of the configuration ConfigMaps in order, the
+// values of each ConfigMap replacing the ones of the previous ConfigMaps. The first
+// ConfigMap is the base, the following ones only define the keys allowed by the base.
+// A nil ConfigMap, not found in the cluster, is skipped.
+func mergeConfigMaps(cmaps []*corev1.ConfigMap) *mergedConfigMaps {
+	merged := &mergedConfigMaps{
+		data:      map[string]string{},
+		source:    map[string]*corev1.ConfigMap{},
+		conflicts: map[*corev1.ConfigMap][]string{},
+		denied:    map[*corev1.ConfigMap][]string{},
+	}
+
+	allowed := sets.New[string]()
+	if len(cmaps) > 0 && cmaps[0] != nil {
+		for _, key := range strings.Split(cmaps[0].Data[overlayAllowedKeys], ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				allowed.Insert(key)
+			}
+		}
+	}
+
+	for i, cmap := range cmaps {
+		if cmap == nil {
+			continue
+		}
+
+		for _, key := range sortedDataKeys(cmap.Data) {
+			value := cmap.Data[key]
+			if i > 0 && (key == overlayAllowedKeys || (allowed.Len() > 0 && !allowed.Has(key))) {
+				merged.denied[cmap] = append(merged.denied[cmap], key)
+				continue
+			}
+
+			if prev, ok := merged.source[key]; ok && merged.data[key] != value {
+				merged.conflicts[cmap] = append(merged.conflicts[cmap], fmt.Sprintf("%v (%v)", key, k8s.MetaNamespaceKey(prev)))
+			}
+
+			merged.data[key] = value
+			merged.source[key] = cmap
+		}
+	}
+
+	delete(merged.data, overlayAllowedKeys)
+	delete(merged.source, overlayAllowedKeys)
+
+	return merged
+}
+
+func sortedDataKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newConfigMap(namespace, name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data:       data,
+	}
+}
+
+func TestSplitConfigMaps(t *testing.T) {
+	expected := []string{"ingress-nginx/base", "team-a/overlay"}
+	if cmaps := splitConfigMaps("ingress-nginx/base, team-a/overlay,"); !reflect.DeepEqual(cmaps, expected) {
+		t.Errorf("expected %v but %v was returned", expected, cmaps)
+	}
+
+	if cmaps := splitConfigMaps(""); cmaps != nil {
+		t.Errorf("expected no ConfigMaps but %v was returned", cmaps)
+	}
+}
+
+func TestMergeConfigMaps(t *testing.T) {
+	base := newConfigMap("ingress-nginx", "base", map[string]string{
+		"use-gzip":        "true",
+		"proxy-body-size": "1m",
+		"ssl-protocols":   "TLSv1.3",
+	})
+	overlay := newConfigMap("team-a", "overlay", map[string]string{
+		"proxy-body-size":   "8m",
+		"use-gzip":          "true",
+		"proxy-buffer-size": "16k",
+	})
+
+	merged := mergeConfigMaps([]*corev1.ConfigMap{base, overlay})
+	expected := map[string]string{
+		"use-gzip":          "true",
+		"proxy-body-size":   "8m",
+		"ssl-protocols":     "TLSv1.3",
+		"proxy-buffer-size": "16k",
+	}
+	if !reflect.DeepEqual(merged.data, expected) {
+		t.Errorf("expected %v but %v was returned", expected, merged.data)
+	}
+	if merged.source["proxy-body-size"] != overlay || merged.source["ssl-protocols"] != base {
+		t.Errorf("unexpected source of the keys: %v", merged.source)
+	}
+	if conflicts := merged.conflicts[overlay]; !reflect.DeepEqual(conflicts, []string{"proxy-body-size (ingress-nginx/base)"}) {
+		t.Errorf("unexpected conflicts: %v", conflicts)
+	}
+	if len(merged.denied) != 0 {
+		t.Errorf("expected no denied keys but %v was returned", merged.denied)
+	}
+}
+
+func TestMergeConfigMapsAllowedKeys(t *testing.T) {
+	base := newConfigMap("ingress-nginx", "base", map[string]string{
+		"use-gzip":                       "true",
+		"configmap-overlay-allowed-keys": "proxy-body-size, proxy-buffer-size",
+	})
+	overlay := newConfigMap("team-a", "overlay", map[string]string{
+		"proxy-body-size":                "8m",
+		"use-gzip":                       "false",
+		"configmap-overlay-allowed-keys": "use-gzip",
+	})
+
+	merged := mergeConfigMaps([]*corev1.ConfigMap{base, overlay})
+	expected := map[string]string{
+		"use-gzip":        "true",
+		"proxy-body-size": "8m",
+	}
+	if !reflect.DeepEqual(merged.data, expected) {
+		t.Errorf("expected %v but %v was returned", expected, merged.data)
+	}
+	if denied := merged.denied[overlay]; !reflect.DeepEqual(denied, []string{"configmap-overlay-allowed-keys", "use-gzip"}) {
+		t.Errorf("unexpected denied keys: %v", denied)
+	}
+
+	// without the base ConfigMap the override ConfigMaps are not constrained
+	merged = mergeConfigMaps([]*corev1.ConfigMap{nil, overlay})
+	if merged.data["use-gzip"] != "false" || len(merged.denied[overlay]) != 1 {
+		t.Errorf("unexpected merge without the base ConfigMap: %v, denied %v", merged.data, merged.denied)
+	}
+}
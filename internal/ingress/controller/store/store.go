@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -36,6 +37,8 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -54,9 +57,13 @@ import (
 	"k8s.io/ingress-nginx/internal/ingress/controller/ingressclass"
 	ngx_template "k8s.io/ingress-nginx/internal/ingress/controller/template"
 	"k8s.io/ingress-nginx/internal/ingress/defaults"
+	"k8s.io/ingress-nginx/internal/ingress/deprecation"
 	"k8s.io/ingress-nginx/internal/ingress/errors"
+	"k8s.io/ingress-nginx/internal/ingress/referencegrant"
 	"k8s.io/ingress-nginx/internal/ingress/resolver"
+	"k8s.io/ingress-nginx/internal/ingress/transform"
 	"k8s.io/ingress-nginx/internal/k8s"
+	"k8s.io/ingress-nginx/internal/redact"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
 )
 
@@ -87,6 +94,9 @@ type Storer interface {
 	// ListIngresses returns a list of all Ingresses in the store.
 	ListIngresses() []*ingress.Ingress
 
+	// GetIngress returns the Ingress matching key, with its parsed annotations.
+	GetIngress(key string) (*ingress.Ingress, error)
+
 	// GetLocalSSLCert returns the local copy of a SSLCert
 	GetLocalSSLCert(name string) (*ingress.SSLCert, error)
 
@@ -106,6 +116,13 @@ type Storer interface {
 
 	// GetIngressClass validates given ingress against ingress class configuration and returns the ingress class.
 	GetIngressClass(ing *networkingv1.Ingress, icConfig *ingressclass.Configuration) (string, error)
+
+	// IsReferenceGranted returns true if a ReferenceGrant allows the Ingresses of a namespace
+	// to reference the object of the kind in another namespace.
+	IsReferenceGranted(fromNamespace, kind, namespace, name string) bool
+
+	// GetHTTPTransform returns the HTTPTransform matching key.
+	GetHTTPTransform(key string) (*transform.HTTPTransform, error)
 }
 
 // EventType type of event associated with an informer
@@ -137,6 +154,9 @@ type Informer struct {
 	Secret        cache.SharedIndexInformer
 	ConfigMap     cache.SharedIndexInformer
 	Namespace     cache.SharedIndexInformer
+
+	ReferenceGrant cache.SharedIndexInformer
+	HTTPTransform  cache.SharedIndexInformer
 }
 
 // Lister contains object listers (stores).
@@ -149,6 +169,8 @@ type Lister struct {
 	ConfigMap             ConfigMapLister
 	Namespace             NamespaceLister
 	IngressWithAnnotation IngressWithAnnotationsLister
+	ReferenceGrant        *referencegrant.Lister
+	HTTPTransform         *transform.Lister
 }
 
 // NotExistsError is returned when an object does not exist in a local store.
@@ -182,6 +204,22 @@ func (i *Informer) Run(stopCh chan struct{}) {
 		runtime.HandleError(fmt.Errorf("timed out waiting for ingress classcaches to sync"))
 	}
 
+	if i.ReferenceGrant != nil {
+		go i.ReferenceGrant.Run(stopCh)
+
+		if !cache.WaitForCacheSync(stopCh, i.ReferenceGrant.HasSynced) {
+			runtime.HandleError(fmt.Errorf("timed out waiting for reference grant caches to sync"))
+		}
+	}
+
+	if i.HTTPTransform != nil {
+		go i.HTTPTransform.Run(stopCh)
+
+		if !cache.WaitForCacheSync(stopCh, i.HTTPTransform.HasSynced) {
+			runtime.HandleError(fmt.Errorf("timed out waiting for http transform caches to sync"))
+		}
+	}
+
 	// when limit controller scope to one namespace, skip sync namespaces at cluster scope
 	if i.Namespace != nil {
 		go i.Namespace.Run(stopCh)
@@ -241,6 +279,9 @@ type k8sStore struct {
 
 	defaultSSLCertificate string
 
+	// configMaps contains the names of the configuration ConfigMaps in the merge order
+	configMaps []string
+
 	recorder record.EventRecorder
 }
 
@@ -258,6 +299,8 @@ func New(
 	deepInspector bool,
 	icConfig *ingressclass.Configuration,
 	disableSyncEvents bool,
+	dynamicClient dynamic.Interface,
+	transformClient dynamic.Interface,
 ) Storer {
 	store := &k8sStore{
 		informers:             &Informer{},
@@ -269,6 +312,7 @@ func New(
 		backendConfigMu:       &sync.RWMutex{},
 		secretIngressMap:      NewObjectRefMap(),
 		defaultSSLCertificate: defaultSSLCertificate,
+		configMaps:            splitConfigMaps(configmap),
 	}
 
 	eventBroadcaster := record.NewBroadcaster()
@@ -278,9 +322,9 @@ func New(
 			Interface: client.CoreV1().Events(namespace),
 		})
 	}
-	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{
+	recorder := redact.NewEventRecorder(eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{
 		Component: "nginx-ingress-controller",
-	})
+	}))
 	store.recorder = recorder
 
 	// k8sStore fulfills resolver.Resolver interface
@@ -418,7 +462,9 @@ func New(
 		}
 
 		key := k8s.MetaNamespaceKey(ing)
+		secrets := store.secretIngressMap.ReferencedBy(key)
 		store.secretIngressMap.Delete(key)
+		store.unregisterUnreferencedSecrets(secrets)
 
 		updateCh.In() <- Event{
 			Type: DeleteEvent,
@@ -605,8 +651,8 @@ func New(
 			}
 			key := k8s.MetaNamespaceKey(sec)
 
-			if store.defaultSSLCertificate == key {
-				store.syncSecret(store.defaultSSLCertificate)
+			if store.isDefaultCertificate(key) {
+				store.syncSecret(key)
 			}
 
 			// find references in ingresses and update local ssl certs
@@ -639,8 +685,8 @@ func New(
 					return
 				}
 
-				if store.defaultSSLCertificate == key {
-					store.syncSecret(store.defaultSSLCertificate)
+				if store.isDefaultCertificate(key) {
+					store.syncSecret(key)
 				}
 
 				// find references in ingresses and update local ssl certs
@@ -684,6 +730,11 @@ func New(
 			store.sslStore.Delete(k8s.MetaNamespaceKey(sec))
 
 			key := k8s.MetaNamespaceKey(sec)
+			redact.Unregister(key)
+
+			if store.GetBackendConfiguration().DefaultServerSSLCertificate == key {
+				store.sendDummyEvent()
+			}
 
 			// find references in ingresses
 			if ings := store.secretIngressMap.Reference(key); len(ings) > 0 {
@@ -737,7 +788,7 @@ func New(
 	}
 
 	changeTriggerUpdate := func(name string) bool {
-		return name == configmap || name == tcp || name == udp
+		return slices.Contains(store.configMaps, name) || name == tcp || name == udp
 	}
 
 	handleCfgMapEvent := func(key string, cfgMap *corev1.ConfigMap, eventName string) {
@@ -746,8 +797,11 @@ func New(
 		if changeTriggerUpdate(key) {
 			triggerUpdate = true
 			recorder.Eventf(cfgMap, corev1.EventTypeNormal, eventName, fmt.Sprintf("ConfigMap %v", key))
-			if key == configmap {
-				store.setConfig(cfgMap)
+			if slices.Contains(store.configMaps, key) {
+				store.setConfig(store.configurationConfigMaps())
+				if secretName := store.GetBackendConfiguration().DefaultServerSSLCertificate; secretName != "" {
+					store.syncSecret(secretName)
+				}
 			}
 		}
 
@@ -799,6 +853,32 @@ func New(
 			key := k8s.MetaNamespaceKey(cfgMap)
 			handleCfgMapEvent(key, cfgMap, "UPDATE")
 		},
+		DeleteFunc: func(obj interface{}) {
+			cfgMap, ok := obj.(*corev1.ConfigMap)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					return
+				}
+
+				cfgMap, ok = tombstone.Obj.(*corev1.ConfigMap)
+				if !ok {
+					return
+				}
+			}
+
+			// an override ConfigMap removed from the cluster no longer changes the configuration
+			key := k8s.MetaNamespaceKey(cfgMap)
+			if !slices.Contains(store.configMaps, key) {
+				return
+			}
+
+			store.setConfig(store.configurationConfigMaps())
+			updateCh.In() <- Event{
+				Type: ConfigurationEvent,
+				Obj:  cfgMap,
+			}
+		},
 	}
 
 	serviceHandler := cache.ResourceEventHandlerFuncs{
@@ -868,17 +948,78 @@ func New(
 		klog.Errorf("Error adding service event handler: %v", err)
 	}
 
-	// do not wait for informers to read the configmap configuration
-	ns, name, err := k8s.ParseNameNS(configmap)
-	if err != nil {
-		klog.Errorf("unexpected error parsing name and ns: %v", err)
+	// the references allowed by the ReferenceGrants and the HTTPTransforms change
+	// the annotations of the Ingresses, so all of them must be synchronized again
+	handleAnnotationReferenceEvent := func(obj interface{}) {
+		for _, ingKey := range store.listers.IngressWithAnnotation.List() {
+			key := k8s.MetaNamespaceKey(ingKey)
+			ing, err := store.getIngress(key)
+			if err != nil {
+				klog.Errorf("could not find Ingress %v in local store: %v", key, err)
+				continue
+			}
+
+			store.updateSecretIngressMap(ing)
+			store.syncSecrets(ing)
+			store.syncIngress(ing)
+		}
+
+		updateCh.In() <- Event{
+			Type: ConfigurationEvent,
+			Obj:  obj,
+		}
 	}
-	cm, err := client.CoreV1().ConfigMaps(ns).Get(context.TODO(), name, metav1.GetOptions{})
-	if err != nil {
-		klog.Warningf("Unexpected error reading configuration configmap: %v", err)
+
+	annotationReferenceEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    handleAnnotationReferenceEvent,
+		DeleteFunc: handleAnnotationReferenceEvent,
+		UpdateFunc: func(old, cur interface{}) {
+			if reflect.DeepEqual(old, cur) {
+				return
+			}
+
+			handleAnnotationReferenceEvent(cur)
+		},
+	}
+
+	// ReferenceGrants are only watched when a dynamic client is configured
+	if dynamicClient != nil {
+		infFactoryDynamic := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicClient, resyncPeriod, namespace, nil)
+		store.informers.ReferenceGrant = infFactoryDynamic.ForResource(referencegrant.GroupVersionResource).Informer()
+		store.listers.ReferenceGrant = &referencegrant.Lister{Store: store.informers.ReferenceGrant.GetStore()}
+
+		if _, err := store.informers.ReferenceGrant.AddEventHandler(annotationReferenceEventHandler); err != nil {
+			klog.Errorf("Error adding reference grant event handler: %v", err)
+		}
+	}
+
+	// HTTPTransforms are only watched when a dynamic client is configured for them
+	if transformClient != nil {
+		infFactoryTransform := dynamicinformer.NewFilteredDynamicSharedInformerFactory(transformClient, resyncPeriod, namespace, nil)
+		store.informers.HTTPTransform = infFactoryTransform.ForResource(transform.GroupVersionResource).Informer()
+		store.listers.HTTPTransform = &transform.Lister{Store: store.informers.HTTPTransform.GetStore()}
+
+		if _, err := store.informers.HTTPTransform.AddEventHandler(annotationReferenceEventHandler); err != nil {
+			klog.Errorf("Error adding http transform event handler: %v", err)
+		}
+	}
+
+	// do not wait for informers to read the configmap configuration
+	cmaps := make([]*corev1.ConfigMap, 0, len(store.configMaps))
+	for _, configmap := range store.configMaps {
+		ns, name, err := k8s.ParseNameNS(configmap)
+		if err != nil {
+			klog.Errorf("unexpected error parsing name and ns: %v", err)
+		}
+		cm, err := client.CoreV1().ConfigMaps(ns).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			klog.Warningf("Unexpected error reading configuration configmap %v: %v", configmap, err)
+			cm = nil
+		}
+		cmaps = append(cmaps, cm)
 	}
 
-	store.setConfig(cm)
+	store.setConfig(cmaps)
 	return store
 }
 
@@ -944,6 +1085,9 @@ func (s *k8sStore) syncIngress(ing *networkingv1.Ingress) {
 	if parsed.Denied != nil {
 		s.recorder.Eventf(ing, corev1.EventTypeWarning, "AnnotationParsingFailed", fmt.Sprintf("Error parsing annotations: %v", *parsed.Denied))
 	}
+	if len(parsed.Deprecated) > 0 {
+		s.recorder.Eventf(ing, corev1.EventTypeWarning, "DeprecatedAnnotations", "Deprecated annotations: %v", joinUsages(parsed.Deprecated))
+	}
 	err = s.listers.IngressWithAnnotation.Update(&ingress.Ingress{
 		Ingress:           *copyIng,
 		ParsedAnnotations: parsed,
@@ -960,7 +1104,9 @@ func (s *k8sStore) updateSecretIngressMap(ing *networkingv1.Ingress) {
 	klog.V(3).Infof("updating references to secrets for ingress %v", key)
 
 	// delete all existing references first
+	previous := s.secretIngressMap.ReferencedBy(key)
 	s.secretIngressMap.Delete(key)
+	defer s.unregisterUnreferencedSecrets(previous)
 
 	var refSecrets []string
 
@@ -978,6 +1124,7 @@ func (s *k8sStore) updateSecretIngressMap(ing *networkingv1.Ingress) {
 	// references it would not trigger a resync of that secret.
 	secretAnnotations := []string{
 		"auth-secret",
+		"auth-ldap-secret",
 		"auth-tls-secret",
 		"proxy-ssl-secret",
 		"secure-verify-ca-secret",
@@ -985,45 +1132,62 @@ func (s *k8sStore) updateSecretIngressMap(ing *networkingv1.Ingress) {
 
 	secConfig := s.GetSecurityConfiguration().AllowCrossNamespaceResources
 	for _, ann := range secretAnnotations {
-		secrKey, err := objectRefAnnotationNsKey(ann, ing, secConfig)
+		secrKeys, err := objectRefAnnotationNsKeys(ann, ing, secConfig, s.IsReferenceGranted)
 		if err != nil && !errors.IsMissingAnnotations(err) {
 			klog.Errorf("error reading secret reference in annotation %q: %s", ann, err)
 			continue
 		}
-		if secrKey != "" {
-			refSecrets = append(refSecrets, secrKey)
-		}
+		refSecrets = append(refSecrets, secrKeys...)
 	}
 
 	// populate map with all secret references
 	s.secretIngressMap.Insert(key, refSecrets...)
 }
 
-// objectRefAnnotationNsKey returns an object reference formatted as a
-// 'namespace/name' key from the given annotation name.
-func objectRefAnnotationNsKey(ann string, ing *networkingv1.Ingress, allowCrossNamespace bool) (string, error) {
+// referenceGrantFunc returns true if the Ingresses of a namespace can reference an object of another namespace
+type referenceGrantFunc func(fromNamespace, kind, namespace, name string) bool
+
+// objectRefAnnotationNsKeys returns the comma separated object references
+// of the given annotation name formatted as 'namespace/name' keys.
+func objectRefAnnotationNsKeys(ann string, ing *networkingv1.Ingress, allowCrossNamespace bool, isGranted referenceGrantFunc) ([]string, error) {
 	// We pass nil fields, as this is an internal process and we don't need to validate it.
 	annValue, err := parser.GetStringAnnotation(ann, ing, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	secrNs, secrName, err := cache.SplitMetaNamespaceKey(annValue)
-	if secrName == "" {
-		return "", err
-	}
+	keys := []string{}
+	for _, ref := range strings.Split(annValue, ",") {
+		secrNs, secrName, err := cache.SplitMetaNamespaceKey(ref)
+		if secrName == "" {
+			return nil, err
+		}
 
-	if secrNs == "" {
-		return fmt.Sprintf("%v/%v", ing.Namespace, secrName), nil
-	}
-	if !allowCrossNamespace && secrNs != ing.Namespace {
-		return "", fmt.Errorf("cross namespace secret is not supported")
+		if secrNs == "" {
+			keys = append(keys, fmt.Sprintf("%v/%v", ing.Namespace, secrName))
+			continue
+		}
+		if !allowCrossNamespace && secrNs != ing.Namespace && !isGranted(ing.Namespace, referencegrant.KindSecret, secrNs, secrName) {
+			return nil, fmt.Errorf("cross namespace secret is not supported")
+		}
+		keys = append(keys, ref)
 	}
-	return annValue, nil
+
+	return keys, nil
 }
 
 // syncSecrets synchronizes data from all Secrets referenced by the given
 // Ingress with the local store and file system.
+// unregisterUnreferencedSecrets stops redacting the values of the Secrets
+// no longer referenced by an Ingress nor used as default certificate
+func (s *k8sStore) unregisterUnreferencedSecrets(keys []string) {
+	for _, key := range keys {
+		if len(s.secretIngressMap.Reference(key)) == 0 && !s.isDefaultCertificate(key) {
+			redact.Unregister(key)
+		}
+	}
+}
+
 func (s *k8sStore) syncSecrets(ing *networkingv1.Ingress) {
 	key := k8s.MetaNamespaceKey(ing)
 	for _, secrKey := range s.secretIngressMap.ReferencedBy(key) {
@@ -1080,6 +1244,11 @@ func (s *k8sStore) GetIngressClass(ing *networkingv1.Ingress, icConfig *ingressc
 }
 
 // getIngress returns the Ingress matching key.
+// GetIngress returns the Ingress matching key, with its parsed annotations.
+func (s *k8sStore) GetIngress(key string) (*ingress.Ingress, error) {
+	return s.listers.IngressWithAnnotation.ByKey(key)
+}
+
 func (s *k8sStore) getIngress(key string) (*networkingv1.Ingress, error) {
 	ing, err := s.listers.IngressWithAnnotation.ByKey(key)
 	if err != nil {
@@ -1156,8 +1325,8 @@ func (s *k8sStore) GetAuthCertificate(name string) (*resolver.AuthSSLCert, error
 	}, nil
 }
 
-func (s *k8sStore) writeSSLSessionTicketKey(cmap *corev1.ConfigMap, fileName string) {
-	ticketString := ngx_template.ReadConfig(cmap.Data).SSLSessionTicketKey
+func (s *k8sStore) writeSSLSessionTicketKey(data map[string]string, fileName string) {
+	ticketString := ngx_template.ReadConfig(data).SSLSessionTicketKey
 	s.backendConfig.SSLSessionTicketKey = ""
 
 	if ticketString != "" {
@@ -1184,6 +1353,31 @@ func (s *k8sStore) writeSSLSessionTicketKey(cmap *corev1.ConfigMap, fileName str
 	}
 }
 
+// IsReferenceGranted returns true if a ReferenceGrant allows the Ingresses of a namespace
+// to reference the object of the kind in another namespace
+func (s *k8sStore) IsReferenceGranted(fromNamespace, kind, namespace, name string) bool {
+	if s.listers.ReferenceGrant == nil {
+		return false
+	}
+
+	return s.listers.ReferenceGrant.Permits(fromNamespace, kind, namespace, name)
+}
+
+// GetHTTPTransform returns the HTTPTransform matching key.
+func (s *k8sStore) GetHTTPTransform(key string) (*transform.HTTPTransform, error) {
+	if s.listers.HTTPTransform == nil {
+		return nil, fmt.Errorf("HTTPTransforms are not watched, use the flag --watch-http-transforms")
+	}
+
+	return s.listers.HTTPTransform.ByKey(key)
+}
+
+// isDefaultCertificate returns if the Secret is used as the default certificate
+// or as the certificate of the catch-all server
+func (s *k8sStore) isDefaultCertificate(key string) bool {
+	return key == s.defaultSSLCertificate || key == s.GetBackendConfiguration().DefaultServerSSLCertificate
+}
+
 // GetDefaultBackend returns the default backend
 func (s *k8sStore) GetDefaultBackend() defaults.Backend {
 	return s.GetBackendConfiguration().Backend
@@ -1207,21 +1401,94 @@ func (s *k8sStore) GetSecurityConfiguration() defaults.SecurityConfiguration {
 	return secConfig
 }
 
-func (s *k8sStore) setConfig(cmap *corev1.ConfigMap) {
+// configurationConfigMaps returns the configuration ConfigMaps in the merge order,
+// nil for the ConfigMaps not found in the local store
+func (s *k8sStore) configurationConfigMaps() []*corev1.ConfigMap {
+	cmaps := make([]*corev1.ConfigMap, 0, len(s.configMaps))
+	for _, key := range s.configMaps {
+		cmap, err := s.GetConfigMap(key)
+		if err != nil {
+			klog.Warningf("Error reading ConfigMap %q from local store: %v", key, err)
+		}
+		cmaps = append(cmaps, cmap)
+	}
+
+	return cmaps
+}
+
+func (s *k8sStore) setConfig(cmaps []*corev1.ConfigMap) {
 	s.backendConfigMu.Lock()
 	defer s.backendConfigMu.Unlock()
 
-	if cmap == nil {
-		return
+	for _, cmap := range cmaps {
+		if cmap != nil {
+			redact.RegisterConfigMap(k8s.MetaNamespaceKey(cmap), cmap.Data)
+		}
 	}
 
-	s.backendConfig = ngx_template.ReadConfig(cmap.Data)
+	merged := mergeConfigMaps(cmaps)
+	s.backendConfig = ngx_template.ReadConfig(merged.data)
+	s.reportConfigMaps(merged)
+
 	if s.backendConfig.UseGeoIP2 && !nginx.GeoLite2DBExists() {
 		klog.Warning("The GeoIP2 feature is enabled but the databases are missing. Disabling")
 		s.backendConfig.UseGeoIP2 = false
 	}
 
-	s.writeSSLSessionTicketKey(cmap, "/etc/ingress-controller/tickets.key")
+	s.writeSSLSessionTicketKey(merged.data, nginx.SSLSessionTicketKeyPath)
+}
+
+// reportConfigMaps emits events on the configuration ConfigMaps with the keys
+// ignored or replacing the value of a previous ConfigMap
+func (s *k8sStore) reportConfigMaps(merged *mergedConfigMaps) {
+	if s.recorder == nil {
+		return
+	}
+
+	rejected := map[*corev1.ConfigMap][]string{}
+	for _, reason := range s.backendConfig.RejectedKeys {
+		key, _, _ := strings.Cut(reason, ":")
+		if cmap, ok := merged.source[key]; ok {
+			rejected[cmap] = append(rejected[cmap], reason)
+		}
+	}
+
+	for cmap, reasons := range rejected {
+		s.recorder.Eventf(cmap, corev1.EventTypeWarning, "InvalidConfiguration",
+			"Ignored invalid ConfigMap keys: %v", strings.Join(reasons, "; "))
+	}
+
+	deprecated := map[*corev1.ConfigMap][]deprecation.Usage{}
+	for _, u := range s.backendConfig.DeprecatedKeys {
+		if cmap, ok := merged.source[u.Name]; ok {
+			deprecated[cmap] = append(deprecated[cmap], u)
+		}
+	}
+
+	for cmap, usages := range deprecated {
+		s.recorder.Eventf(cmap, corev1.EventTypeWarning, "DeprecatedConfiguration",
+			"Deprecated ConfigMap keys: %v", joinUsages(usages))
+	}
+
+	for cmap, keys := range merged.denied {
+		s.recorder.Eventf(cmap, corev1.EventTypeWarning, "ConfigMapKeysNotAllowed",
+			"Ignored keys not allowed by the base ConfigMap: %v", strings.Join(keys, ", "))
+	}
+
+	for cmap, keys := range merged.conflicts {
+		s.recorder.Eventf(cmap, corev1.EventTypeNormal, "ConfigMapConflict",
+			"Keys replacing the value of a previous ConfigMap: %v", strings.Join(keys, ", "))
+	}
+}
+
+// joinUsages returns the description of the deprecated annotations or keys
+func joinUsages(usages []deprecation.Usage) string {
+	descs := make([]string, 0, len(usages))
+	for _, u := range usages {
+		descs = append(descs, u.String())
+	}
+
+	return strings.Join(descs, "; ")
 }
 
 // Run initiates the synchronization of the informers and the initial
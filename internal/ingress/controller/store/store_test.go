@@ -31,6 +31,7 @@ import (
 	networking "k8s.io/api/networking/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -38,6 +39,7 @@ import (
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/controller/ingressclass"
+	"k8s.io/ingress-nginx/internal/ingress/referencegrant"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
 	"k8s.io/ingress-nginx/test/e2e/framework"
 )
@@ -130,7 +132,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			DefaultClassConfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 
@@ -216,7 +220,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			DefaultClassConfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 		ic := createIngressClass(clientSet, t, "not-k8s.io/not-ingress-nginx")
@@ -324,7 +330,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			DefaultClassConfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 		validSpec := commonIngressSpec
@@ -444,7 +452,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			ingressClassconfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 
@@ -578,7 +588,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			ingressClassconfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 		validSpec := commonIngressSpec
@@ -682,7 +694,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			DefaultClassConfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 
@@ -780,7 +794,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			DefaultClassConfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 		invalidSpec := commonIngressSpec
@@ -870,7 +886,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			DefaultClassConfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 
@@ -970,7 +988,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			DefaultClassConfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 
@@ -1097,7 +1117,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			DefaultClassConfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 
@@ -1225,7 +1247,9 @@ func TestStore(t *testing.T) {
 			false,
 			true,
 			DefaultClassConfig,
-			false)
+			false,
+			nil,
+			nil)
 
 		storer.Run(stopCh)
 
@@ -1473,6 +1497,51 @@ func TestUpdateSecretIngressMap(t *testing.T) {
 		}
 	})
 
+	t.Run("with annotation in namespace/name format allowed by a ReferenceGrant", func(t *testing.T) {
+		grants := cache.NewStore(cache.MetaNamespaceKeyFunc)
+		if err := grants.Add(&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1beta1",
+			"kind":       "ReferenceGrant",
+			"metadata":   map[string]interface{}{"name": "auth", "namespace": "anotherns"},
+			"spec": map[string]interface{}{
+				"from": []interface{}{map[string]interface{}{"group": "networking.k8s.io", "kind": "Ingress", "namespace": "testns"}},
+				"to":   []interface{}{map[string]interface{}{"group": "", "kind": "Secret"}},
+			},
+		}}); err != nil {
+			t.Errorf("error adding the ReferenceGrant: %v", err)
+		}
+		s.listers.ReferenceGrant = &referencegrant.Lister{Store: grants}
+		defer func() { s.listers.ReferenceGrant = nil }()
+
+		ing := ingTpl.DeepCopy()
+		ing.ObjectMeta.SetAnnotations(map[string]string{
+			parser.GetAnnotationWithPrefix("auth-secret"): "anotherns/auth",
+		})
+		if err := s.listers.Ingress.Update(ing); err != nil {
+			t.Errorf("error updating the Ingress: %v", err)
+		}
+		s.updateSecretIngressMap(ing)
+
+		if l := s.secretIngressMap.Len(); !(l == 1 && s.secretIngressMap.Has("anotherns/auth")) {
+			t.Errorf("Expected \"anotherns/auth\" to be the only referenced Secret (got %d)", l)
+		}
+	})
+
+	t.Run("with annotation with a list of secrets", func(t *testing.T) {
+		ing := ingTpl.DeepCopy()
+		ing.ObjectMeta.SetAnnotations(map[string]string{
+			parser.GetAnnotationWithPrefix("auth-secret"): "auth,testns/other-auth",
+		})
+		if err := s.listers.Ingress.Update(ing); err != nil {
+			t.Errorf("error updating the Ingress: %v", err)
+		}
+		s.updateSecretIngressMap(ing)
+
+		if l := s.secretIngressMap.Len(); !(l == 2 && s.secretIngressMap.Has("testns/auth") && s.secretIngressMap.Has("testns/other-auth")) {
+			t.Errorf("Expected \"testns/auth\" and \"testns/other-auth\" to be the referenced Secrets (got %d)", l)
+		}
+	})
+
 	t.Run("with annotation in invalid format", func(t *testing.T) {
 		ing := ingTpl.DeepCopy()
 		ing.ObjectMeta.SetAnnotations(map[string]string{
@@ -1633,7 +1702,7 @@ func TestWriteSSLSessionTicketKey(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		s.writeSSLSessionTicketKey(cmap, f.Name())
+		s.writeSSLSessionTicketKey(cmap.Data, f.Name())
 
 		content, err := os.ReadFile(f.Name())
 		if err != nil {
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+const (
+	streamAccessLogOption      = "access-log"
+	streamLogFormatOption      = "log-format"
+	streamProxyResponsesOption = "proxy-responses"
+	streamProxyTimeoutOption   = "proxy-timeout"
+	streamHashByOption         = "hash-by"
+)
+
+var (
+	// streamAccessLogPathRegex matches files and syslog destinations
+	streamAccessLogPathRegex = regexp.MustCompile(`^(/|syslog:)[^\s'"{};]+$`)
+	// streamTimeoutRegex matches NGINX time values
+	streamTimeoutRegex = regexp.MustCompile(`^[0-9]+(ms|s|m|h|d)?$`)
+	// streamHashByRegex matches one or more NGINX variables
+	streamHashByRegex = regexp.MustCompile(`^(\$[A-Za-z0-9_]+)+$`)
+)
+
+// streamOptions contains the settings of a TCP or UDP service overriding
+// the global ones
+type streamOptions struct {
+	accessLog ingress.L4AccessLog
+	proxy     ingress.L4Proxy
+}
+
+// parseStreamOptions parses the options following the service reference of
+// a TCP or UDP service, like "access-log=off" or "proxy-timeout=10s".
+// Invalid options are ignored, keeping the global settings.
+func parseStreamOptions(options string, proto apiv1.Protocol) streamOptions {
+	opts := streamOptions{}
+	if options == "" {
+		return opts
+	}
+
+	for _, option := range strings.Split(options, ";") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		if err := opts.set(option, proto); err != nil {
+			klog.Warningf("Ignoring %v service option %q: %v", proto, option, err)
+		}
+	}
+
+	return opts
+}
+
+func (opts *streamOptions) set(option string, proto apiv1.Protocol) error {
+	name, value, found := strings.Cut(option, "=")
+	if !found || value == "" {
+		return fmt.Errorf("expected a name=value option")
+	}
+
+	name = strings.TrimSpace(name)
+	if name != streamLogFormatOption {
+		value = strings.TrimSpace(value)
+	}
+
+	switch name {
+	case streamAccessLogOption:
+		if value == "off" {
+			opts.accessLog.Disabled = true
+			return nil
+		}
+		if !streamAccessLogPathRegex.MatchString(value) {
+			return fmt.Errorf("invalid access log destination")
+		}
+		opts.accessLog.Path = value
+	case streamLogFormatOption:
+		if strings.ContainsAny(value, "'\\\n\r") {
+			return fmt.Errorf("the log format cannot contain quotes, backslashes or new lines")
+		}
+		opts.accessLog.Format = value
+	case streamProxyResponsesOption:
+		if proto != apiv1.ProtocolUDP {
+			return fmt.Errorf("only UDP services expect a number of responses")
+		}
+		if responses, err := strconv.Atoi(value); err != nil || responses < 0 {
+			return fmt.Errorf("expected a number of datagrams")
+		}
+		opts.proxy.Responses = value
+	case streamProxyTimeoutOption:
+		if !streamTimeoutRegex.MatchString(value) {
+			return fmt.Errorf("invalid timeout")
+		}
+		opts.proxy.Timeout = value
+	case streamHashByOption:
+		if !streamHashByRegex.MatchString(value) {
+			return fmt.Errorf("expected NGINX variables like $remote_addr")
+		}
+		opts.proxy.HashBy = value
+	default:
+		return fmt.Errorf("unknown option")
+	}
+
+	return nil
+}
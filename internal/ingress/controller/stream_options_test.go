@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func TestParseStreamAccessLog(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  string
+		expected ingress.L4AccessLog
+	}{
+		{"without options", "", ingress.L4AccessLog{}},
+		{"disabled", "access-log=off", ingress.L4AccessLog{Disabled: true}},
+		{"file", "access-log=/var/log/nginx/db.log", ingress.L4AccessLog{Path: "/var/log/nginx/db.log"}},
+		{"syslog", "access-log=syslog:server=10.0.0.1:514,tag=db", ingress.L4AccessLog{Path: "syslog:server=10.0.0.1:514,tag=db"}},
+		{
+			"path and format", "access-log=/dev/stdout; log-format=$remote_addr [$time_local] $status",
+			ingress.L4AccessLog{Path: "/dev/stdout", Format: "$remote_addr [$time_local] $status"},
+		},
+		{"relative path", "access-log=db.log", ingress.L4AccessLog{}},
+		{"path with spaces", "access-log=/var/log/db.log buffer=32k", ingress.L4AccessLog{}},
+		{"format with quotes", "log-format=$remote_addr ' $status", ingress.L4AccessLog{}},
+		{"unknown option", "error-log=off", ingress.L4AccessLog{}},
+		{"option without value", "access-log", ingress.L4AccessLog{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if opts := parseStreamOptions(tc.options, apiv1.ProtocolTCP); opts.accessLog != tc.expected {
+				t.Errorf("expected %+v but got %+v", tc.expected, opts.accessLog)
+			}
+		})
+	}
+}
+
+func TestParseStreamProxyOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  string
+		proto    apiv1.Protocol
+		expected ingress.L4Proxy
+	}{
+		{"without options", "", apiv1.ProtocolUDP, ingress.L4Proxy{}},
+		{
+			"udp options", "proxy-responses=0;proxy-timeout=30s;hash-by=$remote_addr", apiv1.ProtocolUDP,
+			ingress.L4Proxy{Responses: "0", Timeout: "30s", HashBy: "$remote_addr"},
+		},
+		{"tcp timeout", "proxy-timeout=5m", apiv1.ProtocolTCP, ingress.L4Proxy{Timeout: "5m"}},
+		{"tcp responses", "proxy-responses=1", apiv1.ProtocolTCP, ingress.L4Proxy{}},
+		{"negative responses", "proxy-responses=-1", apiv1.ProtocolUDP, ingress.L4Proxy{}},
+		{"invalid timeout", "proxy-timeout=10 s", apiv1.ProtocolUDP, ingress.L4Proxy{}},
+		{"multiple variables", "hash-by=$remote_addr$remote_port", apiv1.ProtocolUDP, ingress.L4Proxy{HashBy: "$remote_addr$remote_port"}},
+		{"hash by text", "hash-by=client", apiv1.ProtocolUDP, ingress.L4Proxy{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if opts := parseStreamOptions(tc.options, tc.proto); opts.proxy != tc.expected {
+				t.Errorf("expected %+v but got %+v", tc.expected, opts.proxy)
+			}
+		})
+	}
+}
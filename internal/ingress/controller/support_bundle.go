@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/ingress-nginx/internal/ingress/supportbundle"
+	"k8s.io/ingress-nginx/internal/nginx"
+	"k8s.io/ingress-nginx/version"
+)
+
+// reloadHistorySize is the number of reloads included in the support bundle
+const reloadHistorySize = 20
+
+// recordReload adds the result of a reload started at the given time to the history
+func (n *NGINXController) recordReload(start time.Time, checksum string, err error) {
+	r := supportbundle.Reload{
+		Time:     start,
+		Duration: time.Since(start).Seconds(),
+		Checksum: checksum,
+		Success:  err == nil,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	n.reloads.Add(r)
+}
+
+// SupportBundle returns the state of the controller and NGINX.
+// The parts that can not be collected are listed in the errors of the bundle.
+func (n *NGINXController) SupportBundle() *supportbundle.Bundle {
+	b := &supportbundle.Bundle{
+		CreatedAt: time.Now(),
+		Version: supportbundle.Version{
+			Release:    version.RELEASE,
+			Build:      version.COMMIT,
+			Repository: version.REPO,
+			NGINX:      nginx.Version(),
+		},
+		Flags:   make(map[string]string, len(n.cfg.CommandLineFlags)),
+		Reloads: n.reloads.List(),
+	}
+
+	for k, v := range n.cfg.CommandLineFlags {
+		b.Flags[k] = v
+	}
+
+	// the configuration ConfigMaps are merged in order
+	for _, name := range strings.Split(n.cfg.ConfigMapName, ",") {
+		if name == "" {
+			continue
+		}
+
+		cm, err := n.store.GetConfigMap(name)
+		if err != nil {
+			b.Errors = append(b.Errors, fmt.Sprintf("reading ConfigMap %v: %v", name, err))
+			continue
+		}
+
+		if b.ConfigMap == nil {
+			b.ConfigMap = make(map[string]string, len(cm.Data))
+		}
+		for k, v := range cm.Data {
+			b.ConfigMap[k] = v
+		}
+	}
+
+	conf, err := os.ReadFile(nginx.ConfPath)
+	if err != nil {
+		b.Errors = append(b.Errors, fmt.Sprintf("reading %v: %v", nginx.ConfPath, err))
+	}
+	b.NginxConf = string(conf)
+
+	status, data, err := nginx.NewGetStatusRequest("/configuration/backends")
+	switch {
+	case err != nil:
+		b.Errors = append(b.Errors, fmt.Sprintf("reading dynamic backends: %v", err))
+	case status != http.StatusOK:
+		b.Errors = append(b.Errors, fmt.Sprintf("reading dynamic backends: unexpected status code %v", status))
+	default:
+		if err := json.Unmarshal(data, &b.Backends); err != nil {
+			b.Errors = append(b.Errors, fmt.Sprintf("decoding dynamic backends: %v", err))
+		}
+	}
+
+	return b
+}
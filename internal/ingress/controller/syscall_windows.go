@@ -0,0 +1,44 @@
+//go:build windows
+// +build windows
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+)
+
+// rlimitMaxNumFiles returns 0 as Windows has no RLIMIT_NOFILE,
+// the minimum number of open files of the workers is used
+func rlimitMaxNumFiles() int {
+	return 0
+}
+
+// setProcessGroup starts the command in a new process group
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP,
+	}
+}
+
+// statFilesystem is not supported on Windows, where NGINX does not run
+func statFilesystem(_ string) (used, total uint64, err error) {
+	return 0, 0, errors.New("filesystem usage is not supported on Windows")
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -30,22 +31,32 @@ import (
 	"github.com/mitchellh/mapstructure"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/allowedmethods"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/authreq"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/customheaders"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/ingress/deprecation"
+	"k8s.io/ingress-nginx/internal/ingress/tlsredirect"
 	ing_net "k8s.io/ingress-nginx/internal/net"
+	"k8s.io/ingress-nginx/internal/nginx"
 	"k8s.io/ingress-nginx/pkg/util/runtime"
 )
 
 const (
 	customHTTPErrors              = "custom-http-errors"
 	skipAccessLogUrls             = "skip-access-log-urls"
-	whitelistSourceRange          = "whitelist-source-range"
+	allowlistSourceRange          = "allowlist-source-range"
 	denylistSourceRange           = "denylist-source-range"
 	proxyRealIPCIDR               = "proxy-real-ip-cidr"
 	bindAddress                   = "bind-address"
+	bindInterface                 = "bind-interface"
+	ipv4Listeners                 = "ipv4-listeners"
+	ipv6Listeners                 = "ipv6-listeners"
+	allowedExtraListenPorts       = "allowed-extra-listen-ports"
 	httpRedirectCode              = "http-redirect-code"
+	allowedHTTPMethods            = "allowed-http-methods"
+	allowedHTTPMethodsStatusCode  = "allowed-http-methods-status-code"
 	blockCIDRs                    = "block-cidrs"
 	blockUserAgents               = "block-user-agents"
 	blockReferers                 = "block-referers"
@@ -55,6 +66,7 @@ const (
 	nginxStatusIpv6Whitelist      = "nginx-status-ipv6-whitelist"
 	proxyHeaderTimeout            = "proxy-protocol-header-timeout"
 	workerProcesses               = "worker-processes"
+	workerCPUAffinity             = "worker-cpu-affinity"
 	globalAllowedResponseHeaders  = "global-allowed-response-headers"
 	globalAuthURL                 = "global-auth-url"
 	globalAuthMethod              = "global-auth-method"
@@ -69,12 +81,31 @@ const (
 	luaSharedDictsKey             = "lua-shared-dicts"
 	debugConnections              = "debug-connections"
 	workerSerialReloads           = "enable-serial-reloads"
+	autoTune                      = "auto-tune"
+	sslSessionCacheSize           = "ssl-session-cache-size"
+	sslProtocols                  = "ssl-protocols"
+	sslCiphers                    = "ssl-ciphers"
+	sslECDHCurve                  = "ssl-ecdh-curve"
+	rejectAmbiguousRequestLength  = "reject-ambiguous-request-length"
+	mergeSlashes                  = "merge-slashes"
+	uriDecodingPolicy             = "uri-decoding-policy"
+	absoluteURIPolicy             = "absolute-uri-policy"
+	defaultServerReturnCode       = "default-server-return-code"
+	defaultServerRedirect         = "default-server-redirect"
+	forwardedHeaderPolicy         = "forwarded-header-policy"
+	forwardedHeaderBy             = "forwarded-header-by"
+	trustedProxies                = "trusted-proxies"
+	forwardedForHops              = "forwarded-for-hops"
+	forwardedForHeader            = "forwarded-for-header"
 )
 
 var (
-	validRedirectCodes    = sets.NewInt([]int{301, 302, 307, 308}...)
-	dictSizeRegex         = regexp.MustCompile(`^(\d+)([kKmM])?$`)
-	defaultLuaSharedDicts = map[string]int{
+	validRedirectCodes      = sets.NewInt([]int{301, 302, 307, 308}...)
+	validDefaultServerCodes = sets.NewInt([]int{404, 421, 444}...)
+	// obfuscated identifiers of the Forwarded header (RFC 7239, section 6.3)
+	forwardedObfuscatedRegex = regexp.MustCompile(`^_[a-zA-Z0-9._-]+$`)
+	dictSizeRegex            = regexp.MustCompile(`^(\d+)([kKmM])?$`)
+	defaultLuaSharedDicts    = map[string]int{
 		"configuration_data":            20480,
 		"certificate_data":              20480,
 		"balancer_ewma":                 10240,
@@ -82,8 +113,22 @@ var (
 		"balancer_ewma_locks":           1024,
 		"certificate_servers":           5120,
 		"ocsp_response_cache":           5120, // keep this same as certificate_servers
+		"upload_guard":                  1024,
+		"anomaly_scores":                1024,
 	}
 	defaultGlobalAuthRedirectParam = "rd"
+
+	// memoryLimit returns the memory limit of the pod used to size the
+	// shared memory zones when auto-tune is enabled
+	memoryLimit = runtime.MemoryLimit
+
+	// tlsLibrary returns the TLS library NGINX is linked with, checked
+	// before applying the protocols and ciphers of a ssl-policy-preset
+	tlsLibrary = nginx.GetTLSLibrary
+
+	// cpuTopology returns the CPUs usable by NGINX, used to adjust
+	// the defaults that assume homogeneous x86 CPUs
+	cpuTopology = runtime.GetCPUTopology
 )
 
 const (
@@ -101,16 +146,21 @@ func ReadConfig(src map[string]string) config.Configuration {
 		conf[k] = v
 	}
 
+	// the deprecated keys are renamed to their replacement
+	conf, deprecatedKeys := deprecation.TranslateConfigMap(conf)
+
 	to := config.NewDefault()
 	errors := make([]int, 0)
 	skipUrls := make([]string, 0)
 	denyList := make([]string, 0)
 	whiteList := make([]string, 0)
+	methods := make([]string, 0)
 	proxyList := make([]string, 0)
 	hideHeadersList := make([]string, 0)
 
 	bindAddressIpv4List := make([]string, 0)
 	bindAddressIpv6List := make([]string, 0)
+	extraListenPortsList := make([]int, 0)
 
 	blockCIDRList := make([]string, 0)
 	blockUserAgentList := make([]string, 0)
@@ -149,10 +199,16 @@ func ReadConfig(src map[string]string) config.Configuration {
 			luaSharedDicts[dictName] = size
 		}
 	}
+	// size the default shared memory zones from the memory limit when auto-tune is enabled
+	sharedMemoryScale := 1
+	if val, err := strconv.ParseBool(conf[autoTune]); err == nil && val {
+		sharedMemoryScale = runtime.SharedMemoryScale(memoryLimit())
+	}
+
 	// set default Lua shared dicts
 	for k, v := range defaultLuaSharedDicts {
 		if _, ok := luaSharedDicts[k]; !ok {
-			luaSharedDicts[k] = v
+			luaSharedDicts[k] = v * sharedMemoryScale
 		}
 	}
 
@@ -183,8 +239,8 @@ func ReadConfig(src map[string]string) config.Configuration {
 		denyList = append(denyList, splitAndTrimSpace(val, ",")...)
 	}
 
-	if val, ok := conf[whitelistSourceRange]; ok {
-		delete(conf, whitelistSourceRange)
+	if val, ok := conf[allowlistSourceRange]; ok {
+		delete(conf, allowlistSourceRange)
 		whiteList = append(whiteList, splitAndTrimSpace(val, ",")...)
 	}
 
@@ -195,6 +251,35 @@ func ReadConfig(src map[string]string) config.Configuration {
 		proxyList = append(proxyList, "0.0.0.0/0")
 	}
 
+	if val, ok := conf[trustedProxies]; ok {
+		delete(conf, trustedProxies)
+		trustedProxyList := make([]string, 0)
+		for _, i := range splitAndTrimSpace(val, ",") {
+			if net.ParseIP(i) == nil {
+				if _, _, err := net.ParseCIDR(i); err != nil {
+					klog.Warningf("%v is not a valid IP or CIDR address. Ignoring it.", i)
+					continue
+				}
+			}
+			trustedProxyList = append(trustedProxyList, i)
+		}
+		// the trusted proxies replace the addresses of proxy-real-ip-cidr
+		if len(trustedProxyList) > 0 {
+			to.TrustedProxies = trustedProxyList
+			proxyList = trustedProxyList
+		}
+	}
+
+	if val, ok := conf[forwardedForHops]; ok {
+		delete(conf, forwardedForHops)
+		j, err := strconv.Atoi(val)
+		if err != nil || j < 0 {
+			klog.Warningf("%v is not a valid number of forwarded-for hops. Ignoring it.", val)
+		} else {
+			to.ForwardedForHops = j
+		}
+	}
+
 	if val, ok := conf[bindAddress]; ok {
 		delete(conf, bindAddress)
 		for _, i := range splitAndTrimSpace(val, ",") {
@@ -211,6 +296,52 @@ func ReadConfig(src map[string]string) config.Configuration {
 		}
 	}
 
+	if val, ok := conf[bindInterface]; ok {
+		delete(conf, bindInterface)
+		for _, i := range splitAndTrimSpace(val, ",") {
+			ips, err := interfaceAddresses(i)
+			if err != nil {
+				klog.Warningf("Error obtaining the addresses of interface %v: %v", i, err)
+				continue
+			}
+
+			for _, ip := range ips {
+				if ing_net.IsIPV6(ip) {
+					address := fmt.Sprintf("[%v]", ip)
+					if !slices.Contains(bindAddressIpv6List, address) {
+						bindAddressIpv6List = append(bindAddressIpv6List, address)
+					}
+				} else if !slices.Contains(bindAddressIpv4List, ip.String()) {
+					bindAddressIpv4List = append(bindAddressIpv4List, ip.String())
+				}
+			}
+		}
+	}
+
+	if val, ok := conf[ipv4Listeners]; ok {
+		delete(conf, ipv4Listeners)
+		to.IPv4Listeners = parseListeners(val)
+	}
+
+	if val, ok := conf[ipv6Listeners]; ok {
+		delete(conf, ipv6Listeners)
+		to.IPv6Listeners = parseListeners(val)
+	}
+
+	to.IPv4Listeners, to.IPv6Listeners = validateListeners(to.IPv4Listeners, to.IPv6Listeners)
+
+	if val, ok := conf[allowedExtraListenPorts]; ok {
+		delete(conf, allowedExtraListenPorts)
+		for _, i := range splitAndTrimSpace(val, ",") {
+			port, err := strconv.Atoi(i)
+			if err != nil || port < 1 || port > 65535 {
+				klog.Warningf("%v is not a valid port number", i)
+				continue
+			}
+			extraListenPortsList = append(extraListenPortsList, port)
+		}
+	}
+
 	if val, ok := conf[blockCIDRs]; ok {
 		delete(conf, blockCIDRs)
 		blockCIDRList = splitAndTrimSpace(val, ",")
@@ -240,6 +371,68 @@ func ReadConfig(src map[string]string) config.Configuration {
 		}
 	}
 
+	if val, ok := conf[defaultServerReturnCode]; ok {
+		delete(conf, defaultServerReturnCode)
+		j, err := strconv.Atoi(val)
+		if err != nil || !validDefaultServerCodes.Has(j) {
+			klog.Warningf("%v is not a valid code for the default server. Using the default backend.", val)
+		} else {
+			to.DefaultServerReturnCode = j
+		}
+	}
+
+	if val, ok := conf[defaultServerRedirect]; ok {
+		delete(conf, defaultServerRedirect)
+		redirectURL, err := parser.StringToURL(val)
+		if err != nil || (redirectURL.Scheme != "http" && redirectURL.Scheme != "https") {
+			klog.Warningf("%v is not a valid URL for the default server redirect. Ignoring it.", val)
+		} else {
+			to.DefaultServerRedirect = val
+		}
+	}
+
+	if val, ok := conf[forwardedHeaderPolicy]; ok {
+		delete(conf, forwardedHeaderPolicy)
+		switch val {
+		case config.ForwardedHeaderOff, config.ForwardedHeaderSet, config.ForwardedHeaderAppend:
+			to.ForwardedHeaderPolicy = val
+		default:
+			klog.Warningf("%v is not a valid Forwarded header policy. Using the default.", val)
+		}
+	}
+
+	if val, ok := conf[forwardedHeaderBy]; ok {
+		delete(conf, forwardedHeaderBy)
+		if forwardedObfuscatedRegex.MatchString(val) {
+			to.ForwardedHeaderBy = val
+		} else {
+			klog.Warningf("%v is not a valid obfuscated identifier for the Forwarded header. Ignoring it.", val)
+		}
+	}
+
+	if val, ok := conf[allowedHTTPMethods]; ok {
+		delete(conf, allowedHTTPMethods)
+		for _, method := range splitAndTrimSpace(val, ",") {
+			if !allowedmethods.ValidMethod(method) {
+				klog.Warningf("%v is not a valid HTTP method. Ignoring it.", method)
+				continue
+			}
+			if !slices.Contains(methods, method) {
+				methods = append(methods, method)
+			}
+		}
+	}
+
+	if val, ok := conf[allowedHTTPMethodsStatusCode]; ok {
+		delete(conf, allowedHTTPMethodsStatusCode)
+		j, err := strconv.Atoi(val)
+		if err != nil || j < 400 || j > 499 {
+			klog.Warningf("%v is not a valid HTTP client error code. Using the default.", val)
+		} else {
+			to.AllowedHTTPMethodsStatusCode = j
+		}
+	}
+
 	// Verify that the configured global external authorization URL is parsable as URL. if not, set the default value
 	if val, ok := conf[globalAuthURL]; ok {
 		delete(conf, globalAuthURL)
@@ -438,10 +631,12 @@ func ReadConfig(src map[string]string) config.Configuration {
 	to.CustomHTTPErrors = filterErrors(errors)
 	to.SkipAccessLogURLs = skipUrls
 	to.DenylistSourceRange = denyList
+	to.AllowedHTTPMethods = methods
 	to.WhitelistSourceRange = whiteList
 	to.ProxyRealIPCIDR = proxyList
 	to.BindAddressIpv4 = bindAddressIpv4List
 	to.BindAddressIpv6 = bindAddressIpv6List
+	to.AllowedExtraListenPorts = extraListenPortsList
 	to.BlockCIDRs = blockCIDRList
 	to.BlockUserAgents = blockUserAgentList
 	to.BlockReferers = blockRefererList
@@ -451,6 +646,13 @@ func ReadConfig(src map[string]string) config.Configuration {
 	to.LuaSharedDicts = luaSharedDicts
 	to.Backend.AllowedResponseHeaders = allowedResponseHeaders
 
+	rejectedKeys := rejectInvalidKeys(conf)
+	for _, key := range rejectedKeys {
+		klog.Warningf("Ignoring ConfigMap key %v", key)
+	}
+	to.RejectedKeys = rejectedKeys
+	to.DeprecatedKeys = deprecatedKeys
+
 	decoderConfig := &mapstructure.DecoderConfig{
 		Metadata:         nil,
 		WeaklyTypedInput: true,
@@ -467,6 +669,51 @@ func ReadConfig(src map[string]string) config.Configuration {
 		klog.Warningf("unexpected error merging defaults: %v", err)
 	}
 
+	switch to.IngressConflictPolicy {
+	case config.IngressConflictPolicyOldestWins, config.IngressConflictPolicyNewestWins, config.IngressConflictPolicyPriority:
+	default:
+		klog.Warningf("ingress-conflict-policy %q is not valid, valid values are oldest-wins, newest-wins and priority. Ignoring", to.IngressConflictPolicy)
+		to.IngressConflictPolicy = config.IngressConflictPolicyOldestWins
+	}
+
+	if to.SSLPolicyPreset != "" {
+		applySSLPolicyPreset(&to, src)
+	}
+
+	if to.RequestNormalization != "" {
+		applyRequestNormalization(&to, src)
+	}
+
+	if to.CDNProvider != "" {
+		applyCDNProvider(&to, src)
+	}
+
+	// validated with the schema, the default is valid
+	to.NoTLSRedirectExceptions, err = tlsredirect.Parse(to.NoTLSRedirectLocations)
+	if err != nil {
+		klog.Warningf("unexpected error parsing no-tls-redirect-locations: %v", err)
+	}
+
+	if to.URIDecodingPolicy != config.URIDecodingPermissive && to.URIDecodingPolicy != config.URIDecodingStrict {
+		klog.Warningf("uri-decoding-policy %q is not valid, valid values are permissive and strict. Ignoring", to.URIDecodingPolicy)
+		to.URIDecodingPolicy = config.URIDecodingPermissive
+	}
+
+	switch to.AbsoluteURIPolicy {
+	case config.AbsoluteURIAllow, config.AbsoluteURIMatchHost, config.AbsoluteURIReject:
+	default:
+		klog.Warningf("absolute-uri-policy %q is not valid, valid values are allow, match-host and reject. Ignoring", to.AbsoluteURIPolicy)
+		to.AbsoluteURIPolicy = config.AbsoluteURIAllow
+	}
+
+	applyCPUTopology(&to, src, cpuTopology(), sharedMemoryScale > 1)
+
+	if _, ok := src[sslSessionCacheSize]; !ok && sharedMemoryScale > 1 {
+		if size := dictStrToKb(to.SSLSessionCacheSize); size > 0 {
+			to.SSLSessionCacheSize = dictKbToStr(size * sharedMemoryScale)
+		}
+	}
+
 	hash, err := hashstructure.Hash(to, hashstructure.FormatV1, &hashstructure.HashOptions{
 		TagName: "json",
 	})
@@ -479,6 +726,130 @@ func ReadConfig(src map[string]string) config.Configuration {
 	return to
 }
 
+// applySSLPolicyPreset sets the protocols, ciphers and curves of the ssl-policy-preset
+// not defined in the ConfigMap. The preset is ignored when it is not valid or the TLS
+// library NGINX is linked with does not support it.
+func applySSLPolicyPreset(to *config.Configuration, src map[string]string) {
+	policy, ok := config.SSLPolicyPresets[to.SSLPolicyPreset]
+	if !ok {
+		klog.Warningf("ssl-policy-preset %q is not valid, valid values are modern, intermediate, old and fips. Ignoring", to.SSLPolicyPreset)
+		to.SSLPolicyPreset = ""
+		return
+	}
+
+	if err := checkSSLPolicy(policy, tlsLibrary()); err != nil {
+		klog.Warningf("ssl-policy-preset %q is not supported by NGINX: %v. Ignoring", to.SSLPolicyPreset, err)
+		to.SSLPolicyPreset = ""
+		return
+	}
+
+	if _, ok := src[sslProtocols]; !ok {
+		to.SSLProtocols = policy.Protocols
+	}
+	if _, ok := src[sslCiphers]; !ok {
+		to.SSLCiphers = policy.Ciphers
+	}
+	if _, ok := src[sslECDHCurve]; !ok {
+		to.SSLECDHCurve = policy.ECDHCurve
+	}
+}
+
+// applyCPUTopology adjusts the defaults not defined in the ConfigMap to the CPUs. On
+// heterogeneous CPUs, like big.LITTLE ARM servers, the workers are bound to the fastest
+// cluster when it has a CPU for each of them. Without AES acceleration, ChaCha20-Poly1305
+// is preferred over AES-GCM as it is faster in software.
+func applyCPUTopology(to *config.Configuration, src map[string]string, topology runtime.CPUTopology, autoTune bool) {
+	if _, ok := src[workerCPUAffinity]; !ok && topology.Heterogeneous() {
+		workers, err := strconv.Atoi(to.WorkerProcesses)
+		if autoTune {
+			// the tuner runs a worker for each CPU of the limit
+			workers, err = runtime.NumCPU(), nil
+		}
+
+		fastest := topology.Clusters[0]
+		if err == nil && workers <= len(fastest.CPUs) {
+			to.WorkerCPUAffinity = "auto " + fastest.AffinityMask()
+		}
+	}
+
+	if _, ok := src[sslCiphers]; !ok && to.SSLPolicyPreset == "" && !topology.AES {
+		to.SSLCiphers = preferChaCha20(to.SSLCiphers)
+	}
+}
+
+// preferChaCha20 moves the ChaCha20-Poly1305 ciphers to the beginning of the list
+func preferChaCha20(ciphers string) string {
+	var chacha, others []string
+	for _, cipher := range strings.Split(ciphers, ":") {
+		if strings.Contains(cipher, "CHACHA20") {
+			chacha = append(chacha, cipher)
+			continue
+		}
+
+		others = append(others, cipher)
+	}
+
+	return strings.Join(append(chacha, others...), ":")
+}
+
+// applyCDNProvider enables the real IP module with the client address header
+// of the cdn-provider. Unless defined in the ConfigMap, only the IP ranges of
+// the provider are trusted, as they are added when the configuration is rendered.
+func applyCDNProvider(to *config.Configuration, src map[string]string) {
+	provider, ok := config.CDNProviders[to.CDNProvider]
+	if !ok {
+		klog.Warningf("cdn-provider %q is not valid, valid values are cloudflare, fastly, cloudfront and akamai. Ignoring", to.CDNProvider)
+		to.CDNProvider = ""
+		return
+	}
+
+	to.EnableRealIP = true
+	if _, ok := src[forwardedForHeader]; !ok {
+		to.ForwardedForHeader = provider.Header
+	}
+	if _, ok := src[proxyRealIPCIDR]; !ok && len(to.TrustedProxies) == 0 {
+		to.ProxyRealIPCIDR = []string{}
+	}
+}
+
+// applyRequestNormalization sets the request normalization options of the
+// request-normalization profile not defined in the ConfigMap
+func applyRequestNormalization(to *config.Configuration, src map[string]string) {
+	profile, ok := config.RequestNormalizationProfiles[to.RequestNormalization]
+	if !ok {
+		klog.Warningf("request-normalization %q is not valid, valid values are default and strict. Ignoring", to.RequestNormalization)
+		to.RequestNormalization = ""
+		return
+	}
+
+	if _, ok := src[rejectAmbiguousRequestLength]; !ok {
+		to.RejectAmbiguousRequestLength = profile.RejectAmbiguousRequestLength
+	}
+	if _, ok := src[mergeSlashes]; !ok {
+		to.MergeSlashes = profile.MergeSlashes
+	}
+	if _, ok := src[uriDecodingPolicy]; !ok {
+		to.URIDecodingPolicy = profile.URIDecodingPolicy
+	}
+	if _, ok := src[absoluteURIPolicy]; !ok {
+		to.AbsoluteURIPolicy = profile.AbsoluteURIPolicy
+	}
+}
+
+// checkSSLPolicy returns an error if the TLS library does not support the policy
+func checkSSLPolicy(policy config.SSLPolicy, lib nginx.TLSLibrary) error {
+	if policy.FIPS && !lib.FIPS {
+		return fmt.Errorf("%v %v does not provide a FIPS validated module", lib.Name, lib.Version)
+	}
+
+	// OpenSSL supports TLS 1.3 since 1.1.1
+	if policy.Protocols == "TLSv1.3" && lib.Name == "OpenSSL" && !lib.AtLeast(1, 1, 1) {
+		return fmt.Errorf("OpenSSL %v does not support TLS 1.3", lib.Version)
+	}
+
+	return nil
+}
+
 func filterErrors(codes []int) []int {
 	var fa []int
 	for _, code := range codes {
@@ -492,6 +863,83 @@ func filterErrors(codes []int) []int {
 	return fa
 }
 
+var validListeners = []string{config.HTTPListener, config.HTTPSListener, config.StreamListener, config.StatusListener}
+
+func parseListeners(val string) []string {
+	listeners := make([]string, 0)
+	for _, l := range splitAndTrimSpace(val, ",") {
+		l = strings.ToLower(l)
+		if !slices.Contains(validListeners, l) {
+			klog.Warningf("%v is not a valid listener type. Valid types are %v", l, strings.Join(validListeners, ", "))
+			continue
+		}
+		if !slices.Contains(listeners, l) {
+			listeners = append(listeners, l)
+		}
+	}
+
+	return listeners
+}
+
+// validateListeners ensures every listener type accepts connections from at least
+// one IP family and the status listener accepts IPv4 connections from the controller
+func validateListeners(ipv4, ipv6 []string) (validIPv4, validIPv6 []string) {
+	validIPv4 = slices.Clone(ipv4)
+	for _, l := range validListeners {
+		if slices.Contains(validIPv4, l) {
+			continue
+		}
+
+		if l == config.StatusListener {
+			klog.Warningf("The %v listener cannot be disabled for IPv4 because it is used by the Ingress controller", l)
+			validIPv4 = append(validIPv4, l)
+			continue
+		}
+
+		if !slices.Contains(ipv6, l) {
+			klog.Warningf("The %v listener is disabled for IPv4 and IPv6, enabling IPv4", l)
+			validIPv4 = append(validIPv4, l)
+		}
+	}
+
+	return validIPv4, ipv6
+}
+
+// interfaceAddresses returns the IP addresses assigned to the network interface
+// with the given name. IPv6 link-local addresses are skipped because they
+// require a zone to be used in a listen directive.
+func interfaceAddresses(name string) ([]net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ip, _, err := net.ParseCIDR(addr.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if ip.IsLinkLocalUnicast() {
+			continue
+		}
+
+		ips = append(ips, ip)
+	}
+
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("interface %v does not have usable IP addresses", name)
+	}
+
+	return ips, nil
+}
+
 //nolint:unparam // Ignore `sep` always receives `,` error
 func splitAndTrimSpace(s, sep string) []string {
 	f := func(c rune) bool {
@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/ingress/tlsredirect"
+)
+
+// configKeySchema describes the values accepted for a ConfigMap key
+type configKeySchema struct {
+	kind reflect.Kind
+	bits int
+	// enum contains the valid values of the key, any value is valid when empty
+	enum []string
+	// min and max are the valid range of a numeric key when hasRange is set
+	hasRange bool
+	min, max float64
+	// validator checks the value of a string key when set
+	validator func(string) error
+}
+
+// configKeyRange is the valid range of a numeric ConfigMap key
+type configKeyRange struct {
+	min, max float64
+}
+
+var (
+	// configKeyEnums are the valid values of the ConfigMap keys with a fixed set of values
+	configKeyEnums = map[string][]string{
+		"error-log-level":         {"debug", "info", "notice", "warn", "error", "crit", "alert", "emerg"},
+		"load-balance":            {"round_robin", "ewma"},
+		"proxy-buffering":         {"on", "off"},
+		"proxy-request-buffering": {"on", "off"},
+		"proxy-http-version":      {"1.0", "1.1"},
+		"ingress-conflict-policy": {
+			config.IngressConflictPolicyOldestWins, config.IngressConflictPolicyNewestWins, config.IngressConflictPolicyPriority,
+		},
+		"uri-decoding-policy":   {config.URIDecodingPermissive, config.URIDecodingStrict},
+		"absolute-uri-policy":   {config.AbsoluteURIAllow, config.AbsoluteURIMatchHost, config.AbsoluteURIReject},
+		"ssl-policy-preset":     sortedKeys(config.SSLPolicyPresets),
+		"cdn-provider":          sortedKeys(config.CDNProviders),
+		"request-normalization": sortedKeys(config.RequestNormalizationProfiles),
+	}
+
+	// configKeyRanges are the valid ranges of the numeric ConfigMap keys
+	configKeyRanges = map[string]configKeyRange{
+		"limit-req-status-code":          {400, 599},
+		"limit-conn-status-code":         {400, 599},
+		"otel-sampler-ratio":             {0, 1},
+		"gzip-level":                     {1, 9},
+		"brotli-level":                   {0, 11},
+		"keep-alive":                     {0, math.Inf(1)},
+		"keep-alive-requests":            {0, math.Inf(1)},
+		"upstream-keepalive-connections": {0, math.Inf(1)},
+		"upstream-keepalive-requests":    {0, math.Inf(1)},
+		"upstream-keepalive-timeout":     {0, math.Inf(1)},
+		"proxy-connect-timeout":          {0, math.Inf(1)},
+		"proxy-read-timeout":             {0, math.Inf(1)},
+		"proxy-send-timeout":             {0, math.Inf(1)},
+		"proxy-next-upstream-tries":      {0, math.Inf(1)},
+		"max-worker-connections":         {0, math.Inf(1)},
+		"max-worker-open-files":          {0, math.Inf(1)},
+		"http2-max-concurrent-streams":   {0, math.Inf(1)},
+		"namespace-max-hosts":            {0, math.Inf(1)},
+		"namespace-max-locations":        {0, math.Inf(1)},
+		"namespace-max-snippet-bytes":    {0, math.Inf(1)},
+		"namespace-max-certificates":     {0, math.Inf(1)},
+		"anomaly-scoring-timeout":        {1, math.Inf(1)},
+		"anomaly-scoring-cache-ttl":      {0, math.Inf(1)},
+		"anomaly-scoring-threshold":      {0, math.Inf(1)},
+	}
+
+	// configKeyValidators check the values of the string ConfigMap keys with a syntax
+	configKeyValidators = map[string]func(string) error{
+		"no-tls-redirect-locations": func(value string) error {
+			_, err := tlsredirect.Parse(value)
+			return err
+		},
+		"anomaly-scoring-service": func(value string) error {
+			if value == "" {
+				return nil
+			}
+			host, port, err := net.SplitHostPort(value)
+			if err != nil || host == "" || strings.ContainsAny(host, " \t;{}") {
+				return fmt.Errorf("expected a host:port")
+			}
+			if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+				return fmt.Errorf("expected a host:port")
+			}
+			return nil
+		},
+	}
+
+	// configSchema contains the keys of the configuration decoded from the ConfigMap
+	configSchema = newConfigSchema()
+)
+
+// newConfigSchema builds the schema of the ConfigMap from the json tags of the
+// fields of the configuration
+func newConfigSchema() map[string]configKeySchema {
+	schema := map[string]configKeySchema{}
+	addConfigFields(schema, reflect.TypeOf(config.Configuration{}))
+
+	for key, enum := range configKeyEnums {
+		s := schema[key]
+		s.enum = enum
+		schema[key] = s
+	}
+
+	for key, r := range configKeyRanges {
+		s := schema[key]
+		s.hasRange = true
+		s.min, s.max = r.min, r.max
+		schema[key] = s
+	}
+
+	for key, validator := range configKeyValidators {
+		s := schema[key]
+		s.validator = validator
+		schema[key] = s
+	}
+
+	return schema
+}
+
+func addConfigFields(schema map[string]configKeySchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if strings.Contains(opts, "squash") {
+			addConfigFields(schema, field.Type)
+			continue
+		}
+
+		if name == "" || name == "-" {
+			continue
+		}
+
+		s := configKeySchema{kind: field.Type.Kind()}
+		switch s.kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+			s.bits = field.Type.Bits()
+		}
+		schema[name] = s
+	}
+}
+
+// validateConfigMap checks the values of the ConfigMap against the schema and
+// returns the reason each invalid or unknown key is rejected
+func validateConfigMap(conf map[string]string) map[string]string {
+	rejected := map[string]string{}
+	for key, value := range conf {
+		s, ok := configSchema[key]
+		if !ok {
+			rejected[key] = "unknown key"
+			continue
+		}
+
+		if err := s.validate(value); err != nil {
+			rejected[key] = err.Error()
+		}
+	}
+
+	return rejected
+}
+
+// validate checks the value using the same conversions as the weakly
+// typed decoding of the ConfigMap, where an empty value is the zero value
+func (s configKeySchema) validate(value string) error {
+	var number float64
+	switch s.kind {
+	case reflect.Bool:
+		if value == "" {
+			return nil
+		}
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("expected a boolean")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value == "" {
+			return nil
+		}
+		i, err := strconv.ParseInt(value, 0, s.bits)
+		if err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+		number = float64(i)
+	case reflect.Float32, reflect.Float64:
+		if value == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(value, s.bits)
+		if err != nil {
+			return fmt.Errorf("expected a number")
+		}
+		number = f
+	case reflect.String:
+		if value != "" && len(s.enum) > 0 && !slices.Contains(s.enum, value) {
+			return fmt.Errorf("expected one of %v", strings.Join(s.enum, ", "))
+		}
+		if s.validator != nil {
+			return s.validator(value)
+		}
+		return nil
+	default:
+		// lists, maps and structures are not checked
+		return nil
+	}
+
+	if !s.hasRange || (number >= s.min && number <= s.max) {
+		return nil
+	}
+
+	if math.IsInf(s.max, 1) {
+		return fmt.Errorf("expected a value of at least %v", s.min)
+	}
+
+	return fmt.Errorf("expected a value between %v and %v", s.min, s.max)
+}
+
+// rejectInvalidKeys removes from the ConfigMap the keys with an invalid value,
+// which keep their default, and returns them with the reason sorted by key
+func rejectInvalidKeys(conf map[string]string) []string {
+	rejected := validateConfigMap(conf)
+	if len(rejected) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(rejected))
+	for key, reason := range rejected {
+		delete(conf, key)
+		keys = append(keys, fmt.Sprintf("%v: %v", key, reason))
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateConfigMap(t *testing.T) {
+	testCases := []struct {
+		name   string
+		key    string
+		value  string
+		reason string
+	}{
+		{"valid boolean", "use-gzip", "true", ""},
+		{"empty boolean", "use-gzip", "", ""},
+		{"invalid boolean", "use-gzip", "yes", "expected a boolean"},
+		{"valid integer", "proxy-read-timeout", "60", ""},
+		{"invalid integer", "proxy-read-timeout", "60s", "expected an integer"},
+		{"negative timeout", "proxy-read-timeout", "-1", "expected a value of at least 0"},
+		{"status code out of range", "limit-req-status-code", "200", "expected a value between 400 and 599"},
+		{"valid ratio", "otel-sampler-ratio", "0.5", ""},
+		{"invalid ratio", "otel-sampler-ratio", "half", "expected a number"},
+		{"ratio out of range", "otel-sampler-ratio", "2", "expected a value between 0 and 1"},
+		{"valid enum", "load-balance", "ewma", ""},
+		{"invalid enum", "load-balance", "random", "expected one of round_robin, ewma"},
+		{"empty enum", "ssl-policy-preset", "", ""},
+		{"invalid preset", "cdn-provider", "foo", "expected one of akamai, cloudflare, cloudfront, fastly"},
+		{"string", "server-snippet", "return 404;", ""},
+		{"embedded backend key", "proxy-body-size", "8m", ""},
+		{"unknown key", "use-geoip", "true", "unknown key"},
+		{"valid exceptions", "no-tls-redirect-locations", "/.well-known/acme-challenge,example.com~^/health", ""},
+		{"invalid exception", "no-tls-redirect-locations", "example.com", `exception "example.com": expected a path starting with / or a regular expression starting with ~`},
+		{"valid scoring service", "anomaly-scoring-service", "scorer.security.svc:50051", ""},
+		{"scoring service without port", "anomaly-scoring-service", "scorer.security.svc", "expected a host:port"},
+		{"scoring service with directive", "anomaly-scoring-service", "scorer:1;x", "expected a host:port"},
+		{"negative threshold", "anomaly-scoring-threshold", "-1", "expected a value of at least 0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rejected := validateConfigMap(map[string]string{tc.key: tc.value})
+			if rejected[tc.key] != tc.reason {
+				t.Errorf("expected %q but %q was returned", tc.reason, rejected[tc.key])
+			}
+		})
+	}
+}
+
+func TestReadConfigRejectedKeys(t *testing.T) {
+	cfg := ReadConfig(map[string]string{
+		"gzip-level":         "12",
+		"use-gzip":           "yes",
+		"foo":                "bar",
+		"brotli-level":       "6",
+		"custom-http-errors": "404",
+	})
+
+	expected := []string{
+		"foo: unknown key",
+		"gzip-level: expected a value between 1 and 9",
+		"use-gzip: expected a boolean",
+	}
+	if !reflect.DeepEqual(cfg.RejectedKeys, expected) {
+		t.Errorf("expected %v but %v was returned", expected, cfg.RejectedKeys)
+	}
+
+	def := ReadConfig(map[string]string{})
+	if cfg.GzipLevel != def.GzipLevel || cfg.UseGzip != def.UseGzip {
+		t.Errorf("expected the default values of the rejected keys but %v and %v were returned", cfg.GzipLevel, cfg.UseGzip)
+	}
+	if cfg.BrotliLevel != 6 {
+		t.Errorf("expected the valid keys to be applied but %v was returned", cfg.BrotliLevel)
+	}
+	if def.RejectedKeys != nil {
+		t.Errorf("expected no rejected keys but %v was returned", def.RejectedKeys)
+	}
+}
@@ -27,6 +27,9 @@ import (
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/authreq"
 	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/ingress/deprecation"
+	"k8s.io/ingress-nginx/internal/nginx"
+	"k8s.io/ingress-nginx/pkg/util/runtime"
 )
 
 func TestFilterErrors(t *testing.T) {
@@ -52,6 +55,71 @@ func TestProxyTimeoutParsing(t *testing.T) {
 	}
 }
 
+func TestListenersParsing(t *testing.T) {
+	testCases := map[string]struct {
+		ipv4   string
+		ipv6   string
+		expect [2][]string
+	}{
+		"defaults": {
+			"", "",
+			[2][]string{{"http", "https", "stream", "status"}, {"http", "https", "stream"}},
+		},
+		"IPv6 only": {
+			"status", "http,https,stream",
+			[2][]string{{"status"}, {"http", "https", "stream"}},
+		},
+		"invalid and duplicated types": {
+			"HTTP, http,https,foo", "https,status",
+			[2][]string{{"http", "https", "stream", "status"}, {"https", "status"}},
+		},
+		"status without IPv4": {
+			"http,https,stream", "status",
+			[2][]string{{"http", "https", "stream", "status"}, {"status"}},
+		},
+		"listener without families": {
+			"http", "https",
+			[2][]string{{"http", "stream", "status"}, {"https"}},
+		},
+	}
+	for n, tc := range testCases {
+		conf := map[string]string{}
+		if tc.ipv4 != "" {
+			conf["ipv4-listeners"] = tc.ipv4
+		}
+		if tc.ipv6 != "" {
+			conf["ipv6-listeners"] = tc.ipv6
+		}
+
+		cfg := ReadConfig(conf)
+		if !reflect.DeepEqual(cfg.IPv4Listeners, tc.expect[0]) {
+			t.Errorf("Testing %v. Expected IPv4 listeners %v but got %v", n, tc.expect[0], cfg.IPv4Listeners)
+		}
+		if !reflect.DeepEqual(cfg.IPv6Listeners, tc.expect[1]) {
+			t.Errorf("Testing %v. Expected IPv6 listeners %v but got %v", n, tc.expect[1], cfg.IPv6Listeners)
+		}
+	}
+}
+
+func TestBindInterface(t *testing.T) {
+	cfg := ReadConfig(map[string]string{
+		"bind-address":   "127.0.0.1,10.0.0.1",
+		"bind-interface": "lo,does-not-exist",
+	})
+
+	if !reflect.DeepEqual(cfg.BindAddressIpv4, []string{"127.0.0.1", "10.0.0.1"}) {
+		t.Errorf("unexpected bindAddressIpv4: %v", cfg.BindAddressIpv4)
+	}
+
+	cfg = ReadConfig(map[string]string{
+		"bind-interface": "does-not-exist",
+	})
+
+	if len(cfg.BindAddressIpv4) != 0 || len(cfg.BindAddressIpv6) != 0 {
+		t.Errorf("unexpected bind addresses: %v %v", cfg.BindAddressIpv4, cfg.BindAddressIpv6)
+	}
+}
+
 func TestMergeConfigMapToStruct(t *testing.T) {
 	conf := map[string]string{
 		"custom-http-errors":            "300,400,demo",
@@ -70,6 +138,7 @@ func TestMergeConfigMapToStruct(t *testing.T) {
 		"gzip-types":                    "text/html",
 		"proxy-real-ip-cidr":            "1.1.1.1/8,2.2.2.2/24",
 		"bind-address":                  "1.1.1.1,2.2.2.2,3.3.3,2001:db8:a0b:12f0::1,3731:54:65fe:2::a7,33:33:33::33::33",
+		"allowed-extra-listen-ports":    "8080, 8443,foo,70000",
 		"worker-shutdown-timeout":       "99s",
 		"nginx-status-ipv4-whitelist":   "127.0.0.1,10.0.0.0/24",
 		"nginx-status-ipv6-whitelist":   "::1,2001::/16",
@@ -95,6 +164,7 @@ func TestMergeConfigMapToStruct(t *testing.T) {
 	def.ProxyRealIPCIDR = []string{"1.1.1.1/8", "2.2.2.2/24"}
 	def.BindAddressIpv4 = []string{"1.1.1.1", "2.2.2.2"}
 	def.BindAddressIpv6 = []string{"[2001:db8:a0b:12f0::1]", "[3731:54:65fe:2::a7]"}
+	def.AllowedExtraListenPorts = []int{8080, 8443}
 	def.WorkerShutdownTimeout = "99s"
 	def.NginxStatusIpv4Whitelist = []string{"127.0.0.1", "10.0.0.0/24"}
 	def.NginxStatusIpv6Whitelist = []string{"::1", "2001::/16"}
@@ -154,6 +224,9 @@ func TestMergeConfigMapToStruct(t *testing.T) {
 	def.DenylistSourceRange = []string{"2.2.2.2/32"}
 	def.WhitelistSourceRange = []string{"1.1.1.1/32"}
 	def.DisableIpv6DNS = true
+	def.DeprecatedKeys = []deprecation.Usage{
+		{Name: "whitelist-source-range", Replacement: "allowlist-source-range", Translated: true},
+	}
 
 	hash, err = hashstructure.Hash(def, hashstructure.FormatV1, &hashstructure.HashOptions{
 		TagName: "json",
@@ -213,6 +286,192 @@ func TestGlobalExternalAuthMethodParsing(t *testing.T) {
 	}
 }
 
+func TestAllowedHTTPMethodsParsing(t *testing.T) {
+	testCases := map[string]struct {
+		methods    string
+		statusCode string
+		expect     []string
+		expectCode int
+	}{
+		"empty":               {"", "", []string{}, 405},
+		"valid methods":       {"GET, HEAD,POST", "403", []string{"GET", "HEAD", "POST"}, 403},
+		"duplicated methods":  {"GET,GET", "", []string{"GET"}, 405},
+		"invalid method":      {"GET,post", "", []string{"GET"}, 405},
+		"invalid status code": {"GET", "503", []string{"GET"}, 405},
+	}
+
+	for n, tc := range testCases {
+		cfg := ReadConfig(map[string]string{
+			"allowed-http-methods":             tc.methods,
+			"allowed-http-methods-status-code": tc.statusCode,
+		})
+		if !reflect.DeepEqual(cfg.AllowedHTTPMethods, tc.expect) {
+			t.Errorf("Testing %v. Expected %v but %v was returned", n, tc.expect, cfg.AllowedHTTPMethods)
+		}
+		if cfg.AllowedHTTPMethodsStatusCode != tc.expectCode {
+			t.Errorf("Testing %v. Expected %v but %v was returned", n, tc.expectCode, cfg.AllowedHTTPMethodsStatusCode)
+		}
+	}
+}
+
+func TestDefaultServerParsing(t *testing.T) {
+	testCases := map[string]struct {
+		code           string
+		redirect       string
+		expectCode     int
+		expectRedirect string
+	}{
+		"empty":                 {"", "", 0, ""},
+		"valid code":            {"421", "", 421, ""},
+		"connection close":      {"444", "", 444, ""},
+		"invalid code":          {"500", "", 0, ""},
+		"not a number":          {"foo", "", 0, ""},
+		"valid redirect":        {"", "https://example.com/", 0, "https://example.com/"},
+		"redirect without host": {"", "https://", 0, ""},
+		"redirect scheme":       {"", "ftp://example.com", 0, ""},
+	}
+
+	for n, tc := range testCases {
+		cfg := ReadConfig(map[string]string{
+			"default-server-return-code": tc.code,
+			"default-server-redirect":    tc.redirect,
+		})
+		if cfg.DefaultServerReturnCode != tc.expectCode {
+			t.Errorf("Testing %v. Expected %v but %v was returned", n, tc.expectCode, cfg.DefaultServerReturnCode)
+		}
+		if cfg.DefaultServerRedirect != tc.expectRedirect {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectRedirect, cfg.DefaultServerRedirect)
+		}
+	}
+}
+
+func TestForwardedHeaderParsing(t *testing.T) {
+	testCases := map[string]struct {
+		policy       string
+		by           string
+		expectPolicy string
+		expectBy     string
+	}{
+		"default":            {"", "", "off", ""},
+		"set":                {"set", "_ingress", "set", "_ingress"},
+		"append":             {"append", "_nginx.1", "append", "_nginx.1"},
+		"invalid policy":     {"replace", "", "off", ""},
+		"not obfuscated":     {"set", "ingress", "set", ""},
+		"invalid identifier": {"set", "_a;b", "set", ""},
+	}
+
+	for n, tc := range testCases {
+		conf := map[string]string{}
+		if tc.policy != "" {
+			conf["forwarded-header-policy"] = tc.policy
+		}
+		if tc.by != "" {
+			conf["forwarded-header-by"] = tc.by
+		}
+
+		cfg := ReadConfig(conf)
+		if cfg.ForwardedHeaderPolicy != tc.expectPolicy {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectPolicy, cfg.ForwardedHeaderPolicy)
+		}
+		if cfg.ForwardedHeaderBy != tc.expectBy {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectBy, cfg.ForwardedHeaderBy)
+		}
+	}
+}
+
+func TestTrustedProxiesParsing(t *testing.T) {
+	testCases := map[string]struct {
+		conf          map[string]string
+		expectTrusted []string
+		expectRealIP  []string
+		expectHops    int
+	}{
+		"default": {map[string]string{}, nil, []string{"0.0.0.0/0"}, 0},
+		"trusted proxies": {
+			map[string]string{"trusted-proxies": "10.0.0.0/8, 192.168.1.1"},
+			[]string{"10.0.0.0/8", "192.168.1.1"},
+			[]string{"10.0.0.0/8", "192.168.1.1"},
+			0,
+		},
+		"invalid trusted proxy": {
+			map[string]string{"trusted-proxies": "10.0.0.0/8,10.0.0.0/33,foo"},
+			[]string{"10.0.0.0/8"},
+			[]string{"10.0.0.0/8"},
+			0,
+		},
+		"replaces proxy-real-ip-cidr": {
+			map[string]string{"proxy-real-ip-cidr": "172.16.0.0/12", "trusted-proxies": "10.0.0.0/8"},
+			[]string{"10.0.0.0/8"},
+			[]string{"10.0.0.0/8"},
+			0,
+		},
+		"no valid trusted proxy": {
+			map[string]string{"proxy-real-ip-cidr": "172.16.0.0/12", "trusted-proxies": "foo"},
+			nil,
+			[]string{"172.16.0.0/12"},
+			0,
+		},
+		"hops":          {map[string]string{"forwarded-for-hops": "2"}, nil, []string{"0.0.0.0/0"}, 2},
+		"negative hops": {map[string]string{"forwarded-for-hops": "-1"}, nil, []string{"0.0.0.0/0"}, 0},
+		"invalid hops":  {map[string]string{"forwarded-for-hops": "two"}, nil, []string{"0.0.0.0/0"}, 0},
+	}
+
+	for n, tc := range testCases {
+		cfg := ReadConfig(tc.conf)
+		if !reflect.DeepEqual(cfg.TrustedProxies, tc.expectTrusted) {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectTrusted, cfg.TrustedProxies)
+		}
+		if !reflect.DeepEqual(cfg.ProxyRealIPCIDR, tc.expectRealIP) {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectRealIP, cfg.ProxyRealIPCIDR)
+		}
+		if cfg.ForwardedForHops != tc.expectHops {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectHops, cfg.ForwardedForHops)
+		}
+	}
+}
+
+func TestCDNProviderParsing(t *testing.T) {
+	testCases := map[string]struct {
+		conf           map[string]string
+		expectProvider string
+		expectRealIP   bool
+		expectHeader   string
+		expectCIDRs    []string
+	}{
+		"default":    {map[string]string{}, "", false, "X-Forwarded-For", []string{"0.0.0.0/0"}},
+		"cloudflare": {map[string]string{"cdn-provider": "cloudflare"}, "cloudflare", true, "CF-Connecting-IP", []string{}},
+		"header": {
+			map[string]string{"cdn-provider": "fastly", "forwarded-for-header": "X-Client-IP"},
+			"fastly", true, "X-Client-IP", []string{},
+		},
+		"trusted proxies": {
+			map[string]string{"cdn-provider": "akamai", "trusted-proxies": "10.0.0.0/8"},
+			"akamai", true, "True-Client-IP", []string{"10.0.0.0/8"},
+		},
+		"proxy-real-ip-cidr": {
+			map[string]string{"cdn-provider": "cloudfront", "proxy-real-ip-cidr": "10.0.0.0/8"},
+			"cloudfront", true, "X-Forwarded-For", []string{"10.0.0.0/8"},
+		},
+		"invalid": {map[string]string{"cdn-provider": "foo"}, "", false, "X-Forwarded-For", []string{"0.0.0.0/0"}},
+	}
+
+	for n, tc := range testCases {
+		cfg := ReadConfig(tc.conf)
+		if cfg.CDNProvider != tc.expectProvider {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectProvider, cfg.CDNProvider)
+		}
+		if cfg.EnableRealIP != tc.expectRealIP {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectRealIP, cfg.EnableRealIP)
+		}
+		if cfg.ForwardedForHeader != tc.expectHeader {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectHeader, cfg.ForwardedForHeader)
+		}
+		if !reflect.DeepEqual(cfg.ProxyRealIPCIDR, tc.expectCIDRs) {
+			t.Errorf("Testing %v. Expected \"%v\" but \"%v\" was returned", n, tc.expectCIDRs, cfg.ProxyRealIPCIDR)
+		}
+	}
+}
+
 func TestGlobalExternalAuthSigninParsing(t *testing.T) {
 	errorURL := ""
 	validURL := "http://bar.foo.com/auth-error-page"
@@ -427,6 +686,160 @@ func TestLuaSharedDictsParsing(t *testing.T) {
 	}
 }
 
+func TestAutoTuneSharedMemory(t *testing.T) {
+	defer func(f func() int64) { memoryLimit = f }(memoryLimit)
+	memoryLimit = func() int64 { return 2 * 1024 * 1024 * 1024 }
+
+	cfg := ReadConfig(map[string]string{"auto-tune": "true"})
+	for dictName, dictSize := range defaultLuaSharedDicts {
+		if cfg.LuaSharedDicts[dictName] != 2*dictSize {
+			t.Errorf("expected %v for Lua dictionary %v but %v was returned", 2*dictSize, dictName, cfg.LuaSharedDicts[dictName])
+		}
+	}
+	if cfg.SSLSessionCacheSize != "20M" {
+		t.Errorf("expected 20M for the SSL session cache but %v was returned", cfg.SSLSessionCacheSize)
+	}
+
+	// the sizes configured in the ConfigMap are not changed
+	cfg = ReadConfig(map[string]string{
+		"auto-tune":              "true",
+		"lua-shared-dicts":       "configuration_data: 30",
+		"ssl-session-cache-size": "15m",
+	})
+	if cfg.LuaSharedDicts["configuration_data"] != 30720 {
+		t.Errorf("expected 30720 for Lua dictionary configuration_data but %v was returned", cfg.LuaSharedDicts["configuration_data"])
+	}
+	if cfg.SSLSessionCacheSize != "15m" {
+		t.Errorf("expected 15m for the SSL session cache but %v was returned", cfg.SSLSessionCacheSize)
+	}
+
+	// without auto-tune the defaults are used
+	cfg = ReadConfig(map[string]string{})
+	if !reflect.DeepEqual(cfg.LuaSharedDicts, defaultLuaSharedDicts) {
+		t.Errorf("expected the default Lua dictionaries but %v was returned", cfg.LuaSharedDicts)
+	}
+	if cfg.SSLSessionCacheSize != "10m" {
+		t.Errorf("expected 10m for the SSL session cache but %v was returned", cfg.SSLSessionCacheSize)
+	}
+}
+
+func TestSSLPolicyPreset(t *testing.T) {
+	defer func(f func() nginx.TLSLibrary) { tlsLibrary = f }(tlsLibrary)
+	lib := nginx.TLSLibrary{Name: "OpenSSL", Version: "3.0.13"}
+	tlsLibrary = func() nginx.TLSLibrary { return lib }
+
+	modern := config.SSLPolicyPresets["modern"]
+	cfg := ReadConfig(map[string]string{"ssl-policy-preset": "modern"})
+	if cfg.SSLPolicyPreset != "modern" || cfg.SSLProtocols != modern.Protocols || cfg.SSLCiphers != "" || cfg.SSLECDHCurve != modern.ECDHCurve {
+		t.Errorf("expected the settings of the modern preset but %v, %v and %v were returned", cfg.SSLProtocols, cfg.SSLCiphers, cfg.SSLECDHCurve)
+	}
+
+	// the settings defined in the ConfigMap take precedence over the preset
+	cfg = ReadConfig(map[string]string{"ssl-policy-preset": "intermediate", "ssl-ecdh-curve": "secp384r1"})
+	if cfg.SSLProtocols != config.SSLPolicyPresets["intermediate"].Protocols || cfg.SSLECDHCurve != "secp384r1" {
+		t.Errorf("expected the protocols of the intermediate preset and the curve of the ConfigMap but %v and %v were returned", cfg.SSLProtocols, cfg.SSLECDHCurve)
+	}
+
+	def := config.NewDefault()
+	testCases := []struct {
+		name   string
+		preset string
+		lib    nginx.TLSLibrary
+	}{
+		{"unknown preset", "strict", lib},
+		{"fips without a fips module", "fips", lib},
+		{"modern without TLS 1.3", "modern", nginx.TLSLibrary{Name: "OpenSSL", Version: "1.0.2u"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lib = tc.lib
+			cfg := ReadConfig(map[string]string{"ssl-policy-preset": tc.preset})
+			if cfg.SSLPolicyPreset != "" || cfg.SSLProtocols != def.SSLProtocols || cfg.SSLCiphers != def.SSLCiphers {
+				t.Errorf("expected the preset to be ignored but %v, %v and %v were returned", cfg.SSLPolicyPreset, cfg.SSLProtocols, cfg.SSLCiphers)
+			}
+		})
+	}
+
+	lib = nginx.TLSLibrary{Name: "OpenSSL", Version: "3.0.13", FIPS: true}
+	cfg = ReadConfig(map[string]string{"ssl-policy-preset": "fips"})
+	if cfg.SSLPolicyPreset != "fips" || cfg.SSLCiphers != config.SSLPolicyPresets["fips"].Ciphers {
+		t.Errorf("expected the ciphers of the fips preset but %v was returned", cfg.SSLCiphers)
+	}
+}
+
+func TestApplyCPUTopology(t *testing.T) {
+	defer func(f func() runtime.CPUTopology) { cpuTopology = f }(cpuTopology)
+	bigLittle := runtime.CPUTopology{
+		Architecture: "arm64",
+		Clusters: []runtime.CPUCluster{
+			{CPUs: []int{4, 5}, Capacity: 1024},
+			{CPUs: []int{0, 1, 2, 3}, Capacity: 446},
+		},
+	}
+	cpuTopology = func() runtime.CPUTopology { return bigLittle }
+
+	cfg := ReadConfig(map[string]string{"worker-processes": "2"})
+	if cfg.WorkerCPUAffinity != "auto 110000" {
+		t.Errorf("expected the workers bound to the fastest CPUs but %q was returned", cfg.WorkerCPUAffinity)
+	}
+	if cfg.SSLCiphers != "ECDHE-ECDSA-CHACHA20-POLY1305:ECDHE-RSA-CHACHA20-POLY1305:ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:DHE-RSA-AES128-GCM-SHA256:DHE-RSA-AES256-GCM-SHA384" {
+		t.Errorf("expected ChaCha20-Poly1305 to be preferred without AES acceleration but %q was returned", cfg.SSLCiphers)
+	}
+
+	// more workers than fast CPUs
+	cfg = ReadConfig(map[string]string{"worker-processes": "4"})
+	if cfg.WorkerCPUAffinity != "" {
+		t.Errorf("expected no CPU affinity but %q was returned", cfg.WorkerCPUAffinity)
+	}
+
+	// the values of the ConfigMap take precedence
+	cfg = ReadConfig(map[string]string{"worker-processes": "2", "worker-cpu-affinity": "auto", "ssl-ciphers": "AES128-SHA"})
+	if cfg.WorkerCPUAffinity != "auto" || cfg.SSLCiphers != "AES128-SHA" {
+		t.Errorf("expected the values of the ConfigMap but %q and %q were returned", cfg.WorkerCPUAffinity, cfg.SSLCiphers)
+	}
+
+	bigLittle = runtime.CPUTopology{Architecture: "amd64", Clusters: []runtime.CPUCluster{{CPUs: []int{0, 1}}}, AES: true}
+	def := config.NewDefault()
+	cfg = ReadConfig(map[string]string{"worker-processes": "2"})
+	if cfg.WorkerCPUAffinity != "" || cfg.SSLCiphers != def.SSLCiphers {
+		t.Errorf("expected the defaults on homogeneous CPUs with AES but %q and %q were returned", cfg.WorkerCPUAffinity, cfg.SSLCiphers)
+	}
+}
+
+func TestRequestNormalization(t *testing.T) {
+	strict := config.RequestNormalizationProfiles["strict"]
+	cfg := ReadConfig(map[string]string{"request-normalization": "strict"})
+	if cfg.RejectAmbiguousRequestLength != strict.RejectAmbiguousRequestLength || cfg.URIDecodingPolicy != strict.URIDecodingPolicy || cfg.AbsoluteURIPolicy != strict.AbsoluteURIPolicy {
+		t.Errorf("expected the settings of the strict profile but %v, %v and %v were returned", cfg.RejectAmbiguousRequestLength, cfg.URIDecodingPolicy, cfg.AbsoluteURIPolicy)
+	}
+
+	// the settings defined in the ConfigMap take precedence over the profile
+	cfg = ReadConfig(map[string]string{"request-normalization": "strict", "absolute-uri-policy": "reject", "merge-slashes": "false"})
+	if !cfg.RejectAmbiguousRequestLength || cfg.AbsoluteURIPolicy != config.AbsoluteURIReject || cfg.MergeSlashes {
+		t.Errorf("expected the strict profile with the options of the ConfigMap but %v, %v and %v were returned", cfg.RejectAmbiguousRequestLength, cfg.AbsoluteURIPolicy, cfg.MergeSlashes)
+	}
+
+	def := config.NewDefault()
+	testCases := []struct {
+		name string
+		conf map[string]string
+	}{
+		{"unknown profile", map[string]string{"request-normalization": "paranoid"}},
+		{"unknown uri decoding policy", map[string]string{"uri-decoding-policy": "decode"}},
+		{"unknown absolute uri policy", map[string]string{"absolute-uri-policy": "deny"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := ReadConfig(tc.conf)
+			if cfg.RequestNormalization != "" || cfg.URIDecodingPolicy != def.URIDecodingPolicy || cfg.AbsoluteURIPolicy != def.AbsoluteURIPolicy {
+				t.Errorf("expected the default settings but %v, %v and %v were returned", cfg.RequestNormalization, cfg.URIDecodingPolicy, cfg.AbsoluteURIPolicy)
+			}
+		})
+	}
+}
+
 func TestSplitAndTrimSpace(t *testing.T) {
 	testsCases := []struct {
 		name   string
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// nginxDurationRegex matches one element of a time of the NGINX
+// configuration, a number with an optional unit, e.g. 1h 30m or 500ms
+var nginxDurationRegex = regexp.MustCompile(`^(\d+)(ms|s|m|h|d|w|M|y)?`)
+
+var nginxDurationUnits = map[string]time.Duration{
+	"":   time.Second,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"M":  30 * 24 * time.Hour,
+	"y":  365 * 24 * time.Hour,
+}
+
+// luaQuote returns the input as a double quoted Lua string, the bytes
+// which could end the string or the block of the NGINX configuration
+// are escaped, e.g. {{ luaQuote $location.Path }} in a *_by_lua_block
+func luaQuote(input interface{}) string {
+	s := inputString(input)
+
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case c < 0x20 || c >= 0x7f:
+			// the decimal escapes are always 3 digits so a following digit
+			// is not read as part of the escape
+			fmt.Fprintf(&b, `\%03d`, c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// regexEscape escapes the metacharacters of the input for a PCRE
+// regular expression, e.g. in a location matching a literal path
+func regexEscape(input interface{}) string {
+	return regexp.QuoteMeta(inputString(input))
+}
+
+// cidrContains returns true when the CIDR contains the IP address
+func cidrContains(cidr, ip string) bool {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		klog.Errorf("invalid CIDR %q: %v", cidr, err)
+		return false
+	}
+
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		klog.Errorf("invalid IP address %q: %v", ip, err)
+		return false
+	}
+
+	return prefix.Contains(addr.Unmap())
+}
+
+// cidrNetwork returns the CIDR with the host bits cleared, e.g.
+// 10.0.0.0/8 for 10.1.2.3/8, or an empty string when it is not valid
+func cidrNetwork(cidr string) string {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		klog.Errorf("invalid CIDR %q: %v", cidr, err)
+		return ""
+	}
+
+	return prefix.Masked().String()
+}
+
+// isIPv6CIDR returns true when the CIDR or IP address is IPv6
+func isIPv6CIDR(cidr string) bool {
+	cidr = strings.TrimSpace(cidr)
+	if prefix, err := netip.ParsePrefix(cidr); err == nil {
+		return prefix.Addr().Is6()
+	}
+	if addr, err := netip.ParseAddr(cidr); err == nil {
+		return addr.Is6() && !addr.Is4In6()
+	}
+
+	klog.Errorf("invalid CIDR %q", cidr)
+	return false
+}
+
+// parseNginxDuration parses a time with the syntax of the NGINX
+// configuration, e.g. 90, 1m30s or 1h 30m, where a number without
+// unit is in seconds
+func parseNginxDuration(input string) (time.Duration, error) {
+	s := strings.TrimSpace(input)
+	if s == "" {
+		return 0, fmt.Errorf("empty time")
+	}
+
+	var d time.Duration
+	for s != "" {
+		m := nginxDurationRegex.FindStringSubmatch(s)
+		if m == nil {
+			return 0, fmt.Errorf("invalid time %q", input)
+		}
+
+		n, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time %q: %w", input, err)
+		}
+		unit := nginxDurationUnits[m[2]]
+		if n > int64(math.MaxInt64/unit) || d > math.MaxInt64-time.Duration(n)*unit {
+			return 0, fmt.Errorf("time %q is too large", input)
+		}
+		d += time.Duration(n) * unit
+
+		s = strings.TrimLeft(s[len(m[0]):], " ")
+	}
+
+	return d, nil
+}
+
+// durationSeconds returns the seconds of a time of the NGINX configuration,
+// rounded up, or 0 when it is not valid
+func durationSeconds(input interface{}) int64 {
+	d, err := parseNginxDuration(inputString(input))
+	if err != nil {
+		klog.Errorf("%v", err)
+		return 0
+	}
+
+	return int64((d + time.Second - 1) / time.Second)
+}
+
+// durationMilliseconds returns the milliseconds of a time of the NGINX
+// configuration, or 0 when it is not valid
+func durationMilliseconds(input interface{}) int64 {
+	d, err := parseNginxDuration(inputString(input))
+	if err != nil {
+		klog.Errorf("%v", err)
+		return 0
+	}
+
+	return d.Milliseconds()
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLuaQuote(t *testing.T) {
+	foo := "foo"
+	testCases := []struct {
+		input    interface{}
+		expected string
+	}{
+		{"foo", `"foo"`},
+		{&foo, `"foo"`},
+		{10, `"10"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\path`, `"C:\\path"`},
+		{"a\nb\r\tc", `"a\nb\r\tc"`},
+		{"\x001", `"\0001"`},
+		{"é", `"\195\169"`},
+		{"]] } ngx.exit(500) --", `"]] } ngx.exit(500) --"`},
+	}
+
+	for _, tc := range testCases {
+		if actual := luaQuote(tc.input); actual != tc.expected {
+			t.Errorf("luaQuote(%q): expected %v but returned %v", tc.input, tc.expected, actual)
+		}
+	}
+}
+
+func TestRegexEscape(t *testing.T) {
+	testCases := map[string]string{
+		"/api":          "/api",
+		"/v1.0/(users)": `/v1\.0/\(users\)`,
+		"^a+b*$":        `\^a\+b\*\$`,
+		"{1,2}[x]|y?":   `\{1,2\}\[x\]\|y\?`,
+	}
+
+	for input, expected := range testCases {
+		if actual := regexEscape(input); actual != expected {
+			t.Errorf("regexEscape(%q): expected %v but returned %v", input, expected, actual)
+		}
+	}
+}
+
+func TestCIDRFunctions(t *testing.T) {
+	containsCases := []struct {
+		cidr, ip string
+		expected bool
+	}{
+		{"10.0.0.0/8", "10.1.2.3", true},
+		{"10.0.0.0/8", "11.1.2.3", false},
+		{"10.0.0.0/8", "::ffff:10.1.2.3", true},
+		{"2001:db8::/32", "2001:db8::1", true},
+		{"2001:db8::/32", "10.1.2.3", false},
+		{"invalid", "10.1.2.3", false},
+		{"10.0.0.0/8", "invalid", false},
+	}
+	for _, tc := range containsCases {
+		if actual := cidrContains(tc.cidr, tc.ip); actual != tc.expected {
+			t.Errorf("cidrContains(%q, %q): expected %v but returned %v", tc.cidr, tc.ip, tc.expected, actual)
+		}
+	}
+
+	networkCases := map[string]string{
+		"10.1.2.3/8":        "10.0.0.0/8",
+		" 192.168.1.1/24 ":  "192.168.1.0/24",
+		"2001:db8::1/32":    "2001:db8::/32",
+		"10.0.0.1":          "",
+		"300.0.0.0/8":       "",
+		"2001:db8::1/129":   "",
+		"192.168.1.255/32":  "192.168.1.255/32",
+		"fe80::1:2:3:4/64":  "fe80::/64",
+		"172.16.200.10/12":  "172.16.0.0/12",
+		"0.0.0.0/0":         "0.0.0.0/0",
+		"1.2.3.4/33":        "",
+		"1.2.3.4/-1":        "",
+		"1.2.3.4/8/8":       "",
+		"":                  "",
+		"10.0.0.0/8;deny":   "",
+		"10.0.0.0/8 all;":   "",
+		"10.0.0.0/8\nallow": "",
+	}
+	for input, expected := range networkCases {
+		if actual := cidrNetwork(input); actual != expected {
+			t.Errorf("cidrNetwork(%q): expected %q but returned %q", input, expected, actual)
+		}
+	}
+
+	ipv6Cases := map[string]bool{
+		"10.0.0.0/8":      false,
+		"10.0.0.1":        false,
+		"::ffff:10.0.0.1": false,
+		"2001:db8::/32":   true,
+		"::1":             true,
+		"invalid":         false,
+	}
+	for input, expected := range ipv6Cases {
+		if actual := isIPv6CIDR(input); actual != expected {
+			t.Errorf("isIPv6CIDR(%q): expected %v but returned %v", input, expected, actual)
+		}
+	}
+}
+
+func TestParseNginxDuration(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected time.Duration
+		valid    bool
+	}{
+		{"90", 90 * time.Second, true},
+		{"500ms", 500 * time.Millisecond, true},
+		{"1m30s", 90 * time.Second, true},
+		{"1h 30m", 90 * time.Minute, true},
+		{" 2d ", 48 * time.Hour, true},
+		{"1w", 7 * 24 * time.Hour, true},
+		{"1M", 30 * 24 * time.Hour, true},
+		{"1y", 365 * 24 * time.Hour, true},
+		{"", 0, false},
+		{"1x", 0, false},
+		{"-1s", 0, false},
+		{"1.5s", 0, false},
+		{"10s;", 0, false},
+		{"99999999999999999999", 0, false},
+		{"300y", 0, false},
+	}
+
+	for _, tc := range testCases {
+		actual, err := parseNginxDuration(tc.input)
+		if tc.valid && err != nil {
+			t.Errorf("parseNginxDuration(%q): unexpected error: %v", tc.input, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("parseNginxDuration(%q): expected an error", tc.input)
+		}
+		if actual != tc.expected {
+			t.Errorf("parseNginxDuration(%q): expected %v but returned %v", tc.input, tc.expected, actual)
+		}
+	}
+}
+
+func TestDurationFunctions(t *testing.T) {
+	if actual := durationSeconds("1500ms"); actual != 2 {
+		t.Errorf("expected 2 seconds but returned %v", actual)
+	}
+	if actual := durationSeconds(60); actual != 60 {
+		t.Errorf("expected 60 seconds but returned %v", actual)
+	}
+	if actual := durationSeconds("invalid"); actual != 0 {
+		t.Errorf("expected 0 seconds but returned %v", actual)
+	}
+	if actual := durationMilliseconds("1m 5s"); actual != 65000 {
+		t.Errorf("expected 65000 milliseconds but returned %v", actual)
+	}
+}
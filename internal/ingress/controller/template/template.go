@@ -31,6 +31,7 @@ import (
 	"os"
 	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -42,7 +43,9 @@ import (
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/ratelimit"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/routeif"
 	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/ingress/tlsredirect"
 	ing_net "k8s.io/ingress-nginx/internal/net"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
 )
@@ -206,6 +209,12 @@ The json format should follow what's expected by lua:
 		hsts_max_age = %v,
 		hsts_include_subdomains = %t,
 		hsts_preload = %t,
+
+		auth_cache_memcached = { host = "%v", port = %v, connect_timeout = %v, max_idle_timeout = %v, pool_size = %v },
+		limit_req_status_code = %v,
+		limit_conn_status_code = %v,
+
+		anomaly_scoring = { enabled = %t, cache_ttl = %v, threshold = %v },
 */
 
 type LuaConfig struct {
@@ -213,14 +222,36 @@ type LuaConfig struct {
 	ListenPorts             LuaListenPorts `json:"listen_ports"`
 	UseForwardedHeaders     bool           `json:"use_forwarded_headers"`
 	UseProxyProtocol        bool           `json:"use_proxy_protocol"`
+	ForwardedForHeader      string         `json:"forwarded_for_header"`
+	ForwardedForHops        int            `json:"forwarded_for_hops"`
 	IsSSLPassthroughEnabled bool           `json:"is_ssl_passthrough_enabled"`
 	HTTPRedirectCode        int            `json:"http_redirect_code"`
 	EnableOCSP              bool           `json:"enable_ocsp"`
 	MonitorBatchMaxSize     int            `json:"monitor_batch_max_size"`
+	MetricsSocket           string         `json:"metrics_socket"`
 	HSTS                    bool           `json:"hsts"`
 	HSTSMaxAge              string         `json:"hsts_max_age"`
 	HSTSIncludeSubdomains   bool           `json:"hsts_include_subdomains"`
 	HSTSPreload             bool           `json:"hsts_preload"`
+	AuthCacheMemcached      LuaMemcached   `json:"auth_cache_memcached"`
+	LimitReqStatusCode      int            `json:"limit_req_status_code"`
+	LimitConnStatusCode     int            `json:"limit_conn_status_code"`
+
+	AnomalyScoring LuaAnomalyScoring `json:"anomaly_scoring"`
+}
+
+type LuaAnomalyScoring struct {
+	Enabled   bool    `json:"enabled"`
+	CacheTTL  int     `json:"cache_ttl"`
+	Threshold float64 `json:"threshold"`
+}
+
+type LuaMemcached struct {
+	Host           string `json:"host"`
+	Port           int    `json:"port"`
+	ConnectTimeout int    `json:"connect_timeout"`
+	MaxIdleTimeout int    `json:"max_idle_timeout"`
+	PoolSize       int    `json:"pool_size"`
 }
 
 type LuaListenPorts struct {
@@ -275,6 +306,7 @@ var funcMap = text_template.FuncMap{
 		return true
 	},
 	"escapeLiteralDollar":             escapeLiteralDollar,
+	"buildDefaultServerReturn":        buildDefaultServerReturn,
 	"buildLuaSharedDictionaries":      buildLuaSharedDictionaries,
 	"luaConfigurationRequestBodySize": luaConfigurationRequestBodySize,
 	"buildLocation":                   buildLocation,
@@ -285,6 +317,7 @@ var funcMap = text_template.FuncMap{
 	"buildAuthProxySetHeaders":        buildAuthProxySetHeaders,
 	"buildAuthUpstreamName":           buildAuthUpstreamName,
 	"shouldApplyAuthUpstream":         shouldApplyAuthUpstream,
+	"shouldShareAuthCache":            shouldShareAuthCache,
 	"extractHostPort":                 extractHostPort,
 	"changeHostPort":                  changeHostPort,
 	"buildProxyPass":                  buildProxyPass,
@@ -294,6 +327,9 @@ var funcMap = text_template.FuncMap{
 	"locationConfigForLua":            locationConfigForLua,
 	"buildResolvers":                  buildResolvers,
 	"buildUpstreamName":               buildUpstreamName,
+	"buildCollapsedMaps":              buildCollapsedMaps,
+	"collapsedVariable":               collapsedVariable,
+	"buildAllowedHTTPMethods":         buildAllowedHTTPMethods,
 	"isLocationInLocationList":        isLocationInLocationList,
 	"isLocationAllowed":               isLocationAllowed,
 	"buildDenyVariable":               buildDenyVariable,
@@ -314,6 +350,8 @@ var funcMap = text_template.FuncMap{
 	},
 	"isValidByteSize":                    isValidByteSize,
 	"buildForwardedFor":                  buildForwardedFor,
+	"buildForwardedElement":              buildForwardedElement,
+	"useForwardedForHops":                useForwardedForHops,
 	"buildAuthSignURL":                   buildAuthSignURL,
 	"buildAuthSignURLLocation":           buildAuthSignURLLocation,
 	"buildOpentelemetry":                 buildOpentelemetry,
@@ -321,16 +359,32 @@ var funcMap = text_template.FuncMap{
 	"enforceRegexModifier":               enforceRegexModifier,
 	"buildCustomErrorDeps":               buildCustomErrorDeps,
 	"buildCustomErrorLocationsPerServer": buildCustomErrorLocationsPerServer,
+	"buildCustomErrorLocationName":       buildCustomErrorLocationName,
+	"buildStreamLogFormat":               buildStreamLogFormat,
+	"buildStreamAccessLog":               buildStreamAccessLog,
 	"shouldLoadModSecurityModule":        shouldLoadModSecurityModule,
 	"buildHTTPListener":                  buildHTTPListener,
 	"buildHTTPSListener":                 buildHTTPSListener,
+	"buildExtraListeners":                buildExtraListeners,
+	"listensOnIPv4":                      listensOnIPv4,
+	"listensOnIPv6":                      listensOnIPv6,
 	"buildOpentelemetryForLocation":      buildOpentelemetryForLocation,
 	"shouldLoadOpentelemetryModule":      shouldLoadOpentelemetryModule,
 	"buildModSecurityForLocation":        buildModSecurityForLocation,
 	"buildMirrorLocations":               buildMirrorLocations,
 	"shouldLoadAuthDigestModule":         shouldLoadAuthDigestModule,
+	"shouldConfigureSliceCache":          shouldConfigureSliceCache,
+	"buildGRPCErrorLocations":            buildGRPCErrorLocations,
+	"buildGRPCErrorPages":                buildGRPCErrorPages,
 	"buildServerName":                    buildServerName,
 	"buildCorsOriginRegex":               buildCorsOriginRegex,
+	"luaQuote":                           luaQuote,
+	"regexEscape":                        regexEscape,
+	"cidrContains":                       cidrContains,
+	"cidrNetwork":                        cidrNetwork,
+	"isIPv6CIDR":                         isIPv6CIDR,
+	"durationSeconds":                    durationSeconds,
+	"durationMilliseconds":               durationMilliseconds,
 }
 
 // escapeLiteralDollar will replace the $ character with ${literal_dollar}
@@ -348,6 +402,30 @@ func escapeLiteralDollar(input interface{}) string {
 	return strings.ReplaceAll(inputStr, `$`, `${literal_dollar}`)
 }
 
+// buildDefaultServerReturn returns the return directive of the catch-all
+// server or an empty string when the requests are sent to the default backend
+func buildDefaultServerReturn(c interface{}) string {
+	cfg, ok := c.(config.Configuration)
+	if !ok {
+		klog.Errorf("expected a 'config.Configuration' type but %T was returned", c)
+		return ""
+	}
+
+	if cfg.DefaultServerRedirect != "" {
+		return fmt.Sprintf("return %d %s;", cfg.HTTPRedirectCode, quote(escapeLiteralDollar(cfg.DefaultServerRedirect)))
+	}
+
+	switch {
+	case cfg.DefaultServerReturnCode == 0:
+		return ""
+	// 444 closes the connection without a response
+	case cfg.DefaultServerBody == "" || cfg.DefaultServerReturnCode == 444:
+		return fmt.Sprintf("return %d;", cfg.DefaultServerReturnCode)
+	default:
+		return fmt.Sprintf("return %d %s;", cfg.DefaultServerReturnCode, quote(escapeLiteralDollar(cfg.DefaultServerBody)))
+	}
+}
+
 // formatIP will wrap IPv6 addresses in [] and return IPv4 addresses
 // without modification. If the input cannot be parsed as an IP address
 // it is returned without modification.
@@ -363,18 +441,21 @@ func formatIP(input string) string {
 }
 
 func quote(input interface{}) string {
-	var inputStr string
+	return fmt.Sprintf("%q", inputString(input))
+}
+
+// inputString returns the string of a value passed to a template function
+func inputString(input interface{}) string {
 	switch input := input.(type) {
 	case string:
-		inputStr = input
+		return input
 	case fmt.Stringer:
-		inputStr = input.String()
+		return input.String()
 	case *string:
-		inputStr = *input
+		return *input
 	default:
-		inputStr = fmt.Sprintf("%v", input)
+		return fmt.Sprintf("%v", input)
 	}
-	return fmt.Sprintf("%q", inputStr)
 }
 
 func buildLuaSharedDictionaries(c, s interface{}) string {
@@ -418,13 +499,19 @@ func luaConfigurationRequestBodySize(c interface{}) string {
 }
 
 // locationConfigForLua formats some location specific configuration into Lua table represented as string
-func locationConfigForLua(l, a interface{}) string {
+func locationConfigForLua(l, s, a interface{}) string {
 	location, ok := l.(*ingress.Location)
 	if !ok {
 		klog.Errorf("expected an '*ingress.Location' type but %T was given", l)
 		return "{}"
 	}
 
+	server, ok := s.(*ingress.Server)
+	if !ok {
+		klog.Errorf("expected an '*ingress.Server' type but %T was given", s)
+		return "{}"
+	}
+
 	all, ok := a.(config.TemplateConfig)
 	if !ok {
 		klog.Errorf("expected a 'config.TemplateConfig' type but %T was given", a)
@@ -437,6 +524,12 @@ func locationConfigForLua(l, a interface{}) string {
 	    force_no_ssl_redirect = string_to_bool(ngx.var.force_no_ssl_redirect),
 	    preserve_trailing_slash = string_to_bool(ngx.var.preserve_trailing_slash),
 	    use_port_in_redirects = string_to_bool(ngx.var.use_port_in_redirects),
+	  and the upload guard
+	    upload_max_concurrent = tonumber(ngx.var.upload_max_concurrent),
+	    upload_min_size = tonumber(ngx.var.upload_min_size),
+	  and the base64 encoded JSON of the HTTPTransform and of the route-if rules
+	    transform_rules = ngx.var.transform_rules,
+	    route_rules = ngx.var.route_rules,
 	*/
 
 	return fmt.Sprintf(`
@@ -445,15 +538,63 @@ func locationConfigForLua(l, a interface{}) string {
 	    set $force_no_ssl_redirect "%t";
 	    set $preserve_trailing_slash "%t";
 	    set $use_port_in_redirects "%t";
+	    set $upload_max_concurrent "%d";
+	    set $upload_min_size "%d";
+	    set $transform_rules "%s";
+	    set $route_rules "%s";
 	`,
 		location.Rewrite.ForceSSLRedirect,
 		location.Rewrite.SSLRedirect,
-		isLocationInLocationList(l, all.Cfg.NoTLSRedirectLocations),
+		server.SSLRedirectDeferred || isTLSRedirectException(location, server.Hostname, all.Cfg.NoTLSRedirectExceptions),
 		location.Rewrite.PreserveTrailingSlash,
 		location.UsePortInRedirects,
+		location.UploadGuard.MaxConcurrent,
+		location.UploadGuard.MinSize,
+		buildTransformRules(location),
+		buildRouteRules(location),
 	)
 }
 
+// buildRouteRules returns the compiled route-if rules of the location encoded for Lua
+func buildRouteRules(location *ingress.Location) string {
+	if len(location.RouteIf.Rules) == 0 {
+		return ""
+	}
+
+	type rule struct {
+		If      *routeif.Expr `json:"if"`
+		Backend string        `json:"backend"`
+	}
+
+	rules := make([]rule, 0, len(location.RouteIf.Rules))
+	for _, r := range location.RouteIf.Rules {
+		rules = append(rules, rule{If: r.If, Backend: r.Backend})
+	}
+
+	b, err := json.Marshal(rules)
+	if err != nil {
+		klog.Errorf("unexpected error encoding the route-if rules of location %v: %v", location.Path, err)
+		return ""
+	}
+
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// buildTransformRules returns the HTTPTransform of the location encoded for Lua
+func buildTransformRules(location *ingress.Location) string {
+	if location.HTTPTransform.Name == "" {
+		return ""
+	}
+
+	b, err := json.Marshal(location.HTTPTransform.Spec)
+	if err != nil {
+		klog.Errorf("unexpected error encoding HTTPTransform %v: %v", location.HTTPTransform.Name, err)
+		return ""
+	}
+
+	return base64.StdEncoding.EncodeToString(b)
+}
+
 // buildResolvers returns the resolvers reading the /etc/resolv.conf file
 func buildResolvers(res, disableIpv6 interface{}) string {
 	// NGINX need IPV6 addresses to be surrounded by brackets
@@ -662,6 +803,35 @@ func shouldApplyAuthUpstream(l, c interface{}) bool {
 	return true
 }
 
+// shouldShareAuthCache returns true when the decisions of the external
+// authentication of the location can be shared using memcached. Only the
+// status code is shared, so locations copying headers from the response
+// of the auth service keep using the local cache only.
+func shouldShareAuthCache(l, c interface{}) bool {
+	location, ok := l.(*ingress.Location)
+	if !ok {
+		klog.Errorf("expected an '*ingress.Location' type but %T was returned", l)
+		return false
+	}
+
+	cfg, ok := c.(config.Configuration)
+	if !ok {
+		klog.Errorf("expected a 'config.Configuration' type but %T was returned", c)
+		return false
+	}
+
+	if cfg.AuthMemcachedHost == "" {
+		return false
+	}
+
+	cacheKey, responseHeaders := location.ExternalAuth.AuthCacheKey, location.ExternalAuth.ResponseHeaders
+	if shouldApplyGlobalAuth(location, cfg.GlobalExternalAuth.URL) {
+		cacheKey, responseHeaders = cfg.GlobalExternalAuth.AuthCacheKey, cfg.GlobalExternalAuth.ResponseHeaders
+	}
+
+	return cacheKey != "" && len(responseHeaders) == 0
+}
+
 // extractHostPort will extract the host:port part from the URL specified by url
 func extractHostPort(newURL string) string {
 	if newURL == "" {
@@ -916,6 +1086,13 @@ func isLocationInLocationList(location interface{}, rawLocationList string) bool
 	return false
 }
 
+// isTLSRedirectException returns true when the location of the host is never redirected
+// to HTTPS, because of the no-tls-redirect-locations of the ConfigMap or of its Ingress
+func isTLSRedirectException(location *ingress.Location, hostname string, exceptions []tlsredirect.Exception) bool {
+	return tlsredirect.Match(exceptions, hostname, location.Path) ||
+		tlsredirect.Match(location.Rewrite.NoTLSRedirectLocations, hostname, location.Path)
+}
+
 func isLocationAllowed(input interface{}) bool {
 	loc, ok := input.(*ingress.Location)
 	if !ok {
@@ -954,11 +1131,89 @@ func buildUpstreamName(loc interface{}) string {
 		return ""
 	}
 
+	if location.Collapsed != nil {
+		return collapsedVariable(location, "upstream")
+	}
+
 	upstreamName := location.Backend
 
 	return upstreamName
 }
 
+// collapsedVariable returns the variable with the value of the Host
+// header in a location of a collapsed server
+func collapsedVariable(loc interface{}, name string) string {
+	location, ok := loc.(*ingress.Location)
+	if !ok {
+		klog.Errorf("expected a '*ingress.Location' type but %T was returned", loc)
+		return ""
+	}
+
+	if location.Collapsed == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("$collapsed_%s_%s", location.Collapsed.ID, name)
+}
+
+// buildCollapsedMaps returns the maps from the Host header to the values of
+// the locations of the collapsed servers
+func buildCollapsedMaps(input interface{}) string {
+	servers, ok := input.([]*ingress.Server)
+	if !ok {
+		klog.Errorf("expected a '[]*ingress.Server' type but %T was returned", input)
+		return ""
+	}
+
+	var maps strings.Builder
+	for _, server := range servers {
+		for _, location := range server.Locations {
+			if location.Collapsed == nil {
+				continue
+			}
+
+			values := map[string][]string{}
+			for _, host := range location.Collapsed.Hosts {
+				info := getIngressInformation(host.Ingress, host.Hostname, location.IngressPath)
+				values["upstream"] = append(values["upstream"], host.Backend)
+				values["namespace"] = append(values["namespace"], info.Namespace)
+				values["ingress_name"] = append(values["ingress_name"], info.Rule)
+				values["service_name"] = append(values["service_name"], info.Service)
+				values["service_port"] = append(values["service_port"], info.ServicePort)
+			}
+
+			for _, name := range []string{"upstream", "namespace", "ingress_name", "service_name", "service_port"} {
+				fmt.Fprintf(&maps, "map $host %s {\n", collapsedVariable(location, name))
+				for i, host := range location.Collapsed.Hosts {
+					fmt.Fprintf(&maps, "    %s %q;\n", host.Hostname, values[name][i])
+				}
+				maps.WriteString("}\n\n")
+			}
+		}
+	}
+
+	return maps.String()
+}
+
+// buildAllowedHTTPMethods rejects the requests using a method outside of
+// the ones allowed in the location with the configured status code
+func buildAllowedHTTPMethods(loc interface{}, statusCode int) string {
+	location, ok := loc.(*ingress.Location)
+	if !ok {
+		klog.Errorf("expected a '*ingress.Location' type but %T was returned", loc)
+		return ""
+	}
+
+	if len(location.AllowedHTTPMethods) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`if ($request_method !~ ^(%v)$) {
+                add_header Allow "%v" always;
+                return %v;
+            }`, strings.Join(location.AllowedHTTPMethods, "|"), strings.Join(location.AllowedHTTPMethods, ", "), statusCode)
+}
+
 func buildNextUpstream(i, r interface{}) string {
 	nextUpstream, ok := i.(string)
 	if !ok {
@@ -1153,6 +1408,43 @@ func buildForwardedFor(input interface{}) string {
 	return fmt.Sprintf("$http_%v", ffh)
 }
 
+// buildForwardedElement returns the element of the Forwarded header (RFC 7239)
+// describing the request received by NGINX, quoted for the map it is used in
+func buildForwardedElement(c interface{}) string {
+	cfg, ok := c.(config.Configuration)
+	if !ok {
+		klog.Errorf("expected a 'config.Configuration' type but %T was returned", c)
+		return ""
+	}
+
+	params := make([]string, 0, 4)
+	if cfg.ForwardedHeaderBy != "" {
+		params = append(params, "by="+cfg.ForwardedHeaderBy)
+	}
+
+	// the host can contain a port and must be quoted
+	params = append(params,
+		"for=$forwarded_for_node",
+		`host=\"$best_http_host\"`,
+		"proto=$pass_access_scheme",
+	)
+
+	return strings.Join(params, ";")
+}
+
+// useForwardedForHops returns true when the client address is the entry of the
+// forwarded-for header selected by forwarded-for-hops. The address is extracted
+// in the rewrite phase, so the realip module is configured in the locations.
+func useForwardedForHops(c interface{}) bool {
+	cfg, ok := c.(config.Configuration)
+	if !ok {
+		klog.Errorf("expected a 'config.Configuration' type but %T was returned", c)
+		return false
+	}
+
+	return cfg.UseForwardedHeaders && !cfg.UseProxyProtocol && cfg.ForwardedForHops > 0
+}
+
 func buildAuthSignURL(authSignURL, authRedirectParam string) string {
 	u, err := url.Parse(authSignURL)
 	if err != nil {
@@ -1238,25 +1530,53 @@ func proxySetHeader(loc interface{}) string {
 	return "proxy_set_header"
 }
 
+type customErrorDeps struct {
+	Name               string
+	UpstreamName       string
+	ErrorCodes         []int
+	EnableMetrics      bool
+	ModsecurityEnabled bool
+	Fallback           *customErrorDeps
+}
+
 // buildCustomErrorDeps is a utility function returning a struct wrapper with
-// the data required to build the 'CUSTOM_ERRORS' template
-func buildCustomErrorDeps(upstreamName string, errorCodes []int, enableMetrics, modsecurityEnabled bool) interface{} {
-	return struct {
-		UpstreamName       string
-		ErrorCodes         []int
-		EnableMetrics      bool
-		ModsecurityEnabled bool
-	}{
+// the data required to build the 'CUSTOM_ERRORS' template. When a fallback
+// upstream is set, the errors of the custom error locations are sent to it.
+func buildCustomErrorDeps(upstreamName string, errorCodes []int, enableMetrics, modsecurityEnabled bool, fallbackUpstreamName string) interface{} {
+	deps := &customErrorDeps{
+		Name:               buildCustomErrorLocationName(upstreamName, fallbackUpstreamName),
 		UpstreamName:       upstreamName,
 		ErrorCodes:         errorCodes,
 		EnableMetrics:      enableMetrics,
 		ModsecurityEnabled: modsecurityEnabled,
 	}
+
+	if fallbackUpstreamName != "" {
+		deps.Fallback = &customErrorDeps{
+			Name:               deps.Name + "_fallback",
+			UpstreamName:       fallbackUpstreamName,
+			ErrorCodes:         errorCodes,
+			EnableMetrics:      enableMetrics,
+			ModsecurityEnabled: modsecurityEnabled,
+		}
+	}
+
+	return deps
+}
+
+// buildCustomErrorLocationName returns the prefix of the name of the
+// locations rendering the custom errors of an upstream
+func buildCustomErrorLocationName(upstreamName, fallbackUpstreamName string) string {
+	if fallbackUpstreamName == "" {
+		return fmt.Sprintf("custom_%v", upstreamName)
+	}
+	return fmt.Sprintf("custom_%v_%v", upstreamName, fallbackUpstreamName)
 }
 
 type errorLocation struct {
-	UpstreamName string
-	Codes        []int
+	UpstreamName         string
+	FallbackUpstreamName string
+	Codes                []int
 }
 
 // buildCustomErrorLocationsPerServer is a utility function which will collect all
@@ -1272,9 +1592,13 @@ func buildCustomErrorLocationsPerServer(input interface{}) []errorLocation {
 		return nil
 	}
 
-	codesMap := make(map[string]map[int]bool)
+	type upstreams struct {
+		backend, fallback string
+	}
+
+	codesMap := make(map[upstreams]map[int]bool)
 	for _, loc := range server.Locations {
-		backendUpstream := loc.DefaultBackendUpstreamName
+		backendUpstream := upstreams{loc.DefaultBackendUpstreamName, loc.CustomErrorsFallbackUpstreamName}
 
 		var dedupedCodes map[int]bool
 		if existingMap, ok := codesMap[backendUpstream]; ok {
@@ -1298,18 +1622,85 @@ func buildCustomErrorLocationsPerServer(input interface{}) []errorLocation {
 		}
 		sort.Ints(codesForUpstream)
 		errorLocations = append(errorLocations, errorLocation{
-			UpstreamName: upstream,
-			Codes:        codesForUpstream,
+			UpstreamName:         upstream.backend,
+			FallbackUpstreamName: upstream.fallback,
+			Codes:                codesForUpstream,
 		})
 	}
 
 	sort.Slice(errorLocations, func(i, j int) bool {
+		if errorLocations[i].UpstreamName == errorLocations[j].UpstreamName {
+			return errorLocations[i].FallbackUpstreamName < errorLocations[j].FallbackUpstreamName
+		}
 		return errorLocations[i].UpstreamName < errorLocations[j].UpstreamName
 	})
 
 	return errorLocations
 }
 
+// streamLogFormatName returns the name of the log format of a TCP or UDP service
+func streamLogFormatName(svc *ingress.L4Service) string {
+	return fmt.Sprintf("log_stream_%v_%v", strings.ToLower(string(svc.Backend.Protocol)), svc.Port)
+}
+
+// buildStreamLogFormat returns the log_format directive of a TCP or UDP
+// service with a custom log format
+func buildStreamLogFormat(input interface{}) string {
+	svc, ok := input.(ingress.L4Service)
+	if !ok {
+		klog.Errorf("expected an 'ingress.L4Service' type but %T was returned", input)
+		return ""
+	}
+
+	if svc.AccessLog.Disabled || svc.AccessLog.Format == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("log_format %v '%v';", streamLogFormatName(&svc), svc.AccessLog.Format)
+}
+
+// buildStreamAccessLog returns the access_log directive of a TCP or UDP
+// service, or an empty string when the one of the stream context applies
+func buildStreamAccessLog(input, c interface{}) string {
+	svc, ok := input.(ingress.L4Service)
+	if !ok {
+		klog.Errorf("expected an 'ingress.L4Service' type but %T was returned", input)
+		return ""
+	}
+
+	cfg, ok := c.(config.Configuration)
+	if !ok {
+		klog.Errorf("expected a 'config.Configuration' type but %T was returned", c)
+		return ""
+	}
+
+	if svc.AccessLog.Disabled {
+		return "access_log off;"
+	}
+
+	if svc.AccessLog.Path == "" && svc.AccessLog.Format == "" {
+		return ""
+	}
+
+	path := svc.AccessLog.Path
+	if path == "" {
+		path = cfg.StreamAccessLogPath
+	}
+	if path == "" {
+		path = cfg.AccessLogPath
+	}
+
+	format := "log_stream"
+	if svc.AccessLog.Format != "" {
+		format = streamLogFormatName(&svc)
+	}
+
+	if cfg.AccessLogParams != "" {
+		return fmt.Sprintf("access_log %v %v %v;", path, format, cfg.AccessLogParams)
+	}
+	return fmt.Sprintf("access_log %v %v;", path, format)
+}
+
 func opentelemetryPropagateContext(location *ingress.Location) string {
 	if location == nil {
 		return ""
@@ -1366,26 +1757,16 @@ func buildHTTPListener(t, s interface{}) string {
 		return ""
 	}
 
-	addrV4 := []string{""}
-	if len(tc.Cfg.BindAddressIpv4) > 0 {
-		addrV4 = tc.Cfg.BindAddressIpv4
-	}
-
 	co := commonListenOptions(&tc, hostname)
 
-	out = append(out, httpListener(addrV4, co, &tc)...)
-
-	if !tc.IsIPV6Enabled {
-		return strings.Join(out, "\n")
+	ipv4, ipv6 := listenerFamilies(&tc, config.HTTPListener)
+	if ipv4 {
+		out = append(out, httpListener(ipv4Addresses(&tc), co, &tc)...)
 	}
-
-	addrV6 := []string{"[::]"}
-	if len(tc.Cfg.BindAddressIpv6) > 0 {
-		addrV6 = tc.Cfg.BindAddressIpv6
+	if ipv6 {
+		out = append(out, httpListener(ipv6Addresses(&tc), co, &tc)...)
 	}
 
-	out = append(out, httpListener(addrV6, co, &tc)...)
-
 	return strings.Join(out, "\n")
 }
 
@@ -1406,27 +1787,134 @@ func buildHTTPSListener(t, s interface{}) string {
 
 	co := commonListenOptions(&tc, hostname)
 
-	addrV4 := []string{""}
-	if len(tc.Cfg.BindAddressIpv4) > 0 {
-		addrV4 = tc.Cfg.BindAddressIpv4
+	ipv4, ipv6 := listenerFamilies(&tc, config.HTTPSListener)
+	if ipv4 {
+		out = append(out, httpsListener(ipv4Addresses(&tc), co, &tc)...)
+	}
+	if ipv6 {
+		out = append(out, httpsListener(ipv6Addresses(&tc), co, &tc)...)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// buildExtraListeners returns the listen directives for the additional
+// ports of a server. Options valid only once per port, like reuseport
+// or backlog, are not set because the port can be shared by many servers.
+func buildExtraListeners(t, s interface{}) string {
+	tc, ok := t.(config.TemplateConfig)
+	if !ok {
+		klog.Errorf("expected a 'config.TemplateConfig' type but %T was returned", t)
+		return ""
 	}
 
-	out = append(out, httpsListener(addrV4, co, &tc)...)
+	server, ok := s.(*ingress.Server)
+	if !ok {
+		klog.Errorf("expected an '*ingress.Server' type but %T was returned", s)
+		return ""
+	}
 
-	if !tc.IsIPV6Enabled {
-		return strings.Join(out, "\n")
+	options := func(ssl bool) string {
+		var opts []string
+		if tc.Cfg.UseProxyProtocol {
+			opts = append(opts, "proxy_protocol")
+		}
+		if ssl {
+			opts = append(opts, "ssl")
+		}
+		return strings.Join(opts, " ")
 	}
 
-	addrV6 := []string{"[::]"}
-	if len(tc.Cfg.BindAddressIpv6) > 0 {
-		addrV6 = tc.Cfg.BindAddressIpv6
+	listeners := func(listener string, ports []int, ssl bool) []string {
+		var addresses []string
+		ipv4, ipv6 := listenerFamilies(&tc, listener)
+		if ipv4 {
+			addresses = append(addresses, ipv4Addresses(&tc)...)
+		}
+		if ipv6 {
+			addresses = append(addresses, ipv6Addresses(&tc)...)
+		}
+
+		out := make([]string, 0)
+		for _, address := range addresses {
+			for _, port := range ports {
+				out = append(out, extraListener(address, port, options(ssl)))
+			}
+		}
+		return out
 	}
 
-	out = append(out, httpsListener(addrV6, co, &tc)...)
+	out := listeners(config.HTTPListener, server.ExtraListenPorts.HTTP, false)
+	out = append(out, listeners(config.HTTPSListener, server.ExtraListenPorts.HTTPS, true)...)
 
 	return strings.Join(out, "\n")
 }
 
+func extraListener(address string, port int, options string) string {
+	lo := []string{"listen"}
+
+	if address == "" {
+		lo = append(lo, fmt.Sprintf("%v", port))
+	} else {
+		lo = append(lo, fmt.Sprintf("%v:%v", address, port))
+	}
+
+	if options != "" {
+		lo = append(lo, options)
+	}
+
+	return strings.Join(lo, " ") + ";"
+}
+
+// listenerFamilies returns if a listener type accepts IPv4 and IPv6 connections.
+// Listeners not enabled for IPv6 in a pod always accept IPv4 connections.
+func listenerFamilies(tc *config.TemplateConfig, listener string) (ipv4, ipv6 bool) {
+	ipv6 = tc.IsIPV6Enabled && slices.Contains(tc.Cfg.IPv6Listeners, listener)
+	ipv4 = !ipv6 || slices.Contains(tc.Cfg.IPv4Listeners, listener)
+	return ipv4, ipv6
+}
+
+// ipv4Addresses returns the IPv4 addresses to listen on. An empty
+// string means all the addresses.
+func ipv4Addresses(tc *config.TemplateConfig) []string {
+	if len(tc.Cfg.BindAddressIpv4) > 0 {
+		return tc.Cfg.BindAddressIpv4
+	}
+	return []string{""}
+}
+
+// ipv6Addresses returns the IPv6 addresses to listen on
+func ipv6Addresses(tc *config.TemplateConfig) []string {
+	if len(tc.Cfg.BindAddressIpv6) > 0 {
+		return tc.Cfg.BindAddressIpv6
+	}
+	return []string{"[::]"}
+}
+
+// listensOnIPv4 returns if the listener type accepts IPv4 connections
+func listensOnIPv4(t interface{}, listener string) bool {
+	tc, ok := t.(config.TemplateConfig)
+	if !ok {
+		klog.Errorf("expected a 'config.TemplateConfig' type but %T was returned", t)
+		return false
+	}
+
+	ipv4, _ := listenerFamilies(&tc, listener)
+	return ipv4
+}
+
+// listensOnIPv6 returns if the listener type accepts IPv6 connections
+func listensOnIPv6(t interface{}, listener string) bool {
+	tc, ok := t.(config.TemplateConfig)
+	if !ok {
+		klog.Errorf("expected a 'config.TemplateConfig' type but %T was returned", t)
+		return false
+	}
+
+	_, ipv6 := listenerFamilies(&tc, listener)
+	return ipv6
+}
+
 func commonListenOptions(template *config.TemplateConfig, hostname string) string {
 	var out []string
 
@@ -1632,6 +2120,76 @@ proxy_pass "%v";
 	return buffer.String()
 }
 
+// grpcErrorCodes contains the status codes of the upstream failures
+// translated to a grpc-status with translate-grpc-errors
+var grpcErrorCodes = []int{502, 503, 504}
+
+// buildGRPCErrorLocations returns the locations responding with the
+// grpc-status of the upstream failures
+func buildGRPCErrorLocations(c interface{}) string {
+	cfg, ok := c.(config.Configuration)
+	if !ok {
+		klog.Errorf("expected a 'config.Configuration' type but %T was returned", c)
+		return ""
+	}
+
+	if !cfg.TranslateGRPCErrors {
+		return ""
+	}
+
+	var buffer bytes.Buffer
+	for _, code := range grpcErrorCodes {
+		buffer.WriteString(fmt.Sprintf(`location @grpc_error_%d {
+internal;
+set $grpc_error_status %d;
+content_by_lua_file /etc/nginx/lua/nginx/ngx_conf_grpc_error.lua;
+}
+
+`, code, code))
+	}
+
+	return buffer.String()
+}
+
+// buildGRPCErrorPages returns the error_page directives of a location using
+// the GRPC or GRPCS backend protocol. The error pages of the custom-http-errors
+// ConfigMap key are added when the location has no custom error pages, because
+// NGINX does not inherit them anymore.
+func buildGRPCErrorPages(l, c interface{}) string {
+	location, ok := l.(*ingress.Location)
+	if !ok {
+		klog.Errorf("expected an '*ingress.Location' type but %T was returned", l)
+		return ""
+	}
+
+	cfg, ok := c.(config.Configuration)
+	if !ok {
+		klog.Errorf("expected a 'config.Configuration' type but %T was returned", c)
+		return ""
+	}
+
+	if !cfg.TranslateGRPCErrors || (location.BackendProtocol != "GRPC" && location.BackendProtocol != "GRPCS") {
+		return ""
+	}
+
+	errorPages := make([]string, 0, len(grpcErrorCodes)+len(cfg.CustomHTTPErrors))
+	for _, code := range grpcErrorCodes {
+		errorPages = append(errorPages, fmt.Sprintf("error_page %d = @grpc_error_%d;", code, code))
+	}
+
+	if len(location.CustomHTTPErrors) == 0 {
+		name := buildCustomErrorLocationName("upstream-default-backend", "")
+		for _, code := range cfg.CustomHTTPErrors {
+			if slices.Contains(grpcErrorCodes, code) {
+				continue
+			}
+			errorPages = append(errorPages, fmt.Sprintf("error_page %d = @%s_%d;", code, name, code))
+		}
+	}
+
+	return strings.Join(errorPages, "\n")
+}
+
 // shouldLoadAuthDigestModule determines whether or not the ngx_http_auth_digest_module module needs to be loaded.
 func shouldLoadAuthDigestModule(s interface{}) bool {
 	servers, ok := s.([]*ingress.Server)
@@ -1655,6 +2213,25 @@ func shouldLoadAuthDigestModule(s interface{}) bool {
 	return false
 }
 
+// shouldConfigureSliceCache determines whether or not the cache of the byte-range requests is used by a location.
+func shouldConfigureSliceCache(s interface{}) bool {
+	servers, ok := s.([]*ingress.Server)
+	if !ok {
+		klog.Errorf("expected an '[]*ingress.Server' type but %T was returned", s)
+		return false
+	}
+
+	for _, server := range servers {
+		for _, location := range server.Locations {
+			if location.Ranges.SliceSize != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // buildServerName ensures wildcard hostnames are valid
 func buildServerName(hostname string) string {
 	if !strings.HasPrefix(hostname, "*") {
@@ -1678,17 +2255,13 @@ func buildCorsOriginRegex(corsOrigins []string) string {
 		return "set $http_origin *;\nset $cors 'true';"
 	}
 
-	originsRegex := "if ($http_origin ~* ("
-	for i, origin := range corsOrigins {
-		originTrimmed := strings.TrimSpace(origin)
-		if originTrimmed != "" {
-			builtOrigin := buildOriginRegex(originTrimmed)
-			originsRegex += builtOrigin
-			if i != len(corsOrigins)-1 {
-				originsRegex += "|"
-			}
+	origins := make([]string, 0, len(corsOrigins))
+	for _, origin := range corsOrigins {
+		if originTrimmed := strings.TrimSpace(origin); originTrimmed != "" {
+			origins = append(origins, buildOriginRegex(originTrimmed))
 		}
 	}
-	originsRegex += ")$ ) { set $cors 'true'; }"
-	return originsRegex
+
+	// the whole Origin must match, not only its end
+	return fmt.Sprintf("if ($http_origin ~* ^(%s)$ ) { set $cors 'true'; }", strings.Join(origins, "|"))
 }
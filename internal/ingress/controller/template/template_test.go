@@ -25,6 +25,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -35,11 +36,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations/authreq"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/extralistenports"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/httptransform"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/modsecurity"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/opentelemetry"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/ranges"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/ratelimit"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/rewrite"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/routeif"
 	"k8s.io/ingress-nginx/internal/ingress/controller/config"
+	"k8s.io/ingress-nginx/internal/ingress/tlsredirect"
+	"k8s.io/ingress-nginx/internal/ingress/transform"
 	"k8s.io/ingress-nginx/internal/nginx"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
 )
@@ -650,6 +657,45 @@ func TestShouldApplyAuthUpstream(t *testing.T) {
 	}
 }
 
+func TestShouldShareAuthCache(t *testing.T) {
+	loc := &ingress.Location{
+		ExternalAuth: authreq.Config{
+			URL:          fooAuthHost,
+			AuthCacheKey: "$remote_user$http_authorization",
+		},
+		Path:             "/cat",
+		EnableGlobalAuth: true,
+	}
+
+	cfg := config.Configuration{}
+	if shouldShareAuthCache(loc, cfg) {
+		t.Errorf("expected false without a memcached host")
+	}
+
+	cfg.AuthMemcachedHost = "memcached.default.svc"
+	if !shouldShareAuthCache(loc, cfg) {
+		t.Errorf("expected true with a memcached host and a cache key")
+	}
+
+	loc.ExternalAuth.ResponseHeaders = []string{"X-User"}
+	if shouldShareAuthCache(loc, cfg) {
+		t.Errorf("expected false with response headers")
+	}
+
+	loc.ExternalAuth = authreq.Config{}
+	if shouldShareAuthCache(loc, cfg) {
+		t.Errorf("expected false without external auth")
+	}
+
+	cfg.GlobalExternalAuth = config.GlobalExternalAuth{
+		URL:          "foo.com/global-auth",
+		AuthCacheKey: "$http_authorization",
+	}
+	if !shouldShareAuthCache(loc, cfg) {
+		t.Errorf("expected true with a global auth cache key")
+	}
+}
+
 func TestExtractHostPort(t *testing.T) {
 	testCases := []struct {
 		title    string
@@ -839,6 +885,47 @@ func TestIsLocationAllowed(t *testing.T) {
 	}
 }
 
+func TestBuildForwardedElement(t *testing.T) {
+	cfg := config.NewDefault()
+
+	expected := `for=$forwarded_for_node;host=\"$best_http_host\";proto=$pass_access_scheme`
+	if actual := buildForwardedElement(cfg); actual != expected {
+		t.Errorf("Expected '%v' but returned '%v'", expected, actual)
+	}
+
+	cfg.ForwardedHeaderBy = "_ingress"
+	expected = `by=_ingress;for=$forwarded_for_node;host=\"$best_http_host\";proto=$pass_access_scheme`
+	if actual := buildForwardedElement(cfg); actual != expected {
+		t.Errorf("Expected '%v' but returned '%v'", expected, actual)
+	}
+
+	if actual := buildForwardedElement(nil); actual != "" {
+		t.Errorf("Expected an empty string but returned '%v'", actual)
+	}
+}
+
+func TestUseForwardedForHops(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.ForwardedForHops = 2
+	if useForwardedForHops(cfg) {
+		t.Errorf("Expected false without use-forwarded-headers")
+	}
+
+	cfg.UseForwardedHeaders = true
+	if !useForwardedForHops(cfg) {
+		t.Errorf("Expected true with use-forwarded-headers and forwarded-for-hops")
+	}
+
+	cfg.UseProxyProtocol = true
+	if useForwardedForHops(cfg) {
+		t.Errorf("Expected false with use-proxy-protocol")
+	}
+
+	if useForwardedForHops(nil) {
+		t.Errorf("Expected false for an invalid type")
+	}
+}
+
 func TestBuildForwardedFor(t *testing.T) {
 	invalidType := &ingress.Ingress{}
 	expected := ""
@@ -1058,6 +1145,68 @@ func TestIsLocationInLocationList(t *testing.T) {
 	}
 }
 
+func TestIsTLSRedirectException(t *testing.T) {
+	testCases := []struct {
+		path       string
+		raw        string
+		annotation string
+		expected   bool
+	}{
+		{"/match", "/match", "", true},
+		{"/match", ",/match", "", true},
+		{"/match", "/dontmatch", "", false},
+		{"/match", ",/dontmatch", "", false},
+		{"/match", "/dontmatch,/match", "", true},
+		{"/match", "/dontmatch,/dontmatcheither", "", false},
+		{"/match", "example.com/match", "", true},
+		{"/match", "other.com/match", "", false},
+		{"/match", "*.com~^/m.tch$", "", true},
+		{"/match", "", "/match", true},
+		{"/match", "/dontmatch", "~^/ma", true},
+		{"/match", "", "/dontmatch", false},
+	}
+
+	for _, testCase := range testCases {
+		exceptions, err := tlsredirect.Parse(testCase.raw)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		annotation, err := tlsredirect.Parse(testCase.annotation)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		location := &ingress.Location{Path: testCase.path, Rewrite: rewrite.Config{NoTLSRedirectLocations: annotation}}
+		result := isTLSRedirectException(location, "example.com", exceptions)
+		if result != testCase.expected {
+			t.Errorf("expected %v but returned %v, path: '%s', raw: '%s', annotation: '%s'", testCase.expected, result, testCase.path, testCase.raw, testCase.annotation)
+		}
+	}
+}
+
+func TestBuildAllowedHTTPMethods(t *testing.T) {
+	if actual := buildAllowedHTTPMethods(&ingress.Ingress{}, 405); actual != "" {
+		t.Errorf("Expected an empty string but returned '%v'", actual)
+	}
+
+	loc := &ingress.Location{}
+	if actual := buildAllowedHTTPMethods(loc, 405); actual != "" {
+		t.Errorf("Expected an empty string but returned '%v'", actual)
+	}
+
+	loc.AllowedHTTPMethods = []string{"GET", "HEAD"}
+	actual := buildAllowedHTTPMethods(loc, 403)
+	for _, expected := range []string{
+		"if ($request_method !~ ^(GET|HEAD)$) {",
+		`add_header Allow "GET, HEAD" always;`,
+		"return 403;",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("Expected '%v' in '%v'", expected, actual)
+		}
+	}
+}
+
 func TestBuildUpstreamName(t *testing.T) {
 	invalidType := &ingress.Ingress{}
 	expected := ""
@@ -1104,6 +1253,88 @@ func TestBuildUpstreamName(t *testing.T) {
 	}
 }
 
+func TestBuildCollapsedMaps(t *testing.T) {
+	if actual := buildCollapsedMaps(&ingress.Ingress{}); actual != "" {
+		t.Errorf("Expected an empty string but returned '%v'", actual)
+	}
+
+	newIngress := func(name string) *ingress.Ingress {
+		return &ingress.Ingress{
+			Ingress: networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenants"},
+				Spec: networking.IngressSpec{
+					DefaultBackend: &networking.IngressBackend{
+						Service: &networking.IngressServiceBackend{
+							Name: name,
+							Port: networking.ServiceBackendPort{Number: 80},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	loc := &ingress.Location{
+		Path:    "/",
+		Backend: "tenants-a-80",
+		Collapsed: &ingress.CollapsedLocation{
+			ID: "3_0",
+			Hosts: []ingress.CollapsedHost{
+				{Hostname: "a.example.com", Backend: "tenants-a-80", Ingress: newIngress("a")},
+				{Hostname: "b.example.com", Backend: "tenants-b-80", Ingress: newIngress("b")},
+			},
+		},
+	}
+
+	if actual := buildUpstreamName(loc); actual != "$collapsed_3_0_upstream" {
+		t.Errorf("Expected '$collapsed_3_0_upstream' but returned '%v'", actual)
+	}
+
+	actual := buildCollapsedMaps([]*ingress.Server{{Hostname: "a.example.com", Locations: []*ingress.Location{loc}}})
+	for _, expected := range []string{
+		"map $host $collapsed_3_0_upstream {\n    a.example.com \"tenants-a-80\";\n    b.example.com \"tenants-b-80\";\n}",
+		"map $host $collapsed_3_0_namespace {\n    a.example.com \"tenants\";\n    b.example.com \"tenants\";\n}",
+		"map $host $collapsed_3_0_ingress_name {\n    a.example.com \"a\";\n    b.example.com \"b\";\n}",
+		"map $host $collapsed_3_0_service_name {\n    a.example.com \"a\";\n    b.example.com \"b\";\n}",
+		"map $host $collapsed_3_0_service_port {\n    a.example.com \"80\";\n    b.example.com \"80\";\n}",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("Expected '%v' in '%v'", expected, actual)
+		}
+	}
+}
+
+func TestBuildDefaultServerReturn(t *testing.T) {
+	testCases := map[string]struct {
+		code     int
+		body     string
+		redirect string
+		expected string
+	}{
+		"default backend":  {0, "", "", ""},
+		"status code":      {404, "", "", "return 404;"},
+		"status with body": {421, "no $host here", "", `return 421 "no ${literal_dollar}host here";`},
+		"close connection": {444, "ignored", "", "return 444;"},
+		"redirect":         {404, "", "https://example.com/", `return 308 "https://example.com/";`},
+	}
+
+	for n, tc := range testCases {
+		cfg := config.NewDefault()
+		cfg.DefaultServerReturnCode = tc.code
+		cfg.DefaultServerBody = tc.body
+		cfg.DefaultServerRedirect = tc.redirect
+
+		actual := buildDefaultServerReturn(cfg)
+		if actual != tc.expected {
+			t.Errorf("%v: expected '%v' but returned '%v'", n, tc.expected, actual)
+		}
+	}
+
+	if actual := buildDefaultServerReturn(nil); actual != "" {
+		t.Errorf("expected an empty string but returned '%v'", actual)
+	}
+}
+
 func TestEscapeLiteralDollar(t *testing.T) {
 	escapedPath := escapeLiteralDollar("/$")
 	expected := "/${literal_dollar}"
@@ -1579,6 +1810,55 @@ func TestBuildCustomErrorLocationsPerServer(t *testing.T) {
 	}
 }
 
+func TestBuildCustomErrorLocationsWithFallback(t *testing.T) {
+	server := &ingress.Server{
+		Locations: []*ingress.Location{
+			{
+				DefaultBackendUpstreamName: "custom-default-backend-test",
+				CustomHTTPErrors:           []int{404},
+			},
+			{
+				DefaultBackendUpstreamName:       "custom-default-backend-test",
+				CustomErrorsFallbackUpstreamName: "custom-errors-fallback-test",
+				CustomHTTPErrors:                 []int{503},
+			},
+		},
+	}
+
+	expected := []errorLocation{
+		{
+			UpstreamName: "custom-default-backend-test",
+			Codes:        []int{404},
+		},
+		{
+			UpstreamName:         "custom-default-backend-test",
+			FallbackUpstreamName: "custom-errors-fallback-test",
+			Codes:                []int{503},
+		},
+	}
+
+	response := buildCustomErrorLocationsPerServer(server)
+	if !reflect.DeepEqual(expected, response) {
+		t.Errorf("Expected %+v but got %+v", expected, response)
+	}
+
+	deps, ok := buildCustomErrorDeps("custom-default-backend-test", []int{503}, false, false, "custom-errors-fallback-test").(*customErrorDeps)
+	if !ok {
+		t.Fatalf("expected *customErrorDeps but got %T", deps)
+	}
+	if deps.Name != "custom_custom-default-backend-test_custom-errors-fallback-test" {
+		t.Errorf("unexpected custom error location name %v", deps.Name)
+	}
+	if deps.Fallback == nil || deps.Fallback.UpstreamName != "custom-errors-fallback-test" || deps.Fallback.Name != deps.Name+"_fallback" {
+		t.Errorf("unexpected fallback %+v", deps.Fallback)
+	}
+
+	deps, ok = buildCustomErrorDeps("upstream-default-backend", []int{404}, false, false, "").(*customErrorDeps)
+	if !ok || deps.Name != "custom_upstream-default-backend" || deps.Fallback != nil {
+		t.Errorf("unexpected custom error deps %+v", deps)
+	}
+}
+
 func TestProxySetHeader(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1648,6 +1928,97 @@ func TestBuildOpenTelemetry(t *testing.T) {
 	}
 }
 
+func TestBuildExtraListeners(t *testing.T) {
+	invalidType := &ingress.Ingress{}
+	expected := ""
+	actual := buildExtraListeners(invalidType, &ingress.Server{})
+
+	if expected != actual {
+		t.Errorf("Expected '%v' but returned '%v'", expected, actual)
+	}
+
+	server := &ingress.Server{
+		Hostname: "foo.bar",
+		ExtraListenPorts: extralistenports.Config{
+			HTTP:  []int{8080},
+			HTTPS: []int{8443},
+		},
+	}
+
+	cfgWith := func(f func(*config.Configuration)) config.Configuration {
+		cfg := config.NewDefault()
+		f(&cfg)
+		return cfg
+	}
+
+	testCases := []struct {
+		title    string
+		tc       config.TemplateConfig
+		expected string
+	}{
+		{
+			"default addresses",
+			config.TemplateConfig{Cfg: cfgWith(func(c *config.Configuration) { c.ReusePort = true })},
+			"listen 8080;\nlisten 8443 ssl;",
+		},
+		{
+			"proxy protocol and IPv6",
+			config.TemplateConfig{Cfg: cfgWith(func(c *config.Configuration) { c.UseProxyProtocol = true }), IsIPV6Enabled: true},
+			"listen 8080 proxy_protocol;\nlisten [::]:8080 proxy_protocol;\nlisten 8443 proxy_protocol ssl;\nlisten [::]:8443 proxy_protocol ssl;",
+		},
+		{
+			"bind addresses",
+			config.TemplateConfig{Cfg: cfgWith(func(c *config.Configuration) { c.BindAddressIpv4 = []string{"1.1.1.1"} })},
+			"listen 1.1.1.1:8080;\nlisten 1.1.1.1:8443 ssl;",
+		},
+		{
+			"IPv6 only HTTPS",
+			config.TemplateConfig{Cfg: cfgWith(func(c *config.Configuration) { c.IPv4Listeners = []string{"http"} }), IsIPV6Enabled: true},
+			"listen 8080;\nlisten [::]:8080;\nlisten [::]:8443 ssl;",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			actual := buildExtraListeners(tc.tc, server)
+			if tc.expected != actual {
+				t.Errorf("Expected '%v' but returned '%v'", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestListenerFamilies(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.IPv4Listeners = []string{config.HTTPListener, config.StatusListener}
+	cfg.IPv6Listeners = []string{config.HTTPSListener, config.StreamListener, config.StatusListener}
+
+	testCases := []struct {
+		listener     string
+		ipv6Enabled  bool
+		expectedIPv4 bool
+		expectedIPv6 bool
+	}{
+		{config.HTTPListener, true, true, false},
+		{config.HTTPSListener, true, false, true},
+		{config.StatusListener, true, true, true},
+		{config.StreamListener, true, false, true},
+		// listeners disabled for IPv4 accept IPv4 connections without IPv6
+		{config.HTTPSListener, false, true, false},
+		{config.StatusListener, false, true, false},
+	}
+
+	for _, tc := range testCases {
+		tplCfg := config.TemplateConfig{Cfg: cfg, IsIPV6Enabled: tc.ipv6Enabled}
+		if ipv4 := listensOnIPv4(tplCfg, tc.listener); ipv4 != tc.expectedIPv4 {
+			t.Errorf("expected IPv4 %v for the %v listener (IPv6 enabled: %v) but returned %v", tc.expectedIPv4, tc.listener, tc.ipv6Enabled, ipv4)
+		}
+		if ipv6 := listensOnIPv6(tplCfg, tc.listener); ipv6 != tc.expectedIPv6 {
+			t.Errorf("expected IPv6 %v for the %v listener (IPv6 enabled: %v) but returned %v", tc.expectedIPv6, tc.listener, tc.ipv6Enabled, ipv6)
+		}
+	}
+}
+
 func TestEnforceRegexModifier(t *testing.T) {
 	invalidType := &ingress.Ingress{}
 	expected := false
@@ -1953,3 +2324,196 @@ func TestCleanConf(t *testing.T) {
 		t.Errorf("cleanConf result don't match with expected: %s", diff)
 	}
 }
+
+func TestBuildStreamAccessLog(t *testing.T) {
+	cfg := config.Configuration{
+		AccessLogPath:   "/var/log/nginx/access.log",
+		AccessLogParams: "buffer=16k",
+	}
+
+	svc := ingress.L4Service{
+		Port:    9000,
+		Backend: ingress.L4Backend{Protocol: apiv1.ProtocolTCP},
+	}
+
+	if directive := buildStreamAccessLog(svc, cfg); directive != "" {
+		t.Errorf("expected no access_log directive but got %q", directive)
+	}
+	if format := buildStreamLogFormat(svc); format != "" {
+		t.Errorf("expected no log_format directive but got %q", format)
+	}
+
+	svc.AccessLog.Format = "$remote_addr $status"
+	if format := buildStreamLogFormat(svc); format != "log_format log_stream_tcp_9000 '$remote_addr $status';" {
+		t.Errorf("unexpected log_format directive %q", format)
+	}
+	if directive := buildStreamAccessLog(svc, cfg); directive != "access_log /var/log/nginx/access.log log_stream_tcp_9000 buffer=16k;" {
+		t.Errorf("unexpected access_log directive %q", directive)
+	}
+
+	svc.AccessLog = ingress.L4AccessLog{Path: "/dev/stdout"}
+	if directive := buildStreamAccessLog(svc, cfg); directive != "access_log /dev/stdout log_stream buffer=16k;" {
+		t.Errorf("unexpected access_log directive %q", directive)
+	}
+
+	svc.AccessLog = ingress.L4AccessLog{Disabled: true, Format: "$status"}
+	if directive := buildStreamAccessLog(svc, cfg); directive != "access_log off;" {
+		t.Errorf("unexpected access_log directive %q", directive)
+	}
+	if format := buildStreamLogFormat(svc); format != "" {
+		t.Errorf("expected no log_format directive but got %q", format)
+	}
+}
+
+func TestShouldConfigureSliceCache(t *testing.T) {
+	servers := []*ingress.Server{
+		{
+			Hostname:  "example.com",
+			Locations: []*ingress.Location{{Path: "/"}},
+		},
+	}
+	if shouldConfigureSliceCache(servers) {
+		t.Errorf("expected the slice cache to be disabled")
+	}
+
+	servers[0].Locations = append(servers[0].Locations, &ingress.Location{
+		Path:   "/videos",
+		Ranges: ranges.Config{SliceSize: "1m"},
+	})
+	if !shouldConfigureSliceCache(servers) {
+		t.Errorf("expected the slice cache to be enabled")
+	}
+}
+
+func TestBuildGRPCErrorPages(t *testing.T) {
+	cfg := config.Configuration{
+		TranslateGRPCErrors: true,
+	}
+	cfg.CustomHTTPErrors = []int{404, 503}
+
+	testCases := []struct {
+		title    string
+		location *ingress.Location
+		cfg      config.Configuration
+		expected string
+	}{
+		{"disabled", &ingress.Location{BackendProtocol: "GRPC"}, config.Configuration{}, ""},
+		{"HTTP backend", &ingress.Location{BackendProtocol: "HTTP"}, cfg, ""},
+		{
+			"gRPC backend with the global custom errors",
+			&ingress.Location{BackendProtocol: "GRPCS"},
+			cfg,
+			`error_page 502 = @grpc_error_502;
+error_page 503 = @grpc_error_503;
+error_page 504 = @grpc_error_504;
+error_page 404 = @custom_upstream-default-backend_404;`,
+		},
+		{
+			"gRPC backend with custom errors",
+			&ingress.Location{BackendProtocol: "GRPC", CustomHTTPErrors: []int{404}},
+			cfg,
+			`error_page 502 = @grpc_error_502;
+error_page 503 = @grpc_error_503;
+error_page 504 = @grpc_error_504;`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			if got := buildGRPCErrorPages(tc.location, tc.cfg); got != tc.expected {
+				t.Errorf("expected\n%v\nbut got\n%v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildGRPCErrorLocations(t *testing.T) {
+	if got := buildGRPCErrorLocations(config.Configuration{}); got != "" {
+		t.Errorf("expected no locations but got %v", got)
+	}
+
+	got := buildGRPCErrorLocations(config.Configuration{TranslateGRPCErrors: true})
+	for _, code := range grpcErrorCodes {
+		if !strings.Contains(got, fmt.Sprintf("location @grpc_error_%d {", code)) {
+			t.Errorf("expected a location for the status %d but got %v", code, got)
+		}
+	}
+}
+
+func TestBuildTransformRules(t *testing.T) {
+	if got := buildTransformRules(&ingress.Location{}); got != "" {
+		t.Errorf("expected no rules but got %v", got)
+	}
+
+	loc := &ingress.Location{
+		HTTPTransform: httptransform.Config{
+			Name: "default/strip-api",
+			Spec: transform.Spec{
+				Path: &transform.Path{StripPrefix: "/api"},
+			},
+		},
+	}
+	got := buildTransformRules(loc)
+	b, err := base64.StdEncoding.DecodeString(got)
+	if err != nil {
+		t.Fatalf("unexpected error decoding %v: %v", got, err)
+	}
+	if expected := `{"path":{"stripPrefix":"/api"}}`; string(b) != expected {
+		t.Errorf("expected %v but got %v", expected, string(b))
+	}
+}
+
+func TestBuildRouteRules(t *testing.T) {
+	if got := buildRouteRules(&ingress.Location{}); got != "" {
+		t.Errorf("expected no rules but got %v", got)
+	}
+
+	loc := &ingress.Location{
+		RouteIf: routeif.Config{
+			Rules: []routeif.Rule{{
+				Expression: `request.method == "POST"`,
+				If: &routeif.Expr{Op: routeif.OpEqual, Args: []*routeif.Expr{
+					{Op: routeif.OpMethod},
+					{Op: routeif.OpString, Value: "POST"},
+				}},
+				Backend: "default-writer-80",
+			}},
+		},
+	}
+	got := buildRouteRules(loc)
+	b, err := base64.StdEncoding.DecodeString(got)
+	if err != nil {
+		t.Fatalf("unexpected error decoding %v: %v", got, err)
+	}
+	expected := `[{"if":{"op":"eq","args":[{"op":"method"},{"op":"str","value":"POST"}]},"backend":"default-writer-80"}]`
+	if string(b) != expected {
+		t.Errorf("expected %v but got %v", expected, string(b))
+	}
+}
+
+func TestBuildCorsOriginRegex(t *testing.T) {
+	if got := buildCorsOriginRegex([]string{"*"}); got != "set $http_origin *;\nset $cors 'true';" {
+		t.Errorf("unexpected configuration for any origin: %q", got)
+	}
+
+	got := buildCorsOriginRegex([]string{"https://app.example.com", "https://*.example.org:8443", " "})
+	expected := `if ($http_origin ~* ^((https://app\.example\.com)|(https://[A-Za-z0-9\-]+\.example\.org:8443))$ ) { set $cors 'true'; }`
+	if got != expected {
+		t.Fatalf("expected %q but got %q", expected, got)
+	}
+
+	re := regexp.MustCompile(`(?i)` + got[len("if ($http_origin ~* "):len(got)-len(" ) { set $cors 'true'; }")])
+	for origin, allowed := range map[string]bool{
+		"https://app.example.com":             true,
+		"https://APP.example.com":             true,
+		"https://web.example.org:8443":        true,
+		"https://a.web.example.org:8443":      false,
+		"https://example.org:8443":            false,
+		"http://evil.https://app.example.com": false,
+		"https://app.example.com.evil.com":    false,
+	} {
+		if re.MatchString(origin) != allowed {
+			t.Errorf("expected the origin %v to be allowed: %v", origin, allowed)
+		}
+	}
+}
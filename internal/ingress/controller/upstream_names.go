@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// hashedUpstreamPrefix is the prefix of the upstream names replaced by a hash
+const hashedUpstreamPrefix = "upstream-"
+
+// hashUpstreamName returns a short and stable name for the upstream
+func hashUpstreamName(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hashedUpstreamPrefix + hex.EncodeToString(sum[:8])
+}
+
+// hashUpstreamNames replaces the names of the upstreams with short hashes in the
+// backends, locations and passthrough backends, keeping the readable names in the
+// backends. The upstream of the default backend keeps its name.
+func hashUpstreamNames(upstreams []*ingress.Backend, servers []*ingress.Server, passUpstreams []*ingress.SSLPassthroughBackend) {
+	hashed := func(name string) string {
+		if name == "" || name == defUpstreamName {
+			return name
+		}
+		return hashUpstreamName(name)
+	}
+
+	for _, upstream := range upstreams {
+		upstream.ReadableName = upstream.Name
+		upstream.Name = hashed(upstream.Name)
+
+		alternatives := make([]string, len(upstream.AlternativeBackends))
+		for i, alternative := range upstream.AlternativeBackends {
+			alternatives[i] = hashed(alternative)
+		}
+		if upstream.AlternativeBackends != nil {
+			upstream.AlternativeBackends = alternatives
+		}
+	}
+
+	for _, server := range servers {
+		for _, location := range server.Locations {
+			location.Backend = hashed(location.Backend)
+			location.DefaultBackendUpstreamName = hashed(location.DefaultBackendUpstreamName)
+			location.CustomErrorsFallbackUpstreamName = hashed(location.CustomErrorsFallbackUpstreamName)
+		}
+	}
+
+	for _, passUpstream := range passUpstreams {
+		passUpstream.Backend = hashed(passUpstream.Backend)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func TestHashUpstreamName(t *testing.T) {
+	name := "a-very-long-namespace-a-very-long-service-name-8080"
+
+	hashed := hashUpstreamName(name)
+	if !strings.HasPrefix(hashed, hashedUpstreamPrefix) {
+		t.Errorf("expected prefix %q in %q", hashedUpstreamPrefix, hashed)
+	}
+	if len(hashed) != len(hashedUpstreamPrefix)+16 {
+		t.Errorf("unexpected length of %q", hashed)
+	}
+	if hashUpstreamName(name) != hashed {
+		t.Errorf("expected a stable hash of %q", name)
+	}
+	if hashUpstreamName(name+"1") == hashed {
+		t.Errorf("expected different hashes for different names")
+	}
+}
+
+func TestHashUpstreamNames(t *testing.T) {
+	upstreams := []*ingress.Backend{
+		{Name: defUpstreamName},
+		{Name: "default-app-80", AlternativeBackends: []string{"default-canary-80"}},
+		{Name: "default-canary-80"},
+	}
+	servers := []*ingress.Server{
+		{
+			Hostname: "example.com",
+			Locations: []*ingress.Location{
+				{Path: "/", Backend: "default-app-80", DefaultBackendUpstreamName: "default-app-80"},
+				{Path: "/other", Backend: defUpstreamName},
+			},
+		},
+	}
+	passUpstreams := []*ingress.SSLPassthroughBackend{
+		{Backend: "default-app-80", Hostname: "example.com"},
+	}
+
+	hashUpstreamNames(upstreams, servers, passUpstreams)
+
+	app := hashUpstreamName("default-app-80")
+	canary := hashUpstreamName("default-canary-80")
+
+	if upstreams[0].Name != defUpstreamName {
+		t.Errorf("expected the default upstream to keep its name but got %q", upstreams[0].Name)
+	}
+	if upstreams[1].Name != app || upstreams[1].ReadableName != "default-app-80" {
+		t.Errorf("unexpected names %q and %q", upstreams[1].Name, upstreams[1].ReadableName)
+	}
+	if upstreams[1].AlternativeBackends[0] != canary || upstreams[2].Name != canary {
+		t.Errorf("expected the alternative backend to be hashed as %q", canary)
+	}
+
+	locations := servers[0].Locations
+	if locations[0].Backend != app || locations[0].DefaultBackendUpstreamName != app {
+		t.Errorf("expected the location upstreams to be hashed as %q", app)
+	}
+	if locations[0].CustomErrorsFallbackUpstreamName != "" {
+		t.Errorf("expected an empty custom errors upstream to stay empty")
+	}
+	if locations[1].Backend != defUpstreamName {
+		t.Errorf("expected the default upstream to keep its name but got %q", locations[1].Backend)
+	}
+	if passUpstreams[0].Backend != app {
+		t.Errorf("expected the passthrough backend to be hashed as %q", app)
+	}
+}
@@ -19,15 +19,15 @@ package controller
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path"
 	"strconv"
 	"strings"
-	"syscall"
 
+	"github.com/pmezard/go-difflib/difflib"
 	api "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/ingress-nginx/internal/nginx"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
 	klog "k8s.io/klog/v2"
 )
@@ -77,73 +77,44 @@ func upstreamServiceNameAndPort(service *networking.IngressServiceBackend) (stri
 // for acceptance (value of net.core.somaxconn)
 // http://nginx.org/en/docs/http/ngx_http_core_module.html#listen
 func sysctlSomaxconn() int {
+	// /proc/sys is not readable in some rootless or sandboxed runtimes,
+	// in that case the default backlog of NGINX is used
 	maxConns, err := getSysctl("net/core/somaxconn")
-	if err != nil || maxConns < 512 {
-		klog.V(3).InfoS("Using default net.core.somaxconn", "value", maxConns)
+	if err != nil {
+		klog.V(3).InfoS("Unable to read net.core.somaxconn, using default", "value", 511, "error", err)
 		return 511
 	}
 
-	return maxConns
-}
-
-// rlimitMaxNumFiles returns hard limit for RLIMIT_NOFILE
-func rlimitMaxNumFiles() int {
-	var rLimit syscall.Rlimit
-	err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rLimit)
-	if err != nil {
-		klog.ErrorS(err, "Error reading system maximum number of open file descriptors (RLIMIT_NOFILE)")
-		return 0
+	if maxConns < 512 {
+		klog.V(3).InfoS("Using default net.core.somaxconn", "value", maxConns)
+		return 511
 	}
-	return int(rLimit.Max)
-}
-
-const (
-	defBinary  = "/usr/bin/nginx"
-	cfgPath    = "/etc/nginx/nginx.conf"
-	luaCfgPath = "/etc/nginx/lua/cfg.json"
-)
 
-// NginxExecTester defines the interface to execute
-// command like reload or test configuration
-type NginxExecTester interface {
-	ExecCommand(args ...string) *exec.Cmd
-	Test(cfg string) ([]byte, error)
+	return maxConns
 }
 
-// NginxCommand stores context around a given nginx executable path
-type NginxCommand struct {
-	Binary string
+// nginxConfigurationDiff returns the unified diff between two NGINX configurations,
+// ignoring the checksum comment which changes with every configuration
+func nginxConfigurationDiff(current, next []byte) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        withoutChecksum(difflib.SplitLines(string(current))),
+		B:        withoutChecksum(difflib.SplitLines(string(next))),
+		FromFile: nginx.ConfPath,
+		ToFile:   "new-nginx-cfg",
+		Context:  3,
+	})
 }
 
-// NewNginxCommand returns a new NginxCommand from which path
-// has been detected from environment variable NGINX_BINARY or default
-func NewNginxCommand() NginxCommand {
-	command := NginxCommand{
-		Binary: defBinary,
-	}
-
-	binary := os.Getenv("NGINX_BINARY")
-	if binary != "" {
-		command.Binary = binary
+func withoutChecksum(lines []string) []string {
+	filtered := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(line, "# Configuration") {
+			continue
+		}
+		filtered = append(filtered, line)
 	}
 
-	return command
-}
-
-// ExecCommand instantiates an exec.Cmd object to call nginx program
-func (nc NginxCommand) ExecCommand(args ...string) *exec.Cmd {
-	cmdArgs := []string{}
-
-	cmdArgs = append(cmdArgs, "-c", cfgPath)
-	cmdArgs = append(cmdArgs, args...)
-	//nolint:gosec // Ignore G204 error
-	return exec.Command(nc.Binary, cmdArgs...)
-}
-
-// Test checks if config file is a syntax valid nginx configuration
-func (nc NginxCommand) Test(cfg string) ([]byte, error) {
-	//nolint:gosec // Ignore G204 error
-	return exec.Command(nc.Binary, "-c", cfg, "-t").CombinedOutput()
+	return filtered
 }
 
 // getSysctl returns the value for the specified sysctl setting
@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -33,3 +34,23 @@ func TestSysctlSomaxconn(t *testing.T) {
 		t.Errorf("returned %v but expected >= 511", i)
 	}
 }
+
+func TestNginxConfigurationDiff(t *testing.T) {
+	current := []byte("# Configuration checksum: 1\nworker_processes 1;\nevents {}\n")
+
+	diff, err := nginxConfigurationDiff(current, []byte("# Configuration checksum: 2\nworker_processes 1;\nevents {}\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != "" {
+		t.Errorf("expected no diff when only the checksum changes but got %q", diff)
+	}
+
+	diff, err = nginxConfigurationDiff(current, []byte("# Configuration checksum: 2\nworker_processes 2;\nevents {}\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(diff, "-worker_processes 1;\n") || !strings.Contains(diff, "+worker_processes 2;\n") {
+		t.Errorf("unexpected diff %q", diff)
+	}
+}
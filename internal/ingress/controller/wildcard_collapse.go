@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// collapseWildcardHosts returns the servers replacing the subdomains of a
+// domain with the same locations by a single server when there are at least
+// minServers of them. The collapsed locations select the backend of each host
+// using maps of the Host header. The received servers are not modified.
+func collapseWildcardHosts(servers []*ingress.Server, minServers int) []*ingress.Server {
+	groups := map[string][][]*ingress.Server{}
+	for _, srv := range servers {
+		domain, ok := collapsibleDomain(srv)
+		if !ok {
+			continue
+		}
+
+		found := false
+		for i, group := range groups[domain] {
+			if sameLocations(group[0], srv) {
+				groups[domain][i] = append(group, srv)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			groups[domain] = append(groups[domain], []*ingress.Server{srv})
+		}
+	}
+
+	collapsible := map[*ingress.Server][]*ingress.Server{}
+	for _, domainGroups := range groups {
+		for _, group := range domainGroups {
+			if len(group) < max(minServers, 2) {
+				continue
+			}
+
+			for _, srv := range group {
+				collapsible[srv] = group
+			}
+		}
+	}
+
+	if len(collapsible) == 0 {
+		return servers
+	}
+
+	collapsed := make([]*ingress.Server, 0, len(servers)-len(collapsible))
+	for _, srv := range servers {
+		group, ok := collapsible[srv]
+		if !ok {
+			collapsed = append(collapsed, srv)
+			continue
+		}
+
+		if group[0] != srv {
+			continue
+		}
+
+		klog.V(2).InfoS("Collapsing servers", "hostname", srv.Hostname, "servers", len(group))
+		collapsed = append(collapsed, collapseServers(len(collapsed), group))
+	}
+
+	return collapsed
+}
+
+// collapsibleDomain returns the parent domain of the server host name when
+// the server can be collapsed with other subdomains of the domain
+func collapsibleDomain(srv *ingress.Server) (string, bool) {
+	if srv.Hostname == defServerName || strings.HasPrefix(srv.Hostname, "*") {
+		return "", false
+	}
+
+	// aliases, redirects and SSL passthrough rely on the host name of the server
+	if len(srv.Aliases) > 0 || srv.RedirectFromToWWW || srv.SSLPassthrough {
+		return "", false
+	}
+
+	for _, loc := range srv.Locations {
+		// the cache of the authentication responses is shared by the host names of a server
+		if loc.ExternalAuth.AuthCacheKey != "" {
+			return "", false
+		}
+	}
+
+	_, domain, found := strings.Cut(srv.Hostname, ".")
+	if !found || !strings.Contains(domain, ".") {
+		return "", false
+	}
+
+	return domain, true
+}
+
+// sameLocations checks if the servers only differ in the host name and the
+// backends of the locations
+func sameLocations(s1, s2 *ingress.Server) bool {
+	c1, c2 := *s1, *s2
+	c1.Hostname, c2.Hostname = "", ""
+	// certificates are selected by host name using the SNI
+	c1.SSLCert, c2.SSLCert = nil, nil
+	c1.Locations, c2.Locations = nil, nil
+	if !c1.Equal(&c2) {
+		return false
+	}
+
+	if len(s1.Locations) != len(s2.Locations) {
+		return false
+	}
+
+	for i, l1 := range s1.Locations {
+		l2 := *s2.Locations[i]
+		if l1.IngressPath != l2.IngressPath || pathType(l1) != pathType(&l2) {
+			return false
+		}
+
+		if l1.Backend != l2.Backend && (isDefaultBackend(l1.Backend) || isDefaultBackend(l2.Backend)) {
+			return false
+		}
+
+		l2.Backend = l1.Backend
+		l2.Service = l1.Service
+		l2.Port = l1.Port
+		if !l1.Equal(&l2) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// collapseServers returns a server for the host names of the group using
+// the locations of the first server
+func collapseServers(id int, group []*ingress.Server) *ingress.Server {
+	first := group[0]
+
+	srv := *first
+	srv.Aliases = make([]string, 0, len(group)-1)
+	for _, s := range group[1:] {
+		srv.Aliases = append(srv.Aliases, s.Hostname)
+	}
+
+	srv.Locations = make([]*ingress.Location, 0, len(first.Locations))
+	for i, l := range first.Locations {
+		loc := *l
+		loc.Collapsed = &ingress.CollapsedLocation{
+			ID:    fmt.Sprintf("%d_%d", id, i),
+			Hosts: make([]ingress.CollapsedHost, 0, len(group)),
+		}
+
+		for _, s := range group {
+			loc.Collapsed.Hosts = append(loc.Collapsed.Hosts, ingress.CollapsedHost{
+				Hostname: s.Hostname,
+				Backend:  s.Locations[i].Backend,
+				Ingress:  s.Locations[i].Ingress,
+			})
+		}
+
+		srv.Locations = append(srv.Locations, &loc)
+	}
+
+	return &srv
+}
+
+func isDefaultBackend(name string) bool {
+	return name == defUpstreamName || strings.HasPrefix(name, "custom-default-backend-")
+}
+
+func pathType(loc *ingress.Location) string {
+	if loc.PathType == nil {
+		return ""
+	}
+
+	return string(*loc.PathType)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations/rewrite"
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func TestCollapseWildcardHosts(t *testing.T) {
+	newServer := func(hostname, backend string) *ingress.Server {
+		return &ingress.Server{
+			Hostname: hostname,
+			Locations: []*ingress.Location{
+				{Path: "/", Backend: backend},
+				{Path: "/static", Backend: backend, Rewrite: rewrite.Config{Target: "/"}},
+			},
+		}
+	}
+
+	rewritten := newServer("d.example.com", "tenants-d-80")
+	rewritten.Locations[1].Rewrite.Target = "/assets"
+
+	defaultBackend := newServer("e.example.com", defUpstreamName)
+
+	servers := []*ingress.Server{
+		newServer("_", defUpstreamName),
+		newServer("a.example.com", "tenants-a-80"),
+		newServer("b.example.com", "tenants-b-80"),
+		newServer("c.example.com", "tenants-c-80"),
+		rewritten,
+		defaultBackend,
+		newServer("example.com", "tenants-example-80"),
+		newServer("f.other.com", "tenants-f-80"),
+	}
+
+	if actual := collapseWildcardHosts(servers, 4); !slices.Equal(actual, servers) {
+		t.Errorf("expected the servers without changes but returned %v", actual)
+	}
+
+	actual := collapseWildcardHosts(servers, 2)
+	if len(actual) != 6 {
+		t.Fatalf("expected 6 servers but returned %v", len(actual))
+	}
+
+	if actual[0] != servers[0] || !slices.Equal(actual[2:], servers[4:]) {
+		t.Errorf("expected the servers that cannot be collapsed without changes")
+	}
+
+	collapsed := actual[1]
+	if collapsed.Hostname != "a.example.com" || !slices.Equal(collapsed.Aliases, []string{"b.example.com", "c.example.com"}) {
+		t.Errorf("unexpected host names %v %v", collapsed.Hostname, collapsed.Aliases)
+	}
+
+	for i, loc := range collapsed.Locations {
+		expected := &ingress.CollapsedLocation{
+			ID: []string{"1_0", "1_1"}[i],
+			Hosts: []ingress.CollapsedHost{
+				{Hostname: "a.example.com", Backend: "tenants-a-80"},
+				{Hostname: "b.example.com", Backend: "tenants-b-80"},
+				{Hostname: "c.example.com", Backend: "tenants-c-80"},
+			},
+		}
+		if !loc.Collapsed.Equal(expected) {
+			t.Errorf("expected %v but returned %v", expected, loc.Collapsed)
+		}
+	}
+
+	if servers[1].Locations[0].Collapsed != nil || len(servers[1].Aliases) > 0 {
+		t.Errorf("expected the servers to not be modified")
+	}
+}
@@ -160,6 +160,10 @@ type Backend struct {
 	// http://nginx.org/en/docs/http/ngx_http_access_module.html
 	DenylistSourceRange []string `json:"denylist-source-range"`
 
+	// AllowedHTTPMethods restricts the HTTP methods accepted by the locations.
+	// An empty list allows any method
+	AllowedHTTPMethods []string `json:"allowed-http-methods"`
+
 	// Limits the rate of response transmission to a client.
 	// The rate is specified in bytes per second. The zero value disables rate limiting.
 	// The limit is set per a request, and so if a client simultaneously opens two connections,
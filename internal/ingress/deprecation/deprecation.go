@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deprecation contains the annotations and ConfigMap keys renamed or
+// replaced, and translates the deprecated names to their replacement.
+package deprecation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LegacyAnnotationsPrefix is the prefix of the annotations before the nginx one,
+// the annotations with this prefix are translated to the configured prefix
+const LegacyAnnotationsPrefix = "ingress.kubernetes.io"
+
+// Deprecation is a deprecated annotation, without prefix, or ConfigMap key
+type Deprecation struct {
+	// Name is the deprecated name
+	Name string
+	// Replacement is the name of the annotation or key to use instead
+	Replacement string
+	// Renamed is set when the replacement accepts the same values, the deprecated
+	// name is then translated to the replacement
+	Renamed bool
+}
+
+// ConfigMapKeys are the deprecated keys of the configuration ConfigMap,
+// the deprecated annotations are the Conversions of the annotation groups
+var ConfigMapKeys = []Deprecation{
+	{Name: "whitelist-source-range", Replacement: "allowlist-source-range", Renamed: true},
+	{Name: "http2-max-field-size", Replacement: "large-client-header-buffers"},
+	{Name: "http2-max-header-size", Replacement: "large-client-header-buffers"},
+	{Name: "http2-max-requests", Replacement: "keepalive-requests"},
+}
+
+// Usage is the use of a deprecated annotation or ConfigMap key
+type Usage struct {
+	// Name is the deprecated name, with the prefix for annotations
+	Name string
+	// Replacement is the name to use instead, with the prefix for annotations
+	Replacement string
+	// Translated is set when the value was moved to the replacement, it is not
+	// when the replacement is already defined or does not accept the same values
+	Translated bool
+	// Error is the reason the value could not be converted to the replacement
+	Error string
+}
+
+func (u Usage) String() string {
+	if u.Error != "" {
+		return fmt.Sprintf("%v is deprecated, use %v (%v)", u.Name, u.Replacement, u.Error)
+	}
+	return fmt.Sprintf("%v is deprecated, use %v", u.Name, u.Replacement)
+}
+
+// TranslateAnnotations returns the annotations with the legacy prefix renamed to the prefix
+// and the annotations of previous versions converted to the last ones, and the deprecated
+// annotations used. The annotations are copied only when an annotation is translated.
+func TranslateAnnotations(annotations map[string]string, prefix string) (map[string]string, []Usage) {
+	renames := map[string]Deprecation{}
+	if prefix != LegacyAnnotationsPrefix {
+		for name := range annotations {
+			if suffix, ok := strings.CutPrefix(name, LegacyAnnotationsPrefix+"/"); ok {
+				renames[name] = Deprecation{Name: name, Replacement: fmt.Sprintf("%v/%v", prefix, suffix), Renamed: true}
+			}
+		}
+	}
+
+	translated, usages := translate(annotations, renames)
+	translated, converted := convert(translated, prefix, Conversions)
+	if len(converted) == 0 {
+		return translated, usages
+	}
+
+	usages = append(usages, converted...)
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Name < usages[j].Name
+	})
+
+	return translated, usages
+}
+
+// TranslateConfigMap returns the data of the configuration ConfigMap with the
+// deprecated keys renamed to their replacement, and the deprecated keys used.
+// The data is copied only when a key is renamed.
+func TranslateConfigMap(data map[string]string) (map[string]string, []Usage) {
+	renames := map[string]Deprecation{}
+	for _, d := range ConfigMapKeys {
+		renames[d.Name] = d
+	}
+
+	return translate(data, renames)
+}
+
+func translate(data map[string]string, renames map[string]Deprecation) (map[string]string, []Usage) {
+	var usages []Usage
+	translated, copied := data, false
+	for name, d := range renames {
+		value, ok := data[name]
+		if !ok {
+			continue
+		}
+
+		_, defined := data[d.Replacement]
+		u := Usage{Name: name, Replacement: d.Replacement, Translated: d.Renamed && !defined}
+		usages = append(usages, u)
+		if !d.Renamed {
+			continue
+		}
+
+		if !copied {
+			translated = make(map[string]string, len(data))
+			for k, v := range data {
+				translated[k] = v
+			}
+			copied = true
+		}
+
+		// the replacement takes precedence over the deprecated name
+		delete(translated, name)
+		if u.Translated {
+			translated[d.Replacement] = value
+		}
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Name < usages[j].Name
+	})
+
+	return translated, usages
+}
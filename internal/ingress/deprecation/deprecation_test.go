@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTranslateAnnotations(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    map[string]string
+		usages      []Usage
+	}{
+		{
+			name:        "no deprecated annotation",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "false"},
+			expected:    map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "false"},
+		},
+		{
+			name:        "renamed annotation",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/whitelist-source-range": "10.0.0.0/8"},
+			expected:    map[string]string{"nginx.ingress.kubernetes.io/allowlist-source-range": "10.0.0.0/8"},
+			usages: []Usage{{
+				Name:        "nginx.ingress.kubernetes.io/whitelist-source-range",
+				Replacement: "nginx.ingress.kubernetes.io/allowlist-source-range",
+				Translated:  true,
+			}},
+		},
+		{
+			name: "replacement already defined",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/limit-whitelist": "10.0.0.0/8",
+				"nginx.ingress.kubernetes.io/limit-allowlist": "192.168.0.0/16",
+			},
+			expected: map[string]string{"nginx.ingress.kubernetes.io/limit-allowlist": "192.168.0.0/16"},
+			usages: []Usage{{
+				Name:        "nginx.ingress.kubernetes.io/limit-whitelist",
+				Replacement: "nginx.ingress.kubernetes.io/limit-allowlist",
+			}},
+		},
+		{
+			name:        "legacy prefix",
+			annotations: map[string]string{"ingress.kubernetes.io/rewrite-target": "/"},
+			expected:    map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/"},
+			usages: []Usage{{
+				Name:        "ingress.kubernetes.io/rewrite-target",
+				Replacement: "nginx.ingress.kubernetes.io/rewrite-target",
+				Translated:  true,
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := make(map[string]string, len(tc.annotations))
+			for k, v := range tc.annotations {
+				original[k] = v
+			}
+
+			translated, usages := TranslateAnnotations(tc.annotations, "nginx.ingress.kubernetes.io")
+			if !reflect.DeepEqual(translated, tc.expected) {
+				t.Errorf("expected %v but %v was returned", tc.expected, translated)
+			}
+			if !reflect.DeepEqual(usages, tc.usages) {
+				t.Errorf("expected %v but %v was returned", tc.usages, usages)
+			}
+			if !reflect.DeepEqual(tc.annotations, original) {
+				t.Errorf("the annotations of the Ingress were modified: %v", tc.annotations)
+			}
+		})
+	}
+}
+
+func TestTranslateConfigMap(t *testing.T) {
+	data := map[string]string{
+		"whitelist-source-range": "10.0.0.0/8",
+		"http2-max-requests":     "1000",
+	}
+
+	translated, usages := TranslateConfigMap(data)
+	expected := map[string]string{
+		"allowlist-source-range": "10.0.0.0/8",
+		"http2-max-requests":     "1000",
+	}
+	if !reflect.DeepEqual(translated, expected) {
+		t.Errorf("expected %v but %v was returned", expected, translated)
+	}
+
+	expectedUsages := []Usage{
+		{Name: "http2-max-requests", Replacement: "keepalive-requests"},
+		{Name: "whitelist-source-range", Replacement: "allowlist-source-range", Translated: true},
+	}
+	if !reflect.DeepEqual(usages, expectedUsages) {
+		t.Errorf("expected %v but %v was returned", expectedUsages, usages)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	conversions := []Conversion{
+		Rename("rate-limit", 2, "limit-rate", "limit-rps"),
+		{
+			Group:   "rate-limit",
+			Version: 3,
+			From:    []string{"limit-rps", "limit-burst-multiplier"},
+			To:      []string{"rate-limit"},
+			Convert: func(from map[string]string) (map[string]string, error) {
+				if from["limit-rps"] == "" {
+					return nil, errors.New("limit-rps is not defined")
+				}
+				burst := from["limit-burst-multiplier"]
+				if burst == "" {
+					burst = "5"
+				}
+				return map[string]string{"rate-limit": from["limit-rps"] + "r/s burst=" + burst}, nil
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    map[string]string
+		usages      []Usage
+	}{
+		{
+			name:        "last version",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/rate-limit": "10r/s"},
+			expected:    map[string]string{"nginx.ingress.kubernetes.io/rate-limit": "10r/s"},
+		},
+		{
+			name:        "previous versions",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/limit-rate": "10"},
+			expected:    map[string]string{"nginx.ingress.kubernetes.io/rate-limit": "10r/s burst=5"},
+			usages: []Usage{
+				{Name: "nginx.ingress.kubernetes.io/limit-rate", Replacement: "nginx.ingress.kubernetes.io/limit-rps", Translated: true},
+				{Name: "nginx.ingress.kubernetes.io/limit-rps", Replacement: "nginx.ingress.kubernetes.io/rate-limit", Translated: true},
+			},
+		},
+		{
+			name: "several annotations converted",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/limit-rps":              "10",
+				"nginx.ingress.kubernetes.io/limit-burst-multiplier": "2",
+			},
+			expected: map[string]string{"nginx.ingress.kubernetes.io/rate-limit": "10r/s burst=2"},
+			usages: []Usage{
+				{Name: "nginx.ingress.kubernetes.io/limit-burst-multiplier", Replacement: "nginx.ingress.kubernetes.io/rate-limit", Translated: true},
+				{Name: "nginx.ingress.kubernetes.io/limit-rps", Replacement: "nginx.ingress.kubernetes.io/rate-limit", Translated: true},
+			},
+		},
+		{
+			name: "last version already defined",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/limit-rps":  "10",
+				"nginx.ingress.kubernetes.io/rate-limit": "20r/s",
+			},
+			expected: map[string]string{"nginx.ingress.kubernetes.io/rate-limit": "20r/s"},
+			usages: []Usage{
+				{Name: "nginx.ingress.kubernetes.io/limit-rps", Replacement: "nginx.ingress.kubernetes.io/rate-limit"},
+			},
+		},
+		{
+			name:        "conversion error",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/limit-burst-multiplier": "2"},
+			expected:    map[string]string{},
+			usages: []Usage{{
+				Name:        "nginx.ingress.kubernetes.io/limit-burst-multiplier",
+				Replacement: "nginx.ingress.kubernetes.io/rate-limit",
+				Error:       "converting to version 3 of rate-limit: limit-rps is not defined",
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			converted, usages := convert(tc.annotations, "nginx.ingress.kubernetes.io", conversions)
+			if !reflect.DeepEqual(converted, tc.expected) {
+				t.Errorf("expected %v but %v was returned", tc.expected, converted)
+			}
+			if !reflect.DeepEqual(usages, tc.usages) {
+				t.Errorf("expected %v but %v was returned", tc.usages, usages)
+			}
+		})
+	}
+}
+
+func TestConversions(t *testing.T) {
+	versions := map[string]int{}
+	for _, c := range Conversions {
+		if c.Group == "" || len(c.From) == 0 || len(c.To) == 0 || c.Convert == nil {
+			t.Errorf("conversion to version %v of group %q is incomplete", c.Version, c.Group)
+		}
+
+		last, ok := versions[c.Group]
+		if !ok {
+			last = 1
+		}
+		if c.Version != last+1 {
+			t.Errorf("expected version %v of group %v but %v was found", last+1, c.Group, c.Version)
+		}
+		versions[c.Group] = c.Version
+	}
+}
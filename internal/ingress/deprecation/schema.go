@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Conversion is a version of an annotation group, the Group of parser.Annotation,
+// introduced by a breaking change of its annotations. It converts the annotations of
+// the previous version so both spellings are supported until the old one is removed.
+type Conversion struct {
+	// Group is the annotation group changed
+	Group string
+	// Version is the version of the group, starting at 2 for the first breaking change
+	Version int
+	// From are the annotations of the previous version replaced, without prefix
+	From []string
+	// To are the annotations replacing them, without prefix
+	To []string
+	// Convert returns the annotations of the version, without prefix, from the annotations
+	// of the previous version defined in the Ingress
+	Convert func(from map[string]string) (map[string]string, error)
+}
+
+// Rename returns the conversion of a version renaming an annotation without changing its values
+func Rename(group string, version int, from, to string) Conversion {
+	return Conversion{
+		Group:   group,
+		Version: version,
+		From:    []string{from},
+		To:      []string{to},
+		Convert: func(anns map[string]string) (map[string]string, error) {
+			return map[string]string{to: anns[from]}, nil
+		},
+	}
+}
+
+// Conversions are the versions of the annotation groups, applied in order.
+// The versions of a group are consecutive, so an annotation of any version
+// is converted to the last one.
+var Conversions = []Conversion{
+	Rename("acl", 2, "whitelist-source-range", "allowlist-source-range"),
+	Rename("rate-limit", 2, "limit-whitelist", "limit-allowlist"),
+}
+
+// convert applies the conversions to the annotations with the prefix, returning the
+// annotations of the last versions and the annotations of previous versions used.
+// The annotations of a version already defined take precedence over the converted ones.
+func convert(annotations map[string]string, prefix string, conversions []Conversion) (map[string]string, []Usage) {
+	var usages []Usage
+	converted, copied := annotations, false
+	for _, c := range conversions {
+		from := map[string]string{}
+		for _, name := range c.From {
+			if value, ok := converted[prefixed(prefix, name)]; ok {
+				from[name] = value
+			}
+		}
+		if len(from) == 0 {
+			continue
+		}
+
+		if !copied {
+			converted = make(map[string]string, len(annotations))
+			for k, v := range annotations {
+				converted[k] = v
+			}
+			copied = true
+		}
+
+		defined := false
+		replacements := make([]string, 0, len(c.To))
+		for _, name := range c.To {
+			if _, ok := converted[prefixed(prefix, name)]; ok {
+				defined = true
+			}
+			replacements = append(replacements, prefixed(prefix, name))
+		}
+
+		to, err := c.Convert(from)
+		for name := range from {
+			delete(converted, prefixed(prefix, name))
+
+			u := Usage{
+				Name:        prefixed(prefix, name),
+				Replacement: strings.Join(replacements, ", "),
+				Translated:  err == nil && !defined,
+			}
+			if err != nil {
+				u.Error = fmt.Sprintf("converting to version %v of %v: %v", c.Version, c.Group, err)
+			}
+			usages = append(usages, u)
+		}
+
+		if err != nil || defined {
+			continue
+		}
+		for name, value := range to {
+			converted[prefixed(prefix, name)] = value
+		}
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Name < usages[j].Name
+	})
+
+	return converted, usages
+}
+
+func prefixed(prefix, name string) string {
+	return fmt.Sprintf("%v/%v", prefix, name)
+}
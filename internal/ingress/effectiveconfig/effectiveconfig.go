@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package effectiveconfig exports the settings of an Ingress after merging the
+// defaults of the ConfigMap, the annotations and the policies of the controller.
+package effectiveconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/redact"
+)
+
+// Path is the path of the endpoint exporting the effective configuration of an Ingress
+const Path = "/effective-configuration"
+
+// ErrNotFound is returned by a Source when the Ingress is not handled by the controller
+var ErrNotFound = errors.New("ingress not found")
+
+// Configuration contains the effective configuration of an Ingress
+type Configuration struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Annotations are the annotations of the Ingress
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Settings are the results of the annotation parsers, merged with the defaults of the ConfigMap
+	Settings interface{} `json:"settings"`
+	// Sources tells for every setting if it is set by an annotation or uses the defaults
+	Sources map[string]string `json:"sources"`
+	// Locations are the paths of the Ingress in the running configuration of NGINX
+	Locations []Location `json:"locations"`
+	// Errors contains the parts of the configuration that could not be resolved
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Location is a path of the Ingress in the running configuration of NGINX
+type Location struct {
+	Host string `json:"host"`
+	Path string `json:"path"`
+	// Ingress is the Ingress serving the path, another one when it won a conflict
+	Ingress string `json:"ingress"`
+	// Settings are the settings of the location used to render nginx.conf
+	Settings interface{} `json:"settings,omitempty"`
+}
+
+// Source returns the effective configuration of the Ingresses
+type Source interface {
+	EffectiveConfiguration(namespace, name string) (*Configuration, error)
+}
+
+// Register exposes the effective configuration of the Ingress selected with the
+// query parameters namespace and name in the given mux, without credentials
+func Register(mux *http.ServeMux, src Source) {
+	mux.HandleFunc(Path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		namespace, name := query.Get("namespace"), query.Get("name")
+		if namespace == "" || name == "" {
+			http.Error(w, "the query parameters namespace and name are required", http.StatusBadRequest)
+			return
+		}
+
+		c, err := src.EffectiveConfiguration(namespace, name)
+		if errors.Is(err, ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		for k, v := range c.Annotations {
+			c.Annotations[k] = redact.Value(k, v)
+		}
+
+		b, err := json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(redact.String(string(b)) + "\n")); err != nil {
+			klog.V(2).ErrorS(err, "Error writing the effective configuration")
+		}
+	})
+}
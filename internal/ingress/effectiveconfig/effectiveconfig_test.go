@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effectiveconfig
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeSource map[string]*Configuration
+
+func (s fakeSource) EffectiveConfiguration(namespace, name string) (*Configuration, error) {
+	c, ok := s[namespace+"/"+name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return c, nil
+}
+
+func TestRegister(t *testing.T) {
+	mux := http.NewServeMux()
+	Register(mux, fakeSource{
+		"default/app": {
+			Namespace:   "default",
+			Name:        "app",
+			Annotations: map[string]string{"nginx.ingress.kubernetes.io/auth-secret-password": "s3cr3t"},
+			Sources:     map[string]string{"Proxy": "default"},
+			Locations:   []Location{{Host: "app.example.com", Path: "/", Ingress: "default/app"}},
+		},
+	})
+
+	testCases := []struct {
+		query  string
+		status int
+	}{
+		{"", http.StatusBadRequest},
+		{"?namespace=default", http.StatusBadRequest},
+		{"?namespace=default&name=missing", http.StatusNotFound},
+		{"?namespace=default&name=app", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path+tc.query, http.NoBody))
+		if w.Code != tc.status {
+			t.Errorf("%v: expected status %v but got %v", tc.query, tc.status, w.Code)
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+
+		var c Configuration
+		if err := json.Unmarshal(w.Body.Bytes(), &c); err != nil {
+			t.Fatalf("unexpected error decoding the configuration: %v", err)
+		}
+		if c.Annotations["nginx.ingress.kubernetes.io/auth-secret-password"] == "s3cr3t" {
+			t.Errorf("expected the sensitive annotation to be redacted")
+		}
+		if len(c.Locations) != 1 || c.Locations[0].Host != "app.example.com" {
+			t.Errorf("unexpected locations %v", c.Locations)
+		}
+	}
+}
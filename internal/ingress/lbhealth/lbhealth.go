@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbhealth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/armon/go-proxyproto"
+	klog "k8s.io/klog/v2"
+)
+
+// HostPathPrefix is the path prefix used to check the readiness of a single host
+const HostPathPrefix = "/host/"
+
+// Checker defines the readiness information used to answer
+// the health checks sent by external load balancers
+type Checker interface {
+	// IsReady returns an error if the controller should not receive new connections
+	IsReady() error
+	// IsHostReady returns an error if the host is not served by the running configuration
+	IsHostReady(host string) error
+}
+
+// Server answers raw TCP and HTTP health checks sent by load balancers
+// in front of the controller. Connections can start with a PROXY protocol
+// (v1) header, as sent by load balancers configured to use it.
+//
+// HTTP requests receive a 200 status code when the controller is ready and
+// 503 otherwise. A request to /host/<hostname> also checks the hostname is
+// part of the running configuration.
+// Connections without an HTTP request receive "OK" when the controller is
+// ready and are reset otherwise.
+type Server struct {
+	Checker Checker
+
+	// ProxyHeaderTimeout is the time to wait for the PROXY protocol header
+	ProxyHeaderTimeout time.Duration
+
+	// ReadTimeout is the time to wait for an HTTP request before
+	// answering the connection as a raw TCP check
+	ReadTimeout time.Duration
+}
+
+// ListenAndServe accepts TCP connections in the address and answers them
+func (s *Server) ListenAndServe(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	proxyList := &proxyproto.Listener{Listener: listener, ProxyHeaderTimeout: s.ProxyHeaderTimeout}
+	for {
+		conn, err := proxyList.Accept()
+		if err != nil {
+			klog.Warningf("Error accepting TCP connection: %v", err)
+			continue
+		}
+
+		go s.Handle(conn)
+	}
+}
+
+// Handle answers a health check
+func (s *Server) Handle(conn net.Conn) {
+	defer conn.Close()
+
+	err := conn.SetReadDeadline(time.Now().Add(s.ReadTimeout))
+	if err != nil {
+		klog.V(4).ErrorS(err, "Error setting read deadline")
+		return
+	}
+
+	req, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil {
+		s.handleTCP(conn)
+		return
+	}
+
+	s.handleHTTP(conn, req)
+}
+
+func (s *Server) handleTCP(conn net.Conn) {
+	if err := s.Checker.IsReady(); err != nil {
+		klog.V(4).InfoS("Resetting health check connection", "remote", conn.RemoteAddr(), "reason", err)
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			//nolint:errcheck // the connection is closed anyway
+			tcpConn.SetLinger(0)
+		}
+		return
+	}
+
+	//nolint:errcheck // the load balancer could have closed the connection
+	conn.Write([]byte("OK\n"))
+}
+
+func (s *Server) handleHTTP(conn net.Conn, req *http.Request) {
+	err := s.Checker.IsReady()
+	if err == nil && strings.HasPrefix(req.URL.Path, HostPathPrefix) {
+		err = s.Checker.IsHostReady(strings.TrimPrefix(req.URL.Path, HostPathPrefix))
+	}
+
+	statusCode, body := http.StatusOK, "ok\n"
+	if err != nil {
+		klog.V(4).InfoS("Health check failed", "remote", conn.RemoteAddr(), "path", req.URL.Path, "reason", err)
+		statusCode, body = http.StatusServiceUnavailable, fmt.Sprintf("%v\n", err)
+	}
+
+	res := &http.Response{
+		StatusCode:    statusCode,
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Request:       req,
+		Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Close:         true,
+	}
+
+	if err := res.Write(conn); err != nil {
+		klog.V(4).ErrorS(err, "Error writing health check response")
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbhealth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type fakeChecker struct {
+	ready error
+	hosts map[string]bool
+}
+
+func (f fakeChecker) IsReady() error {
+	return f.ready
+}
+
+func (f fakeChecker) IsHostReady(host string) error {
+	if !f.hosts[host] {
+		return fmt.Errorf("host %v not found", host)
+	}
+	return nil
+}
+
+func httpCheck(t *testing.T, s *Server, path string) int {
+	server, client := net.Pipe()
+	defer client.Close()
+
+	go s.Handle(server)
+
+	req, err := http.NewRequest(http.MethodGet, "http://lb"+path, http.NoBody)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := req.Write(client); err != nil {
+		t.Fatalf("unexpected error writing request: %v", err)
+	}
+
+	res, err := http.ReadResponse(bufio.NewReader(client), req)
+	if err != nil {
+		t.Fatalf("unexpected error reading response: %v", err)
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode
+}
+
+func TestHTTPCheck(t *testing.T) {
+	ready := &Server{
+		Checker:     fakeChecker{hosts: map[string]bool{"foo.bar": true}},
+		ReadTimeout: time.Second,
+	}
+	notReady := &Server{
+		Checker:     fakeChecker{ready: fmt.Errorf("reloading"), hosts: map[string]bool{"foo.bar": true}},
+		ReadTimeout: time.Second,
+	}
+
+	testCases := []struct {
+		title  string
+		server *Server
+		path   string
+		expect int
+	}{
+		{"ready controller", ready, "/", http.StatusOK},
+		{"ready host", ready, "/host/foo.bar", http.StatusOK},
+		{"unknown host", ready, "/host/unknown", http.StatusServiceUnavailable},
+		{"controller not ready", notReady, "/", http.StatusServiceUnavailable},
+		{"host of a controller not ready", notReady, "/host/foo.bar", http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			if status := httpCheck(t, tc.server, tc.path); status != tc.expect {
+				t.Errorf("expected status %v but got %v", tc.expect, status)
+			}
+		})
+	}
+}
+
+func TestTCPCheck(t *testing.T) {
+	testCases := []struct {
+		title  string
+		ready  error
+		expect string
+	}{
+		{"ready controller", nil, "OK\n"},
+		{"controller not ready", fmt.Errorf("draining"), ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			s := &Server{
+				Checker:     fakeChecker{ready: tc.ready},
+				ReadTimeout: 10 * time.Millisecond,
+			}
+
+			server, client := net.Pipe()
+			defer client.Close()
+
+			go s.Handle(server)
+
+			data, err := io.ReadAll(client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tc.expect {
+				t.Errorf("expected %q but got %q", tc.expect, string(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldapauth
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// maxCacheEntries limits the memory used by the results of the binds
+const maxCacheEntries = 10000
+
+type cacheEntry struct {
+	err     error
+	expires time.Time
+}
+
+// bindCache keeps the results of the binds, so every request of a client
+// does not open a connection to the LDAP server. The credentials are only
+// kept hashed with the server and the bind DN.
+type bindCache struct {
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]cacheEntry
+}
+
+var binds = &bindCache{
+	entries: map[[sha256.Size]byte]cacheEntry{},
+}
+
+func cacheKey(cfg *Config, name, password string) [sha256.Size]byte {
+	h := sha256.New()
+	for _, v := range []string{cfg.URL.String(), name, password} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+
+	return key
+}
+
+// get returns the result of a bind not expired
+func (c *bindCache) get(key [sha256.Size]byte) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (c *bindCache) add(key [sha256.Size]byte, err error) {
+	if CacheTTL <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= maxCacheEntries {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	if len(c.entries) >= maxCacheEntries {
+		c.entries = map[[sha256.Size]byte]cacheEntry{}
+	}
+
+	c.entries[key] = cacheEntry{err: err, expires: now.Add(CacheTTL)}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldapauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// Path is the prefix of the endpoint validating the credentials, followed by
+// the namespace and name of the Ingress using the LDAP server
+const Path = "/ldap-auth/"
+
+// tokenParameter is the query parameter with the token of the Ingress
+const tokenParameter = "token"
+
+// ListenAddress is the address of the server exposing the endpoint to NGINX
+var ListenAddress = "127.0.0.1:10254"
+
+// ErrNotAllowed is returned when an Ingress can not use the Secret of its annotation
+var ErrNotAllowed = errors.New("the Secret is not allowed")
+
+// tokenKey signs the URLs of the Ingresses, so a location can not use the
+// endpoint with the LDAP server of another Ingress through auth-url
+var tokenKey = newTokenKey()
+
+func newTokenKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("unexpected error generating the LDAP authentication key: %v", err))
+	}
+
+	return key
+}
+
+// token returns the token of the URL of an Ingress
+func token(ingress string) string {
+	mac := hmac.New(sha256.New, tokenKey)
+	mac.Write([]byte(ingress))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SecretSource returns the Secrets with the settings of the LDAP servers
+type SecretSource interface {
+	// LDAPSecret returns the Secret of the annotation of an Ingress, or an
+	// error wrapping ErrNotAllowed if it is in a namespace it can not use
+	LDAPSecret(ingress string) (*corev1.Secret, error)
+}
+
+// AuthURL returns the URL used by NGINX to validate the credentials
+// against the LDAP server configured in the annotation of an Ingress
+func AuthURL(ingress, realm string) string {
+	query := url.Values{}
+	query.Set(tokenParameter, token(ingress))
+	if realm != "" {
+		query.Set("realm", realm)
+	}
+
+	return fmt.Sprintf("http://%v%v%v?%v", ListenAddress, Path, ingress, query.Encode())
+}
+
+// Host returns the host of the server exposing the endpoint
+func Host() string {
+	host, _, err := net.SplitHostPort(ListenAddress)
+	if err != nil {
+		return ListenAddress
+	}
+
+	return host
+}
+
+// Register exposes the endpoint validating the credentials in the given mux
+func Register(mux *http.ServeMux, src SecretSource) {
+	mux.HandleFunc(Path, func(w http.ResponseWriter, r *http.Request) {
+		// only NGINX, running in the same pod, can validate credentials
+		if !isLocalRequest(r) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, Path)
+		if strings.Count(key, "/") != 1 {
+			http.NotFound(w, r)
+			return
+		}
+
+		if !hmac.Equal([]byte(r.URL.Query().Get(tokenParameter)), []byte(token(key))) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			unauthorized(w, r.URL.Query().Get("realm"))
+			return
+		}
+
+		secret, err := src.LDAPSecret(key)
+		if errors.Is(err, ErrNotAllowed) {
+			klog.ErrorS(err, "Rejected LDAP settings", "ingress", key)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			klog.ErrorS(err, "Error reading LDAP settings", "ingress", key)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		cfg, err := ConfigFromSecret(secret)
+		if err != nil {
+			klog.ErrorS(err, "Invalid LDAP settings", "ingress", key)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		err = Bind(cfg, username, password)
+		if errors.Is(err, ErrInvalidCredentials) {
+			klog.V(3).InfoS("Invalid LDAP credentials", "ingress", key, "username", username)
+			unauthorized(w, r.URL.Query().Get("realm"))
+			return
+		}
+		if err != nil {
+			klog.ErrorS(err, "Error validating credentials against the LDAP server", "ingress", key, "url", cfg.URL)
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func unauthorized(w http.ResponseWriter, realm string) {
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q", realm))
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+// isLocalRequest returns true if the request comes from the loopback interface
+// or from the address of the server, used by the containers of the same pod
+func isLocalRequest(r *http.Request) bool {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+
+	remoteIP := net.ParseIP(remote)
+	if remoteIP == nil {
+		return false
+	}
+	if remoteIP.IsLoopback() {
+		return true
+	}
+
+	local, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	if !ok {
+		return false
+	}
+
+	localHost, _, err := net.SplitHostPort(local.String())
+	if err != nil {
+		return false
+	}
+
+	return remoteIP.Equal(net.ParseIP(localHost))
+}
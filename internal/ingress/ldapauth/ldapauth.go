@@ -0,0 +1,392 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ldapauth validates the credentials of the requests against an
+// LDAP server, allowing NGINX to protect locations with auth_request without
+// deploying a separate authentication proxy.
+package ldapauth
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// URLKey is the key of the Secret with the URL of the LDAP server
+	URLKey = "url"
+	// BindDNKey is the key of the Secret with the template of the DN used to bind as the user
+	BindDNKey = "bind-dn"
+	// CAKey is the key of the Secret with the CA used to verify the certificate of the LDAP server
+	CAKey = "ca.crt"
+	// AllowPlaintextKey is the key of the Secret allowing to send the credentials
+	// to an ldap URL without StartTLS when its value is "true"
+	AllowPlaintextKey = "allow-plaintext"
+
+	// usernamePlaceholder is replaced by the escaped username in the bind DN
+	usernamePlaceholder = "{username}"
+)
+
+var (
+	// ErrInvalidCredentials is returned when the LDAP server rejects the credentials
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// DialTimeout is the time limit to connect to the LDAP server
+	DialTimeout = 5 * time.Second
+	// Timeout is the time limit to bind against the LDAP server
+	Timeout = 10 * time.Second
+	// CacheTTL is the time the result of a bind is reused for the same credentials
+	CacheTTL = 30 * time.Second
+)
+
+// Config contains the settings of the LDAP server
+type Config struct {
+	// URL of the LDAP server, using the ldap or ldaps scheme
+	URL *url.URL
+	// BindDN is the template of the DN used to bind as the user,
+	// like uid={username},ou=people,dc=example,dc=org or {username}@example.org
+	BindDN string
+	// CAs verifying the certificate of the LDAP server. The system CAs are used when nil.
+	CAs *x509.CertPool
+	// StartTLS upgrades the connections to an ldap URL to TLS before sending the credentials
+	StartTLS bool
+}
+
+// ConfigFromSecret returns the LDAP settings contained in a Secret
+func ConfigFromSecret(secret *corev1.Secret) (*Config, error) {
+	rawURL, ok := secret.Data[URLKey]
+	if !ok {
+		return nil, fmt.Errorf("the secret %v/%v does not contain the key %v", secret.Namespace, secret.Name, URLKey)
+	}
+
+	u, err := url.Parse(string(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LDAP URL: %w", err)
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return nil, fmt.Errorf("invalid LDAP URL scheme %q, must be ldap or ldaps", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid LDAP URL %q without host", u)
+	}
+
+	bindDN := string(secret.Data[BindDNKey])
+	if !strings.Contains(bindDN, usernamePlaceholder) {
+		return nil, fmt.Errorf("the key %v of the secret %v/%v must contain %v", BindDNKey, secret.Namespace, secret.Name, usernamePlaceholder)
+	}
+
+	cfg := &Config{
+		URL:    u,
+		BindDN: bindDN,
+		// the credentials are only sent in plaintext when it is explicitly allowed
+		StartTLS: u.Scheme == "ldap" && string(secret.Data[AllowPlaintextKey]) != "true",
+	}
+
+	if ca, ok := secret.Data[CAKey]; ok {
+		cfg.CAs = x509.NewCertPool()
+		if !cfg.CAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("the key %v of the secret %v/%v does not contain valid certificates", CAKey, secret.Namespace, secret.Name)
+		}
+	}
+
+	return cfg, nil
+}
+
+// address returns the host and port of the LDAP server
+func (c *Config) address() string {
+	if c.URL.Port() != "" {
+		return c.URL.Host
+	}
+
+	if c.URL.Scheme == "ldaps" {
+		return net.JoinHostPort(c.URL.Hostname(), "636")
+	}
+	return net.JoinHostPort(c.URL.Hostname(), "389")
+}
+
+// bindName returns the name used to bind as the user. The username is escaped
+// in a DN, like uid={username},ou=people,dc=example,dc=org. Other templates,
+// like the user principal name {username}@example.org of Active Directory,
+// are not DNs and reject the usernames which could name another domain.
+func bindName(template, username string) (string, error) {
+	if strings.Contains(template, "=") {
+		return strings.ReplaceAll(template, usernamePlaceholder, escapeDN(username)), nil
+	}
+
+	if strings.ContainsAny(username, `@\/`) || strings.ContainsFunc(username, unicode.IsControl) {
+		return "", ErrInvalidCredentials
+	}
+
+	return strings.ReplaceAll(template, usernamePlaceholder, username), nil
+}
+
+// escapeDN escapes a value of a DN as defined in RFC 4514
+func escapeDN(value string) string {
+	b := &strings.Builder{}
+	for i, r := range value {
+		switch {
+		case strings.ContainsRune(`\,+"<>;=`, r), r == 0,
+			i == 0 && (r == ' ' || r == '#'),
+			i == len(value)-1 && r == ' ':
+			fmt.Fprintf(b, "\\%02x", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// bindRequest is the BindRequest of RFC 4511 using simple authentication
+type bindRequest struct {
+	Version  int
+	Name     []byte
+	Password []byte `asn1:"tag:0"`
+}
+
+type bindRequestMessage struct {
+	MessageID   int
+	BindRequest bindRequest `asn1:"application,tag:0"`
+}
+
+// extendedRequest is the ExtendedRequest of RFC 4511 without value
+type extendedRequest struct {
+	Name []byte `asn1:"tag:0"`
+}
+
+type extendedRequestMessage struct {
+	MessageID       int
+	ExtendedRequest extendedRequest `asn1:"application,tag:23"`
+}
+
+type responseMessage struct {
+	MessageID  int
+	ProtocolOp asn1.RawValue
+}
+
+const (
+	bindResponseTag          = 1
+	extendedResponseTag      = 24
+	resultSuccess            = 0
+	resultInvalidCredentials = 49
+
+	// startTLSOID is the name of the StartTLS extended operation of RFC 4511
+	startTLSOID = "1.3.6.1.4.1.1466.20037"
+)
+
+// Bind returns nil if the LDAP server accepts the credentials of the user.
+// The result is reused during CacheTTL for the same server and credentials.
+func Bind(cfg *Config, username, password string) error {
+	// a bind with an empty password is an unauthenticated bind, accepted by most servers
+	if username == "" || password == "" {
+		return ErrInvalidCredentials
+	}
+
+	name, err := bindName(cfg.BindDN, username)
+	if err != nil {
+		return err
+	}
+
+	key := cacheKey(cfg, name, password)
+	if entry, ok := binds.get(key); ok {
+		return entry.err
+	}
+
+	err = bind(cfg, name, password)
+	// the errors reaching the server are not cached
+	if err == nil || errors.Is(err, ErrInvalidCredentials) {
+		binds.add(key, err)
+	}
+
+	return err
+}
+
+func bind(cfg *Config, name, password string) error {
+	dialer := &net.Dialer{Timeout: DialTimeout}
+	tlsConfig := &tls.Config{
+		ServerName: cfg.URL.Hostname(),
+		RootCAs:    cfg.CAs,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	var conn net.Conn
+	var err error
+	if cfg.URL.Scheme == "ldaps" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", cfg.address(), tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", cfg.address())
+	}
+	if err != nil {
+		return fmt.Errorf("connecting to the LDAP server: %w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(Timeout)); err != nil {
+		return err
+	}
+
+	messageID := 1
+	r := bufio.NewReader(conn)
+	if cfg.StartTLS {
+		if err := startTLS(conn, r, messageID); err != nil {
+			return err
+		}
+		messageID++
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return fmt.Errorf("starting TLS with the LDAP server: %w", err)
+		}
+		conn = tlsConn
+		r = bufio.NewReader(conn)
+	}
+
+	req, err := asn1.Marshal(bindRequestMessage{
+		MessageID: messageID,
+		BindRequest: bindRequest{
+			Version:  3,
+			Name:     []byte(name),
+			Password: []byte(password),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("sending the bind request: %w", err)
+	}
+
+	packet, err := readPacket(r)
+	if err != nil {
+		return fmt.Errorf("reading the bind response: %w", err)
+	}
+
+	code, err := resultCode(packet, bindResponseTag)
+	if err != nil {
+		return err
+	}
+
+	switch code {
+	case resultSuccess:
+		return nil
+	case resultInvalidCredentials:
+		return ErrInvalidCredentials
+	default:
+		return fmt.Errorf("unexpected LDAP result code %v", code)
+	}
+}
+
+// startTLS asks the LDAP server to upgrade the connection to TLS
+func startTLS(conn net.Conn, r *bufio.Reader, messageID int) error {
+	req, err := asn1.Marshal(extendedRequestMessage{
+		MessageID:       messageID,
+		ExtendedRequest: extendedRequest{Name: []byte(startTLSOID)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("sending the StartTLS request: %w", err)
+	}
+
+	packet, err := readPacket(r)
+	if err != nil {
+		return fmt.Errorf("reading the StartTLS response: %w", err)
+	}
+
+	code, err := resultCode(packet, extendedResponseTag)
+	if err != nil {
+		return err
+	}
+	if code != resultSuccess {
+		return fmt.Errorf("the LDAP server refused StartTLS with the result code %v", code)
+	}
+
+	return nil
+}
+
+// resultCode returns the result code of a response with the given tag
+func resultCode(packet []byte, tag int) (int, error) {
+	msg := &responseMessage{}
+	if _, err := asn1.Unmarshal(packet, msg); err != nil {
+		return 0, fmt.Errorf("invalid LDAP response: %w", err)
+	}
+
+	if msg.ProtocolOp.Class != asn1.ClassApplication || msg.ProtocolOp.Tag != tag {
+		return 0, fmt.Errorf("unexpected LDAP response with tag %v", msg.ProtocolOp.Tag)
+	}
+
+	var code asn1.Enumerated
+	if _, err := asn1.Unmarshal(msg.ProtocolOp.Bytes, &code); err != nil {
+		return 0, fmt.Errorf("invalid LDAP result code: %w", err)
+	}
+
+	return int(code), nil
+}
+
+// maxPacketSize limits the size of the responses of the LDAP server
+const maxPacketSize = 1 << 16
+
+// readPacket reads a BER element with a definite length
+func readPacket(r *bufio.Reader) ([]byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	length := int(header[1])
+	if header[1]&0x80 != 0 {
+		n := int(header[1] & 0x7f)
+		if n == 0 || n > 3 {
+			return nil, fmt.Errorf("unsupported BER length with %v bytes", n)
+		}
+
+		lengthBytes := make([]byte, n)
+		if _, err := io.ReadFull(r, lengthBytes); err != nil {
+			return nil, err
+		}
+
+		header = append(header, lengthBytes...)
+		length = 0
+		for _, b := range lengthBytes {
+			length = length<<8 | int(b)
+		}
+	}
+
+	if length > maxPacketSize {
+		return nil, fmt.Errorf("LDAP response of %v bytes is too large", length)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	return append(header, body...), nil
+}
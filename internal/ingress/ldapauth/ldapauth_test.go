@@ -0,0 +1,394 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package ldapauth
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ldapServer is a fake LDAP server accepting a bind DN and password
+type ldapServer struct {
+	address string
+	// binds is the number of bind requests received
+	binds atomic.Int32
+}
+
+// startLDAPServer starts an LDAP server accepting the bind DN and password.
+// With a TLS configuration, the server requires StartTLS before the bind.
+func startLDAPServer(t *testing.T, dn, password string, tlsConfig *tls.Config) *ldapServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error starting the LDAP server: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	s := &ldapServer{address: l.Addr().String()}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				r := bufio.NewReader(conn)
+				packet, err := readPacket(r)
+				if err != nil {
+					return
+				}
+
+				if tlsConfig != nil {
+					req := &extendedRequestMessage{}
+					if _, err := asn1.Unmarshal(packet, req); err != nil || string(req.ExtendedRequest.Name) != startTLSOID {
+						return
+					}
+					if err := writeResponse(conn, req.MessageID, extendedResponseTag, resultSuccess); err != nil {
+						return
+					}
+
+					tlsConn := tls.Server(conn, tlsConfig)
+					defer tlsConn.Close()
+
+					conn = tlsConn
+					r = bufio.NewReader(conn)
+					if packet, err = readPacket(r); err != nil {
+						return
+					}
+				}
+
+				req := &bindRequestMessage{}
+				if _, err := asn1.Unmarshal(packet, req); err != nil {
+					return
+				}
+				s.binds.Add(1)
+
+				code := resultInvalidCredentials
+				if string(req.BindRequest.Name) == dn && string(req.BindRequest.Password) == password {
+					code = resultSuccess
+				}
+
+				//nolint:errcheck // Ignore the error of the fake server
+				writeResponse(conn, req.MessageID, bindResponseTag, code)
+			}()
+		}
+	}()
+
+	return s
+}
+
+// writeResponse writes an LDAP response with the given tag and result code
+func writeResponse(conn net.Conn, messageID, tag, code int) error {
+	result, err := asn1.Marshal(struct {
+		ResultCode        asn1.Enumerated
+		MatchedDN         []byte
+		DiagnosticMessage []byte
+	}{ResultCode: asn1.Enumerated(code)})
+	if err != nil {
+		return err
+	}
+
+	var seq asn1.RawValue
+	if _, err := asn1.Unmarshal(result, &seq); err != nil {
+		return err
+	}
+
+	resp, err := asn1.Marshal(responseMessage{
+		MessageID: messageID,
+		ProtocolOp: asn1.RawValue{
+			Class:      asn1.ClassApplication,
+			Tag:        tag,
+			IsCompound: true,
+			Bytes:      seq.Bytes,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Write(resp)
+	return err
+}
+
+// newTLSConfig returns the TLS configuration of a server with a certificate
+// for 127.0.0.1 and the PEM of this certificate
+func newTLSConfig(t *testing.T) (*tls.Config, []byte) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	return &tls.Config{Certificates: srv.TLS.Certificates, MinVersion: tls.VersionTLS12}, ca
+}
+
+func newSecret(address string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap", Namespace: "default"},
+		Data: map[string][]byte{
+			URLKey:            []byte("ldap://" + address),
+			BindDNKey:         []byte("uid={username},ou=people,dc=example,dc=org"),
+			AllowPlaintextKey: []byte("true"),
+		},
+	}
+}
+
+func TestConfigFromSecret(t *testing.T) {
+	testCases := []struct {
+		name      string
+		data      map[string][]byte
+		expectErr bool
+		startTLS  bool
+	}{
+		{"valid", map[string][]byte{URLKey: []byte("ldaps://ldap.example.org"), BindDNKey: []byte("{username}@example.org")}, false, false},
+		{"ldap with StartTLS", map[string][]byte{URLKey: []byte("ldap://ldap.example.org"), BindDNKey: []byte("{username}@example.org")}, false, true},
+		{"ldap in plaintext", map[string][]byte{URLKey: []byte("ldap://ldap.example.org"), BindDNKey: []byte("{username}@example.org"), AllowPlaintextKey: []byte("true")}, false, false},
+		{"without url", map[string][]byte{BindDNKey: []byte("{username}@example.org")}, true, false},
+		{"invalid scheme", map[string][]byte{URLKey: []byte("http://ldap.example.org"), BindDNKey: []byte("{username}@example.org")}, true, false},
+		{"bind-dn without username", map[string][]byte{URLKey: []byte("ldap://ldap.example.org"), BindDNKey: []byte("cn=admin")}, true, false},
+		{"invalid ca", map[string][]byte{URLKey: []byte("ldaps://ldap.example.org"), BindDNKey: []byte("{username}@example.org"), CAKey: []byte("invalid")}, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := ConfigFromSecret(&corev1.Secret{Data: tc.data})
+			if tc.expectErr && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err == nil && cfg.StartTLS != tc.startTLS {
+				t.Errorf("expected StartTLS %v but returned %v", tc.startTLS, cfg.StartTLS)
+			}
+		})
+	}
+}
+
+func TestEscapeDN(t *testing.T) {
+	testCases := map[string]string{
+		"foo":          "foo",
+		"foo,ou=admin": "foo\\2cou\\3dadmin",
+		" #foo ":       "\\20#foo\\20",
+		"#foo":         "\\23foo",
+	}
+
+	for value, expected := range testCases {
+		if escaped := escapeDN(value); escaped != expected {
+			t.Errorf("expected %q but returned %q", expected, escaped)
+		}
+	}
+}
+
+func TestBindName(t *testing.T) {
+	testCases := []struct {
+		name      string
+		template  string
+		username  string
+		expected  string
+		expectErr bool
+	}{
+		{"dn", "uid={username},ou=people,dc=example,dc=org", "foo", "uid=foo,ou=people,dc=example,dc=org", false},
+		{"dn escaped", "uid={username},ou=people,dc=example,dc=org", "foo,ou=admin", "uid=foo\\2cou\\3dadmin,ou=people,dc=example,dc=org", false},
+		{"upn", "{username}@example.org", "foo", "foo@example.org", false},
+		{"upn not escaped", "{username}@example.org", "foo+bar", "foo+bar@example.org", false},
+		{"upn with another domain", "{username}@example.org", "foo@evil.org", "", true},
+		{"down-level logon name", `EXAMPLE\{username}`, `OTHER\foo`, "", true},
+		{"control character", "{username}@example.org", "foo\n", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := bindName(tc.template, tc.username)
+			if tc.expectErr {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Errorf("expected invalid credentials but returned %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name != tc.expected {
+				t.Errorf("expected %q but returned %q", tc.expected, name)
+			}
+		})
+	}
+}
+
+func TestBind(t *testing.T) {
+	server := startLDAPServer(t, "uid=foo,ou=people,dc=example,dc=org", "bar", nil)
+
+	cfg, err := ConfigFromSecret(newSecret(server.address))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := Bind(cfg, "foo", "bar"); err != nil {
+		t.Errorf("unexpected error with valid credentials: %v", err)
+	}
+	if err := Bind(cfg, "foo", "baz"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected invalid credentials but returned %v", err)
+	}
+	if err := Bind(cfg, "foo", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected invalid credentials without password but returned %v", err)
+	}
+}
+
+func TestBindStartTLS(t *testing.T) {
+	tlsConfig, ca := newTLSConfig(t)
+	server := startLDAPServer(t, "uid=foo,ou=people,dc=example,dc=org", "bar", tlsConfig)
+
+	secret := newSecret(server.address)
+	delete(secret.Data, AllowPlaintextKey)
+
+	// the certificate of the server is not signed by the system CAs
+	cfg, err := ConfigFromSecret(secret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Bind(cfg, "foo", "bar"); err == nil || errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected an error verifying the certificate but returned %v", err)
+	}
+
+	secret.Data[CAKey] = ca
+	cfg, err = ConfigFromSecret(secret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := Bind(cfg, "foo", "bar"); err != nil {
+		t.Errorf("unexpected error with valid credentials: %v", err)
+	}
+	if err := Bind(cfg, "foo", "baz"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected invalid credentials but returned %v", err)
+	}
+}
+
+func TestBindCache(t *testing.T) {
+	server := startLDAPServer(t, "uid=foo,ou=people,dc=example,dc=org", "bar", nil)
+
+	cfg, err := ConfigFromSecret(newSecret(server.address))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := Bind(cfg, "foo", "bar"); err != nil {
+			t.Errorf("unexpected error with valid credentials: %v", err)
+		}
+		if err := Bind(cfg, "foo", "baz"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("expected invalid credentials but returned %v", err)
+		}
+	}
+
+	if binds := server.binds.Load(); binds != 2 {
+		t.Errorf("expected 2 binds against the LDAP server but got %v", binds)
+	}
+}
+
+type fakeSecrets map[string]*corev1.Secret
+
+func (f fakeSecrets) LDAPSecret(ingress string) (*corev1.Secret, error) {
+	secret, ok := f[ingress]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	if secret == nil {
+		return nil, ErrNotAllowed
+	}
+
+	return secret, nil
+}
+
+func TestHandler(t *testing.T) {
+	server := startLDAPServer(t, "uid=foo,ou=people,dc=example,dc=org", "bar", nil)
+
+	mux := http.NewServeMux()
+	Register(mux, fakeSecrets{
+		"default/ldap":   newSecret(server.address),
+		"default/denied": nil,
+	})
+
+	path := func(ingress string) string {
+		u, err := url.Parse(AuthURL(ingress, "test"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return u.RequestURI()
+	}
+
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		path       string
+		username   string
+		password   string
+		expected   int
+	}{
+		{"valid credentials", "127.0.0.1:1234", path("default/ldap"), "foo", "bar", http.StatusOK},
+		{"invalid credentials", "127.0.0.1:1234", path("default/ldap"), "foo", "baz", http.StatusUnauthorized},
+		{"without credentials", "127.0.0.1:1234", path("default/ldap"), "", "", http.StatusUnauthorized},
+		{"remote request", "192.0.2.1:1234", path("default/ldap"), "foo", "bar", http.StatusForbidden},
+		{"without token", "127.0.0.1:1234", "/ldap-auth/default/ldap?realm=test", "foo", "bar", http.StatusForbidden},
+		{"token of another ingress", "127.0.0.1:1234", "/ldap-auth/default/ldap?realm=test&token=" + token("other/ldap"), "foo", "bar", http.StatusForbidden},
+		{"secret not allowed", "127.0.0.1:1234", path("default/denied"), "foo", "bar", http.StatusForbidden},
+		{"secret not found", "127.0.0.1:1234", path("default/other"), "foo", "bar", http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, http.NoBody)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.username != "" {
+				req.SetBasicAuth(tc.username, tc.password)
+			}
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != tc.expected {
+				t.Errorf("expected status %v but returned %v", tc.expected, w.Code)
+			}
+			if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") != `Basic realm="test"` {
+				t.Errorf("unexpected WWW-Authenticate header %q", w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAuthURL(t *testing.T) {
+	expected := "http://127.0.0.1:10254/ldap-auth/default/ldap?realm=Example+realm&token=" + token("default/ldap")
+	if u := AuthURL("default/ldap", "Example realm"); u != expected {
+		t.Errorf("expected %v but returned %v", expected, u)
+	}
+}
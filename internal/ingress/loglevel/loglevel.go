@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loglevel changes the verbosity of the controller and the level of
+// the error log of NGINX at runtime, reverting them after a duration.
+package loglevel
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	klog "k8s.io/klog/v2"
+)
+
+// Path is the path of the endpoint changing the log levels
+const Path = "/log-level"
+
+const (
+	// DefaultDuration is the time the levels are changed when the request does not define it
+	DefaultDuration = 15 * time.Minute
+	// MaxDuration is the longest time the levels can be changed
+	MaxDuration = 24 * time.Hour
+)
+
+// ErrorLogLevels are the valid levels of the error log of NGINX
+var ErrorLogLevels = []string{"debug", "info", "notice", "warn", "error", "crit", "alert", "emerg"}
+
+// Status contains the levels changed and the time they are reverted
+type Status struct {
+	// Verbosity is the current verbosity of the logs of the controller
+	Verbosity string `json:"verbosity"`
+	// ErrorLogLevel is the level of the error log of NGINX, empty if not changed
+	ErrorLogLevel string `json:"errorLogLevel,omitempty"`
+	// RevertAt is the time the levels are reverted, nil if not changed
+	RevertAt *time.Time `json:"revertAt,omitempty"`
+}
+
+// Changer changes the log levels and reverts them after a duration
+type Changer struct {
+	mu sync.Mutex
+
+	// onErrorLogLevelChange is called when the level of the error log of NGINX changes
+	onErrorLogLevelChange func()
+
+	// verbosity is the verbosity of the controller before the change, empty if not changed
+	verbosity     string
+	errorLogLevel string
+	revertAt      *time.Time
+	timer         *time.Timer
+}
+
+// NewChanger returns a Changer calling onErrorLogLevelChange when the level of
+// the error log of NGINX changes, to render the configuration again
+func NewChanger(onErrorLogLevelChange func()) *Changer {
+	return &Changer{onErrorLogLevelChange: onErrorLogLevelChange}
+}
+
+// Set changes the verbosity of the controller, if not empty, and the level of the error log
+// of NGINX, if not empty, during the duration. Previous changes are reverted.
+func (c *Changer) Set(verbosity, errorLogLevel string, d time.Duration) error {
+	v := flag.Lookup("v")
+	if v == nil {
+		return fmt.Errorf("the verbosity flag is not defined")
+	}
+
+	if verbosity != "" {
+		if n, err := strconv.Atoi(verbosity); err != nil || n < 0 {
+			return fmt.Errorf("invalid verbosity %q, it must be an integer greater or equal to 0", verbosity)
+		}
+	}
+
+	if errorLogLevel != "" && !slices.Contains(ErrorLogLevels, errorLogLevel) {
+		return fmt.Errorf("invalid error log level %q, it must be one of %v", errorLogLevel, ErrorLogLevels)
+	}
+
+	if verbosity == "" && errorLogLevel == "" {
+		return fmt.Errorf("the verbosity or the error log level are required")
+	}
+
+	if d <= 0 || d > MaxDuration {
+		return fmt.Errorf("invalid duration %v, it must be greater than 0 and at most %v", d, MaxDuration)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previousErrorLogLevel := c.errorLogLevel
+	c.revert(v)
+
+	if verbosity != "" {
+		c.verbosity = v.Value.String()
+		if err := v.Value.Set(verbosity); err != nil {
+			c.verbosity = ""
+			return err
+		}
+	}
+
+	c.errorLogLevel = errorLogLevel
+
+	revertAt := time.Now().Add(d)
+	c.revertAt = &revertAt
+	c.timer = time.AfterFunc(d, func() { c.expire(&revertAt) })
+
+	klog.InfoS("Changed the log levels", "verbosity", verbosity, "errorLogLevel", errorLogLevel, "duration", d)
+	if previousErrorLogLevel != errorLogLevel {
+		c.onErrorLogLevelChange()
+	}
+
+	return nil
+}
+
+// Revert restores the log levels changed
+func (c *Changer) Revert() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.revertAndNotify()
+}
+
+// expire reverts the log levels when they are still the ones changed at the given time
+func (c *Changer) expire(revertAt *time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.revertAt != revertAt {
+		return
+	}
+
+	klog.InfoS("Reverting the log levels")
+	c.revertAndNotify()
+}
+
+// revertAndNotify restores the log levels and notifies the change of the error
+// log level. It must be called with the lock held.
+func (c *Changer) revertAndNotify() {
+	v := flag.Lookup("v")
+	if v == nil {
+		return
+	}
+
+	errorLogLevelChanged := c.errorLogLevel != ""
+	c.revert(v)
+
+	if errorLogLevelChanged {
+		c.onErrorLogLevelChange()
+	}
+}
+
+// revert restores the log levels without notifying the change of the error log level.
+// It must be called with the lock held.
+func (c *Changer) revert(v *flag.Flag) {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+
+	if c.verbosity != "" {
+		if err := v.Value.Set(c.verbosity); err != nil {
+			klog.ErrorS(err, "Error reverting the verbosity", "verbosity", c.verbosity)
+		}
+		c.verbosity = ""
+	}
+
+	c.errorLogLevel = ""
+	c.revertAt = nil
+}
+
+// ErrorLogLevel returns the level of the error log of NGINX, empty if not changed
+func (c *Changer) ErrorLogLevel() string {
+	if c == nil {
+		return ""
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.errorLogLevel
+}
+
+// Status returns the current log levels
+func (c *Changer) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := Status{
+		ErrorLogLevel: c.errorLogLevel,
+		RevertAt:      c.revertAt,
+	}
+	if v := flag.Lookup("v"); v != nil {
+		status.Verbosity = v.Value.String()
+	}
+
+	return status
+}
+
+// Register exposes the endpoint changing the log levels in the given mux.
+// GET returns the levels, PUT changes them with the query parameters v,
+// error-log-level and duration, and DELETE reverts them.
+func Register(mux *http.ServeMux, c *Changer) {
+	mux.HandleFunc(Path, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			query := r.URL.Query()
+
+			d := DefaultDuration
+			if value := query.Get("duration"); value != "" {
+				var err error
+				d, err = time.ParseDuration(value)
+				if err != nil {
+					http.Error(w, fmt.Sprintf("invalid duration %q", value), http.StatusBadRequest)
+					return
+				}
+			}
+
+			if err := c.Set(query.Get("v"), query.Get("error-log-level"), d); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		case http.MethodDelete:
+			c.Revert()
+		default:
+			w.Header().Set("Allow", "GET, PUT, DELETE")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(c.Status()); err != nil {
+			klog.V(2).ErrorS(err, "Error writing the log levels")
+		}
+	})
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loglevel
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	klog "k8s.io/klog/v2"
+)
+
+func init() {
+	klog.InitFlags(nil)
+}
+
+func TestChanger(t *testing.T) {
+	if err := flag.Set("v", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	changes := 0
+	c := NewChanger(func() { changes++ })
+
+	invalid := []struct {
+		verbosity     string
+		errorLogLevel string
+		duration      time.Duration
+	}{
+		{"", "", time.Minute},
+		{"-1", "", time.Minute},
+		{"high", "", time.Minute},
+		{"", "verbose", time.Minute},
+		{"5", "", 0},
+		{"5", "", MaxDuration + time.Second},
+	}
+	for _, tc := range invalid {
+		if err := c.Set(tc.verbosity, tc.errorLogLevel, tc.duration); err == nil {
+			t.Errorf("expected an error setting %q, %q and %v", tc.verbosity, tc.errorLogLevel, tc.duration)
+		}
+	}
+
+	if err := c.Set("5", "debug", time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := c.Status()
+	if status.Verbosity != "5" || status.ErrorLogLevel != "debug" || status.RevertAt == nil {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if changes != 1 {
+		t.Errorf("expected 1 change of the error log level but got %v", changes)
+	}
+
+	// a new change reverts the previous one
+	if err := c.Set("3", "debug", time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changes != 1 {
+		t.Errorf("expected 1 change of the error log level but got %v", changes)
+	}
+
+	c.Revert()
+	status = c.Status()
+	if status.Verbosity != "1" || status.ErrorLogLevel != "" || status.RevertAt != nil {
+		t.Errorf("unexpected status after reverting %+v", status)
+	}
+	if changes != 2 {
+		t.Errorf("expected 2 changes of the error log level but got %v", changes)
+	}
+}
+
+func TestChangerExpiration(t *testing.T) {
+	if err := flag.Set("v", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reverted := make(chan struct{}, 2)
+	c := NewChanger(func() { reverted <- struct{}{} })
+
+	if err := c.Set("", "info", 10*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-reverted
+
+	select {
+	case <-reverted:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the error log level to be reverted")
+	}
+
+	if status := c.Status(); status.ErrorLogLevel != "" || status.RevertAt != nil {
+		t.Errorf("unexpected status after the expiration %+v", status)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if err := flag.Set("v", "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	Register(mux, NewChanger(func() {}))
+
+	tests := []struct {
+		method string
+		query  string
+		status int
+	}{
+		{http.MethodGet, "", http.StatusOK},
+		{http.MethodPut, "?v=4&duration=1m", http.StatusOK},
+		{http.MethodPut, "?v=4&duration=forever", http.StatusBadRequest},
+		{http.MethodPut, "?error-log-level=verbose", http.StatusBadRequest},
+		{http.MethodDelete, "", http.StatusOK},
+		{http.MethodPost, "", http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(tc.method, Path+tc.query, http.NoBody))
+
+		if w.Code != tc.status {
+			t.Errorf("expected status %v for %v %v but got %v", tc.status, tc.method, tc.query, w.Code)
+		}
+	}
+}
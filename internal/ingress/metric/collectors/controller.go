@@ -18,11 +18,14 @@ package collectors
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
+	"k8s.io/ingress-nginx/pkg/util/runtime"
 	"k8s.io/ingress-nginx/version"
 	"k8s.io/klog/v2"
 )
@@ -32,6 +35,7 @@ var (
 	ingressOperation = []string{"controller_namespace", "controller_class", "controller_pod", "namespace", "ingress"}
 	sslLabelHost     = []string{"namespace", "class", "host", "secret_name", "identifier"}
 	sslInfoLabels    = []string{"namespace", "class", "host", "secret_name", "identifier", "issuer_organization", "issuer_common_name", "serial_number", "public_key_algorithm"}
+	sslPendingLabels = []string{"namespace", "class", "host", "secret_name"}
 	orphanityLabels  = []string{"controller_namespace", "controller_class", "controller_pod", "namespace", "ingress", "type"}
 )
 
@@ -42,6 +46,21 @@ type Controller struct {
 	configHash        prometheus.Gauge
 	configSuccess     prometheus.Gauge
 	configSuccessTime prometheus.Gauge
+	configErrors      prometheus.Gauge
+
+	deprecatedUsage *prometheus.GaugeVec
+	configDrift     *prometheus.GaugeVec
+
+	serverNamesHashBucketSize prometheus.Gauge
+	serverNamesHashMaxSize    prometheus.Gauge
+
+	cpuTopology *prometheus.GaugeVec
+
+	shutdownForcedCloses *prometheus.CounterVec
+
+	reloadTriggers         *prometheus.CounterVec
+	namespaceConfigBytes   *prometheus.GaugeVec
+	namespaceRenderSeconds *prometheus.CounterVec
 
 	reloadOperation             *prometheus.CounterVec
 	reloadOperationErrors       *prometheus.CounterVec
@@ -49,6 +68,7 @@ type Controller struct {
 	checkIngressOperationErrors *prometheus.CounterVec
 	sslExpireTime               *prometheus.GaugeVec
 	sslInfo                     *prometheus.GaugeVec
+	sslPending                  *prometheus.GaugeVec
 	OrphanIngress               *prometheus.GaugeVec
 
 	constLabels prometheus.Labels
@@ -115,6 +135,90 @@ func NewController(pod, namespace, class string) *Controller {
 				Help:        "Timestamp of the last successful configuration reload.",
 				ConstLabels: constLabels,
 			}),
+		configErrors: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "config_last_apply_errors",
+				Help:        "Number of keys of the configuration ConfigMap ignored because they are unknown or their value is not valid",
+				ConstLabels: constLabels,
+			}),
+		deprecatedUsage: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "deprecated_usage",
+				Help:        "Number of Ingresses using a deprecated annotation, or 1 for a deprecated key of the configuration ConfigMap",
+				ConstLabels: constLabels,
+			},
+			[]string{"kind", "name"},
+		),
+		configDrift: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "config_drift",
+				Help:        "Whether nginx.conf or the backends stored by Lua were changed out of band, by kind",
+				ConstLabels: constLabels,
+			},
+			[]string{"kind"},
+		),
+		serverNamesHashBucketSize: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "server_names_hash_bucket_size",
+				Help:        "Bucket size of the server names hash tables of the running configuration",
+				ConstLabels: constLabels,
+			}),
+		serverNamesHashMaxSize: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "server_names_hash_max_size",
+				Help:        "Maximum size of the server names hash tables of the running configuration",
+				ConstLabels: constLabels,
+			}),
+		cpuTopology: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "cpu_topology",
+				Help:        "Number of CPUs usable by NGINX by cluster of CPUs with the same capacity, from the fastest cluster 0",
+				ConstLabels: constLabels,
+			},
+			[]string{"architecture", "cluster", "capacity", "aes"},
+		),
+		shutdownForcedCloses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "shutdown_forced_closes_total",
+				Help:        "The number of passthrough and stream connections closed at the end of the shutdown grace period",
+				ConstLabels: constLabels,
+			},
+			[]string{"traffic"},
+		),
+		reloadTriggers: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "reload_triggers_total",
+				Help:        "Cumulative number of reloads of NGINX triggered by the creation, change or deletion of an Ingress",
+				ConstLabels: constLabels,
+			},
+			[]string{"namespace", "ingress"},
+		),
+		namespaceConfigBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "namespace_config_bytes",
+				Help:        "Size of the locations of the Ingresses of a namespace in the last nginx.conf rendered",
+				ConstLabels: constLabels,
+			},
+			[]string{"namespace"},
+		),
+		namespaceRenderSeconds: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   PrometheusNamespace,
+				Name:        "namespace_render_seconds_total",
+				Help:        "Cumulative time rendering nginx.conf for reloads, split between the namespaces by the size of their locations",
+				ConstLabels: constLabels,
+			},
+			[]string{"namespace"},
+		),
 		reloadOperation: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: PrometheusNamespace,
@@ -164,6 +268,14 @@ func NewController(pod, namespace, class string) *Controller {
 			},
 			sslInfoLabels,
 		),
+		sslPending: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "ssl_certificate_pending",
+				Help:      `Hosts served with the default certificate because their TLS Secret does not exist yet`,
+			},
+			sslPendingLabels,
+		),
 		leaderElection: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace:   PrometheusNamespace,
@@ -260,17 +372,102 @@ func (cm *Controller) ConfigSuccess(hash uint64, success bool) {
 	cm.configHash.Set(0)
 }
 
+// SetConfigErrors sets the number of keys of the configuration ConfigMap ignored
+// in the last update
+func (cm *Controller) SetConfigErrors(count int) {
+	cm.configErrors.Set(float64(count))
+}
+
+// SetDeprecatedUsage sets the number of Ingresses using each deprecated annotation
+// and the deprecated keys of the configuration ConfigMap
+func (cm *Controller) SetDeprecatedUsage(annotations map[string]int, keys []string) {
+	cm.deprecatedUsage.Reset()
+	for name, count := range annotations {
+		cm.deprecatedUsage.WithLabelValues("annotation", name).Set(float64(count))
+	}
+	for _, name := range keys {
+		cm.deprecatedUsage.WithLabelValues("configmap", name).Set(1)
+	}
+}
+
+// SetConfigDrift sets whether a part of the running configuration was changed out of band
+func (cm *Controller) SetConfigDrift(kind string, drifted bool) {
+	if drifted {
+		cm.configDrift.WithLabelValues(kind).Set(1)
+		return
+	}
+
+	cm.configDrift.WithLabelValues(kind).Set(0)
+}
+
+// SetServerNamesHash sets the sizes of the server names hash tables of the configuration
+func (cm *Controller) SetServerNamesHash(bucketSize, maxSize int) {
+	cm.serverNamesHashBucketSize.Set(float64(bucketSize))
+	cm.serverNamesHashMaxSize.Set(float64(maxSize))
+}
+
+// SetCPUTopology sets the clusters of CPUs detected and whether they accelerate AES
+func (cm *Controller) SetCPUTopology(topology runtime.CPUTopology) {
+	cm.cpuTopology.Reset()
+	for i, cluster := range topology.Clusters {
+		cm.cpuTopology.WithLabelValues(topology.Architecture, strconv.Itoa(i),
+			strconv.FormatInt(cluster.Capacity, 10), strconv.FormatBool(topology.AES)).Set(float64(len(cluster.CPUs)))
+	}
+}
+
+// AddShutdownForcedCloses counts the connections of the traffic, passthrough or
+// stream, closed at the end of the shutdown grace period
+func (cm *Controller) AddShutdownForcedCloses(traffic string, count int) {
+	cm.shutdownForcedCloses.WithLabelValues(traffic).Add(float64(count))
+}
+
+// IncReloadTriggers counts a reload triggered by the Ingresses, by namespace and name
+func (cm *Controller) IncReloadTriggers(ingresses []string) {
+	for _, key := range ingresses {
+		namespace, name, _ := strings.Cut(key, "/")
+		cm.reloadTriggers.WithLabelValues(namespace, name).Inc()
+	}
+}
+
+// SetNamespaceConfig sets the size of the locations of each namespace in nginx.conf
+// and adds their share of the time rendering it
+func (cm *Controller) SetNamespaceConfig(sizes map[string]int, render time.Duration) {
+	total := 0
+	for _, size := range sizes {
+		total += size
+	}
+
+	cm.namespaceConfigBytes.Reset()
+	for namespace, size := range sizes {
+		cm.namespaceConfigBytes.WithLabelValues(namespace).Set(float64(size))
+		if total > 0 {
+			cm.namespaceRenderSeconds.WithLabelValues(namespace).Add(render.Seconds() * float64(size) / float64(total))
+		}
+	}
+}
+
 // Describe implements prometheus.Collector
 func (cm *Controller) Describe(ch chan<- *prometheus.Desc) {
 	cm.configHash.Describe(ch)
 	cm.configSuccess.Describe(ch)
 	cm.configSuccessTime.Describe(ch)
+	cm.configErrors.Describe(ch)
+	cm.deprecatedUsage.Describe(ch)
+	cm.configDrift.Describe(ch)
+	cm.serverNamesHashBucketSize.Describe(ch)
+	cm.serverNamesHashMaxSize.Describe(ch)
+	cm.cpuTopology.Describe(ch)
+	cm.shutdownForcedCloses.Describe(ch)
+	cm.reloadTriggers.Describe(ch)
+	cm.namespaceConfigBytes.Describe(ch)
+	cm.namespaceRenderSeconds.Describe(ch)
 	cm.reloadOperation.Describe(ch)
 	cm.reloadOperationErrors.Describe(ch)
 	cm.checkIngressOperation.Describe(ch)
 	cm.checkIngressOperationErrors.Describe(ch)
 	cm.sslExpireTime.Describe(ch)
 	cm.sslInfo.Describe(ch)
+	cm.sslPending.Describe(ch)
 	cm.leaderElection.Describe(ch)
 	cm.buildInfo.Describe(ch)
 	cm.OrphanIngress.Describe(ch)
@@ -281,12 +478,23 @@ func (cm *Controller) Collect(ch chan<- prometheus.Metric) {
 	cm.configHash.Collect(ch)
 	cm.configSuccess.Collect(ch)
 	cm.configSuccessTime.Collect(ch)
+	cm.configErrors.Collect(ch)
+	cm.deprecatedUsage.Collect(ch)
+	cm.configDrift.Collect(ch)
+	cm.serverNamesHashBucketSize.Collect(ch)
+	cm.serverNamesHashMaxSize.Collect(ch)
+	cm.cpuTopology.Collect(ch)
+	cm.shutdownForcedCloses.Collect(ch)
+	cm.reloadTriggers.Collect(ch)
+	cm.namespaceConfigBytes.Collect(ch)
+	cm.namespaceRenderSeconds.Collect(ch)
 	cm.reloadOperation.Collect(ch)
 	cm.reloadOperationErrors.Collect(ch)
 	cm.checkIngressOperation.Collect(ch)
 	cm.checkIngressOperationErrors.Collect(ch)
 	cm.sslExpireTime.Collect(ch)
 	cm.sslInfo.Collect(ch)
+	cm.sslPending.Collect(ch)
 	cm.leaderElection.Collect(ch)
 	cm.buildInfo.Collect(ch)
 	cm.OrphanIngress.Collect(ch)
@@ -339,6 +547,30 @@ func (cm *Controller) SetSSLInfo(servers []*ingress.Server) {
 	}
 }
 
+// SetSSLPending sets the hosts waiting for their TLS Secret, the hosts
+// of the previous configuration are removed
+func (cm *Controller) SetSSLPending(servers []*ingress.Server) {
+	cm.sslPending.Reset()
+
+	for _, s := range servers {
+		if s.PendingCertificate == "" {
+			continue
+		}
+
+		namespace, name, _ := strings.Cut(s.PendingCertificate, "/")
+
+		labels := make(prometheus.Labels, len(cm.labels)+2)
+		for k, v := range cm.labels {
+			labels[k] = v
+		}
+		labels["host"] = s.Hostname
+		labels["secret_name"] = name
+		labels["namespace"] = namespace
+
+		cm.sslPending.With(labels).Set(1)
+	}
+}
+
 // RemoveMetrics removes metrics for certificates not available anymore by identifier
 func (cm *Controller) RemoveMetrics(certificates []string, registry prometheus.Gatherer) {
 	cm.removeSSLExpireMetrics(true, certificates, registry)
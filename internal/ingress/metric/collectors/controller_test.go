@@ -25,6 +25,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
+	"k8s.io/ingress-nginx/pkg/util/runtime"
 )
 
 func TestControllerCounters(t *testing.T) {
@@ -73,6 +74,111 @@ func TestControllerCounters(t *testing.T) {
 			`,
 			metrics: []string{"nginx_ingress_controller_errors"},
 		},
+		{
+			name: "should set the sizes of the server names hash tables",
+			test: func(cm *Controller) {
+				cm.SetServerNamesHash(128, 2048)
+			},
+			want: `
+				# HELP nginx_ingress_controller_server_names_hash_bucket_size Bucket size of the server names hash tables of the running configuration
+				# TYPE nginx_ingress_controller_server_names_hash_bucket_size gauge
+				nginx_ingress_controller_server_names_hash_bucket_size{controller_class="nginx",controller_namespace="default",controller_pod="pod"} 128
+				# HELP nginx_ingress_controller_server_names_hash_max_size Maximum size of the server names hash tables of the running configuration
+				# TYPE nginx_ingress_controller_server_names_hash_max_size gauge
+				nginx_ingress_controller_server_names_hash_max_size{controller_class="nginx",controller_namespace="default",controller_pod="pod"} 2048
+			`,
+			metrics: []string{"nginx_ingress_controller_server_names_hash_bucket_size", "nginx_ingress_controller_server_names_hash_max_size"},
+		},
+		{
+			name: "should count the reloads triggered by the ingresses",
+			test: func(cm *Controller) {
+				cm.IncReloadTriggers([]string{"team-a/api", "team-b/web"})
+				cm.IncReloadTriggers([]string{"team-a/api"})
+			},
+			want: `
+				# HELP nginx_ingress_controller_reload_triggers_total Cumulative number of reloads of NGINX triggered by the creation, change or deletion of an Ingress
+				# TYPE nginx_ingress_controller_reload_triggers_total counter
+				nginx_ingress_controller_reload_triggers_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",ingress="api",namespace="team-a"} 2
+				nginx_ingress_controller_reload_triggers_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",ingress="web",namespace="team-b"} 1
+			`,
+			metrics: []string{"nginx_ingress_controller_reload_triggers_total"},
+		},
+		{
+			name: "should set the configuration of the namespaces",
+			test: func(cm *Controller) {
+				cm.SetNamespaceConfig(map[string]int{"team-a": 3000, "team-b": 1000}, 2*time.Second)
+				cm.SetNamespaceConfig(map[string]int{"team-a": 1000}, time.Second)
+			},
+			want: `
+				# HELP nginx_ingress_controller_namespace_config_bytes Size of the locations of the Ingresses of a namespace in the last nginx.conf rendered
+				# TYPE nginx_ingress_controller_namespace_config_bytes gauge
+				nginx_ingress_controller_namespace_config_bytes{controller_class="nginx",controller_namespace="default",controller_pod="pod",namespace="team-a"} 1000
+				# HELP nginx_ingress_controller_namespace_render_seconds_total Cumulative time rendering nginx.conf for reloads, split between the namespaces by the size of their locations
+				# TYPE nginx_ingress_controller_namespace_render_seconds_total counter
+				nginx_ingress_controller_namespace_render_seconds_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",namespace="team-a"} 2.5
+				nginx_ingress_controller_namespace_render_seconds_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",namespace="team-b"} 0.5
+			`,
+			metrics: []string{"nginx_ingress_controller_namespace_config_bytes", "nginx_ingress_controller_namespace_render_seconds_total"},
+		},
+		{
+			name: "should set the CPU topology",
+			test: func(cm *Controller) {
+				cm.SetCPUTopology(runtime.CPUTopology{
+					Architecture: "arm64",
+					Clusters: []runtime.CPUCluster{
+						{CPUs: []int{4, 5}, Capacity: 1024},
+						{CPUs: []int{0, 1, 2, 3}, Capacity: 446},
+					},
+					AES: true,
+				})
+			},
+			want: `
+				# HELP nginx_ingress_controller_cpu_topology Number of CPUs usable by NGINX by cluster of CPUs with the same capacity, from the fastest cluster 0
+				# TYPE nginx_ingress_controller_cpu_topology gauge
+				nginx_ingress_controller_cpu_topology{aes="true",architecture="arm64",capacity="1024",cluster="0",controller_class="nginx",controller_namespace="default",controller_pod="pod"} 2
+				nginx_ingress_controller_cpu_topology{aes="true",architecture="arm64",capacity="446",cluster="1",controller_class="nginx",controller_namespace="default",controller_pod="pod"} 4
+			`,
+			metrics: []string{"nginx_ingress_controller_cpu_topology"},
+		},
+		{
+			name: "should set the number of ignored configuration keys",
+			test: func(cm *Controller) {
+				cm.SetConfigErrors(2)
+			},
+			want: `
+				# HELP nginx_ingress_controller_config_last_apply_errors Number of keys of the configuration ConfigMap ignored because they are unknown or their value is not valid
+				# TYPE nginx_ingress_controller_config_last_apply_errors gauge
+				nginx_ingress_controller_config_last_apply_errors{controller_class="nginx",controller_namespace="default",controller_pod="pod"} 2
+			`,
+			metrics: []string{"nginx_ingress_controller_config_last_apply_errors"},
+		},
+		{
+			name: "should set the usage of deprecated annotations and keys",
+			test: func(cm *Controller) {
+				cm.SetDeprecatedUsage(map[string]int{"nginx.ingress.kubernetes.io/whitelist-source-range": 2}, []string{"http2-max-requests"})
+			},
+			want: `
+				# HELP nginx_ingress_controller_deprecated_usage Number of Ingresses using a deprecated annotation, or 1 for a deprecated key of the configuration ConfigMap
+				# TYPE nginx_ingress_controller_deprecated_usage gauge
+				nginx_ingress_controller_deprecated_usage{controller_class="nginx",controller_namespace="default",controller_pod="pod",kind="annotation",name="nginx.ingress.kubernetes.io/whitelist-source-range"} 2
+				nginx_ingress_controller_deprecated_usage{controller_class="nginx",controller_namespace="default",controller_pod="pod",kind="configmap",name="http2-max-requests"} 1
+			`,
+			metrics: []string{"nginx_ingress_controller_deprecated_usage"},
+		},
+		{
+			name: "should set the drift of the configuration",
+			test: func(cm *Controller) {
+				cm.SetConfigDrift("nginx-conf", true)
+				cm.SetConfigDrift("backends", false)
+			},
+			want: `
+				# HELP nginx_ingress_controller_config_drift Whether nginx.conf or the backends stored by Lua were changed out of band, by kind
+				# TYPE nginx_ingress_controller_config_drift gauge
+				nginx_ingress_controller_config_drift{controller_class="nginx",controller_namespace="default",controller_pod="pod",kind="backends"} 0
+				nginx_ingress_controller_config_drift{controller_class="nginx",controller_namespace="default",controller_pod="pod",kind="nginx-conf"} 1
+			`,
+			metrics: []string{"nginx_ingress_controller_config_drift"},
+		},
 		{
 			name: "should set SSL certificates metrics",
 			test: func(cm *Controller) {
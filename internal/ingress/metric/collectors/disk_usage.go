@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PathUsage contains the disk usage of a temporary or cache directory of NGINX
+type PathUsage struct {
+	Path string
+	// Kind is client-body, proxy-temp, fastcgi-temp or cache
+	Kind  string
+	Bytes int64
+	Files int64
+	// FilesystemUsedRatio is the used part of the filesystem containing the directory
+	FilesystemUsedRatio float64
+}
+
+// DiskUsageCollector collects the disk usage of the temporary and cache directories
+// of NGINX, and the cache files removed over the high watermark
+type DiskUsageCollector struct {
+	bytes          *prometheus.GaugeVec
+	files          *prometheus.GaugeVec
+	filesystemUsed *prometheus.GaugeVec
+	evictedBytes   *prometheus.CounterVec
+}
+
+// NewDiskUsageCollector returns a collector of the disk usage of NGINX
+func NewDiskUsageCollector(podName, namespace, ingressClass string) *DiskUsageCollector {
+	constLabels := prometheus.Labels{
+		"controller_namespace": namespace,
+		"controller_class":     ingressClass,
+		"controller_pod":       podName,
+	}
+
+	return &DiskUsageCollector{
+		bytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "disk_usage_bytes",
+				Help:        "The number of bytes of the files of a temporary or cache directory of NGINX",
+				Namespace:   PrometheusNamespace,
+				Subsystem:   subSystem,
+				ConstLabels: constLabels,
+			},
+			[]string{"path", "kind"},
+		),
+		files: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "disk_usage_files",
+				Help:        "The number of files of a temporary or cache directory of NGINX",
+				Namespace:   PrometheusNamespace,
+				Subsystem:   subSystem,
+				ConstLabels: constLabels,
+			},
+			[]string{"path", "kind"},
+		),
+		filesystemUsed: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "disk_filesystem_used_ratio",
+				Help:        "The used part of the filesystem containing a temporary or cache directory of NGINX",
+				Namespace:   PrometheusNamespace,
+				Subsystem:   subSystem,
+				ConstLabels: constLabels,
+			},
+			[]string{"path"},
+		),
+		evictedBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "cache_evicted_bytes_total",
+				Help:        "The number of bytes of the cache files removed because the filesystem was over the high watermark",
+				Namespace:   PrometheusNamespace,
+				Subsystem:   subSystem,
+				ConstLabels: constLabels,
+			},
+			[]string{"path"},
+		),
+	}
+}
+
+// SetPathsUsage replaces the disk usage of the directories
+func (c *DiskUsageCollector) SetPathsUsage(paths []PathUsage) {
+	c.bytes.Reset()
+	c.files.Reset()
+	c.filesystemUsed.Reset()
+
+	for i := range paths {
+		p := &paths[i]
+		c.bytes.WithLabelValues(p.Path, p.Kind).Set(float64(p.Bytes))
+		c.files.WithLabelValues(p.Path, p.Kind).Set(float64(p.Files))
+		c.filesystemUsed.WithLabelValues(p.Path).Set(p.FilesystemUsedRatio)
+	}
+}
+
+// AddCacheEviction counts the bytes of the files removed from a cache directory
+func (c *DiskUsageCollector) AddCacheEviction(path string, bytes int64) {
+	c.evictedBytes.WithLabelValues(path).Add(float64(bytes))
+}
+
+// Describe implements prometheus.Collector
+func (c *DiskUsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.bytes.Describe(ch)
+	c.files.Describe(ch)
+	c.filesystemUsed.Describe(ch)
+	c.evictedBytes.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *DiskUsageCollector) Collect(ch chan<- prometheus.Metric) {
+	c.bytes.Collect(ch)
+	c.files.Collect(ch)
+	c.filesystemUsed.Collect(ch)
+	c.evictedBytes.Collect(ch)
+}
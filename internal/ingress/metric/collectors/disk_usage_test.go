@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestDiskUsageCollector(t *testing.T) {
+	c := NewDiskUsageCollector("pod", "default", "nginx")
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatalf("registering collector failed: %s", err)
+	}
+
+	c.SetPathsUsage([]PathUsage{
+		{Path: "/tmp/nginx/proxy-temp", Kind: "proxy-temp", Bytes: 2048, Files: 2, FilesystemUsedRatio: 0.5},
+	})
+	// the directories removed since the previous check are not reported
+	c.SetPathsUsage([]PathUsage{
+		{Path: "/tmp/nginx/nginx-cache-slice", Kind: "cache", Bytes: 4096, Files: 1, FilesystemUsedRatio: 0.75},
+	})
+	c.AddCacheEviction("/tmp/nginx/nginx-cache-slice", 1024)
+
+	want := `
+		# HELP nginx_ingress_controller_nginx_process_cache_evicted_bytes_total The number of bytes of the cache files removed because the filesystem was over the high watermark
+		# TYPE nginx_ingress_controller_nginx_process_cache_evicted_bytes_total counter
+		nginx_ingress_controller_nginx_process_cache_evicted_bytes_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",path="/tmp/nginx/nginx-cache-slice"} 1024
+		# HELP nginx_ingress_controller_nginx_process_disk_filesystem_used_ratio The used part of the filesystem containing a temporary or cache directory of NGINX
+		# TYPE nginx_ingress_controller_nginx_process_disk_filesystem_used_ratio gauge
+		nginx_ingress_controller_nginx_process_disk_filesystem_used_ratio{controller_class="nginx",controller_namespace="default",controller_pod="pod",path="/tmp/nginx/nginx-cache-slice"} 0.75
+		# HELP nginx_ingress_controller_nginx_process_disk_usage_bytes The number of bytes of the files of a temporary or cache directory of NGINX
+		# TYPE nginx_ingress_controller_nginx_process_disk_usage_bytes gauge
+		nginx_ingress_controller_nginx_process_disk_usage_bytes{controller_class="nginx",controller_namespace="default",controller_pod="pod",kind="cache",path="/tmp/nginx/nginx-cache-slice"} 4096
+		# HELP nginx_ingress_controller_nginx_process_disk_usage_files The number of files of a temporary or cache directory of NGINX
+		# TYPE nginx_ingress_controller_nginx_process_disk_usage_files gauge
+		nginx_ingress_controller_nginx_process_disk_usage_files{controller_class="nginx",controller_namespace="default",controller_pod="pod",kind="cache",path="/tmp/nginx/nginx-cache-slice"} 1
+	`
+
+	if err := GatherAndCompare(c, want, nil, reg); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	reg.Unregister(c)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"errors"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+// ErrorLogLevel is the lowest level of the entries of the error log sent to the collector.
+// Client SSL handshake errors are logged with the info level.
+const ErrorLogLevel = "info"
+
+// Classes of the entries of the error log
+const (
+	ErrorLogClassUpstreamTimeout    = "upstream_timeout"
+	ErrorLogClassUpstreamConnection = "upstream_connection"
+	ErrorLogClassSSLHandshake       = "ssl_handshake"
+	ErrorLogClassResolver           = "resolver"
+	ErrorLogClassWorkerCrash        = "worker_crash"
+	ErrorLogClassLua                = "lua"
+	ErrorLogClassUntrustedProxy     = "untrusted_proxy_protocol"
+	ErrorLogClassOther              = "other"
+)
+
+// errorLogClasses are the classes of the entries of the error log by the texts
+// of their messages, in order of precedence
+var errorLogClasses = []struct {
+	class string
+	texts []string
+}{
+	{ErrorLogClassWorkerCrash, []string{"exited on signal"}},
+	{ErrorLogClassUpstreamTimeout, []string{"upstream timed out"}},
+	{ErrorLogClassResolver, []string{"could not be resolved", "resolver error", "dns resolver", "failed to query the DNS server"}},
+	{ErrorLogClassSSLHandshake, []string{"SSL_do_handshake() failed", "while SSL handshaking"}},
+	{ErrorLogClassUpstreamConnection, []string{"while connecting to upstream", "upstream prematurely closed", "no live upstreams", "upstream sent invalid"}},
+	{ErrorLogClassUntrustedProxy, []string{"PROXY protocol header of untrusted peer"}},
+	{ErrorLogClassLua, []string{"[lua]", "lua entry thread aborted", "runtime error:"}},
+}
+
+// errorLogLevels are the levels of the error log by the severity of the syslog priority
+var errorLogLevels = []string{"emerg", "alert", "crit", "error", "warn", "notice", "info", "debug"}
+
+// errorLogServerRegex extracts the server name of the request from an entry of the error log
+var errorLogServerRegex = regexp.MustCompile(`, server: ([^,\s]*)`)
+
+// errorLogEntry is an entry of the error log of NGINX
+type errorLogEntry struct {
+	level string
+	class string
+	host  string
+}
+
+// parseErrorLogEntry parses an entry of the error log sent with the syslog protocol
+func parseErrorLogEntry(msg string) (*errorLogEntry, bool) {
+	if !strings.HasPrefix(msg, "<") {
+		return nil, false
+	}
+
+	end := strings.IndexByte(msg, '>')
+	if end < 0 {
+		return nil, false
+	}
+
+	priority, err := strconv.Atoi(msg[1:end])
+	if err != nil || priority < 0 {
+		return nil, false
+	}
+
+	entry := &errorLogEntry{
+		level: errorLogLevels[priority%8],
+		class: ErrorLogClassOther,
+	}
+
+	for _, c := range errorLogClasses {
+		if containsAny(msg, c.texts) {
+			entry.class = c.class
+			break
+		}
+	}
+
+	if m := errorLogServerRegex.FindStringSubmatch(msg); m != nil {
+		entry.host = m[1]
+	}
+
+	return entry, true
+}
+
+func containsAny(s string, texts []string) bool {
+	for _, text := range texts {
+		if strings.Contains(s, text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ErrorLogCollector counts the entries of the error log of NGINX by level and
+// class, like upstream timeouts or SSL handshake errors, and by host when the
+// entry is related to a request.
+type ErrorLogCollector struct {
+	entries *prometheus.CounterVec
+
+	metricsPerHost bool
+
+	conn net.PacketConn
+}
+
+// NewErrorLogCollector returns a collector receiving the entries of the error log in nginx.ErrorLogSocket
+func NewErrorLogCollector(podName, namespace, ingressClass string, metricsPerHost bool) (*ErrorLogCollector, error) {
+	// unix sockets must be unlink()ed before being used
+	//nolint:errcheck // Ignore unlink error
+	_ = os.Remove(nginx.ErrorLogSocket)
+
+	conn, err := net.ListenPacket("unixgram", nginx.ErrorLogSocket)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.Chmod(nginx.ErrorLogSocket, 0o777) // #nosec
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return newErrorLogCollector(podName, namespace, ingressClass, metricsPerHost, conn), nil
+}
+
+func newErrorLogCollector(podName, namespace, ingressClass string, metricsPerHost bool, conn net.PacketConn) *ErrorLogCollector {
+	constLabels := prometheus.Labels{
+		"controller_namespace": namespace,
+		"controller_class":     ingressClass,
+		"controller_pod":       podName,
+	}
+
+	labels := []string{"level", "class"}
+	if metricsPerHost {
+		labels = append(labels, "host")
+	}
+
+	return &ErrorLogCollector{
+		entries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "nginx_error_log_entries_total",
+				Help:        "The number of entries of the error log of NGINX by level and class",
+				Namespace:   PrometheusNamespace,
+				ConstLabels: constLabels,
+			},
+			labels,
+		),
+		metricsPerHost: metricsPerHost,
+		conn:           conn,
+	}
+}
+
+// Start reads the entries of the error log until the collector is stopped
+func (c *ErrorLogCollector) Start() {
+	buf := make([]byte, 64*1024)
+	for {
+		n, _, err := c.conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			klog.V(2).ErrorS(err, "Error reading the error log of NGINX")
+			continue
+		}
+
+		c.handleMessage(string(buf[:n]))
+	}
+}
+
+// Stop stops reading the entries of the error log
+func (c *ErrorLogCollector) Stop() {
+	c.conn.Close()
+}
+
+func (c *ErrorLogCollector) handleMessage(msg string) {
+	entry, ok := parseErrorLogEntry(msg)
+	if !ok {
+		klog.V(3).InfoS("Invalid entry of the error log of NGINX", "entry", msg)
+		return
+	}
+
+	labels := prometheus.Labels{
+		"level": entry.level,
+		"class": entry.class,
+	}
+	if c.metricsPerHost {
+		labels["host"] = entry.host
+	}
+
+	c.entries.With(labels).Inc()
+}
+
+// Describe implements prometheus.Collector
+func (c *ErrorLogCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.entries.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *ErrorLogCollector) Collect(ch chan<- prometheus.Metric) {
+	c.entries.Collect(ch)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestParseErrorLogEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   string
+		valid bool
+		entry errorLogEntry
+	}{
+		{
+			"upstream timeout",
+			`<11>nginx: 2026/10/16 10:00:00 [error] 32#32: *5 upstream timed out (110: Connection timed out) while reading response header from upstream, client: 10.0.0.1, server: foo.bar, request: "GET / HTTP/1.1", upstream: "http://10.1.0.5:8080/", host: "foo.bar"`,
+			true,
+			errorLogEntry{level: "error", class: ErrorLogClassUpstreamTimeout, host: "foo.bar"},
+		},
+		{
+			"upstream connection refused",
+			`<11>nginx: [error] 32#32: *7 connect() failed (111: Connection refused) while connecting to upstream, client: 10.0.0.1, server: _, request: "GET / HTTP/1.1"`,
+			true,
+			errorLogEntry{level: "error", class: ErrorLogClassUpstreamConnection, host: "_"},
+		},
+		{
+			"client SSL handshake",
+			`<14>nginx: [info] 32#32: *9 SSL_do_handshake() failed (SSL: error:0A000102:SSL routines::unsupported protocol) while SSL handshaking, client: 10.0.0.1, server: 0.0.0.0:443`,
+			true,
+			errorLogEntry{level: "info", class: ErrorLogClassSSLHandshake, host: "0.0.0.0:443"},
+		},
+		{
+			"resolver",
+			`<11>nginx: [error] 32#32: *3 external.example.com could not be resolved (3: Host not found), client: 10.0.0.1, server: foo.bar, request: "GET / HTTP/1.1"`,
+			true,
+			errorLogEntry{level: "error", class: ErrorLogClassResolver, host: "foo.bar"},
+		},
+		{
+			"worker crash",
+			`<9>nginx: [alert] 7#7: worker process 32 exited on signal 11 (core dumped)`,
+			true,
+			errorLogEntry{level: "alert", class: ErrorLogClassWorkerCrash},
+		},
+		{
+			"lua",
+			`<11>nginx: [error] 32#32: *1 [lua] balancer.lua:348: balance(): no peer was returned, client: 10.0.0.1, server: foo.bar`,
+			true,
+			errorLogEntry{level: "error", class: ErrorLogClassLua, host: "foo.bar"},
+		},
+		{
+			"untrusted proxy protocol",
+			`<12>nginx: [warn] 32#32: *1 [lua] proxy_protocol.lua:30: reject(): closed the connection of the PROXY protocol header of untrusted peer 10.0.0.1, client: 1.2.3.4, server: foo.bar`,
+			true,
+			errorLogEntry{level: "warn", class: ErrorLogClassUntrustedProxy, host: "foo.bar"},
+		},
+		{
+			"other",
+			`<13>nginx: [notice] 7#7: signal process started`,
+			true,
+			errorLogEntry{level: "notice", class: ErrorLogClassOther},
+		},
+		{"without priority", `nginx: [error] upstream timed out`, false, errorLogEntry{}},
+		{"invalid priority", `<error>nginx: upstream timed out`, false, errorLogEntry{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			entry, ok := parseErrorLogEntry(tc.msg)
+			if ok != tc.valid {
+				t.Fatalf("expected valid %v but got %v", tc.valid, ok)
+			}
+			if ok && *entry != tc.entry {
+				t.Errorf("expected %+v but got %+v", tc.entry, *entry)
+			}
+		})
+	}
+}
+
+func TestErrorLogCollector(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "error-log.socket")
+	conn, err := net.ListenPacket("unixgram", socket)
+	if err != nil {
+		t.Fatalf("unexpected error listening in %v: %v", socket, err)
+	}
+
+	c := newErrorLogCollector("pod", "default", "nginx", true, conn)
+	go c.Start()
+	defer c.Stop()
+
+	client, err := net.Dial("unixgram", socket)
+	if err != nil {
+		t.Fatalf("unexpected error connecting to %v: %v", socket, err)
+	}
+	defer client.Close()
+
+	for _, msg := range []string{
+		`<11>nginx: [error] 32#32: *5 upstream timed out (110: Connection timed out) while reading response header from upstream, client: 10.0.0.1, server: foo.bar`,
+		`<11>nginx: [error] 32#32: *6 upstream timed out (110: Connection timed out) while reading response header from upstream, client: 10.0.0.1, server: foo.bar`,
+		`<9>nginx: [alert] 7#7: worker process 32 exited on signal 11`,
+		`invalid`,
+	} {
+		if _, err := client.Write([]byte(msg)); err != nil {
+			t.Fatalf("unexpected error writing to %v: %v", socket, err)
+		}
+	}
+
+	want := `
+		# HELP nginx_ingress_controller_nginx_error_log_entries_total The number of entries of the error log of NGINX by level and class
+		# TYPE nginx_ingress_controller_nginx_error_log_entries_total counter
+		nginx_ingress_controller_nginx_error_log_entries_total{class="upstream_timeout",controller_class="nginx",controller_namespace="default",controller_pod="pod",host="foo.bar",level="error"} 2
+		nginx_ingress_controller_nginx_error_log_entries_total{class="worker_crash",controller_class="nginx",controller_namespace="default",controller_pod="pod",host="",level="alert"} 1
+	`
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Errorf("registering collector failed: %s", err)
+	}
+
+	var gatherErr error
+	for i := 0; i < 50; i++ {
+		if gatherErr = GatherAndCompare(c, want, nil, reg); gatherErr == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if gatherErr != nil {
+		t.Errorf("unexpected collecting result:\n%s", gatherErr)
+	}
+}
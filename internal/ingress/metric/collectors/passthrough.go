@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PassthroughCollector collects the metrics of the connections of the SSL
+// Passthrough proxy by server, the passthrough hostname or nginx for the
+// connections terminated by NGINX.
+type PassthroughCollector struct {
+	connections *prometheus.CounterVec
+	active      *prometheus.GaugeVec
+	dialErrors  *prometheus.CounterVec
+	bytes       *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	untrusted   prometheus.Counter
+}
+
+// NewPassthroughCollector returns a collector of the metrics of the SSL Passthrough proxy
+func NewPassthroughCollector(podName, namespace, ingressClass string) *PassthroughCollector {
+	constLabels := prometheus.Labels{
+		"controller_namespace": namespace,
+		"controller_class":     ingressClass,
+		"controller_pod":       podName,
+	}
+
+	return &PassthroughCollector{
+		connections: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "ssl_passthrough_connections_total",
+				Help:        "The number of connections proxied by the SSL Passthrough proxy",
+				Namespace:   PrometheusNamespace,
+				ConstLabels: constLabels,
+			},
+			[]string{"server"},
+		),
+		active: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "ssl_passthrough_active_connections",
+				Help:        "The number of open connections of the SSL Passthrough proxy",
+				Namespace:   PrometheusNamespace,
+				ConstLabels: constLabels,
+			},
+			[]string{"server"},
+		),
+		dialErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "ssl_passthrough_dial_errors_total",
+				Help:        "The number of errors of the SSL Passthrough proxy connecting to a server",
+				Namespace:   PrometheusNamespace,
+				ConstLabels: constLabels,
+			},
+			[]string{"server"},
+		),
+		bytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "ssl_passthrough_bytes_total",
+				Help:        "The number of bytes received from the clients and sent to them by the SSL Passthrough proxy",
+				Namespace:   PrometheusNamespace,
+				ConstLabels: constLabels,
+			},
+			[]string{"server", "direction"},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:        "ssl_passthrough_connection_duration_seconds",
+				Help:        "The duration of the connections of the SSL Passthrough proxy",
+				Namespace:   PrometheusNamespace,
+				ConstLabels: constLabels,
+				Buckets:     prometheus.ExponentialBuckets(0.01, 4, 10),
+			},
+			[]string{"server"},
+		),
+		untrusted: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name:        "ssl_passthrough_untrusted_proxy_protocol_total",
+				Help:        "The number of connections of peers outside of proxy-real-ip-cidr closed by the SSL Passthrough proxy before decoding their PROXY protocol header",
+				Namespace:   PrometheusNamespace,
+				ConstLabels: constLabels,
+			},
+		),
+	}
+}
+
+// ConnectionOpened counts a new connection to the server
+func (c *PassthroughCollector) ConnectionOpened(server string) {
+	c.connections.WithLabelValues(server).Inc()
+	c.active.WithLabelValues(server).Inc()
+}
+
+// ConnectionClosed records the bytes and the duration of a connection to the server
+func (c *PassthroughCollector) ConnectionClosed(server string, received, sent int64, duration time.Duration) {
+	c.active.WithLabelValues(server).Dec()
+	c.bytes.WithLabelValues(server, "received").Add(float64(received))
+	c.bytes.WithLabelValues(server, "sent").Add(float64(sent))
+	c.duration.WithLabelValues(server).Observe(duration.Seconds())
+}
+
+// DialFailed counts an error connecting to the server
+func (c *PassthroughCollector) DialFailed(server string) {
+	c.dialErrors.WithLabelValues(server).Inc()
+}
+
+// UntrustedProxyProtocol counts a connection closed because the peer is not trusted
+// to send a PROXY protocol header
+func (c *PassthroughCollector) UntrustedProxyProtocol() {
+	c.untrusted.Inc()
+}
+
+// Describe implements prometheus.Collector
+func (c *PassthroughCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.connections.Describe(ch)
+	c.active.Describe(ch)
+	c.dialErrors.Describe(ch)
+	c.bytes.Describe(ch)
+	c.duration.Describe(ch)
+	c.untrusted.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *PassthroughCollector) Collect(ch chan<- prometheus.Metric) {
+	c.connections.Collect(ch)
+	c.active.Collect(ch)
+	c.dialErrors.Collect(ch)
+	c.bytes.Collect(ch)
+	c.duration.Collect(ch)
+	c.untrusted.Collect(ch)
+}
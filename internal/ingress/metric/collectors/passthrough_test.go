@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPassthroughCollector(t *testing.T) {
+	c := NewPassthroughCollector("pod", "default", "nginx")
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatalf("registering collector failed: %s", err)
+	}
+
+	c.ConnectionOpened("foo.bar")
+	c.ConnectionOpened("foo.bar")
+	c.ConnectionClosed("foo.bar", 100, 2000, 20*time.Millisecond)
+	c.DialFailed("nginx")
+	c.UntrustedProxyProtocol()
+
+	want := `
+		# HELP nginx_ingress_controller_ssl_passthrough_active_connections The number of open connections of the SSL Passthrough proxy
+		# TYPE nginx_ingress_controller_ssl_passthrough_active_connections gauge
+		nginx_ingress_controller_ssl_passthrough_active_connections{controller_class="nginx",controller_namespace="default",controller_pod="pod",server="foo.bar"} 1
+		# HELP nginx_ingress_controller_ssl_passthrough_bytes_total The number of bytes received from the clients and sent to them by the SSL Passthrough proxy
+		# TYPE nginx_ingress_controller_ssl_passthrough_bytes_total counter
+		nginx_ingress_controller_ssl_passthrough_bytes_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",direction="received",server="foo.bar"} 100
+		nginx_ingress_controller_ssl_passthrough_bytes_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",direction="sent",server="foo.bar"} 2000
+		# HELP nginx_ingress_controller_ssl_passthrough_connections_total The number of connections proxied by the SSL Passthrough proxy
+		# TYPE nginx_ingress_controller_ssl_passthrough_connections_total counter
+		nginx_ingress_controller_ssl_passthrough_connections_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",server="foo.bar"} 2
+		# HELP nginx_ingress_controller_ssl_passthrough_dial_errors_total The number of errors of the SSL Passthrough proxy connecting to a server
+		# TYPE nginx_ingress_controller_ssl_passthrough_dial_errors_total counter
+		nginx_ingress_controller_ssl_passthrough_dial_errors_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",server="nginx"} 1
+		# HELP nginx_ingress_controller_ssl_passthrough_untrusted_proxy_protocol_total The number of connections of peers outside of proxy-real-ip-cidr closed by the SSL Passthrough proxy before decoding their PROXY protocol header
+		# TYPE nginx_ingress_controller_ssl_passthrough_untrusted_proxy_protocol_total counter
+		nginx_ingress_controller_ssl_passthrough_untrusted_proxy_protocol_total{controller_class="nginx",controller_namespace="default",controller_pod="pod"} 1
+	`
+
+	metrics := []string{
+		"nginx_ingress_controller_ssl_passthrough_active_connections",
+		"nginx_ingress_controller_ssl_passthrough_bytes_total",
+		"nginx_ingress_controller_ssl_passthrough_connections_total",
+		"nginx_ingress_controller_ssl_passthrough_dial_errors_total",
+		"nginx_ingress_controller_ssl_passthrough_untrusted_proxy_protocol_total",
+	}
+	if err := GatherAndCompare(c, want, metrics, reg); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	reg.Unregister(c)
+}
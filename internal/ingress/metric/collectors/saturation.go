@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+// procNetstatPath contains the TCP extended statistics of the network namespace
+var procNetstatPath = "/proc/net/netstat"
+
+// Saturation contains an estimation of the load of NGINX compared to its capacity
+type Saturation struct {
+	// Labels identify the controller pod
+	Labels map[string]string
+	// ActiveConnections is the current number of client connections
+	ActiveConnections int
+	// Capacity is the number of connections NGINX accepts,
+	// the number of worker processes times worker_connections
+	Capacity int
+	// Ratio is the number of active connections over the capacity
+	Ratio float64
+	// ListenOverflows is the number of times the accept queue of a listen socket was full
+	ListenOverflows uint64
+	// ListenDrops is the number of connections dropped by the listen sockets
+	ListenDrops uint64
+	// ListenDropsRate is the number of connections dropped per second since the previous estimation
+	ListenDropsRate float64
+}
+
+type listenStats struct {
+	overflows uint64
+	drops     uint64
+	time      time.Time
+}
+
+// SaturationCollector estimates the saturation of NGINX workers. When
+// reuse-port is enabled each worker has its own accept queue, so the
+// drops of the listen sockets reveal a saturated worker even when the
+// ratio of active connections of all the workers is low.
+type SaturationCollector struct {
+	labels prometheus.Labels
+
+	mu                sync.Mutex
+	workers           int
+	workerConnections int
+	last              *listenStats
+
+	ratio          *prometheus.Desc
+	capacity       *prometheus.Desc
+	listenOverflow *prometheus.Desc
+	listenDrops    *prometheus.Desc
+}
+
+// NewSaturationCollector returns a new prometheus collector of the saturation of NGINX
+func NewSaturationCollector(podName, namespace, ingressClass string) *SaturationCollector {
+	constLabels := prometheus.Labels{
+		"controller_namespace": namespace,
+		"controller_class":     ingressClass,
+		"controller_pod":       podName,
+	}
+
+	return &SaturationCollector{
+		labels: constLabels,
+
+		ratio: prometheus.NewDesc(
+			prometheus.BuildFQName(PrometheusNamespace, subSystem, "saturation_ratio"),
+			"ratio of active connections to the connections accepted by all the worker processes",
+			nil, constLabels),
+
+		capacity: prometheus.NewDesc(
+			prometheus.BuildFQName(PrometheusNamespace, subSystem, "connections_capacity"),
+			"number of connections accepted by all the worker processes",
+			nil, constLabels),
+
+		listenOverflow: prometheus.NewDesc(
+			prometheus.BuildFQName(PrometheusNamespace, subSystem, "listen_overflows_total"),
+			"number of times the accept queue of a listen socket was full",
+			nil, constLabels),
+
+		listenDrops: prometheus.NewDesc(
+			prometheus.BuildFQName(PrometheusNamespace, subSystem, "listen_drops_total"),
+			"number of connections dropped by the listen sockets",
+			nil, constLabels),
+	}
+}
+
+// SetCapacity sets the number of worker processes and connections per worker of NGINX
+func (sc *SaturationCollector) SetCapacity(workers, workerConnections int) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.workers = workers
+	sc.workerConnections = workerConnections
+}
+
+// Saturation returns the current saturation of NGINX
+func (sc *SaturationCollector) Saturation() (*Saturation, error) {
+	status, data, err := nginx.NewGetStatusRequest(nginx.StatusPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if status < 200 || status >= 400 {
+		return nil, fmt.Errorf("unexpected status code %v obtaining nginx status info", status)
+	}
+
+	s := &Saturation{
+		Labels:            sc.labels,
+		ActiveConnections: parse(string(data)).Active,
+	}
+
+	stats, err := readListenStats(procNetstatPath)
+	if err != nil {
+		klog.V(3).ErrorS(err, "Error reading listen socket statistics")
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	s.Capacity = sc.workers * sc.workerConnections
+	if s.Capacity > 0 {
+		s.Ratio = float64(s.ActiveConnections) / float64(s.Capacity)
+	}
+
+	if stats != nil {
+		s.ListenOverflows = stats.overflows
+		s.ListenDrops = stats.drops
+
+		if sc.last != nil {
+			elapsed := stats.time.Sub(sc.last.time).Seconds()
+			if elapsed > 0 && stats.drops >= sc.last.drops {
+				s.ListenDropsRate = float64(stats.drops-sc.last.drops) / elapsed
+			}
+		}
+		sc.last = stats
+	}
+
+	return s, nil
+}
+
+// Describe implements prometheus.Collector
+func (sc *SaturationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sc.ratio
+	ch <- sc.capacity
+	ch <- sc.listenOverflow
+	ch <- sc.listenDrops
+}
+
+// Collect implements prometheus.Collector
+func (sc *SaturationCollector) Collect(ch chan<- prometheus.Metric) {
+	s, err := sc.Saturation()
+	if err != nil {
+		klog.Warningf("unexpected error obtaining nginx saturation: %v", err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(sc.ratio, prometheus.GaugeValue, s.Ratio)
+	ch <- prometheus.MustNewConstMetric(sc.capacity, prometheus.GaugeValue, float64(s.Capacity))
+	ch <- prometheus.MustNewConstMetric(sc.listenOverflow, prometheus.CounterValue, float64(s.ListenOverflows))
+	ch <- prometheus.MustNewConstMetric(sc.listenDrops, prometheus.CounterValue, float64(s.ListenDrops))
+}
+
+// readListenStats reads the ListenOverflows and ListenDrops counters
+// from the TcpExt section of a /proc/net/netstat file
+func readListenStats(path string) (*listenStats, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var header []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "TcpExt:" {
+			continue
+		}
+
+		// the first line contains the names and the second one the values
+		if header == nil {
+			header = fields
+			continue
+		}
+
+		if len(fields) != len(header) {
+			return nil, fmt.Errorf("invalid TcpExt statistics in %v", path)
+		}
+
+		stats := &listenStats{time: time.Now()}
+		for i := 1; i < len(header); i++ {
+			switch header[i] {
+			case "ListenOverflows":
+				stats.overflows, err = strconv.ParseUint(fields[i], 10, 64)
+			case "ListenDrops":
+				stats.drops, err = strconv.ParseUint(fields[i], 10, 64)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return stats, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("TcpExt statistics not found in %v", path)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+const netstat = `TcpExt: SyncookiesSent SyncookiesRecv ListenOverflows ListenDrops TCPBacklogDrop
+TcpExt: 0 0 %v %v 0
+IpExt: InNoRoutes InTruncatedPkts
+IpExt: 0 0
+`
+
+func writeNetstat(t *testing.T, path string, overflows, drops int) {
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(netstat, overflows, drops)), 0o600); err != nil {
+		t.Fatalf("unexpected error writing netstat file: %v", err)
+	}
+}
+
+func TestReadListenStats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "netstat")
+	writeNetstat(t, path, 3, 5)
+
+	stats, err := readListenStats(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.overflows != 3 || stats.drops != 5 {
+		t.Errorf("expected 3 overflows and 5 drops but got %v and %v", stats.overflows, stats.drops)
+	}
+
+	if err := os.WriteFile(path, []byte("IpExt: InNoRoutes\nIpExt: 0\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error writing netstat file: %v", err)
+	}
+	if _, err := readListenStats(path); err == nil {
+		t.Errorf("expected an error reading a file without TcpExt statistics")
+	}
+}
+
+func TestSaturationCollector(t *testing.T) {
+	listener, err := tryListen("tcp", fmt.Sprintf(":%v", nginx.StatusPort))
+	if err != nil {
+		t.Fatalf("crating unix listener: %s", err)
+	}
+
+	server := &httptest.Server{
+		Listener: listener,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { //nolint:gosec // Ignore the gosec error in testing
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "Active connections: 300\nserver accepts handled requests\n1 2 3\nReading: 4 Writing: 5 Waiting: 6\n")
+		})},
+	}
+	server.Start()
+	defer server.Close()
+
+	procNetstatPath = filepath.Join(t.TempDir(), "netstat")
+	defer func() { procNetstatPath = "/proc/net/netstat" }()
+	writeNetstat(t, procNetstatPath, 1, 2)
+
+	sc := NewSaturationCollector("pod", "default", "nginx")
+	sc.SetCapacity(2, 500)
+
+	s, err := sc.Saturation()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.ActiveConnections != 300 || s.Capacity != 1000 || s.Ratio != 0.3 {
+		t.Errorf("unexpected saturation: %+v", s)
+	}
+
+	want := `
+		# HELP nginx_ingress_controller_nginx_process_connections_capacity number of connections accepted by all the worker processes
+		# TYPE nginx_ingress_controller_nginx_process_connections_capacity gauge
+		nginx_ingress_controller_nginx_process_connections_capacity{controller_class="nginx",controller_namespace="default",controller_pod="pod"} 1000
+		# HELP nginx_ingress_controller_nginx_process_listen_drops_total number of connections dropped by the listen sockets
+		# TYPE nginx_ingress_controller_nginx_process_listen_drops_total counter
+		nginx_ingress_controller_nginx_process_listen_drops_total{controller_class="nginx",controller_namespace="default",controller_pod="pod"} 2
+		# HELP nginx_ingress_controller_nginx_process_listen_overflows_total number of times the accept queue of a listen socket was full
+		# TYPE nginx_ingress_controller_nginx_process_listen_overflows_total counter
+		nginx_ingress_controller_nginx_process_listen_overflows_total{controller_class="nginx",controller_namespace="default",controller_pod="pod"} 1
+		# HELP nginx_ingress_controller_nginx_process_saturation_ratio ratio of active connections to the connections accepted by all the worker processes
+		# TYPE nginx_ingress_controller_nginx_process_saturation_ratio gauge
+		nginx_ingress_controller_nginx_process_saturation_ratio{controller_class="nginx",controller_namespace="default",controller_pod="pod"} 0.3
+	`
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(sc); err != nil {
+		t.Errorf("registering collector failed: %s", err)
+	}
+
+	if err := GatherAndCompare(sc, want, nil, reg); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	reg.Unregister(sc)
+}
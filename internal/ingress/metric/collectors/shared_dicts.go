@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+// luaSharedDictsPath returns the usage of the Lua shared dictionaries
+const luaSharedDictsPath = "/configuration/shared-dicts"
+
+// LuaSharedDict contains the usage of a Lua shared dictionary
+type LuaSharedDict struct {
+	// Name of the dictionary
+	Name string `json:"name"`
+	// Capacity is the size in bytes of the dictionary
+	Capacity int64 `json:"capacity"`
+	// FreeSpace is the number of bytes of the free pages of the dictionary
+	FreeSpace int64 `json:"free_space"`
+	// Evictions is the number of entries removed to store new ones when the dictionary is full
+	Evictions uint64 `json:"evictions"`
+}
+
+// Utilization returns the used part of the dictionary, between 0 and 1
+func (d *LuaSharedDict) Utilization() float64 {
+	if d.Capacity <= 0 {
+		return 0
+	}
+
+	return float64(d.Capacity-d.FreeSpace) / float64(d.Capacity)
+}
+
+// GetLuaSharedDicts returns the usage of the Lua shared dictionaries of NGINX
+func GetLuaSharedDicts() ([]LuaSharedDict, error) {
+	status, data, err := nginx.NewGetStatusRequest(luaSharedDictsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if status < 200 || status >= 400 {
+		return nil, fmt.Errorf("unexpected status code %v obtaining Lua shared dictionaries", status)
+	}
+
+	return parseLuaSharedDicts(data)
+}
+
+func parseLuaSharedDicts(data []byte) ([]LuaSharedDict, error) {
+	// cjson encodes an empty array as an empty object
+	if string(data) == "{}" {
+		return nil, nil
+	}
+
+	var dicts []LuaSharedDict
+	if err := json.Unmarshal(data, &dicts); err != nil {
+		return nil, fmt.Errorf("invalid Lua shared dictionaries: %w", err)
+	}
+
+	return dicts, nil
+}
+
+// LuaSharedDictsCollector exports the capacity, utilization and evictions of the
+// Lua shared dictionaries. When configuration_data or certificate_data are full
+// the dynamic updates of backends and certificates are lost without a reload.
+type LuaSharedDictsCollector struct {
+	capacity    *prometheus.Desc
+	utilization *prometheus.Desc
+	evictions   *prometheus.Desc
+}
+
+// NewLuaSharedDictsCollector returns a new prometheus collector of the Lua shared dictionaries
+func NewLuaSharedDictsCollector(podName, namespace, ingressClass string) *LuaSharedDictsCollector {
+	constLabels := prometheus.Labels{
+		"controller_namespace": namespace,
+		"controller_class":     ingressClass,
+		"controller_pod":       podName,
+	}
+
+	return &LuaSharedDictsCollector{
+		capacity: prometheus.NewDesc(
+			prometheus.BuildFQName(PrometheusNamespace, subSystem, "lua_shared_dict_capacity_bytes"),
+			"size in bytes of the Lua shared dictionary",
+			[]string{"dict"}, constLabels),
+
+		utilization: prometheus.NewDesc(
+			prometheus.BuildFQName(PrometheusNamespace, subSystem, "lua_shared_dict_utilization_ratio"),
+			"used part of the Lua shared dictionary",
+			[]string{"dict"}, constLabels),
+
+		evictions: prometheus.NewDesc(
+			prometheus.BuildFQName(PrometheusNamespace, subSystem, "lua_shared_dict_evictions_total"),
+			"number of entries removed from the Lua shared dictionary to store new ones",
+			[]string{"dict"}, constLabels),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *LuaSharedDictsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.capacity
+	ch <- c.utilization
+	ch <- c.evictions
+}
+
+// Collect implements prometheus.Collector
+func (c *LuaSharedDictsCollector) Collect(ch chan<- prometheus.Metric) {
+	dicts, err := GetLuaSharedDicts()
+	if err != nil {
+		klog.Warningf("unexpected error obtaining Lua shared dictionaries: %v", err)
+		return
+	}
+
+	for i := range dicts {
+		d := &dicts[i]
+		ch <- prometheus.MustNewConstMetric(c.capacity, prometheus.GaugeValue, float64(d.Capacity), d.Name)
+		ch <- prometheus.MustNewConstMetric(c.utilization, prometheus.GaugeValue, d.Utilization(), d.Name)
+		ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(d.Evictions), d.Name)
+	}
+}
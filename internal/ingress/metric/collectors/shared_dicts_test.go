@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+func TestParseLuaSharedDicts(t *testing.T) {
+	dicts, err := parseLuaSharedDicts([]byte("{}"))
+	if err != nil || len(dicts) != 0 {
+		t.Errorf("expected no dictionaries but got %v and %v", dicts, err)
+	}
+
+	if _, err := parseLuaSharedDicts([]byte("invalid")); err == nil {
+		t.Errorf("expected an error parsing invalid dictionaries")
+	}
+
+	dicts, err = parseLuaSharedDicts([]byte(`[{"name":"certificate_data","capacity":1048576,"free_space":262144,"evictions":3}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dicts) != 1 || dicts[0].Name != "certificate_data" || dicts[0].Evictions != 3 || dicts[0].Utilization() != 0.75 {
+		t.Errorf("unexpected dictionaries: %+v", dicts)
+	}
+}
+
+func TestLuaSharedDictsCollector(t *testing.T) {
+	listener, err := tryListen("tcp", fmt.Sprintf(":%v", nginx.StatusPort))
+	if err != nil {
+		t.Fatalf("crating unix listener: %s", err)
+	}
+
+	server := &httptest.Server{
+		Listener: listener,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { //nolint:gosec // Ignore the gosec error in testing
+			if r.URL.Path != luaSharedDictsPath {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `[{"name":"configuration_data","capacity":1048576,"free_space":262144,"evictions":2},`+
+				`{"name":"certificate_data","capacity":2097152,"free_space":2097152,"evictions":0}]`)
+		})},
+	}
+	server.Start()
+	defer server.Close()
+
+	c := NewLuaSharedDictsCollector("pod", "default", "nginx")
+
+	want := `
+		# HELP nginx_ingress_controller_nginx_process_lua_shared_dict_capacity_bytes size in bytes of the Lua shared dictionary
+		# TYPE nginx_ingress_controller_nginx_process_lua_shared_dict_capacity_bytes gauge
+		nginx_ingress_controller_nginx_process_lua_shared_dict_capacity_bytes{controller_class="nginx",controller_namespace="default",controller_pod="pod",dict="certificate_data"} 2.097152e+06
+		nginx_ingress_controller_nginx_process_lua_shared_dict_capacity_bytes{controller_class="nginx",controller_namespace="default",controller_pod="pod",dict="configuration_data"} 1.048576e+06
+		# HELP nginx_ingress_controller_nginx_process_lua_shared_dict_evictions_total number of entries removed from the Lua shared dictionary to store new ones
+		# TYPE nginx_ingress_controller_nginx_process_lua_shared_dict_evictions_total counter
+		nginx_ingress_controller_nginx_process_lua_shared_dict_evictions_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",dict="certificate_data"} 0
+		nginx_ingress_controller_nginx_process_lua_shared_dict_evictions_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",dict="configuration_data"} 2
+		# HELP nginx_ingress_controller_nginx_process_lua_shared_dict_utilization_ratio used part of the Lua shared dictionary
+		# TYPE nginx_ingress_controller_nginx_process_lua_shared_dict_utilization_ratio gauge
+		nginx_ingress_controller_nginx_process_lua_shared_dict_utilization_ratio{controller_class="nginx",controller_namespace="default",controller_pod="pod",dict="certificate_data"} 0
+		nginx_ingress_controller_nginx_process_lua_shared_dict_utilization_ratio{controller_class="nginx",controller_namespace="default",controller_pod="pod",dict="configuration_data"} 0.75
+	`
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Errorf("registering collector failed: %s", err)
+	}
+
+	if err := GatherAndCompare(c, want, nil, reg); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	reg.Unregister(c)
+}
@@ -22,12 +22,13 @@ import (
 	"net"
 	"os"
 	"strings"
-	"syscall"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/nginx"
 )
 
 type socketData struct {
@@ -101,10 +102,10 @@ var requestTags = []string{
 // NewSocketCollector creates a new SocketCollector instance using
 // the ingress watch namespace and class used by the controller
 func NewSocketCollector(pod, namespace, class string, metricsPerHost, metricsPerUndefinedHost, reportStatusClasses bool, buckets HistogramBuckets, bucketFactor float64, maxBuckets uint32, excludeMetrics []string) (*SocketCollector, error) {
-	socket := "/tmp/nginx/prometheus-nginx.socket"
+	socket := nginx.MetricsSocket
 	// unix sockets must be unlink()ed before being used
 	//nolint:errcheck // Ignore unlink error
-	_ = syscall.Unlink(socket)
+	_ = os.Remove(socket)
 
 	listener, err := net.Listen("unix", socket)
 	if err != nil {
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+// uploadsPath returns the uploads counted by the max-concurrent-uploads annotation
+const uploadsPath = "/configuration/uploads"
+
+// IngressUploads contains the uploads of an Ingress limited by the max-concurrent-uploads annotation
+type IngressUploads struct {
+	Namespace string `json:"namespace"`
+	Ingress   string `json:"ingress"`
+	// InFlight is the number of uploads in progress
+	InFlight int64 `json:"in_flight"`
+	// Rejected is the number of uploads rejected because the client reached the limit
+	Rejected uint64 `json:"rejected"`
+}
+
+// GetUploads returns the uploads counted by NGINX
+func GetUploads() ([]IngressUploads, error) {
+	status, data, err := nginx.NewGetStatusRequest(uploadsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if status < 200 || status >= 400 {
+		return nil, fmt.Errorf("unexpected status code %v obtaining uploads", status)
+	}
+
+	return parseUploads(data)
+}
+
+func parseUploads(data []byte) ([]IngressUploads, error) {
+	// cjson encodes an empty array as an empty object
+	if string(data) == "{}" {
+		return nil, nil
+	}
+
+	var uploads []IngressUploads
+	if err := json.Unmarshal(data, &uploads); err != nil {
+		return nil, fmt.Errorf("invalid uploads: %w", err)
+	}
+
+	return uploads, nil
+}
+
+// UploadsCollector exports the uploads in flight and rejected of the
+// Ingresses using the max-concurrent-uploads annotation
+type UploadsCollector struct {
+	inFlight *prometheus.Desc
+	rejected *prometheus.Desc
+}
+
+// NewUploadsCollector returns a new prometheus collector of the uploads
+func NewUploadsCollector(podName, namespace, ingressClass string) *UploadsCollector {
+	constLabels := prometheus.Labels{
+		"controller_namespace": namespace,
+		"controller_class":     ingressClass,
+		"controller_pod":       podName,
+	}
+
+	return &UploadsCollector{
+		inFlight: prometheus.NewDesc(
+			prometheus.BuildFQName(PrometheusNamespace, "", "uploads_in_flight"),
+			"number of uploads in progress limited by the max-concurrent-uploads annotation",
+			[]string{"namespace", "ingress"}, constLabels),
+
+		rejected: prometheus.NewDesc(
+			prometheus.BuildFQName(PrometheusNamespace, "", "uploads_rejected_total"),
+			"number of uploads rejected because the client reached the max-concurrent-uploads limit",
+			[]string{"namespace", "ingress"}, constLabels),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *UploadsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.inFlight
+	ch <- c.rejected
+}
+
+// Collect implements prometheus.Collector
+func (c *UploadsCollector) Collect(ch chan<- prometheus.Metric) {
+	uploads, err := GetUploads()
+	if err != nil {
+		klog.Warningf("unexpected error obtaining uploads: %v", err)
+		return
+	}
+
+	for i := range uploads {
+		u := &uploads[i]
+		ch <- prometheus.MustNewConstMetric(c.inFlight, prometheus.GaugeValue, float64(u.InFlight), u.Namespace, u.Ingress)
+		ch <- prometheus.MustNewConstMetric(c.rejected, prometheus.CounterValue, float64(u.Rejected), u.Namespace, u.Ingress)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/ingress-nginx/internal/nginx"
+)
+
+func TestParseUploads(t *testing.T) {
+	uploads, err := parseUploads([]byte("{}"))
+	if err != nil || len(uploads) != 0 {
+		t.Errorf("expected no uploads but got %v and %v", uploads, err)
+	}
+
+	if _, err := parseUploads([]byte("invalid")); err == nil {
+		t.Errorf("expected an error parsing invalid uploads")
+	}
+
+	uploads, err = parseUploads([]byte(`[{"namespace":"default","ingress":"files","in_flight":3,"rejected":1}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(uploads) != 1 || uploads[0].Ingress != "files" || uploads[0].InFlight != 3 || uploads[0].Rejected != 1 {
+		t.Errorf("unexpected uploads: %+v", uploads)
+	}
+}
+
+func TestUploadsCollector(t *testing.T) {
+	listener, err := tryListen("tcp", fmt.Sprintf(":%v", nginx.StatusPort))
+	if err != nil {
+		t.Fatalf("crating unix listener: %s", err)
+	}
+
+	server := &httptest.Server{
+		Listener: listener,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { //nolint:gosec // Ignore the gosec error in testing
+			if r.URL.Path != uploadsPath {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `[{"namespace":"default","ingress":"files","in_flight":3,"rejected":1},`+
+				`{"namespace":"default","ingress":"media","in_flight":0,"rejected":0}]`)
+		})},
+	}
+	server.Start()
+	defer server.Close()
+
+	c := NewUploadsCollector("pod", "default", "nginx")
+
+	want := `
+		# HELP nginx_ingress_controller_uploads_in_flight number of uploads in progress limited by the max-concurrent-uploads annotation
+		# TYPE nginx_ingress_controller_uploads_in_flight gauge
+		nginx_ingress_controller_uploads_in_flight{controller_class="nginx",controller_namespace="default",controller_pod="pod",ingress="files",namespace="default"} 3
+		nginx_ingress_controller_uploads_in_flight{controller_class="nginx",controller_namespace="default",controller_pod="pod",ingress="media",namespace="default"} 0
+		# HELP nginx_ingress_controller_uploads_rejected_total number of uploads rejected because the client reached the max-concurrent-uploads limit
+		# TYPE nginx_ingress_controller_uploads_rejected_total counter
+		nginx_ingress_controller_uploads_rejected_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",ingress="files",namespace="default"} 1
+		nginx_ingress_controller_uploads_rejected_total{controller_class="nginx",controller_namespace="default",controller_pod="pod",ingress="media",namespace="default"} 0
+	`
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Errorf("registering collector failed: %s", err)
+	}
+
+	if err := GatherAndCompare(c, want, nil, reg); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	reg.Unregister(c)
+}
@@ -17,8 +17,13 @@ limitations under the License.
 package metric
 
 import (
+	"errors"
+	"time"
+
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
+	"k8s.io/ingress-nginx/pkg/util/runtime"
 )
 
 // NewDummyCollector returns a dummy metric collector
@@ -65,12 +70,65 @@ func (dc DummyCollector) Stop(_ string) {}
 // SetSSLInfo dummy implementation
 func (dc DummyCollector) SetSSLInfo([]*ingress.Server) {}
 
+// SetSSLPending dummy implementation
+func (dc DummyCollector) SetSSLPending([]*ingress.Server) {}
+
 // SetSSLExpireTime dummy implementation
 func (dc DummyCollector) SetSSLExpireTime([]*ingress.Server) {}
 
 // SetHosts dummy implementation
 func (dc DummyCollector) SetHosts(_ sets.Set[string]) {}
 
+// SetWorkerCapacity dummy implementation
+func (dc DummyCollector) SetWorkerCapacity(_, _ int) {}
+
+// SetConfigErrors dummy implementation
+func (dc DummyCollector) SetConfigErrors(_ int) {}
+
+// SetDeprecatedUsage dummy implementation
+func (dc DummyCollector) SetDeprecatedUsage(_ map[string]int, _ []string) {}
+
+// SetConfigDrift dummy implementation
+func (dc DummyCollector) SetConfigDrift(_ string, _ bool) {}
+
+// SetServerNamesHash dummy implementation
+func (dc DummyCollector) SetServerNamesHash(_, _ int) {}
+
+// IncReloadTriggers dummy implementation
+func (dc DummyCollector) IncReloadTriggers(_ []string) {}
+
+// SetNamespaceConfig dummy implementation
+func (dc DummyCollector) SetNamespaceConfig(_ map[string]int, _ time.Duration) {}
+
+// SetCPUTopology dummy implementation
+func (dc DummyCollector) SetCPUTopology(_ runtime.CPUTopology) {}
+
+// Saturation dummy implementation
+func (dc DummyCollector) Saturation() (*collectors.Saturation, error) {
+	return nil, errors.New("metrics are disabled")
+}
+
+// PassthroughConnectionOpened dummy implementation
+func (dc DummyCollector) PassthroughConnectionOpened(_ string) {}
+
+// PassthroughConnectionClosed dummy implementation
+func (dc DummyCollector) PassthroughConnectionClosed(_ string, _, _ int64, _ time.Duration) {}
+
+// PassthroughDialFailed dummy implementation
+func (dc DummyCollector) PassthroughDialFailed(_ string) {}
+
+// PassthroughUntrustedProxyProtocol dummy implementation
+func (dc DummyCollector) PassthroughUntrustedProxyProtocol() {}
+
+// AddShutdownForcedCloses dummy implementation
+func (dc DummyCollector) AddShutdownForcedCloses(_ string, _ int) {}
+
+// SetDiskUsage dummy implementation
+func (dc DummyCollector) SetDiskUsage(_ []collectors.PathUsage) {}
+
+// AddCacheEviction dummy implementation
+func (dc DummyCollector) AddCacheEviction(_ string, _ int64) {}
+
 // OnStartedLeading indicates the pod is not the current leader
 func (dc DummyCollector) OnStartedLeading(_ string) {}
 
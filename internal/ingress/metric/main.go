@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
+	"k8s.io/ingress-nginx/pkg/util/runtime"
 )
 
 // Collector defines the interface for a metric collector
@@ -50,10 +51,49 @@ type Collector interface {
 
 	SetSSLExpireTime([]*ingress.Server)
 	SetSSLInfo(servers []*ingress.Server)
+	SetSSLPending(servers []*ingress.Server)
 
 	// SetHosts sets the hostnames that are being served by the ingress controller
 	SetHosts(set sets.Set[string])
 
+	// SetWorkerCapacity sets the number of worker processes and connections per worker of NGINX
+	SetWorkerCapacity(workers, workerConnections int)
+	// SetConfigErrors sets the number of keys of the configuration ConfigMap ignored in the last update
+	SetConfigErrors(count int)
+	// SetDeprecatedUsage sets the number of Ingresses using each deprecated annotation and the deprecated ConfigMap keys
+	SetDeprecatedUsage(annotations map[string]int, keys []string)
+	// SetConfigDrift sets whether nginx.conf or the backends stored by Lua were changed out of band
+	SetConfigDrift(kind string, drifted bool)
+	// SetServerNamesHash sets the sizes of the server names hash tables of NGINX
+	SetServerNamesHash(bucketSize, maxSize int)
+	// IncReloadTriggers counts a reload of NGINX triggered by the Ingresses, in the form namespace/name
+	IncReloadTriggers(ingresses []string)
+	// SetNamespaceConfig sets the size of the locations of each namespace in nginx.conf and adds their share of the rendering time
+	SetNamespaceConfig(sizes map[string]int, render time.Duration)
+
+	// SetCPUTopology sets the clusters of CPUs detected
+	SetCPUTopology(topology runtime.CPUTopology)
+	// Saturation returns an estimation of the load of NGINX compared to its capacity
+	Saturation() (*collectors.Saturation, error)
+
+	// PassthroughConnectionOpened counts a connection of the SSL Passthrough proxy
+	PassthroughConnectionOpened(server string)
+	// PassthroughConnectionClosed records the bytes and the duration of a connection of the SSL Passthrough proxy
+	PassthroughConnectionClosed(server string, received, sent int64, duration time.Duration)
+	// PassthroughDialFailed counts an error of the SSL Passthrough proxy connecting to a server
+	PassthroughDialFailed(server string)
+	// PassthroughUntrustedProxyProtocol counts a connection of the SSL Passthrough proxy closed
+	// because the peer is not trusted to send a PROXY protocol header
+	PassthroughUntrustedProxyProtocol()
+
+	// AddShutdownForcedCloses counts the passthrough or stream connections closed at the end of the shutdown grace period
+	AddShutdownForcedCloses(traffic string, count int)
+
+	// SetDiskUsage sets the disk usage of the temporary and cache directories of NGINX
+	SetDiskUsage(paths []collectors.PathUsage)
+	// AddCacheEviction counts the bytes of the files removed from a cache directory over the high watermark
+	AddCacheEviction(path string, bytes int64)
+
 	Start(string)
 	Stop(string)
 }
@@ -61,17 +101,25 @@ type Collector interface {
 type collector struct {
 	nginxStatus  collectors.NGINXStatusCollector
 	nginxProcess collectors.NGINXProcessCollector
+	saturation   *collectors.SaturationCollector
+	sharedDicts  *collectors.LuaSharedDictsCollector
+	uploads      *collectors.UploadsCollector
+	diskUsage    *collectors.DiskUsageCollector
+	passthrough  *collectors.PassthroughCollector
 
 	ingressController   *collectors.Controller
 	admissionController *collectors.AdmissionCollector
 
 	socket *collectors.SocketCollector
 
+	// errorLog is nil when the metrics of the error log are disabled
+	errorLog *collectors.ErrorLogCollector
+
 	registry *prometheus.Registry
 }
 
 // NewCollector creates a new metric collector the for ingress controller
-func NewCollector(metricsPerHost, metricsPerUndefinedHost, reportStatusClasses bool, registry *prometheus.Registry, ingressclass string, buckets collectors.HistogramBuckets, bucketFactor float64, maxBuckets uint32, excludedSocketMetrics []string) (Collector, error) {
+func NewCollector(metricsPerHost, metricsPerUndefinedHost, reportStatusClasses bool, registry *prometheus.Registry, ingressclass string, buckets collectors.HistogramBuckets, bucketFactor float64, maxBuckets uint32, excludedSocketMetrics []string, errorLogMetrics bool) (Collector, error) {
 	podNamespace := os.Getenv("POD_NAMESPACE")
 	if podNamespace == "" {
 		podNamespace = "default"
@@ -94,6 +142,24 @@ func NewCollector(metricsPerHost, metricsPerUndefinedHost, reportStatusClasses b
 		return nil, err
 	}
 
+	var el *collectors.ErrorLogCollector
+	if errorLogMetrics {
+		el, err = collectors.NewErrorLogCollector(podName, podNamespace, ingressclass, metricsPerHost)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sc := collectors.NewSaturationCollector(podName, podNamespace, ingressclass)
+
+	lsd := collectors.NewLuaSharedDictsCollector(podName, podNamespace, ingressclass)
+
+	uc := collectors.NewUploadsCollector(podName, podNamespace, ingressclass)
+
+	du := collectors.NewDiskUsageCollector(podName, podNamespace, ingressclass)
+
+	pt := collectors.NewPassthroughCollector(podName, podNamespace, ingressclass)
+
 	ic := collectors.NewController(podName, podNamespace, ingressclass)
 
 	am := collectors.NewAdmissionCollector(podName, podNamespace, ingressclass)
@@ -101,11 +167,17 @@ func NewCollector(metricsPerHost, metricsPerUndefinedHost, reportStatusClasses b
 	return Collector(&collector{
 		nginxStatus:  nc,
 		nginxProcess: pc,
+		saturation:   sc,
+		sharedDicts:  lsd,
+		uploads:      uc,
+		diskUsage:    du,
+		passthrough:  pt,
 
 		admissionController: am,
 		ingressController:   ic,
 
-		socket: s,
+		socket:   s,
+		errorLog: el,
 
 		registry: registry,
 	}), nil
@@ -139,11 +211,19 @@ func (c *collector) RemoveMetrics(ingresses, certificates []string) {
 func (c *collector) Start(admissionStatus string) {
 	c.registry.MustRegister(c.nginxStatus)
 	c.registry.MustRegister(c.nginxProcess)
+	c.registry.MustRegister(c.saturation)
+	c.registry.MustRegister(c.sharedDicts)
+	c.registry.MustRegister(c.uploads)
+	c.registry.MustRegister(c.diskUsage)
+	c.registry.MustRegister(c.passthrough)
 	if admissionStatus != "" {
 		c.registry.MustRegister(c.admissionController)
 	}
 	c.registry.MustRegister(c.ingressController)
 	c.registry.MustRegister(c.socket)
+	if c.errorLog != nil {
+		c.registry.MustRegister(c.errorLog)
+	}
 
 	// the default nginx.conf does not contains
 	// a server section with the status port
@@ -153,20 +233,34 @@ func (c *collector) Start(admissionStatus string) {
 	}()
 	go c.nginxProcess.Start()
 	go c.socket.Start()
+	if c.errorLog != nil {
+		go c.errorLog.Start()
+	}
 }
 
 func (c *collector) Stop(admissionStatus string) {
 	c.registry.Unregister(c.nginxStatus)
 	c.registry.Unregister(c.nginxProcess)
+	c.registry.Unregister(c.saturation)
+	c.registry.Unregister(c.sharedDicts)
+	c.registry.Unregister(c.uploads)
+	c.registry.Unregister(c.diskUsage)
+	c.registry.Unregister(c.passthrough)
 	if admissionStatus != "" {
 		c.registry.Unregister(c.admissionController)
 	}
 	c.registry.Unregister(c.ingressController)
 	c.registry.Unregister(c.socket)
+	if c.errorLog != nil {
+		c.registry.Unregister(c.errorLog)
+	}
 
 	c.nginxStatus.Stop()
 	c.nginxProcess.Stop()
 	c.socket.Stop()
+	if c.errorLog != nil {
+		c.errorLog.Stop()
+	}
 }
 
 func (c *collector) SetSSLExpireTime(servers []*ingress.Server) {
@@ -183,6 +277,10 @@ func (c *collector) SetSSLInfo(servers []*ingress.Server) {
 	c.ingressController.SetSSLInfo(servers)
 }
 
+func (c *collector) SetSSLPending(servers []*ingress.Server) {
+	c.ingressController.SetSSLPending(servers)
+}
+
 func (c *collector) IncOrphanIngress(namespace, name, orphanityType string) {
 	c.ingressController.IncOrphanIngress(namespace, name, orphanityType)
 }
@@ -195,6 +293,70 @@ func (c *collector) SetHosts(hosts sets.Set[string]) {
 	c.socket.SetHosts(hosts)
 }
 
+func (c *collector) SetWorkerCapacity(workers, workerConnections int) {
+	c.saturation.SetCapacity(workers, workerConnections)
+}
+
+func (c *collector) SetConfigErrors(count int) {
+	c.ingressController.SetConfigErrors(count)
+}
+
+func (c *collector) SetDeprecatedUsage(annotations map[string]int, keys []string) {
+	c.ingressController.SetDeprecatedUsage(annotations, keys)
+}
+
+func (c *collector) SetConfigDrift(kind string, drifted bool) {
+	c.ingressController.SetConfigDrift(kind, drifted)
+}
+
+func (c *collector) SetServerNamesHash(bucketSize, maxSize int) {
+	c.ingressController.SetServerNamesHash(bucketSize, maxSize)
+}
+
+func (c *collector) IncReloadTriggers(ingresses []string) {
+	c.ingressController.IncReloadTriggers(ingresses)
+}
+
+func (c *collector) SetNamespaceConfig(sizes map[string]int, render time.Duration) {
+	c.ingressController.SetNamespaceConfig(sizes, render)
+}
+
+func (c *collector) SetCPUTopology(topology runtime.CPUTopology) {
+	c.ingressController.SetCPUTopology(topology)
+}
+
+func (c *collector) Saturation() (*collectors.Saturation, error) {
+	return c.saturation.Saturation()
+}
+
+func (c *collector) PassthroughConnectionOpened(server string) {
+	c.passthrough.ConnectionOpened(server)
+}
+
+func (c *collector) PassthroughConnectionClosed(server string, received, sent int64, duration time.Duration) {
+	c.passthrough.ConnectionClosed(server, received, sent, duration)
+}
+
+func (c *collector) PassthroughDialFailed(server string) {
+	c.passthrough.DialFailed(server)
+}
+
+func (c *collector) PassthroughUntrustedProxyProtocol() {
+	c.passthrough.UntrustedProxyProtocol()
+}
+
+func (c *collector) AddShutdownForcedCloses(traffic string, count int) {
+	c.ingressController.AddShutdownForcedCloses(traffic, count)
+}
+
+func (c *collector) SetDiskUsage(paths []collectors.PathUsage) {
+	c.diskUsage.SetPathsUsage(paths)
+}
+
+func (c *collector) AddCacheEviction(path string, bytes int64) {
+	c.diskUsage.AddCacheEviction(path, bytes)
+}
+
 func (c *collector) SetAdmissionMetrics(testedIngressLength, testedIngressTime, renderingIngressLength, renderingIngressTime, testedConfigurationSize, admissionTime float64) {
 	c.admissionController.SetAdmissionMetrics(
 		testedIngressLength,
@@ -0,0 +1,245 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preemption detects the notices sent before a node is preempted,
+// like the termination of spot instances, to drain the ingress controller
+// before the node is lost.
+package preemption
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	klog "k8s.io/klog/v2"
+)
+
+// Names of the sources of preemption notices
+const (
+	GCPSource   = "gcp"
+	AWSSource   = "aws"
+	TaintSource = "taint"
+)
+
+// DefaultTaintKeys are the taints added to a node about to be preempted
+// by the cloud providers and the node termination handlers
+var DefaultTaintKeys = []string{
+	"cloud.google.com/impending-node-termination",
+	"aws-node-termination-handler/spot-itn",
+	"aws-node-termination-handler/rebalance-recommendation",
+}
+
+var (
+	gcpPreemptedURL = "http://metadata.google.internal/computeMetadata/v1/instance/preempted"
+	awsTokenURL     = "http://169.254.169.254/latest/api/token"
+	awsActionURL    = "http://169.254.169.254/latest/meta-data/spot/instance-action"
+)
+
+// Source reports if the node running the controller received a preemption notice
+type Source interface {
+	Name() string
+	Preempted(ctx context.Context) (bool, error)
+}
+
+// NewSource returns the source of preemption notices with the given name.
+// The taint source checks the taints of the node with the given name.
+func NewSource(name string, client kubernetes.Interface, node string, taintKeys []string) (Source, error) {
+	httpClient := &http.Client{Timeout: 2 * time.Second}
+
+	switch name {
+	case GCPSource:
+		return &gcp{client: httpClient}, nil
+	case AWSSource:
+		return &aws{client: httpClient}, nil
+	case TaintSource:
+		if node == "" {
+			return nil, fmt.Errorf("the name of the node is required to check its taints")
+		}
+		if len(taintKeys) == 0 {
+			taintKeys = DefaultTaintKeys
+		}
+		return &taint{client: client, node: node, keys: taintKeys}, nil
+	default:
+		return nil, fmt.Errorf("unknown source of preemption notices %q", name)
+	}
+}
+
+// gcp checks the metadata server of Compute Engine, which returns
+// TRUE once a spot or preemptible instance is being preempted
+type gcp struct {
+	client *http.Client
+}
+
+func (s *gcp) Name() string {
+	return GCPSource
+}
+
+func (s *gcp) Preempted(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpPreemptedURL, http.NoBody)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	body, status, err := do(s.client, req)
+	if err != nil {
+		return false, err
+	}
+
+	if status != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %v from the GCP metadata server", status)
+	}
+
+	return strings.TrimSpace(body) == "TRUE", nil
+}
+
+// aws checks the instance metadata service (IMDSv2), which returns
+// the instance action once a spot instance is being interrupted
+type aws struct {
+	client *http.Client
+}
+
+func (s *aws) Name() string {
+	return AWSSource
+}
+
+func (s *aws) Preempted(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, awsTokenURL, http.NoBody)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "60")
+
+	token, status, err := do(s.client, req)
+	if err != nil {
+		return false, err
+	}
+
+	if status != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %v obtaining a token from the AWS metadata service", status)
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, awsActionURL, http.NoBody)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token", token)
+
+	_, status, err = do(s.client, req)
+	if err != nil {
+		return false, err
+	}
+
+	switch status {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code %v from the AWS metadata service", status)
+	}
+}
+
+// taint checks if the node has one of the taints added before its termination
+type taint struct {
+	client kubernetes.Interface
+	node   string
+	keys   []string
+}
+
+func (s *taint) Name() string {
+	return TaintSource
+}
+
+func (s *taint) Preempted(ctx context.Context) (bool, error) {
+	node, err := s.client.CoreV1().Nodes().Get(ctx, s.node, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	for _, t := range node.Spec.Taints {
+		if slices.Contains(s.keys, t.Key) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func do(client *http.Client, req *http.Request) (body string, status int, err error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return string(data), resp.StatusCode, nil
+}
+
+// Watcher polls the sources of preemption notices
+type Watcher struct {
+	Sources  []Source
+	Interval time.Duration
+}
+
+// Wait blocks until one of the sources reports a preemption notice, returning
+// its name, or until the stop channel is closed, returning an empty string.
+func (w *Watcher) Wait(stopCh <-chan struct{}) string {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		if source := w.check(); source != "" {
+			return source
+		}
+
+		select {
+		case <-stopCh:
+			return ""
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) check() string {
+	for _, s := range w.Sources {
+		ctx, cancel := context.WithTimeout(context.Background(), w.Interval)
+		preempted, err := s.Preempted(ctx)
+		cancel()
+
+		if err != nil {
+			klog.V(3).ErrorS(err, "Error checking preemption notice", "source", s.Name())
+			continue
+		}
+
+		if preempted {
+			return s.Name()
+		}
+	}
+
+	return ""
+}
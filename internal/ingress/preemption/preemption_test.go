@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGCPSource(t *testing.T) {
+	preempted := "FALSE"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(preempted))
+	}))
+	defer server.Close()
+
+	gcpPreemptedURL = server.URL
+
+	s, err := NewSource(GCPSource, nil, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, value := range []string{"FALSE", "TRUE"} {
+		preempted = value
+		ok, err := s.Preempted(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != (value == "TRUE") {
+			t.Errorf("expected preempted to be %v with %v", !ok, value)
+		}
+	}
+}
+
+func TestAWSSource(t *testing.T) {
+	interrupted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			_, _ = w.Write([]byte("token"))
+		case http.MethodGet:
+			if r.Header.Get("X-aws-ec2-metadata-token") != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if !interrupted {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"action": "terminate", "time": "2026-10-15T08:22:00Z"}`))
+		}
+	}))
+	defer server.Close()
+
+	awsTokenURL = server.URL
+	awsActionURL = server.URL
+
+	s, err := NewSource(AWSSource, nil, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, value := range []bool{false, true} {
+		interrupted = value
+		ok, err := s.Preempted(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != value {
+			t.Errorf("expected preempted to be %v but returned %v", value, ok)
+		}
+	}
+}
+
+func TestTaintSource(t *testing.T) {
+	if _, err := NewSource(TaintSource, fake.NewSimpleClientset(), "", nil); err == nil {
+		t.Errorf("expected an error without the name of the node")
+	}
+
+	node := &apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	client := fake.NewSimpleClientset(node)
+
+	s, err := NewSource(TaintSource, client, "node-1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ok, err := s.Preempted(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Errorf("expected a node without taints not to be preempted")
+	}
+
+	node.Spec.Taints = []apiv1.Taint{{Key: "cloud.google.com/impending-node-termination", Effect: apiv1.TaintEffectNoSchedule}}
+	if _, err := client.CoreV1().Nodes().Update(context.Background(), node, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ok, err = s.Preempted(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected a node with the termination taint to be preempted")
+	}
+}
+
+type fakeSource struct {
+	name   string
+	checks int
+	after  int
+}
+
+func (f *fakeSource) Name() string {
+	return f.name
+}
+
+func (f *fakeSource) Preempted(_ context.Context) (bool, error) {
+	f.checks++
+	return f.checks > f.after, nil
+}
+
+func TestWatcherWait(t *testing.T) {
+	w := &Watcher{
+		Sources:  []Source{&fakeSource{name: "a", after: 100}, &fakeSource{name: "b", after: 2}},
+		Interval: 10 * time.Millisecond,
+	}
+
+	if source := w.Wait(make(chan struct{})); source != "b" {
+		t.Errorf("expected a notice from b but returned %q", source)
+	}
+
+	stopCh := make(chan struct{})
+	close(stopCh)
+	w.Sources = []Source{&fakeSource{name: "a", after: 100}}
+	if source := w.Wait(stopCh); source != "" {
+		t.Errorf("expected no notice after stopping but returned %q", source)
+	}
+}
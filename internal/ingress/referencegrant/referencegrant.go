@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package referencegrant allows the Ingresses to reference objects of other
+// namespaces permitted by the ReferenceGrants of the Gateway API
+package referencegrant
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// GroupVersionResource of the ReferenceGrants watched by the controller
+var GroupVersionResource = schema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1beta1",
+	Resource: "referencegrants",
+}
+
+const (
+	ingressGroup = "networking.k8s.io"
+	ingressKind  = "Ingress"
+)
+
+// Kinds of the objects referenced by the annotations
+const (
+	KindSecret  = "Secret"
+	KindService = "Service"
+)
+
+// ReferenceGrant contains the fields of a ReferenceGrant used by the controller
+type ReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec Spec `json:"spec"`
+}
+
+// Spec describes the references allowed by a ReferenceGrant
+type Spec struct {
+	// From are the objects allowed to reference the objects of the namespace of the ReferenceGrant
+	From []From `json:"from"`
+	// To are the objects of the namespace of the ReferenceGrant that can be referenced
+	To []To `json:"to"`
+}
+
+// From describes the objects of a namespace allowed to reference other objects
+type From struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+}
+
+// To describes the objects that can be referenced. All the objects
+// of the kind can be referenced when the name is not set.
+type To struct {
+	Group string  `json:"group"`
+	Kind  string  `json:"kind"`
+	Name  *string `json:"name,omitempty"`
+}
+
+// Permits returns true if the ReferenceGrant allows the Ingresses of a namespace
+// to reference the object of the kind and name in the namespace of the ReferenceGrant
+func (g *ReferenceGrant) Permits(fromNamespace, kind, name string) bool {
+	from := false
+	for _, f := range g.Spec.From {
+		if f.Group == ingressGroup && f.Kind == ingressKind && f.Namespace == fromNamespace {
+			from = true
+			break
+		}
+	}
+
+	if !from {
+		return false
+	}
+
+	for _, t := range g.Spec.To {
+		// the referenced kinds belong to the core API group
+		if t.Group != "" || t.Kind != kind {
+			continue
+		}
+
+		if t.Name == nil || *t.Name == "" || *t.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FromUnstructured converts an object returned by the dynamic client into a ReferenceGrant
+func FromUnstructured(obj interface{}) (*ReferenceGrant, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type: %T", obj)
+	}
+
+	grant := &ReferenceGrant{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), grant); err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+// Lister makes a Store that lists ReferenceGrants
+type Lister struct {
+	cache.Store
+}
+
+// Permits returns true if a ReferenceGrant in the namespace allows the Ingresses
+// of another namespace to reference the object of the kind and name
+func (l *Lister) Permits(fromNamespace, kind, namespace, name string) bool {
+	for _, obj := range l.List() {
+		grant, err := FromUnstructured(obj)
+		if err != nil || grant.Namespace != namespace {
+			continue
+		}
+
+		if grant.Permits(fromNamespace, kind, name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package referencegrant
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newReferenceGrant(namespace string, to map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1beta1",
+			"kind":       "ReferenceGrant",
+			"metadata": map[string]interface{}{
+				"name":      "ingresses",
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"from": []interface{}{
+					map[string]interface{}{
+						"group":     "networking.k8s.io",
+						"kind":      "Ingress",
+						"namespace": "apps",
+					},
+				},
+				"to": []interface{}{to},
+			},
+		},
+	}
+}
+
+func TestPermits(t *testing.T) {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, obj := range []*unstructured.Unstructured{
+		newReferenceGrant("certs", map[string]interface{}{"group": "", "kind": "Secret", "name": "shared-ca"}),
+		newReferenceGrant("backends", map[string]interface{}{"group": "", "kind": "Service"}),
+	} {
+		if err := store.Add(obj); err != nil {
+			t.Fatalf("unexpected error adding ReferenceGrant: %v", err)
+		}
+	}
+
+	lister := &Lister{store}
+
+	testCases := []struct {
+		name          string
+		fromNamespace string
+		kind          string
+		namespace     string
+		objName       string
+		expected      bool
+	}{
+		{"secret with the name of the grant", "apps", KindSecret, "certs", "shared-ca", true},
+		{"secret with another name", "apps", KindSecret, "certs", "other", false},
+		{"any service", "apps", KindService, "backends", "default-backend", true},
+		{"kind not granted", "apps", KindService, "certs", "shared-ca", false},
+		{"namespace not granted", "other", KindSecret, "certs", "shared-ca", false},
+		{"namespace without grants", "apps", KindSecret, "other", "shared-ca", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if permits := lister.Permits(tc.fromNamespace, tc.kind, tc.namespace, tc.objName); permits != tc.expected {
+				t.Errorf("expected %v but returned %v", tc.expected, permits)
+			}
+		})
+	}
+}
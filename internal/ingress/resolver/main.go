@@ -19,6 +19,7 @@ package resolver
 import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/ingress-nginx/internal/ingress/defaults"
+	"k8s.io/ingress-nginx/internal/ingress/transform"
 )
 
 // Resolver is an interface that knows how to extract information from a controller
@@ -44,6 +45,13 @@ type Resolver interface {
 
 	// GetService searches for services containing the namespace and name using the character /
 	GetService(string) (*apiv1.Service, error)
+
+	// IsReferenceGranted returns true if a ReferenceGrant allows the Ingresses of a namespace
+	// to reference the object of the kind and name in another namespace
+	IsReferenceGranted(fromNamespace, kind, namespace, name string) bool
+
+	// GetHTTPTransform searches for HTTPTransforms containing the namespace and name using the character /
+	GetHTTPTransform(string) (*transform.HTTPTransform, error)
 }
 
 // AuthSSLCert contains the necessary information to do certificate based
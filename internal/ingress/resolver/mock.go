@@ -18,10 +18,12 @@ package resolver
 
 import (
 	"errors"
+	"fmt"
 
 	apiv1 "k8s.io/api/core/v1"
 
 	"k8s.io/ingress-nginx/internal/ingress/defaults"
+	"k8s.io/ingress-nginx/internal/ingress/transform"
 )
 
 // Mock implements the Resolver interface
@@ -29,6 +31,10 @@ type Mock struct {
 	ConfigMaps           map[string]*apiv1.ConfigMap
 	AnnotationsRiskLevel string
 	AllowCrossNamespace  bool
+	// GrantedReferences are the objects referenced from other namespaces, as kind/namespace/name
+	GrantedReferences []string
+	// HTTPTransforms are the HTTPTransforms by namespace/name
+	HTTPTransforms map[string]*transform.HTTPTransform
 }
 
 // GetDefaultBackend returns the backend that must be used as default
@@ -72,3 +78,22 @@ func (m Mock) GetConfigMap(name string) (*apiv1.ConfigMap, error) {
 	}
 	return nil, errors.New("no configmap")
 }
+
+// IsReferenceGranted returns true if the object is one of the GrantedReferences
+func (m Mock) IsReferenceGranted(_, kind, namespace, name string) bool {
+	ref := fmt.Sprintf("%v/%v/%v", kind, namespace, name)
+	for _, granted := range m.GrantedReferences {
+		if granted == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHTTPTransform searches for HTTPTransforms containing the namespace and name using the character /
+func (m Mock) GetHTTPTransform(name string) (*transform.HTTPTransform, error) {
+	if v, ok := m.HTTPTransforms[name]; ok {
+		return v, nil
+	}
+	return nil, errors.New("no HTTPTransform")
+}
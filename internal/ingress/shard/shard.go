@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shard splits the Ingress objects between the replicas of the
+// controller by the hash of their hosts, so each replica renders and
+// serves only a part of them.
+package shard
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+// defServerName is the name of the catch-all server, served by every shard
+const defServerName = "_"
+
+// Shard is the part of the Ingress objects processed by a replica of the controller
+type Shard struct {
+	// Index of the shard, between 0 and Count-1
+	Index int
+	// Count is the number of shards
+	Count int
+}
+
+// Enabled returns true when the Ingress objects are split between more than one shard
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Validate checks the index is one of the shards
+func (s Shard) Validate() error {
+	if s.Count < 1 {
+		return fmt.Errorf("the number of shards must be greater than zero")
+	}
+
+	if s.Index < 0 || s.Index >= s.Count {
+		return fmt.Errorf("the shard index %v must be between 0 and %v", s.Index, s.Count-1)
+	}
+
+	return nil
+}
+
+// Owns returns true if the host belongs to the shard.
+// Rules without host belong to the first shard.
+func (s Shard) Owns(host string) bool {
+	if !s.Enabled() {
+		return true
+	}
+
+	if host == "" || host == defServerName {
+		return s.Index == 0
+	}
+
+	h := fnv.New32a()
+	// hash.Hash never returns an error
+	_, _ = h.Write([]byte(strings.ToLower(host)))
+
+	return int(h.Sum32()%uint32(s.Count)) == s.Index
+}
+
+// OwnsServer returns true if the server is rendered by the shard.
+// The catch-all server is rendered by every shard.
+func (s Shard) OwnsServer(server *ingress.Server) bool {
+	return server.Hostname == defServerName || s.Owns(server.Hostname)
+}
+
+// OwnsIngress returns true if a host of the Ingress belongs to the shard.
+// An Ingress with hosts in several shards is processed by all of them.
+func (s Shard) OwnsIngress(ing *ingress.Ingress) bool {
+	if !s.Enabled() {
+		return true
+	}
+
+	return s.ownsSpec(&ing.Spec)
+}
+
+func (s Shard) ownsSpec(spec *networking.IngressSpec) bool {
+	if spec.DefaultBackend != nil && s.Owns("") {
+		return true
+	}
+
+	for i := range spec.Rules {
+		if s.Owns(spec.Rules[i].Host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IngressShards returns the indexes of the shards processing the Ingress.
+// The status of an Ingress in several shards only lists the address of the
+// shard of its first host.
+func (s Shard) IngressShards(ing *networking.Ingress) []int {
+	if !s.Enabled() {
+		return nil
+	}
+
+	var indexes []int
+	for i := 0; i < s.Count; i++ {
+		if (Shard{Index: i, Count: s.Count}).ownsSpec(&ing.Spec) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// StatusShard returns the index of the shard updating the status of the
+// Ingress, the shard of its first host
+func (s Shard) StatusShard(ing *networking.Ingress) int {
+	host := ""
+	if len(ing.Spec.Rules) > 0 {
+		host = ing.Spec.Rules[0].Host
+	}
+
+	for i := 0; i < s.Count; i++ {
+		if (Shard{Index: i, Count: s.Count}).Owns(host) {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// FilterIngresses returns the Ingress objects with a host in the shard
+func (s Shard) FilterIngresses(ings []*ingress.Ingress) []*ingress.Ingress {
+	if !s.Enabled() {
+		return ings
+	}
+
+	filtered := make([]*ingress.Ingress, 0, len(ings)/s.Count+1)
+	for _, ing := range ings {
+		if s.OwnsIngress(ing) {
+			filtered = append(filtered, ing)
+		}
+	}
+
+	return filtered
+}
+
+// FilterServers returns the servers rendered by the shard
+func (s Shard) FilterServers(servers []*ingress.Server) []*ingress.Server {
+	if !s.Enabled() {
+		return servers
+	}
+
+	filtered := make([]*ingress.Server, 0, len(servers)/s.Count+1)
+	for _, server := range servers {
+		if s.OwnsServer(server) {
+			filtered = append(filtered, server)
+		}
+	}
+
+	return filtered
+}
+
+// IndexFromPodName returns the ordinal of a pod of a StatefulSet, the number after the last dash of its name
+func IndexFromPodName(name string) (int, error) {
+	i := strings.LastIndex(name, "-")
+	if i < 0 {
+		return 0, fmt.Errorf("pod name %q does not end with an ordinal", name)
+	}
+
+	index, err := strconv.Atoi(name[i+1:])
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("pod name %q does not end with an ordinal", name)
+	}
+
+	return index, nil
+}
+
+// IngressLister lists the Ingress objects
+type IngressLister interface {
+	ListIngresses() []*ingress.Ingress
+}
+
+// Lister lists the Ingress objects whose status is updated by a shard
+type Lister struct {
+	IngressLister
+	Shard Shard
+}
+
+// ListIngresses returns the Ingress objects whose first host belongs to the shard.
+// An Ingress with hosts in several shards is updated only by one of them, so the
+// replicas do not overwrite the address published by each other.
+func (l Lister) ListIngresses() []*ingress.Ingress {
+	ings := l.IngressLister.ListIngresses()
+	if !l.Shard.Enabled() {
+		return ings
+	}
+
+	filtered := make([]*ingress.Ingress, 0, len(ings)/l.Shard.Count+1)
+	for _, ing := range ings {
+		if l.Shard.StatusShard(&ing.Ingress) == l.Shard.Index {
+			filtered = append(filtered, ing)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"fmt"
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/pkg/apis/ingress"
+)
+
+func newIngress(hosts ...string) *ingress.Ingress {
+	ing := &ingress.Ingress{}
+	for _, host := range hosts {
+		ing.Spec.Rules = append(ing.Spec.Rules, networking.IngressRule{Host: host})
+	}
+
+	return ing
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		shard   Shard
+		wantErr bool
+	}{
+		{Shard{Index: 0, Count: 1}, false},
+		{Shard{Index: 2, Count: 3}, false},
+		{Shard{Index: 3, Count: 3}, true},
+		{Shard{Index: -1, Count: 3}, true},
+		{Shard{Index: 0, Count: 0}, true},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.shard.Validate(); (err != nil) != tc.wantErr {
+			t.Errorf("%+v: expected error %v but returned %v", tc.shard, tc.wantErr, err)
+		}
+	}
+}
+
+func TestOwns(t *testing.T) {
+	const count = 4
+
+	owned := make([]int, count)
+	for h := 0; h < 1000; h++ {
+		host := fmt.Sprintf("host-%v.example.com", h)
+
+		owners := 0
+		for i := 0; i < count; i++ {
+			if (Shard{Index: i, Count: count}).Owns(host) {
+				owners++
+				owned[i]++
+			}
+		}
+
+		if owners != 1 {
+			t.Fatalf("expected host %v to belong to one shard but belongs to %v", host, owners)
+		}
+	}
+
+	for i, n := range owned {
+		if n < 150 {
+			t.Errorf("expected the hosts to be split between the shards but shard %v owns %v of 1000", i, n)
+		}
+	}
+
+	if !(Shard{Index: 1, Count: 1}).Owns("example.com") {
+		t.Errorf("expected every host to belong to the shard when sharding is disabled")
+	}
+
+	if s := (Shard{Index: 1, Count: 2}); s.Owns("") || !s.OwnsServer(&ingress.Server{Hostname: "_"}) {
+		t.Errorf("expected rules without host to belong to the first shard and the catch-all server to every shard")
+	}
+
+	if s := (Shard{Index: 0, Count: 2}); s.Owns("Example.com") != s.Owns("example.com") {
+		t.Errorf("expected hosts to be case insensitive")
+	}
+}
+
+func TestFilterIngresses(t *testing.T) {
+	s := Shard{Index: 0, Count: 2}
+
+	var owned, other string
+	for h := 0; owned == "" || other == ""; h++ {
+		host := fmt.Sprintf("host-%v.example.com", h)
+		if s.Owns(host) {
+			owned = host
+		} else {
+			other = host
+		}
+	}
+
+	ings := []*ingress.Ingress{newIngress(owned), newIngress(other), newIngress(other, owned), newIngress("")}
+	filtered := s.FilterIngresses(ings)
+	if len(filtered) != 3 || filtered[0] != ings[0] || filtered[1] != ings[2] || filtered[2] != ings[3] {
+		t.Errorf("unexpected ingresses in the shard: %v", filtered)
+	}
+
+	servers := s.FilterServers([]*ingress.Server{{Hostname: "_"}, {Hostname: owned}, {Hostname: other}})
+	if len(servers) != 2 || servers[1].Hostname != owned {
+		t.Errorf("unexpected servers in the shard: %v", servers)
+	}
+}
+
+func TestIndexFromPodName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected int
+		wantErr  bool
+	}{
+		{"ingress-nginx-controller-0", 0, false},
+		{"ingress-nginx-controller-12", 12, false},
+		{"ingress-nginx-controller-7d9c8b6f5-x2x4z", 0, true},
+		{"controller", 0, true},
+	}
+
+	for _, tc := range testCases {
+		index, err := IndexFromPodName(tc.name)
+		if (err != nil) != tc.wantErr || index != tc.expected {
+			t.Errorf("%v: expected %v and error %v but returned %v and %v", tc.name, tc.expected, tc.wantErr, index, err)
+		}
+	}
+}
+
+type fakeLister []*ingress.Ingress
+
+func (l fakeLister) ListIngresses() []*ingress.Ingress {
+	return l
+}
+
+func TestLister(t *testing.T) {
+	s := Shard{Index: 0, Count: 2}
+
+	var owned, other string
+	for h := 0; owned == "" || other == ""; h++ {
+		host := fmt.Sprintf("host-%v.example.com", h)
+		if s.Owns(host) {
+			owned = host
+		} else {
+			other = host
+		}
+	}
+
+	ings := fakeLister{newIngress(owned), newIngress(other, owned), newIngress(owned, other)}
+	listed := Lister{IngressLister: ings, Shard: s}.ListIngresses()
+	if len(listed) != 2 || listed[0] != ings[0] || listed[1] != ings[2] {
+		t.Errorf("expected only the ingresses with the first host in the shard but listed %v", listed)
+	}
+}
+
+func TestIngressShards(t *testing.T) {
+	s := Shard{Index: 0, Count: 2}
+
+	var owned, other string
+	for h := 0; owned == "" || other == ""; h++ {
+		host := fmt.Sprintf("host-%v.example.com", h)
+		if s.Owns(host) {
+			owned = host
+		} else {
+			other = host
+		}
+	}
+
+	if shards := s.IngressShards(&newIngress(owned, owned).Ingress); len(shards) != 1 || shards[0] != 0 {
+		t.Errorf("expected the ingress in the shard 0 but got %v", shards)
+	}
+
+	ing := newIngress(other, owned)
+	if shards := s.IngressShards(&ing.Ingress); len(shards) != 2 {
+		t.Errorf("expected the ingress in both shards but got %v", shards)
+	}
+	if index := s.StatusShard(&ing.Ingress); index != 1 {
+		t.Errorf("expected the status updated by the shard of the first host but got %v", index)
+	}
+
+	if shards := (Shard{Count: 1}).IngressShards(&ing.Ingress); shards != nil {
+		t.Errorf("expected no shards when the sharding is disabled but got %v", shards)
+	}
+}
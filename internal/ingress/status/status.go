@@ -33,6 +33,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
+	networkingapply "k8s.io/client-go/applyconfigurations/networking/v1"
 	clientset "k8s.io/client-go/kubernetes"
 
 	"k8s.io/ingress-nginx/internal/k8s"
@@ -44,6 +45,9 @@ import (
 // which the status should check if an update is required.
 var UpdateInterval = 60
 
+// FieldManager is the manager of the status fields written with server-side apply
+const FieldManager = "ingress-nginx-status"
+
 // Syncer is an interface that implements syncer
 type Syncer interface {
 	Run(chan struct{})
@@ -68,6 +72,10 @@ type Config struct {
 
 	UseNodeInternalIP bool
 
+	// ServerSideApply writes the status with server-side apply, owning the
+	// load-balancer status with the FieldManager, instead of updating the Ingresses
+	ServerSideApply bool
+
 	IngressLister ingressLister
 }
 
@@ -280,6 +288,11 @@ func (s *statusSync) updateStatus(newIngressPoint []v1.IngressLoadBalancerIngres
 			continue
 		}
 
+		if s.ServerSideApply {
+			batch.Queue(runApply(ing, newIngressPoint, s.Client))
+			continue
+		}
+
 		batch.Queue(runUpdate(ing, newIngressPoint, s.Client))
 	}
 
@@ -312,6 +325,52 @@ func runUpdate(ing *ingress.Ingress, status []v1.IngressLoadBalancerIngress,
 	}
 }
 
+// runApply writes the status with server-side apply, without reading the Ingress first
+func runApply(ing *ingress.Ingress, status []v1.IngressLoadBalancerIngress,
+	client clientset.Interface,
+) pool.WorkFunc {
+	return func(wu pool.WorkUnit) (interface{}, error) {
+		if wu.IsCancelled() {
+			return nil, nil
+		}
+
+		klog.InfoS("applying Ingress status", "namespace", ing.Namespace, "ingress", ing.Name, "currentValue", ing.Status.LoadBalancer.Ingress, "newValue", status)
+		_, err := client.NetworkingV1().Ingresses(ing.Namespace).ApplyStatus(context.TODO(), ingressStatusApply(ing, status),
+			metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
+		if err != nil {
+			klog.Warningf("error applying ingress status: %v", err)
+		}
+
+		return true, nil
+	}
+}
+
+// ingressStatusApply returns the apply configuration of the load-balancer status of an Ingress
+func ingressStatusApply(ing *ingress.Ingress, status []v1.IngressLoadBalancerIngress) *networkingapply.IngressApplyConfiguration {
+	lbs := make([]*networkingapply.IngressLoadBalancerIngressApplyConfiguration, 0, len(status))
+	for i := range status {
+		lb := networkingapply.IngressLoadBalancerIngress()
+		if status[i].IP != "" {
+			lb.WithIP(status[i].IP)
+		}
+		if status[i].Hostname != "" {
+			lb.WithHostname(status[i].Hostname)
+		}
+		for _, port := range status[i].Ports {
+			p := networkingapply.IngressPortStatus().WithPort(port.Port).WithProtocol(port.Protocol)
+			if port.Error != nil {
+				p.WithError(*port.Error)
+			}
+			lb.WithPorts(p)
+		}
+		lbs = append(lbs, lb)
+	}
+
+	return networkingapply.Ingress(ing.Name, ing.Namespace).
+		WithStatus(networkingapply.IngressStatus().
+			WithLoadBalancer(networkingapply.IngressLoadBalancerStatus().WithIngress(lbs...)))
+}
+
 func lessLoadBalancerIngress(addrs []v1.IngressLoadBalancerIngress) func(int, int) bool {
 	return func(a, b int) bool {
 		switch strings.Compare(addrs[a].Hostname, addrs[b].Hostname) {
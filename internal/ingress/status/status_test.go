@@ -740,3 +740,39 @@ func TestIngressSliceEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestIngressStatusApply(t *testing.T) {
+	ing := &ingress.Ingress{
+		Ingress: networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: apiv1.NamespaceDefault,
+			},
+		},
+	}
+	portError := "unreachable"
+	status := []networking.IngressLoadBalancerIngress{
+		{IP: "10.0.0.1"},
+		{Hostname: "foo.bar", Ports: []networking.IngressPortStatus{{Port: 443, Protocol: apiv1.ProtocolTCP, Error: &portError}}},
+	}
+
+	apply := ingressStatusApply(ing, status)
+	if *apply.Name != "foo" || *apply.Namespace != apiv1.NamespaceDefault {
+		t.Errorf("unexpected Ingress %v/%v", *apply.Namespace, *apply.Name)
+	}
+
+	lbs := apply.Status.LoadBalancer.Ingress
+	if len(lbs) != 2 {
+		t.Fatalf("expected 2 load-balancer addresses but %v were returned", len(lbs))
+	}
+	if *lbs[0].IP != "10.0.0.1" || lbs[0].Hostname != nil {
+		t.Errorf("unexpected address %v", lbs[0])
+	}
+	if *lbs[1].Hostname != "foo.bar" || *lbs[1].Ports[0].Port != 443 || *lbs[1].Ports[0].Error != portError {
+		t.Errorf("unexpected address %v", lbs[1])
+	}
+
+	if apply := ingressStatusApply(ing, []networking.IngressLoadBalancerIngress{}); apply.Status.LoadBalancer.Ingress != nil {
+		t.Errorf("expected no load-balancer address but %v was returned", apply.Status.LoadBalancer.Ingress)
+	}
+}
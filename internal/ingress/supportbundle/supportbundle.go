@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supportbundle exports a snapshot of the state of the controller,
+// without private keys and credentials, to attach to bug reports.
+package supportbundle
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/redact"
+)
+
+// Path is the path of the endpoint exporting the support bundle
+const Path = "/support-bundle"
+
+// Version contains the versions of the controller and NGINX
+type Version struct {
+	Release    string `json:"release"`
+	Build      string `json:"build"`
+	Repository string `json:"repository"`
+	NGINX      string `json:"nginx"`
+}
+
+// Reload is the result of a reload of NGINX
+type Reload struct {
+	Time     time.Time `json:"time"`
+	Duration float64   `json:"durationSeconds"`
+	Checksum string    `json:"checksum,omitempty"`
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Bundle is a snapshot of the state of the controller
+type Bundle struct {
+	CreatedAt time.Time         `json:"createdAt"`
+	Version   Version           `json:"version"`
+	Flags     map[string]string `json:"flags"`
+	ConfigMap map[string]string `json:"configMap,omitempty"`
+	NginxConf string            `json:"nginxConf"`
+	Backends  interface{}       `json:"backends,omitempty"`
+	Reloads   []Reload          `json:"reloads"`
+	// Errors contains the parts of the bundle that could not be collected
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Source returns the state of the controller included in the bundle
+type Source interface {
+	SupportBundle() *Bundle
+}
+
+// directiveRegex matches the value of the directives and headers of nginx.conf with sensitive names
+var directiveRegex = regexp.MustCompile(`(?i)\b(authorization|[\w-]*password[\w-]*|[\w-]*secret[\w-]*)(\s+)("[^"]*"|'[^']*'|[^\s;]+)`)
+
+// redactConf removes the private keys, credentials and values of the sensitive directives of nginx.conf
+func redactConf(s string) string {
+	s = redact.String(s)
+	return directiveRegex.ReplaceAllStringFunc(s, func(m string) string {
+		parts := directiveRegex.FindStringSubmatch(m)
+		// the value of a directive removing a header is not sensitive
+		if parts[3] == `""` || parts[3] == `''` {
+			return m
+		}
+		return parts[1] + parts[2] + `"` + redact.Redacted + `"`
+	})
+}
+
+func redactMap(m map[string]string) {
+	for k, v := range m {
+		m[k] = redact.Value(k, v)
+	}
+}
+
+// redactJSON removes the sensitive fields and private keys of a decoded JSON document
+func redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if s, isString := e.(string); isString {
+				t[k] = redact.Value(k, s)
+				continue
+			}
+			t[k] = redactJSON(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redactJSON(e)
+		}
+	case string:
+		return redact.String(t)
+	}
+
+	return v
+}
+
+// Redact removes the private keys and credentials of the bundle
+func (b *Bundle) Redact() {
+	redactMap(b.Flags)
+	redactMap(b.ConfigMap)
+	b.NginxConf = redactConf(b.NginxConf)
+	b.Backends = redactJSON(b.Backends)
+	for i := range b.Reloads {
+		b.Reloads[i].Error = redactConf(b.Reloads[i].Error)
+	}
+}
+
+// History keeps the last reloads of NGINX
+type History struct {
+	mu      sync.Mutex
+	size    int
+	reloads []Reload
+}
+
+// NewHistory returns a history keeping up to size reloads
+func NewHistory(size int) *History {
+	return &History{
+		size:    size,
+		reloads: make([]Reload, 0, size),
+	}
+}
+
+// Add records a reload, removing the oldest one when the history is full
+func (h *History) Add(r Reload) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.reloads) == h.size {
+		copy(h.reloads, h.reloads[1:])
+		h.reloads = h.reloads[:h.size-1]
+	}
+	h.reloads = append(h.reloads, r)
+}
+
+// List returns the recorded reloads, from the oldest to the newest
+func (h *History) List() []Reload {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]Reload(nil), h.reloads...)
+}
+
+// Register exposes the redacted support bundle of the source in the given mux
+func Register(mux *http.ServeMux, src Source) {
+	mux.HandleFunc(Path, func(w http.ResponseWriter, _ *http.Request) {
+		b := src.SupportBundle()
+		b.Redact()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="ingress-nginx-support-bundle.json"`)
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(b); err != nil {
+			klog.V(2).ErrorS(err, "Error writing support bundle")
+		}
+	})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tlsredirect contains the locations excepted from the redirection of
+// HTTP requests to HTTPS.
+package tlsredirect
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Exception is a location whose HTTP requests are never redirected to HTTPS
+type Exception struct {
+	// Host is the server name of the location, empty for all the servers.
+	// A leading "*." matches the subdomains.
+	Host string `json:"host,omitempty"`
+	// Path is the prefix of the path of the location, or a regular
+	// expression the path must match when Regex is set
+	Path string `json:"path"`
+	// Regex indicates Path is a regular expression
+	Regex bool `json:"regex,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// Parse parses a comma-separated list of exceptions with the form [host]/prefix
+// or [host]~regex, e.g. "/.well-known/acme-challenge,example.com~^/health(z)?$"
+func Parse(raw string) ([]Exception, error) {
+	var exceptions []Exception
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		i := strings.IndexAny(item, "/~")
+		if i < 0 {
+			return nil, fmt.Errorf("exception %q: expected a path starting with / or a regular expression starting with ~", item)
+		}
+
+		e := Exception{Host: strings.ToLower(item[:i]), Path: item[i:]}
+		if strings.ContainsAny(e.Host, " *") && !strings.HasPrefix(e.Host, "*.") || strings.Count(e.Host, "*") > 1 {
+			return nil, fmt.Errorf("exception %q: invalid host %q", item, e.Host)
+		}
+
+		if e.Path[0] == '~' {
+			e.Path = e.Path[1:]
+			e.Regex = true
+			re, err := regexp.Compile(e.Path)
+			if err != nil {
+				return nil, fmt.Errorf("exception %q: %w", item, err)
+			}
+			e.re = re
+		}
+
+		exceptions = append(exceptions, e)
+	}
+
+	return exceptions, nil
+}
+
+// Matches returns true when the location with the host and path is excepted
+func (e *Exception) Matches(host, path string) bool {
+	if !e.matchesHost(host) {
+		return false
+	}
+
+	if !e.Regex {
+		return strings.HasPrefix(path, e.Path)
+	}
+
+	if e.re == nil {
+		re, err := regexp.Compile(e.Path)
+		if err != nil {
+			return false
+		}
+		e.re = re
+	}
+
+	return e.re.MatchString(path)
+}
+
+func (e *Exception) matchesHost(host string) bool {
+	if e.Host == "" {
+		return true
+	}
+
+	host = strings.ToLower(host)
+	if suffix, ok := strings.CutPrefix(e.Host, "*"); ok {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+
+	return host == e.Host
+}
+
+// Equal tests for equality between two Exception types
+func (e *Exception) Equal(e2 *Exception) bool {
+	return e.Host == e2.Host && e.Path == e2.Path && e.Regex == e2.Regex
+}
+
+// Match returns true when one of the exceptions matches the location
+func Match(exceptions []Exception, host, path string) bool {
+	for i := range exceptions {
+		if exceptions[i].Matches(host, path) {
+			return true
+		}
+	}
+
+	return false
+}
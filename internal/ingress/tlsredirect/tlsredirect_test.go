@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsredirect
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		raw      string
+		expected []Exception
+		err      bool
+	}{
+		{raw: ""},
+		{raw: " , "},
+		{raw: "/.well-known/acme-challenge", expected: []Exception{{Path: "/.well-known/acme-challenge"}}},
+		{raw: ",/match", expected: []Exception{{Path: "/match"}}},
+		{raw: "Example.com/healthz, ~^/ping$", expected: []Exception{{Host: "example.com", Path: "/healthz"}, {Path: "^/ping$", Regex: true}}},
+		{raw: "*.example.com~^/api/v[0-9]+/status", expected: []Exception{{Host: "*.example.com", Path: "^/api/v[0-9]+/status", Regex: true}}},
+		{raw: "example.com", err: true},
+		{raw: "~^/(", err: true},
+		{raw: "a*.example.com/", err: true},
+		{raw: "*.*.example.com/", err: true},
+	}
+
+	for _, tc := range testCases {
+		exceptions, err := Parse(tc.raw)
+		if tc.err {
+			if err == nil {
+				t.Errorf("%q: expected an error", tc.raw)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.raw, err)
+			continue
+		}
+		if len(exceptions) != len(tc.expected) {
+			t.Errorf("%q: expected %v but returned %v", tc.raw, tc.expected, exceptions)
+			continue
+		}
+		for i := range exceptions {
+			if !exceptions[i].Equal(&tc.expected[i]) {
+				t.Errorf("%q: expected %v but returned %v", tc.raw, tc.expected[i], exceptions[i])
+			}
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		raw      string
+		host     string
+		path     string
+		expected bool
+	}{
+		{"/match", "example.com", "/match", true},
+		{"/dontmatch", "example.com", "/match", false},
+		{"/dontmatch,/match", "example.com", "/match", true},
+		{"/dontmatch,/dontmatcheither", "example.com", "/match", false},
+		{"example.com/match", "example.com", "/match/sub", true},
+		{"example.com/match", "other.com", "/match", false},
+		{"*.example.com/match", "www.example.com", "/match", true},
+		{"*.example.com/match", "example.com", "/match", false},
+		{"~^/health(z)?$", "example.com", "/healthz", true},
+		{"~^/health(z)?$", "example.com", "/healthzz", false},
+		{"other.com~^/health", "example.com", "/health", false},
+	}
+
+	for _, tc := range testCases {
+		exceptions, err := Parse(tc.raw)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.raw, err)
+		}
+		if actual := Match(exceptions, tc.host, tc.path); actual != tc.expected {
+			t.Errorf("%q: expected %v for %v%v but returned %v", tc.raw, tc.expected, tc.host, tc.path, actual)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package transform contains the HTTPTransform custom resource, describing
+// the changes of the requests and responses of the Ingresses referencing it
+package transform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// GroupVersionResource of the HTTPTransforms watched by the controller
+var GroupVersionResource = schema.GroupVersionResource{
+	Group:    "ingress-nginx.x-k8s.io",
+	Version:  "v1alpha1",
+	Resource: "httptransforms",
+}
+
+var (
+	// headerNameRegex matches the tokens allowed as header names by RFC 9110
+	headerNameRegex = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+	// queryParamRegex matches the names of query parameters not requiring encoding
+	queryParamRegex = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+	// pathPrefixRegex matches an absolute path without spaces, queries and fragments
+	pathPrefixRegex = regexp.MustCompile(`^/[A-Za-z0-9._~!$&'()*+,;=:@%/-]*$`)
+)
+
+// HTTPTransform contains the fields of an HTTPTransform used by the controller
+type HTTPTransform struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec Spec `json:"spec"`
+}
+
+// Spec describes the changes of the requests and responses
+type Spec struct {
+	// RequestHeaders are the changes of the headers sent to the backend
+	RequestHeaders *Headers `json:"requestHeaders,omitempty"`
+	// ResponseHeaders are the changes of the headers sent to the client
+	ResponseHeaders *Headers `json:"responseHeaders,omitempty"`
+	// Path is the change of the path sent to the backend
+	Path *Path `json:"path,omitempty"`
+	// QueryParams are the changes of the query parameters sent to the backend
+	QueryParams *QueryParams `json:"queryParams,omitempty"`
+}
+
+// Headers describes the changes of the headers, applied in the order
+// remove, rename, set and add
+type Headers struct {
+	// Set replaces the values of the headers
+	Set []NameValue `json:"set,omitempty"`
+	// Add appends a value to the headers
+	Add []NameValue `json:"add,omitempty"`
+	// Remove are the names of the headers removed
+	Remove []string `json:"remove,omitempty"`
+	// Rename changes the names of the headers, keeping their values
+	Rename []Rename `json:"rename,omitempty"`
+}
+
+// QueryParams describes the changes of the query parameters, applied in the
+// order remove, rename, set and add
+type QueryParams struct {
+	// Set replaces the values of the parameters
+	Set []NameValue `json:"set,omitempty"`
+	// Add appends a value to the parameters
+	Add []NameValue `json:"add,omitempty"`
+	// Remove are the names of the parameters removed
+	Remove []string `json:"remove,omitempty"`
+	// Rename changes the names of the parameters, keeping their values
+	Rename []Rename `json:"rename,omitempty"`
+}
+
+// NameValue is a header or a query parameter
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Rename changes the name of a header or a query parameter
+type Rename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Path describes the change of the path, the prefix is stripped before adding the new one
+type Path struct {
+	// StripPrefix is removed from the beginning of the path
+	StripPrefix string `json:"stripPrefix,omitempty"`
+	// AddPrefix is added to the beginning of the path
+	AddPrefix string `json:"addPrefix,omitempty"`
+}
+
+// Validate returns an error when a name, value or prefix of the spec is not valid
+func (s *Spec) Validate() error {
+	if err := s.RequestHeaders.validate("requestHeaders"); err != nil {
+		return err
+	}
+
+	if err := s.ResponseHeaders.validate("responseHeaders"); err != nil {
+		return err
+	}
+
+	if s.QueryParams != nil {
+		if err := validateNames("queryParams", queryParamRegex, s.QueryParams.Set, s.QueryParams.Add, s.QueryParams.Remove, s.QueryParams.Rename); err != nil {
+			return err
+		}
+	}
+
+	if s.Path != nil {
+		if err := validatePrefix("stripPrefix", s.Path.StripPrefix); err != nil {
+			return err
+		}
+		if err := validatePrefix("addPrefix", s.Path.AddPrefix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validatePrefix(field, prefix string) error {
+	if prefix != "" && !pathPrefixRegex.MatchString(prefix) {
+		return fmt.Errorf("path.%v %q is not a valid path", field, prefix)
+	}
+
+	return nil
+}
+
+func (h *Headers) validate(field string) error {
+	if h == nil {
+		return nil
+	}
+
+	return validateNames(field, headerNameRegex, h.Set, h.Add, h.Remove, h.Rename)
+}
+
+func validateNames(field string, nameRegex *regexp.Regexp, set, add []NameValue, remove []string, rename []Rename) error {
+	names := append([]string{}, remove...)
+	for _, r := range rename {
+		names = append(names, r.From, r.To)
+	}
+
+	for _, nv := range append(append([]NameValue{}, set...), add...) {
+		// CR and LF would split the header
+		if strings.ContainsAny(nv.Value, "\r\n\x00") {
+			return fmt.Errorf("%v: the value of %q contains a control character", field, nv.Name)
+		}
+		names = append(names, nv.Name)
+	}
+
+	for _, name := range names {
+		if !nameRegex.MatchString(name) {
+			return fmt.Errorf("%v: %q is not a valid name", field, name)
+		}
+	}
+
+	return nil
+}
+
+// FromUnstructured converts an object returned by the dynamic client into an HTTPTransform
+func FromUnstructured(obj interface{}) (*HTTPTransform, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type: %T", obj)
+	}
+
+	transform := &HTTPTransform{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), transform); err != nil {
+		return nil, err
+	}
+
+	return transform, nil
+}
+
+// Lister makes a Store that lists HTTPTransforms
+type Lister struct {
+	cache.Store
+}
+
+// ByKey returns the HTTPTransform of the key namespace/name
+func (l *Lister) ByKey(key string) (*HTTPTransform, error) {
+	obj, exists, err := l.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("HTTPTransform %v not found", key)
+	}
+
+	return FromUnstructured(obj)
+}
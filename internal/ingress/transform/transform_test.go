@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transform
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		spec  Spec
+		valid bool
+	}{
+		{"empty", Spec{}, true},
+		{"headers", Spec{
+			RequestHeaders:  &Headers{Set: []NameValue{{"X-Tenant", "a b"}}, Remove: []string{"Cookie"}, Rename: []Rename{{"X-Old", "X-New"}}},
+			ResponseHeaders: &Headers{Add: []NameValue{{"Cache-Control", "no-store"}}},
+		}, true},
+		{"header name with a space", Spec{RequestHeaders: &Headers{Remove: []string{"X Tenant"}}}, false},
+		{"header value with a line feed", Spec{ResponseHeaders: &Headers{Set: []NameValue{{"X-Tenant", "a\r\nSet-Cookie: b"}}}}, false},
+		{"query parameters", Spec{QueryParams: &QueryParams{Set: []NameValue{{"lang", "en"}}, Rename: []Rename{{"q", "query"}}}}, true},
+		{"query parameter with an equal sign", Spec{QueryParams: &QueryParams{Remove: []string{"a=b"}}}, false},
+		{"path", Spec{Path: &Path{StripPrefix: "/api", AddPrefix: "/v2"}}, true},
+		{"relative path", Spec{Path: &Path{AddPrefix: "v2"}}, false},
+		{"path with a query", Spec{Path: &Path{StripPrefix: "/api?a=b"}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spec.Validate()
+			if tc.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestByKey(t *testing.T) {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	err := store.Add(&unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "ingress-nginx.x-k8s.io/v1alpha1",
+			"kind":       "HTTPTransform",
+			"metadata": map[string]interface{}{
+				"name":      "strip-api",
+				"namespace": "apps",
+			},
+			"spec": map[string]interface{}{
+				"path": map[string]interface{}{
+					"stripPrefix": "/api",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error adding HTTPTransform: %v", err)
+	}
+
+	lister := &Lister{store}
+
+	transform, err := lister.ByKey("apps/strip-api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transform.Spec.Path == nil || transform.Spec.Path.StripPrefix != "/api" {
+		t.Errorf("unexpected spec %+v", transform.Spec)
+	}
+
+	if _, err := lister.ByKey("other/strip-api"); err == nil {
+		t.Errorf("expected an error for a missing HTTPTransform")
+	}
+}
@@ -19,7 +19,7 @@ package net
 import (
 	"fmt"
 	_net "net"
-	"os/exec"
+	"os"
 )
 
 // IsIPV6 checks if the input contains a valid IPV6 address
@@ -41,8 +41,7 @@ func IsPortAvailable(p int) bool {
 // IsIPv6Enabled checks if IPV6 is enabled or not and we have
 // at least one configured in the pod
 func IsIPv6Enabled() bool {
-	cmd := exec.Command("test", "-f", "/proc/net/if_inet6")
-	if cmd.Run() != nil {
+	if _, err := os.Stat("/proc/net/if_inet6"); err != nil {
 		return false
 	}
 
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// capNetBindService is the bit of CAP_NET_BIND_SERVICE in the capability sets
+const capNetBindService = 10
+
+// defaultUnprivilegedPortStart is the first port a process without CAP_NET_BIND_SERVICE
+// can listen on when net.ipv4.ip_unprivileged_port_start is not available
+const defaultUnprivilegedPortStart = 1024
+
+var (
+	procStatusPath              = "/proc/self/status"
+	unprivilegedPortStartSysctl = "/proc/sys/net/ipv4/ip_unprivileged_port_start"
+)
+
+// UnprivilegedPortStart returns the first port a process without CAP_NET_BIND_SERVICE
+// can listen on, 1024 when net.ipv4.ip_unprivileged_port_start cannot be read
+func UnprivilegedPortStart() int {
+	data, err := os.ReadFile(unprivilegedPortStartSysctl)
+	if err != nil {
+		return defaultUnprivilegedPortStart
+	}
+
+	port, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return defaultUnprivilegedPortStart
+	}
+
+	return port
+}
+
+// HasNetBindServiceCapability returns if CAP_NET_BIND_SERVICE is in the effective
+// capabilities of the process. It returns true when they cannot be read, e.g.
+// outside of Linux, to keep the previous behavior.
+func HasNetBindServiceCapability() bool {
+	f, err := os.Open(procStatusPath)
+	if err != nil {
+		return true
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		value, found := strings.CutPrefix(scanner.Text(), "CapEff:")
+		if !found {
+			continue
+		}
+
+		caps, err := strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		if err != nil {
+			return true
+		}
+
+		return caps&(1<<capNetBindService) != 0
+	}
+
+	return true
+}
+
+// CheckPrivilegedPort returns an error when the process is not allowed to listen on a port,
+// because it is below net.ipv4.ip_unprivileged_port_start and CAP_NET_BIND_SERVICE is missing.
+// The port 0 listens on a random port.
+func CheckPrivilegedPort(port int) error {
+	start := UnprivilegedPortStart()
+	if port == 0 || port >= start || HasNetBindServiceCapability() {
+		return nil
+	}
+
+	return fmt.Errorf("port %v requires the NET_BIND_SERVICE capability, missing for uid %v. "+
+		"Add it to the capabilities of the container or use ports from %v", port, os.Getuid(), start)
+}
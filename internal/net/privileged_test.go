@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckPrivilegedPort(t *testing.T) {
+	defer func(status, sysctl string) {
+		procStatusPath, unprivilegedPortStartSysctl = status, sysctl
+	}(procStatusPath, unprivilegedPortStartSysctl)
+
+	dir := t.TempDir()
+	procStatusPath = filepath.Join(dir, "status")
+	unprivilegedPortStartSysctl = filepath.Join(dir, "ip_unprivileged_port_start")
+
+	testCases := []struct {
+		name      string
+		capEff    string
+		portStart string
+		port      int
+		expectErr bool
+	}{
+		{"with capability", "0000000000000400", "1024", 80, false},
+		{"without capability", "0000000000000000", "1024", 80, true},
+		{"unprivileged port", "0000000000000000", "1024", 8080, false},
+		{"random port", "0000000000000000", "1024", 0, false},
+		{"unprivileged port start lowered", "0000000000000000", "0", 80, false},
+		{"unknown unprivileged port start", "0000000000000000", "", 443, true},
+		{"unknown capabilities", "", "1024", 80, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := "Name:\tnginx-ingress-c\n"
+			if tc.capEff != "" {
+				status += "CapEff:\t" + tc.capEff + "\n"
+			}
+			if err := os.WriteFile(procStatusPath, []byte(status), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(unprivilegedPortStartSysctl, []byte(tc.portStart+"\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := CheckPrivilegedPort(tc.port); (err != nil) != tc.expectErr {
+				t.Errorf("expected error %v but returned %v", tc.expectErr, err)
+			}
+		})
+	}
+}
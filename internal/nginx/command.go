@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginx
+
+import (
+	"os"
+	"os/exec"
+)
+
+// defaultBinary is the location of the NGINX binary in the image
+const defaultBinary = "/usr/bin/nginx"
+
+// Command executes the NGINX binary. It is the only subprocess started by the
+// controller, so seccomp and AppArmor profiles only need to allow the execution
+// of this binary.
+type Command interface {
+	// ExecCommand returns the command to run NGINX with the current configuration
+	ExecCommand(args ...string) *exec.Cmd
+	// Test checks if config file is a syntax valid NGINX configuration
+	Test(cfg string) ([]byte, error)
+	// Output runs NGINX without configuration, e.g. to read the version
+	Output(args ...string) ([]byte, error)
+}
+
+// BinaryCommand stores context around a given NGINX executable path
+type BinaryCommand struct {
+	Binary string
+}
+
+// NewCommand returns a new BinaryCommand from which path
+// has been detected from environment variable NGINX_BINARY or default
+func NewCommand() BinaryCommand {
+	command := BinaryCommand{
+		Binary: defaultBinary,
+	}
+
+	binary := os.Getenv("NGINX_BINARY")
+	if binary != "" {
+		command.Binary = binary
+	}
+
+	return command
+}
+
+// Exec is the Command used by the functions of this package
+var Exec Command = NewCommand()
+
+// ExecCommand instantiates an exec.Cmd object to call nginx program
+func (nc BinaryCommand) ExecCommand(args ...string) *exec.Cmd {
+	cmdArgs := []string{}
+
+	cmdArgs = append(cmdArgs, "-c", ConfPath)
+	cmdArgs = append(cmdArgs, args...)
+	//nolint:gosec // Ignore G204 error
+	return exec.Command(nc.Binary, cmdArgs...)
+}
+
+// Test checks if config file is a syntax valid nginx configuration
+func (nc BinaryCommand) Test(cfg string) ([]byte, error) {
+	//nolint:gosec // Ignore G204 error
+	return exec.Command(nc.Binary, "-c", cfg, "-t").CombinedOutput()
+}
+
+// Output runs nginx with the arguments and returns the combined output
+func (nc BinaryCommand) Output(args ...string) ([]byte, error) {
+	//nolint:gosec // Ignore G204 error
+	return exec.Command(nc.Binary, args...).CombinedOutput()
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conf parses the NGINX configuration into directives, with the
+// rules of the parser of NGINX for the quotes, escapes, variables and comments.
+package conf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Directive is a directive of the NGINX configuration. Block contains the
+// directives of the block of the directive, like the ones of a server or
+// location. The content of the *_by_lua_block directives is not parsed.
+type Directive struct {
+	Name  string
+	Args  []string
+	Line  int
+	Block []*Directive
+}
+
+// Find returns the directives of the block with the given name. When
+// arguments are given, only the directives with the same arguments are returned.
+func (d *Directive) Find(name string, args ...string) []*Directive {
+	var found []*Directive
+	for _, child := range d.Block {
+		if child.Name != name {
+			continue
+		}
+
+		if len(args) > 0 && !slices.Equal(child.Args, args) {
+			continue
+		}
+
+		found = append(found, child)
+	}
+
+	return found
+}
+
+// Has returns true if the block contains a directive with the given name and arguments
+func (d *Directive) Has(name string, args ...string) bool {
+	return len(d.Find(name, args...)) > 0
+}
+
+// Value returns the arguments of the first directive of the block with
+// the given name joined by a space, or an empty string if there is none
+func (d *Directive) Value(name string) string {
+	found := d.Find(name)
+	if len(found) == 0 {
+		return ""
+	}
+
+	return strings.Join(found[0].Args, " ")
+}
+
+// Variable returns the value set to a variable in the block by a set directive
+func (d *Directive) Variable(name string) (string, bool) {
+	for _, set := range d.Find("set") {
+		if len(set.Args) == 2 && set.Args[0] == "$"+name {
+			return set.Args[1], true
+		}
+	}
+
+	return "", false
+}
+
+// Location returns the location of the block with the given path. The path
+// is the last argument of the location, so it ignores modifiers like ~*.
+func (d *Directive) Location(path string) *Directive {
+	for _, location := range d.Find("location") {
+		if len(location.Args) > 0 && location.Args[len(location.Args)-1] == path {
+			return location
+		}
+	}
+
+	return nil
+}
+
+// String returns the name and the arguments of the directive
+func (d *Directive) String() string {
+	return strings.Join(append([]string{d.Name}, d.Args...), " ")
+}
+
+// SyntaxError is an error of the structure of the configuration
+type SyntaxError struct {
+	Line int
+	Msg  string
+	// Blocks are the blocks open at the line of the error, from the
+	// outermost one. They only contain the directives before the error.
+	Blocks []*Directive
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Msg)
+}
+
+// Parse parses a NGINX configuration into a directive without name
+// containing the directives of the main context. It returns a
+// *SyntaxError when the blocks or the directives are not closed.
+func Parse(data []byte) (*Directive, error) {
+	s := &scanner{data: data, line: 1}
+	root := &Directive{}
+	stack := []*Directive{root}
+	var current *Directive
+
+	fail := func(line int, format string, args ...interface{}) error {
+		return &SyntaxError{
+			Line:   line,
+			Msg:    fmt.Sprintf(format, args...),
+			Blocks: slices.Clone(stack[1:]),
+		}
+	}
+
+	for {
+		tok, err := s.next()
+		if err != nil {
+			return nil, fail(err.Line, "%v", err.Msg)
+		}
+
+		block := stack[len(stack)-1]
+		switch tok.kind {
+		case tokenWord:
+			if current == nil {
+				current = &Directive{Name: tok.text, Line: tok.line}
+			} else {
+				current.Args = append(current.Args, tok.text)
+			}
+		case tokenEnd:
+			if current == nil {
+				return nil, fail(tok.line, `unexpected ";"`)
+			}
+			block.Block = append(block.Block, current)
+			current = nil
+		case tokenOpen:
+			if current == nil {
+				return nil, fail(tok.line, `unexpected "{"`)
+			}
+			block.Block = append(block.Block, current)
+			if strings.HasSuffix(current.Name, "_by_lua_block") {
+				if err := s.skipLua(); err != nil {
+					return nil, fail(err.Line, "%v", err.Msg)
+				}
+			} else {
+				stack = append(stack, current)
+			}
+			current = nil
+		case tokenClose:
+			if current != nil {
+				return nil, fail(tok.line, `unexpected "}", the directive %q is not terminated by ";"`, current.Name)
+			}
+			if len(stack) == 1 {
+				return nil, fail(tok.line, `unexpected "}"`)
+			}
+			stack = stack[:len(stack)-1]
+		case tokenEOF:
+			if current != nil {
+				return nil, fail(tok.line, `unexpected end of file, the directive %q is not terminated by ";"`, current.Name)
+			}
+			if len(stack) > 1 {
+				return nil, fail(tok.line, `unexpected end of file, expecting "}"`)
+			}
+			return root, nil
+		}
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conf
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const testConf = `
+# comment
+worker_processes 2;
+
+http {
+    map $http_upgrade $connection_upgrade {
+        default upgrade;
+        '' close;
+    }
+
+    server {
+        server_name foo.bar "*.foo.bar";
+        listen 80;
+
+        location ~* "^/api/(v1|v2)" {
+            set $namespace "default";
+            set $ingress_name 'api';
+            rewrite_by_lua_block {
+                local s = "}"
+                -- }
+                local t = [==[ } ]==]
+                if true then local x = { a = 1 } end
+            }
+            proxy_set_header X-Forwarded-Prefix ${proxy_prefix}\;;
+        }
+
+        location / {
+            return 200 "a \"quoted\" value;";
+        }
+    }
+}
+`
+
+func TestParse(t *testing.T) {
+	root, err := Parse([]byte(testConf))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v := root.Value("worker_processes"); v != "2" {
+		t.Errorf("expected worker_processes 2 but got %q", v)
+	}
+
+	http := root.Find("http")
+	if len(http) != 1 {
+		t.Fatalf("expected one http block but got %v", len(http))
+	}
+
+	if !http[0].Has("map", "$http_upgrade", "$connection_upgrade") {
+		t.Errorf("expected the map of $connection_upgrade")
+	}
+	if !http[0].Find("map")[0].Has("", "close") {
+		t.Errorf("expected the quoted empty key of the map")
+	}
+
+	servers := http[0].Find("server")
+	if len(servers) != 1 {
+		t.Fatalf("expected one server but got %v", len(servers))
+	}
+	server := servers[0]
+
+	if !reflect.DeepEqual(server.Find("server_name")[0].Args, []string{"foo.bar", "*.foo.bar"}) {
+		t.Errorf("unexpected server_name %v", server.Find("server_name")[0].Args)
+	}
+	if server.Line != 11 {
+		t.Errorf("expected the server at the line 11 but got %v", server.Line)
+	}
+
+	api := server.Location("^/api/(v1|v2)")
+	if api == nil {
+		t.Fatalf("expected the location ^/api/(v1|v2)")
+	}
+	if api.String() != "location ~* ^/api/(v1|v2)" {
+		t.Errorf("unexpected location %q", api.String())
+	}
+	if v, ok := api.Variable("ingress_name"); !ok || v != "api" {
+		t.Errorf("expected the variable ingress_name api but got %q", v)
+	}
+	if _, ok := api.Variable("service_name"); ok {
+		t.Errorf("unexpected variable service_name")
+	}
+
+	lua := api.Find("rewrite_by_lua_block")
+	if len(lua) != 1 || len(lua[0].Block) != 0 {
+		t.Errorf("expected the Lua block without directives but got %v", lua)
+	}
+	if v := api.Value("proxy_set_header"); v != `X-Forwarded-Prefix ${proxy_prefix}\;` {
+		t.Errorf("unexpected proxy_set_header %q", v)
+	}
+	if api.Find("proxy_set_header")[0].Line != 24 {
+		t.Errorf("expected proxy_set_header at the line 24 but got %v", api.Find("proxy_set_header")[0].Line)
+	}
+
+	root2 := server.Location("/")
+	if root2 == nil {
+		t.Fatalf("expected the location /")
+	}
+	if v := root2.Value("return"); v != `200 a "quoted" value;` {
+		t.Errorf("unexpected return %q", v)
+	}
+	if server.Location("/missing") != nil {
+		t.Errorf("unexpected location /missing")
+	}
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	tests := []struct {
+		name   string
+		conf   string
+		line   int
+		msg    string
+		blocks []string
+	}{
+		{
+			name:   "directive not terminated",
+			conf:   "http {\n  server {\n    listen 80\n  }\n}\n",
+			line:   4,
+			msg:    `unexpected "}", the directive "listen" is not terminated by ";"`,
+			blocks: []string{"http", "server"},
+		},
+		{
+			name:   "unclosed block",
+			conf:   "http {\n  server {\n  }\n",
+			line:   4,
+			msg:    `unexpected end of file, expecting "}"`,
+			blocks: []string{"http"},
+		},
+		{
+			name: "unexpected brace",
+			conf: "events {}\n}\n",
+			line: 2,
+			msg:  `unexpected "}"`,
+		},
+		{
+			name:   "unexpected semicolon",
+			conf:   "events {\n  ;\n}\n",
+			line:   2,
+			msg:    `unexpected ";"`,
+			blocks: []string{"events"},
+		},
+		{
+			name:   "unterminated string",
+			conf:   "http {\n  return 200 \"foo;\n}\n",
+			line:   2,
+			msg:    "unterminated string",
+			blocks: []string{"http"},
+		},
+		{
+			name:   "unterminated Lua block",
+			conf:   "http {\n  init_by_lua_block {\n    local t = {\n  }\n",
+			line:   2,
+			msg:    "unterminated Lua block",
+			blocks: []string{"http"},
+		},
+		{
+			name:   "unterminated Lua long string",
+			conf:   "http {\n  init_by_lua_block {\n    local a = [[ }\n  }\n}\n",
+			line:   3,
+			msg:    "unterminated Lua long string or comment",
+			blocks: []string{"http"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.conf))
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a syntax error but got %v", err)
+			}
+			if syntaxErr.Line != tc.line || syntaxErr.Msg != tc.msg {
+				t.Errorf("expected %q at the line %v but got %q at the line %v", tc.msg, tc.line, syntaxErr.Msg, syntaxErr.Line)
+			}
+
+			var blocks []string
+			for _, block := range syntaxErr.Blocks {
+				blocks = append(blocks, block.Name)
+			}
+			if !reflect.DeepEqual(blocks, tc.blocks) {
+				t.Errorf("expected the open blocks %v but got %v", tc.blocks, blocks)
+			}
+		})
+	}
+}
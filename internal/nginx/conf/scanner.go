@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conf
+
+import (
+	"bytes"
+	"strings"
+)
+
+// tokenKind is the kind of a token of the NGINX configuration
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenOpen
+	tokenClose
+	tokenEnd
+	tokenEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// scanner splits the NGINX configuration in tokens. Quoted words are
+// returned without the quotes.
+type scanner struct {
+	data []byte
+	pos  int
+	line int
+}
+
+func (s *scanner) next() (token, *SyntaxError) {
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		switch {
+		case c == '\n':
+			s.line++
+			s.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			s.pos++
+		case c == '#':
+			for s.pos < len(s.data) && s.data[s.pos] != '\n' {
+				s.pos++
+			}
+		case c == '{':
+			s.pos++
+			return token{kind: tokenOpen, text: "{", line: s.line}, nil
+		case c == '}':
+			s.pos++
+			return token{kind: tokenClose, text: "}", line: s.line}, nil
+		case c == ';':
+			s.pos++
+			return token{kind: tokenEnd, text: ";", line: s.line}, nil
+		case c == '"' || c == '\'':
+			return s.quoted(c)
+		default:
+			return s.word(), nil
+		}
+	}
+
+	return token{kind: tokenEOF, line: s.line}, nil
+}
+
+func (s *scanner) quoted(quote byte) (token, *SyntaxError) {
+	line := s.line
+	var b strings.Builder
+	for s.pos++; s.pos < len(s.data); s.pos++ {
+		c := s.data[s.pos]
+		switch {
+		case c == '\\' && s.pos+1 < len(s.data):
+			s.pos++
+			b.WriteByte(s.data[s.pos])
+			if s.data[s.pos] == '\n' {
+				s.line++
+			}
+		case c == quote:
+			s.pos++
+			return token{kind: tokenWord, text: b.String(), line: line}, nil
+		default:
+			if c == '\n' {
+				s.line++
+			}
+			b.WriteByte(c)
+		}
+	}
+
+	return token{}, &SyntaxError{Line: line, Msg: "unterminated string"}
+}
+
+func (s *scanner) word() token {
+	start, line := s.pos, s.line
+	variable := false
+	for ; s.pos < len(s.data); s.pos++ {
+		c := s.data[s.pos]
+		if c == '{' && variable {
+			// ${name}
+			continue
+		}
+		variable = c == '$'
+		if c == '\\' && s.pos+1 < len(s.data) {
+			s.pos++
+			continue
+		}
+		if c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ';' || c == '{' {
+			break
+		}
+	}
+
+	return token{kind: tokenWord, text: string(s.data[start:s.pos]), line: line}
+}
+
+// skipLua skips the Lua code of a *_by_lua_block up to its closing brace,
+// with the strings, long brackets and comments of Lua
+func (s *scanner) skipLua() *SyntaxError {
+	line := s.line
+	depth := 0
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		switch {
+		case c == '\n':
+			s.line++
+			s.pos++
+		case c == '"' || c == '\'':
+			if err := s.skipLuaString(c); err != nil {
+				return err
+			}
+		case c == '[' && s.longBracket() >= 0:
+			if err := s.skipLongBracket(); err != nil {
+				return err
+			}
+		case c == '-' && bytes.HasPrefix(s.data[s.pos:], []byte("--")):
+			s.pos += 2
+			if s.pos < len(s.data) && s.data[s.pos] == '[' && s.longBracket() >= 0 {
+				if err := s.skipLongBracket(); err != nil {
+					return err
+				}
+				continue
+			}
+			for s.pos < len(s.data) && s.data[s.pos] != '\n' {
+				s.pos++
+			}
+		case c == '{':
+			depth++
+			s.pos++
+		case c == '}':
+			s.pos++
+			if depth == 0 {
+				return nil
+			}
+			depth--
+		default:
+			s.pos++
+		}
+	}
+
+	return &SyntaxError{Line: line, Msg: "unterminated Lua block"}
+}
+
+func (s *scanner) skipLuaString(quote byte) *SyntaxError {
+	line := s.line
+	for s.pos++; s.pos < len(s.data); s.pos++ {
+		switch s.data[s.pos] {
+		case '\\':
+			s.pos++
+			if s.pos < len(s.data) && s.data[s.pos] == '\n' {
+				s.line++
+			}
+		case '\n':
+			return &SyntaxError{Line: line, Msg: "unterminated Lua string"}
+		case quote:
+			s.pos++
+			return nil
+		}
+	}
+
+	return &SyntaxError{Line: line, Msg: "unterminated Lua string"}
+}
+
+// longBracket returns the level of the Lua long bracket at the position,
+// e.g. 0 for [[ and 2 for [==[, or -1 when there is none
+func (s *scanner) longBracket() int {
+	level := 0
+	for i := s.pos + 1; i < len(s.data); i++ {
+		switch s.data[i] {
+		case '=':
+			level++
+		case '[':
+			return level
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+func (s *scanner) skipLongBracket() *SyntaxError {
+	line := s.line
+	level := s.longBracket()
+	closing := []byte("]" + strings.Repeat("=", level) + "]")
+	s.pos += level + 2
+
+	end := bytes.Index(s.data[s.pos:], closing)
+	if end < 0 {
+		return &SyntaxError{Line: line, Msg: "unterminated Lua long string or comment"}
+	}
+	s.line += bytes.Count(s.data[s.pos:s.pos+end], []byte("\n"))
+	s.pos += end + len(closing)
+
+	return nil
+}
@@ -23,7 +23,6 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
 	"strings"
 	"time"
 
@@ -82,15 +81,27 @@ func NewGetStatusRequest(path string) (statusCode int, data []byte, err error) {
 
 // NewPostStatusRequest creates a new POST request to the internal NGINX status server
 func NewPostStatusRequest(path, contentType string, data interface{}) (statusCode int, body []byte, err error) {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return NewRawPostStatusRequest(path, http.Header{"Content-Type": []string{contentType}}, buf)
+}
+
+// NewRawPostStatusRequest creates a new POST request with the given
+// headers and body to the internal NGINX status server
+func NewRawPostStatusRequest(path string, header http.Header, data []byte) (statusCode int, body []byte, err error) {
 	url := fmt.Sprintf("http://127.0.0.1:%v%v", StatusPort, path)
 
-	buf, err := json.Marshal(data)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
 	if err != nil {
 		return 0, nil, err
 	}
+	req.Header = header
 
 	client := http.Client{}
-	res, err := client.Post(url, contentType, bytes.NewReader(buf))
+	res, err := client.Do(req)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -125,7 +136,7 @@ func GetServerBlock(conf, host string) (string, error) {
 
 // ReadNginxConf reads the nginx configuration file into a string
 func ReadNginxConf() (string, error) {
-	return readFileToString("/etc/nginx/nginx.conf")
+	return readFileToString(ConfPath)
 }
 
 // readFileToString reads any file into a string
@@ -151,8 +162,7 @@ func Version() string {
 		flag = "-V"
 	}
 
-	cmd := exec.Command("nginx", flag)
-	out, err := cmd.CombinedOutput()
+	out, err := Exec.Output(flag)
 	if err != nil {
 		klog.ErrorS(err, "unexpected error obtaining NGINX version")
 		return "N/A"
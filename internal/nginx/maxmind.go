@@ -56,7 +56,6 @@ var MaxmindRetriesTimeout = time.Second * 0
 const minimumRetriesCount = 1
 
 const (
-	geoIPPath   = "/etc/ingress-controller/geoip"
 	dbExtension = ".mmdb"
 
 	maxmindURL = "https://download.maxmind.com/app/geoip_download?license_key=%v&edition_id=%v&suffix=tar.gz"
@@ -70,7 +69,7 @@ func GeoLite2DBExists() bool {
 	files := []string{}
 	for _, dbName := range strings.Split(MaxmindEditionIDs, ",") {
 		filename := dbName + dbExtension
-		if !fileExists(path.Join(geoIPPath, filename)) {
+		if !fileExists(path.Join(GeoIPDirectory, filename)) {
 			klog.Error(filename, " not found")
 			return false
 		}
@@ -180,7 +179,7 @@ func downloadDatabase(dbName string) error {
 				continue
 			}
 			return func() error {
-				outFile, err := os.Create(path.Join(geoIPPath, mmdbFile))
+				outFile, err := os.Create(path.Join(GeoIPDirectory, mmdbFile))
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginx
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// procDirectory is the mount point of procfs
+var procDirectory = "/proc"
+
+// FindProcesses returns the PIDs of the processes with a command line containing
+// pattern, like "pgrep -f", without the current process
+func FindProcesses(pattern string) ([]int, error) {
+	entries, err := os.ReadDir(procDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	self := os.Getpid()
+	pids := []int{}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == self {
+			continue
+		}
+
+		// the process may have terminated after reading the directory
+		cmdline, err := os.ReadFile(filepath.Join(procDirectory, entry.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+
+		args := strings.TrimSpace(string(bytes.ReplaceAll(cmdline, []byte{0}, []byte{' '})))
+		if strings.Contains(args, pattern) {
+			pids = append(pids, pid)
+		}
+	}
+
+	return pids, nil
+}
+
+// WorkerProcesses returns the number of running NGINX worker processes
+func WorkerProcesses() (int, error) {
+	pids, err := FindProcesses("nginx: worker process")
+	if err != nil {
+		return 0, err
+	}
+
+	return len(pids), nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProcesses(t *testing.T) {
+	dir := t.TempDir()
+	processes := map[string]string{
+		"1":  "/usr/bin/dumb-init\x00--\x00/nginx-ingress-controller\x00--v=2\x00",
+		"20": "/nginx-ingress-controller\x00--v=2\x00",
+		"30": "nginx: master process /usr/bin/nginx -c /etc/nginx/nginx.conf\x00",
+		"31": "nginx: worker process\x00\x00\x00\x00",
+		"32": "nginx: worker process\x00\x00\x00\x00",
+	}
+	for pid, cmdline := range processes {
+		if err := os.MkdirAll(filepath.Join(dir, pid), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, pid, "cmdline"), []byte(cmdline), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "sys"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(dir string) { procDirectory = dir }(procDirectory)
+	procDirectory = dir
+
+	pids, err := FindProcesses("nginx-ingress-controller")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pids) != 2 {
+		t.Errorf("expected 2 processes but got %v", pids)
+	}
+
+	workers, err := WorkerProcesses()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if workers != 2 {
+		t.Errorf("expected 2 workers but got %v", workers)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginx
+
+import (
+	"path/filepath"
+
+	"k8s.io/ingress-nginx/pkg/util/file"
+)
+
+// ConfPath is the path of the configuration file of NGINX
+var ConfPath = "/etc/nginx/nginx.conf"
+
+// LuaConfigPath is the path of the configuration of the Lua modules, read when NGINX starts
+var LuaConfigPath = "/etc/nginx/lua/cfg.json"
+
+// TempDirectory contains the temporary and cache directories, the PID file and the sockets of NGINX
+var TempDirectory = "/tmp/nginx"
+
+// MetricsSocket is the unix socket receiving the metrics of the requests from Lua
+var MetricsSocket = "/tmp/nginx/prometheus-nginx.socket"
+
+// ErrorLogSocket is the unix socket receiving the entries of the error log of NGINX with the syslog protocol
+var ErrorLogSocket = "/tmp/nginx/error-log.socket"
+
+// GeoIPDirectory contains the GeoIP2 databases downloaded from MaxMind
+var GeoIPDirectory = "/etc/ingress-controller/geoip"
+
+// DefaultBackendAssetsDirectory contains the pages and assets of the internal default backend
+var DefaultBackendAssetsDirectory = "/etc/ingress-controller/default-backend"
+
+// SSLSessionTicketKeyPath is the path of the key encrypting the TLS session tickets
+var SSLSessionTicketKeyPath = "/etc/ingress-controller/tickets.key"
+
+// ConfigHistoryDirectory contains the last generations of nginx.conf when the configuration history is enabled
+var ConfigHistoryDirectory = "/etc/ingress-controller/config-history"
+
+// OpentelemetryConfigPath is the default path of the configuration of the OpenTelemetry module
+var OpentelemetryConfigPath = "/etc/ingress-controller/telemetry/opentelemetry.toml"
+
+// SetRuntimeDirectory relocates the files and directories written at runtime under dir,
+// for the controller to run with a read-only root filesystem and dir in a writable volume.
+// It must be called before the controller starts.
+func SetRuntimeDirectory(dir string) {
+	ConfPath = filepath.Join(dir, "nginx.conf")
+	LuaConfigPath = filepath.Join(dir, "lua-cfg.json")
+	TempDirectory = filepath.Join(dir, "tmp")
+	PID = filepath.Join(TempDirectory, "nginx.pid")
+	MetricsSocket = filepath.Join(TempDirectory, "prometheus-nginx.socket")
+	ErrorLogSocket = filepath.Join(TempDirectory, "error-log.socket")
+	GeoIPDirectory = filepath.Join(dir, "geoip")
+	DefaultBackendAssetsDirectory = filepath.Join(dir, "default-backend")
+	SSLSessionTicketKeyPath = filepath.Join(dir, "tickets.key")
+	ConfigHistoryDirectory = filepath.Join(dir, "config-history")
+	OpentelemetryConfigPath = filepath.Join(dir, "telemetry", "opentelemetry.toml")
+
+	file.DefaultSSLDirectory = filepath.Join(dir, "ssl")
+	file.AuthDirectory = filepath.Join(dir, "auth")
+	file.RequireDirectories(TempDirectory, GeoIPDirectory, DefaultBackendAssetsDirectory, filepath.Dir(OpentelemetryConfigPath))
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginx
+
+import (
+	"testing"
+
+	"k8s.io/ingress-nginx/pkg/util/file"
+)
+
+func TestSetRuntimeDirectory(t *testing.T) {
+	vars := []*string{
+		&ConfPath, &LuaConfigPath, &TempDirectory, &PID, &MetricsSocket, &ErrorLogSocket,
+		&GeoIPDirectory, &DefaultBackendAssetsDirectory, &SSLSessionTicketKeyPath, &OpentelemetryConfigPath,
+		&file.DefaultSSLDirectory, &file.AuthDirectory,
+	}
+	defaults := make([]string, len(vars))
+	for i, v := range vars {
+		defaults[i] = *v
+	}
+	defer func() {
+		for i, v := range vars {
+			*v = defaults[i]
+		}
+	}()
+
+	SetRuntimeDirectory("/run/ingress-nginx")
+
+	expected := []string{
+		"/run/ingress-nginx/nginx.conf",
+		"/run/ingress-nginx/lua-cfg.json",
+		"/run/ingress-nginx/tmp",
+		"/run/ingress-nginx/tmp/nginx.pid",
+		"/run/ingress-nginx/tmp/prometheus-nginx.socket",
+		"/run/ingress-nginx/tmp/error-log.socket",
+		"/run/ingress-nginx/geoip",
+		"/run/ingress-nginx/default-backend",
+		"/run/ingress-nginx/tickets.key",
+		"/run/ingress-nginx/telemetry/opentelemetry.toml",
+		"/run/ingress-nginx/ssl",
+		"/run/ingress-nginx/auth",
+	}
+	for i, v := range vars {
+		if *v != expected[i] {
+			t.Errorf("expected %v but returned %v", expected[i], *v)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginx
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	klog "k8s.io/klog/v2"
+)
+
+// TLSLibrary describes the TLS library NGINX is linked with
+type TLSLibrary struct {
+	// Name of the library, like OpenSSL, LibreSSL or BoringSSL
+	Name string
+	// Version of the library, like 3.0.13
+	Version string
+	// FIPS is true when a FIPS validated module is available
+	FIPS bool
+}
+
+var (
+	tlsLibraryOnce  sync.Once
+	tlsLibrary      TLSLibrary
+	tlsLibraryRegex = regexp.MustCompile(`(?m)^built with (OpenSSL|LibreSSL|BoringSSL) ?(\S*)(?:.*\(running with \S+ (\S+))?`)
+
+	// fipsModulePaths are the locations of the FIPS provider of OpenSSL 3
+	fipsModulePaths = []string{
+		"/usr/lib/ossl-modules/fips.so",
+		"/usr/lib64/ossl-modules/fips.so",
+		"/usr/local/lib/ossl-modules/fips.so",
+		"/usr/local/lib64/ossl-modules/fips.so",
+	}
+)
+
+// GetTLSLibrary returns the TLS library NGINX is linked with, as reported by "nginx -V"
+func GetTLSLibrary() TLSLibrary {
+	tlsLibraryOnce.Do(func() {
+		out, err := Exec.Output("-V")
+		if err != nil {
+			klog.ErrorS(err, "unexpected error obtaining NGINX build information")
+			return
+		}
+
+		modules := fipsModulePaths
+		if dir := os.Getenv("OPENSSL_MODULES"); dir != "" {
+			modules = append([]string{filepath.Join(dir, "fips.so")}, modules...)
+		}
+
+		tlsLibrary = parseTLSLibrary(string(out), modules)
+	})
+
+	return tlsLibrary
+}
+
+func parseTLSLibrary(out string, fipsModules []string) TLSLibrary {
+	m := tlsLibraryRegex.FindStringSubmatch(out)
+	if m == nil {
+		return TLSLibrary{}
+	}
+
+	lib := TLSLibrary{Name: m[1], Version: m[2]}
+	// the library loaded at runtime can be newer than the one used to build NGINX
+	if m[3] != "" {
+		lib.Version = m[3]
+	}
+
+	lib.FIPS = strings.Contains(strings.ToLower(lib.Version), "fips")
+	for _, module := range fipsModules {
+		if _, err := os.Stat(module); err == nil {
+			lib.FIPS = true
+		}
+	}
+
+	return lib
+}
+
+// AtLeast returns true if the version of the library is equal or newer than
+// the given major, minor and patch numbers. Letters after the patch number,
+// like the 1.1.1w releases of OpenSSL, are ignored.
+func (l TLSLibrary) AtLeast(major, minor, patch int) bool {
+	want := []int{major, minor, patch}
+
+	parts := strings.SplitN(l.Version, ".", 3)
+	for i, part := range parts {
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+
+		n, err := strconv.Atoi(part[:end])
+		if err != nil {
+			return false
+		}
+
+		if n != want[i] {
+			return n > want[i]
+		}
+	}
+
+	return len(parts) == len(want)
+}
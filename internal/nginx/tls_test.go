@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseTLSLibrary(t *testing.T) {
+	module := filepath.Join(t.TempDir(), "fips.so")
+	if err := os.WriteFile(module, []byte{}, 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		out      string
+		modules  []string
+		expected TLSLibrary
+	}{
+		{
+			"openssl",
+			"nginx version: nginx/1.25.5\nbuilt with OpenSSL 3.0.13 30 Jan 2024\nTLS SNI support enabled\n",
+			nil,
+			TLSLibrary{Name: "OpenSSL", Version: "3.0.13"},
+		},
+		{
+			"running with a newer openssl",
+			"built with OpenSSL 1.1.1w  11 Sep 2023 (running with OpenSSL 3.0.15 3 Sep 2024)\n",
+			nil,
+			TLSLibrary{Name: "OpenSSL", Version: "3.0.15"},
+		},
+		{
+			"fips version",
+			"built with OpenSSL 3.0.7-fips 1 Nov 2022\n",
+			nil,
+			TLSLibrary{Name: "OpenSSL", Version: "3.0.7-fips", FIPS: true},
+		},
+		{
+			"fips provider",
+			"built with OpenSSL 3.0.13 30 Jan 2024\n",
+			[]string{"/nonexistent/fips.so", module},
+			TLSLibrary{Name: "OpenSSL", Version: "3.0.13", FIPS: true},
+		},
+		{
+			"unknown",
+			"nginx version: nginx/1.25.5\n",
+			nil,
+			TLSLibrary{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if lib := parseTLSLibrary(tc.out, tc.modules); lib != tc.expected {
+				t.Errorf("expected %+v but returned %+v", tc.expected, lib)
+			}
+		})
+	}
+}
+
+func TestTLSLibraryAtLeast(t *testing.T) {
+	testCases := []struct {
+		version  string
+		expected bool
+	}{
+		{"3.0.13", true},
+		{"1.1.1w", true},
+		{"1.1.1", true},
+		{"1.1.0l", false},
+		{"1.0.2u", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		if atLeast := (TLSLibrary{Version: tc.version}).AtLeast(1, 1, 1); atLeast != tc.expected {
+			t.Errorf("%v: expected %v but returned %v", tc.version, tc.expected, atLeast)
+		}
+	}
+}
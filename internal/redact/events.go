@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// eventRecorder redacts the messages of the events
+type eventRecorder struct {
+	record.EventRecorder
+}
+
+// NewEventRecorder returns a recorder redacting the messages of the events sent to the given one
+func NewEventRecorder(recorder record.EventRecorder) record.EventRecorder {
+	return &eventRecorder{recorder}
+}
+
+// Event implements record.EventRecorder
+func (r *eventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(object, eventtype, reason, String(message))
+}
+
+// Eventf implements record.EventRecorder
+func (r *eventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.Event(object, eventtype, reason, String(fmt.Sprintf(messageFmt, args...)))
+}
+
+// AnnotatedEventf implements record.EventRecorder
+func (r *eventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", String(fmt.Sprintf(messageFmt, args...)))
+}
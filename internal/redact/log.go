@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+// LogFilter redacts the messages and values logged with klog
+type LogFilter struct{}
+
+var _ klog.LogFilter = LogFilter{}
+
+// InstallLogFilter redacts the output of klog
+func InstallLogFilter() {
+	klog.SetLogFilter(LogFilter{})
+}
+
+// arg returns the value formatted as a redacted string, or the value itself
+// when there is nothing to redact to keep the formatting of klog
+func arg(v interface{}) interface{} {
+	if v == nil {
+		return v
+	}
+
+	s := fmt.Sprint(v)
+	if r := String(s); r != s {
+		return r
+	}
+
+	return v
+}
+
+// Filter implements klog.LogFilter
+func (LogFilter) Filter(args []interface{}) []interface{} {
+	for i := range args {
+		args[i] = arg(args[i])
+	}
+
+	return args
+}
+
+// FilterF implements klog.LogFilter
+func (LogFilter) FilterF(format string, args []interface{}) (string, []interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if r := String(msg); r != msg {
+		return "%s", []interface{}{r}
+	}
+
+	return format, args
+}
+
+// FilterS implements klog.LogFilter
+func (LogFilter) FilterS(msg string, keysAndValues []interface{}) (string, []interface{}) {
+	// the keys are not checked, as "secret" usually names the key of a Secret and not its content
+	for i := 1; i < len(keysAndValues); i += 2 {
+		keysAndValues[i] = arg(keysAndValues[i])
+	}
+
+	return String(msg), keysAndValues
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+func TestLogFilter(t *testing.T) {
+	fs := flag.NewFlagSet("", flag.PanicOnError)
+	klog.InitFlags(fs)
+	if err := fs.Set("logtostderr", "false"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	klog.SetOutput(&buf)
+	InstallLogFilter()
+	defer func() {
+		klog.SetLogFilter(nil)
+		klog.SetOutput(os.Stderr)
+		_ = fs.Set("logtostderr", "true")
+	}()
+
+	RegisterSecret("default/canary", map[string][]byte{"api-token": []byte(canary), "auth": []byte("user:" + canary)})
+	defer Unregister("default/canary")
+
+	klog.Info("unexpected secret ", canary)
+	klog.Infof("unexpected secret %v in %v", canary, "default/canary")
+	klog.Warningf("unexpected key %s", privateKey)
+	klog.InfoS("NGINX configuration change", "diff", "+ user:"+canary, "error", errors.New(htpasswd))
+	// the error of ErrorS is not filtered by klog, so the secrets are logged as values
+	klog.ErrorS(nil, "Error reading secret", "secret", "default/canary", "content", "user:"+canary)
+	klog.Flush()
+
+	logs := buf.String()
+	for _, seeded := range []string{canary, "MHcCAQEEIIrYSSNQ", "OFG3Xybp", "C+7Hteo"} {
+		if strings.Contains(logs, seeded) {
+			t.Errorf("expected %q to be redacted from the logs:\n%v", seeded, logs)
+		}
+	}
+
+	if !strings.Contains(logs, "default/canary") {
+		t.Errorf("expected the name of the secret to be logged:\n%v", logs)
+	}
+}
+
+func TestEventRecorder(t *testing.T) {
+	RegisterSecret("default/canary", map[string][]byte{"tls.key": []byte(canary)})
+	defer Unregister("default/canary")
+
+	fake := record.NewFakeRecorder(3)
+	recorder := NewEventRecorder(fake)
+
+	pod := &apiv1.Pod{}
+	recorder.Event(pod, apiv1.EventTypeWarning, "RELOAD", "Error reloading NGINX: "+canary)
+	recorder.Eventf(pod, apiv1.EventTypeWarning, "RELOAD", "Error reloading NGINX: %v", privateKey)
+	recorder.AnnotatedEventf(pod, nil, apiv1.EventTypeNormal, "Sync", "Secret %v with %v", "default/canary", canary)
+	close(fake.Events)
+
+	for event := range fake.Events {
+		if strings.Contains(event, canary) || strings.Contains(event, "MHcCAQEEIIrYSSNQ") {
+			t.Errorf("expected the event message to be redacted: %v", event)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redact removes private keys, credentials and the values of
+// Secrets and sensitive ConfigMap keys from the logs, events and
+// support bundles of the controller.
+package redact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the sensitive values
+const Redacted = "REDACTED"
+
+// minValueLength is the shortest registered value replaced. Shorter values
+// like "true" or "80" would redact unrelated parts of the messages.
+const minValueLength = 8
+
+var (
+	privateKeyRegex = regexp.MustCompile(`(?s)-----BEGIN ([A-Z ]*)PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)
+
+	// credentialRegex matches the credentials of an Authorization header
+	credentialRegex = regexp.MustCompile(`(?i)(authorization\W{0,3}(?:basic|bearer|digest)\s+)[A-Za-z0-9._~+/=-]{8,}`)
+
+	// passwordHashRegex matches the password hashes of htpasswd files
+	passwordHashRegex = regexp.MustCompile(`(\$(?:apr1|2[abxy]?|1|5|6)\$|\{SHA\}|\{SSHA\})[./0-9A-Za-z$+=]{8,}`)
+
+	// sensitiveNameRegex matches the names of flags, keys and fields with sensitive values
+	sensitiveNameRegex = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|authorization|key)$`)
+)
+
+// sensitiveSecretKeys are the keys of the Secrets used by the controller
+// whose values are registered besides the ones with sensitive names
+var sensitiveSecretKeys = map[string]bool{
+	"tls.key": true,
+	"auth":    true,
+	"ca.key":  true,
+}
+
+// IsSensitive returns true if the value of a flag, key or field with the given name should not be exposed
+func IsSensitive(name string) bool {
+	return sensitiveNameRegex.MatchString(strings.NewReplacer("-", "", "_", "", ".", "").Replace(name))
+}
+
+// registry keeps the values of the Secrets and ConfigMaps to redact
+type registry struct {
+	mu     sync.RWMutex
+	values map[string][]string
+	// dirty is set when the values changed after the replacer was built,
+	// so many updates, like the sync of all the Secrets, build it once
+	dirty    bool
+	replacer *strings.Replacer
+}
+
+var values = &registry{
+	values: map[string][]string{},
+}
+
+// Register replaces the values to redact of the given owner, like the key of a Secret
+func Register(owner string, vals ...string) {
+	var filtered []string
+	for _, v := range vals {
+		// each line of a file, like an htpasswd entry, can be logged on its own
+		for _, line := range strings.Split(v, "\n") {
+			line = strings.TrimSpace(line)
+			if len(line) >= minValueLength {
+				filtered = append(filtered, line)
+			}
+		}
+		if v = strings.TrimSpace(v); len(v) >= minValueLength && strings.Contains(v, "\n") {
+			filtered = append(filtered, v)
+		}
+	}
+
+	values.mu.Lock()
+	defer values.mu.Unlock()
+
+	if len(filtered) == 0 {
+		if _, ok := values.values[owner]; !ok {
+			return
+		}
+		delete(values.values, owner)
+	} else {
+		values.values[owner] = filtered
+	}
+	values.dirty = true
+}
+
+// Unregister removes the values to redact of the given owner
+func Unregister(owner string) {
+	Register(owner)
+}
+
+// RegisterSecret registers the values of the keys of a Secret used as certificates,
+// keys or basic authentication files, and the ones with sensitive names
+func RegisterSecret(key string, data map[string][]byte) {
+	var vals []string
+	for k, v := range data {
+		if sensitiveSecretKeys[k] || IsSensitive(k) {
+			vals = append(vals, string(v))
+		}
+	}
+
+	Register(key, vals...)
+}
+
+// RegisterConfigMap registers the values of the keys of a ConfigMap with sensitive names
+func RegisterConfigMap(key string, data map[string]string) {
+	var vals []string
+	for k, v := range data {
+		if IsSensitive(k) {
+			vals = append(vals, v)
+		}
+	}
+
+	Register(key, vals...)
+}
+
+// currentReplacer returns the replacer of the registered values, built again
+// when they changed
+func (r *registry) currentReplacer() *strings.Replacer {
+	r.mu.RLock()
+	replacer, dirty := r.replacer, r.dirty
+	r.mu.RUnlock()
+
+	if !dirty {
+		return replacer
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.dirty {
+		r.rebuild()
+		r.dirty = false
+	}
+
+	return r.replacer
+}
+
+// rebuild creates the replacer of the registered values. The longest values are
+// replaced first, so a value containing another one is redacted completely.
+func (r *registry) rebuild() {
+	var all []string
+	for _, vals := range r.values {
+		all = append(all, vals...)
+	}
+
+	if len(all) == 0 {
+		r.replacer = nil
+		return
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return len(all[i]) > len(all[j])
+	})
+
+	oldnew := make([]string, 0, len(all)*2)
+	for _, v := range all {
+		oldnew = append(oldnew, v, Redacted)
+	}
+	r.replacer = strings.NewReplacer(oldnew...)
+}
+
+// String removes the private keys, credentials, password hashes and registered values of a text
+func String(s string) string {
+	if replacer := values.currentReplacer(); replacer != nil {
+		s = replacer.Replace(s)
+	}
+
+	s = privateKeyRegex.ReplaceAllString(s, "-----BEGIN ${1}PRIVATE KEY-----\n"+Redacted+"\n-----END ${1}PRIVATE KEY-----")
+	s = credentialRegex.ReplaceAllString(s, "${1}"+Redacted)
+
+	return passwordHashRegex.ReplaceAllString(s, "${1}"+Redacted)
+}
+
+// Value returns the value redacted, completely if the name is sensitive
+func Value(name, value string) string {
+	if value != "" && IsSensitive(name) {
+		return Redacted
+	}
+
+	return String(value)
+}
@@ -23,12 +23,20 @@ import (
 
 	"k8s.io/ingress-nginx/internal/ingress/annotations"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/auth"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/authldap"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/authreq"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/authtls"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/compression"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/connection"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/cors"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/customheaders"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/extralistenports"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/fastcgi"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/headerlimits"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/headersfromvariables"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/http2"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/httptransform"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/internallocations"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/ipallowlist"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/ipdenylist"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/log"
@@ -37,9 +45,14 @@ import (
 	"k8s.io/ingress-nginx/internal/ingress/annotations/opentelemetry"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/proxy"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/proxyssl"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/ranges"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/ratelimit"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/realip"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/redirect"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/rewrite"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/routeif"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/subfilter"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/uploadguard"
 )
 
 // TODO: The API shouldn't be importing structs from annotation code. Instead we probably want a conversion from internal
@@ -73,15 +86,26 @@ type Configuration struct {
 	DefaultSSLCertificate *SSLCert `json:"-"`
 
 	StreamSnippets []string `json:"StreamSnippets"`
+
+	// CDNRanges contains the IP ranges downloaded for the cdn-provider
+	// +optional
+	CDNRanges []string `json:"cdnRanges,omitempty"`
+
+	// ErrorLogLevel overrides the level of the error log of NGINX while debugging
+	// +optional
+	ErrorLogLevel string `json:"errorLogLevel,omitempty"`
 }
 
 // Backend describes one or more remote server/s (endpoints) associated with a service
 // +k8s:deepcopy-gen=true
 type Backend struct {
 	// Name represents an unique apiv1.Service name formatted as <namespace>-<name>-<port>
-	Name    string             `json:"name"`
-	Service *apiv1.Service     `json:"service,omitempty"`
-	Port    intstr.IntOrString `json:"port"`
+	Name string `json:"name"`
+	// ReadableName is the name of the backend when Name is a hash of it, with hash-upstream-names
+	// +optional
+	ReadableName string             `json:"readableName,omitempty"`
+	Service      *apiv1.Service     `json:"service,omitempty"`
+	Port         intstr.IntOrString `json:"port"`
 	// SSLPassthrough indicates that Ingress controller will delegate TLS termination to the endpoints.
 	SSLPassthrough bool `json:"sslPassthrough"`
 	// Endpoints contains the list of endpoints currently running
@@ -198,6 +222,8 @@ type Server struct {
 	Aliases []string `json:"aliases,omitempty"`
 	// RedirectFromToWWW returns if a redirect to/from prefix www is required
 	RedirectFromToWWW bool `json:"redirectFromToWWW,omitempty"`
+	// RedirectFromToWWWDirection restricts the redirect to/from prefix www to one direction
+	RedirectFromToWWWDirection string `json:"redirectFromToWWWDirection,omitempty"`
 	// CertificateAuth indicates this server requires mutual authentication
 	// +optional
 	CertificateAuth authtls.Config `json:"certificateAuth"`
@@ -212,8 +238,33 @@ type Server struct {
 	// SSLPreferServerCiphers indicates that server ciphers should be preferred
 	// over client ciphers when using the TLS protocols.
 	SSLPreferServerCiphers string `json:"sslPreferServerCiphers,omitempty"`
+	// SSLProtocols returns list of TLS protocols to be enabled
+	SSLProtocols string `json:"sslProtocols,omitempty"`
 	// AuthTLSError contains the reason why the access to a server should be denied
 	AuthTLSError string `json:"authTLSError,omitempty"`
+	// ExtraListenPorts contains additional ports the server listens on
+	// +optional
+	ExtraListenPorts extralistenports.Config `json:"extraListenPorts"`
+	// HeaderLimits contains the limits of the request headers of the server
+	// +optional
+	HeaderLimits headerlimits.Config `json:"headerLimits"`
+	// HTTP2 indicates if HTTP/2 is enabled or disabled in the server
+	// +optional
+	HTTP2 http2.Config `json:"http2"`
+	// InternalLocations contains the locations only reachable with X-Accel-Redirect
+	// +optional
+	InternalLocations []internallocations.Location `json:"internalLocations,omitempty"`
+	// RealIP indicates if the client address is replaced by the real IP in the server
+	// +optional
+	RealIP realip.Config `json:"realIP"`
+	// PendingCertificate is the key of the TLS Secret of the server when it does
+	// not exist yet, e.g. while cert-manager issues the certificate
+	// +optional
+	PendingCertificate string `json:"pendingCertificate,omitempty"`
+	// SSLRedirectDeferred indicates the requests are not redirected to HTTPS
+	// until the TLS Secret of the server exists
+	// +optional
+	SSLRedirectDeferred bool `json:"sslRedirectDeferred,omitempty"`
 }
 
 // Location describes an URI inside a server.
@@ -261,10 +312,17 @@ type Location struct {
 	// an Ingress rule.
 	// +optional
 	BasicDigestAuth auth.Config `json:"basicDigestAuth,omitempty"`
+	// AuthLDAP validates the credentials against an LDAP server
+	// +optional
+	AuthLDAP authldap.Config `json:"authLDAP,omitempty"`
 	// Denied returns an error when this location cannot not be allowed
 	// Requesting a denied location should return HTTP code 403.
 	Denied        *string              `json:"denied,omitempty"`
 	CustomHeaders customheaders.Config `json:"customHeaders,omitempty"`
+	// HeadersFromVariables contains the upstream and response headers
+	// set from NGINX variables
+	// +optional
+	HeadersFromVariables headersfromvariables.Config `json:"headersFromVariables,omitempty"`
 	// CorsConfig returns the Cors Configuration for the ingress rule
 	// +optional
 	CorsConfig cors.Config `json:"corsConfig,omitempty"`
@@ -279,11 +337,24 @@ type Location struct {
 	// original location.
 	// +optional
 	HTTP2PushPreload bool `json:"http2PushPreload,omitempty"`
+	// Ranges contains the byte-range configuration of the location
+	// +optional
+	Ranges ranges.Config `json:"ranges"`
 	// RateLimit describes a limit in the number of connections per IP
 	// address or connections per second.
 	// The Redirect annotation precedes RateLimit
 	// +optional
 	RateLimit ratelimit.Config `json:"rateLimit,omitempty"`
+	// UploadGuard limits the simultaneous uploads of a client IP address
+	// +optional
+	UploadGuard uploadguard.Config `json:"uploadGuard"`
+	// HTTPTransform changes the headers, path and query parameters of the
+	// requests and the headers of the responses
+	// +optional
+	HTTPTransform httptransform.Config `json:"httpTransform"`
+	// RouteIf routes the requests matching expressions to other services
+	// +optional
+	RouteIf routeif.Config `json:"routeIf"`
 	// Redirect describes a temporal o permanent redirection this location.
 	// +optional
 	Redirect redirect.Config `json:"redirect,omitempty"`
@@ -298,6 +369,10 @@ type Location struct {
 	// addresses or networks are allowed.
 	// +optional
 	Allowlist ipallowlist.SourceRange `json:"allowlist,omitempty"`
+	// AllowedHTTPMethods restricts the HTTP methods accepted by the location.
+	// An empty list allows any method.
+	// +optional
+	AllowedHTTPMethods []string `json:"allowedHTTPMethods,omitempty"`
 	// Proxy contains information about timeouts and buffer sizes
 	// to be used in connections against endpoints
 	// +optional
@@ -320,6 +395,10 @@ type Location struct {
 	// buffer size for a specific location.
 	// +optional
 	ClientBodyBufferSize string `json:"clientBodyBufferSize,omitempty"`
+	// Compression allows to disable or change the MIME types of the gzip
+	// and brotli compression for a specific location.
+	// +optional
+	Compression compression.Config `json:"compression"`
 	// DefaultBackend allows the use of a custom default backend for this location.
 	// +optional
 	DefaultBackend *apiv1.Service `json:"-"`
@@ -347,6 +426,18 @@ type Location struct {
 	// but service-a can return 404 and 503 error codes without intercept
 	// +optional
 	DisableProxyInterceptErrors bool `json:"disable-proxy-intercept-errors"`
+	// ProxyInterceptErrors overrides the proxy_intercept_errors directive
+	// of the location with "on" or "off"
+	// +optional
+	ProxyInterceptErrors string `json:"proxyInterceptErrors,omitempty"`
+	// CustomErrorsFallback is the service handling the custom errors when
+	// the custom default backend of the location fails
+	// +optional
+	CustomErrorsFallback *apiv1.Service `json:"-"`
+	// CustomErrorsFallbackUpstreamName is the upstream-formatted string for
+	// the name of the service handling the custom errors as a fallback
+	// +optional
+	CustomErrorsFallbackUpstreamName string `json:"customErrorsFallbackUpstreamName,omitempty"`
 	// ModSecurity allows to enable and configure modsecurity
 	// +optional
 	ModSecurity modsecurity.Config `json:"modsecurity"`
@@ -355,9 +446,35 @@ type Location struct {
 	// Mirror allows you to mirror traffic to a "test" backend
 	// +optional
 	Mirror mirror.Config `json:"mirror,omitempty"`
+	// SubFilter contains the strings to replace in the response body
+	// +optional
+	SubFilter subfilter.Config `json:"subFilter,omitempty"`
 	// Opentelemetry allows the global opentelemetry setting to be overridden for a location
 	// +optional
 	Opentelemetry opentelemetry.Config `json:"opentelemetry"`
+	// Collapsed contains the values of the location for each host of a
+	// server collapsed from servers with the same locations
+	// +optional
+	Collapsed *CollapsedLocation `json:"collapsed,omitempty"`
+}
+
+// CollapsedLocation describes a location shared by many hosts. The values
+// that differ between the hosts are looked up using the Host header.
+type CollapsedLocation struct {
+	// ID identifies the variables of the location
+	ID string `json:"id"`
+	// Hosts contains the values of the location for each host
+	Hosts []CollapsedHost `json:"hosts"`
+}
+
+// CollapsedHost contains the values of a collapsed location for a host
+type CollapsedHost struct {
+	// Hostname is the FQDN of the host
+	Hostname string `json:"hostname"`
+	// Backend is the name of the upstream of the location for the host
+	Backend string `json:"backend"`
+	// Ingress is the Ingress defining the location for the host
+	Ingress *Ingress `json:"-"`
 }
 
 // SSLPassthroughBackend describes a SSL upstream server configured
@@ -383,6 +500,33 @@ type L4Service struct {
 	Endpoints []Endpoint `json:"endpoints,omitempty"`
 	// k8s Service
 	Service *apiv1.Service `json:"-"`
+	// AccessLog overrides the access log of the stream context
+	// +optional
+	AccessLog L4AccessLog `json:"accessLog"`
+	// Proxy overrides the proxy settings of the stream context
+	// +optional
+	Proxy L4Proxy `json:"proxy"`
+}
+
+// L4AccessLog describes the access log of a L4 service
+type L4AccessLog struct {
+	// Disabled turns off the access log of the service
+	Disabled bool `json:"disabled,omitempty"`
+	// Path is the destination of the access log, the global one when empty
+	Path string `json:"path,omitempty"`
+	// Format is the log format of the service, the global one when empty
+	Format string `json:"format,omitempty"`
+}
+
+// L4Proxy describes the proxy settings of a L4 service
+type L4Proxy struct {
+	// Responses is the number of datagrams expected from the upstream
+	// for each client datagram (UDP only), the global one when empty
+	Responses string `json:"responses,omitempty"`
+	// Timeout is the idle timeout of the connection, the global one when empty
+	Timeout string `json:"timeout,omitempty"`
+	// HashBy is the key used to pin clients to an endpoint
+	HashBy string `json:"hashBy,omitempty"`
 }
 
 // L4Backend describes the kubernetes service behind L4 Ingress service
@@ -17,6 +17,8 @@ limitations under the License.
 package ingress
 
 import (
+	"slices"
+
 	"k8s.io/ingress-nginx/pkg/util/sets"
 )
 
@@ -76,6 +78,14 @@ func (c1 *Configuration) Equal(c2 *Configuration) bool {
 		}
 	}
 
+	if !sets.StringElementsMatch(c1.CDNRanges, c2.CDNRanges) {
+		return false
+	}
+
+	if c1.ErrorLogLevel != c2.ErrorLogLevel {
+		return false
+	}
+
 	return c1.BackendConfigChecksum == c2.BackendConfigChecksum
 }
 
@@ -90,6 +100,9 @@ func (b *Backend) Equal(newB *Backend) bool {
 	if b.Name != newB.Name {
 		return false
 	}
+	if b.ReadableName != newB.ReadableName {
+		return false
+	}
 	if b.NoServer != newB.NoServer {
 		return false
 	}
@@ -301,6 +314,9 @@ func (s1 *Server) Equal(s2 *Server) bool {
 	if s1.RedirectFromToWWW != s2.RedirectFromToWWW {
 		return false
 	}
+	if s1.RedirectFromToWWWDirection != s2.RedirectFromToWWWDirection {
+		return false
+	}
 	if !(&s1.CertificateAuth).Equal(&s2.CertificateAuth) {
 		return false
 	}
@@ -313,12 +329,36 @@ func (s1 *Server) Equal(s2 *Server) bool {
 	if s1.SSLPreferServerCiphers != s2.SSLPreferServerCiphers {
 		return false
 	}
+	if s1.SSLProtocols != s2.SSLProtocols {
+		return false
+	}
 	if s1.AuthTLSError != s2.AuthTLSError {
 		return false
 	}
 	if !(&s1.ProxySSL).Equal(&s2.ProxySSL) {
 		return false
 	}
+	if !(&s1.ExtraListenPorts).Equal(&s2.ExtraListenPorts) {
+		return false
+	}
+	if !(&s1.HeaderLimits).Equal(&s2.HeaderLimits) {
+		return false
+	}
+	if !(&s1.HTTP2).Equal(&s2.HTTP2) {
+		return false
+	}
+	if !slices.Equal(s1.InternalLocations, s2.InternalLocations) {
+		return false
+	}
+	if !(&s1.RealIP).Equal(&s2.RealIP) {
+		return false
+	}
+	if s1.PendingCertificate != s2.PendingCertificate {
+		return false
+	}
+	if s1.SSLRedirectDeferred != s2.SSLRedirectDeferred {
+		return false
+	}
 
 	if len(s1.Locations) != len(s2.Locations) {
 		return false
@@ -372,6 +412,9 @@ func (l1 *Location) Equal(l2 *Location) bool {
 	if !(&l1.BasicDigestAuth).Equal(&l2.BasicDigestAuth) {
 		return false
 	}
+	if !(&l1.AuthLDAP).Equal(&l2.AuthLDAP) {
+		return false
+	}
 	if l1.Denied != l2.Denied {
 		return false
 	}
@@ -387,9 +430,21 @@ func (l1 *Location) Equal(l2 *Location) bool {
 	if l1.HTTP2PushPreload != l2.HTTP2PushPreload {
 		return false
 	}
+	if !(&l1.Ranges).Equal(&l2.Ranges) {
+		return false
+	}
 	if !(&l1.RateLimit).Equal(&l2.RateLimit) {
 		return false
 	}
+	if !(&l1.UploadGuard).Equal(&l2.UploadGuard) {
+		return false
+	}
+	if !(&l1.HTTPTransform).Equal(&l2.HTTPTransform) {
+		return false
+	}
+	if !(&l1.RouteIf).Equal(&l2.RouteIf) {
+		return false
+	}
 	if !(&l1.Redirect).Equal(&l2.Redirect) {
 		return false
 	}
@@ -402,6 +457,9 @@ func (l1 *Location) Equal(l2 *Location) bool {
 	if !(&l1.Allowlist).Equal(&l2.Allowlist) {
 		return false
 	}
+	if !sets.StringElementsMatch(l1.AllowedHTTPMethods, l2.AllowedHTTPMethods) {
+		return false
+	}
 	if !(&l1.Proxy).Equal(&l2.Proxy) {
 		return false
 	}
@@ -417,6 +475,9 @@ func (l1 *Location) Equal(l2 *Location) bool {
 	if l1.ClientBodyBufferSize != l2.ClientBodyBufferSize {
 		return false
 	}
+	if !(&l1.Compression).Equal(&l2.Compression) {
+		return false
+	}
 	if l1.UpstreamVhost != l2.UpstreamVhost {
 		return false
 	}
@@ -463,17 +524,54 @@ func (l1 *Location) Equal(l2 *Location) bool {
 		return false
 	}
 
+	if !l1.SubFilter.Equal(&l2.SubFilter) {
+		return false
+	}
+
 	if l1.DisableProxyInterceptErrors != l2.DisableProxyInterceptErrors {
 		return false
 	}
 
+	if l1.ProxyInterceptErrors != l2.ProxyInterceptErrors {
+		return false
+	}
+
+	if l1.CustomErrorsFallbackUpstreamName != l2.CustomErrorsFallbackUpstreamName {
+		return false
+	}
+
 	if !l1.CustomHeaders.Equal(&l2.CustomHeaders) {
 		return false
 	}
 
+	if !l1.HeadersFromVariables.Equal(&l2.HeadersFromVariables) {
+		return false
+	}
+
+	if !l1.Collapsed.Equal(l2.Collapsed) {
+		return false
+	}
+
 	return true
 }
 
+// Equal tests for equality between two CollapsedLocation types
+func (c1 *CollapsedLocation) Equal(c2 *CollapsedLocation) bool {
+	if c1 == c2 {
+		return true
+	}
+	if c1 == nil || c2 == nil {
+		return false
+	}
+	if c1.ID != c2.ID {
+		return false
+	}
+
+	return slices.EqualFunc(c1.Hosts, c2.Hosts, func(h1, h2 CollapsedHost) bool {
+		return h1.Hostname == h2.Hostname && h1.Backend == h2.Backend
+	})
+}
+
 // Equal tests for equality between two SSLPassthroughBackend types
 func (ptb1 *SSLPassthroughBackend) Equal(ptb2 *SSLPassthroughBackend) bool {
 	if ptb1 == ptb2 {
@@ -521,6 +619,12 @@ func (e1 *L4Service) Equal(e2 *L4Service) bool {
 	if !(&e1.Backend).Equal(&e2.Backend) {
 		return false
 	}
+	if e1.AccessLog != e2.AccessLog {
+		return false
+	}
+	if e1.Proxy != e2.Proxy {
+		return false
+	}
 
 	return compareEndpoints(e1.Endpoints, e2.Endpoints)
 }
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	klog "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/ingress-nginx/pkg/util/file"
+)
+
+const (
+	// ConfigFileAPIVersion is the version of the format of the configuration file
+	ConfigFileAPIVersion = "ingress-nginx.kubernetes.io/v1alpha1"
+	// ConfigFileKind is the kind of the configuration file
+	ConfigFileKind = "ControllerConfiguration"
+)
+
+// reloadableFlags are the flags applied when the configuration file changes.
+// The rest of the flags require a restart of the controller.
+var reloadableFlags = []string{"v", "vmodule"}
+
+// definedInCommandLine are the flags defined in the command line, which take
+// precedence over the configuration file
+var definedInCommandLine = map[string]bool{}
+
+// ConfigFile contains the values of the flags of the controller
+type ConfigFile struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Flags contains the values of the flags by name, without the leading dashes.
+	// Lists are defined as YAML sequences.
+	Flags map[string]interface{} `json:"flags"`
+}
+
+// readConfigFile reads and validates the configuration file, returning the
+// values of the flags as they are defined in the command line
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading the configuration file: %w", err)
+	}
+
+	cf := &ConfigFile{}
+	if err := yaml.UnmarshalStrict(data, cf); err != nil {
+		return nil, fmt.Errorf("decoding the configuration file %v: %w", path, err)
+	}
+
+	if cf.APIVersion != ConfigFileAPIVersion || cf.Kind != ConfigFileKind {
+		return nil, fmt.Errorf("unsupported configuration file %v: expected apiVersion %v and kind %v", path, ConfigFileAPIVersion, ConfigFileKind)
+	}
+
+	values := make(map[string]string, len(cf.Flags))
+	for name, v := range cf.Flags {
+		value, err := flagValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of the flag %v in the configuration file %v: %w", name, path, err)
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// flagValue returns a value of the configuration file as it is defined in the command line
+func flagValue(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case nil:
+		return "", nil
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			s, err := flagValue(item)
+			if err != nil {
+				return "", err
+			}
+			if strings.Contains(s, ",") {
+				return "", fmt.Errorf("list item %q contains a comma", s)
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// applyConfigFile sets the flags defined in the configuration file and not
+// in the command line
+func applyConfigFile(flags *pflag.FlagSet, path string) error {
+	values, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	definedInCommandLine = map[string]bool{}
+	flags.Visit(func(f *pflag.Flag) {
+		definedInCommandLine[f.Name] = true
+	})
+
+	for name, value := range values {
+		f := flags.Lookup(name)
+		if f == nil || name == "config" {
+			return fmt.Errorf("unknown flag %v in the configuration file %v", name, path)
+		}
+
+		if definedInCommandLine[name] {
+			klog.InfoS("Ignoring the flag of the configuration file defined in the command line", "flag", name)
+			continue
+		}
+
+		if err := flags.Set(name, value); err != nil {
+			return fmt.Errorf("invalid value of the flag %v in the configuration file %v: %w", name, path, err)
+		}
+	}
+
+	return nil
+}
+
+// WatchConfigFile applies the changes of the reloadable flags in the configuration
+// file. Changes of the rest of the flags are logged, as they require a restart.
+func WatchConfigFile(path string, flagValues map[string]string) (file.Watcher, error) {
+	return file.NewFileWatcher(path, func() {
+		values, err := readConfigFile(path)
+		if err != nil {
+			klog.ErrorS(err, "Error reloading the configuration file")
+			return
+		}
+
+		for name, value := range values {
+			if definedInCommandLine[name] {
+				continue
+			}
+
+			if !slices.Contains(reloadableFlags, name) {
+				if current, ok := flagValues[name]; ok && current != value {
+					klog.Warningf("The flag %v changed in the configuration file, the controller must be restarted to apply it", name)
+				}
+				continue
+			}
+
+			f := flag.Lookup(name)
+			if f == nil || f.Value.String() == value {
+				continue
+			}
+
+			if err := f.Value.Set(value); err != nil {
+				klog.ErrorS(err, "Invalid value of the flag in the configuration file", "flag", name)
+				continue
+			}
+			klog.InfoS("Reloaded the flag of the configuration file", "flag", name, "value", value)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "ingress-controller.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Unexpected error writing the configuration file: %v", err)
+	}
+	return path
+}
+
+func TestConfigFile(t *testing.T) {
+	ResetForTesting(func() { t.Fatal("Parsing failed") })
+
+	path := writeConfigFile(t, `apiVersion: ingress-nginx.kubernetes.io/v1alpha1
+kind: ControllerConfiguration
+flags:
+  http-port: 8080
+  election-ttl: 60s
+  disable-leader-election: true
+  dynamic-configuration-chunk-size: 16777216
+  preemption-watcher:
+  - gcp
+  - taint
+`)
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	os.Args = []string{"cmd", "--config", path, "--http-port", "0", "--https-port", "0"}
+
+	_, conf, err := ParseFlags()
+	if err != nil {
+		t.Fatalf("Unexpected error parsing the configuration file: %v", err)
+	}
+
+	if conf.ListenPorts.HTTP != 0 {
+		t.Errorf("Expected the http port of the command line but found %v", conf.ListenPorts.HTTP)
+	}
+	if conf.ElectionTTL != 60*time.Second {
+		t.Errorf("Expected an election TTL of 60s but found %v", conf.ElectionTTL)
+	}
+	if !conf.DisableLeaderElection {
+		t.Errorf("Expected the leader election to be disabled")
+	}
+	if conf.DynamicConfigurationChunkSize != 16777216 {
+		t.Errorf("Expected a chunk size of 16777216 but found %v", conf.DynamicConfigurationChunkSize)
+	}
+	if len(conf.PreemptionSources) != 2 || conf.PreemptionSources[0] != "gcp" || conf.PreemptionSources[1] != "taint" {
+		t.Errorf("Expected the preemption sources gcp and taint but found %v", conf.PreemptionSources)
+	}
+	if conf.ConfigFile != path {
+		t.Errorf("Expected the configuration file %v but found %v", path, conf.ConfigFile)
+	}
+}
+
+func TestInvalidConfigFile(t *testing.T) {
+	testCases := map[string]string{
+		"unknown field": `apiVersion: ingress-nginx.kubernetes.io/v1alpha1
+kind: ControllerConfiguration
+flag:
+  http-port: 8080
+`,
+		"unknown flag": `apiVersion: ingress-nginx.kubernetes.io/v1alpha1
+kind: ControllerConfiguration
+flags:
+  http-prot: 8080
+`,
+		"invalid value": `apiVersion: ingress-nginx.kubernetes.io/v1alpha1
+kind: ControllerConfiguration
+flags:
+  election-ttl: forever
+`,
+		"nested value": `apiVersion: ingress-nginx.kubernetes.io/v1alpha1
+kind: ControllerConfiguration
+flags:
+  http-port:
+    value: 8080
+`,
+		"config flag": `apiVersion: ingress-nginx.kubernetes.io/v1alpha1
+kind: ControllerConfiguration
+flags:
+  config: /etc/other.yaml
+`,
+		"version": `apiVersion: ingress-nginx.kubernetes.io/v1
+kind: ControllerConfiguration
+flags: {}
+`,
+	}
+
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ResetForTesting(func() { t.Fatal("Parsing failed") })
+
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+			os.Args = []string{"cmd", "--config", writeConfigFile(t, content), "--http-port", "0", "--https-port", "0"}
+
+			if _, _, err := ParseFlags(); err == nil {
+				t.Errorf("Expected an error parsing the configuration file")
+			}
+		})
+	}
+}
@@ -20,19 +20,26 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"maps"
 	"net"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/pflag"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/ingress-nginx/internal/ingress/adminauth"
 	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
 	"k8s.io/ingress-nginx/internal/ingress/controller"
 	ngx_config "k8s.io/ingress-nginx/internal/ingress/controller/config"
 	"k8s.io/ingress-nginx/internal/ingress/controller/ingressclass"
 	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
+	"k8s.io/ingress-nginx/internal/ingress/preemption"
+	"k8s.io/ingress-nginx/internal/ingress/shard"
 	"k8s.io/ingress-nginx/internal/ingress/status"
 	ing_net "k8s.io/ingress-nginx/internal/net"
 	"k8s.io/ingress-nginx/internal/nginx"
@@ -47,11 +54,37 @@ func ParseFlags() (bool, *controller.Configuration, error) {
 	var (
 		flags = pflag.NewFlagSet("", pflag.ExitOnError)
 
+		configFile = flags.String("config", "",
+			`Path to a YAML file with the values of the flags of the controller, with apiVersion
+ingress-nginx.kubernetes.io/v1alpha1 and kind ControllerConfiguration. The flags defined in the
+command line take precedence over the file. Changes of the flags v and vmodule are applied
+without a restart.`)
+
 		apiserverHost = flags.String("apiserver-host", "",
 			`Address of the Kubernetes API server.
 Takes the form "protocol://address:port". If not specified, it is assumed the
 program runs inside a Kubernetes cluster and local discovery is attempted.`)
 
+		kubeAPIQPS = flags.Float32("kube-api-qps", 20,
+			`Maximum number of requests per second to the Kubernetes API server, excluding the
+updates of the Ingress status.`)
+
+		kubeAPIBurst = flags.Int("kube-api-burst", 40,
+			`Maximum burst of requests to the Kubernetes API server, excluding the updates of
+the Ingress status.`)
+
+		statusKubeAPIQPS = flags.Float32("status-kube-api-qps", 5,
+			`Maximum number of requests per second to the Kubernetes API server to update the
+Ingress status, so mass status updates don't throttle the other requests.`)
+
+		statusKubeAPIBurst = flags.Int("status-kube-api-burst", 10,
+			`Maximum burst of requests to the Kubernetes API server to update the Ingress status.`)
+
+		statusServerSideApply = flags.Bool("status-server-side-apply", false,
+			`Update the load-balancer status of Ingress objects with server-side apply, owning the
+status with the field manager ingress-nginx-status, instead of reading and updating
+every Ingress. Requires the update-status parameter.`)
+
 		rootCAFile = flags.String("certificate-authority", "",
 			`Path to a cert file for the certificate authority. This certificate is used
 only when the flag --apiserver-host is specified.`)
@@ -64,6 +97,11 @@ only when the flag --apiserver-host is specified.`)
 Takes the form "namespace/name". The controller configures NGINX to forward
 requests to the first port of this Service.`)
 
+		defaultBackendAssetsConfigMap = flags.String("default-backend-assets-configmap", "",
+			`Name of the ConfigMap containing the pages served by the internal default backend when
+--default-backend-service is not set, in the form "namespace/name". The key 404.html is served for
+unknown requests, healthz.html for the health checks and any other key below the path /assets/.`)
+
 		ingressClassAnnotation = flags.String("ingress-class", ingressclass.DefaultAnnotationValue,
 			`[IN DEPRECATION] Name of the ingress class this controller satisfies.
 The class of an Ingress object is set using the annotation "kubernetes.io/ingress.class" (deprecated).
@@ -80,8 +118,10 @@ referenced in an Ingress Object should be the same value specified here to make
 		ingressClassByName = flags.Bool("ingress-class-by-name", false,
 			`Define if Ingress Controller should watch for Ingress Class by Name together with Controller Class.`)
 
-		configMap = flags.String("configmap", "",
-			`Name of the ConfigMap containing custom global configurations for the controller.`)
+		configMap = flags.StringSlice("configmap", []string{},
+			`Name of the ConfigMap containing custom global configurations for the controller.
+Can be repeated or a comma-separated list to merge several ConfigMaps in order: the first one
+is the base configuration and the keys of each following ConfigMap replace the previous values.`)
 
 		publishSvc = flags.String("publish-service", "",
 			`Service fronting the Ingress controller.
@@ -112,6 +152,12 @@ namespaces are watched if this parameter is left empty.`)
 		watchNamespaceSelector = flags.String("watch-namespace-selector", "",
 			`Selector selects namespaces the controller watches for updates to Kubernetes objects.`)
 
+		watchReferenceGrants = flags.Bool("watch-reference-grants", false,
+			`Watch the ReferenceGrants of the Gateway API to allow Ingresses to reference secrets and services of other namespaces.`)
+
+		watchHTTPTransforms = flags.Bool("watch-http-transforms", false,
+			`Watch the HTTPTransforms referenced by the http-transform annotation. Requires the HTTPTransform CustomResourceDefinition.`)
+
 		profiling = flags.Bool("profiling", true,
 			`Enable profiling via web interface host:port/debug/pprof/ .`)
 
@@ -136,6 +182,12 @@ Requires setting the publish-service parameter to a valid Service reference.`)
 		electionTTL = flags.Duration("election-ttl", 30*time.Second,
 			`Duration a leader election is valid before it's getting re-elected`)
 
+		ingressClassLeaseNamespace = flags.String("ingress-class-lease-namespace", "",
+			`Namespace of the Leases owning the IngressClasses, shared by the controllers of the cluster.
+Only the controller owning the IngressClass updates the status of its Ingresses, the other
+controllers configured with the same class emit an event and back off. Disabled by default.
+Requires the update-status parameter.`)
+
 		updateStatusOnShutdown = flags.Bool("update-status-on-shutdown", true,
 			`Update the load-balancer status of Ingress objects when the controller shuts down.
 Requires the update-status parameter.`)
@@ -150,6 +202,10 @@ Requires the update-status parameter.`)
 		enableSSLPassthrough = flags.Bool("enable-ssl-passthrough", false,
 			`Enable SSL Passthrough.`)
 
+		sslPassthroughPoolSize = flags.Int("ssl-passthrough-pool-size", 0,
+			`Number of connections to NGINX opened in advance by the SSL Passthrough proxy, so the
+connections terminated by NGINX do not wait for a new connection. 0 disables the pool.`)
+
 		disableLeaderElection = flags.Bool("disable-leader-election", false,
 			`Disable Leader Election on NGINX Controller.`)
 
@@ -180,6 +236,9 @@ Requires the update-status parameter.`)
 			`Export metrics per-host.`)
 		metricsPerUndefinedHost = flags.Bool("metrics-per-undefined-host", false,
 			`Export metrics per-host even if the host is not defined in an ingress. Requires --metrics-per-host to be set to true.`)
+		errorLogMetrics = flags.Bool("error-log-metrics", false,
+			`Exports counters of the entries of the error log of NGINX by level, class, like upstream timeouts or SSL handshake
+errors, and host when --metrics-per-host is enabled. Requires --enable-metrics.`)
 		reportStatusClasses = flags.Bool("report-status-classes", false,
 			`Use status classes (2xx, 3xx, 4xx and 5xx) instead of status codes in metrics.`)
 
@@ -199,9 +258,18 @@ Requires the update-status parameter.`)
 		healthzPort   = flags.Int("healthz-port", 10254, "Port to use for the healthz endpoint.")
 		healthzHost   = flags.String("healthz-host", "", "Address to bind the healthz endpoint.")
 
+		lbHealthCheckPort = flags.Int("lb-health-check-port", 0,
+			`Port to use for answering TCP and HTTP health checks from external load balancers.
+Connections can start with a PROXY protocol header. Disabled by default.`)
+		lbHealthCheckUnhealthyOnReload = flags.Bool("lb-health-check-unhealthy-on-reload", true,
+			`Report the controller as unhealthy in the lb-health-check-port while NGINX is reloading.`)
+
 		disableCatchAll = flags.Bool("disable-catch-all", false,
 			`Disable support for catch-all Ingresses.`)
 
+		disallowWeakerTLS = flags.Bool("disallow-weaker-tls-overrides", false,
+			`Reject Ingresses with ssl-protocols or ssl-ciphers annotations enabling protocols or ciphers not enabled globally.`)
+
 		validationWebhook = flags.String("validating-webhook", "",
 			`The address to start an admission controller on to validate incoming ingresses.
 Takes the form "<host>:port". If not provided, no admission controller is started.`)
@@ -209,12 +277,21 @@ Takes the form "<host>:port". If not provided, no admission controller is starte
 			`The path of the validating webhook certificate PEM.`)
 		validationWebhookKey = flags.String("validating-webhook-key", "",
 			`The path of the validating webhook key PEM.`)
+		admissionPoliciesConfigMap = flags.String("admission-policies-configmap", "",
+			`Name of the ConfigMap containing the policies the Ingress objects must satisfy to be admitted
+by the validating webhook, in the form "namespace/name". Each key is the name of a policy and each
+value a YAML document with the expression and the message of the policy.`)
 		disableFullValidationTest = flags.Bool("disable-full-test", false,
 			`Disable full test of all merged ingresses at the admission stage and tests the template of the ingress being created or updated  (full test of all ingresses is enabled by default).`)
 
 		statusPort = flags.Int("status-port", 10246, `Port to use for the lua HTTP endpoint configuration.`)
 		streamPort = flags.Int("stream-port", 10247, "Port to use for the lua TCP/UDP endpoint configuration.")
 
+		runtimeDir = flags.String("runtime-dir", "",
+			`Directory containing the files written at runtime, like nginx.conf, the temporary directories of NGINX,
+the SSL certificates and the GeoIP2 databases, to run the controller with a read-only root filesystem
+and this directory in a writable volume. By default the files are written in /etc/nginx, /etc/ingress-controller and /tmp/nginx.`)
+
 		internalLoggerAddress = flags.String("internal-logger-address", "127.0.0.1:11514", "Address to be used when binding internal syslogger.")
 
 		profilerPort    = flags.Int("profiler-port", 10245, "Port to use for expose the ingress controller Go profiler when it is enabled.")
@@ -226,13 +303,89 @@ Takes the form "<host>:port". If not provided, no admission controller is starte
 
 		postShutdownGracePeriod = flags.Int("post-shutdown-grace-period", 10, "Seconds to wait after the nginx process has stopped before controller exits.")
 
+		l4ShutdownGracePeriod = flags.Int("l4-shutdown-grace-period", 0,
+			`Seconds to keep the SSL Passthrough and stream connections open after stopping the nginx process.
+The connections still open after it are closed and counted in the metrics. 0 waits for the nginx process to stop.`)
+
+		preemptionWatcher = flags.StringSlice("preemption-watcher", []string{},
+			`Sources of node preemption notices that trigger the graceful shutdown of the controller before the node is lost.
+Supported values are gcp, aws and taint. Disabled by default.`)
+		preemptionTaintKeys = flags.StringSlice("preemption-taint-keys", preemption.DefaultTaintKeys,
+			`Keys of the node taints considered a preemption notice by the taint source of --preemption-watcher.`)
+		preemptionPollInterval = flags.Duration("preemption-poll-interval", 5*time.Second,
+			`Time between checks of the sources of node preemption notices.`)
+		preemptionDrainDelay = flags.Duration("preemption-drain-delay", 0,
+			`Time to wait after receiving a node preemption notice before starting the graceful shutdown.`)
+
+		luaSharedDictsUsageThreshold = flags.Float64("lua-shared-dicts-usage-threshold", 0,
+			`Used part of the configuration_data and certificate_data Lua shared dictionaries, between 0 and 1,
+over which an event recommending a bigger size in lua-shared-dicts is emitted. Disabled by default.`)
+
+		diskUsageCheckPeriod = flags.Duration("disk-usage-check-period", 0,
+			`Time between checks of the disk usage of the temporary and cache directories of NGINX, exported in the metrics.
+An event is emitted when requests or responses are buffered to temporary files. Disabled by default.`)
+		cacheEvictionHighWatermark = flags.Float64("cache-eviction-high-watermark", 0,
+			`Used part of a filesystem, between 0 and 1, over which the oldest files of the NGINX cache directories
+it contains are removed at each check of --disk-usage-check-period. Disabled by default.`)
+
+		backendProtocolProbeInterval = flags.Duration("backend-protocol-probe-interval", 0,
+			`Time between requests to an endpoint of the backends using HTTP, emitting an event in the Ingress
+when the backend redirects them to HTTPS. Disabled by default.`)
+
+		configDriftCheckPeriod = flags.Duration("config-drift-check-period", 0,
+			`Time between checks of nginx.conf and the backends stored by Lua against the ones written by the controller.
+A metric and an event report the changes made out of band, e.g. in a debugging session. Disabled by default.`)
+
+		configHistorySize = flags.Int("config-history-size", 0,
+			`Number of generations of nginx.conf kept on disk with the trigger, the checksum and the time of their reload,
+exposed in the admin endpoint /configuration-history. Disabled by default.`)
+		configHistoryRetention = flags.Duration("config-history-retention", 0,
+			`Time the generations of nginx.conf of --config-history-size are kept, the last one is always kept. Unlimited by default.`)
+
+		shards = flags.Int("shards", 1,
+			`Number of replicas of the controller the Ingress objects are split between by the hash of their hosts.
+Each replica renders and serves only the hosts of its shard. 1 disables the sharding.`)
+		shardIndex = flags.Int("shard-index", -1,
+			`Shard of the Ingress objects processed by this replica, between 0 and --shards minus one.
+By default it is the ordinal of the StatefulSet pod name in the POD_NAME environment variable.`)
+
 		deepInspector = flags.Bool("deep-inspect", true, "Enables ingress object security deep inspector")
 
+		healthzDeepCheck = flags.Bool("healthz-deep-check", false,
+			`Extends the health check to verify the backends stored by Lua match the last ones sent by the controller
+and a sample of the backends with endpoints have a balancer in the NGINX workers.`)
+
+		crashRecovery = flags.Bool("crash-recovery", false,
+			`Reverts the changes of the Ingresses since the last good configuration when the NGINX workers crash
+repeatedly after them, emitting CrashLoop events. The Ingresses use their previous version until they change again.`)
+		crashRecoveryBisect = flags.Bool("crash-recovery-bisect", false,
+			`Reloads NGINX without the changes of half of the Ingresses changed before a crash loop, halving them
+until the one causing it is found, to revert only this one. Requires --crash-recovery.`)
+
+		adminAuth = flags.String("admin-auth", adminauth.ModeToken,
+			`Protection of the admin endpoints, like the support bundle: token requires a bearer token allowed to
+get their path with a TokenReview and a SubjectAccessReview, mtls exposes them in --admin-port requiring
+client certificates signed by --admin-client-ca-file, and none exposes them in the healthz port without
+authentication, only to the requests from the loopback interface.`)
+		adminPort         = flags.Int("admin-port", 10260, "Port of the admin endpoints when --admin-auth is mtls.")
+		adminTLSCertFile  = flags.String("admin-tls-cert-file", "", "Path of the certificate of the admin endpoints when --admin-auth is mtls.")
+		adminTLSKeyFile   = flags.String("admin-tls-key-file", "", "Path of the private key of the admin endpoints when --admin-auth is mtls.")
+		adminClientCAFile = flags.String("admin-client-ca-file", "", "Path of the CA verifying the client certificates of the admin endpoints when --admin-auth is mtls.")
+
 		dynamicConfigurationRetries = flags.Int("dynamic-configuration-retries", 15, "Number of times to retry failed dynamic configuration before failing to sync an ingress.")
 
+		dynamicConfigurationChunkSize = flags.Int("dynamic-configuration-chunk-size", 8*1024*1024,
+			`Biggest size in bytes of a request sending the dynamic configuration to NGINX. Bigger configurations are sent in chunks, stored in the configuration_data Lua shared dictionary until NGINX decodes the whole configuration with the last one. 0 disables the chunks.`)
+		compressDynamicConfiguration = flags.Bool("dynamic-configuration-compression", false,
+			`Compress with gzip the requests sending the dynamic configuration to NGINX.`)
+
 		disableSyncEvents = flags.Bool("disable-sync-events", false, "Disables the creation of 'Sync' event resources")
 
 		enableTopologyAwareRouting = flags.Bool("enable-topology-aware-routing", false, "Enable topology aware routing feature, needs service object annotation service.kubernetes.io/topology-mode sets to auto.")
+
+		enableIPv6Endpoints = flags.Bool("enable-ipv6-endpoints", true,
+			`Use the IPv6 addresses of the EndpointSlices as upstream endpoints.
+Disable it in dual-stack clusters where the controller cannot reach the pods over IPv6.`)
 	)
 
 	flags.StringVar(&nginx.MaxmindMirror, "maxmind-mirror", "", `Maxmind mirror url (example: http://geoip.local/databases.`)
@@ -247,6 +400,12 @@ https://blog.maxmind.com/2019/12/significant-changes-to-accessing-and-using-geol
 		return false, nil, err
 	}
 
+	if *configFile != "" {
+		if err := applyConfigFile(flags, *configFile); err != nil {
+			return false, nil, err
+		}
+	}
+
 	pflag.VisitAll(func(flag *pflag.Flag) {
 		klog.V(2).InfoS("FLAG", flag.Name, flag.Value)
 	})
@@ -255,6 +414,11 @@ https://blog.maxmind.com/2019/12/significant-changes-to-accessing-and-using-geol
 		return true, nil, nil
 	}
 
+	commandLineFlags := map[string]string{}
+	flags.VisitAll(func(flag *pflag.Flag) {
+		commandLineFlags[flag.Name] = flag.Value.String()
+	})
+
 	if *statusUpdateInterval < 5 {
 		klog.Warningf("The defined time to update the Ingress status too low (%v seconds). Adjusting to 5 seconds", *statusUpdateInterval)
 		status.UpdateInterval = 5
@@ -265,6 +429,18 @@ https://blog.maxmind.com/2019/12/significant-changes-to-accessing-and-using-geol
 	parser.AnnotationsPrefix = *annotationsPrefix
 	parser.EnableAnnotationValidation = *enableAnnotationValidation
 
+	// ports below net.ipv4.ip_unprivileged_port_start require NET_BIND_SERVICE,
+	// missing when the controller runs with an arbitrary uid and no capabilities
+	privilegedPorts := map[string]int{"--http-port": *httpPort, "--https-port": *httpsPort, "--default-server-port": *defServerPort}
+	if *enableSSLPassthrough {
+		privilegedPorts["--ssl-passthrough-proxy-port"] = *sslProxyPort
+	}
+	for _, flag := range slices.Sorted(maps.Keys(privilegedPorts)) {
+		if err := ing_net.CheckPrivilegedPort(privilegedPorts[flag]); err != nil {
+			return false, nil, fmt.Errorf("%w. Please check the flag %v", err, flag)
+		}
+	}
+
 	// check port collisions
 	if !ing_net.IsPortAvailable(*httpPort) {
 		return false, nil, fmt.Errorf("port %v is already in use. Please check the flag --http-port", *httpPort)
@@ -290,6 +466,37 @@ https://blog.maxmind.com/2019/12/significant-changes-to-accessing-and-using-geol
 		return false, nil, fmt.Errorf("port %v is already in use. Please check the flag --profiler-port", *profilerPort)
 	}
 
+	if *lbHealthCheckPort != 0 && !ing_net.IsPortAvailable(*lbHealthCheckPort) {
+		return false, nil, fmt.Errorf("port %v is already in use. Please check the flag --lb-health-check-port", *lbHealthCheckPort)
+	}
+
+	for _, source := range *preemptionWatcher {
+		if !slices.Contains([]string{preemption.GCPSource, preemption.AWSSource, preemption.TaintSource}, source) {
+			return false, nil, fmt.Errorf("invalid source of preemption notices %q. Please check the flag --preemption-watcher", source)
+		}
+	}
+
+	switch *adminAuth {
+	case adminauth.ModeNone, adminauth.ModeToken:
+	case adminauth.ModeMTLS:
+		if *adminTLSCertFile == "" || *adminTLSKeyFile == "" || *adminClientCAFile == "" {
+			return false, nil, fmt.Errorf("flags --admin-tls-cert-file, --admin-tls-key-file and --admin-client-ca-file are required when --admin-auth is mtls")
+		}
+
+		if !ing_net.IsPortAvailable(*adminPort) {
+			return false, nil, fmt.Errorf("port %v is already in use. Please check the flag --admin-port", *adminPort)
+		}
+	default:
+		return false, nil, fmt.Errorf("invalid value %q. Please check the flag --admin-auth", *adminAuth)
+	}
+
+	if *runtimeDir != "" {
+		if !filepath.IsAbs(*runtimeDir) {
+			return false, nil, errors.New("--runtime-dir must be an absolute path")
+		}
+		nginx.SetRuntimeDirectory(*runtimeDir)
+	}
+
 	nginx.StatusPort = *statusPort
 	nginx.StreamPort = *streamPort
 	nginx.ProfilerPort = *profilerPort
@@ -299,10 +506,26 @@ https://blog.maxmind.com/2019/12/significant-changes-to-accessing-and-using-geol
 		return false, nil, fmt.Errorf("port %v is already in use. Please check the flag --ssl-passthrough-proxy-port", *sslProxyPort)
 	}
 
+	if *l4ShutdownGracePeriod < 0 {
+		return false, nil, errors.New("--l4-shutdown-grace-period must be positive")
+	}
+
+	if *sslPassthroughPoolSize < 0 {
+		return false, nil, errors.New("--ssl-passthrough-pool-size must be positive")
+	}
+
+	if *sslPassthroughPoolSize > 0 && !*enableSSLPassthrough {
+		return false, nil, errors.New("--ssl-passthrough-pool-size must be passed with --enable-ssl-passthrough=true")
+	}
+
 	if *publishSvc != "" && *publishStatusAddress != "" {
 		return false, nil, fmt.Errorf("flags --publish-service and --publish-status-address are mutually exclusive")
 	}
 
+	if *kubeAPIQPS <= 0 || *kubeAPIBurst <= 0 || *statusKubeAPIQPS <= 0 || *statusKubeAPIBurst <= 0 {
+		return false, nil, errors.New("flags --kube-api-qps, --kube-api-burst, --status-kube-api-qps and --status-kube-api-burst must be greater than 0")
+	}
+
 	nginx.HealthPath = *defHealthzURL
 
 	if *defHealthCheckTimeout > 0 {
@@ -326,6 +549,62 @@ https://blog.maxmind.com/2019/12/significant-changes-to-accessing-and-using-geol
 		return false, nil, errors.New("--metrics-per-undefined-host=true must be passed with --metrics-per-host=true")
 	}
 
+	if *crashRecoveryBisect && !*crashRecovery {
+		return false, nil, errors.New("--crash-recovery-bisect=true must be passed with --crash-recovery=true")
+	}
+
+	if *errorLogMetrics && !*enableMetrics {
+		return false, nil, errors.New("--error-log-metrics=true must be passed with --enable-metrics=true")
+	}
+
+	if *dynamicConfigurationChunkSize < 0 {
+		return false, nil, errors.New("--dynamic-configuration-chunk-size must not be negative")
+	}
+
+	if *luaSharedDictsUsageThreshold < 0 || *luaSharedDictsUsageThreshold >= 1 {
+		return false, nil, errors.New("--lua-shared-dicts-usage-threshold must be between 0 and 1")
+	}
+
+	if *diskUsageCheckPeriod < 0 {
+		return false, nil, errors.New("--disk-usage-check-period must not be negative")
+	}
+
+	if *cacheEvictionHighWatermark < 0 || *cacheEvictionHighWatermark >= 1 {
+		return false, nil, errors.New("--cache-eviction-high-watermark must be between 0 and 1")
+	}
+
+	if *cacheEvictionHighWatermark > 0 && *diskUsageCheckPeriod == 0 {
+		return false, nil, errors.New("--cache-eviction-high-watermark requires --disk-usage-check-period")
+	}
+
+	if *configDriftCheckPeriod < 0 {
+		return false, nil, errors.New("--config-drift-check-period must not be negative")
+	}
+
+	if *configHistorySize < 0 || *configHistoryRetention < 0 {
+		return false, nil, errors.New("--config-history-size and --config-history-retention must not be negative")
+	}
+
+	ingressShard := shard.Shard{Index: *shardIndex, Count: *shards}
+	if ingressShard.Enabled() {
+		if ingressShard.Index < 0 {
+			index, err := shard.IndexFromPodName(os.Getenv("POD_NAME"))
+			if err != nil {
+				return false, nil, fmt.Errorf("--shard-index is required when --shards is greater than 1: %w", err)
+			}
+			ingressShard.Index = index
+		}
+
+		// each shard updates the status of its own Ingress objects
+		*electionID = fmt.Sprintf("%v-shard-%v", *electionID, ingressShard.Index)
+	} else {
+		ingressShard.Index = 0
+	}
+
+	if err := ingressShard.Validate(); err != nil {
+		return false, nil, fmt.Errorf("invalid --shards or --shard-index: %w", err)
+	}
+
 	if *electionTTL <= 0 {
 		*electionTTL = 30 * time.Second
 	}
@@ -339,50 +618,90 @@ https://blog.maxmind.com/2019/12/significant-changes-to-accessing-and-using-geol
 	ngx_config.EnableSSLChainCompletion = *enableSSLChainCompletion
 
 	config := &controller.Configuration{
-		APIServerHost:               *apiserverHost,
-		KubeConfigFile:              *kubeConfigFile,
-		UpdateStatus:                *updateStatus,
-		ElectionID:                  *electionID,
-		ElectionTTL:                 *electionTTL,
-		EnableProfiling:             *profiling,
-		EnableMetrics:               *enableMetrics,
-		MetricsPerHost:              *metricsPerHost,
-		MetricsPerUndefinedHost:     *metricsPerUndefinedHost,
-		MetricsBuckets:              histogramBuckets,
-		MetricsBucketFactor:         *bucketFactor,
-		MetricsMaxBuckets:           *maxBuckets,
-		ReportStatusClasses:         *reportStatusClasses,
-		ExcludeSocketMetrics:        *excludeSocketMetrics,
-		MonitorMaxBatchSize:         *monitorMaxBatchSize,
-		DisableServiceExternalName:  *disableServiceExternalName,
-		EnableSSLPassthrough:        *enableSSLPassthrough,
-		DisableLeaderElection:       *disableLeaderElection,
-		ResyncPeriod:                *resyncPeriod,
-		DefaultService:              *defaultSvc,
-		Namespace:                   *watchNamespace,
-		WatchNamespaceSelector:      namespaceSelector,
-		ConfigMapName:               *configMap,
-		TCPConfigMapName:            *tcpConfigMapName,
-		UDPConfigMapName:            *udpConfigMapName,
-		DisableFullValidationTest:   *disableFullValidationTest,
-		DefaultSSLCertificate:       *defSSLCertificate,
-		DeepInspector:               *deepInspector,
-		PublishService:              *publishSvc,
-		PublishStatusAddress:        *publishStatusAddress,
-		UpdateStatusOnShutdown:      *updateStatusOnShutdown,
-		ShutdownGracePeriod:         *shutdownGracePeriod,
-		PostShutdownGracePeriod:     *postShutdownGracePeriod,
-		UseNodeInternalIP:           *useNodeInternalIP,
-		SyncRateLimit:               *syncRateLimit,
-		HealthCheckHost:             *healthzHost,
-		DynamicConfigurationRetries: *dynamicConfigurationRetries,
-		EnableTopologyAwareRouting:  *enableTopologyAwareRouting,
+		APIServerHost:                  *apiserverHost,
+		KubeConfigFile:                 *kubeConfigFile,
+		KubeAPIQPS:                     *kubeAPIQPS,
+		KubeAPIBurst:                   *kubeAPIBurst,
+		StatusKubeAPIQPS:               *statusKubeAPIQPS,
+		StatusKubeAPIBurst:             *statusKubeAPIBurst,
+		StatusServerSideApply:          *statusServerSideApply,
+		UpdateStatus:                   *updateStatus,
+		ElectionID:                     *electionID,
+		ElectionTTL:                    *electionTTL,
+		IngressClassLeaseNamespace:     *ingressClassLeaseNamespace,
+		EnableProfiling:                *profiling,
+		EnableMetrics:                  *enableMetrics,
+		MetricsPerHost:                 *metricsPerHost,
+		MetricsPerUndefinedHost:        *metricsPerUndefinedHost,
+		MetricsBuckets:                 histogramBuckets,
+		MetricsBucketFactor:            *bucketFactor,
+		MetricsMaxBuckets:              *maxBuckets,
+		ReportStatusClasses:            *reportStatusClasses,
+		ExcludeSocketMetrics:           *excludeSocketMetrics,
+		ErrorLogMetrics:                *errorLogMetrics,
+		MonitorMaxBatchSize:            *monitorMaxBatchSize,
+		DisableServiceExternalName:     *disableServiceExternalName,
+		EnableSSLPassthrough:           *enableSSLPassthrough,
+		SSLPassthroughPoolSize:         *sslPassthroughPoolSize,
+		DisableLeaderElection:          *disableLeaderElection,
+		ResyncPeriod:                   *resyncPeriod,
+		DefaultService:                 *defaultSvc,
+		Namespace:                      *watchNamespace,
+		WatchNamespaceSelector:         namespaceSelector,
+		WatchReferenceGrants:           *watchReferenceGrants,
+		WatchHTTPTransforms:            *watchHTTPTransforms,
+		ConfigMapName:                  strings.Join(*configMap, ","),
+		TCPConfigMapName:               *tcpConfigMapName,
+		UDPConfigMapName:               *udpConfigMapName,
+		DisableFullValidationTest:      *disableFullValidationTest,
+		AdmissionPoliciesConfigMap:     *admissionPoliciesConfigMap,
+		DefaultBackendAssetsConfigMap:  *defaultBackendAssetsConfigMap,
+		DefaultSSLCertificate:          *defSSLCertificate,
+		DeepInspector:                  *deepInspector,
+		HealthzDeepCheck:               *healthzDeepCheck,
+		CrashRecovery:                  *crashRecovery,
+		CrashRecoveryBisect:            *crashRecoveryBisect,
+		AdminAuth:                      *adminAuth,
+		AdminPort:                      *adminPort,
+		AdminTLSCertFile:               *adminTLSCertFile,
+		AdminTLSKeyFile:                *adminTLSKeyFile,
+		AdminClientCAFile:              *adminClientCAFile,
+		PublishService:                 *publishSvc,
+		PublishStatusAddress:           *publishStatusAddress,
+		UpdateStatusOnShutdown:         *updateStatusOnShutdown,
+		ShutdownGracePeriod:            *shutdownGracePeriod,
+		PostShutdownGracePeriod:        *postShutdownGracePeriod,
+		L4ShutdownGracePeriod:          *l4ShutdownGracePeriod,
+		UseNodeInternalIP:              *useNodeInternalIP,
+		SyncRateLimit:                  *syncRateLimit,
+		HealthCheckHost:                *healthzHost,
+		DynamicConfigurationRetries:    *dynamicConfigurationRetries,
+		DynamicConfigurationChunkSize:  *dynamicConfigurationChunkSize,
+		CompressDynamicConfiguration:   *compressDynamicConfiguration,
+		EnableTopologyAwareRouting:     *enableTopologyAwareRouting,
+		EnableIPv6Endpoints:            *enableIPv6Endpoints,
+		LBHealthCheckUnhealthyOnReload: *lbHealthCheckUnhealthyOnReload,
+		PreemptionSources:              *preemptionWatcher,
+		PreemptionTaintKeys:            *preemptionTaintKeys,
+		PreemptionPollInterval:         *preemptionPollInterval,
+		PreemptionDrainDelay:           *preemptionDrainDelay,
+		LuaSharedDictsUsageThreshold:   *luaSharedDictsUsageThreshold,
+		DiskUsageCheckPeriod:           *diskUsageCheckPeriod,
+		CacheEvictionHighWatermark:     *cacheEvictionHighWatermark,
+		BackendProtocolProbeInterval:   *backendProtocolProbeInterval,
+		ConfigDriftCheckPeriod:         *configDriftCheckPeriod,
+		ConfigHistorySize:              *configHistorySize,
+		ConfigHistoryRetention:         *configHistoryRetention,
+		Shard:                          ingressShard,
+		CommandLineFlags:               commandLineFlags,
+		ConfigFile:                     *configFile,
 		ListenPorts: &ngx_config.ListenPorts{
-			Default:  *defServerPort,
-			Health:   *healthzPort,
-			HTTP:     *httpPort,
-			HTTPS:    *httpsPort,
-			SSLProxy: *sslProxyPort,
+			Default:       *defServerPort,
+			Health:        *healthzPort,
+			HTTP:          *httpPort,
+			HTTPS:         *httpsPort,
+			SSLProxy:      *sslProxyPort,
+			LBHealthCheck: *lbHealthCheckPort,
 		},
 		IngressClassConfiguration: &ingressclass.Configuration{
 			Controller:         *ingressClassController,
@@ -391,6 +710,7 @@ https://blog.maxmind.com/2019/12/significant-changes-to-accessing-and-using-geol
 			IngressClassByName: *ingressClassByName,
 		},
 		DisableCatchAll:           *disableCatchAll,
+		DisallowWeakerTLS:         *disallowWeakerTLS,
 		ValidationWebhook:         *validationWebhook,
 		ValidationWebhookCertPath: *validationWebhookCert,
 		ValidationWebhookKeyPath:  *validationWebhookKey,
@@ -238,3 +238,82 @@ func TestMetricsPerUndefinedHostWithMetricsPerHostFalse(t *testing.T) {
 		t.Fatalf("Expected an error parsing flags but none returned")
 	}
 }
+
+func TestAdminAuth(t *testing.T) {
+	tests := []struct {
+		args    []string
+		isError bool
+	}{
+		{[]string{"--admin-auth=token"}, false},
+		{[]string{"--admin-auth=basic"}, true},
+		{[]string{"--admin-auth=mtls"}, true},
+	}
+
+	for _, tc := range tests {
+		ResetForTesting(func() { t.Fatal("Parsing failed") })
+
+		oldArgs := os.Args
+		os.Args = append([]string{"cmd"}, tc.args...)
+
+		_, _, err := ParseFlags()
+		if (err != nil) != tc.isError {
+			t.Errorf("expected error %v parsing %v but got %v", tc.isError, tc.args, err)
+		}
+
+		os.Args = oldArgs
+	}
+}
+
+func TestSSLPassthroughPoolSize(t *testing.T) {
+	tests := []struct {
+		args    []string
+		isError bool
+	}{
+		{[]string{"--ssl-passthrough-pool-size=0"}, false},
+		{[]string{"--ssl-passthrough-pool-size=-1"}, true},
+		{[]string{"--ssl-passthrough-pool-size=4"}, true},
+	}
+
+	for _, tc := range tests {
+		ResetForTesting(func() { t.Fatal("Parsing failed") })
+
+		oldArgs := os.Args
+		os.Args = append([]string{"cmd"}, tc.args...)
+
+		_, _, err := ParseFlags()
+		if (err != nil) != tc.isError {
+			t.Errorf("expected error %v parsing %v but got %v", tc.isError, tc.args, err)
+		}
+
+		os.Args = oldArgs
+	}
+}
+
+func TestKubeAPIRateLimits(t *testing.T) {
+	tests := []struct {
+		args    []string
+		isError bool
+	}{
+		{[]string{"--kube-api-qps=50", "--kube-api-burst=100"}, false},
+		{[]string{"--status-kube-api-qps=1", "--status-kube-api-burst=1"}, false},
+		{[]string{"--kube-api-qps=0"}, true},
+		{[]string{"--status-kube-api-burst=-1"}, true},
+	}
+
+	for _, tc := range tests {
+		ResetForTesting(func() { t.Fatal("Parsing failed") })
+
+		oldArgs := os.Args
+		os.Args = append([]string{"cmd"}, tc.args...)
+
+		_, conf, err := ParseFlags()
+		if (err != nil) != tc.isError {
+			t.Errorf("expected error %v parsing %v but got %v", tc.isError, tc.args, err)
+		}
+		if err == nil && (conf.KubeAPIQPS <= 0 || conf.StatusKubeAPIBurst <= 0) {
+			t.Errorf("expected the rate limits to be set parsing %v", tc.args)
+		}
+
+		os.Args = oldArgs
+	}
+}
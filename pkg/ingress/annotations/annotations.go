@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package annotations parses and validates the annotations of Ingresses with the
+// parsers of the controller, for the tools checking Ingresses outside of it. The
+// objects referenced by the annotations are found by a Resolver.
+package annotations
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+
+	"k8s.io/ingress-nginx/internal/ingress/annotations"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/parser"
+	"k8s.io/ingress-nginx/internal/ingress/defaults"
+	"k8s.io/ingress-nginx/internal/ingress/resolver"
+	"k8s.io/ingress-nginx/internal/ingress/transform"
+)
+
+// DefaultPrefix is the prefix of the annotations of the controller
+const DefaultPrefix = parser.DefaultAnnotationsPrefix
+
+type (
+	// Resolver finds the objects referenced by the annotations
+	Resolver = resolver.Resolver
+	// AuthSSLCert is the certificate of a Secret used to authenticate clients
+	AuthSSLCert = resolver.AuthSSLCert
+	// Backend are the defaults of the configuration ConfigMap
+	Backend = defaults.Backend
+	// SecurityConfiguration are the annotations and references allowed
+	SecurityConfiguration = defaults.SecurityConfiguration
+	// HTTPTransform is an HTTPTransform referenced by the http-transform annotation
+	HTTPTransform = transform.HTTPTransform
+
+	// Ingress are the parsed annotations of an Ingress
+	Ingress = annotations.Ingress
+	// Fields are the annotations, without prefix, of a parser
+	Fields = parser.AnnotationFields
+	// Risk is the risk of an annotation, checked against the annotations-risk-level
+	Risk = parser.AnnotationRisk
+)
+
+// Risks of the annotations
+const (
+	RiskLow      = parser.AnnotationRiskLow
+	RiskMedium   = parser.AnnotationRiskMedium
+	RiskHigh     = parser.AnnotationRiskHigh
+	RiskCritical = parser.AnnotationRiskCritical
+)
+
+// Options configures a Parser
+type Options struct {
+	// Prefix is the prefix of the annotations, DefaultPrefix when empty
+	Prefix string
+	// AuthDirectory is the directory of the files written for the basic
+	// authentication annotations, the temporary directory when empty
+	AuthDirectory string
+}
+
+// Parser parses the annotations of Ingresses like the controller
+type Parser struct {
+	prefix    string
+	extractor annotations.Extractor
+}
+
+// NewParser creates a Parser finding the referenced objects with the Resolver
+func NewParser(r Resolver, opts Options) *Parser {
+	if opts.Prefix == "" {
+		opts.Prefix = DefaultPrefix
+	}
+	if opts.AuthDirectory == "" {
+		opts.AuthDirectory = os.TempDir()
+	}
+
+	return &Parser{
+		prefix:    opts.Prefix,
+		extractor: annotations.NewExtractor(r, opts.AuthDirectory),
+	}
+}
+
+// Parse returns the parsed annotations of the Ingress, or the error the controller
+// rejects the Ingress with. Annotations referencing objects that can't be resolved
+// are reported in the Denied field, as the controller does.
+func (p *Parser) Parse(ing *networking.Ingress) (*Ingress, error) {
+	return p.extractor.Extract(p.translate(ing))
+}
+
+// Documentation returns the annotations, without prefix, of every parser
+func (p *Parser) Documentation() map[string]Fields {
+	return p.extractor.Documentation()
+}
+
+// Sources returns for every parser if it is configured by an annotation of the
+// Ingress or uses the defaults of the configuration ConfigMap
+func (p *Parser) Sources(ing *networking.Ingress) map[string]string {
+	return p.extractor.Sources(p.translate(ing))
+}
+
+// translate renames the annotations with the prefix of the Parser to the prefix
+// of the parsers, ignoring the annotations with the prefix of the parsers like a
+// controller configured with another prefix
+func (p *Parser) translate(ing *networking.Ingress) *networking.Ingress {
+	if p.prefix == parser.AnnotationsPrefix {
+		return ing
+	}
+
+	anns := make(map[string]string, len(ing.GetAnnotations()))
+	for name, value := range ing.GetAnnotations() {
+		if suffix, ok := strings.CutPrefix(name, p.prefix+"/"); ok {
+			anns[parser.GetAnnotationWithPrefix(suffix)] = value
+			continue
+		}
+		if strings.HasPrefix(name, parser.AnnotationsPrefix+"/") {
+			continue
+		}
+		anns[name] = value
+	}
+
+	ing = ing.DeepCopy()
+	ing.SetAnnotations(anns)
+
+	return ing
+}
+
+// ErrNotResolved is returned by the Offline resolver
+var ErrNotResolved = errors.New("references are not resolved")
+
+var _ Resolver = Offline{}
+
+// Offline is a Resolver for the tools without access to the cluster, the
+// annotations referencing other objects are denied
+type Offline struct {
+	// Backend are the defaults of the configuration ConfigMap
+	Backend Backend
+	// Security is the security configuration, the annotations-risk-level
+	// is Critical when empty
+	Security SecurityConfiguration
+}
+
+// GetDefaultBackend returns the defaults of the configuration ConfigMap
+func (o Offline) GetDefaultBackend() Backend {
+	return o.Backend
+}
+
+// GetSecurityConfiguration returns the security configuration
+func (o Offline) GetSecurityConfiguration() SecurityConfiguration {
+	if o.Security.AnnotationsRiskLevel == "" {
+		o.Security.AnnotationsRiskLevel = RiskCritical.ToString()
+	}
+	return o.Security
+}
+
+// GetConfigMap returns ErrNotResolved
+func (o Offline) GetConfigMap(string) (*apiv1.ConfigMap, error) {
+	return nil, ErrNotResolved
+}
+
+// GetSecret returns ErrNotResolved
+func (o Offline) GetSecret(string) (*apiv1.Secret, error) {
+	return nil, ErrNotResolved
+}
+
+// GetAuthCertificate returns ErrNotResolved
+func (o Offline) GetAuthCertificate(string) (*AuthSSLCert, error) {
+	return nil, ErrNotResolved
+}
+
+// GetService returns ErrNotResolved
+func (o Offline) GetService(string) (*apiv1.Service, error) {
+	return nil, ErrNotResolved
+}
+
+// IsReferenceGranted returns false
+func (o Offline) IsReferenceGranted(_, _, _, _ string) bool {
+	return false
+}
+
+// GetHTTPTransform returns ErrNotResolved
+func (o Offline) GetHTTPTransform(string) (*HTTPTransform, error) {
+	return nil, ErrNotResolved
+}
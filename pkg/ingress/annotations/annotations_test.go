@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func buildIngress(annotations map[string]string) *networking.Ingress {
+	return &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "foo",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	p := NewParser(Offline{}, Options{})
+
+	anns, err := p.Parse(buildIngress(map[string]string{"nginx.ingress.kubernetes.io/enable-cors": "true"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !anns.CorsConfig.CorsEnabled {
+		t.Errorf("expected CORS to be enabled")
+	}
+
+	_, err = p.Parse(buildIngress(map[string]string{"nginx.ingress.kubernetes.io/upstream-vhost": "a;b"}))
+	if err == nil {
+		t.Errorf("expected an error parsing an invalid value")
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	p := NewParser(Offline{}, Options{Prefix: "example.com"})
+
+	anns, err := p.Parse(buildIngress(map[string]string{"example.com/enable-cors": "true"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !anns.CorsConfig.CorsEnabled {
+		t.Errorf("expected CORS to be enabled with the prefix of the parser")
+	}
+
+	anns, err = p.Parse(buildIngress(map[string]string{"nginx.ingress.kubernetes.io/enable-cors": "true"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if anns.CorsConfig.CorsEnabled {
+		t.Errorf("expected the annotations with the default prefix to be ignored")
+	}
+}
+
+func TestOfflineRisk(t *testing.T) {
+	p := NewParser(Offline{Security: SecurityConfiguration{AnnotationsRiskLevel: RiskLow.ToString()}}, Options{})
+
+	_, err := p.Parse(buildIngress(map[string]string{"nginx.ingress.kubernetes.io/configuration-snippet": "more_set_headers \"Foo: bar\";"}))
+	if err == nil {
+		t.Errorf("expected an error parsing an annotation above the risk level")
+	}
+}
+
+func TestDocumentation(t *testing.T) {
+	docs := NewParser(Offline{}, Options{}).Documentation()
+	if _, ok := docs["CorsConfig"]["enable-cors"]; !ok {
+		t.Errorf("expected the documentation of the enable-cors annotation")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
+)
+
+// SaturationPath is the path of the endpoint returning the saturation of NGINX
+const SaturationPath = "/saturation"
+
+// Names of the metrics returned by the saturation endpoint
+const (
+	SaturationRatioMetric     = "nginx_saturation_ratio"
+	ActiveConnectionsMetric   = "nginx_active_connections"
+	ConnectionsCapacityMetric = "nginx_connections_capacity"
+	ListenDropsRateMetric     = "nginx_listen_drops_per_second"
+)
+
+// SaturationSource returns the saturation of NGINX
+type SaturationSource interface {
+	Saturation() (*collectors.Saturation, error)
+}
+
+// ExternalMetricValueList follows the format of the list of
+// metric values of the external.metrics.k8s.io/v1beta1 API
+type ExternalMetricValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ExternalMetricValue `json:"items"`
+}
+
+// ExternalMetricValue is a metric value of the external.metrics.k8s.io/v1beta1 API
+type ExternalMetricValue struct {
+	MetricName   string            `json:"metricName"`
+	MetricLabels map[string]string `json:"metricLabels"`
+	Timestamp    metav1.Time       `json:"timestamp"`
+	Value        resource.Quantity `json:"value"`
+}
+
+// RegisterSaturation exposes the saturation of NGINX in the format of the
+// external metrics API, so a metrics adapter can provide it to a
+// HorizontalPodAutoscaler. The metric query parameter returns a single metric.
+func RegisterSaturation(mux *http.ServeMux, source SaturationSource) {
+	mux.HandleFunc(SaturationPath, func(w http.ResponseWriter, r *http.Request) {
+		s, err := source.Saturation()
+		if err != nil {
+			klog.V(2).ErrorS(err, "Error obtaining NGINX saturation")
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		list := saturationMetrics(s, metav1.Now())
+		if name := r.URL.Query().Get("metric"); name != "" {
+			items := make([]ExternalMetricValue, 0, 1)
+			for _, item := range list.Items {
+				if item.MetricName == name {
+					items = append(items, item)
+				}
+			}
+
+			if len(items) == 0 {
+				http.Error(w, "unknown metric "+name, http.StatusNotFound)
+				return
+			}
+			list.Items = items
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(list); err != nil {
+			klog.V(2).ErrorS(err, "Error writing NGINX saturation")
+		}
+	})
+}
+
+func saturationMetrics(s *collectors.Saturation, now metav1.Time) *ExternalMetricValueList {
+	value := func(name string, q *resource.Quantity) ExternalMetricValue {
+		return ExternalMetricValue{
+			MetricName:   name,
+			MetricLabels: s.Labels,
+			Timestamp:    now,
+			Value:        *q,
+		}
+	}
+
+	return &ExternalMetricValueList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ExternalMetricValueList",
+			APIVersion: "external.metrics.k8s.io/v1beta1",
+		},
+		Items: []ExternalMetricValue{
+			value(SaturationRatioMetric, resource.NewMilliQuantity(int64(s.Ratio*1000), resource.DecimalSI)),
+			value(ActiveConnectionsMetric, resource.NewQuantity(int64(s.ActiveConnections), resource.DecimalSI)),
+			value(ConnectionsCapacityMetric, resource.NewQuantity(int64(s.Capacity), resource.DecimalSI)),
+			value(ListenDropsRateMetric, resource.NewMilliQuantity(int64(s.ListenDropsRate*1000), resource.DecimalSI)),
+		},
+	}
+}
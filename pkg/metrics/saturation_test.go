@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/ingress-nginx/internal/ingress/metric/collectors"
+)
+
+type fakeSaturationSource struct {
+	saturation *collectors.Saturation
+	err        error
+}
+
+func (f fakeSaturationSource) Saturation() (*collectors.Saturation, error) {
+	return f.saturation, f.err
+}
+
+func TestRegisterSaturation(t *testing.T) {
+	source := fakeSaturationSource{
+		saturation: &collectors.Saturation{
+			Labels:            map[string]string{"controller_pod": "pod"},
+			ActiveConnections: 300,
+			Capacity:          1000,
+			Ratio:             0.3,
+			ListenDropsRate:   1.5,
+		},
+	}
+
+	testCases := []struct {
+		title   string
+		source  SaturationSource
+		query   string
+		status  int
+		metrics map[string]string
+	}{
+		{"all the metrics", source, "", http.StatusOK, map[string]string{
+			SaturationRatioMetric:     "300m",
+			ActiveConnectionsMetric:   "300",
+			ConnectionsCapacityMetric: "1k",
+			ListenDropsRateMetric:     "1500m",
+		}},
+		{"single metric", source, "?metric=" + SaturationRatioMetric, http.StatusOK, map[string]string{
+			SaturationRatioMetric: "300m",
+		}},
+		{"unknown metric", source, "?metric=foo", http.StatusNotFound, nil},
+		{"metrics disabled", fakeSaturationSource{err: errors.New("metrics are disabled")}, "", http.StatusServiceUnavailable, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			mux := http.NewServeMux()
+			RegisterSaturation(mux, tc.source)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, SaturationPath+tc.query, http.NoBody))
+
+			if w.Code != tc.status {
+				t.Fatalf("expected status %v but got %v", tc.status, w.Code)
+			}
+			if tc.status != http.StatusOK {
+				return
+			}
+
+			var list ExternalMetricValueList
+			if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatalf("unexpected error decoding response: %v", err)
+			}
+
+			if list.Kind != "ExternalMetricValueList" {
+				t.Errorf("unexpected kind %v", list.Kind)
+			}
+			if len(list.Items) != len(tc.metrics) {
+				t.Fatalf("expected %v metrics but got %v", len(tc.metrics), len(list.Items))
+			}
+			for _, item := range list.Items {
+				if value := item.Value.String(); value != tc.metrics[item.MetricName] {
+					t.Errorf("expected %v for metric %v but got %v", tc.metrics[item.MetricName], item.MetricName, value)
+				}
+				if item.MetricLabels["controller_pod"] != "pod" {
+					t.Errorf("unexpected labels %v", item.MetricLabels)
+				}
+			}
+		})
+	}
+}
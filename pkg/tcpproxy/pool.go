@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tcpproxy
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// DefaultPoolMaxIdle is the time a connection opened in advance waits for a client.
+// It must be lower than the time NGINX waits for the data of a new connection.
+const DefaultPoolMaxIdle = 10 * time.Second
+
+// Pool keeps connections to the servers opened in advance, so the new
+// connections of the clients do not wait for the TCP handshake.
+type Pool struct {
+	size    int
+	maxIdle time.Duration
+
+	dial func(address string) (net.Conn, error)
+
+	mu      sync.Mutex
+	idle    map[string][]*pooledConn
+	filling map[string]bool
+}
+
+// pooledConn is an idle connection of the pool
+type pooledConn struct {
+	net.Conn
+	expire *time.Timer
+}
+
+// NewPool returns a pool keeping up to size idle connections by server
+func NewPool(size int, maxIdle time.Duration) *Pool {
+	return &Pool{
+		size:    size,
+		maxIdle: maxIdle,
+		dial: func(address string) (net.Conn, error) {
+			return net.DialTimeout("tcp", address, dialTimeout)
+		},
+		idle:    make(map[string][]*pooledConn),
+		filling: make(map[string]bool),
+	}
+}
+
+// Get returns an idle connection to the server, or a new one when there is no
+// idle connection, and opens new connections in the background to fill the pool.
+func (p *Pool) Get(address string) (net.Conn, error) {
+	p.mu.Lock()
+	var conn *pooledConn
+	if conns := p.idle[address]; len(conns) > 0 {
+		conn = conns[len(conns)-1]
+		p.idle[address] = conns[:len(conns)-1]
+		conn.expire.Stop()
+	}
+	p.mu.Unlock()
+
+	go p.fill(address)
+
+	if conn != nil {
+		return conn.Conn, nil
+	}
+
+	return p.dial(address)
+}
+
+// fill opens connections to the server until the pool is full
+func (p *Pool) fill(address string) {
+	p.mu.Lock()
+	if p.filling[address] {
+		p.mu.Unlock()
+		return
+	}
+	p.filling[address] = true
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		delete(p.filling, address)
+		p.mu.Unlock()
+	}()
+
+	for {
+		p.mu.Lock()
+		full := len(p.idle[address]) >= p.size
+		p.mu.Unlock()
+		if full {
+			return
+		}
+
+		conn, err := p.dial(address)
+		if err != nil {
+			klog.V(4).ErrorS(err, "Error opening a connection of the pool", "address", address)
+			return
+		}
+
+		pc := &pooledConn{Conn: conn}
+		p.mu.Lock()
+		pc.expire = time.AfterFunc(p.maxIdle, func() { p.remove(address, pc) })
+		p.idle[address] = append(p.idle[address], pc)
+		p.mu.Unlock()
+	}
+}
+
+// remove closes an idle connection when it expires
+func (p *Pool) remove(address string, conn *pooledConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conns := p.idle[address]
+	for i, c := range conns {
+		if c == conn {
+			p.idle[address] = append(conns[:i], conns[i+1:]...)
+			if len(p.idle[address]) == 0 {
+				delete(p.idle, address)
+			}
+			conn.Close()
+			return
+		}
+	}
+}
+
+// Idle returns the number of idle connections to the server
+func (p *Pool) Idle(address string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.idle[address])
+}
@@ -17,15 +17,41 @@ limitations under the License.
 package tcpproxy
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net"
+	"sync"
+	"time"
 
 	"k8s.io/klog/v2"
 
 	"pault.ag/go/sniff/parser"
 )
 
+const (
+	// helloTimeout is the time to receive the TLS ClientHello of a connection
+	helloTimeout = 10 * time.Second
+	// dialTimeout is the time to connect to the server of a connection
+	dialTimeout = 5 * time.Second
+
+	// See: https://www.ibm.com/docs/en/ztpf/1.1.0.15?topic=sessions-ssl-record-format
+	tlsRecordHeaderLen = 5
+	maxTLSRecordLen    = 16384
+	tlsHandshakeRecord = 0x16
+
+	// DefaultServerName is the name of the default server in the metrics
+	DefaultServerName = "nginx"
+)
+
+// helloBuffers are the buffers reading the TLS ClientHello of the connections
+var helloBuffers = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, tlsRecordHeaderLen+maxTLSRecordLen)
+		return &b
+	},
+}
+
 // TCPServer describes a server that works in passthrough mode.
 type TCPServer struct {
 	Hostname      string
@@ -34,10 +60,30 @@ type TCPServer struct {
 	ProxyProtocol bool
 }
 
+// Metrics receives the events of the connections handled by the proxy.
+type Metrics interface {
+	// PassthroughConnectionOpened is called when a connection is proxied to a server
+	PassthroughConnectionOpened(server string)
+	// PassthroughConnectionClosed is called when a proxied connection ends, with
+	// the bytes received from the client and the bytes sent to it
+	PassthroughConnectionClosed(server string, received, sent int64, duration time.Duration)
+	// PassthroughDialFailed is called when the proxy cannot connect to a server
+	PassthroughDialFailed(server string)
+}
+
 // TCPProxy describes the passthrough servers and a default as catch all.
 type TCPProxy struct {
 	ServerList []*TCPServer
 	Default    *TCPServer
+
+	// Metrics receives the events of the connections when it is not nil
+	Metrics Metrics
+	// Pool opens the connections to the default server in advance when it is not nil
+	Pool *Pool
+
+	mu sync.Mutex
+	// conns are the open connections of the clients
+	conns map[net.Conn]struct{}
 }
 
 // Get returns the TCPServer to use for a given host.
@@ -59,17 +105,25 @@ func (p *TCPProxy) Get(host string) *TCPServer {
 // and open a connection to the passthrough server.
 func (p *TCPProxy) Handle(conn net.Conn) {
 	defer conn.Close()
-	// See: https://www.ibm.com/docs/en/ztpf/1.1.0.15?topic=sessions-ssl-record-format
-	data := make([]byte, 16384)
 
-	length, err := conn.Read(data)
+	p.track(conn)
+	defer p.untrack(conn)
+
+	bufp, ok := helloBuffers.Get().(*[]byte)
+	if !ok {
+		return
+	}
+	defer helloBuffers.Put(bufp)
+	data := *bufp
+
+	length, err := readClientHello(conn, data)
 	if err != nil {
 		klog.V(4).ErrorS(err, "Error reading data from the connection")
 		return
 	}
 
 	proxy := p.Default
-	hostname, err := parser.GetHostname(data)
+	hostname, err := parser.GetHostname(data[:length])
 	if err == nil {
 		klog.V(4).InfoS("TLS Client Hello", "host", hostname)
 		proxy = p.Get(hostname)
@@ -80,11 +134,19 @@ func (p *TCPProxy) Handle(conn net.Conn) {
 		return
 	}
 
+	server := proxy.Hostname
+	if proxy == p.Default {
+		server = DefaultServerName
+	}
+
 	hostPort := net.JoinHostPort(proxy.IP, fmt.Sprintf("%v", proxy.Port))
 	klog.V(4).InfoS("passing to", "hostport", hostPort)
-	clientConn, err := net.Dial("tcp", hostPort)
+	clientConn, err := p.dial(proxy, hostPort)
 	if err != nil {
 		klog.V(4).ErrorS(err, "error dialing proxy", "ip", proxy.IP, "port", proxy.Port, "hostname", proxy.Hostname)
+		if p.Metrics != nil {
+			p.Metrics.PassthroughDialFailed(server)
+		}
 		return
 	}
 	defer clientConn.Close()
@@ -111,29 +173,140 @@ func (p *TCPProxy) Handle(conn net.Conn) {
 	}
 	if err != nil {
 		klog.ErrorS(err, "Error writing Proxy Protocol header")
-		clientConn.Close()
-	} else {
-		_, err = clientConn.Write(data[:length])
+		return
+	}
+
+	_, err = clientConn.Write(data[:length])
+	if err != nil {
+		klog.Errorf("Error writing the TLS Client Hello to the proxy: %v", err)
+		return
+	}
+
+	if p.Metrics != nil {
+		p.Metrics.PassthroughConnectionOpened(server)
+	}
+
+	start := time.Now()
+	received, sent := pipe(conn, clientConn)
+
+	if p.Metrics != nil {
+		p.Metrics.PassthroughConnectionClosed(server, int64(length)+received, sent, time.Since(start))
+	}
+}
+
+// Active returns the number of open connections of the clients.
+func (p *TCPProxy) Active() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.conns)
+}
+
+// CloseAll closes the open connections of the clients and returns their number.
+func (p *TCPProxy) CloseAll() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for conn := range p.conns {
+		conn.Close()
+	}
+
+	return len(p.conns)
+}
+
+func (p *TCPProxy) track(conn net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conns == nil {
+		p.conns = make(map[net.Conn]struct{})
+	}
+	p.conns[conn] = struct{}{}
+}
+
+func (p *TCPProxy) untrack(conn net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.conns, conn)
+}
+
+// dial opens a connection to the server, taking it from the pool for the default server
+func (p *TCPProxy) dial(proxy *TCPServer, hostPort string) (net.Conn, error) {
+	if p.Pool != nil && proxy == p.Default {
+		return p.Pool.Get(hostPort)
+	}
+
+	return net.DialTimeout("tcp", hostPort, dialTimeout)
+}
+
+// readClientHello reads the first TLS record of the connection, which contains
+// the ClientHello. The data of a connection not starting with a TLS handshake
+// record is returned as is, to be proxied to the default server.
+func readClientHello(conn net.Conn, data []byte) (int, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(helloTimeout)); err != nil {
+		return 0, err
+	}
+
+	length, err := io.ReadFull(conn, data[:tlsRecordHeaderLen])
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) && length > 0 {
+			return length, conn.SetReadDeadline(time.Time{})
+		}
+		return 0, err
+	}
+
+	if data[0] == tlsHandshakeRecord {
+		recordLen := int(data[3])<<8 | int(data[4])
+		if recordLen > maxTLSRecordLen {
+			recordLen = maxTLSRecordLen
+		}
+
+		n, err := io.ReadFull(conn, data[tlsRecordHeaderLen:tlsRecordHeaderLen+recordLen])
+		length += n
 		if err != nil {
-			klog.Errorf("Error writing the first 4k of proxy data: %v", err)
-			clientConn.Close()
+			return 0, err
 		}
 	}
 
-	pipe(clientConn, conn)
+	return length, conn.SetReadDeadline(time.Time{})
 }
 
-func pipe(client, server net.Conn) {
-	doCopy := func(s, c net.Conn, cancel chan<- bool) {
-		//nolint:errcheck // No need to catch these errors
-		io.Copy(s, c)
-		cancel <- true
-	}
+// pipe copies the data between the client and the server until both directions
+// of the connection are closed, and returns the bytes received from the client
+// and the bytes sent to it. Copying the data between two TCP connections uses
+// splice(2) on Linux, without copying the data to userland.
+func pipe(client, server net.Conn) (received, sent int64) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		sent = copyAndCloseWrite(client, server)
+	}()
+
+	received = copyAndCloseWrite(server, client)
+	wg.Wait()
+
+	return received, sent
+}
 
-	cancel := make(chan bool, 2)
+// copyAndCloseWrite copies the data from src to dst and closes the write side
+// of dst, so the peer reads the end of the stream and can still send its data.
+// An error closes both connections to stop the copy in the other direction.
+func copyAndCloseWrite(dst, src net.Conn) int64 {
+	n, err := io.Copy(dst, src)
+	if err != nil {
+		dst.Close()
+		src.Close()
+		return n
+	}
 
-	go doCopy(server, client, cancel)
-	go doCopy(client, server, cancel)
+	if cw, ok := dst.(interface{ CloseWrite() error }); ok {
+		if cw.CloseWrite() == nil {
+			return n
+		}
+	}
 
-	<-cancel
+	dst.Close()
+	return n
 }
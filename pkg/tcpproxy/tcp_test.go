@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tcpproxy
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeMetrics struct {
+	mu       sync.Mutex
+	opened   map[string]int
+	received int64
+	sent     int64
+	failed   map[string]int
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{opened: map[string]int{}, failed: map[string]int{}}
+}
+
+func (m *fakeMetrics) PassthroughConnectionOpened(server string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.opened[server]++
+}
+
+func (m *fakeMetrics) PassthroughConnectionClosed(_ string, received, sent int64, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.received += received
+	m.sent += sent
+}
+
+func (m *fakeMetrics) PassthroughDialFailed(server string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed[server]++
+}
+
+// echoServer answers with the data it receives until the client closes its side
+func echoServer(t *testing.T) (*net.TCPAddr, func()) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				//nolint:errcheck // the test checks the data received by the client
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	return l.Addr().(*net.TCPAddr), func() { l.Close() }
+}
+
+// proxyConn returns a connection handled by the proxy
+func proxyConn(t *testing.T, p *TCPProxy) (net.Conn, <-chan struct{}) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer l.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		p.Handle(conn)
+	}()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return conn, done
+}
+
+func TestReadClientHello(t *testing.T) {
+	record := append([]byte{tlsHandshakeRecord, 0x03, 0x01, 0x00, 0x08}, []byte("abcdefgh")...)
+
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go func() {
+		// the record is received in several packets
+		for _, b := range record {
+			//nolint:errcheck // the test checks the data read by the server
+			client.Write([]byte{b})
+		}
+	}()
+
+	data := make([]byte, tlsRecordHeaderLen+maxTLSRecordLen)
+	length, err := readClientHello(server, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(data[:length], record) {
+		t.Errorf("expected the record %q but got %q", record, data[:length])
+	}
+}
+
+func TestHandle(t *testing.T) {
+	addr, stop := echoServer(t)
+	defer stop()
+
+	metrics := newFakeMetrics()
+	p := &TCPProxy{
+		Default: &TCPServer{Hostname: "localhost", IP: "127.0.0.1", Port: addr.Port},
+		Metrics: metrics,
+	}
+
+	conn, done := proxyConn(t, p)
+	defer conn.Close()
+
+	msg := []byte("GET / HTTP/1.1\r\n\r\n")
+	if _, err := conn.Write(msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// closing the write side must not drop the answer of the server
+	if err := conn.(*net.TCPConn).CloseWrite(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	answer, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(answer, msg) {
+		t.Errorf("expected the answer %q but got %q", msg, answer)
+	}
+
+	<-done
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if metrics.opened[DefaultServerName] != 1 {
+		t.Errorf("expected one connection to the default server but got %v", metrics.opened)
+	}
+	if metrics.received != int64(len(msg)) || metrics.sent != int64(len(msg)) {
+		t.Errorf("expected %v bytes in both directions but got %v and %v", len(msg), metrics.received, metrics.sent)
+	}
+}
+
+func TestHandleDialError(t *testing.T) {
+	addr, stop := echoServer(t)
+	stop()
+
+	metrics := newFakeMetrics()
+	p := &TCPProxy{
+		Default: &TCPServer{Hostname: "localhost", IP: "127.0.0.1", Port: addr.Port},
+		Metrics: metrics,
+	}
+
+	conn, done := proxyConn(t, p)
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-done
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if metrics.failed[DefaultServerName] != 1 {
+		t.Errorf("expected a dial error but got %v", metrics.failed)
+	}
+}
+
+func TestPool(t *testing.T) {
+	addr, stop := echoServer(t)
+	defer stop()
+
+	p := NewPool(2, 200*time.Millisecond)
+
+	conn, err := p.Get(addr.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conn.Close()
+
+	waitFor := func(idle int) {
+		t.Helper()
+		for i := 0; i < 100; i++ {
+			if p.Idle(addr.String()) == idle {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("expected %v idle connections but got %v", idle, p.Idle(addr.String()))
+	}
+
+	waitFor(2)
+
+	conn, err = p.Get(addr.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	msg := []byte("hello")
+	if _, err := conn.Write(msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	answer := make([]byte, len(msg))
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the idle connections are closed when they expire
+	waitFor(0)
+}
+
+func TestCloseAll(t *testing.T) {
+	addr, stop := echoServer(t)
+	defer stop()
+
+	p := &TCPProxy{
+		Default: &TCPServer{Hostname: "localhost", IP: "127.0.0.1", Port: addr.Port},
+	}
+
+	conn, done := proxyConn(t, p)
+	defer conn.Close()
+
+	msg := []byte("hello")
+	if _, err := conn.Write(msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	answer := make([]byte, len(msg))
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if active := p.Active(); active != 1 {
+		t.Fatalf("expected one active connection but got %v", active)
+	}
+	if closed := p.CloseAll(); closed != 1 {
+		t.Errorf("expected one closed connection but got %v", closed)
+	}
+
+	<-done
+	if active := p.Active(); active != 0 {
+		t.Errorf("expected no active connection but got %v", active)
+	}
+	if _, err := conn.Read(answer); err == nil {
+		t.Errorf("expected the connection of the client to be closed")
+	}
+}
@@ -21,7 +21,7 @@ import (
 	"os"
 )
 
-const (
+var (
 	// AuthDirectory default directory used to store files
 	// to authenticate request
 	AuthDirectory = "/etc/ingress-controller/auth"
@@ -33,14 +33,18 @@ const (
 	DefaultSSLDirectory = "/etc/ingress-controller/ssl"
 )
 
-var directories = []string{
-	DefaultSSLDirectory,
-	AuthDirectory,
+// extraDirectories are the other directories written at runtime
+var extraDirectories []string
+
+// RequireDirectories adds directories created by CreateRequiredDirectories
+func RequireDirectories(dirs ...string) {
+	extraDirectories = append(extraDirectories, dirs...)
 }
 
 // CreateRequiredDirectories verifies if the required directories to
 // start the ingress controller exist and creates the missing ones.
 func CreateRequiredDirectories() error {
+	directories := append([]string{DefaultSSLDirectory, AuthDirectory}, extraDirectories...)
 	for _, directory := range directories {
 		_, err := os.Stat(directory)
 		if err != nil {
@@ -55,7 +59,23 @@ func CreateRequiredDirectories() error {
 
 			return fmt.Errorf("checking directory %s: %w", directory, err)
 		}
+
+		if err := checkWritable(directory); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// checkWritable returns an error when the directory does not accept new files,
+// e.g. when the container runs with an arbitrary UID or a read-only root filesystem
+func checkWritable(directory string) error {
+	f, err := os.CreateTemp(directory, ".write-check-")
+	if err != nil {
+		return fmt.Errorf("directory %s is not writable by uid %v, use --runtime-dir to point to a writable volume: %w", directory, os.Getuid(), err)
+	}
+
+	f.Close()
+	return os.Remove(f.Name())
+}
@@ -18,9 +18,11 @@ package ingress
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-nginx/internal/ingress/annotations/redirect"
 	"k8s.io/ingress-nginx/internal/k8s"
 	"k8s.io/ingress-nginx/internal/net/ssl"
 	"k8s.io/ingress-nginx/pkg/apis/ingress"
@@ -175,6 +177,38 @@ type Redirect struct {
 	SSLCert *ingress.SSLCert
 }
 
+// FromToWWWRedirectHost returns the host redirected to the server by the
+// from-to-www-redirect annotation. Hostnames that are IP addresses and
+// redirects in the opposite direction of the configured one are ignored.
+func FromToWWWRedirectHost(srv *ingress.Server) (string, bool) {
+	if !srv.RedirectFromToWWW {
+		return "", false
+	}
+
+	to := srv.Hostname
+	if net.ParseIP(strings.Trim(to, "[]")) != nil {
+		return "", false
+	}
+
+	toWWW := strings.HasPrefix(to, "www.")
+	switch srv.RedirectFromToWWWDirection {
+	case redirect.FromToWWWDirectionToWWW:
+		if !toWWW {
+			return "", false
+		}
+	case redirect.FromToWWWDirectionToApex:
+		if toWWW {
+			return "", false
+		}
+	}
+
+	if toWWW {
+		return strings.TrimPrefix(to, "www."), true
+	}
+
+	return fmt.Sprintf("www.%v", to), true
+}
+
 // BuildRedirects build the redirects of servers based on configurations and certificates
 func BuildRedirects(servers []*ingress.Server) []*Redirect {
 	names := sets.Set[string]{}
@@ -185,15 +219,14 @@ func BuildRedirects(servers []*ingress.Server) []*Redirect {
 			continue
 		}
 
-		to := srv.Hostname
-
-		var from string
-		if strings.HasPrefix(to, "www.") {
-			from = strings.TrimPrefix(to, "www.")
-		} else {
-			from = fmt.Sprintf("www.%v", to)
+		from, ok := FromToWWWRedirectHost(srv)
+		if !ok {
+			klog.Warningf("Skipping creation of redirection to/from www for the hostname %q (direction %q).", srv.Hostname, srv.RedirectFromToWWWDirection)
+			continue
 		}
 
+		to := srv.Hostname
+
 		if names.Has(to) {
 			continue
 		}
@@ -130,3 +130,33 @@ func TestIsDynamicConfigurationEnough(t *testing.T) {
 		t.Errorf("Expected new config to not change")
 	}
 }
+
+func TestBuildRedirects(t *testing.T) {
+	servers := []*ingress.Server{
+		{Hostname: "example.com", RedirectFromToWWW: true},
+		{Hostname: "www.foo.com", RedirectFromToWWW: true, RedirectFromToWWWDirection: "to-apex"},
+		{Hostname: "www.bar.com", RedirectFromToWWW: true, RedirectFromToWWWDirection: "to-www"},
+		{Hostname: "baz.com", RedirectFromToWWW: true, RedirectFromToWWWDirection: "to-apex"},
+		{Hostname: "10.0.0.1", RedirectFromToWWW: true},
+		{Hostname: "[::1]", RedirectFromToWWW: true},
+		{Hostname: "other.com", RedirectFromToWWW: true},
+		{Hostname: "www.other.com"},
+	}
+
+	expected := map[string]string{
+		"www.example.com": "example.com",
+		"bar.com":         "www.bar.com",
+		"www.baz.com":     "baz.com",
+	}
+
+	redirects := BuildRedirects(servers)
+	if len(redirects) != len(expected) {
+		t.Fatalf("expected %d redirects but got %d", len(expected), len(redirects))
+	}
+
+	for _, r := range redirects {
+		if expected[r.From] != r.To {
+			t.Errorf("unexpected redirect from %q to %q", r.From, r.To)
+		}
+	}
+}
@@ -30,10 +30,21 @@ type exiter func(code int)
 // HandleSigterm receives a ProcessController interface and deals with
 // the graceful shutdown
 func HandleSigterm(ngx Controller, delay int, exit exiter) {
+	HandleShutdown(ngx, delay, exit, nil)
+}
+
+// HandleShutdown deals with the graceful shutdown after receiving a SIGTERM
+// or a value in the drain channel, the reason to drain the controller
+func HandleShutdown(ngx Controller, delay int, exit exiter, drain <-chan string) {
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGTERM)
-	<-signalChan
-	klog.InfoS("Received SIGTERM, shutting down")
+
+	select {
+	case <-signalChan:
+		klog.InfoS("Received SIGTERM, shutting down")
+	case reason := <-drain:
+		klog.InfoS("Draining controller, shutting down", "reason", reason)
+	}
 
 	exitCode := 0
 	if err := ngx.Stop(); err != nil {
@@ -82,3 +82,14 @@ func TestHandleSigterm(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleShutdownDrain(t *testing.T) {
+	process := &FakeProcess{exitCode: -1}
+	drain := make(chan string, 1)
+	drain <- "node preemption"
+
+	HandleShutdown(process, 0, process.exiterFunc, drain)
+	if process.exitCode != 0 {
+		t.Errorf("wrong return, should be 0 and returned %d", process.exitCode)
+	}
+}
@@ -0,0 +1,71 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeCgroup creates a cgroup directory with the given files,
+// adding cgroup.controllers to the files to emulate cgroups v2
+func fakeCgroup(t *testing.T, version int, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if version == 2 {
+		files["cgroup.controllers"] = "cpu memory"
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("unexpected error writing %v: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+func TestNumCPUWithCustomPath(t *testing.T) {
+	testCases := []struct {
+		name     string
+		version  int
+		files    map[string]string
+		expected int
+	}{
+		{"cgroups v1 with quota", 1, map[string]string{"cpu.cfs_quota_us": "150000\n", "cpu.cfs_period_us": "100000\n"}, 2},
+		{"cgroups v1 without quota", 1, map[string]string{"cpu.cfs_quota_us": "-1\n", "cpu.cfs_period_us": "100000\n"}, runtime.NumCPU()},
+		{"cgroups v2 with quota", 2, map[string]string{"cpu.max": "300000 100000\n"}, 3},
+		{"cgroups v2 with quota without period", 2, map[string]string{"cpu.max": "400000\n"}, 4},
+		{"cgroups v2 without quota", 2, map[string]string{"cpu.max": "max 100000\n"}, runtime.NumCPU()},
+		{"cgroups v2 without cpu.max", 2, map[string]string{}, runtime.NumCPU()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := fakeCgroup(t, tc.version, tc.files)
+			if cpus := NumCPUWithCustomPath(path); cpus != tc.expected {
+				t.Errorf("expected %v CPUs but returned %v", tc.expected, cpus)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+const (
+	// sharedMemoryStep is the memory limit required to increase the size of the shared memory zones
+	sharedMemoryStep = int64(1024 * 1024 * 1024)
+	// maxSharedMemoryScale is the highest factor applied to the size of the shared memory zones
+	maxSharedMemoryScale = 4
+)
+
+// SharedMemoryScale returns the factor applied to the default sizes of the
+// shared memory zones of NGINX, like the Lua shared dicts and the SSL session
+// cache, for the given limit of memory in bytes. The defaults are used without
+// a limit or with less than 2GiB, and increase by one for each GiB up to 4GiB.
+func SharedMemoryScale(memoryLimit int64) int {
+	if memoryLimit < 0 {
+		return 1
+	}
+
+	scale := int(memoryLimit / sharedMemoryStep)
+	if scale < 1 {
+		return 1
+	}
+
+	if scale > maxSharedMemoryScale {
+		return maxSharedMemoryScale
+	}
+
+	return scale
+}
@@ -0,0 +1,76 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	libcontainercgroups "github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// unlimitedMemoryV1 is the lowest value of memory.limit_in_bytes considered unlimited.
+// Without a limit, cgroups v1 reports the maximum int64 rounded to the page size
+const unlimitedMemoryV1 = int64(1) << 62
+
+// MemoryLimit returns the limit of memory in bytes of the current process
+// configured in the memory cgroup, or -1 if there is no limit
+func MemoryLimit() int64 {
+	return MemoryLimitWithCustomPath("")
+}
+
+func MemoryLimitWithCustomPath(path string) int64 {
+	cgroupVersionCheckPath := path
+
+	if cgroupVersionCheckPath == "" {
+		cgroupVersionCheckPath = "/sys/fs/cgroup/"
+	}
+
+	if GetCgroupVersion(cgroupVersionCheckPath) == 2 {
+		cgroupPath := "/sys/fs/cgroup/"
+		if path != "" {
+			cgroupPath = path
+		}
+
+		contents, err := os.ReadFile(filepath.Join(cgroupPath, "memory.max"))
+		if err != nil || strings.TrimSpace(string(contents)) == "max" {
+			return -1
+		}
+
+		return readCgroupStringToInt64(string(contents))
+	}
+
+	cgroupPath := path
+	if cgroupPath == "" {
+		cgroupPathRd, err := libcontainercgroups.FindCgroupMountpoint("", "memory")
+		if err != nil {
+			return -1
+		}
+		cgroupPath = cgroupPathRd
+	}
+
+	limit := readCgroupFileToInt64(cgroupPath, "memory.limit_in_bytes")
+	if limit >= unlimitedMemoryV1 {
+		return -1
+	}
+
+	return limit
+}
@@ -0,0 +1,67 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import "testing"
+
+func TestMemoryLimitWithCustomPath(t *testing.T) {
+	testCases := []struct {
+		name     string
+		version  int
+		files    map[string]string
+		expected int64
+	}{
+		{"cgroups v1 with limit", 1, map[string]string{"memory.limit_in_bytes": "536870912\n"}, 536870912},
+		{"cgroups v1 without limit", 1, map[string]string{"memory.limit_in_bytes": "9223372036854771712\n"}, -1},
+		{"cgroups v1 without memory.limit_in_bytes", 1, map[string]string{}, -1},
+		{"cgroups v2 with limit", 2, map[string]string{"memory.max": "1073741824\n"}, 1073741824},
+		{"cgroups v2 without limit", 2, map[string]string{"memory.max": "max\n"}, -1},
+		{"cgroups v2 without memory.max", 2, map[string]string{}, -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := fakeCgroup(t, tc.version, tc.files)
+			if limit := MemoryLimitWithCustomPath(path); limit != tc.expected {
+				t.Errorf("expected a limit of %v but returned %v", tc.expected, limit)
+			}
+		})
+	}
+}
+
+func TestSharedMemoryScale(t *testing.T) {
+	testCases := []struct {
+		memoryLimit int64
+		expected    int
+	}{
+		{-1, 1},
+		{256 * 1024 * 1024, 1},
+		{1024 * 1024 * 1024, 1},
+		{2 * 1024 * 1024 * 1024, 2},
+		{3 * 1024 * 1024 * 1024, 3},
+		{16 * 1024 * 1024 * 1024, 4},
+	}
+
+	for _, tc := range testCases {
+		if scale := SharedMemoryScale(tc.memoryLimit); scale != tc.expected {
+			t.Errorf("expected a scale of %v for %v bytes but returned %v", tc.expected, tc.memoryLimit, scale)
+		}
+	}
+}
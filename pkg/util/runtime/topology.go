@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"runtime"
+	"strings"
+
+	"golang.org/x/sys/cpu"
+)
+
+// CPUTopology describes the CPUs usable by the process
+type CPUTopology struct {
+	// Architecture is the architecture of the CPUs, like amd64 or arm64
+	Architecture string `json:"architecture"`
+	// Clusters are the groups of CPUs with the same capacity, from the fastest.
+	// Homogeneous CPUs have one cluster, big.LITTLE ARM CPUs have several.
+	Clusters []CPUCluster `json:"clusters"`
+	// AES is true when the CPUs accelerate AES, with AES-NI on x86
+	// or the Cryptography Extensions on ARMv8
+	AES bool `json:"aes"`
+}
+
+// CPUCluster is a group of CPUs with the same capacity
+type CPUCluster struct {
+	// CPUs are the numbers of the CPUs
+	CPUs []int `json:"cpus"`
+	// Capacity is the relative performance of the CPUs, from the
+	// cpu_capacity of the kernel or the maximum frequency, 0 if unknown
+	Capacity int64 `json:"capacity"`
+}
+
+// Heterogeneous returns true when the CPUs do not have the same capacity
+func (t CPUTopology) Heterogeneous() bool {
+	return len(t.Clusters) > 1
+}
+
+// AffinityMask returns the CPUs of the cluster as a mask of worker_cpu_affinity,
+// where the rightmost digit is the first CPU
+func (c CPUCluster) AffinityMask() string {
+	highest := -1
+	for _, n := range c.CPUs {
+		highest = max(highest, n)
+	}
+
+	if highest < 0 {
+		return ""
+	}
+
+	mask := []byte(strings.Repeat("0", highest+1))
+	for _, n := range c.CPUs {
+		mask[highest-n] = '1'
+	}
+
+	return string(mask)
+}
+
+// hasAES returns true when the CPU has instructions to accelerate AES
+func hasAES() bool {
+	switch runtime.GOARCH {
+	case "amd64", "386":
+		return cpu.X86.HasAES
+	case "arm64":
+		return cpu.ARM64.HasAES
+	case "s390x":
+		return cpu.S390X.HasAES
+	case "ppc64le", "ppc64":
+		// AES instructions are part of POWER8 and later
+		return cpu.PPC64.IsPOWER8
+	}
+
+	return false
+}
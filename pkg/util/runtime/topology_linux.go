@@ -0,0 +1,142 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// sysCPUPath is the directory with the information of the CPUs in sysfs
+	sysCPUPath = "/sys/devices/system/cpu"
+	// selfStatusPath contains the CPUs the process is allowed to run on
+	selfStatusPath = "/proc/self/status"
+
+	topologyOnce sync.Once
+	topology     CPUTopology
+)
+
+// GetCPUTopology returns the topology of the CPUs usable by the process.
+// The topology is detected on the first call.
+func GetCPUTopology() CPUTopology {
+	topologyOnce.Do(func() {
+		topology = detectCPUTopology()
+	})
+
+	return topology
+}
+
+func detectCPUTopology() CPUTopology {
+	byCapacity := map[int64][]int{}
+	for _, n := range allowedCPUs() {
+		capacity := cpuCapacity(n)
+		byCapacity[capacity] = append(byCapacity[capacity], n)
+	}
+
+	clusters := make([]CPUCluster, 0, len(byCapacity))
+	for capacity, cpus := range byCapacity {
+		sort.Ints(cpus)
+		clusters = append(clusters, CPUCluster{CPUs: cpus, Capacity: capacity})
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Capacity > clusters[j].Capacity
+	})
+
+	return CPUTopology{
+		Architecture: runtime.GOARCH,
+		Clusters:     clusters,
+		AES:          hasAES(),
+	}
+}
+
+// allowedCPUs returns the CPUs the process is allowed to run on,
+// from the Cpus_allowed_list of the process, or all the CPUs
+func allowedCPUs() []int {
+	if content, err := os.ReadFile(selfStatusPath); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			value, ok := strings.CutPrefix(line, "Cpus_allowed_list:")
+			if !ok {
+				continue
+			}
+
+			if cpus, err := parseCPUList(strings.TrimSpace(value)); err == nil && len(cpus) > 0 {
+				return cpus
+			}
+		}
+	}
+
+	cpus := make([]int, runtime.NumCPU())
+	for i := range cpus {
+		cpus[i] = i
+	}
+
+	return cpus
+}
+
+// parseCPUList parses a list of CPUs in the format of the kernel, like 0-3,6
+func parseCPUList(list string) ([]int, error) {
+	cpus := []int{}
+	for _, item := range strings.Split(list, ",") {
+		if item == "" {
+			continue
+		}
+
+		first, last, isRange := strings.Cut(item, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU list %q: %w", list, err)
+		}
+
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(last)
+			if err != nil || end < start {
+				return nil, fmt.Errorf("invalid CPU list %q", list)
+			}
+		}
+
+		for n := start; n <= end; n++ {
+			cpus = append(cpus, n)
+		}
+	}
+
+	return cpus, nil
+}
+
+// cpuCapacity returns the capacity of the CPU set by the kernel on
+// heterogeneous ARM CPUs, or its maximum frequency, 0 if unknown
+func cpuCapacity(n int) int64 {
+	dir := filepath.Join(sysCPUPath, fmt.Sprintf("cpu%d", n))
+	for _, file := range []string{"cpu_capacity", "cpufreq/cpuinfo_max_freq"} {
+		if value := readCgroupFileToInt64(dir, file); value > 0 {
+			return value
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,97 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetectCPUTopology(t *testing.T) {
+	dir := t.TempDir()
+	// big.LITTLE with 4 efficiency cores, 2 performance cores and 1 CPU without capacity
+	capacities := map[string]string{"cpu0": "446", "cpu1": "446", "cpu2": "446", "cpu3": "446", "cpu4": "1024", "cpu5": "1024"}
+	for name, capacity := range capacities {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name, "cpu_capacity"), []byte(capacity+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "cpu6", "cpufreq"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cpu6", "cpufreq", "cpuinfo_max_freq"), []byte("2000000\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	status := filepath.Join(t.TempDir(), "status")
+	if err := os.WriteFile(status, []byte("Name:\tnginx\nCpus_allowed:\t7f\nCpus_allowed_list:\t0-2,4-6\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(cpuPath, statusPath string) {
+		sysCPUPath, selfStatusPath = cpuPath, statusPath
+	}(sysCPUPath, selfStatusPath)
+	sysCPUPath, selfStatusPath = dir, status
+
+	topology := detectCPUTopology()
+	expected := []CPUCluster{
+		{CPUs: []int{6}, Capacity: 2000000},
+		{CPUs: []int{4, 5}, Capacity: 1024},
+		{CPUs: []int{0, 1, 2}, Capacity: 446},
+	}
+	if !reflect.DeepEqual(topology.Clusters, expected) {
+		t.Errorf("expected clusters %v but returned %v", expected, topology.Clusters)
+	}
+	if !topology.Heterogeneous() {
+		t.Errorf("expected an heterogeneous topology")
+	}
+	if mask := topology.Clusters[1].AffinityMask(); mask != "110000" {
+		t.Errorf("expected the mask 110000 but returned %v", mask)
+	}
+}
+
+func TestParseCPUList(t *testing.T) {
+	testCases := []struct {
+		list     string
+		expected []int
+		err      bool
+	}{
+		{"0", []int{0}, false},
+		{"0-3", []int{0, 1, 2, 3}, false},
+		{"0-1,4,6-7", []int{0, 1, 4, 6, 7}, false},
+		{"3-1", nil, true},
+		{"a", nil, true},
+	}
+
+	for _, tc := range testCases {
+		cpus, err := parseCPUList(tc.list)
+		if (err != nil) != tc.err {
+			t.Errorf("%v: unexpected error %v", tc.list, err)
+		}
+		if !tc.err && !reflect.DeepEqual(cpus, tc.expected) {
+			t.Errorf("%v: expected %v but returned %v", tc.list, tc.expected, cpus)
+		}
+	}
+}
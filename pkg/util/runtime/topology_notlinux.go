@@ -0,0 +1,39 @@
+//go:build !linux
+// +build !linux
+
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"runtime"
+)
+
+// GetCPUTopology returns the CPUs usable by the process as one cluster
+// of unknown capacity, as the topology is only detected on Linux.
+func GetCPUTopology() CPUTopology {
+	cpus := make([]int, runtime.NumCPU())
+	for i := range cpus {
+		cpus[i] = i
+	}
+
+	return CPUTopology{
+		Architecture: runtime.GOARCH,
+		Clusters:     []CPUCluster{{CPUs: cpus}},
+		AES:          hasAES(),
+	}
+}
@@ -0,0 +1,273 @@
+local ngx = ngx
+local pairs = pairs
+local ipairs = ipairs
+local tostring = tostring
+local string_byte = string.byte
+local string_char = string.char
+local table_concat = table.concat
+local math_floor = math.floor
+local math_ldexp = math.ldexp
+local math_huge = math.huge
+
+local _M = {}
+
+local DICT_NAME = "anomaly_scores"
+-- internal location of the server proxying the scoring requests with grpc_pass
+local SCORE_URI = "/ingress_nginx.anomaly.v1.Scorer/Score"
+
+-- fields of the ScoreRequest message, in the order of their numbers
+local REQUEST_FIELDS = {
+  "client_ip",
+  "method",
+  "host",
+  "path",
+  "user_agent",
+  "tls_ja4",
+  "namespace",
+  "ingress",
+}
+
+-- scoring settings passed by the controller
+local config = {}
+
+function _M.set_config(new_config)
+  config = new_config or {}
+end
+
+local function encode_varint(value)
+  local out = {}
+  while value >= 0x80 do
+    out[#out + 1] = string_char(value % 0x80 + 0x80)
+    value = math_floor(value / 0x80)
+  end
+  out[#out + 1] = string_char(value)
+  return table_concat(out)
+end
+
+local function decode_varint(data, pos)
+  local value, multiplier = 0, 1
+  while pos <= #data do
+    local byte = string_byte(data, pos)
+    pos = pos + 1
+    value = value + (byte % 0x80) * multiplier
+    if byte < 0x80 then
+      return value, pos
+    end
+    multiplier = multiplier * 0x80
+  end
+  return nil, "truncated varint"
+end
+
+-- decodes the little-endian IEEE 754 double at the position of the data
+local function decode_double(data, pos)
+  local b1, b2, b3, b4, b5, b6, b7, b8 = string_byte(data, pos, pos + 7)
+  local sign = b8 >= 0x80 and -1 or 1
+  local exponent = (b8 % 0x80) * 16 + math_floor(b7 / 16)
+  local mantissa = b7 % 16
+  for _, byte in ipairs({ b6, b5, b4, b3, b2, b1 }) do
+    mantissa = mantissa * 256 + byte
+  end
+
+  if exponent == 0 then
+    return sign * math_ldexp(mantissa, -1074)
+  end
+  if exponent == 0x7ff then
+    if mantissa == 0 then
+      return sign * math_huge
+    end
+    return nil, "score is not a number"
+  end
+  return sign * math_ldexp(mantissa + 2 ^ 52, exponent - 1075)
+end
+
+-- encode returns the gRPC message of the ScoreRequest with the metadata,
+-- a 5 bytes prefix with the compression flag and the length of the message
+function _M.encode(metadata)
+  local fields = {}
+  for number, name in ipairs(REQUEST_FIELDS) do
+    local value = metadata[name]
+    if value and value ~= "" then
+      fields[#fields + 1] = string_char(number * 8 + 2) .. encode_varint(#value) .. value
+    end
+  end
+
+  local message = table_concat(fields)
+  local length = #message
+  return string_char(0,
+    math_floor(length / 0x1000000) % 256, math_floor(length / 0x10000) % 256,
+    math_floor(length / 0x100) % 256, length % 256) .. message
+end
+
+-- decode returns the score of the gRPC message of a ScoreResponse,
+-- 0 when the score is the default value of the field
+function _M.decode(body)
+  if not body or #body < 5 then
+    return nil, "truncated message"
+  end
+  if string_byte(body, 1) ~= 0 then
+    return nil, "compressed messages are not supported"
+  end
+
+  local b2, b3, b4, b5 = string_byte(body, 2, 5)
+  local last = 5 + ((b2 * 256 + b3) * 256 + b4) * 256 + b5
+  if #body < last then
+    return nil, "truncated message"
+  end
+
+  local score = 0
+  local pos = 6
+  while pos <= last do
+    local key, err = decode_varint(body, pos)
+    if not key then
+      return nil, err
+    end
+    pos = err
+
+    local number, wire_type = math_floor(key / 8), key % 8
+    if wire_type == 0 then
+      local value, next_pos = decode_varint(body, pos)
+      if not value then
+        return nil, next_pos
+      end
+      pos = next_pos
+    elseif wire_type == 1 then
+      if pos + 7 > last then
+        return nil, "truncated message"
+      end
+      if number == 1 then
+        score, err = decode_double(body, pos)
+        if not score then
+          return nil, err
+        end
+      end
+      pos = pos + 8
+    elseif wire_type == 2 then
+      local length, next_pos = decode_varint(body, pos)
+      if not length then
+        return nil, next_pos
+      end
+      pos = next_pos + length
+    elseif wire_type == 5 then
+      pos = pos + 4
+    else
+      return nil, "unsupported wire type " .. wire_type
+    end
+  end
+
+  if pos ~= last + 1 then
+    return nil, "truncated message"
+  end
+
+  return score
+end
+
+local function metadata()
+  local var = ngx.var
+  return {
+    client_ip = var.remote_addr,
+    method = ngx.req.get_method(),
+    host = var.host,
+    path = var.uri,
+    user_agent = var.http_user_agent,
+    -- only defined when enable-tls-fingerprint is set
+    tls_ja4 = var.tls_ja4,
+    namespace = var.namespace,
+    ingress = var.ingress_name,
+  }
+end
+
+local function cache_key(data)
+  local values = {}
+  for i, name in ipairs(REQUEST_FIELDS) do
+    values[i] = data[name] or ""
+  end
+  return ngx.md5(table_concat(values, "\0"))
+end
+
+local function fetch(data)
+  local res = ngx.location.capture(SCORE_URI, {
+    method = ngx.HTTP_POST,
+    body = _M.encode(data),
+  })
+
+  if res.status ~= ngx.HTTP_OK then
+    return nil, "unexpected status " .. tostring(res.status)
+  end
+  local grpc_status = res.header["grpc-status"]
+  if grpc_status and grpc_status ~= "0" then
+    return nil, "unexpected grpc-status " .. grpc_status
+  end
+  if res.truncated then
+    return nil, "truncated response"
+  end
+
+  return _M.decode(res.body)
+end
+
+-- score returns the score of the request, from the cache when a request
+-- with the same metadata was scored in the last cache_ttl seconds
+local function score()
+  local data = metadata()
+  local dict = ngx.shared[DICT_NAME]
+  local ttl = config.cache_ttl or 0
+
+  local key
+  if dict and ttl > 0 then
+    key = cache_key(data)
+    local cached = dict:get(key)
+    if cached then
+      return cached
+    end
+  end
+
+  local value, err = fetch(data)
+  if not value then
+    return nil, err
+  end
+
+  if key then
+    local ok, set_err = dict:safe_set(key, value, ttl)
+    if not ok then
+      ngx.log(ngx.WARN, "failed to cache the anomaly score: ", set_err)
+    end
+  end
+
+  return value
+end
+
+-- rewrite gets called in the rewrite phase, sets the anomaly_score variable
+-- and rejects the request when its score reaches the threshold. The requests
+-- are let through without a score when the scoring service fails.
+function _M.rewrite()
+  if not config.enabled or ngx.req.is_internal() then
+    return
+  end
+
+  local value, err = score()
+  if not value then
+    ngx.log(ngx.INFO, "failed to score the request: ", err)
+    return
+  end
+
+  ngx.var.anomaly_score = value
+
+  local threshold = config.threshold or 0
+  if threshold > 0 and value >= threshold then
+    ngx.log(ngx.WARN, "rejected the request with the anomaly score ", value)
+    return ngx.exit(ngx.HTTP_FORBIDDEN)
+  end
+end
+
+-- clear_headers gets called in the rewrite phase of the scoring location so
+-- the headers of the client are not sent to the scoring service, the
+-- subrequest has its own copy of the headers
+function _M.clear_headers()
+  for name in pairs(ngx.req.get_headers(0, true)) do
+    -- the length of the body set by ngx.location.capture
+    if name:lower() ~= "content-length" then
+      ngx.req.clear_header(name)
+    end
+  end
+end
+
+return _M
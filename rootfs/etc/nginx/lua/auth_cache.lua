@@ -0,0 +1,159 @@
+local memcached = require("resty.memcached")
+local ngx_re_split = require("ngx.re").split
+
+local ngx = ngx
+local ipairs = ipairs
+local tonumber = tonumber
+local tostring = tostring
+local unpack = unpack
+local string_match = string.match
+local string_gmatch = string.gmatch
+
+local _M = {}
+
+local KEY_PREFIX = "ingress-nginx-auth:"
+local DEFAULT_STATUSES = { "200", "301", "302" }
+local UNITS = { s = 1, m = 60, h = 3600, d = 86400 }
+
+-- memcached connection settings passed by the controller
+local config = {}
+
+function _M.set_config(new_config)
+  config = new_config or {}
+end
+
+local function parse_time(value)
+  local amount, unit = string_match(value, "^(%d+)([smhd]?)$")
+  if not amount then
+    return nil
+  end
+  return tonumber(amount) * UNITS[unit ~= "" and unit or "s"]
+end
+
+-- ttl returns the number of seconds a response with the given status
+-- is kept, following the proxy_cache_valid syntax of the
+-- auth-cache-duration annotation, e.g. "200 202 10m,401 30s,any 1m".
+function _M.ttl(status, durations)
+  if not durations or durations == "" then
+    return nil
+  end
+
+  status = tostring(status)
+
+  local any
+  for _, entry in ipairs(ngx_re_split(durations, ",")) do
+    local parts = {}
+    for part in string_gmatch(entry, "%S+") do
+      parts[#parts + 1] = part
+    end
+    local seconds = #parts > 0 and parse_time(parts[#parts])
+    if seconds then
+      local codes = DEFAULT_STATUSES
+      if #parts > 1 then
+        codes = { unpack(parts, 1, #parts - 1) }
+      end
+      for _, code in ipairs(codes) do
+        if code == status then
+          return seconds
+        end
+        if code == "any" and not any then
+          any = seconds
+        end
+      end
+    end
+  end
+
+  return any
+end
+
+local function connect()
+  if not config.host or config.host == "" then
+    return nil, "memcached host is not configured"
+  end
+
+  local memc, err = memcached:new()
+  if not memc then
+    return nil, err
+  end
+  memc:set_timeout(config.connect_timeout)
+
+  local ok
+  ok, err = memc:connect(config.host, config.port)
+  if not ok then
+    return nil, err
+  end
+  return memc
+end
+
+local function release(memc)
+  local ok, err = memc:set_keepalive(config.max_idle_timeout, config.pool_size)
+  if not ok then
+    ngx.log(ngx.WARN, "failed to keep the memcached connection alive: ", err)
+  end
+end
+
+local function set(premature, key, status, ttl)
+  if premature then
+    return
+  end
+
+  local memc, err = connect()
+  if not memc then
+    ngx.log(ngx.ERR, "failed to connect to memcached: ", err)
+    return
+  end
+
+  local ok
+  ok, err = memc:set(key, status, ttl)
+  if not ok then
+    ngx.log(ngx.ERR, "failed to store the auth decision in memcached: ", err)
+  end
+  release(memc)
+end
+
+-- lookup gets called in the access phase of the external auth location
+-- and answers the subrequest with a decision stored by any replica.
+function _M.lookup()
+  local memc, err = connect()
+  if not memc then
+    ngx.log(ngx.ERR, "failed to connect to memcached: ", err)
+    return
+  end
+
+  local status
+  status, err = memc:get(KEY_PREFIX .. ngx.var.cache_key)
+  release(memc)
+  if err then
+    ngx.log(ngx.ERR, "failed to read the auth decision from memcached: ", err)
+    return
+  end
+
+  status = tonumber(status)
+  if not status then
+    return
+  end
+
+  ngx.ctx.auth_cache_hit = true
+  ngx.status = status
+  ngx.exit(status)
+end
+
+-- store gets called in the header filter phase of the external auth
+-- location and shares the decision of the auth service with other replicas.
+function _M.store()
+  if ngx.ctx.auth_cache_hit or ngx.var.upstream_cache_status == "HIT" then
+    return
+  end
+
+  local ttl = _M.ttl(ngx.status, ngx.var.auth_cache_duration)
+  if not ttl then
+    return
+  end
+
+  local ok, err = ngx.timer.at(0, set, KEY_PREFIX .. ngx.var.cache_key, ngx.status, ttl)
+  if not ok then
+    ngx.log(ngx.ERR, "failed to create timer to store the auth decision: ", err)
+  end
+end
+
+return _M
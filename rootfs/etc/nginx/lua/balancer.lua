@@ -9,6 +9,7 @@ local chashsubset = require("balancer.chashsubset")
 local sticky_balanced = require("balancer.sticky_balanced")
 local sticky_persistent = require("balancer.sticky_persistent")
 local ewma = require("balancer.ewma")
+local route_if = require("route_if")
 local string = string
 local ipairs = ipairs
 local table = table
@@ -41,6 +42,8 @@ local _M = {}
 local balancers = {}
 local backends_with_external_name = {}
 local backends_last_synced_at = 0
+-- readable names of the upstreams by hash, with hash-upstream-names
+local readable_upstream_names = {}
 
 local function get_implementation(backend)
   local name = backend["load-balance"] or DEFAULT_LB_ALG
@@ -85,11 +88,17 @@ local function resolve_external_names(original_backend)
   return backend
 end
 
+-- is_ipv6_address returns true for the IPv6 addresses not wrapped yet
+-- into square brackets, hostnames and IPv4 addresses have no colon
+local function is_ipv6_address(address)
+  return address:find(":", 1, true) ~= nil and address:sub(1, 1) ~= "["
+end
+
 local function format_ipv6_endpoints(endpoints)
   local formatted_endpoints = {}
   for _, endpoint in ipairs(endpoints) do
     local formatted_endpoint = endpoint
-    if not endpoint.address:match("^%d+.%d+.%d+.%d+$") then
+    if is_ipv6_address(endpoint.address) then
       formatted_endpoint.address = string.format("[%s]", endpoint.address)
     end
     table.insert(formatted_endpoints, formatted_endpoint)
@@ -162,7 +171,11 @@ local function sync_backends()
   end
 
   local balancers_to_keep = {}
+  local new_readable_upstream_names = {}
   for _, new_backend in ipairs(new_backends) do
+    if new_backend.readableName then
+      new_readable_upstream_names[new_backend.name] = new_backend.readableName
+    end
     if is_backend_with_external_name(new_backend) then
       local backend_with_external_name = util.deepcopy(new_backend)
       backends_with_external_name[backend_with_external_name.name] = backend_with_external_name
@@ -178,9 +191,21 @@ local function sync_backends()
       backends_with_external_name[backend_name] = nil
     end
   end
+  readable_upstream_names = new_readable_upstream_names
   backends_last_synced_at = raw_backends_last_synced_at
 end
 
+-- readable_upstream_name returns the name of the upstream before hashing it
+-- with hash-upstream-names, or the given name when it is not a hash
+function _M.readable_upstream_name(name)
+  return readable_upstream_names[name] or name
+end
+
+-- is_synced returns true when the worker synced the last backends
+local function is_synced()
+  return backends_last_synced_at >= configuration.get_raw_backends_last_synced_at()
+end
+
 local function route_to_alternative_balancer(balancer)
   if balancer.is_affinitized(balancer) then
     -- If request is already affinitized to a primary balancer, keep the primary balancer.
@@ -282,7 +307,12 @@ local function get_balancer()
     return nil
   end
 
-  if route_to_alternative_balancer(balancer) then
+  -- the route-if rules take precedence over the canary rules
+  local route_backend_name = route_if.backend()
+  if route_backend_name and balancers[route_backend_name] then
+    ngx.var.proxy_alternative_upstream_name = route_backend_name
+    balancer = balancers[route_backend_name]
+  elseif route_to_alternative_balancer(balancer) then
     local alternative_backend_name = balancer.alternative_backends[1]
     ngx.var.proxy_alternative_upstream_name = alternative_backend_name
 
@@ -369,6 +399,7 @@ setmetatable(_M, {__index = {
   route_to_alternative_balancer = route_to_alternative_balancer,
   get_balancer = get_balancer,
   get_balancer_by_upstream_name = get_balancer_by_upstream_name,
+  is_synced = is_synced,
 }})
 
 return _M
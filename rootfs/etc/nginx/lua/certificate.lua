@@ -8,6 +8,7 @@ local re_sub = ngx.re.sub
 local unpack = unpack
 
 local dns_lookup = require("util.dns").lookup
+local shared_dict = require("util.shared_dict")
 
 local _M = {
   is_ocsp_stapling_enabled = false
@@ -188,6 +189,7 @@ local function fetch_and_cache_ocsp_response(uid, der_cert)
   if forcible then
     ngx.log(ngx.NOTICE, "removed an existing item when saving OCSP response, ",
       "consider increasing shared dictionary size for 'ocsp_response_cache'")
+    shared_dict.record_eviction("ocsp_response_cache")
   end
 end
 
@@ -1,4 +1,7 @@
 local cjson = require("cjson.safe")
+local gzip = require("util.gzip")
+local shared_dict = require("util.shared_dict")
+local upload_guard = require("upload_guard")
 
 local io = io
 local ngx = ngx
@@ -6,6 +9,8 @@ local tostring = tostring
 local string = string
 local table = table
 local pairs = pairs
+local ipairs = ipairs
+local tonumber = tonumber
 
 -- this is the Lua representation of Configuration struct in internal/ingress/types.go
 local configuration_data = ngx.shared.configuration_data
@@ -15,6 +20,9 @@ local ocsp_response_cache = ngx.shared.ocsp_response_cache
 
 local EMPTY_UID = "-1"
 
+-- chunks of a configuration are removed if the last one is not received in time
+local PENDING_CHUNK_TTL = 60
+
 local _M = {}
 
 function _M.get_backends_data()
@@ -25,6 +33,17 @@ function _M.get_general_data()
   return configuration_data:get("general")
 end
 
+-- get_backends_checksum returns the CRC32 of the backends stored in the shared
+-- dictionary, compared by the controller with the ones it sent to detect stale
+-- backends and changes of the dictionary out of band
+function _M.get_backends_checksum()
+  local backends = configuration_data:get("backends")
+  if not backends then
+    return nil
+  end
+  return ngx.crc32_long(backends)
+end
+
 function _M.get_raw_backends_last_synced_at()
   local raw_backends_last_synced_at = configuration_data:get("raw_backends_last_synced_at")
   if raw_backends_last_synced_at == nil then
@@ -50,9 +69,105 @@ local function fetch_request_body()
     file:close()
   end
 
+  if body and ngx.var.http_content_encoding == "gzip" then
+    local err
+    body, err = gzip.decompress(body)
+    if not body then
+      ngx.log(ngx.ERR, "could not decompress request body: ", err)
+      return nil
+    end
+  end
+
   return body
 end
 
+-- discard_chunks removes the chunks of a generation stored in configuration_data,
+-- for a configuration that can not be joined to not hold memory until they expire.
+local function discard_chunks(prefix, chunks)
+  for i = 0, chunks - 1 do
+    configuration_data:delete(prefix .. i)
+  end
+  configuration_data:delete(prefix .. "received")
+end
+
+-- fetch_chunked_request_body returns the body of a request not split in chunks,
+-- or the chunks of a configuration joined when the last one is received.
+-- The chunks are stored in configuration_data until then, so reading the body
+-- of each request is bounded by the chunk size. The request with the last chunk
+-- still joins and decodes the whole configuration at once.
+-- Otherwise it returns nil and the status code of the response.
+local function fetch_chunked_request_body(name)
+  local body = fetch_request_body()
+  if not body then
+    return nil, ngx.HTTP_BAD_REQUEST
+  end
+
+  local generation = tonumber(ngx.var.http_x_configuration_generation)
+  if not generation then
+    return body
+  end
+
+  local chunk = tonumber(ngx.var.http_x_configuration_chunk)
+  local chunks = tonumber(ngx.var.http_x_configuration_chunks)
+  if not chunk or not chunks or chunk < 0 or chunk >= chunks then
+    ngx.log(ngx.ERR, "invalid chunk ", tostring(chunk), " of ", tostring(chunks), " for ", name)
+    return nil, ngx.HTTP_BAD_REQUEST
+  end
+
+  local generation_key = name .. "_generation"
+  local current = configuration_data:get(generation_key) or 0
+  if generation <= current then
+    ngx.log(ngx.WARN, "ignoring chunk of stale generation ", generation, " for ", name)
+    return nil, ngx.HTTP_CONFLICT
+  end
+
+  local prefix = string.format("chunk:%s:%d:", name, generation)
+
+  -- safe_add fails instead of evicting entries like the running configuration
+  local success, err = configuration_data:safe_add(prefix .. chunk, body, PENDING_CHUNK_TTL)
+  if not success then
+    if err == "exists" then
+      return nil, ngx.HTTP_ACCEPTED
+    end
+
+    ngx.log(ngx.WARN, "dataplane overloaded storing chunk ", chunk, " of ", name, ": ", tostring(err))
+    discard_chunks(prefix, chunks)
+    return nil, ngx.HTTP_SERVICE_UNAVAILABLE
+  end
+
+  local received
+  received, err = configuration_data:incr(prefix .. "received", 1, 0, PENDING_CHUNK_TTL)
+  if not received then
+    ngx.log(ngx.WARN, "dataplane overloaded counting chunks of ", name, ": ", tostring(err))
+    discard_chunks(prefix, chunks)
+    return nil, ngx.HTTP_SERVICE_UNAVAILABLE
+  end
+
+  if received < chunks then
+    return nil, ngx.HTTP_ACCEPTED
+  end
+
+  local parts = {}
+  local missing = false
+  for i = 0, chunks - 1 do
+    local part = configuration_data:get(prefix .. i)
+    if not part then
+      missing = true
+    end
+    parts[i + 1] = part or ""
+  end
+  discard_chunks(prefix, chunks)
+
+  if missing then
+    ngx.log(ngx.ERR, "chunks of generation ", generation, " for ", name, " expired")
+    return nil, ngx.HTTP_BAD_REQUEST
+  end
+
+  configuration_data:set(generation_key, generation)
+
+  return table.concat(parts)
+end
+
 local function get_pem_cert(hostname)
   local uid = certificate_servers:get(hostname)
   if not uid then
@@ -69,7 +184,11 @@ local function handle_servers()
     return
   end
 
-  local raw_configuration = fetch_request_body()
+  local raw_configuration, status = fetch_chunked_request_body("servers")
+  if not raw_configuration then
+    ngx.status = status
+    return
+  end
 
   local configuration, err = cjson.decode(raw_configuration)
   if not configuration then
@@ -96,6 +215,7 @@ local function handle_servers()
         local msg = string.format("certificate_servers dictionary is full, "
           .. "LRU entry has been removed to store %s", server)
         ngx.log(ngx.WARN, msg)
+        shared_dict.record_eviction("certificate_servers")
       end
     end
   end
@@ -122,6 +242,7 @@ local function handle_servers()
       local msg = string.format("certificate_data dictionary is full, "
         .. "LRU entry has been removed to store %s", uid)
       ngx.log(ngx.WARN, msg)
+      shared_dict.record_eviction("certificate_data")
     end
   end
 
@@ -193,19 +314,26 @@ local function handle_backends()
     return
   end
 
-  local backends = fetch_request_body()
+  local backends, status = fetch_chunked_request_body("backends")
   if not backends then
-    ngx.log(ngx.ERR, "dynamic-configuration: unable to read valid request body")
-    ngx.status = ngx.HTTP_BAD_REQUEST
+    if status == ngx.HTTP_BAD_REQUEST then
+      ngx.log(ngx.ERR, "dynamic-configuration: unable to read valid request body")
+    end
+    ngx.status = status
     return
   end
 
-  local success, err = configuration_data:set("backends", backends)
+  local success, err, forcible = configuration_data:set("backends", backends)
   if not success then
     ngx.log(ngx.ERR, "dynamic-configuration: error updating configuration: " .. tostring(err))
     ngx.status = ngx.HTTP_BAD_REQUEST
     return
   end
+  if forcible then
+    ngx.log(ngx.WARN, "configuration_data dictionary is full, "
+      .. "LRU entry has been removed to store the backends")
+    shared_dict.record_eviction("configuration_data")
+  end
 
   ngx.update_time()
   local raw_backends_last_synced_at = ngx.time()
@@ -220,6 +348,58 @@ local function handle_backends()
   ngx.status = ngx.HTTP_CREATED
 end
 
+-- handle_upstream_names returns the readable names of the upstreams by hash, with hash-upstream-names
+local function handle_upstream_names()
+  if ngx.var.request_method ~= "GET" then
+    ngx.status = ngx.HTTP_BAD_REQUEST
+    ngx.print("Only GET requests are allowed!")
+    return
+  end
+
+  local names = {}
+
+  local backends_data = _M.get_backends_data()
+  if backends_data then
+    local backends, err = cjson.decode(backends_data)
+    if not backends then
+      ngx.log(ngx.ERR, "could not parse backends data: ", err)
+      ngx.status = ngx.HTTP_INTERNAL_SERVER_ERROR
+      return
+    end
+
+    for _, backend in ipairs(backends) do
+      if backend.readableName then
+        names[backend.name] = backend.readableName
+      end
+    end
+  end
+
+  ngx.status = ngx.HTTP_OK
+  ngx.print(cjson.encode(names))
+end
+
+local function handle_shared_dicts()
+  if ngx.var.request_method ~= "GET" then
+    ngx.status = ngx.HTTP_BAD_REQUEST
+    ngx.print("Only GET requests are allowed!")
+    return
+  end
+
+  ngx.status = ngx.HTTP_OK
+  ngx.print(cjson.encode(shared_dict.stats()))
+end
+
+local function handle_uploads()
+  if ngx.var.request_method ~= "GET" then
+    ngx.status = ngx.HTTP_BAD_REQUEST
+    ngx.print("Only GET requests are allowed!")
+    return
+  end
+
+  ngx.status = ngx.HTTP_OK
+  ngx.print(cjson.encode(upload_guard.stats()))
+end
+
 function _M.call()
   if ngx.var.request_method ~= "POST" and ngx.var.request_method ~= "GET" then
     ngx.status = ngx.HTTP_BAD_REQUEST
@@ -247,6 +427,21 @@ function _M.call()
     return
   end
 
+  if ngx.var.request_uri == "/configuration/upstream-names" then
+    handle_upstream_names()
+    return
+  end
+
+  if ngx.var.request_uri == "/configuration/shared-dicts" then
+    handle_shared_dicts()
+    return
+  end
+
+  if ngx.var.request_uri == "/configuration/uploads" then
+    handle_uploads()
+    return
+  end
+
   ngx.status = ngx.HTTP_NOT_FOUND
   ngx.print("Not found!")
 end
@@ -0,0 +1,46 @@
+local ngx = ngx
+local string_find = string.find
+
+local _M = {}
+
+-- gRPC status codes returned instead of the error pages of the upstream
+-- failures, see https://grpc.github.io/grpc/core/md_doc_statuscodes.html
+local GRPC_STATUS = {
+  [502] = { code = 14, message = "upstream unavailable" },
+  [503] = { code = 14, message = "service unavailable" },
+  [504] = { code = 4, message = "upstream timed out" },
+}
+
+local function has_prefix(s, prefix)
+  return s ~= nil and string_find(s, prefix, 1, true) == 1
+end
+
+function _M.is_grpc(content_type)
+  return has_prefix(content_type, "application/grpc")
+end
+
+-- respond gets called in the content phase of the error locations of the
+-- gRPC backends. gRPC clients get a response with the grpc-status of the
+-- failure, the other ones get the error page.
+function _M.respond(status)
+  local grpc = GRPC_STATUS[status]
+  local content_type = ngx.var.http_content_type
+  if not grpc or not _M.is_grpc(content_type) then
+    return ngx.exit(status)
+  end
+
+  ngx.status = ngx.HTTP_OK
+  if has_prefix(content_type, "application/grpc-web") then
+    ngx.header["Content-Type"] = "application/grpc-web+proto"
+  else
+    ngx.header["Content-Type"] = "application/grpc"
+  end
+  ngx.header["grpc-status"] = grpc.code
+  ngx.header["grpc-message"] = grpc.message
+  ngx.header["Content-Length"] = 0
+
+  ngx.send_headers()
+  return ngx.exit(ngx.HTTP_OK)
+end
+
+return _M
@@ -0,0 +1,149 @@
+local cjson = require("cjson.safe")
+
+local ngx = ngx
+local type = type
+local ipairs = ipairs
+local string_sub = string.sub
+local ngx_decode_base64 = ngx.decode_base64
+
+local _M = {}
+
+-- the rules are passed by the controller in $transform_rules as base64
+-- encoded JSON, they are decoded once per worker and configuration
+local MAX_CACHED_RULES = 1024
+local cache = {}
+local cached = 0
+
+local function get_rules()
+  local encoded = ngx.var.transform_rules
+  if not encoded or encoded == "" then
+    return nil
+  end
+
+  local rules = cache[encoded]
+  if rules ~= nil then
+    return rules or nil
+  end
+
+  local err
+  rules, err = cjson.decode(ngx_decode_base64(encoded) or "")
+  if not rules then
+    ngx.log(ngx.ERR, "failed to decode the HTTPTransform rules: ", err)
+    rules = false
+  end
+
+  -- stale rules of previous configurations are dropped all at once
+  if cached >= MAX_CACHED_RULES then
+    cache = {}
+    cached = 0
+  end
+  cache[encoded] = rules
+  cached = cached + 1
+
+  return rules or nil
+end
+
+-- append returns the values of a header or a query parameter after adding value
+local function append(current, value)
+  if current == nil or current == true then
+    return value
+  end
+  if type(current) == "table" then
+    current[#current + 1] = value
+    return current
+  end
+  return { current, value }
+end
+
+-- apply changes the values of the table t in the order remove, rename, set and add.
+-- get and set are used to read and write the values of a name.
+local function apply(changes, get, set)
+  for _, name in ipairs(changes.remove or {}) do
+    set(name, nil)
+  end
+  for _, rename in ipairs(changes.rename or {}) do
+    local value = get(rename.from)
+    if value ~= nil then
+      set(rename.from, nil)
+      set(rename.to, value)
+    end
+  end
+  for _, h in ipairs(changes.set or {}) do
+    set(h.name, h.value)
+  end
+  for _, h in ipairs(changes.add or {}) do
+    set(h.name, append(get(h.name), h.value))
+  end
+end
+
+-- transform_path strips and adds the prefixes of the path
+function _M.transform_path(uri, path)
+  local strip, add = path.stripPrefix, path.addPrefix
+
+  if strip and strip ~= "" and string_sub(uri, 1, #strip) == strip then
+    uri = string_sub(uri, #strip + 1)
+    if string_sub(uri, 1, 1) ~= "/" then
+      uri = "/" .. uri
+    end
+  end
+
+  if add and add ~= "" then
+    if string_sub(add, -1) == "/" then
+      add = string_sub(add, 1, -2)
+    end
+    uri = add .. uri
+  end
+
+  return uri
+end
+
+-- rewrite changes the request headers, path and query parameters sent to the backend
+function _M.rewrite()
+  local rules = get_rules()
+  if not rules then
+    return
+  end
+
+  if rules.requestHeaders then
+    local headers = ngx.req.get_headers(0)
+    apply(rules.requestHeaders,
+      function(name) return headers[name] end,
+      function(name, value)
+        headers[name] = value
+        if value == nil then
+          ngx.req.clear_header(name)
+        else
+          ngx.req.set_header(name, value)
+        end
+      end)
+  end
+
+  if rules.path then
+    local uri = _M.transform_path(ngx.var.uri, rules.path)
+    if uri ~= ngx.var.uri then
+      ngx.req.set_uri(uri)
+    end
+  end
+
+  if rules.queryParams then
+    local args = ngx.req.get_uri_args(0)
+    apply(rules.queryParams,
+      function(name) return args[name] end,
+      function(name, value) args[name] = value end)
+    ngx.req.set_uri_args(args)
+  end
+end
+
+-- header changes the response headers sent to the client
+function _M.header()
+  local rules = get_rules()
+  if not rules or not rules.responseHeaders then
+    return
+  end
+
+  apply(rules.responseHeaders,
+    function(name) return ngx.header[name] end,
+    function(name, value) ngx.header[name] = value end)
+end
+
+return _M
@@ -0,0 +1,101 @@
+local cjson = require("cjson.safe")
+
+local ngx = ngx
+local tonumber = tonumber
+local math_ceil = math.ceil
+local math_max = math.max
+
+local _M = {}
+
+local REASONS = {
+  [429] = "Too Many Requests",
+  [503] = "Service Temporarily Unavailable",
+}
+
+-- status codes of the rejected requests passed by the controller
+local config = {}
+
+function _M.set_config(new_config)
+  config = new_config or {}
+end
+
+-- retry_after returns the number of seconds a client has to wait before
+-- limit_req accepts a new request. Rejected requests do not consume the
+-- rate limit, so at most the time to drain one request is needed.
+function _M.retry_after(rps, rpm)
+  rps = tonumber(rps) or 0
+  rpm = tonumber(rpm) or 0
+
+  local wait = 1
+  if rps > 0 then
+    wait = math_max(wait, math_ceil(1 / rps))
+  end
+  if rpm > 0 then
+    wait = math_max(wait, math_ceil(60 / rpm))
+  end
+
+  return wait
+end
+
+local function rejection_delay(status, var)
+  if status == config.limit_req_status_code and var.limit_req_status == "REJECTED" then
+    return _M.retry_after(var.limit_rps, var.limit_rpm)
+  end
+  if status == config.limit_conn_status_code and var.limit_conn_status == "REJECTED" then
+    -- connections are released when requests end, nothing tells when
+    return 1
+  end
+  return nil
+end
+
+-- header gets called in the header filter phase and describes the
+-- requests rejected by limit_req or limit_conn.
+function _M.header()
+  local status = ngx.status
+  if status ~= config.limit_req_status_code and status ~= config.limit_conn_status_code then
+    return
+  end
+
+  local var = ngx.var
+  local retry_after = rejection_delay(status, var)
+  if not retry_after then
+    return
+  end
+
+  if var.limit_retry_after == "true" then
+    ngx.header["Retry-After"] = retry_after
+  end
+
+  -- the page of custom-http-errors is kept as it is
+  if var.limit_json_response == "true" and not ngx.req.is_internal() then
+    ngx.header["Content-Type"] = "application/json"
+    ngx.header["Content-Length"] = nil
+    ngx.ctx.limit_response = cjson.encode({
+      status = status,
+      message = REASONS[status] or "Request Rejected",
+      retry_after = retry_after,
+    })
+  end
+end
+
+-- body gets called in the body filter phase and replaces the error
+-- page of a rejected request with the JSON built by header.
+function _M.body()
+  local ctx = ngx.ctx
+  local response = ctx.limit_response
+  if not response then
+    return
+  end
+
+  if ctx.limit_response_sent then
+    -- throw away what is left of the original page
+    ngx.arg[1] = nil
+    return
+  end
+
+  ctx.limit_response_sent = true
+  ngx.arg[1] = response .. "\n"
+  ngx.arg[2] = true
+end
+
+return _M
@@ -15,6 +15,9 @@ local ngx_redirect = ngx.redirect
 local _M = {}
 
 local seeds = {}
+-- header used to pass the client address found in the forwarded-for header
+-- to the realip module when forwarded-for-hops is enabled
+local CLIENT_ADDR_HEADER = "X-Ingress-Client-Addr"
 -- general Nginx configuration passed by controller to be used in this module
 local config
 
@@ -98,6 +101,51 @@ local function parse_x_forwarded_host()
   return hosts[1]
 end
 
+-- forwarded_for_client_addr returns the address added to the forwarded-for
+-- header by the outermost of the proxies in front of the controller
+local function forwarded_for_client_addr()
+  local header = string.lower(config.forwarded_for_header)
+  local forwarded_for = ngx.var["http_" .. (string.gsub(header, "-", "_"))]
+  if not forwarded_for then
+    return nil
+  end
+
+  local addrs, err = ngx_re_split(forwarded_for, "\\s*,\\s*", "jo")
+  if err then
+    ngx.log(ngx.ERR, string_format("could not parse variable: %s", err))
+    return nil
+  end
+
+  local i = #addrs - config.forwarded_for_hops + 1
+  if i < 1 then
+    return nil
+  end
+
+  return addrs[i]
+end
+
+-- client_certificate_allowed checks the subject of the client certificate
+-- matches the regex defined in the auth-tls-match-subject annotation
+local function client_certificate_allowed()
+  local match_subject = ngx.var.auth_tls_match_subject
+  if not match_subject or match_subject == "" then
+    return true
+  end
+
+  local subject = ngx.var.ssl_client_s_dn
+  if not subject then
+    return false
+  end
+
+  local from, _, err = ngx.re.find(subject, match_subject, "jo")
+  if err then
+    ngx.log(ngx.ERR, "invalid regex in auth-tls-match-subject: ", err)
+    return false
+  end
+
+  return from ~= nil
+end
+
 function _M.init_worker()
   randomseed()
 end
@@ -137,6 +185,14 @@ function _M.rewrite()
     if ngx.var.http_x_forwarded_host then
       ngx.var.best_http_host = parse_x_forwarded_host()
     end
+
+    -- the header is always replaced to discard a value sent by the client
+    if config.forwarded_for_hops > 0 and not config.use_proxy_protocol then
+      local client_addr = forwarded_for_client_addr()
+      ngx.req.set_header(CLIENT_ADDR_HEADER, client_addr)
+      -- kept for the modules running before the realip module
+      ngx.ctx.client_addr = client_addr
+    end
   end
 
   if config.use_proxy_protocol then
@@ -173,6 +229,10 @@ function _M.rewrite()
     return ngx_redirect(uri, config.http_redirect_code)
   end
 
+  if not client_certificate_allowed() then
+    return ngx.exit(ngx.HTTP_FORBIDDEN)
+  end
+
 end
 
 function _M.header()
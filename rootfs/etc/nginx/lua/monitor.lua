@@ -5,6 +5,7 @@ local string = string
 local tostring = tostring
 local socket = ngx.socket.tcp
 local cjson = require("cjson.safe")
+local balancer = require("balancer")
 local new_tab = require "table.new"
 local clear_tab = require "table.clear"
 local table = table
@@ -16,6 +17,8 @@ local pairs = pairs
 local MAX_BATCH_SIZE = 10000
 local FLUSH_INTERVAL = 1 -- second
 
+local metrics_socket = "unix:/tmp/nginx/prometheus-nginx.socket"
+
 local metrics_batch = new_tab(MAX_BATCH_SIZE, 0)
 local metrics_count = 0
 
@@ -26,18 +29,27 @@ local _M = {}
 
 local function send(payload)
   local s = assert(socket())
-  assert(s:connect("unix:/tmp/nginx/prometheus-nginx.socket"))
+  assert(s:connect(metrics_socket))
   assert(s:send(payload))
   assert(s:close())
 end
 
+local function canary_name()
+  local name = ngx.var.proxy_alternative_upstream_name
+  if not name then
+    return "-"
+  end
+
+  return balancer.readable_upstream_name(name)
+end
+
 local function metrics()
   return {
     host = ngx.var.host or "-",
     namespace = ngx.var.namespace or "-",
     ingress = ngx.var.ingress_name or "-",
     service = ngx.var.service_name or "-",
-    canary = ngx.var.proxy_alternative_upstream_name or "-",
+    canary = canary_name(),
     path = ngx.var.location_path or "-",
 
     method = ngx.var.request_method or "-",
@@ -88,8 +100,11 @@ local function set_metrics_max_batch_size(max_batch_size)
   end
 end
 
-function _M.init_worker(max_batch_size)
+function _M.init_worker(max_batch_size, socket_path)
   set_metrics_max_batch_size(max_batch_size)
+  if socket_path then
+    metrics_socket = "unix:" .. socket_path
+  end
   local _, err = ngx.timer.every(FLUSH_INTERVAL, flush)
   if err then
     ngx.log(ngx.ERR, string.format("error when setting up timer.every: %s", tostring(err)))
@@ -0,0 +1,2 @@
+local auth_cache = require("auth_cache")
+auth_cache.store()
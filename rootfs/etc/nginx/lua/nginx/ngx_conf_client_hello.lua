@@ -0,0 +1,2 @@
+local tls_fingerprint = require("tls_fingerprint")
+tls_fingerprint.client_hello()
@@ -0,0 +1,25 @@
+local cjson = require("cjson.safe")
+local configuration = require("configuration")
+local balancer = require("balancer")
+
+local backends = ngx.req.get_uri_args().backend
+if type(backends) == "string" then
+  backends = { backends }
+end
+
+-- the balancers are checked once the worker synced the last backends
+local missing = setmetatable({}, cjson.empty_array_mt)
+if type(backends) == "table" and balancer.is_synced() then
+  for _, name in ipairs(backends) do
+    if not balancer.get_balancer_by_upstream_name(name) then
+      table.insert(missing, name)
+    end
+  end
+end
+
+ngx.header.content_type = "application/json"
+ngx.say(cjson.encode({
+  backends_checksum = configuration.get_backends_checksum(),
+  missing_backends = missing,
+}))
+ngx.exit(ngx.HTTP_OK)
@@ -0,0 +1,2 @@
+local grpc_error = require("grpc_error")
+grpc_error.respond(tonumber(ngx.var.grpc_error_status))
@@ -0,0 +1,2 @@
+local limit_response = require("limit_response")
+limit_response.body()
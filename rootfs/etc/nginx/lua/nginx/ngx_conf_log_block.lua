@@ -1,10 +1,12 @@
 local balancer = require("balancer")
 local monitor = require("monitor")
+local upload_guard = require("upload_guard")
 
 local luaconfig = ngx.shared.luaconfig
 local enablemetrics = luaconfig:get("enablemetrics")
 
 balancer.log()
+upload_guard.release()
 
 if enablemetrics then
     monitor.call()
@@ -1,2 +1,6 @@
 local lua_ingress = require("lua_ingress")
-lua_ingress.header()
\ No newline at end of file
+local limit_response = require("limit_response")
+local http_transform = require("http_transform")
+lua_ingress.header()
+limit_response.header()
+http_transform.header()
\ No newline at end of file
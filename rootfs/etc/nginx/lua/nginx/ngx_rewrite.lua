@@ -1,5 +1,11 @@
 local lua_ingress = require("lua_ingress")
 local balancer = require("balancer")
+local upload_guard = require("upload_guard")
+local http_transform = require("http_transform")
+local anomaly_scoring = require("anomaly_scoring")
 
 lua_ingress.rewrite()
-balancer.rewrite()
\ No newline at end of file
+anomaly_scoring.rewrite()
+balancer.rewrite()
+upload_guard.acquire()
+http_transform.rewrite()
\ No newline at end of file
@@ -0,0 +1,9 @@
+local max_headers = tonumber(ngx.arg[1])
+
+-- get_headers returns "truncated" as error when the request has more headers
+local _, err = ngx.req.get_headers(max_headers, true)
+if err == "truncated" then
+    return "1"
+end
+
+return ""
@@ -20,5 +20,11 @@ if use_forwarded_headers then
     end
 end
 
+-- the default port of the scheme is not part of the redirect
+local default_ports = { http = "80", https = "443" }
+if default_ports[redirectScheme] == redirectPort then
+    return string.format("%s://%s%s", redirectScheme, redirect_to, request_uri)
+end
+
 return string.format("%s://%s:%s%s", redirectScheme,
     redirect_to, redirectPort, request_uri)
\ No newline at end of file
@@ -0,0 +1,2 @@
+local lua_ingress = require("lua_ingress")
+lua_ingress.header()
@@ -1,7 +1,8 @@
 local cjson = require("cjson.safe")
 
 collectgarbage("collect")
-local f = io.open("/etc/nginx/lua/cfg.json", "r")
+-- lua_config_path is set by the init_by_lua_block of nginx.conf
+local f = io.open(lua_config_path or "/etc/nginx/lua/cfg.json", "r")
 local content = f:read("*a")
 f:close()
 local configfile = cjson.decode(content)
@@ -28,6 +29,24 @@ else
   end
   configuration.prohibited_localhost_port = configfile.listen_ports.status_port
 end
+ok, res = pcall(require, "auth_cache")
+if not ok then
+  error("require failed: " .. tostring(res))
+else
+  res.set_config(configfile.auth_cache_memcached)
+end
+ok, res = pcall(require, "limit_response")
+if not ok then
+  error("require failed: " .. tostring(res))
+else
+  res.set_config(configfile)
+end
+ok, res = pcall(require, "anomaly_scoring")
+if not ok then
+  error("require failed: " .. tostring(res))
+else
+  res.set_config(configfile.anomaly_scoring)
+end
 ok, res = pcall(require, "balancer")
 if not ok then
   error("require failed: " .. tostring(res))
@@ -1,6 +1,7 @@
 local cjson = require("cjson.safe")
 collectgarbage("collect")
-local f = io.open("/etc/nginx/lua/cfg.json", "r")
+-- lua_config_path is set by the init_by_lua_block of nginx.conf
+local f = io.open(lua_config_path or "/etc/nginx/lua/cfg.json", "r")
 local content = f:read("*a")
 f:close()
 local configfile = cjson.decode(content)
@@ -1,6 +1,6 @@
 local cjson = require("cjson.safe")
 
-local f = io.open("/etc/nginx/lua/cfg.json", "r")
+local f = io.open(lua_config_path or "/etc/nginx/lua/cfg.json", "r")
 local content = f:read("*a")
 f:close()
 local configfile = cjson.decode(content)
@@ -11,5 +11,5 @@ local monitor = require("monitor")
 lua_ingress.init_worker()
 balancer.init_worker()
 if configfile.enable_metrics and configfile.monitor_batch_max_size then
-  monitor.init_worker(configfile.monitor_batch_max_size)
+  monitor.init_worker(configfile.monitor_batch_max_size, configfile.metrics_socket)
 end
\ No newline at end of file
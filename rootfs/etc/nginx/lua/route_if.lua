@@ -0,0 +1,137 @@
+local cjson = require("cjson.safe")
+
+local ngx = ngx
+local type = type
+local ipairs = ipairs
+local string_sub = string.sub
+local string_find = string.find
+local ngx_re_find = ngx.re.find
+local ngx_decode_base64 = ngx.decode_base64
+
+local _M = {}
+
+-- the rules are passed by the controller in $route_rules as base64
+-- encoded JSON, they are decoded once per worker and configuration
+local MAX_CACHED_RULES = 1024
+local cache = {}
+local cached = 0
+
+local function get_rules()
+  local encoded = ngx.var.route_rules
+  if not encoded or encoded == "" then
+    return nil
+  end
+
+  local rules = cache[encoded]
+  if rules ~= nil then
+    return rules or nil
+  end
+
+  local err
+  rules, err = cjson.decode(ngx_decode_base64(encoded) or "")
+  if not rules then
+    ngx.log(ngx.ERR, "failed to decode the route-if rules: ", err)
+    rules = false
+  end
+
+  -- stale rules of previous configurations are dropped all at once
+  if cached >= MAX_CACHED_RULES then
+    cache = {}
+    cached = 0
+  end
+  cache[encoded] = rules
+  cached = cached + 1
+
+  return rules or nil
+end
+
+-- first returns the first value of a header sent more than once
+local function first(value)
+  if type(value) == "table" then
+    return value[1]
+  end
+  return value
+end
+
+-- lookup returns the value of a header, query parameter or cookie, nil when it is missing
+local function lookup(op, name)
+  if op == "header" then
+    return first(ngx.req.get_headers()[name])
+  elseif op == "query" then
+    return ngx.var["arg_" .. name]
+  elseif op == "cookie" then
+    return ngx.var["cookie_" .. name]
+  end
+  return nil
+end
+
+local eval
+
+-- the operations of the expressions compiled by the controller,
+-- the values of the request missing are evaluated as empty strings
+local operations = {
+  ["and"] = function(args) return eval(args[1]) and eval(args[2]) end,
+  ["or"] = function(args) return eval(args[1]) or eval(args[2]) end,
+  ["not"] = function(args) return not eval(args[1]) end,
+  eq = function(args) return eval(args[1]) == eval(args[2]) end,
+  ne = function(args) return eval(args[1]) ~= eval(args[2]) end,
+  startsWith = function(args)
+    local s, prefix = eval(args[1]), eval(args[2])
+    return string_sub(s, 1, #prefix) == prefix
+  end,
+  endsWith = function(args)
+    local s, suffix = eval(args[1]), eval(args[2])
+    return suffix == "" or string_sub(s, -#suffix) == suffix
+  end,
+  contains = function(args)
+    return string_find(eval(args[1]), eval(args[2]), 1, true) ~= nil
+  end,
+  matches = function(args)
+    local from, _, err = ngx_re_find(eval(args[1]), eval(args[2]), "jo")
+    if err then
+      ngx.log(ngx.ERR, "failed to match the route-if expression: ", err)
+    end
+    return from ~= nil
+  end,
+  has = function(args) return lookup(args[1].op, args[1].value) ~= nil end,
+  str = function(_, value) return value end,
+  ["true"] = function() return true end,
+  ["false"] = function() return false end,
+  path = function() return ngx.var.uri or "" end,
+  method = function() return ngx.req.get_method() end,
+  host = function() return ngx.var.host or "" end,
+  scheme = function() return ngx.var.scheme or "" end,
+  header = function(_, value) return lookup("header", value) or "" end,
+  query = function(_, value) return lookup("query", value) or "" end,
+  cookie = function(_, value) return lookup("cookie", value) or "" end,
+}
+
+eval = function(expr)
+  local operation = operations[expr.op]
+  if not operation then
+    ngx.log(ngx.ERR, "unknown operation in the route-if expression: ", expr.op)
+    return false
+  end
+  return operation(expr.args, expr.value or "")
+end
+
+_M.eval = eval
+
+-- backend returns the backend of the first route-if rule matching the request,
+-- nil when there are no rules or none of them matches
+function _M.backend()
+  local rules = get_rules()
+  if not rules then
+    return nil
+  end
+
+  for _, rule in ipairs(rules) do
+    if eval(rule["if"]) == true then
+      return rule.backend
+    end
+  end
+
+  return nil
+end
+
+return _M
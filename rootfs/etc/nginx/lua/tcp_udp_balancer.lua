@@ -4,6 +4,7 @@ local util = require("util")
 local dns_lookup = require("util.dns").lookup
 local configuration = require("tcp_udp_configuration")
 local round_robin = require("balancer.round_robin")
+local chash = require("balancer.chash")
 
 local ngx = ngx
 local table = table
@@ -22,7 +23,8 @@ local BACKENDS_FORCE_SYNC_INTERVAL = 30
 
 local DEFAULT_LB_ALG = "round_robin"
 local IMPLEMENTATIONS = {
-  round_robin = round_robin
+  round_robin = round_robin,
+  chash = chash
 }
 
 local PROHIBITED_LOCALHOST_PORT = configuration.prohibited_localhost_port or '10246'
@@ -59,11 +61,17 @@ local function resolve_external_names(original_backend)
   return backend
 end
 
+-- is_ipv6_address returns true for the IPv6 addresses not wrapped yet
+-- into square brackets, hostnames and IPv4 addresses have no colon
+local function is_ipv6_address(address)
+  return address:find(":", 1, true) ~= nil and address:sub(1, 1) ~= "["
+end
+
 local function format_ipv6_endpoints(endpoints)
   local formatted_endpoints = {}
   for _, endpoint in ipairs(endpoints) do
     local formatted_endpoint = endpoint
-    if not endpoint.address:match("^%d+.%d+.%d+.%d+$") then
+    if is_ipv6_address(endpoint.address) then
       formatted_endpoint.address = string.format("[%s]", endpoint.address)
     end
     table.insert(formatted_endpoints, formatted_endpoint)
@@ -0,0 +1,127 @@
+local anomaly_scoring
+
+-- gRPC message of a ScoreResponse with a score of 0.75
+local SCORE_075 = "\0\0\0\0\9\9\0\0\0\0\0\0\232\63"
+
+local function mock_ngx(var, capture)
+  local _ngx = {
+    var = var,
+    req = {
+      is_internal = function() return false end,
+      get_method = function() return "GET" end,
+    },
+    location = { capture = capture },
+    exit = spy.new(function() end),
+    log = function() end,
+  }
+  setmetatable(_ngx, { __index = ngx })
+  _G.ngx = _ngx
+
+  anomaly_scoring = require_without_cache("anomaly_scoring")
+end
+
+local function request_var()
+  return {
+    remote_addr = "10.0.0.1",
+    host = "example.com",
+    uri = "/login",
+    namespace = "default",
+    ingress_name = "web",
+  }
+end
+
+local function score_response()
+  return { status = 200, header = {}, body = SCORE_075 }
+end
+
+describe("anomaly_scoring", function()
+  before_each(function()
+    anomaly_scoring = require_without_cache("anomaly_scoring")
+    ngx.shared.anomaly_scores:flush_all()
+  end)
+
+  after_each(function()
+    reset_ngx()
+  end)
+
+  describe("encode()", function()
+    it("frames the non empty fields of the metadata", function()
+      assert.are.equal("\0\0\0\0\8\18\3GET\26\1a", anomaly_scoring.encode({ method = "GET", host = "a", path = "" }))
+    end)
+
+    it("frames an empty message", function()
+      assert.are.equal("\0\0\0\0\0", anomaly_scoring.encode({}))
+    end)
+  end)
+
+  describe("decode()", function()
+    it("returns the score", function()
+      assert.are.equal(0.75, anomaly_scoring.decode(SCORE_075))
+    end)
+
+    it("returns 0 for the default value", function()
+      assert.are.equal(0, anomaly_scoring.decode("\0\0\0\0\0"))
+    end)
+
+    it("skips the unknown fields", function()
+      assert.are.equal(0.75, anomaly_scoring.decode("\0\0\0\0\12\18\1x\9\0\0\0\0\0\0\232\63"))
+    end)
+
+    it("rejects the truncated messages", function()
+      local score, err = anomaly_scoring.decode("\0\0\0\0\9\9\0\0")
+      assert.is_nil(score)
+      assert.are.equal("truncated message", err)
+    end)
+
+    it("rejects the compressed messages", function()
+      local score, err = anomaly_scoring.decode("\1\0\0\0\0")
+      assert.is_nil(score)
+      assert.are.equal("compressed messages are not supported", err)
+    end)
+  end)
+
+  describe("rewrite()", function()
+    it("does nothing when disabled", function()
+      local capture = spy.new(score_response)
+      mock_ngx(request_var(), capture)
+
+      anomaly_scoring.rewrite()
+
+      assert.spy(capture).was_not_called()
+    end)
+
+    it("sets the score and caches it", function()
+      local capture = spy.new(score_response)
+      mock_ngx(request_var(), capture)
+      anomaly_scoring.set_config({ enabled = true, cache_ttl = 60, threshold = 0 })
+
+      anomaly_scoring.rewrite()
+      anomaly_scoring.rewrite()
+
+      assert.are.equal(0.75, ngx.var.anomaly_score)
+      assert.spy(capture).was_called(1)
+      assert.spy(ngx.exit).was_not_called()
+    end)
+
+    it("rejects the request reaching the threshold", function()
+      mock_ngx(request_var(), score_response)
+      anomaly_scoring.set_config({ enabled = true, cache_ttl = 0, threshold = 0.5 })
+
+      anomaly_scoring.rewrite()
+
+      assert.spy(ngx.exit).was_called_with(ngx.HTTP_FORBIDDEN)
+    end)
+
+    it("lets the request through when the service fails", function()
+      mock_ngx(request_var(), function()
+        return { status = 502, header = {}, body = "" }
+      end)
+      anomaly_scoring.set_config({ enabled = true, cache_ttl = 60, threshold = 0.5 })
+
+      anomaly_scoring.rewrite()
+
+      assert.is_nil(ngx.var.anomaly_score)
+      assert.spy(ngx.exit).was_not_called()
+    end)
+  end)
+end)
@@ -0,0 +1,41 @@
+local auth_cache
+
+describe("auth_cache", function()
+  before_each(function()
+    auth_cache = require_without_cache("auth_cache")
+  end)
+
+  after_each(function()
+    reset_ngx()
+  end)
+
+  describe("ttl()", function()
+    it("returns nil without durations", function()
+      assert.is_nil(auth_cache.ttl(200, ""))
+      assert.is_nil(auth_cache.ttl(200, nil))
+    end)
+
+    it("applies a time without codes to 200, 301 and 302", function()
+      assert.equal(300, auth_cache.ttl(200, "5m"))
+      assert.equal(300, auth_cache.ttl(302, "5m"))
+      assert.is_nil(auth_cache.ttl(401, "5m"))
+    end)
+
+    it("matches the listed status codes", function()
+      local durations = "200 202 10m, 401 30s"
+      assert.equal(600, auth_cache.ttl(202, durations))
+      assert.equal(30, auth_cache.ttl(401, durations))
+      assert.is_nil(auth_cache.ttl(403, durations))
+    end)
+
+    it("falls back to any", function()
+      local durations = "any 1h,200 1d"
+      assert.equal(86400, auth_cache.ttl(200, durations))
+      assert.equal(3600, auth_cache.ttl(403, durations))
+    end)
+
+    it("treats a time without unit as seconds", function()
+      assert.equal(45, auth_cache.ttl(200, "200 45"))
+    end)
+  end)
+end)
@@ -466,6 +466,32 @@ describe("Balancer", function()
       assert.stub(mock_instance.sync).was_called_with(mock_instance, expected_backend)
     end)
 
+    it("does not wrap hostnames and wrapped IPv6 addresses into square brackets", function()
+      local backend = {
+        name = "example-com",
+        endpoints = {
+          { address = "[fd00::1]", port = "8080", maxFails = 0, failTimeout = 0 },
+          { address = "fd00:0:0:0:0:0:0:2", port = "8080", maxFails = 0, failTimeout = 0 },
+          { address = "example.com", port = "8080", maxFails = 0, failTimeout = 0 },
+        }
+      }
+      local expected_backend = {
+        name = "example-com",
+        endpoints = {
+          { address = "[fd00::1]", port = "8080", maxFails = 0, failTimeout = 0 },
+          { address = "[fd00:0:0:0:0:0:0:2]", port = "8080", maxFails = 0, failTimeout = 0 },
+          { address = "example.com", port = "8080", maxFails = 0, failTimeout = 0 },
+        }
+      }
+
+      local mock_instance = { sync = function(backend) end }
+      setmetatable(mock_instance, implementation)
+      implementation.new = function(self, backend) return mock_instance end
+      local s = spy.on(implementation, "new")
+      assert.has_no.errors(function() balancer.sync_backend(util.deepcopy(backend)) end)
+      assert.spy(s).was_called_with(implementation, expected_backend)
+    end)
+
     it("replaces the existing balancer when load balancing config changes for backend", function()
       assert.has_no.errors(function() balancer.sync_backend(backend) end)
 
@@ -105,6 +105,17 @@ describe("Configuration", function()
         assert.equal(ngx.shared.configuration_data:get("backends"), cjson.encode(get_backends()))
       end)
 
+      it("returns the checksum of the posted backends", function()
+        assert.has_no.errors(configuration.call)
+        assert.equal(configuration.get_backends_checksum(), ngx.crc32_long(cjson.encode(get_backends())))
+      end)
+
+      it("returns the checksum of the backends changed out of band", function()
+        assert.has_no.errors(configuration.call)
+        ngx.shared.configuration_data:set("backends", "[]")
+        assert.equal(configuration.get_backends_checksum(), ngx.crc32_long("[]"))
+      end)
+
       context("Failed to read request body", function()
         local mocked_get_body_data = ngx.req.get_body_data
         before_each(function()
@@ -162,6 +173,74 @@ describe("Configuration", function()
         end)
       end)
     end)
+
+    context("POST request in chunks", function()
+      local body = cjson.encode(get_backends())
+      local size = math.ceil(#body / 3)
+
+      local function post_chunk(generation, chunk)
+        ngx.var.request_method = "POST"
+        ngx.var.request_uri = "/configuration/backends"
+        ngx.var.http_x_configuration_generation = tostring(generation)
+        ngx.var.http_x_configuration_chunk = tostring(chunk)
+        ngx.var.http_x_configuration_chunks = "3"
+        ngx.req.get_body_data = function() return body:sub(chunk * size + 1, (chunk + 1) * size) end
+        assert.has_no.errors(configuration.call)
+      end
+
+      before_each(function()
+        ngx.shared.configuration_data:flush_all()
+      end)
+
+      it("stores the backends when the last chunk is received", function()
+        post_chunk(10, 0)
+        assert.equal(ngx.HTTP_ACCEPTED, ngx.status)
+        post_chunk(10, 2)
+        assert.equal(ngx.HTTP_ACCEPTED, ngx.status)
+        assert.is_nil(ngx.shared.configuration_data:get("backends"))
+
+        post_chunk(10, 1)
+        assert.equal(ngx.HTTP_CREATED, ngx.status)
+        assert.equal(body, ngx.shared.configuration_data:get("backends"))
+        assert.equal(10, ngx.shared.configuration_data:get("backends_generation"))
+      end)
+
+      it("rejects the chunks of a stale generation", function()
+        for chunk = 0, 2 do
+          post_chunk(10, chunk)
+        end
+
+        post_chunk(9, 0)
+        assert.equal(ngx.HTTP_CONFLICT, ngx.status)
+      end)
+
+      it("returns a status of 503 when the chunk can not be stored", function()
+        local resty_configuration_data_safe_add = ngx.shared.configuration_data.safe_add
+        ngx.shared.configuration_data.safe_add = function() return false, "no memory" end
+
+        post_chunk(10, 0)
+        assert.equal(ngx.HTTP_SERVICE_UNAVAILABLE, ngx.status)
+
+        ngx.shared.configuration_data.safe_add = resty_configuration_data_safe_add
+      end)
+
+      it("discards the stored chunks of the generation when a chunk can not be stored", function()
+        post_chunk(10, 0)
+        assert.equal(ngx.HTTP_ACCEPTED, ngx.status)
+        assert.is_not_nil(ngx.shared.configuration_data:get("chunk:backends:10:0"))
+
+        local resty_configuration_data_incr = ngx.shared.configuration_data.incr
+        ngx.shared.configuration_data.incr = function() return nil, "no memory" end
+
+        post_chunk(10, 1)
+        assert.equal(ngx.HTTP_SERVICE_UNAVAILABLE, ngx.status)
+        assert.is_nil(ngx.shared.configuration_data:get("chunk:backends:10:0"))
+        assert.is_nil(ngx.shared.configuration_data:get("chunk:backends:10:1"))
+        assert.is_nil(ngx.shared.configuration_data:get("chunk:backends:10:received"))
+
+        ngx.shared.configuration_data.incr = resty_configuration_data_incr
+      end)
+    end)
   end)
 
   describe("handle_servers()", function()
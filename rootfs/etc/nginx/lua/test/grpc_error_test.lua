@@ -0,0 +1,64 @@
+local grpc_error
+
+local function mock_ngx(var)
+  local _ngx = {
+    status = 0,
+    var = var,
+    header = {},
+    send_headers = function() end,
+    exit = spy.new(function() end),
+  }
+  setmetatable(_ngx, { __index = ngx })
+  _G.ngx = _ngx
+
+  grpc_error = require_without_cache("grpc_error")
+end
+
+describe("grpc_error", function()
+  after_each(function()
+    reset_ngx()
+  end)
+
+  describe("is_grpc()", function()
+    it("detects the gRPC content types", function()
+      grpc_error = require_without_cache("grpc_error")
+
+      assert.is_true(grpc_error.is_grpc("application/grpc"))
+      assert.is_true(grpc_error.is_grpc("application/grpc+proto"))
+      assert.is_true(grpc_error.is_grpc("application/grpc-web-text"))
+      assert.is_false(grpc_error.is_grpc("text/html"))
+      assert.is_false(grpc_error.is_grpc(nil))
+    end)
+  end)
+
+  describe("respond()", function()
+    it("returns UNAVAILABLE when the upstream fails", function()
+      mock_ngx({ http_content_type = "application/grpc" })
+
+      grpc_error.respond(502)
+
+      assert.equal(200, ngx.status)
+      assert.equal("application/grpc", ngx.header["Content-Type"])
+      assert.equal(14, ngx.header["grpc-status"])
+      assert.spy(ngx.exit).was_called_with(200)
+    end)
+
+    it("returns DEADLINE_EXCEEDED when the upstream times out", function()
+      mock_ngx({ http_content_type = "application/grpc-web+proto" })
+
+      grpc_error.respond(504)
+
+      assert.equal("application/grpc-web+proto", ngx.header["Content-Type"])
+      assert.equal(4, ngx.header["grpc-status"])
+    end)
+
+    it("returns the error page to the other clients", function()
+      mock_ngx({ http_content_type = "application/json" })
+
+      grpc_error.respond(503)
+
+      assert.is_nil(ngx.header["grpc-status"])
+      assert.spy(ngx.exit).was_called_with(503)
+    end)
+  end)
+end)
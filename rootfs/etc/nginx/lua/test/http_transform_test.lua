@@ -0,0 +1,118 @@
+local cjson = require("cjson.safe")
+
+local http_transform
+
+local function encode(rules)
+  return ngx.encode_base64(cjson.encode(rules))
+end
+
+local function mock_ngx(rules, uri, headers, args)
+  local response_headers = {}
+  local _ngx = {
+    var = { transform_rules = rules and encode(rules) or "", uri = uri or "/" },
+    header = response_headers,
+    req = {
+      get_headers = function() return headers or {} end,
+      set_header = spy.new(function() end),
+      clear_header = spy.new(function() end),
+      set_uri = spy.new(function() end),
+      get_uri_args = function() return args or {} end,
+      set_uri_args = spy.new(function() end),
+    },
+    log = function() end,
+  }
+  setmetatable(_ngx, { __index = ngx })
+  _G.ngx = _ngx
+
+  http_transform = require_without_cache("http_transform")
+  return response_headers
+end
+
+describe("http_transform", function()
+  after_each(function()
+    reset_ngx()
+  end)
+
+  describe("transform_path()", function()
+    it("strips and adds the prefixes", function()
+      mock_ngx()
+
+      assert.equal("/users", http_transform.transform_path("/api/users", { stripPrefix = "/api" }))
+      assert.equal("/", http_transform.transform_path("/api", { stripPrefix = "/api" }))
+      assert.equal("/other/api", http_transform.transform_path("/other/api", { stripPrefix = "/api" }))
+      assert.equal("/v2/users", http_transform.transform_path("/api/users", { stripPrefix = "/api", addPrefix = "/v2/" }))
+    end)
+  end)
+
+  describe("rewrite()", function()
+    it("does nothing without rules", function()
+      mock_ngx(nil, "/api/users")
+
+      http_transform.rewrite()
+
+      assert.spy(ngx.req.set_uri).was_not_called()
+      assert.spy(ngx.req.set_uri_args).was_not_called()
+      assert.spy(ngx.req.set_header).was_not_called()
+    end)
+
+    it("changes the request headers", function()
+      mock_ngx({
+        requestHeaders = {
+          remove = { "X-Debug" },
+          rename = { { from = "X-Old", to = "X-New" } },
+          set = { { name = "X-Tenant", value = "blue" } },
+          add = { { name = "X-Tag", value = "b" } },
+        },
+      }, "/", { ["X-Old"] = "value", ["X-Tag"] = "a" })
+
+      http_transform.rewrite()
+
+      assert.spy(ngx.req.clear_header).was_called_with("X-Debug")
+      assert.spy(ngx.req.clear_header).was_called_with("X-Old")
+      assert.spy(ngx.req.set_header).was_called_with("X-New", "value")
+      assert.spy(ngx.req.set_header).was_called_with("X-Tenant", "blue")
+      assert.spy(ngx.req.set_header).was_called_with("X-Tag", { "a", "b" })
+    end)
+
+    it("changes the path and the query parameters", function()
+      mock_ngx({
+        path = { stripPrefix = "/api" },
+        queryParams = {
+          remove = { "debug" },
+          set = { { name = "version", value = "2" } },
+        },
+      }, "/api/users", nil, { debug = "1", page = "3" })
+
+      http_transform.rewrite()
+
+      assert.spy(ngx.req.set_uri).was_called_with("/users")
+      assert.spy(ngx.req.set_uri_args).was_called_with({ page = "3", version = "2" })
+    end)
+
+    it("ignores rules that can not be decoded", function()
+      mock_ngx()
+      ngx.var.transform_rules = "not base64"
+
+      http_transform.rewrite()
+
+      assert.spy(ngx.req.set_uri_args).was_not_called()
+    end)
+  end)
+
+  describe("header()", function()
+    it("changes the response headers", function()
+      local response_headers = mock_ngx({
+        responseHeaders = {
+          remove = { "Server" },
+          set = { { name = "X-Frame-Options", value = "DENY" } },
+        },
+      })
+      response_headers["Server"] = "backend"
+
+      http_transform.header()
+
+      assert.is_nil(response_headers["Server"])
+      assert.equal("DENY", response_headers["X-Frame-Options"])
+    end)
+  end)
+end)
@@ -0,0 +1,59 @@
+local limit_response
+
+local function mock_ngx(status, var)
+  local _ngx = { status = status, var = var, header = {}, ctx = {} }
+  setmetatable(_ngx, { __index = ngx })
+  _G.ngx = _ngx
+
+  limit_response = require_without_cache("limit_response")
+  limit_response.set_config({ limit_req_status_code = 429, limit_conn_status_code = 503 })
+end
+
+describe("limit_response", function()
+  before_each(function()
+    limit_response = require_without_cache("limit_response")
+  end)
+
+  after_each(function()
+    reset_ngx()
+  end)
+
+  describe("retry_after()", function()
+    it("waits at least one second", function()
+      assert.equal(1, limit_response.retry_after("100", "0"))
+      assert.equal(1, limit_response.retry_after(nil, nil))
+    end)
+
+    it("waits for one request of the slowest limit", function()
+      assert.equal(6, limit_response.retry_after("10", "10"))
+      assert.equal(60, limit_response.retry_after("0", "1"))
+      assert.equal(3, limit_response.retry_after("0", "25"))
+    end)
+  end)
+
+  describe("header()", function()
+    it("adds Retry-After to the requests rejected by limit_req", function()
+      mock_ngx(429, { limit_req_status = "REJECTED", limit_rpm = "30", limit_retry_after = "true" })
+
+      limit_response.header()
+
+      assert.equal(2, ngx.header["Retry-After"])
+    end)
+
+    it("adds Retry-After to the requests rejected by limit_conn", function()
+      mock_ngx(503, { limit_conn_status = "REJECTED", limit_retry_after = "true" })
+
+      limit_response.header()
+
+      assert.equal(1, ngx.header["Retry-After"])
+    end)
+
+    it("ignores responses of the backend", function()
+      mock_ngx(429, { limit_req_status = "PASSED", limit_rpm = "30", limit_retry_after = "true" })
+
+      limit_response.header()
+
+      assert.is_nil(ngx.header["Retry-After"])
+    end)
+  end)
+end)
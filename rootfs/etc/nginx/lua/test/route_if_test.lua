@@ -0,0 +1,84 @@
+local cjson = require("cjson.safe")
+
+local route_if
+
+local function encode(rules)
+  return ngx.encode_base64(cjson.encode(rules))
+end
+
+local function mock_ngx(rules, var, headers, method)
+  var = var or {}
+  var.route_rules = rules and encode(rules) or ""
+  local _ngx = {
+    var = var,
+    req = {
+      get_headers = function() return headers or {} end,
+      get_method = function() return method or "GET" end,
+    },
+    log = function() end,
+  }
+  setmetatable(_ngx, { __index = ngx })
+  _G.ngx = _ngx
+
+  route_if = require_without_cache("route_if")
+end
+
+local function str(value)
+  return { op = "str", value = value }
+end
+
+describe("route_if", function()
+  after_each(function()
+    reset_ngx()
+  end)
+
+  describe("eval()", function()
+    it("evaluates the operations on the request", function()
+      mock_ngx(nil, { uri = "/api/users", host = "example.com", scheme = "https", arg_debug = "1" },
+        { ["x-version"] = { "v2", "v3" } }, "POST")
+
+      assert.is_true(route_if.eval({ op = "eq", args = { { op = "method" }, str("POST") } }))
+      assert.is_true(route_if.eval({ op = "startsWith", args = { { op = "path" }, str("/api") } }))
+      assert.is_true(route_if.eval({ op = "endsWith", args = { { op = "host" }, str(".com") } }))
+      assert.is_true(route_if.eval({ op = "contains", args = { { op = "path" }, str("user") } }))
+      assert.is_true(route_if.eval({ op = "matches", args = { { op = "path" }, str("^/api/[a-z]+$") } }))
+      assert.is_true(route_if.eval({ op = "eq", args = { { op = "header", value = "x-version" }, str("v2") } }))
+      assert.is_true(route_if.eval({ op = "has", args = { { op = "query", value = "debug" } } }))
+      assert.is_false(route_if.eval({ op = "has", args = { { op = "cookie", value = "session" } } }))
+      assert.is_true(route_if.eval({ op = "eq", args = { { op = "cookie", value = "session" }, str("") } }))
+      assert.is_true(route_if.eval({ op = "and", args = {
+        { op = "ne", args = { { op = "scheme" }, str("http") } },
+        { op = "not", args = { { op = "false" } } },
+      } }))
+      assert.is_false(route_if.eval({ op = "unknown" }))
+    end)
+  end)
+
+  describe("backend()", function()
+    it("returns nil without rules", function()
+      mock_ngx()
+      assert.is_nil(route_if.backend())
+    end)
+
+    it("returns the backend of the first matching rule", function()
+      mock_ngx({
+        { ["if"] = { op = "false" }, backend = "default-never-80" },
+        { ["if"] = { op = "has", args = { { op = "header", value = "x-beta" } } }, backend = "default-beta-80" },
+        { ["if"] = { op = "true" }, backend = "default-other-80" },
+      }, {}, { ["x-beta"] = "1" })
+
+      assert.equal("default-beta-80", route_if.backend())
+    end)
+
+    it("returns nil when no rule matches", function()
+      mock_ngx({ { ["if"] = { op = "false" }, backend = "default-never-80" } })
+      assert.is_nil(route_if.backend())
+    end)
+
+    it("ignores rules failing to decode", function()
+      mock_ngx()
+      ngx.var.route_rules = "not base64"
+      assert.is_nil(route_if.backend())
+    end)
+  end)
+end)
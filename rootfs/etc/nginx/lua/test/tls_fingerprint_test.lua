@@ -0,0 +1,75 @@
+local tls_fingerprint
+
+local function chrome_like_hello()
+  return {
+    version = 0x0303,
+    ciphers = { 0x0a0a, 0x1301, 0x1302, 0xc02b },
+    extensions = { 0x1a1a, 0x0000, 0x0010, 0x000a, 0x000b, 0x000d, 0x002b },
+    groups = { 0x2a2a, 29, 23 },
+    point_formats = { 0 },
+    signature_algorithms = { 0x0403, 0x0804 },
+    supported_versions = { 0x3a3a, 0x0304, 0x0303 },
+    alpn = "h2",
+  }
+end
+
+local function legacy_hello()
+  return {
+    version = 0x0301,
+    ciphers = { 0x002f },
+    extensions = {},
+    groups = {},
+    point_formats = {},
+    signature_algorithms = {},
+    supported_versions = {},
+  }
+end
+
+describe("tls_fingerprint", function()
+  before_each(function()
+    tls_fingerprint = require_without_cache("tls_fingerprint")
+  end)
+
+  after_each(function()
+    reset_ngx()
+  end)
+
+  describe("ja3()", function()
+    it("hashes the fields of the hello without the GREASE values", function()
+      -- md5 of 771,4865-4866-49195,0-16-10-11-13-43,29-23,0
+      assert.are.equal("3736761f91e3f9597a641ce4c92f256c", tls_fingerprint.ja3(chrome_like_hello()))
+    end)
+
+    it("keeps the empty fields", function()
+      -- md5 of 769,47,,,
+      assert.are.equal("b02be259814e870a469a20ce9b2a7900", tls_fingerprint.ja3(legacy_hello()))
+    end)
+  end)
+
+  describe("ja4()", function()
+    it("uses the highest supported version, the SNI, the counts and the ALPN", function()
+      assert.are.equal("t13d0306h2_5559582ccdc4_fb71836bce29", tls_fingerprint.ja4(chrome_like_hello()))
+    end)
+
+    it("uses the legacy version without extensions", function()
+      assert.are.equal("t10i0100_ba72b8082249_000000000000", tls_fingerprint.ja4(legacy_hello()))
+    end)
+
+    it("uses the hex of a non alphanumeric ALPN", function()
+      local hello = chrome_like_hello()
+      hello.alpn = "\171h\205"
+      assert.are.equal("t13d0306ad", tls_fingerprint.ja4(hello):sub(1, 10))
+    end)
+  end)
+
+  describe("get()", function()
+    it("returns an empty string without TLS", function()
+      local _ngx = { var = { https = "" } }
+      setmetatable(_ngx, { __index = ngx })
+      _G.ngx = _ngx
+      tls_fingerprint = require_without_cache("tls_fingerprint")
+
+      assert.are.equal("", tls_fingerprint.get("ja4"))
+    end)
+  end)
+end)
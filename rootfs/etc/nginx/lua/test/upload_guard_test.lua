@@ -0,0 +1,147 @@
+local upload_guard
+
+local function mock_ngx(var)
+  local _ngx = {
+    var = var,
+    ctx = {},
+    req = { is_internal = function() return false end },
+    exit = spy.new(function() end),
+    log = function() end,
+  }
+  setmetatable(_ngx, { __index = ngx })
+  _G.ngx = _ngx
+
+  upload_guard = require_without_cache("upload_guard")
+end
+
+local function upload_var(addr, length)
+  return {
+    upload_max_concurrent = "2",
+    upload_min_size = "1024",
+    namespace = "default",
+    ingress_name = "files",
+    remote_addr = addr,
+    http_content_length = length,
+  }
+end
+
+local function find(stats, namespace, ingress)
+  for _, s in ipairs(stats) do
+    if s.namespace == namespace and s.ingress == ingress then
+      return s
+    end
+  end
+end
+
+describe("upload_guard", function()
+  before_each(function()
+    ngx.shared.upload_guard:flush_all()
+  end)
+
+  after_each(function()
+    reset_ngx()
+  end)
+
+  describe("acquire()", function()
+    it("ignores the locations without limit", function()
+      local var = upload_var("10.0.0.1", "4096")
+      var.upload_max_concurrent = "0"
+      mock_ngx(var)
+
+      upload_guard.acquire()
+
+      assert.is_nil(ngx.ctx.upload_guard_key)
+    end)
+
+    it("ignores the request bodies smaller than the minimum size", function()
+      mock_ngx(upload_var("10.0.0.1", "512"))
+
+      upload_guard.acquire()
+
+      assert.is_nil(ngx.ctx.upload_guard_key)
+    end)
+
+    it("counts the chunked request bodies", function()
+      local var = upload_var("10.0.0.1", nil)
+      var.http_transfer_encoding = "chunked"
+      mock_ngx(var)
+
+      upload_guard.acquire()
+
+      assert.equal("client:default/files/10.0.0.1", ngx.ctx.upload_guard_key)
+      assert.equal(1, ngx.shared.upload_guard:get("client:default/files/10.0.0.1"))
+    end)
+
+    it("rejects the uploads over the limit of the client", function()
+      for _ = 1, 2 do
+        mock_ngx(upload_var("10.0.0.1", "4096"))
+        upload_guard.acquire()
+        assert.spy(ngx.exit).was_not_called()
+      end
+
+      mock_ngx(upload_var("10.0.0.1", "4096"))
+      upload_guard.acquire()
+      assert.spy(ngx.exit).was_called_with(429)
+      assert.is_nil(ngx.ctx.upload_guard_key)
+      assert.equal(2, ngx.shared.upload_guard:get("client:default/files/10.0.0.1"))
+
+      mock_ngx(upload_var("10.0.0.2", "4096"))
+      upload_guard.acquire()
+      assert.spy(ngx.exit).was_not_called()
+    end)
+
+    it("uses the client address found in the forwarded-for header", function()
+      mock_ngx(upload_var("10.0.0.1", "4096"))
+      ngx.ctx.client_addr = "192.168.0.1"
+
+      upload_guard.acquire()
+
+      assert.equal("client:default/files/192.168.0.1", ngx.ctx.upload_guard_key)
+    end)
+  end)
+
+  describe("release()", function()
+    it("ends the upload", function()
+      mock_ngx(upload_var("10.0.0.1", "4096"))
+      upload_guard.acquire()
+
+      upload_guard.release()
+
+      assert.is_nil(ngx.ctx.upload_guard_key)
+      assert.equal(0, ngx.shared.upload_guard:get("client:default/files/10.0.0.1"))
+    end)
+
+    it("does nothing when the upload was not counted", function()
+      mock_ngx(upload_var("10.0.0.1", "512"))
+      upload_guard.acquire()
+
+      upload_guard.release()
+
+      assert.is_nil(ngx.shared.upload_guard:get("client:default/files/10.0.0.1"))
+    end)
+  end)
+
+  describe("stats()", function()
+    it("returns the uploads in flight and rejected per Ingress", function()
+      for _ = 1, 3 do
+        mock_ngx(upload_var("10.0.0.1", "4096"))
+        upload_guard.acquire()
+      end
+      mock_ngx(upload_var("10.0.0.2", "4096"))
+      upload_guard.acquire()
+
+      local var = upload_var("10.0.0.1", "4096")
+      var.ingress_name = "other"
+      mock_ngx(var)
+      upload_guard.acquire()
+      upload_guard.release()
+
+      local stats = upload_guard.stats()
+      assert.equal(2, #stats)
+      assert.same({ namespace = "default", ingress = "files", in_flight = 3, rejected = 1 },
+        find(stats, "default", "files"))
+      assert.same({ namespace = "default", ingress = "other", in_flight = 0, rejected = 0 },
+        find(stats, "default", "other"))
+    end)
+  end)
+end)
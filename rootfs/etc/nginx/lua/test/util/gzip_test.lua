@@ -0,0 +1,25 @@
+local gzip = require("util.gzip")
+
+describe("gzip", function()
+  describe("decompress", function()
+    it("returns the content of gzip data", function()
+      local data = ngx.decode_base64("H4sIAAAAAAACA6tWSkpMzk7NSylWsoqOrQUAn2kdWg8AAAA=")
+      local content, err = gzip.decompress(data)
+      assert.is_nil(err)
+      assert.equal('{"backends":[]}', content)
+    end)
+
+    it("returns an error for invalid data", function()
+      local content, err = gzip.decompress("not gzip data")
+      assert.is_nil(content)
+      assert.is.truthy(err)
+    end)
+
+    it("returns an error for truncated data", function()
+      local data = ngx.decode_base64("H4sIAAAAAAACA6tWSkpMzk7NSylWsoqOrQUAn2kdWg8AAAA=")
+      local content, err = gzip.decompress(data:sub(1, 12))
+      assert.is_nil(content)
+      assert.is.truthy(err)
+    end)
+  end)
+end)
@@ -0,0 +1,38 @@
+local shared_dict = require("util.shared_dict")
+
+local function find(stats, name)
+  for _, s in ipairs(stats) do
+    if s.name == name then
+      return s
+    end
+  end
+end
+
+describe("shared_dict", function()
+  before_each(function()
+    ngx.shared.configuration_data:flush_all()
+  end)
+
+  describe("stats", function()
+    it("returns the capacity and free space of every shared dictionary", function()
+      local stats = shared_dict.stats()
+
+      local certificate_data = find(stats, "certificate_data")
+      assert.is.truthy(certificate_data)
+      assert.equal(16 * 1024 * 1024, certificate_data.capacity)
+      assert.is_true(certificate_data.free_space <= certificate_data.capacity)
+      assert.equal(0, certificate_data.evictions)
+    end)
+
+    it("returns the evictions recorded for a shared dictionary", function()
+      shared_dict.record_eviction("certificate_data")
+      shared_dict.record_eviction("certificate_data")
+      shared_dict.record_eviction("certificate_servers")
+
+      local stats = shared_dict.stats()
+      assert.equal(2, find(stats, "certificate_data").evictions)
+      assert.equal(1, find(stats, "certificate_servers").evictions)
+      assert.equal(0, find(stats, "ocsp_response_cache").evictions)
+    end)
+  end)
+end)
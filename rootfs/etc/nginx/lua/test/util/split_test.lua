@@ -6,13 +6,25 @@ describe("split", function()
     it("splits value of an upstream variable and returns last value", function()
       for _, case in ipairs({{"127.0.0.1:26157 : 127.0.0.1:26158", "127.0.0.1:26158"},
                              {"127.0.0.1:26157, 127.0.0.1:26158", "127.0.0.1:26158"},
-                             {"127.0.0.1:26158", "127.0.0.1:26158"}}) do
+                             {"127.0.0.1:26158", "127.0.0.1:26158"},
+                             {"[fd00::1]:26157 : [fd00::2]:26158", "[fd00::2]:26158"}}) do
         local last = split.get_last_value(case[1])
         assert.equal(case[2], last)
       end
     end)
   end)
 
+  describe("split_upstream_addr", function()
+    it("splits IPv4 and IPv6 addresses into host and port", function()
+      local addrs, err = split.split_upstream_addr("127.0.0.1:26157 : [fd00::1]:26158")
+      assert.is_nil(err)
+      assert.are.same({
+        { host = "127.0.0.1", port = "26157" },
+        { host = "fd00::1", port = "26158" },
+      }, addrs)
+    end)
+  end)
+
   describe("split_string", function()
 
     it("returns empty array if input string is empty", function()
@@ -0,0 +1,286 @@
+local ffi = require("ffi")
+local ssl = require("ngx.ssl")
+local lrucache = require("resty.lrucache")
+local resty_sha256 = require("resty.sha256")
+local resty_str = require("resty.string")
+
+local ngx = ngx
+local C = ffi.C
+local bit = bit
+local table_concat = table.concat
+local table_sort = table.sort
+local string_format = string.format
+local string_byte = string.byte
+local tonumber = tonumber
+local ipairs = ipairs
+local pcall = pcall
+
+-- the ClientHello can only be read in the client hello callback of OpenSSL
+pcall(ffi.cdef, [[
+unsigned int SSL_client_hello_get0_legacy_version(void *s);
+size_t SSL_client_hello_get0_ciphers(void *s, const unsigned char **out);
+int SSL_client_hello_get_extension_order(void *s, uint16_t *exts, size_t *num_exts);
+int SSL_client_hello_get0_ext(void *s, unsigned int type, const unsigned char **out,
+                              size_t *outlen);
+]])
+
+local EXT_SERVER_NAME = 0x0000
+local EXT_SUPPORTED_GROUPS = 0x000a
+local EXT_EC_POINT_FORMATS = 0x000b
+local EXT_SIGNATURE_ALGORITHMS = 0x000d
+local EXT_ALPN = 0x0010
+local EXT_SUPPORTED_VERSIONS = 0x002b
+
+local JA4_VERSIONS = {
+  [0x0304] = "13",
+  [0x0303] = "12",
+  [0x0302] = "11",
+  [0x0301] = "10",
+  [0x0300] = "s3",
+  [0x0002] = "s2",
+  [0xfeff] = "d1",
+  [0xfefd] = "d2",
+  [0xfefc] = "d3",
+}
+
+local EMPTY_HASH = "000000000000"
+
+-- fingerprints of the connections by the address of their SSL object, the
+-- object of a closed connection is only reused for a new handshake
+local fingerprints = lrucache.new(65536)
+
+local _M = {}
+
+local function is_grease(value)
+  return bit.band(value, 0x0f0f) == 0x0a0a and bit.rshift(value, 8) == bit.band(value, 0xff)
+end
+
+local function without_grease(values)
+  local out = {}
+  for _, value in ipairs(values) do
+    if not is_grease(value) then
+      out[#out + 1] = value
+    end
+  end
+  return out
+end
+
+-- parses a list of integers of size bytes from the offset of the data
+local function uint_list(data, offset, size)
+  local out = {}
+  for i = offset, #data - size + 1, size do
+    local value = 0
+    for j = 0, size - 1 do
+      value = value * 256 + string_byte(data, i + j)
+    end
+    out[#out + 1] = value
+  end
+  return out
+end
+
+local function sha256_12(text)
+  local sha256 = resty_sha256:new()
+  sha256:update(text)
+  return resty_str.to_hex(sha256:final()):sub(1, 12)
+end
+
+local function hex_list(values, sorted)
+  local out = {}
+  for i, value in ipairs(values) do
+    out[i] = string_format("%04x", value)
+  end
+  if sorted then
+    table_sort(out)
+  end
+  return table_concat(out, ",")
+end
+
+local function is_alphanumeric(byte)
+  return (byte >= 0x30 and byte <= 0x39) or (byte >= 0x41 and byte <= 0x5a) or
+    (byte >= 0x61 and byte <= 0x7a)
+end
+
+-- returns the JA3 fingerprint, the MD5 of the version, ciphers, extensions,
+-- curves and point formats of the hello, without the GREASE values
+function _M.ja3(hello)
+  local text = table_concat({
+    hello.version,
+    table_concat(without_grease(hello.ciphers), "-"),
+    table_concat(without_grease(hello.extensions), "-"),
+    table_concat(without_grease(hello.groups), "-"),
+    table_concat(hello.point_formats, "-"),
+  }, ",")
+
+  return ngx.md5(text)
+end
+
+-- returns the JA4 fingerprint of the hello of a TCP connection
+function _M.ja4(hello)
+  local version = hello.version
+  local versions = without_grease(hello.supported_versions)
+  if #versions > 0 then
+    version = 0
+    for _, v in ipairs(versions) do
+      if v > version then
+        version = v
+      end
+    end
+  end
+
+  local ciphers = without_grease(hello.ciphers)
+  local extensions = without_grease(hello.extensions)
+
+  local sni = "i"
+  local hashed = {}
+  for _, ext in ipairs(extensions) do
+    if ext == EXT_SERVER_NAME then
+      sni = "d"
+    end
+    if ext ~= EXT_SERVER_NAME and ext ~= EXT_ALPN then
+      hashed[#hashed + 1] = ext
+    end
+  end
+
+  local alpn = "00"
+  local first = hello.alpn
+  if first and #first > 0 then
+    local a, z = string_byte(first, 1), string_byte(first, -1)
+    if is_alphanumeric(a) and is_alphanumeric(z) then
+      alpn = first:sub(1, 1) .. first:sub(-1)
+    else
+      local hex = resty_str.to_hex(first)
+      alpn = hex:sub(1, 1) .. hex:sub(-1)
+    end
+  end
+
+  local a = string_format("t%s%s%02d%02d%s", JA4_VERSIONS[version] or "00", sni,
+    #ciphers > 99 and 99 or #ciphers, #extensions > 99 and 99 or #extensions, alpn)
+
+  local b = EMPTY_HASH
+  if #ciphers > 0 then
+    b = sha256_12(hex_list(ciphers, true))
+  end
+
+  local c = EMPTY_HASH
+  if #hashed > 0 then
+    local text = hex_list(hashed, true)
+    if #hello.signature_algorithms > 0 then
+      text = text .. "_" .. hex_list(hello.signature_algorithms, false)
+    end
+    c = sha256_12(text)
+  end
+
+  return a .. "_" .. b .. "_" .. c
+end
+
+local function get_ext(s, ext_type)
+  local out = ffi.new("const unsigned char *[1]")
+  local outlen = ffi.new("size_t[1]")
+  if C.SSL_client_hello_get0_ext(s, ext_type, out, outlen) ~= 1 then
+    return nil
+  end
+  return ffi.string(out[0], outlen[0])
+end
+
+-- reads the hello of the handshake in progress
+local function read_hello(s)
+  local ciphers = ffi.new("const unsigned char *[1]")
+  local ciphers_len = C.SSL_client_hello_get0_ciphers(s, ciphers)
+
+  local num = ffi.new("size_t[1]")
+  if C.SSL_client_hello_get_extension_order(s, nil, num) ~= 1 then
+    return nil, "failed to read the extensions"
+  end
+  local extensions = {}
+  if num[0] > 0 then
+    local exts = ffi.new("uint16_t[?]", num[0])
+    if C.SSL_client_hello_get_extension_order(s, exts, num) ~= 1 then
+      return nil, "failed to read the extensions"
+    end
+    for i = 0, tonumber(num[0]) - 1 do
+      extensions[#extensions + 1] = exts[i]
+    end
+  end
+
+  local hello = {
+    version = C.SSL_client_hello_get0_legacy_version(s),
+    ciphers = uint_list(ffi.string(ciphers[0], ciphers_len), 1, 2),
+    extensions = extensions,
+    groups = {},
+    point_formats = {},
+    signature_algorithms = {},
+    supported_versions = {},
+  }
+
+  local data = get_ext(s, EXT_SUPPORTED_GROUPS)
+  if data then
+    hello.groups = uint_list(data, 3, 2)
+  end
+  data = get_ext(s, EXT_EC_POINT_FORMATS)
+  if data then
+    hello.point_formats = uint_list(data, 2, 1)
+  end
+  data = get_ext(s, EXT_SIGNATURE_ALGORITHMS)
+  if data then
+    hello.signature_algorithms = uint_list(data, 3, 2)
+  end
+  data = get_ext(s, EXT_SUPPORTED_VERSIONS)
+  if data then
+    hello.supported_versions = uint_list(data, 2, 2)
+  end
+  data = get_ext(s, EXT_ALPN)
+  if data and #data > 3 then
+    local len = string_byte(data, 3)
+    hello.alpn = data:sub(4, 3 + len)
+  end
+
+  return hello
+end
+
+local function connection_key()
+  local s, err = ssl.get_req_ssl_pointer()
+  if not s then
+    return nil, err
+  end
+  return tonumber(ffi.cast("uintptr_t", s)), s
+end
+
+-- computes the fingerprints of the connection in the client hello phase
+function _M.client_hello()
+  local key, s = connection_key()
+  if not key then
+    ngx.log(ngx.ERR, "failed to get the SSL object: ", s)
+    return
+  end
+
+  local ok, hello, err = pcall(read_hello, s)
+  if not ok or not hello then
+    ngx.log(ngx.ERR, "failed to read the client hello: ", ok and err or hello)
+    fingerprints:delete(key)
+    return
+  end
+
+  fingerprints:set(key, { ja3 = _M.ja3(hello), ja4 = _M.ja4(hello) })
+end
+
+-- returns the fingerprint of the connection of the request, ja3 or ja4,
+-- or an empty string for the connections without TLS
+function _M.get(kind)
+  if ngx.var.https ~= "on" then
+    return ""
+  end
+
+  local key = connection_key()
+  if not key then
+    return ""
+  end
+
+  local fingerprint = fingerprints:get(key)
+  if not fingerprint then
+    return ""
+  end
+
+  return fingerprint[kind]
+end
+
+return _M
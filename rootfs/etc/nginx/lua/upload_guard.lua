@@ -0,0 +1,135 @@
+local shared_dict = require("util.shared_dict")
+
+local ngx = ngx
+local pairs = pairs
+local ipairs = ipairs
+local table = table
+local tonumber = tonumber
+local string_format = string.format
+
+local _M = {}
+
+local DICT_NAME = "upload_guard"
+-- the uploads in flight are counted per Ingress and client address
+-- in keys like client:<namespace>/<ingress>/<address>
+local CLIENT_KEY_FORMAT = "client:%s/%s/%s"
+local CLIENT_KEY_PATTERN = "^client:([^/]+)/([^/]+)/"
+local REJECTED_KEY_FORMAT = "rejected:%s/%s"
+local REJECTED_KEY_PATTERN = "^rejected:([^/]+)/([^/]+)$"
+-- the counters of the clients expire in case an upload is never released,
+-- for instance when ngx.ctx is lost in an internal redirect
+local CLIENT_KEY_TTL = 3600
+
+-- is_upload checks the request body is large enough to be counted.
+-- The size of a chunked body is unknown, so it is always counted.
+local function is_upload(min_size)
+  local length = tonumber(ngx.var.http_content_length)
+  if length then
+    return length >= min_size
+  end
+
+  local transfer_encoding = ngx.var.http_transfer_encoding
+  return transfer_encoding ~= nil and transfer_encoding:lower():find("chunked", 1, true) ~= nil
+end
+
+-- acquire gets called in the rewrite phase and rejects the upload
+-- when the client already sends upload_max_concurrent uploads to the Ingress
+function _M.acquire()
+  local max_concurrent = tonumber(ngx.var.upload_max_concurrent)
+  if not max_concurrent or max_concurrent <= 0 or ngx.req.is_internal() then
+    return
+  end
+
+  if not is_upload(tonumber(ngx.var.upload_min_size) or 0) then
+    return
+  end
+
+  local dict = ngx.shared[DICT_NAME]
+  if not dict then
+    return
+  end
+
+  local namespace, ingress = ngx.var.namespace, ngx.var.ingress_name
+  -- the realip module replaces remote_addr after the rewrite phase when forwarded-for-hops is used
+  local client_addr = ngx.ctx.client_addr or ngx.var.remote_addr
+  local key = string_format(CLIENT_KEY_FORMAT, namespace, ingress, client_addr)
+
+  local count, err, forcible = dict:incr(key, 1, 0, CLIENT_KEY_TTL)
+  if not count then
+    -- the upload is allowed when it can not be counted
+    ngx.log(ngx.ERR, "failed to count upload of ", client_addr, ": ", err)
+    return
+  end
+  if forcible then
+    shared_dict.record_eviction(DICT_NAME)
+  end
+
+  ngx.ctx.upload_guard_key = key
+
+  if count > max_concurrent then
+    _M.release()
+    dict:incr(string_format(REJECTED_KEY_FORMAT, namespace, ingress), 1, 0)
+    ngx.log(ngx.WARN, "rejecting upload of ", client_addr, " to ingress ", namespace, "/", ingress,
+      ": ", max_concurrent, " uploads already in flight")
+    return ngx.exit(ngx.HTTP_TOO_MANY_REQUESTS)
+  end
+end
+
+-- release gets called in the log phase and ends the upload counted by acquire
+function _M.release()
+  local key = ngx.ctx.upload_guard_key
+  if not key then
+    return
+  end
+  ngx.ctx.upload_guard_key = nil
+
+  -- the key is not initialized again when it expired during the upload
+  local _, err = ngx.shared[DICT_NAME]:incr(key, -1)
+  if err and err ~= "not found" then
+    ngx.log(ngx.WARN, "failed to release upload ", key, ": ", err)
+  end
+end
+
+-- stats returns the number of uploads in flight and
+-- the number of rejected uploads of every Ingress
+function _M.stats()
+  local dict = ngx.shared[DICT_NAME]
+  if not dict then
+    return {}
+  end
+
+  local ingresses = {}
+  local function get(namespace, ingress)
+    local id = namespace .. "/" .. ingress
+    if not ingresses[id] then
+      ingresses[id] = { namespace = namespace, ingress = ingress, in_flight = 0, rejected = 0 }
+    end
+    return ingresses[id]
+  end
+
+  for _, key in ipairs(dict:get_keys(0)) do
+    local namespace, ingress = key:match(CLIENT_KEY_PATTERN)
+    if namespace then
+      -- the counters of finished uploads stay at zero until they expire
+      local s = get(namespace, ingress)
+      local count = dict:get(key) or 0
+      if count > 0 then
+        s.in_flight = s.in_flight + count
+      end
+    else
+      namespace, ingress = key:match(REJECTED_KEY_PATTERN)
+      if namespace then
+        get(namespace, ingress).rejected = dict:get(key) or 0
+      end
+    end
+  end
+
+  local stats = {}
+  for _, s in pairs(ingresses) do
+    table.insert(stats, s)
+  end
+
+  return stats
+end
+
+return _M
@@ -0,0 +1,85 @@
+local ffi = require("ffi")
+
+local ffi_new = ffi.new
+local ffi_string = ffi.string
+local ffi_sizeof = ffi.sizeof
+local table = table
+local tostring = tostring
+
+ffi.cdef[[
+typedef struct z_stream_s {
+  const char *next_in;
+  unsigned int avail_in;
+  unsigned long total_in;
+  unsigned char *next_out;
+  unsigned int avail_out;
+  unsigned long total_out;
+  const char *msg;
+  void *state;
+  void *zalloc;
+  void *zfree;
+  void *opaque;
+  int data_type;
+  unsigned long adler;
+  unsigned long reserved;
+} z_stream;
+
+const char *zlibVersion(void);
+int inflateInit2_(z_stream *strm, int windowBits, const char *version, int stream_size);
+int inflate(z_stream *strm, int flush);
+int inflateEnd(z_stream *strm);
+]]
+
+local Z_OK = 0
+local Z_STREAM_END = 1
+local Z_NO_FLUSH = 0
+
+-- 15 bits of window size plus 16 to decode gzip headers instead of zlib ones
+local GZIP_WINDOW_BITS = 31
+local BUFFER_SIZE = 64 * 1024
+
+local zlib
+
+local _M = {}
+
+-- decompress returns the content of data compressed with gzip
+function _M.decompress(data)
+  if not zlib then
+    local ok, lib = pcall(ffi.load, "z")
+    if not ok then
+      return nil, "failed to load zlib: " .. tostring(lib)
+    end
+    zlib = lib
+  end
+
+  local stream = ffi_new("z_stream")
+  local ret = zlib.inflateInit2_(stream, GZIP_WINDOW_BITS, zlib.zlibVersion(), ffi_sizeof(stream))
+  if ret ~= Z_OK then
+    return nil, "failed to initialize inflate: " .. ret
+  end
+
+  local buffer = ffi_new("unsigned char[?]", BUFFER_SIZE)
+  local out = {}
+
+  stream.next_in = data
+  stream.avail_in = #data
+
+  repeat
+    stream.next_out = buffer
+    stream.avail_out = BUFFER_SIZE
+
+    ret = zlib.inflate(stream, Z_NO_FLUSH)
+    if ret ~= Z_OK and ret ~= Z_STREAM_END then
+      zlib.inflateEnd(stream)
+      return nil, "failed to inflate: " .. ret
+    end
+
+    table.insert(out, ffi_string(buffer, BUFFER_SIZE - stream.avail_out))
+  until ret == Z_STREAM_END
+
+  zlib.inflateEnd(stream)
+
+  return table.concat(out)
+end
+
+return _M
@@ -0,0 +1,47 @@
+local ngx = ngx
+local pairs = pairs
+local table = table
+
+-- the counters of evictions are stored in configuration_data to share them between the workers
+local EVICTIONS_KEY_PREFIX = "evictions:"
+
+local _M = {}
+
+-- record_eviction counts an entry removed from the shared dictionary
+-- to store a new one, reported by the forcible return value of set
+function _M.record_eviction(name)
+  local counters = ngx.shared.configuration_data
+  if not counters then
+    return
+  end
+
+  local _, err = counters:incr(EVICTIONS_KEY_PREFIX .. name, 1, 0)
+  if err then
+    ngx.log(ngx.WARN, "failed to count eviction of shared dictionary ", name, ": ", err)
+  end
+end
+
+-- stats returns the capacity and free space in bytes
+-- and the number of evictions of every shared dictionary
+function _M.stats()
+  local counters = ngx.shared.configuration_data
+  local stats = {}
+
+  for name, dict in pairs(ngx.shared) do
+    local evictions = 0
+    if counters then
+      evictions = counters:get(EVICTIONS_KEY_PREFIX .. name) or 0
+    end
+
+    table.insert(stats, {
+      name = name,
+      capacity = dict:capacity(),
+      free_space = dict:free_space(),
+      evictions = evictions,
+    })
+  end
+
+  return stats
+end
+
+return _M
@@ -2,9 +2,14 @@ local ipairs = ipairs
 
 local _M = {}
 
--- splits strings into host and port
+-- splits strings into host and port, IPv6 hosts are wrapped into square brackets
 local function parse_addr(addr)
-  local _, _, host, port = addr:find("([^:]+):([^:]+)")
+  local _, _, host, port = addr:find("^%[([^%]]+)%]:(%d+)$")
+  if host and port then
+    return {host=host, port=port}
+  end
+
+  _, _, host, port = addr:find("([^:]+):([^:]+)")
   if host and port then
     return {host=host, port=port}
   else
@@ -269,6 +269,34 @@ var _ = framework.DescribeAnnotation("cors-*", func() {
 			ValueEqual("Access-Control-Allow-Origin", []string{origin2})
 	})
 
+	ginkgo.It("should vary with the origin for multiple cors values", func() {
+		host := corsHost
+		annotations := map[string]string{
+			"nginx.ingress.kubernetes.io/enable-cors":       "true",
+			"nginx.ingress.kubernetes.io/cors-allow-origin": "https://origin2.cors.com, https://origin.com",
+		}
+
+		ing := framework.NewSingleIngress(host, "/", host, f.Namespace, framework.EchoService, 80, annotations)
+		f.EnsureIngress(ing)
+
+		for _, origin := range []string{"https://origin.com", "https://no.origin.com"} {
+			f.HTTPTestClient().
+				GET("/").
+				WithHeader("Host", host).
+				WithHeader("Origin", origin).
+				Expect().
+				Status(http.StatusOK).
+				Header("Vary").Contains("Origin")
+		}
+
+		f.HTTPTestClient().
+			GET("/").
+			WithHeader("Host", host).
+			WithHeader("Origin", "https://evil.com/https://origin.com").
+			Expect().
+			Headers().NotContainsKey("Access-Control-Allow-Origin")
+	})
+
 	ginkgo.It("should not break functionality", func() {
 		host := corsHost
 		annotations := map[string]string{
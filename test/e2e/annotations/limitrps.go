@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+
+	"k8s.io/ingress-nginx/test/e2e/framework"
+)
+
+var _ = framework.DescribeAnnotation("Annotation - limit-rps", func() {
+	f := framework.NewDefaultFramework("limit-rps")
+
+	ginkgo.BeforeEach(func() {
+		f.NewEchoDeployment()
+	})
+
+	ginkgo.It("should reject the requests above the rate limit", func() {
+		host := "limit-rps"
+
+		f.UpdateNginxConfigMapData("limit-req-status-code", "429")
+
+		annotations := map[string]string{
+			"nginx.ingress.kubernetes.io/limit-rps":              "2",
+			"nginx.ingress.kubernetes.io/limit-burst-multiplier": "1",
+		}
+
+		ing := framework.NewSingleIngress(host, "/", host, f.Namespace, framework.EchoService, 80, annotations)
+		f.EnsureIngress(ing)
+		f.WaitForNginxLocationDirectives(host, "/", func(location *framework.Directive) bool {
+			return location.Has("limit_req")
+		})
+
+		// 100 requests in 5 seconds, when about 12 are accepted
+		result := f.GenerateLoad(framework.LoadOptions{
+			Host:     host,
+			RPS:      20,
+			Duration: 5 * time.Second,
+		})
+
+		result.AssertErrorRateBelow(0)
+		result.AssertStatusRateAbove(http.StatusOK, 0.05)
+		result.AssertStatusRateAbove(http.StatusTooManyRequests, 0.7)
+		result.AssertLatencyBelow(99, time.Second)
+	})
+})
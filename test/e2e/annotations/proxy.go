@@ -127,11 +127,11 @@ var _ = framework.DescribeAnnotation("proxy-*", func() {
 		ing := framework.NewSingleIngress(host, "/", host, f.Namespace, framework.EchoService, 80, annotations)
 		f.EnsureIngress(ing)
 
-		f.WaitForNginxServer(host,
-			func(server string) bool {
-				return strings.Contains(server, fmt.Sprintf("proxy_connect_timeout %ss;", proxyConnectTimeout)) &&
-					strings.Contains(server, fmt.Sprintf("proxy_send_timeout %ss;", proxySendTimeout)) &&
-					strings.Contains(server, fmt.Sprintf("proxy_read_timeout %ss;", proxyReadtimeout))
+		f.WaitForNginxLocationDirectives(host, "/",
+			func(location *framework.Directive) bool {
+				return location.Value("proxy_connect_timeout") == proxyConnectTimeout+"s" &&
+					location.Value("proxy_send_timeout") == proxySendTimeout+"s" &&
+					location.Value("proxy_read_timeout") == proxyReadtimeout+"s"
 			})
 	})
 
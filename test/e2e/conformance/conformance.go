@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance contains a suite of tests checking the behavior
+// defined by the Ingress API against an already deployed controller.
+// Unlike the e2e suite, it does not deploy the ingress controller,
+// so it can certify any build or distribution of the controller.
+package conformance
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"k8s.io/ingress-nginx/test/e2e/framework"
+)
+
+// TestContextType describes the controller instance under test
+type TestContextType struct {
+	// KubeConfig is the kubeconfig file used to create the resources of the tests.
+	// If empty the in-cluster configuration is used
+	KubeConfig string
+	// IngressClass is the name of the IngressClass of the controller
+	IngressClass string
+	// HTTPAddress is the address (host:port) of the controller for HTTP traffic
+	HTTPAddress string
+	// HTTPSAddress is the address (host:port) of the controller for HTTPS traffic
+	HTTPSAddress string
+	// CheckStatus enables the checks of the load balancer status of the Ingress objects
+	CheckStatus bool
+	// ReportDir is the directory where the reports of the suite are written
+	ReportDir string
+}
+
+// TestContext is the global context of the conformance tests
+var TestContext TestContextType
+
+// RegisterFlags registers the flags of the conformance suite
+func RegisterFlags() {
+	flag.StringVar(&TestContext.KubeConfig, "kubeconfig", "", "Path to a kubeconfig file. If empty, the in-cluster configuration is used")
+	flag.StringVar(&TestContext.IngressClass, "ingress-class", "nginx", "Name of the IngressClass of the controller under test")
+	flag.StringVar(&TestContext.HTTPAddress, "http-address", "", "Address (host:port) of the controller under test for HTTP traffic")
+	flag.StringVar(&TestContext.HTTPSAddress, "https-address", "", "Address (host:port) of the controller under test for HTTPS traffic. Defaults to the host of --http-address and port 443")
+	flag.BoolVar(&TestContext.CheckStatus, "check-status", true, "Check the controller updates the load balancer status of the Ingress objects")
+	flag.StringVar(&TestContext.ReportDir, "report-dir", "", "Directory where the JUnit and JSON reports of the suite are written")
+}
+
+// Validate checks the context contains the information required by the suite
+func (c *TestContextType) Validate() error {
+	if c.HTTPAddress == "" {
+		return fmt.Errorf("the address of the controller under test is required. Please check the flag --http-address")
+	}
+
+	if c.HTTPSAddress == "" {
+		host, _, err := net.SplitHostPort(c.HTTPAddress)
+		if err != nil {
+			return fmt.Errorf("invalid address %v: %w", c.HTTPAddress, err)
+		}
+		c.HTTPSAddress = net.JoinHostPort(host, "443")
+	}
+
+	return nil
+}
+
+// newFramework returns a framework that only creates a namespace for
+// each test, using the client configured in the context of the suite
+func newFramework(baseName string) *framework.Framework {
+	defer ginkgo.GinkgoRecover()
+
+	f := &framework.Framework{
+		BaseName: baseName,
+	}
+
+	ginkgo.BeforeEach(func() {
+		f.IngressClass = TestContext.IngressClass
+		if f.KubeClientSet == nil {
+			config, err := loadConfig()
+			assert.Nil(ginkgo.GinkgoT(), err, "loading a kubernetes client configuration")
+
+			f.KubeConfig = config
+			f.KubeClientSet, err = kubernetes.NewForConfig(config)
+			assert.Nil(ginkgo.GinkgoT(), err, "creating a kubernetes client")
+		}
+
+		f.CreateEnvironment()
+	})
+	ginkgo.AfterEach(f.DestroyEnvironment)
+
+	return f
+}
+
+func loadConfig() (*rest.Config, error) {
+	var (
+		config *rest.Config
+		err    error
+	)
+
+	if TestContext.KubeConfig == "" {
+		config, err = rest.InClusterConfig()
+	} else {
+		config, err = clientcmd.BuildConfigFromFlags("", TestContext.KubeConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	config.UserAgent = "ingress-nginx-conformance"
+	return config, nil
+}
+
+// ensureIngress creates an Ingress of the class of the controller under test
+func ensureIngress(f *framework.Framework, name string, spec networking.IngressSpec) *networking.Ingress {
+	spec.IngressClassName = &TestContext.IngressClass
+
+	ing, err := f.KubeClientSet.NetworkingV1().Ingresses(f.Namespace).Create(context.TODO(), &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: f.Namespace,
+		},
+		Spec: spec,
+	}, metav1.CreateOptions{})
+	assert.Nil(ginkgo.GinkgoT(), err, "creating ingress %v", name)
+
+	return ing
+}
+
+// response is the information returned by the echo server
+type response struct {
+	StatusCode int
+	// Backend is the name of the deployment of the echo server that answered the request
+	Backend string
+	Body    string
+}
+
+var hostnameRegex = regexp.MustCompile(`Hostname: (\S+)`)
+
+// backendOf returns the name of the deployment of a pod of the echo server
+func backendOf(body string) string {
+	match := hostnameRegex.FindStringSubmatch(body)
+	if len(match) != 2 {
+		return ""
+	}
+
+	// pod names of a deployment end with -<replicaset hash>-<pod hash>
+	name := match[1]
+	for i := 0; i < 2; i++ {
+		if idx := strings.LastIndex(name, "-"); idx > 0 {
+			name = name[:idx]
+		}
+	}
+
+	return name
+}
+
+// doRequest sends a request for the given host and path to the controller under test
+func doRequest(scheme framework.RequestScheme, host, path string) (*response, error) {
+	address := TestContext.HTTPAddress
+	if scheme == framework.HTTPS {
+		address = TestContext.HTTPSAddress
+	}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				ServerName:         host,
+				InsecureSkipVerify: true, //nolint:gosec // Ignore the gosec error in testing
+			},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v://%v%v", scheme, address, path), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Host = host
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{
+		StatusCode: resp.StatusCode,
+		Backend:    backendOf(string(body)),
+		Body:       string(body),
+	}, nil
+}
+
+// expectBackend waits until the requests for the host and path are answered by the given backend.
+// An empty backend means the request must not be answered by any of the echo servers of the test.
+func expectBackend(scheme framework.RequestScheme, host, path, backend string) {
+	var last *response
+
+	//nolint:staticcheck // TODO: will replace it since wait.Poll is deprecated
+	err := wait.Poll(framework.Poll, framework.DefaultTimeout, func() (bool, error) {
+		resp, err := doRequest(scheme, host, path)
+		if err != nil {
+			return false, nil
+		}
+
+		last = resp
+		if backend == "" {
+			return resp.Backend == "" && resp.StatusCode == http.StatusNotFound, nil
+		}
+
+		return resp.Backend == backend && resp.StatusCode == http.StatusOK, nil
+	})
+
+	if last == nil {
+		last = &response{}
+	}
+	assert.Nil(ginkgo.GinkgoT(), err, "expected request %v://%v%v to be answered by %q but was answered by %q with status code %v",
+		scheme, host, path, backend, last.Backend, last.StatusCode)
+}
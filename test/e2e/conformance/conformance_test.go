@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	"k8s.io/component-base/logs"
+
+	// required
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/ingress-nginx/test/e2e/framework"
+)
+
+func init() {
+	testing.Init()
+	RegisterFlags()
+	flag.Parse()
+}
+
+func TestConformance(t *testing.T) {
+	logs.InitLogs()
+	defer logs.FlushLogs()
+
+	if err := TestContext.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	framework.Logf("Starting conformance run against %v with IngressClass %v", TestContext.HTTPAddress, TestContext.IngressClass)
+	ginkgo.RunSpecs(t, "ingress conformance suite")
+}
+
+var _ = ginkgo.ReportAfterSuite("conformance report", func(report types.Report) {
+	if TestContext.ReportDir == "" {
+		return
+	}
+
+	err := os.MkdirAll(TestContext.ReportDir, 0o755)
+	if err != nil {
+		framework.Failf("creating report directory: %v", err)
+	}
+
+	err = reporters.GenerateJUnitReport(report, filepath.Join(TestContext.ReportDir, "conformance-report.xml"))
+	if err != nil {
+		framework.Failf("writing JUnit report: %v", err)
+	}
+
+	err = reporters.GenerateJSONReport(report, filepath.Join(TestContext.ReportDir, "conformance-report.json"))
+	if err != nil {
+		framework.Failf("writing JSON report: %v", err)
+	}
+})
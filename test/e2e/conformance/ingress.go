@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"k8s.io/ingress-nginx/test/e2e/framework"
+)
+
+func serviceBackend(service string) networking.IngressBackend {
+	return networking.IngressBackend{
+		Service: &networking.IngressServiceBackend{
+			Name: service,
+			Port: networking.ServiceBackendPort{
+				Number: 80,
+			},
+		},
+	}
+}
+
+func ingressPath(path string, pathType networking.PathType, service string) networking.HTTPIngressPath {
+	return networking.HTTPIngressPath{
+		Path:     path,
+		PathType: &pathType,
+		Backend:  serviceBackend(service),
+	}
+}
+
+func ingressRule(host string, paths ...networking.HTTPIngressPath) networking.IngressRule {
+	return networking.IngressRule{
+		Host: host,
+		IngressRuleValue: networking.IngressRuleValue{
+			HTTP: &networking.HTTPIngressRuleValue{
+				Paths: paths,
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("[Conformance] Ingress", func() {
+	f := newFramework("conformance")
+
+	var host string
+
+	ginkgo.BeforeEach(func() {
+		host = fmt.Sprintf("%v.conformance.test", f.Namespace)
+	})
+
+	ginkgo.It("should send requests without a matching rule to the default backend", ginkgo.Serial, func() {
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-default"))
+
+		ensureIngress(f, "default-backend", networking.IngressSpec{
+			DefaultBackend: &networking.IngressBackend{
+				Service: serviceBackend("echo-default").Service,
+			},
+		})
+
+		expectBackend(framework.HTTP, host, "/", "echo-default")
+		expectBackend(framework.HTTP, "other."+host, "/any/path", "echo-default")
+	})
+
+	ginkgo.It("should route requests by host", func() {
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-a"))
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-b"))
+
+		ensureIngress(f, "hosts", networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				ingressRule("a."+host, ingressPath("/", networking.PathTypePrefix, "echo-a")),
+				ingressRule("b."+host, ingressPath("/", networking.PathTypePrefix, "echo-b")),
+			},
+		})
+
+		expectBackend(framework.HTTP, "a."+host, "/", "echo-a")
+		expectBackend(framework.HTTP, "b."+host, "/", "echo-b")
+		expectBackend(framework.HTTP, "c."+host, "/", "")
+	})
+
+	ginkgo.It("should match Exact paths", func() {
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-a"))
+
+		ensureIngress(f, "exact", networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				ingressRule(host, ingressPath("/foo", networking.PathTypeExact, "echo-a")),
+			},
+		})
+
+		expectBackend(framework.HTTP, host, "/foo", "echo-a")
+		expectBackend(framework.HTTP, host, "/foo?query=value", "echo-a")
+		expectBackend(framework.HTTP, host, "/foo/", "")
+		expectBackend(framework.HTTP, host, "/foobar", "")
+	})
+
+	ginkgo.It("should match Prefix paths by path elements", func() {
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-a"))
+
+		ensureIngress(f, "prefix", networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				ingressRule(host, ingressPath("/foo", networking.PathTypePrefix, "echo-a")),
+			},
+		})
+
+		expectBackend(framework.HTTP, host, "/foo", "echo-a")
+		expectBackend(framework.HTTP, host, "/foo/", "echo-a")
+		expectBackend(framework.HTTP, host, "/foo/bar", "echo-a")
+		expectBackend(framework.HTTP, host, "/foobar", "")
+	})
+
+	ginkgo.It("should prefer the longest matching path", func() {
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-a"))
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-b"))
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-c"))
+
+		ensureIngress(f, "longest-path", networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				ingressRule(host,
+					ingressPath("/", networking.PathTypePrefix, "echo-a"),
+					ingressPath("/foo", networking.PathTypePrefix, "echo-b"),
+					ingressPath("/foo/bar", networking.PathTypeExact, "echo-c"),
+				),
+			},
+		})
+
+		expectBackend(framework.HTTP, host, "/", "echo-a")
+		expectBackend(framework.HTTP, host, "/other", "echo-a")
+		expectBackend(framework.HTTP, host, "/foo/baz", "echo-b")
+		expectBackend(framework.HTTP, host, "/foo/bar", "echo-c")
+		expectBackend(framework.HTTP, host, "/foo/bar/baz", "echo-b")
+	})
+
+	ginkgo.It("should match wildcard hosts with a single DNS label", func() {
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-a"))
+
+		ensureIngress(f, "wildcard", networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				ingressRule("*."+host, ingressPath("/", networking.PathTypePrefix, "echo-a")),
+			},
+		})
+
+		expectBackend(framework.HTTP, "foo."+host, "/", "echo-a")
+		expectBackend(framework.HTTP, "bar.foo."+host, "/", "")
+		expectBackend(framework.HTTP, host, "/", "")
+	})
+
+	ginkgo.It("should terminate TLS with the certificate of the host", func() {
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-a"))
+
+		tlsConfig, err := framework.CreateIngressTLSSecret(f.KubeClientSet, []string{host}, host, f.Namespace)
+		assert.Nil(ginkgo.GinkgoT(), err, "creating TLS secret")
+
+		ensureIngress(f, "tls", networking.IngressSpec{
+			TLS: []networking.IngressTLS{
+				{
+					Hosts:      []string{host},
+					SecretName: host,
+				},
+			},
+			Rules: []networking.IngressRule{
+				ingressRule(host, ingressPath("/", networking.PathTypePrefix, "echo-a")),
+			},
+		})
+
+		//nolint:staticcheck // TODO: will replace it since wait.Poll is deprecated
+		err = wait.Poll(framework.Poll, framework.DefaultTimeout, func() (bool, error) {
+			conn, err := tls.Dial("tcp", TestContext.HTTPSAddress, tlsConfig)
+			if err != nil {
+				return false, nil
+			}
+			conn.Close()
+			return true, nil
+		})
+		assert.Nil(ginkgo.GinkgoT(), err, "waiting for the certificate of host %v", host)
+
+		expectBackend(framework.HTTPS, host, "/", "echo-a")
+	})
+
+	ginkgo.It("should update the load balancer status", func() {
+		if !TestContext.CheckStatus {
+			ginkgo.Skip("the checks of the load balancer status are disabled")
+		}
+
+		f.NewEchoDeployment(framework.WithDeploymentName("echo-a"))
+
+		ensureIngress(f, "status", networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				ingressRule(host, ingressPath("/", networking.PathTypePrefix, "echo-a")),
+			},
+		})
+
+		//nolint:staticcheck // TODO: will replace it since wait.Poll is deprecated
+		err := wait.Poll(framework.Poll, framework.DefaultTimeout, func() (bool, error) {
+			ing, err := f.KubeClientSet.NetworkingV1().Ingresses(f.Namespace).Get(context.TODO(), "status", metav1.GetOptions{})
+			if err != nil {
+				return false, nil
+			}
+			return len(ing.Status.LoadBalancer.Ingress) > 0, nil
+		})
+		assert.Nil(ginkgo.GinkgoT(), err, "waiting for the load balancer status of the ingress")
+	})
+})
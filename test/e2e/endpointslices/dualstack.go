@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpointslices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/ingress-nginx/test/e2e/framework"
+)
+
+var _ = framework.IngressNginxDescribeSerial("[Endpointslices] dual-stack service", func() {
+	f := framework.NewDefaultFramework("dualstack")
+	host := "dualstack.foo.com"
+
+	ginkgo.BeforeEach(func() {
+		if os.Getenv("IS_DUAL_STACK") != "true" {
+			ginkgo.Skip("the cluster is not dual-stack")
+		}
+
+		f.NewEchoDeployment(framework.WithSvcIPFamilyPolicy(corev1.IPFamilyPolicyRequireDualStack))
+	})
+
+	// ipv6Endpoints returns the number of IPv6 endpoints of the echo backend
+	ipv6Endpoints := func() int {
+		status, err := f.ExecIngressPod("/dbg backends all")
+		assert.Nil(ginkgo.GinkgoT(), err)
+
+		var backends []struct {
+			Name      string `json:"name"`
+			Endpoints []struct {
+				Address string `json:"address"`
+			} `json:"endpoints"`
+		}
+		err = json.Unmarshal([]byte(status), &backends)
+		assert.Nil(ginkgo.GinkgoT(), err, "unexpected error unmarshalling backends")
+
+		count := 0
+		for _, backend := range backends {
+			if !strings.HasPrefix(backend.Name, f.Namespace) {
+				continue
+			}
+			for _, endpoint := range backend.Endpoints {
+				if strings.Contains(endpoint.Address, ":") {
+					count++
+				}
+			}
+		}
+		return count
+	}
+
+	ginkgo.It("should proxy the requests to the IPv4 and IPv6 endpoints", func() {
+		ing := framework.NewSingleIngress(host, "/", host, f.Namespace, framework.EchoService, 80, nil)
+		f.EnsureIngress(ing)
+
+		f.WaitForNginxServer(host, func(server string) bool {
+			return strings.Contains(server, fmt.Sprintf("server_name %s", host))
+		})
+
+		assert.Equal(ginkgo.GinkgoT(), 1, ipv6Endpoints())
+
+		// the round robin balancer alternates between both endpoints
+		for i := 0; i < 4; i++ {
+			f.HTTPTestClient().
+				GET("/").
+				WithHeader("Host", host).
+				Expect().
+				Status(http.StatusOK)
+		}
+	})
+
+	ginkgo.It("should ignore the IPv6 endpoints with --enable-ipv6-endpoints=false", func() {
+		err := f.UpdateIngressControllerDeployment(func(deployment *appsv1.Deployment) error {
+			args := deployment.Spec.Template.Spec.Containers[0].Args
+			args = append(args, "--enable-ipv6-endpoints=false")
+			deployment.Spec.Template.Spec.Containers[0].Args = args
+			_, err := f.KubeClientSet.AppsV1().Deployments(f.Namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+			return err
+		})
+		assert.Nil(ginkgo.GinkgoT(), err, "updating deployment")
+
+		ing := framework.NewSingleIngress(host, "/", host, f.Namespace, framework.EchoService, 80, nil)
+		f.EnsureIngress(ing)
+
+		f.WaitForNginxServer(host, func(server string) bool {
+			return strings.Contains(server, fmt.Sprintf("server_name %s", host))
+		})
+
+		assert.Equal(ginkgo.GinkgoT(), 0, ipv6Endpoints())
+
+		f.HTTPTestClient().
+			GET("/").
+			WithHeader("Host", host).
+			Expect().
+			Status(http.StatusOK)
+	})
+})
@@ -58,6 +58,7 @@ type deploymentOptions struct {
 	image          string
 	replicas       int
 	svcAnnotations map[string]string
+	ipFamilyPolicy *corev1.IPFamilyPolicy
 }
 
 // WithDeploymentNamespace allows configuring the deployment's namespace
@@ -76,6 +77,13 @@ func WithSvcTopologyAnnotations() func(*deploymentOptions) {
 	}
 }
 
+// WithSvcIPFamilyPolicy allows configuring the IP family policy of the svc
+func WithSvcIPFamilyPolicy(p corev1.IPFamilyPolicy) func(*deploymentOptions) {
+	return func(o *deploymentOptions) {
+		o.ipFamilyPolicy = &p
+	}
+}
+
 // WithDeploymentName allows configuring the deployment's names
 func WithDeploymentName(n string) func(*deploymentOptions) {
 	return func(o *deploymentOptions) {
@@ -145,6 +153,7 @@ func (f *Framework) NewEchoDeployment(opts ...func(*deploymentOptions)) {
 			Selector: map[string]string{
 				"app": options.name,
 			},
+			IPFamilyPolicy: options.ipFamilyPolicy,
 		},
 	})
 
@@ -184,7 +184,7 @@ func (f *Framework) AfterEach() {
 		return
 	}
 
-	cmd := "cat /etc/nginx/nginx.conf"
+	cmd := fmt.Sprintf("cat %v", f.nginxConfPath())
 	o, err := f.ExecCommand(f.pod, cmd)
 	if err != nil {
 		Logf("Unexpected error obtaining nginx.conf file: %v", err)
@@ -313,9 +313,9 @@ func (f *Framework) matchNginxConditions(name string, matcher func(cfg string) b
 	return func() (bool, error) {
 		var cmd string
 		if name == "" {
-			cmd = "cat /etc/nginx/nginx.conf"
+			cmd = fmt.Sprintf("cat %v", f.nginxConfPath())
 		} else {
-			cmd = fmt.Sprintf("cat /etc/nginx/nginx.conf | awk '/## start server %v/,/## end server %v/'", name, name)
+			cmd = fmt.Sprintf("cat %v | awk '/## start server %v/,/## end server %v/'", f.nginxConfPath(), name, name)
 		}
 
 		o, err := f.ExecCommand(f.pod, cmd)
@@ -361,7 +361,7 @@ func (f *Framework) matchLuaConditions(matcher func(jsonCfg map[string]interface
 
 func (f *Framework) matchNginxCustomConditions(from, to string, matcher func(cfg string) bool) wait.ConditionFunc {
 	return func() (bool, error) {
-		cmd := fmt.Sprintf("cat /etc/nginx/nginx.conf| awk '/%v/,/%v/'", from, to)
+		cmd := fmt.Sprintf("cat %v | awk '/%v/,/%v/'", f.nginxConfPath(), from, to)
 
 		o, err := f.ExecCommand(f.pod, cmd)
 		if err != nil {
@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"crypto/tls"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// LoadOptions defines the traffic sent by GenerateLoad
+type LoadOptions struct {
+	// Host is the value of the Host header of the requests
+	Host string
+	// Path of the requests. Defaults to /
+	Path string
+	// Scheme of the requests. Defaults to HTTP
+	Scheme RequestScheme
+	// Headers added to the requests
+	Headers map[string]string
+	// RPS is the number of requests per second. Defaults to 10
+	RPS int
+	// Duration of the load. Defaults to 5 seconds
+	Duration time.Duration
+	// Concurrency is the maximum number of requests in flight. Defaults to RPS
+	Concurrency int
+	// ReuseConnections keeps the connections alive between requests
+	ReuseConnections bool
+	// Timeout of each request. Defaults to 10 seconds
+	Timeout time.Duration
+}
+
+// LoadResult contains the outcome of the requests sent by GenerateLoad
+type LoadResult struct {
+	// Requests is the number of requests sent
+	Requests int
+	// Errors is the number of requests without a response
+	Errors int
+	// StatusCodes is the number of responses by status code
+	StatusCodes map[int]int
+	// Latencies of the responses, sorted from lowest to highest
+	Latencies []time.Duration
+}
+
+// StatusRate returns the ratio of requests answered with the given status code
+func (r *LoadResult) StatusRate(code int) float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+
+	return float64(r.StatusCodes[code]) / float64(r.Requests)
+}
+
+// ErrorRate returns the ratio of requests without a response or with a 5xx status code
+func (r *LoadResult) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+
+	failed := r.Errors
+	for code, count := range r.StatusCodes {
+		if code >= http.StatusInternalServerError {
+			failed += count
+		}
+	}
+
+	return float64(failed) / float64(r.Requests)
+}
+
+// Percentile returns the latency of the given percentile (0-100) of the responses
+func (r *LoadResult) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+
+	i := int(math.Ceil(p/100*float64(len(r.Latencies)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(r.Latencies) {
+		i = len(r.Latencies) - 1
+	}
+
+	return r.Latencies[i]
+}
+
+// AssertErrorRateBelow fails the test if the error rate is higher than the given ratio
+func (r *LoadResult) AssertErrorRateBelow(maxRate float64) {
+	assert.LessOrEqual(ginkgo.GinkgoT(), r.ErrorRate(), maxRate,
+		"error rate of %v requests (errors: %v, status codes: %v)", r.Requests, r.Errors, r.StatusCodes)
+}
+
+// AssertStatusRateAbove fails the test if the ratio of responses with the given status code is lower than minRate
+func (r *LoadResult) AssertStatusRateAbove(code int, minRate float64) {
+	assert.GreaterOrEqual(ginkgo.GinkgoT(), r.StatusRate(code), minRate,
+		"rate of status code %v of %v requests (status codes: %v)", code, r.Requests, r.StatusCodes)
+}
+
+// AssertLatencyBelow fails the test if the latency of the given percentile is higher than maxLatency
+func (r *LoadResult) AssertLatencyBelow(p float64, maxLatency time.Duration) {
+	assert.LessOrEqual(ginkgo.GinkgoT(), r.Percentile(p), maxLatency,
+		"latency of percentile %v of %v requests", p, r.Requests)
+}
+
+// GenerateLoad sends requests to NGINX at a constant rate during the configured
+// duration and returns the status codes and latencies of the responses.
+func (f *Framework) GenerateLoad(opts LoadOptions) *LoadResult {
+	if opts.Path == "" {
+		opts.Path = "/"
+	}
+	if opts.Scheme == "" {
+		opts.Scheme = HTTP
+	}
+	if opts.RPS <= 0 {
+		opts.RPS = 10
+	}
+	if opts.Duration <= 0 {
+		opts.Duration = 5 * time.Second
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = opts.RPS
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	client := &http.Client{
+		Timeout: opts.Timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				ServerName:         opts.Host,
+				InsecureSkipVerify: true, //nolint:gosec // Ignore the gosec error in testing
+			},
+			DisableKeepAlives:   !opts.ReuseConnections,
+			MaxIdleConnsPerHost: opts.Concurrency,
+		},
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	url := f.GetURL(opts.Scheme) + opts.Path
+
+	result := &LoadResult{StatusCodes: make(map[int]int)}
+	var lock sync.Mutex
+
+	requests := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer ginkgo.GinkgoRecover()
+			defer wg.Done()
+
+			for range requests {
+				code, latency := sendLoadRequest(client, url, &opts)
+
+				lock.Lock()
+				result.Requests++
+				if code == 0 {
+					result.Errors++
+				} else {
+					result.StatusCodes[code]++
+					result.Latencies = append(result.Latencies, latency)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(opts.RPS))
+	deadline := time.After(opts.Duration)
+
+loop:
+	for {
+		select {
+		case <-deadline:
+			break loop
+		case <-ticker.C:
+			select {
+			case requests <- struct{}{}:
+			default:
+				// all the workers are busy, the request counts as an error
+				lock.Lock()
+				result.Requests++
+				result.Errors++
+				lock.Unlock()
+			}
+		}
+	}
+
+	ticker.Stop()
+	close(requests)
+	wg.Wait()
+
+	sort.Slice(result.Latencies, func(i, j int) bool {
+		return result.Latencies[i] < result.Latencies[j]
+	})
+
+	Logf("Load against %v%v: %v requests, %v errors, status codes %v, p50 %v, p99 %v",
+		opts.Host, opts.Path, result.Requests, result.Errors, result.StatusCodes, result.Percentile(50), result.Percentile(99))
+
+	return result
+}
+
+// sendLoadRequest returns the status code and latency of a request, or a
+// status code 0 if there was no response
+func sendLoadRequest(client *http.Client, url string, opts *LoadOptions) (code int, latency time.Duration) {
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return 0, 0
+	}
+
+	req.Host = opts.Host
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0
+	}
+	defer resp.Body.Close()
+
+	// read the body to reuse the connection
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, time.Since(start)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"k8s.io/ingress-nginx/internal/nginx/conf"
+)
+
+// Directive is a parsed nginx directive
+type Directive = conf.Directive
+
+// ParseNginxConfig parses a nginx configuration into a directive without
+// name containing the directives of the main context. The content of the
+// *_by_lua_block directives is not parsed.
+func ParseNginxConfig(cfg string) (*Directive, error) {
+	return conf.Parse([]byte(cfg))
+}
+
+// nginxConfPath returns the path of nginx.conf in the controller pod,
+// which is in the --runtime-dir of the controller when it is set
+func (f *Framework) nginxConfPath() string {
+	if f.pod != nil {
+		for _, container := range f.pod.Spec.Containers {
+			for i, arg := range container.Args {
+				name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+				if name != "runtime-dir" {
+					continue
+				}
+				if !hasValue && i+1 < len(container.Args) {
+					value = container.Args[i+1]
+				}
+				if value != "" {
+					return path.Join(value, "nginx.conf")
+				}
+			}
+		}
+	}
+
+	return "/etc/nginx/nginx.conf"
+}
+
+// WaitForNginxServerDirectives waits until the server section with the given name
+// is present in the nginx configuration and the matcher returns true for its server block.
+func (f *Framework) WaitForNginxServerDirectives(name string, matcher func(server *Directive) bool) {
+	//nolint:staticcheck // TODO: will replace it since wait.Poll is deprecated
+	err := wait.Poll(Poll, DefaultTimeout, f.matchNginxServerDirectives(name, matcher))
+	assert.Nil(ginkgo.GinkgoT(), err, "waiting for nginx server %v directive/s", name)
+}
+
+// WaitForNginxLocationDirectives waits until the server section with the given name
+// contains a location with the given path created by an Ingress of the namespace of
+// the framework, and the matcher returns true for the location block.
+// Only checking the locations of the namespace allows running specs in parallel.
+func (f *Framework) WaitForNginxLocationDirectives(name, path string, matcher func(location *Directive) bool) {
+	//nolint:staticcheck // TODO: will replace it since wait.Poll is deprecated
+	err := wait.Poll(Poll, DefaultTimeout, f.matchNginxServerDirectives(name, func(server *Directive) bool {
+		location := server.Location(path)
+		if location == nil {
+			return false
+		}
+
+		if namespace, ok := location.Variable("namespace"); !ok || namespace != f.Namespace {
+			return false
+		}
+
+		return matcher(location)
+	}))
+	assert.Nil(ginkgo.GinkgoT(), err, "waiting for nginx location %v%v directive/s", name, path)
+}
+
+func (f *Framework) matchNginxServerDirectives(name string, matcher func(server *Directive) bool) wait.ConditionFunc {
+	return func() (bool, error) {
+		cmd := fmt.Sprintf("cat %v | awk '/## start server %v$/,/## end server %v$/'", f.nginxConfPath(), name, name)
+
+		o, err := f.ExecCommand(f.pod, cmd)
+		if err != nil || o == "" {
+			return false, nil
+		}
+
+		if klog.V(10).Enabled() {
+			klog.InfoS("NGINX", "configuration", o)
+		}
+
+		cfg, err := ParseNginxConfig(o)
+		if err != nil {
+			// the section could be incomplete while nginx.conf is written
+			return false, nil
+		}
+
+		servers := cfg.Find("server")
+		if len(servers) == 0 {
+			return false, nil
+		}
+
+		return matcher(servers[0]), nil
+	}
+}
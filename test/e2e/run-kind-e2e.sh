@@ -39,6 +39,7 @@ fi
 
 KIND_LOG_LEVEL="1"
 IS_CHROOT="${IS_CHROOT:-false}"
+export IS_DUAL_STACK="${IS_DUAL_STACK:-false}"
 export KIND_CLUSTER_NAME=${KIND_CLUSTER_NAME:-ingress-nginx-dev}
 DIR="$( cd "$( dirname "${BASH_SOURCE[0]}" )" && pwd )"
 # Use 1.0.0-dev to make sure we use the latest configuration in the helm template
@@ -70,10 +71,15 @@ if [ "${SKIP_CLUSTER_CREATION}" = "false" ]; then
     kind delete cluster --name "${KIND_CLUSTER_NAME}"
   fi
 
+  KIND_CONFIG="${DIR}"/kind.yaml
+  if [ "${IS_DUAL_STACK}" = "true" ]; then
+    KIND_CONFIG="${DIR}"/kind-dual-stack.yaml
+  fi
+
   kind create cluster \
     --verbosity="${KIND_LOG_LEVEL}" \
     --name "${KIND_CLUSTER_NAME}" \
-    --config "${DIR}"/kind.yaml \
+    --config "${KIND_CONFIG}" \
     --retain \
     --image "kindest/node:${K8S_VERSION}"
 
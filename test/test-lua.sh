@@ -36,6 +36,8 @@ SHDICT_ARGS=(
     "--shdict" "high_throughput_tracker 1M"
     "--shdict" "balancer_ewma_last_touched_at 1M"
     "--shdict" "balancer_ewma_locks 512k"
+    "--shdict" "upload_guard 1M"
+    "--shdict" "anomaly_scores 1M"
     "./rootfs/etc/nginx/lua/test/run.lua"
 )
 